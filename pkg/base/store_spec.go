@@ -228,6 +228,10 @@ type StoreSpec struct {
 	EncryptionOptions []byte
 	// ProvisionedRateSpec is optional.
 	ProvisionedRateSpec ProvisionedRateSpec
+	// RaftLogPath, if set, is the directory in which a separate storage engine
+	// holding the store's Raft log and unreplicated Raft state is located. When
+	// empty, the Raft log shares the engine with the state machine.
+	RaftLogPath string
 }
 
 // String returns a fully parsable version of the store spec.
@@ -274,6 +278,9 @@ func (ss StoreSpec) String() string {
 		fmt.Fprintf(&buffer, "provisioned-rate=bandwidth=%s/s,",
 			humanizeutil.IBytes(ss.ProvisionedRateSpec.ProvisionedBandwidth))
 	}
+	if len(ss.RaftLogPath) != 0 {
+		fmt.Fprintf(&buffer, "raft-log-path=%s,", ss.RaftLogPath)
+	}
 	// Trim the extra comma from the end if it exists.
 	if l := buffer.Len(); l > 0 {
 		buffer.Truncate(l - 1)
@@ -319,6 +326,9 @@ var fractionRegex = regexp.MustCompile(`^([-]?([0-9]+\.[0-9]*|[0-9]*\.[0-9]+|[0-
 //   - provisioned-rate=bandwidth=<bandwidth-bytes/s> The provisioned-rate can be
 //     used for admission control for operations on the store and if unspecified,
 //     a cluster setting (kvadmission.store.provisioned_bandwidth) will be used.
+//   - raft-log-path=xxx The optional directory in which a separate storage
+//     engine for the store's Raft log should be located. Not supported for in
+//     memory stores.
 //
 // Note that commas are forbidden within any field name or value.
 func NewStoreSpec(value string) (StoreSpec, error) {
@@ -448,6 +458,8 @@ func NewStoreSpec(value string) (StoreSpec, error) {
 				return StoreSpec{}, err
 			}
 			ss.ProvisionedRateSpec = rateSpec
+		case "raft-log-path":
+			ss.RaftLogPath = value
 
 		default:
 			return StoreSpec{}, fmt.Errorf("%s is not a valid store field", field)
@@ -464,8 +476,13 @@ func NewStoreSpec(value string) (StoreSpec, error) {
 		if ss.BallastSize != nil {
 			return StoreSpec{}, fmt.Errorf("ballast-size specified for in memory store")
 		}
+		if ss.RaftLogPath != "" {
+			return StoreSpec{}, fmt.Errorf("raft-log-path specified for in memory store")
+		}
 	} else if ss.Path == "" {
 		return StoreSpec{}, fmt.Errorf("no path specified")
+	} else if ss.RaftLogPath == ss.Path {
+		return StoreSpec{}, fmt.Errorf("raft-log-path must differ from the store path")
 	}
 	return ss, nil
 }
@@ -157,6 +157,12 @@ target_file_size=2097152`
 		{"path=/mnt/hda1,provisioned-rate=200MiB/s", "provisioned-rate field has invalid value 200MiB/s", StoreSpec{}},
 		{"path=/mnt/hda1,provisioned-rate=bandwidth=0B/s", "provisioned-rate field is trying to set bandwidth to 0", StoreSpec{}},
 
+		// raft log path
+		{"path=/mnt/hda1,raft-log-path=/mnt/hdb1", "", StoreSpec{Path: "/mnt/hda1", RaftLogPath: "/mnt/hdb1"}},
+		{"path=/mnt/hda1,raft-log-path=", "no value specified for raft-log-path", StoreSpec{}},
+		{"path=/mnt/hda1,raft-log-path=/mnt/hda1", "raft-log-path must differ from the store path", StoreSpec{}},
+		{"type=mem,size=20GiB,raft-log-path=/mnt/hdb1", "raft-log-path specified for in memory store", StoreSpec{}},
+
 		// RocksDB
 		{"path=/,rocksdb=key1=val1;key2=val2", "", StoreSpec{Path: "/", RocksDBOptions: "key1=val1;key2=val2"}},
 
@@ -12,14 +12,17 @@ import (
 	"context"
 	"net/url"
 	"path"
+	"sort"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backupdest"
 	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backupencryption"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
 	"github.com/cockroachdb/cockroach/pkg/featureflag"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/errors"
@@ -172,45 +175,105 @@ func doAlterBackupPlan(
 			break
 		}
 	}
-	if !oldKMSFound {
-		return errors.New("no key in OLD_KMS matches a key that was previously used to encrypt the backup")
+
+	encryptionInfo := &jobspb.EncryptionInfo{}
+	if oldKMSFound {
+		encryption := &jobspb.BackupEncryptionOptions{
+			Mode:    jobspb.EncryptionMode_KMS,
+			KMSInfo: defaultKMSInfo}
+
+		// Recover the encryption key using the old key, so we can encrypt it again with the new keys.
+		var plaintextDataKey []byte
+		plaintextDataKey, err = backupencryption.GetEncryptionKey(ctx, encryption, &kmsEnv)
+		if err != nil {
+			return err
+		}
+
+		encryptionInfo.EncryptedDataKeyByKMSMasterKeyID, err = encryptDataKeyWithNewKMS(
+			ctx, plaintextDataKey, newKms, &kmsEnv)
+		if err != nil {
+			return err
+		}
 	}
 
-	encryption := &jobspb.BackupEncryptionOptions{
-		Mode:    jobspb.EncryptionMode_KMS,
-		KMSInfo: defaultKMSInfo}
+	// The data keys of the databases that were backed up with their own key
+	// are rotated independently: each of them is encrypted again with the new
+	// keys if one of the old keys was used to encrypt it.
+	for _, dbID := range databasesEncryptedWithOwnKey(opts) {
+		var dbKMSInfo *jobspb.BackupEncryptionOptions_KMSInfo
+		for _, old := range oldKms {
+			if dbKMSInfo, err = backupencryption.GetDatabaseKMSInfo(ctx, opts, dbID, old, &kmsEnv); err == nil {
+				break
+			}
+		}
+		if dbKMSInfo == nil {
+			continue
+		}
+		dbDataKeys, err := backupencryption.GetDatabaseEncryptionKeys(ctx, &jobspb.BackupEncryptionOptions{
+			Mode:              jobspb.EncryptionMode_KMS,
+			KMSInfoByDatabase: map[descpb.ID]jobspb.BackupEncryptionOptions_KMSInfo{dbID: *dbKMSInfo},
+		}, &kmsEnv)
+		if err != nil {
+			return err
+		}
+		encryptedDataKeys, err := encryptDataKeyWithNewKMS(ctx, dbDataKeys[dbID], newKms, &kmsEnv)
+		if err != nil {
+			return err
+		}
+		if encryptionInfo.EncryptedDataKeysByDatabase == nil {
+			encryptionInfo.EncryptedDataKeysByDatabase = make(map[descpb.ID]jobspb.EncryptionInfo_EncryptedDataKeys)
+		}
+		encryptionInfo.EncryptedDataKeysByDatabase[dbID] = jobspb.EncryptionInfo_EncryptedDataKeys{
+			EncryptedDataKeyByKMSMasterKeyID: encryptedDataKeys,
+		}
+	}
 
-	// Recover the encryption key using the old key, so we can encrypt it again with the new keys.
-	var plaintextDataKey []byte
-	plaintextDataKey, err = backupencryption.GetEncryptionKey(ctx, encryption, &kmsEnv)
-	if err != nil {
-		return err
+	if !oldKMSFound && len(encryptionInfo.EncryptedDataKeysByDatabase) == 0 {
+		return errors.New("no key in OLD_KMS matches a key that was previously used to encrypt the backup")
 	}
 
+	// Write the new ENCRYPTION-INFO file.
+	return backupencryption.WriteNewEncryptionInfoToBackup(ctx, encryptionInfo, baseStore, len(opts))
+}
+
+// encryptDataKeyWithNewKMS encrypts the data key with each of the new KMS
+// keys, and returns the encrypted data keys in the form in which they are
+// stored in an ENCRYPTION-INFO file.
+func encryptDataKeyWithNewKMS(
+	ctx context.Context, plaintextDataKey []byte, newKms []string, kmsEnv cloud.KMSEnv,
+) (map[string][]byte, error) {
 	encryptedDataKeyByKMSMasterKeyID := backupencryption.NewEncryptedDataKeyMap()
 
 	// Add each new key user wants to add to a new data key map.
 	for _, kmsURI := range newKms {
 		masterKeyID, encryptedDataKey, err := backupencryption.GetEncryptedDataKeyFromURI(ctx,
-			plaintextDataKey, kmsURI, &kmsEnv)
+			plaintextDataKey, kmsURI, kmsEnv)
 		if err != nil {
-			return errors.Wrap(err, "failed to encrypt data key when adding new KMS")
+			return nil, errors.Wrap(err, "failed to encrypt data key when adding new KMS")
 		}
 
 		encryptedDataKeyByKMSMasterKeyID.AddEncryptedDataKey(backupencryption.PlaintextMasterKeyID(masterKeyID),
 			encryptedDataKey)
 	}
+	return encryptedDataKeyByKMSMasterKeyID.ProtoMap(), nil
+}
 
-	encryptedDataKeyMapForProto := make(map[string][]byte)
-	encryptedDataKeyByKMSMasterKeyID.RangeOverMap(
-		func(masterKeyID backupencryption.HashedMasterKeyID, dataKey []byte) {
-			encryptedDataKeyMapForProto[string(masterKeyID)] = dataKey
-		})
-
-	encryptionInfo := &jobspb.EncryptionInfo{EncryptedDataKeyByKMSMasterKeyID: encryptedDataKeyMapForProto}
-
-	// Write the new ENCRYPTION-INFO file.
-	return backupencryption.WriteNewEncryptionInfoToBackup(ctx, encryptionInfo, baseStore, len(opts))
+// databasesEncryptedWithOwnKey returns the IDs of the databases which were
+// backed up with their own key, as requested by the kms_per_database option,
+// in ascending order.
+func databasesEncryptedWithOwnKey(opts []jobspb.EncryptionInfo) []descpb.ID {
+	var dbIDs []descpb.ID
+	seen := make(map[descpb.ID]struct{})
+	for _, encFile := range opts {
+		for dbID := range encFile.EncryptedDataKeysByDatabase {
+			if _, ok := seen[dbID]; !ok {
+				seen[dbID] = struct{}{}
+				dbIDs = append(dbIDs, dbID)
+			}
+		}
+	}
+	sort.Slice(dbIDs, func(i, j int) bool { return dbIDs[i] < dbIDs[j] })
+	return dbIDs
 }
 
 func init() {
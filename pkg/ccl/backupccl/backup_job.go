@@ -203,6 +203,13 @@ func backup(
 	spans := filterSpans(backupManifest.Spans, completedSpans)
 	introducedSpans := filterSpans(backupManifest.IntroducedSpans, completedIntroducedSpans)
 
+	// The files of each database which was given its own KMS are encrypted
+	// with the key of that database.
+	encryptionKeyByTable, err := encryptionKeysByTable(ctx, encryption, &kmsEnv, iterFactory)
+	if err != nil {
+		return roachpb.RowCount{}, 0, err
+	}
+
 	pkIDs := make(map[uint64]bool)
 	for i := range backupManifest.Descriptors {
 		if t, _, _, _, _ := descpb.GetDescriptors(&backupManifest.Descriptors[i]); t != nil {
@@ -243,6 +250,7 @@ func backup(
 		urisByLocalityKV,
 		encryption,
 		&kmsEnv,
+		encryptionKeyByTable,
 		kvpb.MVCCFilter(backupManifest.MVCCFilter),
 		backupManifest.StartTime,
 		backupManifest.EndTime,
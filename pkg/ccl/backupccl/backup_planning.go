@@ -94,6 +94,11 @@ func resolveOptionsForBackupJobDescription(
 		return tree.BackupOptions{}, err
 	}
 
+	newOpts.EncryptionKMSURIPerDatabase, err = sanitizeKMSPerDatabaseList(opts.EncryptionKMSURIPerDatabase)
+	if err != nil {
+		return tree.BackupOptions{}, err
+	}
+
 	newOpts.IncrementalStorage, err = sanitizeURIList(incrementalStorage)
 	if err != nil {
 		return tree.BackupOptions{}, err
@@ -165,6 +170,82 @@ func sanitizeURIList(uris []string) ([]tree.Expr, error) {
 	return sanitizedURIs, nil
 }
 
+// sanitizeKMSPerDatabaseList sanitizes the KMS URIs in the entries of the
+// kms_per_database option in order to build an AST.
+func sanitizeKMSPerDatabaseList(entries tree.StringOrPlaceholderOptList) ([]tree.Expr, error) {
+	var sanitizedEntries []tree.Expr
+	for _, expr := range entries {
+		var entry string
+		switch e := expr.(type) {
+		case *tree.StrVal:
+			entry = e.RawString()
+		case *tree.DString:
+			entry = string(*e)
+		default:
+			// Placeholders do not contain any secret information.
+			sanitizedEntries = append(sanitizedEntries, expr)
+			continue
+		}
+		database, kmsURI, err := parseKMSPerDatabaseEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		sanitizedURI, err := cloud.SanitizeExternalStorageURI(kmsURI, nil /* extraParams */)
+		if err != nil {
+			return nil, err
+		}
+		sanitizedEntries = append(sanitizedEntries, tree.NewDString(database+"="+sanitizedURI))
+	}
+	return sanitizedEntries, nil
+}
+
+// parseKMSPerDatabaseEntry splits an entry of the kms_per_database option,
+// which has the form '<database>=<kms uri>', into the name of the database
+// and the URI of its KMS.
+func parseKMSPerDatabaseEntry(entry string) (database string, kmsURI string, _ error) {
+	database, kmsURI, ok := strings.Cut(entry, "=")
+	if !ok || database == "" || kmsURI == "" {
+		return "", "", errors.Newf(
+			"invalid kms_per_database entry %q, expected '<database>=<kms uri>'", entry)
+	}
+	return database, kmsURI, nil
+}
+
+// resolveKMSURIsByDatabase maps the ID of each database in the entries of the
+// kms_per_database option to the URI of its KMS. Each of the databases must be
+// included in the backup.
+func resolveKMSURIsByDatabase(
+	ctx context.Context, entries []string, targetDescs []catalog.Descriptor,
+) (map[descpb.ID]string, error) {
+	dbIDs := make(map[string]descpb.ID)
+	for _, desc := range targetDescs {
+		if db, ok := desc.(catalog.DatabaseDescriptor); ok {
+			dbIDs[db.GetName()] = db.GetID()
+		}
+	}
+	kmsURIByDatabase := make(map[descpb.ID]string, len(entries))
+	for _, entry := range entries {
+		database, kmsURI, err := parseKMSPerDatabaseEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		id, ok := dbIDs[database]
+		if !ok {
+			return nil, errors.Newf(
+				"database %q in the kms_per_database option is not included in the backup", database)
+		}
+		if _, ok := kmsURIByDatabase[id]; ok {
+			return nil, errors.Newf(
+				"database %q is specified multiple times in the kms_per_database option", database)
+		}
+		if err := logAndSanitizeKmsURIs(ctx, kmsURI); err != nil {
+			return nil, err
+		}
+		kmsURIByDatabase[id] = kmsURI
+	}
+	return kmsURIByDatabase, nil
+}
+
 func backupJobDescription(
 	p sql.PlanHookState,
 	backup *tree.Backup,
@@ -410,6 +491,7 @@ func backupTypeCheck(
 			backupStmt.IncrementalFrom,
 			tree.Exprs(backupStmt.Options.IncrementalStorage),
 			tree.Exprs(backupStmt.Options.EncryptionKMSURI),
+			tree.Exprs(backupStmt.Options.EncryptionKMSURIPerDatabase),
 		},
 		exprutil.Bools{
 			backupStmt.Options.CaptureRevisionHistory,
@@ -541,6 +623,22 @@ func backupPlanHook(
 		}
 	}
 
+	var kmsPerDatabase []string
+	if backupStmt.Options.EncryptionKMSURIPerDatabase != nil {
+		// The manifest and other metadata of the backup are encrypted using the
+		// KMS specified by the kms option.
+		if encryptionParams.Mode != jobspb.EncryptionMode_KMS {
+			return nil, nil, nil, false,
+				errors.New("the kms_per_database option requires the kms option to be set")
+		}
+		kmsPerDatabase, err = exprEval.StringArray(
+			ctx, tree.Exprs(backupStmt.Options.EncryptionKMSURIPerDatabase),
+		)
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+	}
+
 	var updatesClusterMonitoringMetrics bool
 	if backupStmt.Options.UpdatesClusterMonitoringMetrics != nil {
 		updatesClusterMonitoringMetrics, err = exprEval.Bool(
@@ -642,6 +740,13 @@ func backupPlanHook(
 			return err
 		}
 
+		if len(kmsPerDatabase) > 0 {
+			encryptionParams.RawKMSURIByDatabase, err = resolveKMSURIsByDatabase(ctx, kmsPerDatabase, targetDescs)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Check that a node will currently be able to run this before we create it.
 		if executionLocality.NonEmpty() {
 			if _, err := p.DistSQLPlanner().GetAllInstancesByLocality(ctx, executionLocality); err != nil {
@@ -36,6 +36,7 @@ func TestBackupResolveOptionsForJobDescription(t *testing.T) {
 		EncryptionPassphrase:            tree.NewDString("test expr"),
 		Detached:                        tree.DBoolTrue,
 		EncryptionKMSURI:                []tree.Expr{tree.NewDString("test expr")},
+		EncryptionKMSURIPerDatabase:     []tree.Expr{tree.NewDString("db=http://example.com")},
 		IncrementalStorage:              []tree.Expr{tree.NewDString("test expr")},
 		ExecutionLocality:               tree.NewDString("test expr"),
 		UpdatesClusterMonitoringMetrics: tree.NewDString("test expr"),
@@ -416,10 +416,11 @@ func runBackupProcessor(
 	}
 
 	sinkConf := sstSinkConf{
-		id:       flowCtx.NodeID.SQLInstanceID(),
-		enc:      spec.Encryption,
-		progCh:   progCh,
-		settings: &flowCtx.Cfg.Settings.SV,
+		id:              flowCtx.NodeID.SQLInstanceID(),
+		enc:             spec.Encryption,
+		encKeyByTableID: spec.EncryptionKeyByTableID,
+		progCh:          progCh,
+		settings:        &flowCtx.Cfg.Settings.SV,
 	}
 	storage, err := flowCtx.Cfg.ExternalStorage(ctx, dest)
 	if err != nil {
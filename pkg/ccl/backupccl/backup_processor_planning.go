@@ -12,6 +12,8 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backupencryption"
+	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backupinfo"
 	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backuppb"
 	"github.com/cockroachdb/cockroach/pkg/cloud"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -20,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -43,6 +46,7 @@ func distBackupPlanSpecs(
 	urisByLocalityKV map[string]string,
 	encryption *jobspb.BackupEncryptionOptions,
 	kmsEnv cloud.KMSEnv,
+	encryptionKeyByTable map[uint32][]byte,
 	mvccFilter kvpb.MVCCFilter,
 	startTime, endTime hlc.Timestamp,
 	elide execinfrapb.ElidePrefix,
@@ -105,6 +109,7 @@ func distBackupPlanSpecs(
 			URIsByLocalityKV:       urisByLocalityKV,
 			MVCCFilter:             mvccFilter,
 			Encryption:             fileEncryption,
+			EncryptionKeyByTableID: encryptionKeyByTable,
 			PKIDs:                  pkIDs,
 			BackupStartTime:        startTime,
 			BackupEndTime:          endTime,
@@ -129,6 +134,7 @@ func distBackupPlanSpecs(
 				URIsByLocalityKV:       urisByLocalityKV,
 				MVCCFilter:             mvccFilter,
 				Encryption:             fileEncryption,
+				EncryptionKeyByTableID: encryptionKeyByTable,
 				PKIDs:                  pkIDs,
 				BackupStartTime:        startTime,
 				BackupEndTime:          endTime,
@@ -231,3 +237,41 @@ func distBackup(
 	dsp.Run(ctx, planCtx, noTxn, p, recv, &evalCtxCopy, nil /* finishedSetupFn */)
 	return rowResultWriter.Err()
 }
+
+// encryptionKeysByTable returns, for each table whose database is encrypted
+// with its own key, as requested by the kms_per_database option of BACKUP, the
+// decrypted key of that database. The tables are read from the descriptors of
+// the given backup layers.
+func encryptionKeysByTable(
+	ctx context.Context,
+	encryption *jobspb.BackupEncryptionOptions,
+	kmsEnv cloud.KMSEnv,
+	iterFactories ...*backupinfo.IterFactory,
+) (map[uint32][]byte, error) {
+	dbKeys, err := backupencryption.GetDatabaseEncryptionKeys(ctx, encryption, kmsEnv)
+	if err != nil || len(dbKeys) == 0 {
+		return nil, err
+	}
+	keyByTable := make(map[uint32][]byte)
+	for _, f := range iterFactories {
+		if err := func() error {
+			descs := f.NewDescIter(ctx)
+			defer descs.Close()
+			for ; ; descs.Next() {
+				if ok, err := descs.Valid(); err != nil {
+					return err
+				} else if !ok {
+					return nil
+				}
+				if tbl, _, _, _, _ := descpb.GetDescriptors(descs.Value()); tbl != nil {
+					if key, ok := dbKeys[tbl.ParentID]; ok {
+						keyByTable[uint32(tbl.ID)] = key
+					}
+				}
+			}
+		}(); err != nil {
+			return nil, err
+		}
+	}
+	return keyByTable, nil
+}
@@ -4724,6 +4724,60 @@ func TestGetEncryptedDataKeyByKMSMasterKeyID(t *testing.T) {
 	}
 }
 
+// TestBackupKMSPerDatabase tests that the data of a database backed up with
+// its own KMS key can only be restored using that key, and that the key can be
+// rotated using ALTER BACKUP.
+func TestBackupKMSPerDatabase(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 10
+	_, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+
+	uris := constructMockKMSURIsWithKeyID([]string{"main", "data", "rotated"})
+	mainURI, dataURI, rotatedURI := uris[0], uris[1], uris[2]
+
+	sqlDB.Exec(t, `CREATE DATABASE data2`)
+	sqlDB.Exec(t, `CREATE TABLE data2.t (k INT PRIMARY KEY, v STRING)`)
+	sqlDB.Exec(t, `INSERT INTO data2.t VALUES (1, 'a'), (2, 'b')`)
+
+	sqlDB.ExpectErr(t, `the kms_per_database option requires the kms option to be set`,
+		`BACKUP DATABASE data INTO $1 WITH kms_per_database = $2`, localFoo, "data="+dataURI)
+	sqlDB.ExpectErr(t, `database "data3" in the kms_per_database option is not included in the backup`,
+		`BACKUP DATABASE data INTO $1 WITH kms = $2, kms_per_database = $3`,
+		localFoo, mainURI, "data3="+dataURI)
+
+	backupStmt := `BACKUP DATABASE data, data2 INTO $1 WITH kms = $2, kms_per_database = $3`
+	sqlDB.Exec(t, backupStmt, localFoo, mainURI, "data="+dataURI)
+	sqlDB.Exec(t, `UPDATE data.bank SET balance = balance + 1`)
+	sqlDB.ExpectErr(t, `its KMS must be specified using the kms_per_database option`,
+		`BACKUP DATABASE data, data2 INTO LATEST IN $1 WITH kms = $2`, localFoo, mainURI)
+	sqlDB.Exec(t, `BACKUP DATABASE data, data2 INTO LATEST IN $1 WITH kms = $2, kms_per_database = $3`,
+		localFoo, mainURI, "data="+dataURI)
+
+	bank := sqlDB.QueryStr(t, `SELECT * FROM data.bank ORDER BY id`)
+	sqlDB.Exec(t, `DROP DATABASE data CASCADE`)
+	sqlDB.Exec(t, `DROP DATABASE data2 CASCADE`)
+
+	// The key of data is not needed to restore data2.
+	sqlDB.Exec(t, `RESTORE DATABASE data2 FROM LATEST IN $1 WITH kms = $2`, localFoo, mainURI)
+	sqlDB.CheckQueryResults(t, `SELECT * FROM data2.t ORDER BY k`, [][]string{{"1", "a"}, {"2", "b"}})
+
+	sqlDB.ExpectErr(t, `database "data" was backed up with its own key`,
+		`RESTORE DATABASE data FROM LATEST IN $1 WITH kms = $2`, localFoo, mainURI)
+	sqlDB.ExpectErr(t, `the KMS provided for database \d+ was not used to encrypt its data`,
+		`RESTORE DATABASE data FROM LATEST IN $1 WITH kms = $2, kms_per_database = $3`,
+		localFoo, mainURI, "data="+rotatedURI)
+
+	// Rotate the key of data, which does not require the main key.
+	sqlDB.Exec(t, `ALTER BACKUP LATEST IN $1 ADD NEW_KMS = $2 WITH OLD_KMS = $3`,
+		localFoo, rotatedURI, dataURI)
+	sqlDB.Exec(t, `RESTORE DATABASE data FROM LATEST IN $1 WITH kms = $2, kms_per_database = $3`,
+		localFoo, mainURI, "data="+rotatedURI)
+	sqlDB.CheckQueryResults(t, `SELECT * FROM data.bank ORDER BY id`, bank)
+}
+
 func TestRestoredPrivileges(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
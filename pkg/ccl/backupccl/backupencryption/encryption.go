@@ -22,8 +22,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
 	"github.com/cockroachdb/cockroach/pkg/cloud"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
@@ -157,6 +161,16 @@ func (e *EncryptedDataKeyMap) RangeOverMap(fn func(masterKeyID HashedMasterKeyID
 	}
 }
 
+// ProtoMap returns the map in the form in which it is stored in an
+// EncryptionInfo.
+func (e *EncryptedDataKeyMap) ProtoMap() map[string][]byte {
+	m := make(map[string][]byte, len(e.m))
+	for k, v := range e.m {
+		m[string(k)] = v
+	}
+	return m
+}
+
 // ValidateKMSURIsAgainstFullBackup ensures that the KMS URIs provided to an
 // incremental BACKUP are a subset of those used during the full BACKUP. It does
 // this by ensuring that the KMS master key ID of each KMS URI specified during
@@ -227,10 +241,9 @@ func MakeNewEncryptionOptions(
 	case jobspb.EncryptionMode_KMS:
 		// Generate a 32 byte/256-bit crypto-random number which will serve as
 		// the data key for encrypting the BACKUP data and manifest files.
-		plaintextDataKey := make([]byte, 32)
-		_, err := cryptorand.Read(plaintextDataKey)
+		plaintextDataKey, err := generateDataKey()
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "failed to generate DataKey")
+			return nil, nil, err
 		}
 
 		encryptedDataKeyByKMSMasterKeyID, defaultKMSInfo, err :=
@@ -250,10 +263,42 @@ func MakeNewEncryptionOptions(
 			Mode:    jobspb.EncryptionMode_KMS,
 			KMSInfo: defaultKMSInfo,
 		}
+
+		// The data of each database given its own KMS is encrypted with a
+		// separate data key, which only that KMS can decrypt.
+		for dbID, kmsURI := range encryptionParams.RawKMSURIByDatabase {
+			dbDataKey, err := generateDataKey()
+			if err != nil {
+				return nil, nil, err
+			}
+			dbDataKeyByKMSMasterKeyID, dbKMSInfo, err :=
+				GetEncryptedDataKeyByKMSMasterKeyID(ctx, []string{kmsURI}, dbDataKey, kmsEnv)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "encrypting the data key of database %d", dbID)
+			}
+			if encryptionOptions.KMSInfoByDatabase == nil {
+				encryptionOptions.KMSInfoByDatabase = make(map[descpb.ID]jobspb.BackupEncryptionOptions_KMSInfo)
+				encryptionInfo.EncryptedDataKeysByDatabase = make(map[descpb.ID]jobspb.EncryptionInfo_EncryptedDataKeys)
+			}
+			encryptionOptions.KMSInfoByDatabase[dbID] = *dbKMSInfo
+			encryptionInfo.EncryptedDataKeysByDatabase[dbID] = jobspb.EncryptionInfo_EncryptedDataKeys{
+				EncryptedDataKeyByKMSMasterKeyID: dbDataKeyByKMSMasterKeyID.ProtoMap(),
+			}
+		}
 	}
 	return encryptionOptions, encryptionInfo, nil
 }
 
+// generateDataKey generates a 32 byte/256-bit crypto-random number which will
+// serve as a data key.
+func generateDataKey() ([]byte, error) {
+	plaintextDataKey := make([]byte, 32)
+	if _, err := cryptorand.Read(plaintextDataKey); err != nil {
+		return nil, errors.Wrap(err, "failed to generate DataKey")
+	}
+	return plaintextDataKey, nil
+}
+
 // GetEncryptedDataKeyFromURI returns the encrypted data key from the KMS
 // specified by kmsURI.
 func GetEncryptedDataKeyFromURI(
@@ -377,11 +422,63 @@ func GetEncryptionFromBase(
 			encryptionOptions = &jobspb.BackupEncryptionOptions{
 				Mode:    jobspb.EncryptionMode_KMS,
 				KMSInfo: defaultKMSInfo}
+
+			// Incremental backups must encrypt the data of each database with
+			// the same key as the base backup.
+			for _, encFile := range opts {
+				for dbID := range encFile.EncryptedDataKeysByDatabase {
+					if _, ok := encryptionParams.RawKMSURIByDatabase[dbID]; !ok {
+						return nil, errors.Newf("database %d was encrypted with its own key in the base "+
+							"BACKUP, so its KMS must be specified using the kms_per_database option", dbID)
+					}
+				}
+			}
+			for dbID, kmsURI := range encryptionParams.RawKMSURIByDatabase {
+				kmsInfo, err := GetDatabaseKMSInfo(ctx, opts, dbID, kmsURI, kmsEnv)
+				if err != nil {
+					return nil, err
+				}
+				if encryptionOptions.KMSInfoByDatabase == nil {
+					encryptionOptions.KMSInfoByDatabase = make(map[descpb.ID]jobspb.BackupEncryptionOptions_KMSInfo)
+				}
+				encryptionOptions.KMSInfoByDatabase[dbID] = *kmsInfo
+			}
 		}
 	}
 	return encryptionOptions, nil
 }
 
+// GetDatabaseKMSInfo returns the KMSInfo to use to decrypt the data key of the
+// given database, which was encrypted with its own key as requested by the
+// kms_per_database option of BACKUP. An error is returned if kmsURI was not
+// used to encrypt the data key of the database in any of the given
+// ENCRYPTION-INFO files.
+func GetDatabaseKMSInfo(
+	ctx context.Context,
+	opts []jobspb.EncryptionInfo,
+	dbID descpb.ID,
+	kmsURI string,
+	kmsEnv cloud.KMSEnv,
+) (*jobspb.BackupEncryptionOptions_KMSInfo, error) {
+	found := false
+	for _, encFile := range opts {
+		dataKeys, ok := encFile.EncryptedDataKeysByDatabase[dbID]
+		if !ok {
+			continue
+		}
+		found = true
+		kmsInfo, err := ValidateKMSURIsAgainstFullBackup(ctx, []string{kmsURI},
+			NewEncryptedDataKeyMapFromProtoMap(dataKeys.EncryptedDataKeyByKMSMasterKeyID), kmsEnv)
+		if err == nil {
+			return kmsInfo, nil
+		}
+	}
+	if !found {
+		return nil, errors.Newf("database %d was not encrypted with its own key in the base BACKUP", dbID)
+	}
+	return nil, errors.Newf("the KMS provided for database %d was not used to encrypt its data in the base BACKUP", dbID)
+}
+
 // GetEncryptionKey returns the decrypted plaintext data key to be used for
 // encryption.
 func GetEncryptionKey(
@@ -397,24 +494,75 @@ func GetEncryptionKey(
 		// Contact the selected KMS to derive the decrypted data key.
 		// TODO(pbardea): Add a check here if encryption.KMSInfo is unexpectedly nil
 		// here to avoid a panic, and return an error instead.
-		kms, err := cloud.KMSFromURI(ctx, encryption.KMSInfo.Uri, kmsEnv)
-		if err != nil {
-			return nil, err
-		}
+		return decryptDataKey(ctx, *encryption.KMSInfo, kmsEnv)
+	}
 
-		defer func() {
-			_ = kms.Close()
-		}()
+	return nil, errors.New("invalid encryption mode")
+}
+
+// decryptDataKey contacts the KMS in kmsInfo to decrypt its data key.
+func decryptDataKey(
+	ctx context.Context, kmsInfo jobspb.BackupEncryptionOptions_KMSInfo, kmsEnv cloud.KMSEnv,
+) ([]byte, error) {
+	kms, err := cloud.KMSFromURI(ctx, kmsInfo.Uri, kmsEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = kms.Close()
+	}()
 
-		plaintextDataKey, err := kms.Decrypt(ctx, encryption.KMSInfo.EncryptedDataKey)
+	plaintextDataKey, err := kms.Decrypt(ctx, kmsInfo.EncryptedDataKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt data key")
+	}
+
+	return plaintextDataKey, nil
+}
+
+// GetDatabaseEncryptionKeys returns the decrypted data keys of the databases
+// which are encrypted with their own key, keyed by database ID.
+func GetDatabaseEncryptionKeys(
+	ctx context.Context, encryption *jobspb.BackupEncryptionOptions, kmsEnv cloud.KMSEnv,
+) (map[descpb.ID][]byte, error) {
+	if encryption == nil || len(encryption.KMSInfoByDatabase) == 0 {
+		return nil, nil
+	}
+	dataKeys := make(map[descpb.ID][]byte, len(encryption.KMSInfoByDatabase))
+	for dbID, kmsInfo := range encryption.KMSInfoByDatabase {
+		plaintextDataKey, err := decryptDataKey(ctx, kmsInfo, kmsEnv)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to decrypt data key")
+			return nil, errors.Wrapf(err, "decrypting the data key of database %d", dbID)
 		}
-
-		return plaintextDataKey, nil
+		dataKeys[dbID] = plaintextDataKey
 	}
+	return dataKeys, nil
+}
 
-	return nil, errors.New("invalid encryption mode")
+// FileEncryptionForKey returns the encryption options of a backup data file
+// whose span starts at key. Files of tables in keyByTableID are encrypted with
+// the key of their database, and all other files with defaultEncryption.
+func FileEncryptionForKey(
+	key roachpb.Key, defaultEncryption *kvpb.FileEncryptionOptions, keyByTableID map[uint32][]byte,
+) *kvpb.FileEncryptionOptions {
+	if len(keyByTableID) == 0 {
+		return defaultEncryption
+	}
+	// Backups of tenants contain the keys of the tenant, so the tenant prefix
+	// is stripped before decoding the table ID.
+	rem, _, err := keys.DecodeTenantPrefix(key)
+	if err != nil {
+		return defaultEncryption
+	}
+	_, tableID, err := keys.SystemSQLCodec.DecodeTablePrefix(rem)
+	if err != nil {
+		return defaultEncryption
+	}
+	if dataKey, ok := keyByTableID[tableID]; ok {
+		return &kvpb.FileEncryptionOptions{Key: dataKey}
+	}
+	return defaultEncryption
 }
 
 // ReadEncryptionOptions takes in a backup location and tries to find
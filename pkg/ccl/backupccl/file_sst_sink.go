@@ -15,6 +15,7 @@ import (
 	io "io"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backupencryption"
 	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backuppb"
 	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
 	"github.com/cockroachdb/cockroach/pkg/cloud"
@@ -34,10 +35,13 @@ import (
 )
 
 type sstSinkConf struct {
-	progCh   chan execinfrapb.RemoteProducerMetadata_BulkProcessorProgress
-	enc      *kvpb.FileEncryptionOptions
-	id       base.SQLInstanceID
-	settings *settings.Values
+	progCh chan execinfrapb.RemoteProducerMetadata_BulkProcessorProgress
+	enc    *kvpb.FileEncryptionOptions
+	// encKeyByTableID contains the keys of the tables whose files are not
+	// encrypted using enc, as requested by the kms_per_database option.
+	encKeyByTableID map[uint32][]byte
+	id              base.SQLInstanceID
+	settings        *settings.Values
 }
 
 type fileSSTSink struct {
@@ -50,6 +54,8 @@ type fileSSTSink struct {
 	cancel  func()
 	out     io.WriteCloser
 	outName string
+	// outEnc is the encryption of the file being written.
+	outEnc *kvpb.FileEncryptionOptions
 
 	flushedFiles []backuppb.BackupManifest_File
 	flushedSize  int64
@@ -189,8 +195,8 @@ func (s *fileSSTSink) open(ctx context.Context) error {
 		return err
 	}
 	s.out = w
-	if s.conf.enc != nil {
-		e, err := storageccl.EncryptingWriter(w, s.conf.enc.Key)
+	if s.outEnc != nil {
+		e, err := storageccl.EncryptingWriter(w, s.outEnc.Key)
 		if err != nil {
 			return err
 		}
@@ -221,6 +227,7 @@ func (s *fileSSTSink) write(ctx context.Context, resp exportedSpan) error {
 	if err != nil {
 		return err
 	}
+	enc := backupencryption.FileEncryptionForKey(span.Key, s.conf.enc, s.conf.encKeyByTableID)
 
 	// If this span starts before the last buffered span ended, we need to flush
 	// since it overlaps but SSTWriter demands writes in-order.
@@ -234,11 +241,21 @@ func (s *fileSSTSink) write(ctx context.Context, resp exportedSpan) error {
 			if err := s.flushFile(ctx); err != nil {
 				return err
 			}
+		} else if !bytes.Equal(enc.GetKey(), s.outEnc.GetKey()) {
+			// Each file is encrypted with a single key, so the data of databases
+			// with their own key are written to separate files.
+			log.VEventf(ctx, 1, "flushing backup file %s of size %d because span %s uses a different key",
+				s.outName, s.flushedSize, span,
+			)
+			if err := s.flushFile(ctx); err != nil {
+				return err
+			}
 		}
 	}
 
 	// Initialize the writer if needed.
 	if s.out == nil {
+		s.outEnc = enc
 		if err := s.open(ctx); err != nil {
 			return err
 		}
@@ -15,6 +15,7 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backupencryption"
 	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backuppb"
 	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backuputils"
 	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
@@ -359,7 +360,15 @@ func (rd *restoreDataProcessor) openSSTs(
 			return mergedSST{}, nil, err
 		}
 		dirs = append(dirs, dir)
-		storeFiles = append(storeFiles, storageccl.StoreFile{Store: dir, FilePath: file.Path})
+		storeFiles = append(storeFiles, storageccl.StoreFile{
+			Store:    dir,
+			FilePath: file.Path,
+			// Files of databases backed up with their own key are decrypted
+			// using that key.
+			Encryption: backupencryption.FileEncryptionForKey(
+				file.BackupFileEntrySpan.Key, rd.spec.Encryption, rd.spec.EncryptionKeyByTableID,
+			),
+		})
 	}
 
 	iterOpts := storage.IterOptions{
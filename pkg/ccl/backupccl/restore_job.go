@@ -351,6 +351,14 @@ func restore(
 	if err != nil {
 		return roachpb.RowCount{}, err
 	}
+	iterFactories := make([]*backupinfo.IterFactory, 0, len(layerToIterFactory))
+	for _, f := range layerToIterFactory {
+		iterFactories = append(iterFactories, f)
+	}
+	encryptionKeyByTable, err := encryptionKeysByTable(restoreCtx, encryption, kmsEnv, iterFactories...)
+	if err != nil {
+		return roachpb.RowCount{}, err
+	}
 
 	// If any layer of the backup was produced with revision history before 24.1,
 	// we need to assume inclusive end-keys. If no layers used revision history or
@@ -492,17 +500,18 @@ func restore(
 			return errors.Wrap(err, "sending remote AddSSTable requests")
 		}
 		md := restoreJobMetadata{
-			jobID:              job.ID(),
-			dataToRestore:      dataToRestore,
-			restoreTime:        endTime,
-			encryption:         encryption,
-			kmsEnv:             kmsEnv,
-			uris:               details.URIs,
-			backupLocalityInfo: backupLocalityInfo,
-			spanFilter:         filter,
-			numImportSpans:     numImportSpans,
-			execLocality:       details.ExecutionLocality,
-			exclusiveEndKeys:   fsc.isExclusive(),
+			jobID:                job.ID(),
+			dataToRestore:        dataToRestore,
+			restoreTime:          endTime,
+			encryption:           encryption,
+			encryptionKeyByTable: encryptionKeyByTable,
+			kmsEnv:               kmsEnv,
+			uris:                 details.URIs,
+			backupLocalityInfo:   backupLocalityInfo,
+			spanFilter:           filter,
+			numImportSpans:       numImportSpans,
+			execLocality:         details.ExecutionLocality,
+			exclusiveEndKeys:     fsc.isExclusive(),
 		}
 		return errors.Wrap(distRestore(
 			ctx,
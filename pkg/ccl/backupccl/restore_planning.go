@@ -1111,6 +1111,14 @@ func resolveOptionsForRestoreJobDescription(
 		logSanitizedKmsURI(ctx, redactedURI)
 	}
 
+	if opts.DecryptionKMSURIPerDatabase != nil {
+		var err error
+		newOpts.DecryptionKMSURIPerDatabase, err = sanitizeKMSPerDatabaseList(opts.DecryptionKMSURIPerDatabase)
+		if err != nil {
+			return tree.RestoreOptions{}, err
+		}
+	}
+
 	if opts.IncrementalStorage != nil {
 		var err error
 		newOpts.IncrementalStorage, err = sanitizeURIList(incFrom)
@@ -1178,6 +1186,7 @@ func restoreTypeCheck(
 		append(
 			exprutil.MakeStringArraysFromOptList(restoreStmt.From),
 			tree.Exprs(restoreStmt.Options.DecryptionKMSURI),
+			tree.Exprs(restoreStmt.Options.DecryptionKMSURIPerDatabase),
 			tree.Exprs(restoreStmt.Options.IncrementalStorage),
 		),
 		exprutil.Strings{
@@ -1203,6 +1212,94 @@ func restoreTypeCheck(
 	return true, header, nil
 }
 
+// resolveKMSInfoByDatabaseForRestore returns the KMSInfo used to decrypt the
+// data key of each database that was backed up with its own key, as requested
+// by the kms_per_database option of BACKUP, and which contains a restored
+// table. The KMS of these databases must be specified using the
+// kms_per_database option of RESTORE, while the KMS of the other databases is
+// not needed.
+func resolveKMSInfoByDatabaseForRestore(
+	ctx context.Context,
+	encryptionInfos []jobspb.EncryptionInfo,
+	kmsPerDatabase []string,
+	tablesByID map[descpb.ID]*tabledesc.Mutable,
+	layerToIterFactory backupinfo.LayerToBackupManifestFileIterFactory,
+	kmsEnv cloud.KMSEnv,
+) (map[descpb.ID]jobspb.BackupEncryptionOptions_KMSInfo, error) {
+	kmsURIByDatabase := make(map[string]string, len(kmsPerDatabase))
+	for _, entry := range kmsPerDatabase {
+		database, kmsURI, err := parseKMSPerDatabaseEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := kmsURIByDatabase[database]; ok {
+			return nil, errors.Newf(
+				"database %q is specified multiple times in the kms_per_database option", database)
+		}
+		kmsURIByDatabase[database] = kmsURI
+	}
+
+	encryptedDatabases := make(map[descpb.ID]struct{})
+	for _, encFile := range encryptionInfos {
+		for dbID := range encFile.EncryptedDataKeysByDatabase {
+			encryptedDatabases[dbID] = struct{}{}
+		}
+	}
+	if len(encryptedDatabases) == 0 {
+		if len(kmsURIByDatabase) > 0 {
+			return nil, errors.New("kms_per_database was specified, but no database was backed up with its own key")
+		}
+		return nil, nil
+	}
+
+	// The names of the databases are read from the backup, since the database
+	// of a restored table is not necessarily restored.
+	databaseNames := make(map[descpb.ID]string)
+	for layer := 0; layer < len(layerToIterFactory); layer++ {
+		if err := func() error {
+			descs := layerToIterFactory[layer].NewDescIter(ctx)
+			defer descs.Close()
+			for ; ; descs.Next() {
+				if ok, err := descs.Valid(); err != nil {
+					return err
+				} else if !ok {
+					return nil
+				}
+				if _, db, _, _, _ := descpb.GetDescriptors(descs.Value()); db != nil {
+					databaseNames[db.ID] = db.Name
+				}
+			}
+		}(); err != nil {
+			return nil, err
+		}
+	}
+
+	var kmsInfoByDatabase map[descpb.ID]jobspb.BackupEncryptionOptions_KMSInfo
+	for _, tbl := range tablesByID {
+		dbID := tbl.GetParentID()
+		if _, ok := encryptedDatabases[dbID]; !ok {
+			continue
+		}
+		if _, ok := kmsInfoByDatabase[dbID]; ok {
+			continue
+		}
+		kmsURI, ok := kmsURIByDatabase[databaseNames[dbID]]
+		if !ok {
+			return nil, errors.Newf("database %q was backed up with its own key, so its KMS "+
+				"must be specified using the kms_per_database option", databaseNames[dbID])
+		}
+		kmsInfo, err := backupencryption.GetDatabaseKMSInfo(ctx, encryptionInfos, dbID, kmsURI, kmsEnv)
+		if err != nil {
+			return nil, err
+		}
+		if kmsInfoByDatabase == nil {
+			kmsInfoByDatabase = make(map[descpb.ID]jobspb.BackupEncryptionOptions_KMSInfo)
+		}
+		kmsInfoByDatabase[dbID] = *kmsInfo
+	}
+	return kmsInfoByDatabase, nil
+}
+
 func logSanitizedKmsURI(ctx context.Context, kmsDestination string) {
 	log.Ops.Infof(ctx, "restore planning to connect to KMS destination %v", redact.Safe(kmsDestination))
 }
@@ -1273,6 +1370,20 @@ func restorePlanHook(
 		}
 	}
 
+	var kmsPerDatabase []string
+	if restoreStmt.Options.DecryptionKMSURIPerDatabase != nil {
+		if restoreStmt.Options.DecryptionKMSURI == nil {
+			return nil, nil, nil, false, errors.New("the kms_per_database option requires the kms option to be set")
+		}
+		var err error
+		kmsPerDatabase, err = exprEval.StringArray(
+			ctx, tree.Exprs(restoreStmt.Options.DecryptionKMSURIPerDatabase),
+		)
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+	}
+
 	var intoDB string
 	if restoreStmt.Options.IntoDB != nil {
 		if restoreStmt.DescriptorCoverage == tree.SystemUsers {
@@ -1438,7 +1549,7 @@ func restorePlanHook(
 		// locality aware.
 
 		return doRestorePlan(
-			ctx, restoreStmt, &exprEval, p, from, incStorage, pw, kms, intoDB,
+			ctx, restoreStmt, &exprEval, p, from, incStorage, pw, kms, kmsPerDatabase, intoDB,
 			newDBName, newTenantID, newTenantName, endTime, resultsCh, subdir, execLocality,
 		)
 	}
@@ -1683,6 +1794,7 @@ func doRestorePlan(
 	incFrom []string,
 	passphrase string,
 	kms []string,
+	kmsPerDatabase []string,
 	intoDB string,
 	newDBName string,
 	newTenantID *roachpb.TenantID,
@@ -1778,6 +1890,7 @@ func doRestorePlan(
 	)
 
 	var encryption *jobspb.BackupEncryptionOptions
+	var encryptionInfos []jobspb.EncryptionInfo
 	if restoreStmt.Options.EncryptionPassphrase != nil {
 		opts, err := backupencryption.ReadEncryptionOptions(ctx, baseStores[0])
 		if err != nil {
@@ -1793,6 +1906,7 @@ func doRestorePlan(
 		if err != nil {
 			return err
 		}
+		encryptionInfos = opts
 
 		// A backup could have been encrypted with multiple KMS keys that
 		// are stored across ENCRYPTION-INFO files. Iterate over all
@@ -1985,6 +2099,15 @@ func doRestorePlan(
 		}
 	}
 
+	if encryption != nil && encryption.Mode == jobspb.EncryptionMode_KMS {
+		encryption.KMSInfoByDatabase, err = resolveKMSInfoByDatabaseForRestore(
+			ctx, encryptionInfos, kmsPerDatabase, tablesByID, layerToIterFactory, &kmsEnv,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	if restoreStmt.Options.RemoveRegions {
 		for _, t := range tablesByID {
 			if t.LocalityConfig.GetRegionalByRow() != nil {
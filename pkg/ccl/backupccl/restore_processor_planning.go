@@ -34,17 +34,20 @@ import (
 )
 
 type restoreJobMetadata struct {
-	jobID              jobspb.JobID
-	dataToRestore      restorationData
-	restoreTime        hlc.Timestamp
-	encryption         *jobspb.BackupEncryptionOptions
-	kmsEnv             cloud.KMSEnv
-	uris               []string
-	backupLocalityInfo []jobspb.RestoreDetails_BackupLocalityInfo
-	spanFilter         spanCoveringFilter
-	numImportSpans     int
-	execLocality       roachpb.Locality
-	exclusiveEndKeys   bool
+	jobID         jobspb.JobID
+	dataToRestore restorationData
+	restoreTime   hlc.Timestamp
+	encryption    *jobspb.BackupEncryptionOptions
+	// encryptionKeyByTable contains the keys of the tables of databases that
+	// were backed up with their own key.
+	encryptionKeyByTable map[uint32][]byte
+	kmsEnv               cloud.KMSEnv
+	uris                 []string
+	backupLocalityInfo   []jobspb.RestoreDetails_BackupLocalityInfo
+	spanFilter           spanCoveringFilter
+	numImportSpans       int
+	execLocality         roachpb.Locality
+	exclusiveEndKeys     bool
 }
 
 // distRestore plans a 2 stage distSQL flow for a distributed restore. It
@@ -106,13 +109,14 @@ func distRestore(
 		p := planCtx.NewPhysicalPlan()
 
 		restoreDataSpec := execinfrapb.RestoreDataSpec{
-			JobID:        int64(md.jobID),
-			RestoreTime:  md.restoreTime,
-			Encryption:   fileEncryption,
-			TableRekeys:  md.dataToRestore.getRekeys(),
-			TenantRekeys: md.dataToRestore.getTenantRekeys(),
-			PKIDs:        md.dataToRestore.getPKIDs(),
-			ValidateOnly: md.dataToRestore.isValidateOnly(),
+			JobID:                  int64(md.jobID),
+			RestoreTime:            md.restoreTime,
+			Encryption:             fileEncryption,
+			EncryptionKeyByTableID: md.encryptionKeyByTable,
+			TableRekeys:            md.dataToRestore.getRekeys(),
+			TenantRekeys:           md.dataToRestore.getTenantRekeys(),
+			PKIDs:                  md.dataToRestore.getPKIDs(),
+			ValidateOnly:           md.dataToRestore.isValidateOnly(),
 		}
 
 		// Plan SplitAndScatter in a round-robin fashion.
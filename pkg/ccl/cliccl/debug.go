@@ -105,6 +105,23 @@ with their env type and encryption settings (if applicable).
 		RunE: clierrorplus.MaybeDecorateError(runList),
 	}
 
+	encryptionRotateCmd := &cobra.Command{
+		Use:   "encryption-rotate <directory>",
+		Short: "re-encrypt files of a store still using old data keys",
+		Long: `
+Rewrites all sstables of the store located in 'directory' that are encrypted
+with a data key other than the active one, so that they are encrypted with the
+active data key. Encryption keys must be specified in the
+'--enterprise-encryption' flag; to rotate the store key, pass the new key as
+the store key and the previous one as the old key.
+
+The store must not be in use by a running node. Prints the number of files
+encrypted with each data key before and after the rewrite.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: clierrorplus.MaybeDecorateError(runEncryptionRotate),
+	}
+
 	checkFipsCmd := &cobra.Command{
 		Use:   "enterprise-check-fips",
 		Short: "print diagnostics for FIPS-ready configuration",
@@ -122,6 +139,7 @@ mode in the current environment.
 	cli.DebugCmd.AddCommand(encryptionActiveKeyCmd)
 	cli.DebugCmd.AddCommand(encryptionDecryptCmd)
 	cli.DebugCmd.AddCommand(encryptionRegistryList)
+	cli.DebugCmd.AddCommand(encryptionRotateCmd)
 	cli.DebugCmd.AddCommand(checkFipsCmd)
 
 	// Add the encryption flag to commands that need it.
@@ -137,6 +155,9 @@ mode in the current environment.
 	// For the encryption-registry-list command.
 	f = encryptionRegistryList.Flags()
 	cliflagcfg.VarFlag(f, &encryptionSpecs, cliflagsccl.EnterpriseEncryption)
+	// For the encryption-rotate command.
+	f = encryptionRotateCmd.Flags()
+	cliflagcfg.VarFlag(f, &encryptionSpecs, cliflagsccl.EnterpriseEncryption)
 
 	// Add encryption flag to all OSS debug commands that want it.
 	for _, cmd := range cli.DebugCommandsRequiringEncryption {
@@ -388,6 +409,56 @@ func getActiveEncryptionkey(dir string) (string, string, error) {
 	return setting.EncryptionType.String(), setting.KeyId, nil
 }
 
+func runEncryptionRotate(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+
+	db, err := cli.OpenEngine(args[0], stopper, fs.ReadWrite, storage.MustExist)
+	if err != nil {
+		return err
+	}
+
+	printKeys := func(header string) error {
+		stats, err := db.GetEnvStats()
+		if err != nil {
+			return err
+		}
+		fmt.Println(header)
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetBorder(false)
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		table.SetHeader([]string{"Key ID", "Type", "Active", "Files", "Bytes"})
+		for _, k := range stats.Keys {
+			keyType := "data"
+			if k.StoreKey {
+				keyType = "store"
+			}
+			table.Append([]string{
+				k.KeyID, keyType, fmt.Sprint(k.Active), fmt.Sprint(k.Files), fmt.Sprint(k.Bytes),
+			})
+		}
+		table.Render()
+		return nil
+	}
+
+	if err := printKeys("Before rotation:"); err != nil {
+		return err
+	}
+	remaining, err := db.CompactStaleEncryptedFiles(ctx)
+	if err != nil {
+		return err
+	}
+	if err := printKeys("After rotation:"); err != nil {
+		return err
+	}
+	if remaining > 0 {
+		return errors.Newf("%d sstables are still encrypted with an inactive data key", remaining)
+	}
+	fmt.Println("All sstables are encrypted with the active data key.")
+	return nil
+}
+
 func runCheckFips(cmd *cobra.Command, args []string) error {
 	if runtime.GOOS != "linux" {
 		return errors.New("FIPS-ready mode is only supported on linux")
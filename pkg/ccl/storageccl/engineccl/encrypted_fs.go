@@ -11,6 +11,7 @@ package engineccl
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/baseccl"
 	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl/engineccl/enginepbccl"
@@ -290,6 +291,34 @@ func (e *encryptionStatsHandler) GetKeyIDFromSettings(settings []byte) (string,
 	return s.KeyId, nil
 }
 
+func (e *encryptionStatsHandler) GetKeyStats() ([]fs.EncryptionKeyStats, error) {
+	r := e.dataKM.getScrubbedRegistry()
+	keys := make([]fs.EncryptionKeyStats, 0, len(r.StoreKeys)+len(r.DataKeys))
+	add := func(info *enginepbccl.KeyInfo, storeKey, active bool) {
+		keys = append(keys, fs.EncryptionKeyStats{
+			KeyID:          info.KeyId,
+			ParentKeyID:    info.ParentKeyId,
+			StoreKey:       storeKey,
+			Active:         active,
+			EncryptionType: info.EncryptionType.String(),
+			CreationTime:   info.CreationTime,
+		})
+	}
+	for id, info := range r.StoreKeys {
+		add(info, true /* storeKey */, id == r.ActiveStoreKeyId)
+	}
+	for id, key := range r.DataKeys {
+		add(key.Info, false /* storeKey */, id == r.ActiveDataKeyId)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CreationTime != keys[j].CreationTime {
+			return keys[i].CreationTime < keys[j].CreationTime
+		}
+		return keys[i].KeyID < keys[j].KeyID
+	})
+	return keys, nil
+}
+
 // init initializes function hooks used in non-CCL code.
 func init() {
 	fs.NewEncryptedEnvFunc = newEncryptedEnv
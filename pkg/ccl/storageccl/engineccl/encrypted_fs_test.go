@@ -429,6 +429,85 @@ func TestPebbleEncryption2(t *testing.T) {
 	addKeyAndValidate("d", "d", "plain", "16v2.key")
 }
 
+// TestPebbleCompactStaleEncryptedFiles verifies that after a store key
+// rotation, CompactStaleEncryptedFiles rewrites all sstables written under the
+// old data key.
+func TestPebbleCompactStaleEncryptedFiles(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const stickyVFSID = `foo`
+	stickyRegistry := fs.NewStickyRegistry()
+
+	memFS := stickyRegistry.Get(stickyVFSID)
+	writeToFile(t, memFS, "16v1.key", []byte("111111111111111111111111111111111234567890123456"))
+	writeToFile(t, memFS, "16v2.key", []byte("111111111111111111111111111111198765432198765432"))
+
+	ctx := context.Background()
+	openEngine := func(encKeyFile string, oldEncFileKey string) storage.Engine {
+		encOptionsBytes, err := protoutil.Marshal(&baseccl.EncryptionOptions{
+			KeySource: baseccl.EncryptionKeySource_KeyFiles,
+			KeyFiles: &baseccl.EncryptionKeyFiles{
+				CurrentKey: encKeyFile,
+				OldKey:     oldEncFileKey,
+			},
+			DataKeyRotationPeriod: 1000,
+		})
+		require.NoError(t, err)
+		env, err := fs.InitEnvFromStoreSpec(
+			ctx,
+			base.StoreSpec{
+				InMemory:          true,
+				Attributes:        roachpb.Attributes{},
+				Size:              base.SizeSpec{InBytes: 512 << 20},
+				EncryptionOptions: encOptionsBytes,
+				StickyVFSID:       stickyVFSID,
+			},
+			fs.ReadWrite,
+			stickyRegistry, /* sticky registry */
+		)
+		require.NoError(t, err)
+		db, err := storage.Open(ctx, env, cluster.MakeTestingClusterSettings())
+		require.NoError(t, err)
+		return db
+	}
+
+	// Write a few sstables under the first store key.
+	db := openEngine("16v1.key", "plain")
+	for _, k := range []string{"a", "b", "c"} {
+		_, err := storage.MVCCPut(
+			ctx, db, roachpb.Key(k), hlc.Timestamp{},
+			roachpb.MakeValueFromBytes([]byte(k)), storage.MVCCWriteOptions{},
+		)
+		require.NoError(t, err)
+		require.NoError(t, db.Flush())
+	}
+	db.Close()
+
+	// Rotate the store key, which also rotates the active data key.
+	db = openEngine("16v2.key", "16v1.key")
+	defer db.Close()
+
+	stats, err := db.GetEnvStats()
+	require.NoError(t, err)
+	var staleFiles uint64
+	for _, k := range stats.Keys {
+		if !k.StoreKey && !k.Active {
+			staleFiles += k.Files
+		}
+	}
+	require.NotZero(t, staleFiles)
+
+	remaining, err := db.CompactStaleEncryptedFiles(ctx)
+	require.NoError(t, err)
+	require.Zero(t, remaining)
+
+	for _, k := range []string{"a", "b", "c"} {
+		res, err := storage.MVCCGet(ctx, db, roachpb.Key(k), hlc.MaxTimestamp, storage.MVCCGetOptions{})
+		require.NoError(t, err)
+		require.NotNil(t, res.Value)
+	}
+}
+
 func TestCanRegistryElide(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
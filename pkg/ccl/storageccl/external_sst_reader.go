@@ -63,6 +63,20 @@ func getFileWithRetry(
 type StoreFile struct {
 	Store    cloud.ExternalStorage
 	FilePath string
+	// Encryption, if set, overrides the encryption options passed to the
+	// reader for this file.
+	Encryption *kvpb.FileEncryptionOptions
+}
+
+// encryption returns the encryption options of the file, given the default
+// options of the reader.
+func (sf StoreFile) encryption(
+	defaultEncryption *kvpb.FileEncryptionOptions,
+) *kvpb.FileEncryptionOptions {
+	if sf.Encryption != nil {
+		return sf.Encryption
+	}
+	return defaultEncryption
 }
 
 // newMemPebbleSSTReader returns a PebbleSSTIterator for in-memory SSTs from
@@ -89,8 +103,8 @@ func newMemPebbleSSTReader(
 		if err != nil {
 			return nil, err
 		}
-		if encryption != nil {
-			content, err = DecryptFile(ctx, content, encryption.Key, nil /* mm */)
+		if enc := sf.encryption(encryption); enc != nil {
+			content, err = DecryptFile(ctx, content, enc.Key, nil /* mm */)
 			if err != nil {
 				return nil, err
 			}
@@ -159,8 +173,8 @@ func ExternalSSTReader(
 
 		var reader sstable.ReadableFile
 
-		if encryption != nil {
-			r, err := decryptingReader(raw, encryption.Key)
+		if enc := sf.encryption(encryption); enc != nil {
+			r, err := decryptingReader(raw, enc.Key)
 			if err != nil {
 				f.Close(ctx)
 				return nil, err
@@ -1218,6 +1218,13 @@ is the prefix for range local keys. The hex format takes an encoded MVCCKey.`}
 		Description: "Restrict scan to replicated data.",
 	}
 
+	RaftLogDir = FlagInfo{
+		Name: "raft-log-dir",
+		Description: `
+Path to the separate Raft log engine of the store, if it was started with
+raft-log-path in its --store specification.`,
+	}
+
 	GossipInputFile = FlagInfo{
 		Name:      "file",
 		Shorthand: "f",
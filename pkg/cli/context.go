@@ -452,6 +452,7 @@ var debugCtx struct {
 	decodeAsTableDesc string
 	verbose           bool
 	keyTypes          keyTypeFilter
+	raftLogDir        string
 }
 
 // setDebugContextDefaults set the default values in debugCtx.  This
@@ -470,6 +471,7 @@ func setDebugContextDefaults() {
 	debugCtx.decodeAsTableDesc = ""
 	debugCtx.verbose = false
 	debugCtx.keyTypes = showAll
+	debugCtx.raftLogDir = ""
 }
 
 // startCtx captures the command-line arguments for the `start` command.
@@ -39,6 +39,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/gc"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvstorage"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness/livenesspb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rditer"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -772,7 +773,8 @@ var debugRaftLogCmd = &cobra.Command{
 	Use:   "raft-log <directory> <range id>",
 	Short: "print the raft log for a range",
 	Long: `
-Prints all log entries in a store for the given range.
+Prints all log entries in a store for the given range. If the store keeps its
+Raft log in a separate engine, its directory must be passed with --raft-log-dir.
 `,
 	Args: cobra.ExactArgs(2),
 	RunE: clierrorplus.MaybeDecorateError(runDebugRaftLog),
@@ -786,6 +788,22 @@ func runDebugRaftLog(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	separated, err := kvstorage.UsesRaftLogEngine(cmd.Context(), db)
+	if err != nil {
+		return err
+	}
+	if separated != (debugCtx.raftLogDir != "") {
+		if separated {
+			return errors.Errorf("store %s keeps its Raft log in a separate engine; use --%s",
+				args[0], cliflags.RaftLogDir.Name)
+		}
+		return errors.Errorf("store %s does not keep its Raft log in a separate engine", args[0])
+	}
+	if separated {
+		if db, err = OpenEngine(debugCtx.raftLogDir, stopper, fs.ReadOnly, storage.MustExist); err != nil {
+			return err
+		}
+	}
 
 	rangeID, err := parseRangeID(args[1])
 	if err != nil {
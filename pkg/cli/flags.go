@@ -913,6 +913,10 @@ func init() {
 		cliflagcfg.IntFlag(f, &debugCtx.maxResults, cliflags.Limit)
 		cliflagcfg.StringFlag(f, &serverCfg.SharedStorage, cliflags.SharedStorage)
 	}
+	{
+		f := debugRaftLogCmd.Flags()
+		cliflagcfg.StringFlag(f, &debugCtx.raftLogDir, cliflags.RaftLogDir)
+	}
 	{
 		f := debugGossipValuesCmd.Flags()
 		cliflagcfg.StringFlag(f, &debugCtx.inputFile, cliflags.GossipInputFile)
@@ -309,6 +309,36 @@ const (
 	// to be pipelined.
 	V24_1_ReplicatedLockPipelining
 
+	// V24_1_LeaderLeases enables leader leases and the raft leader fortification
+	// they rely on. Nodes running older versions don't know about the lease's
+	// term and minimum expiration, the HardState's fortification fields or the
+	// MsgFortifyLeader and MsgDeFortifyLeader messages.
+	V24_1_LeaderLeases
+
+	// V24_1_HotKeys enables per-key rate limits, which reject requests with a
+	// KeyRateLimitedError that older nodes can't decode, and the persistence of
+	// hot keys in key visualizer samples.
+	V24_1_HotKeys
+
+	// V24_1_StatementPlanHintsTable adds the system.statement_plan_hints table,
+	// which stores the plan baselines used to pin query plans.
+	V24_1_StatementPlanHintsTable
+
+	// V24_1_TableStatisticsExtendedStats adds the extendedStats column to
+	// system.table_statistics, which stores functional dependencies and
+	// most-common-value lists for multi-column statistics.
+	V24_1_TableStatisticsExtendedStats
+
+	// V24_1_RowLevelSecurity enables row-level security policies. Nodes running
+	// older versions don't know about the policies stored on table descriptors
+	// and would not enforce them.
+	V24_1_RowLevelSecurity
+
+	// V24_1_WitnessReplicas enables witness replicas, which vote without
+	// storing range data. Nodes running older versions don't know about the
+	// WITNESS replica type, so witnesses can't be added before then.
+	V24_1_WitnessReplicas
+
 	numKeys
 )
 
@@ -377,6 +407,12 @@ var versionTable = [numKeys]roachpb.Version{
 	V24_1_GossipMaximumIOOverload:              {Major: 23, Minor: 2, Internal: 20},
 	V24_1_EstimatedMVCCStatsInSplit:            {Major: 23, Minor: 2, Internal: 22},
 	V24_1_ReplicatedLockPipelining:             {Major: 23, Minor: 2, Internal: 24},
+	V24_1_LeaderLeases:                         {Major: 23, Minor: 2, Internal: 26},
+	V24_1_HotKeys:                              {Major: 23, Minor: 2, Internal: 28},
+	V24_1_StatementPlanHintsTable:              {Major: 23, Minor: 2, Internal: 30},
+	V24_1_TableStatisticsExtendedStats:         {Major: 23, Minor: 2, Internal: 32},
+	V24_1_RowLevelSecurity:                     {Major: 23, Minor: 2, Internal: 34},
+	V24_1_WitnessReplicas:                      {Major: 23, Minor: 2, Internal: 36},
 }
 
 // Latest is always the highest version key. This is the maximum logical cluster
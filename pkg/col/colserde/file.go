@@ -18,6 +18,7 @@ import (
 	"os"
 
 	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/col/colserde/arrowserde"
 	"github.com/cockroachdb/cockroach/pkg/col/typeconv"
@@ -48,9 +49,14 @@ type FileSerializer struct {
 
 	w    *countingWriter
 	typs []*types.T
-	fb   *flatbuffers.Builder
-	a    *ArrowBatchConverter
-	rb   *RecordBatchSerializer
+	// names, if set, are the field names written into the schema.
+	names []string
+	// external, if set, indicates that the file is meant to be read by other
+	// arrow implementations (see NewFileSerializerWithFieldNames).
+	external bool
+	fb       *flatbuffers.Builder
+	a        *ArrowBatchConverter
+	rb       *RecordBatchSerializer
 
 	recordBatches []fileBlock
 }
@@ -59,6 +65,37 @@ type FileSerializer struct {
 // responsible for closing the given writer as well as the given memory account.
 func NewFileSerializer(
 	w io.Writer, typs []*types.T, acc *mon.BoundAccount,
+) (*FileSerializer, error) {
+	return newFileSerializer(w, typs, nil /* names */, acc)
+}
+
+// NewFileSerializerWithFieldNames is like NewFileSerializer but also records
+// the given column names as the field names in the schema of the file. This is
+// useful when the file is meant to be consumed by tools outside of
+// CockroachDB.
+//
+// Unlike NewFileSerializer, the null count of every column is written, and
+// booleans are written bit-packed as required by the arrow spec. Files with
+// boolean columns written by this serializer therefore can't be read by
+// FileDeserializer, which expects one byte per value.
+func NewFileSerializerWithFieldNames(
+	w io.Writer, typs []*types.T, names []string, acc *mon.BoundAccount,
+) (*FileSerializer, error) {
+	if len(names) != len(typs) {
+		return nil, errors.AssertionFailedf(
+			"mismatched number of field names %d and types %d", len(names), len(typs),
+		)
+	}
+	s, err := newFileSerializer(w, typs, names, acc)
+	if err != nil {
+		return nil, err
+	}
+	s.external = true
+	return s, nil
+}
+
+func newFileSerializer(
+	w io.Writer, typs []*types.T, names []string, acc *mon.BoundAccount,
 ) (*FileSerializer, error) {
 	a, err := NewArrowBatchConverter(typs, BatchToArrowOnly, acc)
 	if err != nil {
@@ -69,10 +106,11 @@ func NewFileSerializer(
 		return nil, err
 	}
 	s := &FileSerializer{
-		typs: typs,
-		fb:   flatbuffers.NewBuilder(flatbufferBuilderInitialCapacity),
-		a:    a,
-		rb:   rb,
+		typs:  typs,
+		names: names,
+		fb:    flatbuffers.NewBuilder(flatbufferBuilderInitialCapacity),
+		a:     a,
+		rb:    rb,
 	}
 	return s, s.Reset(w)
 }
@@ -96,14 +134,21 @@ func (s *FileSerializer) Reset(w io.Writer) error {
 
 	// The file format is a wrapper around the streaming format and the streaming
 	// format starts with a Schema message.
+	// Like the RecordBatch messages, it is prefixed by its length and padded
+	// so that the following message starts on an 8 byte boundary.
 	s.fb.Reset()
-	messageOffset := schemaMessage(s.fb, s.typs)
+	messageOffset := schemaMessage(s.fb, s.typs, s.names)
 	s.fb.Finish(messageOffset)
 	schemaBytes := s.fb.FinishedBytes()
+	padding := calculatePadding(metadataLengthNumBytes + len(schemaBytes))
+	binary.LittleEndian.PutUint32(s.scratch[:], uint32(len(schemaBytes)+padding))
+	if _, err := s.w.Write(s.scratch[:]); err != nil {
+		return err
+	}
 	if _, err := s.w.Write(schemaBytes); err != nil {
 		return err
 	}
-	_, err := s.w.Write(make([]byte, calculatePadding(len(schemaBytes))))
+	_, err := s.w.Write(make([]byte, padding))
 	return err
 }
 
@@ -115,19 +160,59 @@ func (s *FileSerializer) AppendBatch(ctx context.Context, batch coldata.Batch) e
 	if err != nil {
 		return err
 	}
+	if s.external {
+		for i, typ := range s.typs {
+			arrow[i] = toExternalLayout(arrow[i], typ)
+		}
+	}
 	metadataLen, bodyLen, err := s.rb.Serialize(s.w, arrow, batch.Length())
 	if err != nil {
 		return err
 	}
 
+	// The arrow spec defines the metadata length of a block to include the
+	// length prefix of the message.
 	s.recordBatches = append(s.recordBatches, fileBlock{
 		offset:      offset,
-		metadataLen: int32(metadataLen),
+		metadataLen: int32(metadataLengthNumBytes + metadataLen),
 		bodyLen:     int64(bodyLen),
 	})
 	return nil
 }
 
+// toExternalLayout adjusts the given array, as produced by ArrowBatchConverter,
+// so that it can be read by other arrow implementations. Readers skip the
+// validity bitmap of arrays without a null count, so the null count is set.
+// Booleans, which ArrowBatchConverter represents with one byte per value, are
+// bit-packed.
+func toExternalLayout(data array.Data, typ *types.T) array.Data {
+	n := data.Len()
+	buffers := data.Buffers()
+	nullCount := 0
+	if buffers[0] != nil && buffers[0].Len() > 0 {
+		bitmap := buffers[0].Bytes()
+		for i := 0; i < n; i++ {
+			if bitmap[i/8]&(1<<(i%8)) == 0 {
+				nullCount++
+			}
+		}
+	}
+	if typ.Family() == types.BoolFamily {
+		packed := make([]byte, (n+7)/8)
+		if buffers[1] != nil {
+			for i, b := range buffers[1].Bytes()[:n] {
+				if b != 0 {
+					packed[i/8] |= 1 << (i % 8)
+				}
+			}
+		}
+		buffers = []*memory.Buffer{buffers[0], memory.NewBufferBytes(packed)}
+	}
+	return *array.NewData(
+		nil /* dtype */, n, buffers, nil /* childData */, nullCount, 0, /* offset */
+	)
+}
+
 // Finish writes the footer metadata described by the arrow spec. Nothing can be
 // called after Finish except Reset.
 func (s *FileSerializer) Finish() error {
@@ -138,7 +223,7 @@ func (s *FileSerializer) Finish() error {
 	// Write the footer flatbuffer, which has byte offsets of all the record
 	// batch messages in the file.
 	s.fb.Reset()
-	footerOffset := fileFooter(s.fb, s.typs, s.recordBatches)
+	footerOffset := fileFooter(s.fb, s.typs, s.names, s.recordBatches)
 	s.fb.Finish(footerOffset)
 	footerBytes := s.fb.FinishedBytes()
 	if _, err := s.w.Write(footerBytes); err != nil {
@@ -248,7 +333,7 @@ func (d *FileDeserializer) NumBatches() int {
 func (d *FileDeserializer) GetBatch(batchIdx int, b coldata.Batch) error {
 	rb := d.recordBatches[batchIdx]
 	d.idx = int(rb.offset)
-	buf, err := d.read(metadataLengthNumBytes + int(rb.metadataLen) + int(rb.bodyLen))
+	buf, err := d.read(int(rb.metadataLen) + int(rb.bodyLen))
 	if err != nil {
 		return err
 	}
@@ -305,8 +390,8 @@ func (d *FileDeserializer) init() error {
 		return pgerror.Wrap(err, pgcode.DataException, `reading arrow file footer`)
 	}
 	footer := arrowserde.GetRootAsFooter(footerBytes, 0)
-	if footer.Version() != arrowserde.MetadataVersionV1 {
-		return errors.Errorf(`only arrow V1 is supported got %d`, footer.Version())
+	if footer.Version() != arrowserde.MetadataVersionV4 {
+		return errors.Errorf(`only arrow V4 is supported got %d`, footer.Version())
 	}
 
 	var block arrowserde.Block
@@ -334,9 +419,17 @@ func (w *countingWriter) Write(buf []byte) (int, error) {
 	return n, err
 }
 
-func schema(fb *flatbuffers.Builder, typs []*types.T) flatbuffers.UOffsetT {
+// schema serializes the arrow schema for the given types. names are optional
+// and, if non-empty, must have the same length as typs.
+func schema(fb *flatbuffers.Builder, typs []*types.T, names []string) flatbuffers.UOffsetT {
 	fieldOffsets := make([]flatbuffers.UOffsetT, len(typs))
 	for idx, typ := range typs {
+		// Strings have to be created before the table that references them is
+		// started.
+		var nameOffset flatbuffers.UOffsetT
+		if len(names) > 0 {
+			nameOffset = fb.CreateString(names[idx])
+		}
 		var fbTyp byte
 		var fbTypOffset flatbuffers.UOffsetT
 		switch typeconv.TypeFamilyToCanonicalTypeFamily(typ.Family()) {
@@ -345,6 +438,14 @@ func schema(fb *flatbuffers.Builder, typs []*types.T) flatbuffers.UOffsetT {
 			fbTypOffset = arrowserde.BoolEnd(fb)
 			fbTyp = arrowserde.TypeBool
 		case types.BytesFamily, types.JsonFamily:
+			if typ.Family() == types.StringFamily {
+				// Strings share the physical representation of bytes, but
+				// external readers expect them to be typed as utf8.
+				arrowserde.Utf8Start(fb)
+				fbTypOffset = arrowserde.Utf8End(fb)
+				fbTyp = arrowserde.TypeUtf8
+				break
+			}
 			arrowserde.BinaryStart(fb)
 			fbTypOffset = arrowserde.BinaryEnd(fb)
 			fbTyp = arrowserde.TypeBinary
@@ -400,6 +501,10 @@ func schema(fb *flatbuffers.Builder, typs []*types.T) flatbuffers.UOffsetT {
 			panic(errors.Errorf(`don't know how to map %s`, typ))
 		}
 		arrowserde.FieldStart(fb)
+		if len(names) > 0 {
+			arrowserde.FieldAddName(fb, nameOffset)
+		}
+		arrowserde.FieldAddNullable(fb, 1)
 		arrowserde.FieldAddTypeType(fb, fbTyp)
 		arrowserde.FieldAddType(fb, fbTypOffset)
 		fieldOffsets[idx] = arrowserde.FieldEnd(fb)
@@ -418,19 +523,21 @@ func schema(fb *flatbuffers.Builder, typs []*types.T) flatbuffers.UOffsetT {
 	return arrowserde.SchemaEnd(fb)
 }
 
-func schemaMessage(fb *flatbuffers.Builder, typs []*types.T) flatbuffers.UOffsetT {
-	schemaOffset := schema(fb, typs)
+func schemaMessage(
+	fb *flatbuffers.Builder, typs []*types.T, names []string,
+) flatbuffers.UOffsetT {
+	schemaOffset := schema(fb, typs, names)
 	arrowserde.MessageStart(fb)
-	arrowserde.MessageAddVersion(fb, arrowserde.MetadataVersionV1)
+	arrowserde.MessageAddVersion(fb, arrowserde.MetadataVersionV4)
 	arrowserde.MessageAddHeaderType(fb, arrowserde.MessageHeaderSchema)
 	arrowserde.MessageAddHeader(fb, schemaOffset)
 	return arrowserde.MessageEnd(fb)
 }
 
 func fileFooter(
-	fb *flatbuffers.Builder, typs []*types.T, recordBatches []fileBlock,
+	fb *flatbuffers.Builder, typs []*types.T, names []string, recordBatches []fileBlock,
 ) flatbuffers.UOffsetT {
-	schemaOffset := schema(fb, typs)
+	schemaOffset := schema(fb, typs, names)
 	arrowserde.FooterStartRecordBatchesVector(fb, len(recordBatches))
 	// flatbuffers adds everything back to front. Reverse iterate so they're in
 	// the right order when they come out.
@@ -440,7 +547,7 @@ func fileFooter(
 	}
 	recordBatchesOffset := fb.EndVector(len(recordBatches))
 	arrowserde.FooterStart(fb)
-	arrowserde.FooterAddVersion(fb, arrowserde.MetadataVersionV1)
+	arrowserde.FooterAddVersion(fb, arrowserde.MetadataVersionV4)
 	arrowserde.FooterAddSchema(fb, schemaOffset)
 	arrowserde.FooterAddRecordBatches(fb, recordBatchesOffset)
 	return arrowserde.FooterEnd(fb)
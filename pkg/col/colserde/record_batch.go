@@ -142,7 +142,9 @@ func (s *RecordBatchSerializer) Serialize(
 				bufferLen = buffers[j].Len()
 			}
 			s.scratch.bufferLens = append(s.scratch.bufferLens, bufferLen)
-			totalBufferLen += bufferLen
+			// Every buffer starts on an 8 byte boundary, as required by the
+			// arrow spec.
+			totalBufferLen += bufferLen + calculatePadding(bufferLen)
 		}
 	}
 	nodes := s.builder.EndVector(len(data))
@@ -157,7 +159,7 @@ func (s *RecordBatchSerializer) Serialize(
 	arrowserde.RecordBatchStartBuffersVector(s.builder, len(s.scratch.bufferLens))
 	for i, offset := 0, totalBufferLen; i < len(s.scratch.bufferLens); i++ {
 		bufferLen := s.scratch.bufferLens[i]
-		offset -= bufferLen
+		offset -= bufferLen + calculatePadding(bufferLen)
 		arrowserde.CreateBuffer(s.builder, int64(offset), int64(bufferLen))
 	}
 	buffers := s.builder.EndVector(len(s.scratch.bufferLens))
@@ -173,7 +175,7 @@ func (s *RecordBatchSerializer) Serialize(
 	// Finally, encode the Message table. This will include the RecordBatch above
 	// as well as some metadata.
 	arrowserde.MessageStart(s.builder)
-	arrowserde.MessageAddVersion(s.builder, arrowserde.MetadataVersionV1)
+	arrowserde.MessageAddVersion(s.builder, arrowserde.MetadataVersionV4)
 	arrowserde.MessageAddHeaderType(s.builder, arrowserde.MessageHeaderRecordBatch)
 	arrowserde.MessageAddHeader(s.builder, header)
 	arrowserde.MessageAddBodyLength(s.builder, int64(totalBufferLen))
@@ -212,20 +214,21 @@ func (s *RecordBatchSerializer) Serialize(
 				// Some value buffers can be nil if the data are all zero values.
 				bufferBytes = buffers[j].Bytes()
 			}
-			bodyLength += len(bufferBytes)
 			if _, err := w.Write(bufferBytes); err != nil {
 				return 0, 0, err
 			}
+			// Pad the buffer so that the next one starts on an 8 byte boundary.
+			// This also makes the body a multiple of 8 bytes.
+			padding = s.scratch.padding[:calculatePadding(len(bufferBytes))]
+			if _, err := w.Write(padding); err != nil {
+				return 0, 0, err
+			}
+			bodyLength += len(bufferBytes) + len(padding)
 		}
 		// Eagerly discard the buffer; we have no use for it any longer.
 		data[i] = array.Data{}
 	}
-
-	// Add body padding. The body also needs to be a multiple of 8 bytes.
-	padding = s.scratch.padding[:calculatePadding(bodyLength)]
-	_, err := w.Write(padding)
-	bodyLength += len(padding)
-	return metadataLength, uint64(bodyLength), err
+	return metadataLength, uint64(bodyLength), nil
 }
 
 // Deserialize deserializes an arrow IPC RecordBatch message contained in bytes
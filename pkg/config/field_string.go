@@ -17,6 +17,8 @@ func _() {
 	_ = x[Constraints-7]
 	_ = x[VoterConstraints-8]
 	_ = x[LeasePreferences-9]
+	_ = x[NumWitnesses-10]
+	_ = x[MaxQPSPerKey-11]
 }
 
 func (i Field) String() string {
@@ -39,6 +41,10 @@ func (i Field) String() string {
 		return "voter_constraints"
 	case LeasePreferences:
 		return "lease_preferences"
+	case NumWitnesses:
+		return "num_witnesses"
+	case MaxQPSPerKey:
+		return "max_qps_per_key"
 	default:
 		return "Field(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
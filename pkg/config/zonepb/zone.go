@@ -395,6 +395,14 @@ func (z *ZoneConfig) Validate() error {
 		}
 	}
 
+	if z.NumWitnesses != nil && *z.NumWitnesses < 0 {
+		return fmt.Errorf("num_witnesses cannot be negative")
+	}
+
+	if z.MaxQPSPerKey != nil && *z.MaxQPSPerKey < 0 {
+		return fmt.Errorf("max_qps_per_key cannot be negative")
+	}
+
 	if z.RangeMaxBytes != nil && *z.RangeMaxBytes < minRangeMaxBytes {
 		return fmt.Errorf("RangeMaxBytes %d less than minimum allowed %d",
 			*z.RangeMaxBytes, minRangeMaxBytes)
@@ -561,6 +569,16 @@ func (z *ZoneConfig) InheritFromParent(parent *ZoneConfig) {
 			z.NumVoters = proto.Int32(*parent.NumVoters)
 		}
 	}
+	if z.NumWitnesses == nil {
+		if parent.NumWitnesses != nil {
+			z.NumWitnesses = proto.Int32(*parent.NumWitnesses)
+		}
+	}
+	if z.MaxQPSPerKey == nil {
+		if parent.MaxQPSPerKey != nil {
+			z.MaxQPSPerKey = proto.Int32(*parent.MaxQPSPerKey)
+		}
+	}
 	if z.GlobalReads == nil {
 		if parent.GlobalReads != nil {
 			z.GlobalReads = proto.Bool(*parent.GlobalReads)
@@ -610,6 +628,16 @@ func (z *ZoneConfig) CopyFromZone(other ZoneConfig, fieldList []tree.Name) {
 			if other.NumVoters != nil {
 				z.NumVoters = proto.Int32(*other.NumVoters)
 			}
+		case "num_witnesses":
+			z.NumWitnesses = nil
+			if other.NumWitnesses != nil {
+				z.NumWitnesses = proto.Int32(*other.NumWitnesses)
+			}
+		case "max_qps_per_key":
+			z.MaxQPSPerKey = nil
+			if other.MaxQPSPerKey != nil {
+				z.MaxQPSPerKey = proto.Int32(*other.MaxQPSPerKey)
+			}
 		case "range_min_bytes":
 			z.RangeMinBytes = nil
 			if other.RangeMinBytes != nil {
@@ -725,6 +753,30 @@ func (z *ZoneConfig) DiffWithZone(
 					Actual:   int32ToString(z.NumVoters),
 				}, nil
 			}
+		case "num_witnesses":
+			if other.NumWitnesses == nil && z.NumWitnesses == nil {
+				continue
+			}
+			if z.NumWitnesses == nil || other.NumWitnesses == nil ||
+				*z.NumWitnesses != *other.NumWitnesses {
+				return false, DiffWithZoneMismatch{
+					Field:    "num_witnesses",
+					Expected: int32ToString(other.NumWitnesses),
+					Actual:   int32ToString(z.NumWitnesses),
+				}, nil
+			}
+		case "max_qps_per_key":
+			if other.MaxQPSPerKey == nil && z.MaxQPSPerKey == nil {
+				continue
+			}
+			if z.MaxQPSPerKey == nil || other.MaxQPSPerKey == nil ||
+				*z.MaxQPSPerKey != *other.MaxQPSPerKey {
+				return false, DiffWithZoneMismatch{
+					Field:    "max_qps_per_key",
+					Expected: int32ToString(other.MaxQPSPerKey),
+					Actual:   int32ToString(z.MaxQPSPerKey),
+				}, nil
+			}
 		case "range_min_bytes":
 			if other.RangeMinBytes == nil && z.RangeMinBytes == nil {
 				continue
@@ -1248,6 +1300,12 @@ func (z *ZoneConfig) toSpanConfig() (roachpb.SpanConfig, error) {
 	if z.NumVoters != nil {
 		sc.NumVoters = *z.NumVoters
 	}
+	if z.NumWitnesses != nil {
+		sc.NumWitnesses = *z.NumWitnesses
+	}
+	if z.MaxQPSPerKey != nil {
+		sc.MaxQPSPerKey = *z.MaxQPSPerKey
+	}
 
 	toSpanConfigConstraints := func(src []Constraint) ([]roachpb.Constraint, error) {
 		spanConfigConstraints := make([]roachpb.Constraint, len(src))
@@ -55,6 +55,20 @@ func TestZoneConfigValidate(t *testing.T) {
 			},
 			"at least 3 replicas are required for multi-replica configurations",
 		},
+		{
+			ZoneConfig{
+				NumReplicas:  proto.Int32(1),
+				NumWitnesses: proto.Int32(-1),
+			},
+			"num_witnesses cannot be negative",
+		},
+		{
+			ZoneConfig{
+				NumReplicas:  proto.Int32(1),
+				MaxQPSPerKey: proto.Int32(-1),
+			},
+			"max_qps_per_key cannot be negative",
+		},
 		{
 			ZoneConfig{
 				NumReplicas:   proto.Int32(1),
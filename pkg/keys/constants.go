@@ -207,12 +207,22 @@ var (
 	// localStoreNodeTombstoneSuffix stores key value pairs that map
 	// nodeIDs to time of removal from cluster.
 	localStoreNodeTombstoneSuffix = []byte("ntmb")
+	// localStoreRaftLogEngineSuffix marks a store whose Raft log and
+	// unreplicated Raft state live in a separate storage engine. The value is
+	// the StoreIdent that the separate engine was initialized with.
+	localStoreRaftLogEngineSuffix = []byte("rlog")
 	// localStoreCachedSettingsSuffix stores the cached settings for node.
 	localStoreCachedSettingsSuffix = []byte("stng")
 	// LocalStoreCachedSettingsKeyMin is the start of span of possible cached settings keys.
 	LocalStoreCachedSettingsKeyMin = MakeStoreKey(localStoreCachedSettingsSuffix, nil)
 	// LocalStoreCachedSettingsKeyMax is the end of span of possible cached settings keys.
 	LocalStoreCachedSettingsKeyMax = LocalStoreCachedSettingsKeyMin.PrefixEnd()
+	// localStoreLivenessRequesterMetaSuffix stores the store liveness epoch
+	// under which this store requests support from other stores.
+	localStoreLivenessRequesterMetaSuffix = []byte("slrm")
+	// localStoreLivenessSupporterMetaSuffix stores the store liveness epochs
+	// at which this store supports other stores.
+	localStoreLivenessSupporterMetaSuffix = []byte("slsm")
 	// localStoreLastUpSuffix stores the last timestamp that a store's node
 	// acknowledged that it was still running. This value will be regularly
 	// refreshed on all stores for a running node; the intention of this value
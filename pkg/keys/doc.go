@@ -218,6 +218,9 @@ var _ = [...]interface{}{
 	StoreIdentKey,                    // "iden"
 	StoreUnsafeReplicaRecoveryKey,    // "loqr"
 	StoreNodeTombstoneKey,            // "ntmb"
+	StoreRaftLogEngineKey,            // "rlog"
+	StoreLivenessRequesterMetaKey,    // "slrm"
+	StoreLivenessSupporterMetaKey,    // "slsm"
 	StoreCachedSettingsKey,           // "stng"
 	StoreLastUpKey,                   // "uptm"
 
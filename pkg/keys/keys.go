@@ -75,12 +75,30 @@ func StoreLastUpKey() roachpb.Key {
 	return MakeStoreKey(localStoreLastUpSuffix, nil)
 }
 
+// StoreLivenessRequesterMetaKey returns the store-local key for the store
+// liveness epoch under which the store requests support from other stores.
+func StoreLivenessRequesterMetaKey() roachpb.Key {
+	return MakeStoreKey(localStoreLivenessRequesterMetaSuffix, nil)
+}
+
+// StoreLivenessSupporterMetaKey returns the store-local key for the store
+// liveness support that the store provides to other stores.
+func StoreLivenessSupporterMetaKey() roachpb.Key {
+	return MakeStoreKey(localStoreLivenessSupporterMetaSuffix, nil)
+}
+
 // StoreHLCUpperBoundKey returns the store-local key for storing an upper bound
 // to the wall time used by HLC.
 func StoreHLCUpperBoundKey() roachpb.Key {
 	return MakeStoreKey(localStoreHLCUpperBoundSuffix, nil)
 }
 
+// StoreRaftLogEngineKey returns the store-local key which records that the
+// store's Raft log lives in a separate engine.
+func StoreRaftLogEngineKey() roachpb.Key {
+	return MakeStoreKey(localStoreRaftLogEngineSuffix, nil)
+}
+
 // StoreNodeTombstoneKey returns the key for storing a node tombstone for nodeID.
 func StoreNodeTombstoneKey(nodeID roachpb.NodeID) roachpb.Key {
 	return MakeStoreKey(localStoreNodeTombstoneSuffix, encoding.EncodeUint32Ascending(nil, uint32(nodeID)))
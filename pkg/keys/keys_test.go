@@ -37,6 +37,8 @@ func TestStoreKeyEncodeDecode(t *testing.T) {
 		{key: DeprecatedStoreClusterVersionKey(), expSuffix: localStoreClusterVersionSuffix, expDetail: nil},
 		{key: StoreLastUpKey(), expSuffix: localStoreLastUpSuffix, expDetail: nil},
 		{key: StoreHLCUpperBoundKey(), expSuffix: localStoreHLCUpperBoundSuffix, expDetail: nil},
+		{key: StoreLivenessRequesterMetaKey(), expSuffix: localStoreLivenessRequesterMetaSuffix, expDetail: nil},
+		{key: StoreLivenessSupporterMetaKey(), expSuffix: localStoreLivenessSupporterMetaSuffix, expDetail: nil},
 	}
 	for _, test := range testCases {
 		t.Run("", func(t *testing.T) {
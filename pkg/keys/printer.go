@@ -148,7 +148,10 @@ var constSubKeyDict = []struct {
 	{"/gossipBootstrap", localStoreGossipSuffix},
 	{"/clusterVersion", localStoreClusterVersionSuffix},
 	{"/nodeTombstone", localStoreNodeTombstoneSuffix},
+	{"/raftLogEngine", localStoreRaftLogEngineSuffix},
 	{"/cachedSettings", localStoreCachedSettingsSuffix},
+	{"/storeLiveness/requesterMeta", localStoreLivenessRequesterMetaSuffix},
+	{"/storeLiveness/supporterMeta", localStoreLivenessSupporterMetaSuffix},
 	{"/lossOfQuorumRecovery/applied", localStoreUnsafeReplicaRecoverySuffix},
 	{"/lossOfQuorumRecovery/status", localStoreLossOfQuorumRecoveryStatusSuffix},
 	{"/lossOfQuorumRecovery/cleanup", localStoreLossOfQuorumRecoveryCleanupActionsSuffix},
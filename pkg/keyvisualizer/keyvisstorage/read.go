@@ -93,6 +93,17 @@ func ReadSamples(
 		endKeyID := tree.MustBeDUuid(row[3]).UUID
 		requests := tree.MustBeDInt(row[4])
 
+		// buckets whose start and end keys are the same are hot keys, see
+		// writeBuckets.
+		if startKeyID == endKeyID {
+			samples[sampleID].HotKeys = append(samples[sampleID].HotKeys,
+				serverpb.KeyVisSamplesResponse_HotKey{
+					KeyID:    startKeyID,
+					Requests: uint64(requests),
+				})
+			continue
+		}
+
 		// create a bucket
 		bucket := serverpb.KeyVisSamplesResponse_Bucket{
 			StartKeyID: startKeyID,
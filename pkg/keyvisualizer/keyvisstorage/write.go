@@ -78,6 +78,7 @@ func writeBuckets(
 	existingKeys map[string]string,
 	newKeys map[string]string,
 	sample keyvispb.Sample,
+	sampleInterval time.Duration,
 ) error {
 
 	values := make([]string, 0)
@@ -107,6 +108,20 @@ func writeBuckets(
 		values = append(values, rowValue)
 	}
 
+	// Hot keys are stored as buckets whose start and end keys are the same,
+	// which no span statistics bucket can have. Their request count is
+	// estimated from their load over the sample interval.
+	for _, hotKey := range sample.HotKeys {
+		keyHex := hex.EncodeToString(hotKey.Key)
+		keyID, ok := existingKeys[keyHex]
+		if !ok {
+			keyID = newKeys[keyHex]
+		}
+		requests := uint64(hotKey.QPS * sampleInterval.Seconds())
+		rowValue := fmt.Sprintf("('%s', '%s', '%s', %d)", sampleID, keyID, keyID, requests)
+		values = append(values, rowValue)
+	}
+
 	if len(values) == 0 {
 		return nil
 	}
@@ -130,13 +145,19 @@ func getKeys(
 	ctx context.Context, ie *sql.InternalExecutor, sample keyvispb.Sample,
 ) (map[string]string, map[string]string, error) {
 
+	// collect all keys in the sample, including its hot keys.
+	var sampleKeys []roachpb.Key
+	for _, bucket := range sample.SpanStats {
+		sampleKeys = append(sampleKeys, bucket.Span.Key, bucket.Span.EndKey)
+	}
+	for _, hotKey := range sample.HotKeys {
+		sampleKeys = append(sampleKeys, hotKey.Key)
+	}
+
 	// query unique_keys for all keys in the sample
 	keysAsString := make([]string, 0)
-	for _, bucket := range sample.SpanStats {
-		startKeyAsString := hex.EncodeToString(bucket.Span.Key)
-		endKeyAsString := hex.EncodeToString(bucket.Span.EndKey)
-		keysAsString = append(keysAsString, "X'"+startKeyAsString+"'")
-		keysAsString = append(keysAsString, "X'"+endKeyAsString+"'")
+	for _, key := range sampleKeys {
+		keysAsString = append(keysAsString, "X'"+hex.EncodeToString(key)+"'")
 	}
 
 	stmt := fmt.Sprintf("SELECT * FROM system.span_stats_unique_keys "+
@@ -163,26 +184,28 @@ func getKeys(
 	}
 
 	newKeysToWrite := make(map[string]string)
-	for _, bucket := range sample.SpanStats {
-
-		// is the start key in existing keys?
-		start := hex.EncodeToString(bucket.Span.Key)
-		end := hex.EncodeToString(bucket.Span.EndKey)
-
-		if _, ok := existingKeys[start]; !ok {
-			newKeysToWrite[start] = uuid.MakeV4().String()
+	for _, key := range sampleKeys {
+		// is the key in existing keys, or already about to be written?
+		keyHex := hex.EncodeToString(key)
+		if _, ok := existingKeys[keyHex]; ok {
+			continue
 		}
-
-		if _, ok := existingKeys[end]; !ok {
-			newKeysToWrite[end] = uuid.MakeV4().String()
+		if _, ok := newKeysToWrite[keyHex]; !ok {
+			newKeysToWrite[keyHex] = uuid.MakeV4().String()
 		}
 	}
 
 	return existingKeys, newKeysToWrite, nil
 }
 
-// WriteSamples persists the keyvispb.GetSamplesResponse.
-func WriteSamples(ctx context.Context, ie *sql.InternalExecutor, samples []keyvispb.Sample) error {
+// WriteSamples persists the keyvispb.GetSamplesResponse. The sample interval is
+// used to estimate the number of requests to the samples' hot keys.
+func WriteSamples(
+	ctx context.Context,
+	ie *sql.InternalExecutor,
+	samples []keyvispb.Sample,
+	sampleInterval time.Duration,
+) error {
 	for _, sample := range samples {
 
 		// write a new sample, returning the primary key.
@@ -204,7 +227,7 @@ func WriteSamples(ctx context.Context, ie *sql.InternalExecutor, samples []keyvi
 
 		// write new buckets
 		if err := writeBuckets(
-			ctx, ie, sampleID, existingKeys, newKeysToWrite, sample,
+			ctx, ie, sampleID, existingKeys, newKeysToWrite, sample, sampleInterval,
 		); err != nil {
 			return err
 		}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/keyvisualizer/keyvispb"
 	"github.com/cockroachdb/cockroach/pkg/keyvisualizer/keyvissettings"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/hotkeys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/util/container/ring"
@@ -47,6 +48,14 @@ func (t *statsBucket) ID() uintptr {
 	return t.id
 }
 
+const (
+	// hotKeysSampleRate is the fraction of point requests whose keys are
+	// recorded to estimate the hottest keys.
+	hotKeysSampleRate = 0.01
+	// hotKeysPerSample is the number of hot keys reported in each sample.
+	hotKeysPerSample = 10
+)
+
 type boundaryUpdate struct {
 	boundaries    []roachpb.Span
 	scheduledTime time.Time
@@ -57,7 +66,9 @@ type SpanStatsCollector struct {
 	// tree is an interval.Tree whose buckets are of type statsBucket.
 	tree     atomic.Value
 	settings *cluster.Settings
-	mu       struct {
+	// hotKeys estimates the hottest keys accessed by point requests.
+	hotKeys *hotkeys.Finder
+	mu      struct {
 		syncutil.Mutex
 		// A queue maintains boundary updates that should be applied in the
 		// future.
@@ -78,6 +89,8 @@ func New(settings *cluster.Settings) *SpanStatsCollector {
 	collector := &SpanStatsCollector{}
 	collector.tree.Store(newTreeWithBoundaries(nil))
 	collector.settings = settings
+	collector.hotKeys = hotkeys.NewFinder(
+		hotkeys.DefaultCapacity, hotkeys.DefaultWindow, timeutil.DefaultTimeSource{})
 	collector.mu.r = ring.New[keyvispb.Sample](5) // Keep the 5 most recent samples.
 	return collector
 }
@@ -166,8 +179,12 @@ func (s *SpanStatsCollector) getStats() []keyvispb.SpanStats {
 // Increment adds 1 to the counter that counts requests for this
 // span. If the span does not fall within the previously saved boundaries,
 // this is a no-op. If boundaries have not yet been installed,
-// this function returns false.
+// this function returns false. The keys of point requests are also sampled
+// to estimate the hottest keys.
 func (s *SpanStatsCollector) Increment(sp roachpb.Span) {
+	if len(sp.EndKey) == 0 {
+		s.hotKeys.Record(sp.Key, hotKeysSampleRate)
+	}
 	s.getTree().DoMatching(func(i interval.Interface) (done bool) {
 		bucket := i.(*statsBucket)
 		bucket.counter.Add(1)
@@ -284,6 +301,11 @@ func (s *SpanStatsCollector) reset() {
 
 func (s *SpanStatsCollector) rolloverSample(sampleTime time.Time) {
 	stats := s.getStats()
+	loads := s.hotKeys.HotKeys(hotKeysPerSample)
+	hotKeys := make([]keyvispb.HotKey, len(loads))
+	for i, l := range loads {
+		hotKeys[i] = keyvispb.HotKey{Key: l.Key, QPS: l.QPS}
+	}
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -293,6 +315,7 @@ func (s *SpanStatsCollector) rolloverSample(sampleTime time.Time) {
 	s.mu.r.Value = keyvispb.Sample{
 		SampleTime: sampleTime,
 		SpanStats:  stats,
+		HotKeys:    hotKeys,
 	}
 
 	// Advance the ring buffer.
@@ -15,6 +15,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keyvisualizer/keyvissettings"
 	"github.com/cockroachdb/cockroach/pkg/keyvisualizer/keyvisstorage"
 	"github.com/cockroachdb/cockroach/pkg/keyvisualizer/spanstatskvaccessor"
@@ -73,7 +74,16 @@ func (s *SpanStatsConsumer) GetSamples(ctx context.Context) error {
 		return err
 	}
 
-	return keyvisstorage.WriteSamples(ctx, s.ie, samplesRes.Samples)
+	// Older versions don't expect the hot keys that are stored alongside the
+	// samples' buckets.
+	if !s.settings.Version.IsActive(ctx, clusterversion.V24_1_HotKeys) {
+		for i := range samplesRes.Samples {
+			samplesRes.Samples[i].HotKeys = nil
+		}
+	}
+
+	return keyvisstorage.WriteSamples(ctx, s.ie, samplesRes.Samples,
+		keyvissettings.SampleInterval.Get(&s.settings.SV))
 }
 
 // maybeAggregateBoundaries aggregates boundaries if len(boundaries) <= max.
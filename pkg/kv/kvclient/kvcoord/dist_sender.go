@@ -152,6 +152,12 @@ var (
 		Measurement: "Errors",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaDistSenderKeyRateLimitedBackoffsCount = metric.Metadata{
+		Name:        "distsender.errors.keyratelimitedbackoffs",
+		Help:        "Number of times backed off due to KeyRateLimitedErrors from requests to hot keys",
+		Measurement: "Errors",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaDistSenderRangeLookups = metric.Metadata{
 		Name:        "distsender.rangelookups",
 		Help:        "Number of range lookups",
@@ -219,6 +225,18 @@ errors as 'roachpb.InternalErrType'.
 		Measurement: "RPCs",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaDistSenderHedgedReadCount = metric.Metadata{
+		Name:        "distsender.rpc.hedged.sent",
+		Help:        "Number of reads that were hedged to another replica after the first replica was slow to respond.",
+		Measurement: "RPCs",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaDistSenderHedgedReadWinCount = metric.Metadata{
+		Name:        "distsender.rpc.hedged.won",
+		Help:        "Number of hedged reads for which the response of the hedge was used.",
+		Measurement: "RPCs",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaDistSenderProxyForwardErrCount = metric.Metadata{
 		Name:        "distsender.rpc.proxy.forward.err",
 		Help:        "Number of attempts on a follower replica to proxy a request which resulted in a failure.",
@@ -400,6 +418,7 @@ type DistSenderMetrics struct {
 	NextReplicaErrCount                *metric.Counter
 	NotLeaseHolderErrCount             *metric.Counter
 	InLeaseTransferBackoffs            *metric.Counter
+	KeyRateLimitedBackoffs             *metric.Counter
 	RangeLookups                       *metric.Counter
 	SlowRPCs                           *metric.Gauge
 	SlowReplicaRPCs                    *metric.Counter
@@ -407,6 +426,8 @@ type DistSenderMetrics struct {
 	ProxyErrCount                      *metric.Counter
 	ProxyForwardSentCount              *metric.Counter
 	ProxyForwardErrCount               *metric.Counter
+	HedgedReadCount                    *metric.Counter
+	HedgedReadWinCount                 *metric.Counter
 	MethodCounts                       [kvpb.NumMethods]*metric.Counter
 	ErrCounts                          [kvpb.NumErrors]*metric.Counter
 	CircuitBreaker                     DistSenderCircuitBreakerMetrics
@@ -452,6 +473,7 @@ func MakeDistSenderMetrics() DistSenderMetrics {
 		NextReplicaErrCount:                metric.NewCounter(metaTransportSenderNextReplicaErrCount),
 		NotLeaseHolderErrCount:             metric.NewCounter(metaDistSenderNotLeaseHolderErrCount),
 		InLeaseTransferBackoffs:            metric.NewCounter(metaDistSenderInLeaseTransferBackoffsCount),
+		KeyRateLimitedBackoffs:             metric.NewCounter(metaDistSenderKeyRateLimitedBackoffsCount),
 		RangeLookups:                       metric.NewCounter(metaDistSenderRangeLookups),
 		SlowRPCs:                           metric.NewGauge(metaDistSenderSlowRPCs),
 		SlowReplicaRPCs:                    metric.NewCounter(metaDistSenderSlowReplicaRPCs),
@@ -460,6 +482,8 @@ func MakeDistSenderMetrics() DistSenderMetrics {
 		ProxyErrCount:                      metric.NewCounter(metaDistSenderProxyErrCount),
 		ProxyForwardSentCount:              metric.NewCounter(metaDistSenderProxyForwardSentCount),
 		ProxyForwardErrCount:               metric.NewCounter(metaDistSenderProxyForwardErrCount),
+		HedgedReadCount:                    metric.NewCounter(metaDistSenderHedgedReadCount),
+		HedgedReadWinCount:                 metric.NewCounter(metaDistSenderHedgedReadWinCount),
 		DistSenderRangeFeedMetrics:         makeDistSenderRangeFeedMetrics(),
 	}
 	for i := range m.MethodCounts {
@@ -2422,6 +2446,15 @@ const slowDistSenderReplicaThreshold = 10 * time.Second
 // value when populating the batch header.
 const defaultSendClosedTimestampPolicy = roachpb.LEAD_FOR_GLOBAL_READS
 
+// keyRateLimitedRetryOptions are used to back off requests that are rejected
+// with a KeyRateLimitedError, before the error is returned to the client.
+var keyRateLimitedRetryOptions = retry.Options{
+	InitialBackoff: 10 * time.Millisecond,
+	MaxBackoff:     time.Second,
+	Multiplier:     2,
+	MaxRetries:     5,
+}
+
 // sendToReplicas sends a batch to the replicas of a range. Replicas are tried one
 // at a time (generally the leaseholder first). The result of this call is
 // either a BatchResponse or an error. In the former case, the BatchResponse
@@ -2585,6 +2618,10 @@ func (ds *DistSender) sendToReplicas(
 	// rethink this backoff policy.
 	inTransferRetry := retry.StartWithCtx(ctx, ds.rpcRetryOptions)
 	inTransferRetry.Next() // The first call to Next does not block.
+	// rateLimitedRetry backs off requests rejected because they access a hot
+	// key whose request rate exceeds its limit.
+	rateLimitedRetry := retry.StartWithCtx(ctx, keyRateLimitedRetryOptions)
+	rateLimitedRetry.Next() // The first call to Next does not block.
 	var sameReplicaRetries int
 	var prevReplica roachpb.ReplicaDescriptor
 
@@ -2733,9 +2770,25 @@ func (ds *DistSender) sendToReplicas(
 			err = cbErr
 			transport.SkipReplica()
 		} else {
-			br, err = transport.SendNext(sendCtx, requestToSend)
+			// Reads that may be served by any replica are hedged to the next
+			// closest replica if the first one is slow to respond.
+			var hedge *ReplicaInfo
+			if first && HedgedReadsEnabled.Get(&ds.st.SV) && shouldHedge(requestToSend, withCommit) {
+				for i := range replicas {
+					if replicas[i].ReplicaID != curReplica.ReplicaID {
+						hedge = &replicas[i]
+						break
+					}
+				}
+			}
+			res, primary := ds.sendNextMaybeHedged(sendCtx, transport, opts, desc, requestToSend, hedge)
+			br, err = res.br, res.err
 			tEnd := timeutil.Now()
-			if cancelErr := cbToken.Done(br, err, tEnd.UnixNano()); cancelErr != nil {
+			// Report the result of the request sent to curReplica to its circuit
+			// breaker. If the hedge won, the request to curReplica was typically
+			// cancelled, which the breaker treats as an unknown outcome rather
+			// than a success.
+			if cancelErr := cbToken.Done(primary.br, primary.err, tEnd.UnixNano()); cancelErr != nil && !res.hedge {
 				// The request was cancelled by the circuit breaker tripping. If this is
 				// detected by request evaluation (as opposed to the transport send), it
 				// will return the context error in br.Error instead of err, which won't
@@ -2743,6 +2796,12 @@ func (ds *DistSender) sendToReplicas(
 				// when possible. This commonly happens when the replica is local.
 				br, err = nil, cancelErr
 			}
+			if res.hedge {
+				// The response came from the hedge replica, attribute it to it.
+				log.VEventf(ctx, 2, "hedged read to %s won over %s", hedge.ReplicaDescriptor, curReplica)
+				curReplica = hedge.ReplicaDescriptor
+				comparisonResult = ds.getLocalityComparison(ctx, ds.nodeIDGetter(), curReplica.NodeID)
+			}
 
 			if dur := tEnd.Sub(tBegin); dur > slowDistSenderReplicaThreshold {
 				var s redact.StringBuilder
@@ -3086,6 +3145,22 @@ func (ds *DistSender) sendToReplicas(
 					}
 					inTransferRetry.Next()
 				}
+			case *kvpb.KeyRateLimitedError:
+				// The replica rejected the request before evaluating it, because it
+				// accesses a hot key whose request rate exceeds the limit. Back off
+				// and retry the same replica, which sheds load from the hot key
+				// without restarting the transaction. After a few attempts, return
+				// the error, which restarts the transaction.
+				if ambiguousError != nil {
+					return nil, kvpb.NewAmbiguousResultErrorf("error=%v [propagate] (last error: %v)",
+						ambiguousError, br.Error.GoError())
+				}
+				if !rateLimitedRetry.Next() {
+					return br, nil
+				}
+				log.VErrEventf(ctx, 2, "backing off rate limited request to hot key %s", tErr.Key)
+				ds.metrics.KeyRateLimitedBackoffs.Inc(1)
+				transport.MoveToFront(curReplica)
 			default:
 				if ambiguousError != nil {
 					return nil, kvpb.NewAmbiguousResultErrorf("error=%v [propagate] (last error: %v)",
@@ -0,0 +1,200 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvcoord
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// HedgedReadsEnabled controls whether the DistSender hedges reads that may be
+// served by any replica. If the replica a read is sent to doesn't respond
+// within the hedging delay, a duplicate of the read is sent to the next
+// closest replica and the first successful response is used.
+var HedgedReadsEnabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"kv.dist_sender.hedged_reads.enabled",
+	"when true, follower reads and bounded staleness reads that are slow to "+
+		"respond are duplicated to another replica, using the first response",
+	false,
+	settings.WithPublic,
+)
+
+// hedgedReadsLatencyMultiplier is the multiple of the round-trip latency to a
+// node after which reads sent to it are hedged.
+var hedgedReadsLatencyMultiplier = settings.RegisterFloatSetting(
+	settings.ApplicationLevel,
+	"kv.dist_sender.hedged_reads.latency_multiplier",
+	"multiple of the measured round-trip latency to a node after which a read "+
+		"sent to it is hedged to another replica",
+	3,
+	settings.FloatWithMinimum(1),
+)
+
+// hedgedReadsMinDelay is the minimum delay before a read is hedged,
+// regardless of the observed latencies. It accounts for the time spent
+// evaluating the read, which the round-trip latency doesn't include.
+var hedgedReadsMinDelay = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"kv.dist_sender.hedged_reads.min_delay",
+	"minimum delay before a read is hedged to another replica",
+	5*time.Millisecond,
+	settings.NonNegativeDuration,
+)
+
+// shouldHedge returns whether the batch is eligible for hedging. Only reads
+// that may be served by any replica, i.e. follower reads and bounded staleness
+// reads, are hedged: they are idempotent and don't depend on the leaseholder.
+func shouldHedge(ba *kvpb.BatchRequest, withCommit bool) bool {
+	return ba.RoutingPolicy == kvpb.RoutingPolicy_NEAREST &&
+		ba.IsReadOnly() && !ba.IsLocking() && !withCommit && ba.ProxyRangeInfo == nil
+}
+
+// hedgeDelay returns the delay after which a read sent to the given node is
+// hedged, and false if the read should not be hedged. The delay is derived
+// from the round-trip latency to the node measured by the RPC heartbeats, see
+// rpc.RemoteClockMonitor.
+func (ds *DistSender) hedgeDelay(nodeID roachpb.NodeID) (time.Duration, bool) {
+	latency, ok := ds.latencyFunc(nodeID)
+	if !ok {
+		return 0, false
+	}
+	delay := time.Duration(float64(latency) * hedgedReadsLatencyMultiplier.Get(&ds.st.SV))
+	return max(delay, hedgedReadsMinDelay.Get(&ds.st.SV)), true
+}
+
+type hedgedSendResult struct {
+	br    *kvpb.BatchResponse
+	err   error
+	hedge bool
+}
+
+func (r hedgedSendResult) succeeded() bool {
+	return r.err == nil && r.br.Error == nil
+}
+
+// sendNextMaybeHedged sends the batch to the next replica of the transport,
+// like Transport.SendNext. If hedge is non-nil and the replica doesn't respond
+// within the hedging delay of its node, a duplicate of the batch is sent to
+// hedge through a separate transport. The first successful response wins and
+// the other request is cancelled. If neither request succeeds, the result of
+// the request sent through the transport is returned, so that the caller
+// handles it as if the batch had not been hedged.
+//
+// The first return value is the result that the caller should use, and the
+// second is the result of the request sent through the transport, which is
+// what the caller should report to the replica's circuit breaker. They differ
+// when the hedge won, in which case the first result has hedge set and the
+// request to the slow replica was typically cancelled before it responded.
+// The hedge itself is tracked by the circuit breaker of its replica.
+func (ds *DistSender) sendNextMaybeHedged(
+	ctx context.Context,
+	transport Transport,
+	opts SendOptions,
+	desc *roachpb.RangeDescriptor,
+	ba *kvpb.BatchRequest,
+	hedge *ReplicaInfo,
+) (res, primary hedgedSendResult) {
+	sendNext := func(ctx context.Context) hedgedSendResult {
+		br, err := transport.SendNext(ctx, ba)
+		return hedgedSendResult{br: br, err: err}
+	}
+	if hedge == nil {
+		res = sendNext(ctx)
+		return res, res
+	}
+	delay, ok := ds.hedgeDelay(ba.Replica.NodeID)
+	if !ok {
+		// No latency measurements for the node yet.
+		res = sendNext(ctx)
+		return res, res
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// The channel is buffered so that the losing request never blocks.
+	resC := make(chan hedgedSendResult, 2)
+	if err := ds.stopper.RunAsyncTask(ctx, "kv.DistSender: hedged read", func(ctx context.Context) {
+		resC <- sendNext(ctx)
+	}); err != nil {
+		res = sendNext(ctx)
+		return res, res
+	}
+	inFlight := 1
+
+	var timer timeutil.Timer
+	defer timer.Stop()
+	timer.Reset(delay)
+
+	for {
+		select {
+		case <-timer.C:
+			timer.Read = true
+			hedgeBa := ba.ShallowCopy()
+			hedgeBa.Replica = hedge.ReplicaDescriptor
+			hedgeCtx, cbToken, cbErr := ds.circuitBreakers.ForReplica(desc, &hedge.ReplicaDescriptor).
+				Track(ctx, hedgeBa, timeutil.Now().UnixNano())
+			if cbErr != nil {
+				// The hedge replica's circuit breaker is tripped, don't hedge.
+				log.VErrEventf(ctx, 2, "not hedging read to %s: %s", hedge.ReplicaDescriptor, cbErr)
+				continue
+			}
+			hedgeTransport := ds.transportFactory(opts, ReplicaSlice{*hedge})
+			if err := ds.stopper.RunAsyncTask(hedgeCtx, "kv.DistSender: hedged read", func(ctx context.Context) {
+				defer hedgeTransport.Release()
+				br, err := hedgeTransport.SendNext(ctx, hedgeBa)
+				if cancelErr := cbToken.Done(br, err, timeutil.Now().UnixNano()); cancelErr != nil {
+					br, err = nil, cancelErr
+				}
+				resC <- hedgedSendResult{br: br, err: err, hedge: true}
+			}); err != nil {
+				_ = cbToken.Done(nil, err, timeutil.Now().UnixNano())
+				hedgeTransport.Release()
+				continue
+			}
+			log.VEventf(ctx, 2, "hedged read to %s after %s", hedge.ReplicaDescriptor, delay)
+			ds.metrics.HedgedReadCount.Inc(1)
+			inFlight++
+
+		case r := <-resC:
+			inFlight--
+			if !r.hedge {
+				primary = r
+			}
+			if r.succeeded() {
+				if r.hedge {
+					ds.metrics.HedgedReadWinCount.Inc(1)
+				}
+				// Cancel the other request and wait for it, since the transport
+				// may not be used concurrently with the caller.
+				cancel()
+				for ; inFlight > 0; inFlight-- {
+					if other := <-resC; !other.hedge {
+						primary = other
+					}
+				}
+				return r, primary
+			}
+			// If the primary request failed before the read was hedged, or
+			// both requests failed, fall back to the caller's retry logic.
+			// Otherwise, wait for the request that is still in flight.
+			if inFlight == 0 {
+				return primary, primary
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvcoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHedgeDelay(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	hedgedReadsLatencyMultiplier.Override(ctx, &st.SV, 3)
+	hedgedReadsMinDelay.Override(ctx, &st.SV, 5*time.Millisecond)
+	ds := &DistSender{
+		st: st,
+		latencyFunc: func(nodeID roachpb.NodeID) (time.Duration, bool) {
+			switch nodeID {
+			case 1:
+				return time.Millisecond, true
+			case 2:
+				return 10 * time.Millisecond, true
+			default:
+				return 0, false
+			}
+		},
+	}
+
+	// The delay is a multiple of the measured latency, but no less than the
+	// minimum delay.
+	delay, ok := ds.hedgeDelay(1)
+	require.True(t, ok)
+	require.Equal(t, 5*time.Millisecond, delay)
+	delay, ok = ds.hedgeDelay(2)
+	require.True(t, ok)
+	require.Equal(t, 30*time.Millisecond, delay)
+
+	// Reads to nodes without latency measurements aren't hedged.
+	_, ok = ds.hedgeDelay(3)
+	require.False(t, ok)
+}
+
+func TestShouldHedge(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	get := &kvpb.GetRequest{RequestHeader: kvpb.RequestHeader{Key: roachpb.Key("a")}}
+	put := &kvpb.PutRequest{RequestHeader: kvpb.RequestHeader{Key: roachpb.Key("a")}}
+	makeBa := func(policy kvpb.RoutingPolicy, reqs ...kvpb.Request) *kvpb.BatchRequest {
+		ba := &kvpb.BatchRequest{}
+		ba.RoutingPolicy = policy
+		ba.Add(reqs...)
+		return ba
+	}
+
+	require.True(t, shouldHedge(makeBa(kvpb.RoutingPolicy_NEAREST, get), false))
+	require.False(t, shouldHedge(makeBa(kvpb.RoutingPolicy_NEAREST, get), true))
+	require.False(t, shouldHedge(makeBa(kvpb.RoutingPolicy_LEASEHOLDER, get), false))
+	require.False(t, shouldHedge(makeBa(kvpb.RoutingPolicy_NEAREST, put), false))
+	proxied := makeBa(kvpb.RoutingPolicy_NEAREST, get)
+	proxied.ProxyRangeInfo = &roachpb.RangeInfo{}
+	require.False(t, shouldHedge(proxied, false))
+}
+
+// TestSendNextMaybeHedged tests that a read sent to a slow replica is hedged
+// to another replica, whose response is used.
+func TestSendNextMaybeHedged(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+
+	replicas := ReplicaSlice{
+		{ReplicaDescriptor: roachpb.ReplicaDescriptor{NodeID: 1, StoreID: 1, ReplicaID: 1}},
+		{ReplicaDescriptor: roachpb.ReplicaDescriptor{NodeID: 2, StoreID: 2, ReplicaID: 2}},
+	}
+	// The first replica blocks until its request is cancelled.
+	sendFn := func(ctx context.Context, ba *kvpb.BatchRequest) (*kvpb.BatchResponse, error) {
+		if ba.Replica.NodeID == 1 {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		br := ba.CreateReply()
+		br.Now = hlc.Timestamp{WallTime: int64(ba.Replica.NodeID)}
+		return br, nil
+	}
+	st := cluster.MakeTestingClusterSettings()
+	hedgedReadsMinDelay.Override(ctx, &st.SV, time.Millisecond)
+	metrics := MakeDistSenderMetrics()
+	transportFactory := adaptSimpleTransport(sendFn)
+	ds := &DistSender{
+		st:               st,
+		stopper:          stopper,
+		metrics:          metrics,
+		transportFactory: transportFactory,
+		circuitBreakers: NewDistSenderCircuitBreakers(
+			log.MakeTestingAmbientCtxWithNewTracer(), stopper, st, transportFactory, metrics),
+		latencyFunc: func(roachpb.NodeID) (time.Duration, bool) {
+			return time.Millisecond, true
+		},
+	}
+	desc := &roachpb.RangeDescriptor{RangeID: 1}
+
+	ba := &kvpb.BatchRequest{}
+	ba.RoutingPolicy = kvpb.RoutingPolicy_NEAREST
+	ba.Replica = replicas[0].ReplicaDescriptor
+	ba.Add(&kvpb.GetRequest{RequestHeader: kvpb.RequestHeader{Key: roachpb.Key("a")}})
+
+	transport := ds.transportFactory(SendOptions{}, replicas)
+	res, primary := ds.sendNextMaybeHedged(ctx, transport, SendOptions{}, desc, ba, &replicas[1])
+	require.NoError(t, res.err)
+	require.True(t, res.hedge)
+	require.Equal(t, int64(2), res.br.Now.WallTime)
+	require.Equal(t, int64(1), ds.metrics.HedgedReadCount.Count())
+	require.Equal(t, int64(1), ds.metrics.HedgedReadWinCount.Count())
+	// The request to the slow replica was cancelled, and is reported as such
+	// rather than as a success.
+	require.False(t, primary.hedge)
+	require.ErrorIs(t, primary.err, context.Canceled)
+	// The transport is left positioned after the primary replica.
+	require.Equal(t, replicas[1].ReplicaDescriptor, transport.NextReplica())
+
+	// Without a hedge replica, the batch is sent through the transport as is.
+	res, primary = ds.sendNextMaybeHedged(ctx, transport, SendOptions{}, desc, ba, nil)
+	require.NoError(t, res.err)
+	require.False(t, res.hedge)
+	require.Equal(t, res, primary)
+	require.Equal(t, int64(2), res.br.Now.WallTime)
+	require.Equal(t, int64(1), ds.metrics.HedgedReadCount.Count())
+}
@@ -15,6 +15,7 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"math/rand"
 	"reflect"
 	"regexp"
@@ -5824,6 +5825,85 @@ func TestDistSenderRPCMetrics(t *testing.T) {
 	require.Equal(t, ds.metrics.ErrCounts[kvpb.ConditionFailedErrType].Count(), int64(1))
 }
 
+// TestDistSenderBacksOffKeyRateLimitedErrors tests that requests rejected with
+// a KeyRateLimitedError are retried against the same replica after a backoff,
+// and that the error is returned once the retries are exhausted.
+func TestDistSenderBacksOffKeyRateLimitedErrors(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+
+	clock := hlc.NewClockForTesting(nil)
+	ns := &mockNodeStore{nodes: []roachpb.NodeDescriptor{
+		{NodeID: 1, Address: util.UnresolvedAddr{}},
+		{NodeID: 2, Address: util.UnresolvedAddr{}},
+	}}
+	desc := roachpb.RangeDescriptor{
+		RangeID:    roachpb.RangeID(1),
+		Generation: 1,
+		StartKey:   roachpb.RKeyMin,
+		EndKey:     roachpb.RKeyMax,
+		InternalReplicas: []roachpb.ReplicaDescriptor{
+			{NodeID: 1, StoreID: 1, ReplicaID: 1},
+			{NodeID: 2, StoreID: 2, ReplicaID: 2},
+		},
+	}
+
+	for _, tc := range []struct {
+		rejections int
+		expErr     bool
+	}{
+		{rejections: 2},
+		{rejections: math.MaxInt, expErr: true},
+	} {
+		t.Run(fmt.Sprintf("rejections=%d", tc.rejections), func(t *testing.T) {
+			var calls int
+			transportFn := func(_ context.Context, ba *kvpb.BatchRequest) (*kvpb.BatchResponse, error) {
+				require.Equal(t, desc.InternalReplicas[0], ba.Replica)
+				calls++
+				br := ba.CreateReply()
+				if calls <= tc.rejections {
+					br.Error = kvpb.NewError(kvpb.NewKeyRateLimitedError(roachpb.Key("a"), 10))
+				}
+				return br, nil
+			}
+			cfg := DistSenderConfig{
+				AmbientCtx: log.MakeTestingAmbientCtxWithNewTracer(),
+				Clock:      clock,
+				NodeDescs:  ns,
+				Stopper:    stopper,
+				RangeDescriptorDB: MockRangeDescriptorDB(func(key roachpb.RKey, reverse bool) (
+					[]roachpb.RangeDescriptor, []roachpb.RangeDescriptor, error,
+				) {
+					return nil, nil, errors.New("range desc db unexpectedly used")
+				}),
+				TransportFactory: adaptSimpleTransport(transportFn),
+				Settings:         cluster.MakeTestingClusterSettings(),
+			}
+			ds := NewDistSender(cfg)
+			ds.rangeCache.Insert(ctx, roachpb.RangeInfo{
+				Desc:  desc,
+				Lease: roachpb.Lease{Replica: desc.InternalReplicas[0]},
+			})
+
+			ba := &kvpb.BatchRequest{}
+			ba.Add(kvpb.NewGet(roachpb.Key("a")))
+			_, pErr := ds.Send(ctx, ba)
+			if tc.expErr {
+				require.NotNil(t, pErr)
+				require.IsType(t, &kvpb.KeyRateLimitedError{}, pErr.GetDetail())
+				require.Equal(t, keyRateLimitedRetryOptions.MaxRetries+1, calls)
+			} else {
+				require.Nil(t, pErr)
+				require.Equal(t, tc.rejections+1, calls)
+			}
+			require.Equal(t, int64(calls-1), ds.metrics.KeyRateLimitedBackoffs.Count())
+		})
+	}
+}
+
 // TestDistSenderCrossLocalityMetrics verifies that
 // updateCrossLocalityMetricsOnReplicaAddressedBatch{Request|Response} correctly
 // updates cross-region, cross-zone byte count metrics for batch requests sent
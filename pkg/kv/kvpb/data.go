@@ -108,6 +108,10 @@ func PrepareTransactionForRetry(
 	case *WriteTooOldError:
 		// Increase the timestamp to the ts at which we've actually written.
 		txn.WriteTimestamp.Forward(tErr.RetryTimestamp())
+	case *KeyRateLimitedError:
+		// The request was rejected before evaluation, so the transaction is
+		// restarted at its current timestamp. The DistSender already backed off
+		// before returning the error.
 	case *IntentMissingError:
 		// IntentMissingErrors are not expected to be handled at this level;
 		// We instead expect the txnPipeliner to transform them into a
@@ -300,6 +300,7 @@ const (
 	LockConflictErrType                     ErrorDetailType = 45
 	ReplicaUnavailableErrType               ErrorDetailType = 46
 	ProxyFailedErrType                      ErrorDetailType = 47
+	KeyRateLimitedErrType                   ErrorDetailType = 48
 	// When adding new error types, don't forget to update NumErrors below.
 
 	// CommunicationErrType indicates a gRPC error; this is not an ErrorDetail.
@@ -309,7 +310,7 @@ const (
 	// detail. The value 25 is chosen because it's reserved in the errors proto.
 	InternalErrType ErrorDetailType = 25
 
-	NumErrors int = 48
+	NumErrors int = 49
 )
 
 // Register the migration of all errors that used to be in the roachpb package
@@ -1762,6 +1763,32 @@ func init() {
 	errors.RegisterWrapperDecoder(typeName, decode)
 }
 
+// NewKeyRateLimitedError initializes a new KeyRateLimitedError.
+func NewKeyRateLimitedError(key roachpb.Key, maxQPSPerKey int32) *KeyRateLimitedError {
+	return &KeyRateLimitedError{Key: key, MaxQPSPerKey: maxQPSPerKey}
+}
+
+func (e *KeyRateLimitedError) Error() string {
+	return redact.Sprint(e).StripMarkers()
+}
+
+func (e *KeyRateLimitedError) SafeFormatError(p errors.Printer) (next error) {
+	p.Printf("request rate to key %s exceeds the limit of %d per second", e.Key, e.MaxQPSPerKey)
+	return nil
+}
+
+// Type is part of the ErrorDetailInterface.
+func (e *KeyRateLimitedError) Type() ErrorDetailType {
+	return KeyRateLimitedErrType
+}
+
+func (*KeyRateLimitedError) canRestartTransaction() TransactionRestart {
+	return TransactionRestart_IMMEDIATE
+}
+
+var _ ErrorDetailInterface = &KeyRateLimitedError{}
+var _ transactionRestartError = &KeyRateLimitedError{}
+
 func init() {
 	errors.RegisterLeafDecoder(errors.GetTypeKey((*MissingRecordError)(nil)), func(_ context.Context, _ string, _ []string, _ proto.Message) error {
 		return &MissingRecordError{}
@@ -1807,3 +1834,4 @@ var _ errors.SafeFormatter = &MVCCHistoryMutationError{}
 var _ errors.SafeFormatter = &UnhandledRetryableError{}
 var _ errors.SafeFormatter = &ReplicaUnavailableError{}
 var _ errors.SafeFormatter = &ProxyFailedError{}
+var _ errors.SafeFormatter = &KeyRateLimitedError{}
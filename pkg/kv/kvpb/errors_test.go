@@ -329,6 +329,10 @@ func TestErrorRedaction(t *testing.T) {
 			err:    &MVCCHistoryMutationError{},
 			expect: "unexpected MVCC history mutation in span ‹/Min›",
 		},
+		{
+			err:    &KeyRateLimitedError{Key: roachpb.Key("a"), MaxQPSPerKey: 10},
+			expect: "request rate to key \"a\" exceeds the limit of 10 per second",
+		},
 		{
 			err:    &UnhandledRetryableError{},
 			expect: "{<nil> 0 {<nil>} ‹<nil>› 0,0}",
@@ -141,6 +141,8 @@ const (
 	AllocatorConsiderRebalance
 	AllocatorRangeUnavailable
 	AllocatorFinalizeAtomicReplicationChange
+	AllocatorAddWitness
+	AllocatorRemoveWitness
 )
 
 // Add indicates an action adding a replica.
@@ -183,6 +185,9 @@ func (a AllocatorAction) TargetReplicaType() TargetReplicaType {
 		a == AllocatorReplaceDecommissioningNonVoter ||
 		a == AllocatorRemoveDecommissioningNonVoter {
 		t = NonVoterTarget
+	} else if a == AllocatorAddWitness ||
+		a == AllocatorRemoveWitness {
+		t = WitnessTarget
 	}
 	return t
 }
@@ -194,7 +199,9 @@ func (a AllocatorAction) ReplicaStatus() ReplicaStatus {
 	if a == AllocatorRemoveVoter ||
 		a == AllocatorRemoveNonVoter ||
 		a == AllocatorAddVoter ||
-		a == AllocatorAddNonVoter {
+		a == AllocatorAddNonVoter ||
+		a == AllocatorAddWitness ||
+		a == AllocatorRemoveWitness {
 		s = Alive
 	} else if a == AllocatorReplaceDeadVoter ||
 		a == AllocatorReplaceDeadNonVoter ||
@@ -228,6 +235,8 @@ var allocatorActionNames = map[AllocatorAction]string{
 	AllocatorConsiderRebalance:               "consider rebalance",
 	AllocatorRangeUnavailable:                "range unavailable",
 	AllocatorFinalizeAtomicReplicationChange: "finalize conf change",
+	AllocatorAddWitness:                      "add witness",
+	AllocatorRemoveWitness:                   "remove witness",
 }
 
 func (a AllocatorAction) String() string {
@@ -263,6 +272,8 @@ func (a AllocatorAction) Priority() float64 {
 		return 900
 	case AllocatorRemoveVoter:
 		return 800
+	case AllocatorAddWitness:
+		return 750
 	case AllocatorReplaceDeadNonVoter:
 		return 700
 	case AllocatorAddNonVoter:
@@ -273,6 +284,8 @@ func (a AllocatorAction) Priority() float64 {
 		return 400
 	case AllocatorRemoveDecommissioningNonVoter:
 		return 300
+	case AllocatorRemoveWitness:
+		return 250
 	case AllocatorRemoveNonVoter:
 		return 200
 	case AllocatorConsiderRebalance, AllocatorRangeUnavailable, AllocatorNoop:
@@ -282,8 +295,8 @@ func (a AllocatorAction) Priority() float64 {
 	}
 }
 
-// TargetReplicaType indicates whether the target replica is a voter or
-// non-voter.
+// TargetReplicaType indicates whether the target replica is a voter, a
+// non-voter or a witness.
 type TargetReplicaType int
 
 const (
@@ -292,6 +305,8 @@ const (
 	VoterTarget
 	// NonVoterTarget represents a non-voting target replica.
 	NonVoterTarget
+	// WitnessTarget represents a witness target replica.
+	WitnessTarget
 )
 
 // ReplicaStatus represents whether a replica is currently alive,
@@ -319,6 +334,8 @@ func (t TargetReplicaType) AddChangeType() roachpb.ReplicaChangeType {
 		return roachpb.ADD_VOTER
 	case NonVoterTarget:
 		return roachpb.ADD_NON_VOTER
+	case WitnessTarget:
+		return roachpb.ADD_WITNESS
 	default:
 		panic(fmt.Sprintf("unknown targetReplicaType %d", t))
 	}
@@ -332,6 +349,8 @@ func (t TargetReplicaType) RemoveChangeType() roachpb.ReplicaChangeType {
 		return roachpb.REMOVE_VOTER
 	case NonVoterTarget:
 		return roachpb.REMOVE_NON_VOTER
+	case WitnessTarget:
+		return roachpb.REMOVE_WITNESS
 	default:
 		panic(fmt.Sprintf("unknown targetReplicaType %d", t))
 	}
@@ -343,6 +362,8 @@ func (t TargetReplicaType) String() string {
 		return "voter"
 	case NonVoterTarget:
 		return "non-voter"
+	case WitnessTarget:
+		return "witness"
 	default:
 		panic(fmt.Sprintf("unknown targetReplicaType %d", t))
 	}
@@ -946,7 +967,7 @@ func (a *Allocator) ComputeAction(
 	}
 
 	return a.computeAction(ctx, storePool, conf, desc.Replicas().VoterDescriptors(),
-		desc.Replicas().NonVoterDescriptors())
+		desc.Replicas().NonVoterDescriptors(), desc.Replicas().WitnessDescriptors())
 }
 
 func (a *Allocator) computeAction(
@@ -955,6 +976,7 @@ func (a *Allocator) computeAction(
 	conf *roachpb.SpanConfig,
 	voterReplicas []roachpb.ReplicaDescriptor,
 	nonVoterReplicas []roachpb.ReplicaDescriptor,
+	witnessReplicas []roachpb.ReplicaDescriptor,
 ) (action AllocatorAction, adjustedPriority float64) {
 	// NB: The ordering of the checks in this method is intentional. The order in
 	// which these actions are returned by this method determines the relative
@@ -963,8 +985,9 @@ func (a *Allocator) computeAction(
 	// (which influence the replicateQueue's decision of which range it'll pick to
 	// repair/rebalance before the others).
 	//
-	// In broad strokes, we first handle all voting replica-based actions and then
-	// the actions pertaining to non-voting replicas. Within each replica set, we
+	// In broad strokes, we first handle all voting replica-based actions, then
+	// the actions pertaining to witnesses and finally the actions pertaining to
+	// non-voting replicas. Within each replica set, we
 	// first handle operations that correspond to repairing/recovering the range.
 	// After that we handle rebalancing related actions, followed by removal
 	// actions.
@@ -976,7 +999,9 @@ func (a *Allocator) computeAction(
 	clusterNodes := storePool.ClusterNodeCount()
 	neededVoters := GetNeededVoters(conf.GetNumVoters(), clusterNodes)
 	desiredQuorum := computeQuorum(neededVoters)
-	quorum := computeQuorum(haveVoters)
+	// Witnesses are part of the raft quorum, even though they are not counted as
+	// voters by the span config.
+	quorum := computeQuorum(haveVoters + len(witnessReplicas))
 
 	// TODO(aayush): When haveVoters < neededVoters but we don't have quorum to
 	// actually execute the addition of a new replica, we should be returning a
@@ -1000,15 +1025,16 @@ func (a *Allocator) computeAction(
 	// elsewhere (for a regular rebalance or for decommissioning).
 	const includeSuspectAndDrainingStores = true
 	liveVoters, deadVoters := storePool.LiveAndDeadReplicas(voterReplicas, includeSuspectAndDrainingStores)
+	liveWitnesses, _ := storePool.LiveAndDeadReplicas(witnessReplicas, includeSuspectAndDrainingStores)
 
-	if len(liveVoters) < quorum {
+	if len(liveVoters)+len(liveWitnesses) < quorum {
 		// Do not take any replacement/removal action if we do not have a quorum of
 		// live voters. If we're correctly assessing the unavailable state of the
 		// range, we also won't be able to add replicas as we try above, but hope
 		// springs eternal.
 		action = AllocatorRangeUnavailable
-		log.KvDistribution.VEventf(ctx, 1, "unable to take action - live voters %v don't meet quorum of %d",
-			liveVoters, quorum)
+		log.KvDistribution.VEventf(ctx, 1, "unable to take action - live voters %v and witnesses %v don't meet quorum of %d",
+			liveVoters, liveWitnesses, quorum)
 		return action, action.Priority()
 	}
 
@@ -1039,7 +1065,7 @@ func (a *Allocator) computeAction(
 	if len(deadVoters) > 0 {
 		// The range has dead replicas, which should be removed immediately.
 		action = AllocatorRemoveDeadVoter
-		adjustedPriority = action.Priority() + float64(quorum-len(liveVoters))
+		adjustedPriority = action.Priority() + float64(quorum-len(liveVoters)-len(liveWitnesses))
 		log.KvDistribution.VEventf(ctx, 3, "%s - dead=%d, live=%d, quorum=%d, priority=%.2f",
 			action, len(deadVoters), len(liveVoters), quorum, adjustedPriority)
 		return action, adjustedPriority
@@ -1066,6 +1092,28 @@ func (a *Allocator) computeAction(
 		return action, adjustedPriority
 	}
 
+	// Witness actions follow. Witnesses don't store any range data, so a witness
+	// on a dead or decommissioning store is replaced by adding a new witness
+	// before removing the old one, which is then over-replication.
+	// Nodes running older versions don't know about witnesses, so none are
+	// added until the cluster version allows it.
+	neededWitnesses := int(conf.NumWitnesses)
+	healthyWitnesses := len(liveWitnesses) - len(storePool.DecommissioningReplicas(liveWitnesses))
+	if healthyWitnesses < neededWitnesses &&
+		a.st.Version.IsActive(ctx, clusterversion.V24_1_WitnessReplicas) {
+		action = AllocatorAddWitness
+		log.KvDistribution.VEventf(ctx, 3, "%s - missing witness need=%d, have=%d, priority=%.2f",
+			action, neededWitnesses, healthyWitnesses, action.Priority())
+		return action, action.Priority()
+	}
+
+	if len(witnessReplicas) > neededWitnesses {
+		action = AllocatorRemoveWitness
+		log.KvDistribution.VEventf(ctx, 3, "%s - need=%d, have=%d, priority=%.2f", action,
+			neededWitnesses, len(witnessReplicas), action.Priority())
+		return action, action.Priority()
+	}
+
 	// Non-voting replica actions follow.
 	//
 	// Non-voting replica addition / replacement.
@@ -1162,7 +1210,7 @@ func getReplicasForDiversityCalc(
 		// off of all `existingReplicas`), regions A, B, and C would all be equally
 		// likely to get a new voting replica.
 		return existingVoters
-	case NonVoterTarget:
+	case NonVoterTarget, WitnessTarget:
 		return allExistingReplicas
 	default:
 		panic(fmt.Sprintf("unsupported targetReplicaType: %v", t))
@@ -1441,6 +1489,8 @@ func (a *Allocator) allocateTargetFromList(
 		} else {
 			constraintsChecker = nonVoterConstraintsCheckerForAllocation(analyzedOverallConstraints)
 		}
+	case WitnessTarget:
+		constraintsChecker = witnessConstraintsChecker()
 	default:
 		log.KvDistribution.Fatalf(ctx, "unsupported targetReplicaType: %v", t)
 	}
@@ -1582,6 +1632,8 @@ func (a Allocator) RemoveTarget(
 		)
 	case NonVoterTarget:
 		constraintsChecker = nonVoterConstraintsCheckerForRemoval(analyzedOverallConstraints)
+	case WitnessTarget:
+		constraintsChecker = witnessConstraintsChecker()
 	default:
 		log.KvDistribution.Fatalf(ctx, "unsupported targetReplicaType: %v", t)
 	}
@@ -1680,6 +1732,61 @@ func (a Allocator) RemoveNonVoter(
 	)
 }
 
+// AllocateWitness returns a suitable store for a new witness of the range.
+// Nodes accommodating any replica of the range are ruled out.
+func (a *Allocator) AllocateWitness(
+	ctx context.Context,
+	storePool storepool.AllocatorStorePool,
+	conf *roachpb.SpanConfig,
+	existingVoters, existingNonVoters, existingWitnesses []roachpb.ReplicaDescriptor,
+) (roachpb.ReplicationTarget, string, error) {
+	existingNonVoters = append(existingNonVoters[:len(existingNonVoters):len(existingNonVoters)],
+		existingWitnesses...)
+	return a.AllocateTarget(ctx, storePool, conf, existingVoters, existingNonVoters,
+		nil /* replacing */, Alive, WitnessTarget)
+}
+
+// RemoveWitness returns a witness of the range to remove. Witnesses on dead
+// stores are removed first, followed by those on decommissioning stores.
+// Otherwise, the witness whose removal least reduces the diversity of the
+// range is selected.
+func (a Allocator) RemoveWitness(
+	ctx context.Context,
+	storePool storepool.AllocatorStorePool,
+	conf *roachpb.SpanConfig,
+	existingVoters, existingNonVoters, existingWitnesses []roachpb.ReplicaDescriptor,
+	options ScorerOptions,
+) (roachpb.ReplicationTarget, string, error) {
+	_, deadWitnesses := storePool.LiveAndDeadReplicas(existingWitnesses, true /* includeSuspectAndDrainingStores */)
+	if len(deadWitnesses) == 0 {
+		deadWitnesses = storePool.DecommissioningReplicas(existingWitnesses)
+	}
+	if len(deadWitnesses) > 0 {
+		return roachpb.ReplicationTarget{
+			NodeID: deadWitnesses[0].NodeID, StoreID: deadWitnesses[0].StoreID,
+		}, "", nil
+	}
+
+	candidateStoreIDs := make(roachpb.StoreIDSlice, len(existingWitnesses))
+	for i, exist := range existingWitnesses {
+		candidateStoreIDs[i] = exist.StoreID
+	}
+	candidateStoreList, _, _ := storePool.GetStoreListFromIDs(candidateStoreIDs, storepool.StoreFilterNone)
+
+	existingNonVoters = append(existingNonVoters[:len(existingNonVoters):len(existingNonVoters)],
+		existingWitnesses...)
+	return a.RemoveTarget(
+		ctx,
+		storePool,
+		conf,
+		candidateStoreList,
+		existingVoters,
+		existingNonVoters,
+		WitnessTarget,
+		options,
+	)
+}
+
 // RebalanceTarget returns a suitable store for a rebalance target (of the given
 // type) with required attributes.
 func (a Allocator) RebalanceTarget(
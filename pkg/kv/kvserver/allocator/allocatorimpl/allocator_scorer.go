@@ -1925,6 +1925,15 @@ func nonVoterConstraintsCheckerForAllocation(
 	}
 }
 
+// witnessConstraintsChecker returns a constraintsCheckFn that considers every
+// store valid for a witness. Witnesses don't store any range data, so neither
+// `constraints` nor `voter_constraints` apply to them.
+func witnessConstraintsChecker() constraintsCheckFn {
+	return func(roachpb.StoreDescriptor) (valid, necessary bool) {
+		return true, false
+	}
+}
+
 // voterConstraintsCheckerForRemoval returns a constraintsCheckFn that
 // determines whether an existing voting replica is valid and/or necessary with
 // respect to the `constraints` and `voter_constraints` on the range.
@@ -6859,6 +6859,106 @@ func TestAllocatorComputeActionRemoveDead(t *testing.T) {
 	}
 }
 
+func TestAllocatorComputeActionWitness(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	withWitnesses := func(storeIDs ...roachpb.StoreID) roachpb.RangeDescriptor {
+		desc := roachpb.RangeDescriptor{}
+		for i := 1; i <= 3; i++ {
+			desc.InternalReplicas = append(desc.InternalReplicas, roachpb.ReplicaDescriptor{
+				StoreID:   roachpb.StoreID(i),
+				NodeID:    roachpb.NodeID(i),
+				ReplicaID: roachpb.ReplicaID(i),
+			})
+		}
+		for _, storeID := range storeIDs {
+			desc.InternalReplicas = append(desc.InternalReplicas, roachpb.ReplicaDescriptor{
+				StoreID:   storeID,
+				NodeID:    roachpb.NodeID(storeID),
+				ReplicaID: roachpb.ReplicaID(storeID),
+				Type:      roachpb.WITNESS,
+			})
+		}
+		return desc
+	}
+
+	testCases := []struct {
+		name           string
+		numWitnesses   int32
+		desc           roachpb.RangeDescriptor
+		live           []roachpb.StoreID
+		dead           []roachpb.StoreID
+		expectedAction AllocatorAction
+	}{
+		{
+			name:           "missing witness",
+			numWitnesses:   1,
+			desc:           withWitnesses(),
+			live:           []roachpb.StoreID{1, 2, 3, 4, 5},
+			expectedAction: AllocatorAddWitness,
+		},
+		{
+			name:           "nothing to do",
+			numWitnesses:   1,
+			desc:           withWitnesses(4),
+			live:           []roachpb.StoreID{1, 2, 3, 4, 5},
+			expectedAction: AllocatorConsiderRebalance,
+		},
+		{
+			name:           "dead witness is replaced",
+			numWitnesses:   1,
+			desc:           withWitnesses(4),
+			live:           []roachpb.StoreID{1, 2, 3, 5},
+			dead:           []roachpb.StoreID{4},
+			expectedAction: AllocatorAddWitness,
+		},
+		{
+			name:           "too many witnesses",
+			numWitnesses:   1,
+			desc:           withWitnesses(4, 5),
+			live:           []roachpb.StoreID{1, 2, 3, 4},
+			dead:           []roachpb.StoreID{5},
+			expectedAction: AllocatorRemoveWitness,
+		},
+		{
+			name:           "witnesses not configured",
+			desc:           withWitnesses(4),
+			live:           []roachpb.StoreID{1, 2, 3, 4, 5},
+			expectedAction: AllocatorRemoveWitness,
+		},
+		{
+			name:           "witness keeps quorum with a dead voter",
+			numWitnesses:   1,
+			desc:           withWitnesses(4),
+			live:           []roachpb.StoreID{1, 2, 4, 5},
+			dead:           []roachpb.StoreID{3},
+			expectedAction: AllocatorReplaceDeadVoter,
+		},
+		{
+			name:           "witness counts towards quorum",
+			numWitnesses:   1,
+			desc:           withWitnesses(4),
+			live:           []roachpb.StoreID{1, 4, 5},
+			dead:           []roachpb.StoreID{2, 3},
+			expectedAction: AllocatorRangeUnavailable,
+		},
+	}
+
+	ctx := context.Background()
+	stopper, _, sp, a, _ := CreateTestAllocator(ctx, 10, false /* deterministic */)
+	defer stopper.Stop(ctx)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := roachpb.SpanConfig{NumReplicas: 3, NumWitnesses: tc.numWitnesses}
+			mockStorePool(sp, tc.live, nil, tc.dead, nil, nil, nil)
+			action, _ := a.ComputeAction(ctx, sp, &conf, &tc.desc)
+			require.Equal(t, tc.expectedAction, action)
+		})
+	}
+}
+
 func TestAllocatorComputeActionWithStorePoolRemoveDead(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -287,6 +287,12 @@ func (rp ReplicaPlanner) PlanOneChange(
 	case allocatorimpl.AllocatorRemoveNonVoter:
 		op, stats, err = rp.removeNonVoter(ctx, repl, desc, conf, voterReplicas, nonVoterReplicas)
 
+	// Add or remove witnesses.
+	case allocatorimpl.AllocatorAddWitness:
+		op, stats, err = rp.addWitness(ctx, repl, desc, conf, voterReplicas, nonVoterReplicas, allocatorPrio)
+	case allocatorimpl.AllocatorRemoveWitness:
+		op, stats, err = rp.removeWitness(ctx, repl, desc, conf, voterReplicas, nonVoterReplicas)
+
 	// Remove decommissioning replicas.
 	//
 	// NB: these two paths will only be hit when the range is over-replicated and
@@ -677,6 +683,74 @@ func (rp ReplicaPlanner) removeNonVoter(
 	return op, stats, nil
 }
 
+// addWitness adds a witness to the range.
+func (rp ReplicaPlanner) addWitness(
+	ctx context.Context,
+	repl AllocatorReplica,
+	desc *roachpb.RangeDescriptor,
+	conf *roachpb.SpanConfig,
+	existingVoters, existingNonVoters []roachpb.ReplicaDescriptor,
+	allocatorPrio float64,
+) (op AllocationOp, stats ReplicateStats, _ error) {
+	existingWitnesses := desc.Replicas().WitnessDescriptors()
+	newWitness, details, err := rp.allocator.AllocateWitness(
+		ctx, rp.storePool, conf, existingVoters, existingNonVoters, existingWitnesses,
+	)
+	if err != nil {
+		return nil, stats, err
+	}
+	stats.AddReplicaCount++
+
+	log.KvDistribution.Infof(ctx, "adding witness %+v: %s",
+		newWitness, rangeRaftProgress(repl.RaftStatus(), existingVoters))
+	op = AllocationChangeReplicasOp{
+		LeaseholderStore:  repl.StoreID(),
+		Usage:             repl.RangeUsageInfo(),
+		Chgs:              kvpb.MakeReplicationChanges(roachpb.ADD_WITNESS, newWitness),
+		AllocatorPriority: allocatorPrio,
+		Reason:            kvserverpb.ReasonRangeUnderReplicated,
+		Details:           details,
+	}
+	return op, stats, nil
+}
+
+// removeWitness removes a witness from the range, either because the range
+// has more witnesses than configured or because the witness was replaced.
+func (rp ReplicaPlanner) removeWitness(
+	ctx context.Context,
+	repl AllocatorReplica,
+	desc *roachpb.RangeDescriptor,
+	conf *roachpb.SpanConfig,
+	existingVoters, existingNonVoters []roachpb.ReplicaDescriptor,
+) (op AllocationOp, stats ReplicateStats, _ error) {
+	existingWitnesses := desc.Replicas().WitnessDescriptors()
+	target, details, err := rp.allocator.RemoveWitness(
+		ctx,
+		rp.storePool,
+		conf,
+		existingVoters,
+		existingNonVoters,
+		existingWitnesses,
+		rp.allocator.ScorerOptions(ctx),
+	)
+	if err != nil {
+		return nil, stats, err
+	}
+	stats.RemoveReplicaCount++
+
+	log.KvDistribution.Infof(ctx, "removing witness %+v: %s",
+		target, rangeRaftProgress(repl.RaftStatus(), existingVoters))
+	op = AllocationChangeReplicasOp{
+		LeaseholderStore:  repl.StoreID(),
+		Usage:             repl.RangeUsageInfo(),
+		Chgs:              kvpb.MakeReplicationChanges(roachpb.REMOVE_WITNESS, target),
+		AllocatorPriority: 0.0, // unused
+		Reason:            kvserverpb.ReasonRangeOverReplicated,
+		Details:           details,
+	}
+	return op, stats, nil
+}
+
 func (rp ReplicaPlanner) removeDecommissioning(
 	ctx context.Context,
 	repl AllocatorReplica,
@@ -67,9 +67,10 @@ func evalNewLease(
 		return newFailedLeaseTrigger(isTransfer), errors.AssertionFailedf("ProposedTS must be set")
 	}
 
-	// Ensure either an Epoch is set or Start < Expiration.
+	// Ensure either an Epoch or a Term is set or Start < Expiration.
 	if (lease.Type() == roachpb.LeaseExpiration && lease.GetExpiration().LessEq(lease.Start.ToTimestamp())) ||
-		(lease.Type() == roachpb.LeaseEpoch && lease.Expiration != nil) {
+		(lease.Type() == roachpb.LeaseEpoch && lease.Expiration != nil) ||
+		(lease.Type() == roachpb.LeaseLeader && (lease.Expiration != nil || lease.Epoch != 0)) {
 		// This amounts to a bug.
 		return newFailedLeaseTrigger(isTransfer),
 			&kvpb.LeaseRejectedError{
@@ -138,10 +138,17 @@ func RequestLease(
 			t := *newLease.Expiration
 			newLease.Expiration = &t
 			newLease.Expiration.Forward(prevLease.GetExpiration())
+		} else if newLease.Type() == roachpb.LeaseLeader {
+			newLease.MinExpiration.Forward(prevLease.MinExpiration)
 		}
 	} else if prevLease.Type() == roachpb.LeaseExpiration && effectiveStart.ToTimestamp().Less(prevLease.GetExpiration()) {
 		rErr.Message = "requested lease overlaps previous lease"
 		return newFailedLeaseTrigger(false /* isTransfer */), rErr
+	} else if prevLease.Type() == roachpb.LeaseLeader && effectiveStart.ToTimestamp().Less(prevLease.MinExpiration) {
+		// A leader lease is valid until its minimum expiration regardless of
+		// the state of raft leadership.
+		rErr.Message = "requested lease overlaps previous lease"
+		return newFailedLeaseTrigger(false /* isTransfer */), rErr
 	}
 	newLease.Start = effectiveStart
 
@@ -1590,6 +1590,75 @@ func TestLeaseTransfersUseExpirationLeasesAndBumpToEpochBasedOnes(t *testing.T)
 	require.Equal(t, expirationL.Sequence, epochL.Sequence)
 }
 
+// TestLeaderLeaseFollowsLeaseTransfer tests that, with leader leases enabled, a
+// lease transfer hands out an expiration-based lease, that raft leadership
+// follows the lease to its new holder, and that the new holder then upgrades
+// to a leader lease backed by its leadership.
+func TestLeaderLeaseFollowsLeaseTransfer(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	kvserver.ExpirationLeasesOnly.Override(ctx, &st.SV, false) // override metamorphism
+	kvserver.LeaderLeasesEnabled.Override(ctx, &st.SV, true)
+
+	tc := testcluster.StartTestCluster(t, 2, base.TestClusterArgs{
+		ReplicationMode: base.ReplicationManual,
+		ServerArgs:      base.TestServerArgs{Settings: st},
+	})
+	defer tc.Stopper().Stop(ctx)
+
+	scratchKey := tc.ScratchRange(t)
+	desc := tc.AddVotersOrFatal(t, scratchKey, tc.Target(1))
+	n2 := tc.Server(1)
+
+	// Wait for the lease on n1 to be a leader lease.
+	var prevLease roachpb.Lease
+	testutils.SucceedsSoon(t, func() error {
+		li, _, err := tc.FindRangeLeaseEx(ctx, desc, nil)
+		require.NoError(t, err)
+		prevLease = li.Current()
+		if typ := prevLease.Type(); typ != roachpb.LeaseLeader {
+			return errors.Errorf("lease is a %s lease", typ)
+		}
+		return nil
+	})
+
+	// Transfer the lease from n1 to n2.
+	tc.TransferRangeLeaseOrFatal(t, desc, tc.Target(1))
+
+	// Expect raft leadership to follow the lease, and the lease to be upgraded
+	// to a leader lease held by n2 in the new leader's term.
+	store := tc.GetFirstStoreFromServer(t, 1)
+	repl := store.LookupReplica(roachpb.RKey(scratchKey))
+	require.NotNil(t, repl)
+	var l roachpb.Lease
+	testutils.SucceedsSoon(t, func() error {
+		li, _, err := tc.FindRangeLeaseEx(ctx, desc, nil)
+		require.NoError(t, err)
+		l = li.Current()
+		if !l.OwnedBy(n2.GetFirstStoreID()) {
+			return errors.New("lease still owned by n1")
+		}
+		if l.Type() != roachpb.LeaseLeader {
+			return errors.Errorf("lease is a %s lease", l.Type())
+		}
+		if status := repl.RaftStatus(); status.RaftState != raft.StateLeader {
+			return errors.New("n2 is not the raft leader")
+		} else if l.Term != status.Term {
+			return errors.Errorf("lease term %d doesn't match raft term %d", l.Term, status.Term)
+		}
+		return nil
+	})
+
+	// Expect the leader lease to be an extension of the transferred lease, and
+	// the transferred lease to have been expiration-based: the upgrade carries
+	// over its expiration as the leader lease's minimum expiration.
+	require.Equal(t, prevLease.Sequence+1, l.Sequence)
+	require.False(t, l.MinExpiration.IsEmpty())
+}
+
 // TestLeaseRequestBumpsEpoch tests that a non-cooperative lease acquisition of
 // an expired epoch lease always bumps the epoch of the outgoing leaseholder,
 // regardless of the type of lease being acquired.
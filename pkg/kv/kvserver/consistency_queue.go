@@ -46,6 +46,21 @@ var consistencyCheckRate = settings.RegisterByteSizeSetting(
 	settings.PositiveInt,
 	settings.WithPublic)
 
+// consistencyCheckAutoRepair controls what happens when the consistency queue
+// finds that a minority of replicas have diverged from a strict majority that
+// agrees on the range contents. By default, the nodes holding the diverged
+// replicas are terminated. With auto-repair, they are kept running and the
+// diverged replicas are removed from the range instead, to be replaced with
+// copies of a healthy replica by the replicate queue. Only replicas that
+// diverge again in the second, checkpointing round of the check are removed.
+var consistencyCheckAutoRepair = settings.RegisterBoolSetting(
+	settings.SystemOnly,
+	"server.consistency_check.auto_repair.enabled",
+	"if enabled, replicas that diverged from a majority of their peers are removed and "+
+		"re-replicated from a healthy replica instead of terminating the node holding them",
+	false,
+)
+
 // consistencyCheckRateBurstFactor we use this to set the burst parameter on the
 // quotapool.RateLimiter. It seems overkill to provide a user setting for this,
 // so we use a factor to scale the burst setting based on the rate defined above.
@@ -27,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvstorage"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/stateloader"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -431,6 +432,81 @@ func TestCheckConsistencyInconsistent(t *testing.T) {
 	require.NotEmpty(t, b)
 }
 
+// TestCheckConsistencyAutoRepair verifies that with auto-repair enabled, a
+// replica that diverged from its peers is removed and replaced instead of its
+// node being terminated.
+func TestCheckConsistencyAutoRepair(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// Test expects simple MVCC value encoding.
+	storage.DisableMetamorphicSimpleValueEncoding(t)
+
+	ctx := context.Background()
+	testKnobs := kvserver.StoreTestingKnobs{DisableConsistencyQueue: true}
+	testKnobs.ConsistencyTestingKnobs.OnBadChecksumFatal = func(s roachpb.StoreIdent) {
+		t.Errorf("unexpected fatal on %s", s)
+	}
+	tc := testcluster.StartTestCluster(t, 3, base.TestClusterArgs{
+		ReplicationMode: base.ReplicationAuto,
+		ServerArgs: base.TestServerArgs{
+			Knobs: base.TestingKnobs{Store: &testKnobs},
+		},
+	})
+	defer tc.Stopper().Stop(ctx)
+
+	_, err := tc.ServerConn(0).Exec(`SET CLUSTER SETTING server.consistency_check.auto_repair.enabled = true`)
+	require.NoError(t, err)
+
+	store := tc.GetFirstStoreFromServer(t, 0)
+	_, pErr := kv.SendWrapped(ctx, store.DB().NonTransactionalSender(), putArgs([]byte("a"), []byte("b")))
+	require.NoError(t, pErr.GoError())
+
+	runConsistencyCheck := func() *kvpb.CheckConsistencyResponse {
+		req := kvpb.CheckConsistencyRequest{
+			RequestHeader: kvpb.RequestHeader{Key: []byte("a"), EndKey: []byte("z")},
+			Mode:          kvpb.ChecksumMode_CHECK_VIA_QUEUE,
+		}
+		resp, pErr := kv.SendWrapped(ctx, store.DB().NonTransactionalSender(), &req)
+		require.NoError(t, pErr.GoError())
+		return resp.(*kvpb.CheckConsistencyResponse)
+	}
+
+	// Put an inconsistent key "e" to s2, and have s1 and s3 still agree.
+	s2 := tc.GetFirstStoreFromServer(t, 1)
+	var val roachpb.Value
+	val.SetInt(42)
+	_, err = storage.MVCCPut(ctx, s2.TODOEngine(),
+		roachpb.Key("e"), tc.Server(0).Clock().Now(), val, storage.MVCCWriteOptions{})
+	require.NoError(t, err)
+
+	resp := runConsistencyCheck()
+	require.Len(t, resp.Result, 1)
+	require.Equal(t, kvpb.CheckConsistencyResponse_RANGE_INCONSISTENT, resp.Result[0].Status)
+	// The details carry the key-level diff from the checkpoint round.
+	require.Contains(t, resp.Result[0].Detail, "checkpoint round:")
+	require.Contains(t, resp.Result[0].Detail, "key-level diff against")
+	require.Contains(t, resp.Result[0].Detail, "extra")
+
+	// The diverged replica was removed and the repair recorded in the rangelog.
+	n, err := countEvents(ctx, tc.ServerConn(0), kvserverpb.RangeLogEventType_consistency_repair)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	// The replicate queue up-replicates the range from a healthy replica, after
+	// which the range is consistent again.
+	testutils.SucceedsSoon(t, func() error {
+		desc := tc.LookupRangeOrFatal(t, roachpb.Key("a"))
+		if n := len(desc.Replicas().VoterDescriptors()); n != 3 {
+			return errors.Errorf("range has %d voters, want 3", n)
+		}
+		if status := runConsistencyCheck().Result[0].Status; status != kvpb.CheckConsistencyResponse_RANGE_CONSISTENT {
+			return errors.Errorf("range is %s", status)
+		}
+		return nil
+	})
+}
+
 // TestConsistencyQueueRecomputeStats is an end-to-end test of the mechanism CockroachDB
 // employs to adjust incorrect MVCCStats ("incorrect" meaning not an inconsistency of
 // these stats between replicas, but a delta between persisted stats and those one
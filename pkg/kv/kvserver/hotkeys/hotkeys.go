@@ -0,0 +1,204 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package hotkeys estimates the most frequently accessed keys of a replica and
+// rate limits requests to them.
+//
+// Load-based splitting can't split a range below a single key, so a single hot
+// key can saturate its leaseholder. The Finder keeps a small set of counters
+// for a sample of the keys accessed by requests, using the space-saving
+// algorithm: a sampled key that isn't tracked yet replaces the tracked key with
+// the lowest count, and inherits its count. This overestimates the load on
+// infrequently accessed keys, but guarantees that any key whose share of the
+// load exceeds 1/capacity is tracked.
+//
+// The Finder also rate limits requests to its tracked keys with a token bucket
+// per key. Keys that aren't tracked are never hot enough to be limited.
+package hotkeys
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/tokenbucket"
+)
+
+const (
+	// DefaultCapacity is the default number of keys tracked by a Finder.
+	DefaultCapacity = 32
+	// DefaultWindow is the default duration over which a Finder estimates the
+	// load on its keys.
+	DefaultWindow = 10 * time.Second
+)
+
+// KeyLoad is the estimated load on a single key.
+type KeyLoad struct {
+	Key roachpb.Key
+	// QPS is the estimated number of requests per second to the key.
+	QPS float64
+}
+
+// counter tracks the load on a single key.
+type counter struct {
+	key roachpb.Key
+	// cur and prev are the estimated number of requests to the key in the
+	// current and previous windows.
+	cur, prev float64
+	// bucket rate limits requests to the key. It is initialized when the key
+	// is first subject to a rate limit.
+	bucket    *tokenbucket.TokenBucket
+	bucketQPS int32
+}
+
+func (c *counter) count() float64 {
+	return c.cur + c.prev
+}
+
+// Finder estimates the hottest keys of a replica from a sample of its
+// requests, and rate limits requests to them. It is safe for concurrent use.
+type Finder struct {
+	capacity   int
+	window     time.Duration
+	timeSource timeutil.TimeSource
+	mu         struct {
+		syncutil.Mutex
+		windowStart time.Time
+		counters    map[string]*counter
+	}
+}
+
+// NewFinder returns a Finder that tracks up to capacity keys and estimates
+// their load over the given window.
+func NewFinder(capacity int, window time.Duration, timeSource timeutil.TimeSource) *Finder {
+	f := &Finder{
+		capacity:   capacity,
+		window:     window,
+		timeSource: timeSource,
+	}
+	f.mu.windowStart = timeSource.Now()
+	f.mu.counters = make(map[string]*counter, capacity)
+	return f
+}
+
+// Record records a request to the given key with probability sampleRate. The
+// count of sampled requests is scaled by the inverse of the sample rate, so
+// that the estimated load is unbiased.
+func (f *Finder) Record(key roachpb.Key, sampleRate float64) {
+	if sampleRate <= 0 || (sampleRate < 1 && rand.Float64() >= sampleRate) {
+		return
+	}
+	weight := 1 / min(sampleRate, 1)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.maybeRollLocked()
+	c, ok := f.mu.counters[string(key)]
+	if !ok {
+		c = &counter{key: key.Clone()}
+		if len(f.mu.counters) >= f.capacity {
+			// Replace the key with the lowest count, which the new key inherits.
+			var minC *counter
+			for _, other := range f.mu.counters {
+				if minC == nil || other.count() < minC.count() {
+					minC = other
+				}
+			}
+			delete(f.mu.counters, string(minC.key))
+			c.cur = minC.count()
+		}
+		f.mu.counters[string(key)] = c
+	}
+	c.cur += weight
+}
+
+// maybeRollLocked starts a new window if the current one has elapsed.
+func (f *Finder) maybeRollLocked() {
+	now := f.timeSource.Now()
+	elapsed := now.Sub(f.mu.windowStart)
+	if elapsed < f.window {
+		return
+	}
+	for k, c := range f.mu.counters {
+		if elapsed >= 2*f.window {
+			c.prev = 0
+		} else {
+			c.prev = c.cur
+		}
+		c.cur = 0
+		if c.prev == 0 {
+			delete(f.mu.counters, k)
+		}
+	}
+	f.mu.windowStart = now
+}
+
+// qpsLocked returns the estimated QPS of the given counter. The count of the
+// previous window is weighted by its overlap with a sliding window ending now.
+func (f *Finder) qpsLocked(c *counter) float64 {
+	frac := float64(f.timeSource.Since(f.mu.windowStart)) / float64(f.window)
+	frac = min(max(frac, 0), 1)
+	return (c.prev*(1-frac) + c.cur) / f.window.Seconds()
+}
+
+// HotKeys returns up to k of the tracked keys with the highest estimated
+// load, in decreasing order of load.
+func (f *Finder) HotKeys(k int) []KeyLoad {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.maybeRollLocked()
+	res := make([]KeyLoad, 0, len(f.mu.counters))
+	for _, c := range f.mu.counters {
+		if qps := f.qpsLocked(c); qps > 0 {
+			res = append(res, KeyLoad{Key: c.key, QPS: qps})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].QPS > res[j].QPS })
+	if len(res) > k {
+		res = res[:k]
+	}
+	return res
+}
+
+// Admit returns whether a request to the given key is admitted under a limit
+// of maxQPS requests per second to the key. If it isn't, Admit also returns
+// the duration after which the request could be admitted.
+func (f *Finder) Admit(key roachpb.Key, maxQPS int32) (bool, time.Duration) {
+	if maxQPS <= 0 {
+		return true, 0
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c, ok := f.mu.counters[string(key)]
+	if !ok {
+		return true, 0
+	}
+	rate, burst := tokenbucket.TokensPerSecond(maxQPS), tokenbucket.Tokens(maxQPS)
+	if c.bucket == nil {
+		c.bucket = &tokenbucket.TokenBucket{}
+		c.bucket.InitWithNowFn(rate, burst, f.timeSource.Now)
+		c.bucketQPS = maxQPS
+	} else if c.bucketQPS != maxQPS {
+		c.bucket.UpdateConfig(rate, burst)
+		c.bucketQPS = maxQPS
+	}
+	return c.bucket.TryToFulfill(1)
+}
+
+// Reset discards all tracked keys, e.g. when the replica's key span changes.
+func (f *Finder) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.mu.windowStart = f.timeSource.Now()
+	f.mu.counters = make(map[string]*counter, f.capacity)
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package hotkeys
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinderHotKeys(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	manual := timeutil.NewManualTime(timeutil.Unix(0, 0))
+	f := NewFinder(4, 10*time.Second, manual)
+
+	// Key "a" is accessed 10x more often than the other keys, which cycle
+	// through the remaining counters.
+	for i := 0; i < 1000; i++ {
+		f.Record(roachpb.Key("a"), 1)
+		if i%10 == 0 {
+			f.Record(roachpb.Key(fmt.Sprintf("b%d", i%70)), 1)
+		}
+	}
+	hot := f.HotKeys(1)
+	require.Len(t, hot, 1)
+	require.Equal(t, roachpb.Key("a"), hot[0].Key)
+	require.InDelta(t, 100, hot[0].QPS, 1)
+	require.Len(t, f.HotKeys(10), 4)
+
+	// The load of the previous window is phased out over the current one.
+	manual.Advance(10 * time.Second)
+	require.InDelta(t, 100, f.HotKeys(1)[0].QPS, 1)
+	manual.Advance(5 * time.Second)
+	require.InDelta(t, 50, f.HotKeys(1)[0].QPS, 1)
+	manual.Advance(5 * time.Second)
+	require.Empty(t, f.HotKeys(10))
+
+	f.Record(roachpb.Key("a"), 1)
+	f.Reset()
+	require.Empty(t, f.HotKeys(10))
+}
+
+func TestFinderAdmit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	manual := timeutil.NewManualTime(timeutil.Unix(0, 0))
+	f := NewFinder(DefaultCapacity, DefaultWindow, manual)
+	key := roachpb.Key("a")
+
+	// Untracked keys are never limited.
+	for i := 0; i < 10; i++ {
+		ok, _ := f.Admit(key, 5)
+		require.True(t, ok)
+	}
+
+	// Tracked keys are limited once they exhaust their burst.
+	f.Record(key, 1)
+	for i := 0; i < 5; i++ {
+		ok, _ := f.Admit(key, 5)
+		require.True(t, ok)
+	}
+	ok, tryAgainAfter := f.Admit(key, 5)
+	require.False(t, ok)
+	require.Equal(t, 200*time.Millisecond, tryAgainAfter)
+
+	manual.Advance(tryAgainAfter)
+	ok, _ = f.Admit(key, 5)
+	require.True(t, ok)
+
+	// A limit of zero disables rate limiting.
+	ok, _ = f.Admit(key, 0)
+	require.True(t, ok)
+}
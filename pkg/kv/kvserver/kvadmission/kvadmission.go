@@ -14,6 +14,7 @@ package kvadmission
 
 import (
 	"context"
+	"math"
 	"sync"
 	"time"
 
@@ -189,6 +190,19 @@ type Controller interface {
 // weights.
 type TenantWeightProvider interface {
 	GetTenantWeights() TenantWeights
+	// GetTenantAdmissionConfigs returns the admission control configuration
+	// set explicitly for tenants, keyed by tenant ID, and a channel that is
+	// closed when it may have changed.
+	GetTenantAdmissionConfigs() (map[uint64]TenantAdmissionConfig, <-chan struct{})
+}
+
+// TenantAdmissionConfig is the admission control configuration of a tenant,
+// as set by ALTER VIRTUAL CLUSTER ... SET ADMISSION.
+type TenantAdmissionConfig struct {
+	// Weight multiplies the weight derived from the tenant's share of the
+	// replicas on a node or store. Zero means the default weight of 1.
+	Weight        uint32
+	PriorityClass admission.TenantPriorityClass
 }
 
 // TenantWeights contains the various tenant weights.
@@ -508,34 +522,41 @@ func (n *controllerImpl) SetTenantWeightProvider(
 		const weightCalculationPeriod = 10 * time.Minute
 		ticker := time.NewTicker(weightCalculationPeriod)
 		// Used for short-circuiting the weights calculation if all weights are
-		// disabled.
+		// disabled and no tenant has an admission configuration.
 		allWeightsDisabled := false
+		configs, configsChangedCh := provider.GetTenantAdmissionConfigs()
+		update := func() {
+			kvDisabled := !admission.KVTenantWeightsEnabled.Get(&n.settings.SV)
+			kvStoresDisabled := !admission.KVStoresTenantWeightsEnabled.Get(&n.settings.SV)
+			if allWeightsDisabled && kvDisabled && kvStoresDisabled && len(configs) == 0 {
+				// Have already transitioned to disabled, so noop.
+				return
+			}
+			classes := tenantPriorityClasses(configs)
+			weights := provider.GetTenantWeights()
+			nodeWeights := applyConfiguredTenantWeights(weights.Node, kvDisabled, configs)
+			n.kvAdmissionQ.SetTenantWeights(nodeWeights)
+			n.kvAdmissionQ.SetTenantPriorityClasses(classes)
+			n.elasticCPUGrantCoordinator.ElasticCPUWorkQueue.SetTenantWeights(nodeWeights)
+			n.elasticCPUGrantCoordinator.ElasticCPUWorkQueue.SetTenantPriorityClasses(classes)
+
+			for _, storeWeights := range weights.Stores {
+				q := n.storeGrantCoords.TryGetQueueForStore(int32(storeWeights.StoreID))
+				if q != nil {
+					q.SetTenantWeights(
+						applyConfiguredTenantWeights(storeWeights.Weights, kvStoresDisabled, configs))
+					q.SetTenantPriorityClasses(classes)
+				}
+			}
+			allWeightsDisabled = kvDisabled && kvStoresDisabled && len(configs) == 0
+		}
 		for {
 			select {
 			case <-ticker.C:
-				kvDisabled := !admission.KVTenantWeightsEnabled.Get(&n.settings.SV)
-				kvStoresDisabled := !admission.KVStoresTenantWeightsEnabled.Get(&n.settings.SV)
-				if allWeightsDisabled && kvDisabled && kvStoresDisabled {
-					// Have already transitioned to disabled, so noop.
-					continue
-				}
-				weights := provider.GetTenantWeights()
-				if kvDisabled {
-					weights.Node = nil
-				}
-				n.kvAdmissionQ.SetTenantWeights(weights.Node)
-				n.elasticCPUGrantCoordinator.ElasticCPUWorkQueue.SetTenantWeights(weights.Node)
-
-				for _, storeWeights := range weights.Stores {
-					q := n.storeGrantCoords.TryGetQueueForStore(int32(storeWeights.StoreID))
-					if q != nil {
-						if kvStoresDisabled {
-							storeWeights.Weights = nil
-						}
-						q.SetTenantWeights(storeWeights.Weights)
-					}
-				}
-				allWeightsDisabled = kvDisabled && kvStoresDisabled
+				update()
+			case <-configsChangedCh:
+				configs, configsChangedCh = provider.GetTenantAdmissionConfigs()
+				update()
 			case <-stopper.ShouldQuiesce():
 				ticker.Stop()
 				return
@@ -544,6 +565,50 @@ func (n *controllerImpl) SetTenantWeightProvider(
 	}()
 }
 
+// tenantPriorityClasses returns the tenant ID => priority class map for the
+// tenants that are not in the default class, or nil if there are none.
+func tenantPriorityClasses(
+	configs map[uint64]TenantAdmissionConfig,
+) map[uint64]admission.TenantPriorityClass {
+	var classes map[uint64]admission.TenantPriorityClass
+	for tenantID, c := range configs {
+		if c.PriorityClass == admission.TenantPriorityClassStandard {
+			continue
+		}
+		if classes == nil {
+			classes = make(map[uint64]admission.TenantPriorityClass)
+		}
+		classes[tenantID] = c.PriorityClass
+	}
+	return classes
+}
+
+// applyConfiguredTenantWeights scales the weights derived from replica counts
+// by the weights configured for tenants. If the derived weights are disabled,
+// the configured weights are used on their own, since operators that set them
+// expect them to take effect.
+func applyConfiguredTenantWeights(
+	weights map[uint64]uint32, derivedDisabled bool, configs map[uint64]TenantAdmissionConfig,
+) map[uint64]uint32 {
+	if derivedDisabled {
+		weights = nil
+	}
+	for tenantID, c := range configs {
+		if c.Weight == 0 {
+			continue
+		}
+		if derivedDisabled {
+			if weights == nil {
+				weights = make(map[uint64]uint32)
+			}
+			weights[tenantID] = c.Weight
+		} else if w, ok := weights[tenantID]; ok {
+			weights[tenantID] = uint32(min(uint64(w)*uint64(c.Weight), math.MaxUint32))
+		}
+	}
+	return weights
+}
+
 // SnapshotIngestedOrWritten implements the Controller interface.
 func (n *controllerImpl) SnapshotIngestedOrWritten(
 	storeID roachpb.StoreID, ingestStats pebble.IngestOperationStats, writeBytes uint64,
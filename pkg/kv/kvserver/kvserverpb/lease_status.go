@@ -33,6 +33,8 @@ func (st LeaseStatus) Expiration() hlc.Timestamp {
 		return st.Lease.GetExpiration()
 	case roachpb.LeaseEpoch:
 		return st.Liveness.Expiration.ToTimestamp()
+	case roachpb.LeaseLeader:
+		return st.LeaderLeaseExpiration
 	default:
 		panic("unexpected")
 	}
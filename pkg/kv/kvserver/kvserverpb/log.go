@@ -24,4 +24,5 @@ const (
 	ReasonAdminRequest         RangeLogEventReason = "admin request"
 	ReasonAbandonedLearner     RangeLogEventReason = "abandoned learner replica"
 	ReasonUnsafeRecovery       RangeLogEventReason = "unsafe loss of quorum recovery"
+	ReasonConsistencyRepair    RangeLogEventReason = "replica inconsistency repair"
 )
@@ -10,9 +10,10 @@
 
 // Package kvstorage houses the logic that manages the on-disk state for the
 // Replicas housed on a Store. Replicas store data in two storage.Engine
-// instances, which are optionally (and at the time of writing, always)
-// identical. One Engine, the "log storage", stores Raft-related state (such as
-// the raft log), while the other engine stores the replicated keyspace.
+// instances, which are identical unless the store is configured with a
+// separate Raft log engine (see Engines). One Engine, the "log storage",
+// stores Raft-related state (such as the raft log), while the other engine
+// stores the replicated keyspace.
 //
 // The ability to separate log and state machine opens up performance
 // improvements, but results in a more complex lifecycle where operations that
@@ -338,7 +338,7 @@ func (r Replica) ID() storage.FullReplicaID {
 
 // Load loads the state necessary to instantiate a replica in memory.
 func (r Replica) Load(
-	ctx context.Context, eng storage.Reader, storeID roachpb.StoreID,
+	ctx context.Context, engs Engines, storeID roachpb.StoreID,
 ) (LoadedReplicaState, error) {
 	ls := LoadedReplicaState{
 		ReplicaID: r.ReplicaID,
@@ -346,10 +346,10 @@ func (r Replica) Load(
 	}
 	sl := stateloader.Make(r.Desc.RangeID)
 	var err error
-	if ls.LastIndex, err = sl.LoadLastIndex(ctx, eng); err != nil {
+	if ls.LastIndex, err = sl.LoadLastIndex(ctx, engs.LogEngine()); err != nil {
 		return LoadedReplicaState{}, err
 	}
-	if ls.ReplState, err = sl.Load(ctx, eng, r.Desc); err != nil {
+	if ls.ReplState, err = LoadReplState(ctx, engs, r.Desc); err != nil {
 		return LoadedReplicaState{}, err
 	}
 
@@ -390,8 +390,9 @@ func (m replicaMap) setDesc(rangeID roachpb.RangeID, desc roachpb.RangeDescripto
 	return nil
 }
 
-func loadReplicas(ctx context.Context, eng storage.Engine) ([]Replica, error) {
+func loadReplicas(ctx context.Context, engs Engines) ([]Replica, error) {
 	s := replicaMap{}
+	eng := engs.StateEngine()
 
 	// INVARIANT: the latest visible committed version of the RangeDescriptor
 	// (which is what IterateRangeDescriptorsFromDisk returns) is the one reflecting
@@ -441,7 +442,7 @@ func loadReplicas(ctx context.Context, eng storage.Engine) ([]Replica, error) {
 		logEvery = log.Every(10 * time.Second)
 		i = 0
 		var hs raftpb.HardState
-		if err := IterateIDPrefixKeys(ctx, eng, func(rangeID roachpb.RangeID) roachpb.Key {
+		if err := IterateIDPrefixKeys(ctx, engs.LogEngine(), func(rangeID roachpb.RangeID) roachpb.Key {
 			return keys.RaftHardStateKey(rangeID)
 		}, &hs, func(rangeID roachpb.RangeID) error {
 			if logEvery.ShouldLog() && i > 0 { // only log if slow
@@ -470,13 +471,13 @@ func loadReplicas(ctx context.Context, eng storage.Engine) ([]Replica, error) {
 // The returned slice is sorted by ReplicaID.
 //
 // TODO(sep-raft-log): consider a callback-visitor pattern here.
-func LoadAndReconcileReplicas(ctx context.Context, eng storage.Engine) ([]Replica, error) {
-	ident, err := ReadStoreIdent(ctx, eng)
+func LoadAndReconcileReplicas(ctx context.Context, engs Engines) ([]Replica, error) {
+	ident, err := ReadStoreIdent(ctx, engs.StateEngine())
 	if err != nil {
 		return nil, err
 	}
 
-	sl, err := loadReplicas(ctx, eng)
+	sl, err := loadReplicas(ctx, engs)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,411 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvstorage
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/logstore"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/raftlog"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/stateloader"
+	"github.com/cockroachdb/cockroach/pkg/raft/raftpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// migrationBatchSize is the size at which the batch moving the Raft log into
+// a separate engine is committed and a new one is started.
+const migrationBatchSize = 4 << 20 // 4 MiB
+
+// Engines holds the storage engines backing a Store. The state engine holds
+// the replicated state machine along with most of the unreplicated state. The
+// log engine holds the Raft log, HardState and RaftTruncatedState of each
+// replica. Unless the store has been configured with a separate Raft log
+// engine, both are the same engine.
+//
+// When the engines are separate, writes to them are not atomic. The state
+// engine is always written first, and the Raft state in the log engine is
+// reconciled with it when the store starts; see InitRaftLogEngine.
+type Engines struct {
+	stateEngine storage.Engine
+	logEngine   storage.Engine
+}
+
+// MakeEngines returns Engines for a store whose Raft log shares an engine with
+// the state machine.
+func MakeEngines(eng storage.Engine) Engines {
+	return Engines{stateEngine: eng, logEngine: eng}
+}
+
+// MakeSeparatedEngines returns Engines for a store whose Raft log lives in a
+// dedicated engine.
+func MakeSeparatedEngines(stateEngine, logEngine storage.Engine) Engines {
+	return Engines{stateEngine: stateEngine, logEngine: logEngine}
+}
+
+// StateEngine returns the engine holding the state machine.
+func (e Engines) StateEngine() storage.Engine {
+	return e.stateEngine
+}
+
+// LogEngine returns the engine holding the Raft log.
+func (e Engines) LogEngine() storage.Engine {
+	return e.logEngine
+}
+
+// Separated returns whether the Raft log lives in a dedicated engine.
+func (e Engines) Separated() bool {
+	return e.logEngine != e.stateEngine
+}
+
+// LoadReplState loads the ReplicaState of the given initialized replica. The
+// RaftTruncatedState is read from the log engine, everything else from the
+// state engine.
+func LoadReplState(
+	ctx context.Context, engs Engines, desc *roachpb.RangeDescriptor,
+) (kvserverpb.ReplicaState, error) {
+	sl := stateloader.Make(desc.RangeID)
+	state, err := sl.Load(ctx, engs.StateEngine(), desc)
+	if err != nil {
+		return kvserverpb.ReplicaState{}, err
+	}
+	if engs.Separated() {
+		ts, err := sl.LoadRaftTruncatedState(ctx, engs.LogEngine())
+		if err != nil {
+			return kvserverpb.ReplicaState{}, err
+		}
+		state.TruncatedState = &ts
+	}
+	return state, nil
+}
+
+// ClearRaftLogState clears the part of a replica's unreplicated state which
+// lives in the log engine: the HardState, the Raft log, and the
+// RaftTruncatedState.
+func ClearRaftLogState(w storage.Writer, rangeID roachpb.RangeID) error {
+	if err := w.ClearUnversioned(keys.RaftHardStateKey(rangeID), storage.ClearOptions{}); err != nil {
+		return err
+	}
+	prefix := keys.RaftLogPrefix(rangeID)
+	if err := w.ClearRawRange(
+		prefix, prefix.PrefixEnd(), true /* pointKeys */, false, /* rangeKeys */
+	); err != nil {
+		return err
+	}
+	return w.ClearUnversioned(keys.RaftTruncatedStateKey(rangeID), storage.ClearOptions{})
+}
+
+// raftLogStateSpans returns the spans of the keys cleared by ClearRaftLogState.
+func raftLogStateSpans(rangeID roachpb.RangeID) []roachpb.Span {
+	hs, ts := keys.RaftHardStateKey(rangeID), keys.RaftTruncatedStateKey(rangeID)
+	prefix := keys.RaftLogPrefix(rangeID)
+	return []roachpb.Span{
+		{Key: hs, EndKey: hs.Next()},
+		{Key: prefix, EndKey: prefix.PrefixEnd()},
+		{Key: ts, EndKey: ts.Next()},
+	}
+}
+
+// UsesRaftLogEngine returns whether the store with the given state engine has
+// moved its Raft log into a separate engine.
+func UsesRaftLogEngine(ctx context.Context, stateEng storage.Reader) (bool, error) {
+	var marker roachpb.StoreIdent
+	return storage.MVCCGetProto(ctx, stateEng, keys.StoreRaftLogEngineKey(),
+		hlc.Timestamp{}, &marker, storage.MVCCGetOptions{})
+}
+
+// InitRaftLogEngine prepares the engines of a store before its replicas are
+// loaded. It must be called on every start of a store.
+//
+// The first time a store is started with a separate Raft log engine, the Raft
+// log and the Raft state of all replicas are moved from the state engine into
+// it, and a marker recording the move is persisted in the state engine. From
+// then on the store can not be started without its log engine. On every start
+// with a separate log engine, the Raft state in the log engine is reconciled
+// with the state machine, which it may have fallen behind of on a crash.
+func InitRaftLogEngine(ctx context.Context, engs Engines) error {
+	stateEng := engs.StateEngine()
+	ident, err := ReadStoreIdent(ctx, stateEng)
+	if err != nil {
+		return err
+	}
+	var marker roachpb.StoreIdent
+	migrated, err := storage.MVCCGetProto(ctx, stateEng, keys.StoreRaftLogEngineKey(),
+		hlc.Timestamp{}, &marker, storage.MVCCGetOptions{})
+	if err != nil {
+		return err
+	}
+	if !engs.Separated() {
+		if migrated {
+			return errors.Errorf(
+				"store %s keeps its Raft log in a separate engine, but none was configured", ident)
+		}
+		return nil
+	}
+	if !migrated {
+		if err := migrateToRaftLogEngine(ctx, engs, ident); err != nil {
+			return errors.Wrap(err, "moving Raft log into separate engine")
+		}
+	} else if marker != ident {
+		return errors.AssertionFailedf("Raft log engine marker %s does not match store %s", marker, ident)
+	}
+	logIdent, err := ReadStoreIdent(ctx, engs.LogEngine())
+	if err != nil {
+		return errors.Wrap(err, "reading Raft log engine ident")
+	}
+	if logIdent != ident {
+		return errors.Errorf("Raft log engine belongs to store %s, not %s", logIdent, ident)
+	}
+	return reconcileRaftLogEngine(ctx, engs)
+}
+
+// migrateToRaftLogEngine copies the Raft state of all replicas from the state
+// engine into the log engine, and then removes it from the state engine.
+//
+// The migration is idempotent: the log engine is written (and synced) in full
+// before the marker is written to the state engine atomically with removing
+// the Raft state from it. If the process crashes before that, the migration is
+// restarted from scratch on the next start.
+func migrateToRaftLogEngine(
+	ctx context.Context, engs Engines, ident roachpb.StoreIdent,
+) error {
+	stateEng, logEng := engs.StateEngine(), engs.LogEngine()
+
+	var rangeIDs []roachpb.RangeID
+	seen := map[roachpb.RangeID]struct{}{}
+	collect := func(rangeID roachpb.RangeID) error {
+		if _, ok := seen[rangeID]; !ok {
+			seen[rangeID] = struct{}{}
+			rangeIDs = append(rangeIDs, rangeID)
+		}
+		return nil
+	}
+	var hs raftpb.HardState
+	if err := IterateIDPrefixKeys(ctx, stateEng, keys.RaftHardStateKey, &hs, collect); err != nil {
+		return err
+	}
+	var ts kvserverpb.RaftTruncatedState
+	if err := IterateIDPrefixKeys(ctx, stateEng, keys.RaftTruncatedStateKey, &ts, collect); err != nil {
+		return err
+	}
+	log.Infof(ctx, "moving Raft log of %d replicas into separate engine", len(rangeIDs))
+
+	logBatch := logEng.NewWriteBatch()
+	defer func() { logBatch.Close() }()
+	// Start from a clean slate in case a previous attempt was interrupted.
+	if err := logBatch.ClearRawRange(
+		keys.LocalRangeIDPrefix.AsRawKey(), keys.LocalRangeIDPrefix.PrefixEnd().AsRawKey(),
+		true /* pointKeys */, false, /* rangeKeys */
+	); err != nil {
+		return err
+	}
+	for _, rangeID := range rangeIDs {
+		if err := copyRaftLogState(ctx, stateEng, logBatch, rangeID); err != nil {
+			return errors.Wrapf(err, "r%d", rangeID)
+		}
+		if logBatch.Len() >= migrationBatchSize {
+			if err := logBatch.Commit(false /* sync */); err != nil {
+				return err
+			}
+			logBatch.Close()
+			logBatch = logEng.NewWriteBatch()
+		}
+	}
+	if err := storage.MVCCBlindPutProto(
+		ctx, logBatch, keys.StoreIdentKey(), hlc.Timestamp{}, &ident, storage.MVCCWriteOptions{},
+	); err != nil {
+		return err
+	}
+	if err := logBatch.Commit(true /* sync */); err != nil {
+		return err
+	}
+	if err := logEng.SetStoreID(ctx, int32(ident.StoreID)); err != nil {
+		return err
+	}
+
+	stateBatch := stateEng.NewWriteBatch()
+	defer stateBatch.Close()
+	for _, rangeID := range rangeIDs {
+		if err := ClearRaftLogState(stateBatch, rangeID); err != nil {
+			return err
+		}
+	}
+	if err := storage.MVCCBlindPutProto(
+		ctx, stateBatch, keys.StoreRaftLogEngineKey(), hlc.Timestamp{}, &ident, storage.MVCCWriteOptions{},
+	); err != nil {
+		return err
+	}
+	if err := stateBatch.Commit(true /* sync */); err != nil {
+		return err
+	}
+	log.Infof(ctx, "moved Raft log of %d replicas into separate engine", len(rangeIDs))
+	return nil
+}
+
+// copyRaftLogState copies the keys cleared by ClearRaftLogState for the given
+// range from the reader into the writer.
+func copyRaftLogState(
+	ctx context.Context, r storage.Reader, w storage.Writer, rangeID roachpb.RangeID,
+) error {
+	for _, span := range raftLogStateSpans(rangeID) {
+		if err := func() error {
+			iter, err := r.NewMVCCIterator(ctx, storage.MVCCKeyIterKind, storage.IterOptions{
+				LowerBound: span.Key,
+				UpperBound: span.EndKey,
+			})
+			if err != nil {
+				return err
+			}
+			defer iter.Close()
+			for iter.SeekGE(storage.MakeMVCCMetadataKey(span.Key)); ; iter.Next() {
+				if ok, err := iter.Valid(); err != nil || !ok {
+					return err
+				}
+				v, err := iter.UnsafeValue()
+				if err != nil {
+					return err
+				}
+				if err := w.PutUnversioned(iter.UnsafeKey().Key, v); err != nil {
+					return err
+				}
+			}
+		}(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileRaftLogEngine brings the Raft state in the log engine in line with
+// the state machine after a crash that interrupted a write to both engines:
+//
+//   - Raft state of replicas which were removed from the state engine is
+//     dropped.
+//   - The Raft log of replicas whose applied state is ahead of, or diverges
+//     from their log (e.g. after a snapshot or split) is reset to the applied
+//     state.
+func reconcileRaftLogEngine(ctx context.Context, engs Engines) error {
+	stateEng, logEng := engs.StateEngine(), engs.LogEngine()
+	batch := logEng.NewBatch()
+	defer batch.Close()
+
+	var orphans []roachpb.RangeID
+	var hs raftpb.HardState
+	if err := IterateIDPrefixKeys(ctx, logEng, keys.RaftHardStateKey, &hs,
+		func(rangeID roachpb.RangeID) error {
+			var id kvserverpb.RaftReplicaID
+			ok, err := storage.MVCCGetProto(ctx, stateEng, keys.RaftReplicaIDKey(rangeID),
+				hlc.Timestamp{}, &id, storage.MVCCGetOptions{})
+			if err == nil && !ok {
+				orphans = append(orphans, rangeID)
+			}
+			return err
+		}); err != nil {
+		return err
+	}
+	for _, rangeID := range orphans {
+		log.Infof(ctx, "r%d: removing Raft state of destroyed replica", rangeID)
+		if err := ClearRaftLogState(batch, rangeID); err != nil {
+			return err
+		}
+	}
+
+	var as kvserverpb.RangeAppliedState
+	if err := IterateIDPrefixKeys(ctx, stateEng, keys.RangeAppliedStateKey, &as,
+		func(rangeID roachpb.RangeID) error {
+			return ReconcileRaftLogState(ctx, batch, rangeID, as.RaftAppliedIndex, as.RaftAppliedIndexTerm)
+		}); err != nil {
+		return err
+	}
+	if batch.Empty() {
+		return nil
+	}
+	return batch.Commit(true /* sync */)
+}
+
+// ReconcileRaftLogState makes the Raft state of a replica consistent with the
+// given applied index and term of its state machine. If the log does not
+// contain the applied entry, it is reset to start right after it, and the
+// HardState is updated to consider it committed.
+func ReconcileRaftLogState(
+	ctx context.Context,
+	rw storage.ReadWriter,
+	rangeID roachpb.RangeID,
+	applied kvpb.RaftIndex,
+	appliedTerm kvpb.RaftTerm,
+) error {
+	sl := logstore.NewStateLoader(rangeID)
+	ts, err := sl.LoadRaftTruncatedState(ctx, rw)
+	if err != nil {
+		return err
+	}
+	if ts.Index > applied {
+		return errors.AssertionFailedf("r%d: Raft log truncated at index %d beyond applied index %d",
+			rangeID, ts.Index, applied)
+	}
+	lastIndex, err := sl.LoadLastIndex(ctx, rw)
+	if err != nil {
+		return err
+	}
+	reset := lastIndex < applied
+	if !reset && ts.Index == applied {
+		reset = ts.Term != appliedTerm
+	} else if !reset {
+		var term kvpb.RaftTerm
+		if err := raftlog.Visit(ctx, rw, rangeID, applied, applied+1, func(ent raftpb.Entry) error {
+			term = kvpb.RaftTerm(ent.Term)
+			return iterutil.StopIteration()
+		}); err != nil {
+			return err
+		}
+		reset = term != appliedTerm
+	}
+
+	hs, err := sl.LoadHardState(ctx, rw)
+	if err != nil {
+		return err
+	}
+	if !reset && hs.Commit >= uint64(applied) {
+		return nil
+	}
+	truncState := kvserverpb.RaftTruncatedState{Index: applied, Term: appliedTerm}
+	if reset {
+		// Committed entries never change, so a log diverging from the applied
+		// state can only differ in its uncommitted suffix.
+		if hs.Commit > uint64(applied) {
+			return errors.AssertionFailedf(
+				"r%d: Raft log diverges from applied index %d below commit index %d",
+				rangeID, applied, hs.Commit)
+		}
+		log.Infof(ctx, "r%d: resetting Raft log (last index %d) to applied index %d",
+			rangeID, lastIndex, applied)
+		prefix := keys.RaftLogPrefix(rangeID)
+		if err := rw.ClearRawRange(
+			prefix, prefix.PrefixEnd(), true /* pointKeys */, false, /* rangeKeys */
+		); err != nil {
+			return err
+		}
+		if err := sl.SetRaftTruncatedState(ctx, rw, &truncState); err != nil {
+			return err
+		}
+	}
+	if hs.Commit >= uint64(applied) {
+		return nil
+	}
+	return sl.SynthesizeHardState(ctx, rw, hs, truncState, applied)
+}
@@ -0,0 +1,174 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvstorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/logstore"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/stateloader"
+	"github.com/cockroachdb/cockroach/pkg/raft/raftpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestRaftState writes the Raft state of a replica whose log holds the
+// entries in (truncIndex, lastIndex], all at the given term, and which has
+// applied the given index.
+func writeTestRaftState(
+	t *testing.T,
+	eng storage.Engine,
+	rangeID roachpb.RangeID,
+	term kvpb.RaftTerm,
+	truncIndex, lastIndex, applied kvpb.RaftIndex,
+) {
+	ctx := context.Background()
+	sl := stateloader.Make(rangeID)
+	require.NoError(t, sl.SetRaftReplicaID(ctx, eng, 1))
+	require.NoError(t, sl.SetHardState(ctx, eng, raftpb.HardState{
+		Term: uint64(term), Commit: uint64(lastIndex),
+	}))
+	require.NoError(t, sl.SetRaftTruncatedState(ctx, eng, &kvserverpb.RaftTruncatedState{
+		Index: truncIndex, Term: term,
+	}))
+	for i := truncIndex + 1; i <= lastIndex; i++ {
+		ent := raftpb.Entry{Index: uint64(i), Term: uint64(term)}
+		require.NoError(t, storage.MVCCBlindPutProto(ctx, eng, keys.RaftLogKey(rangeID, i),
+			hlc.Timestamp{}, &ent, storage.MVCCWriteOptions{}))
+	}
+	require.NoError(t, sl.SetRangeAppliedState(
+		ctx, eng, applied, 1, term, &enginepb.MVCCStats{}, hlc.Timestamp{}, nil))
+}
+
+func TestRaftLogEngineMigration(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	stateEng := storage.NewDefaultInMemForTesting()
+	defer stateEng.Close()
+	logEng := storage.NewDefaultInMemForTesting()
+	defer logEng.Close()
+
+	require.NoError(t, WriteClusterVersion(ctx, stateEng, clusterversion.TestingClusterVersion))
+	ident := roachpb.StoreIdent{ClusterID: uuid.FastMakeV4(), NodeID: 1, StoreID: 1}
+	require.NoError(t, InitEngine(ctx, stateEng, ident))
+
+	writeTestRaftState(t, stateEng, 1, 5, 10, 20, 15)
+	writeTestRaftState(t, stateEng, 2, 6, 10, 12, 12)
+
+	// A store without a log engine starts as usual.
+	require.NoError(t, InitRaftLogEngine(ctx, MakeEngines(stateEng)))
+
+	engs := MakeSeparatedEngines(stateEng, logEng)
+	require.NoError(t, InitRaftLogEngine(ctx, engs))
+
+	for _, rangeID := range []roachpb.RangeID{1, 2} {
+		sl := logstore.NewStateLoader(rangeID)
+		// The Raft state is gone from the state engine...
+		hs, err := sl.LoadHardState(ctx, stateEng)
+		require.NoError(t, err)
+		require.Equal(t, raftpb.HardState{}, hs)
+		ts, err := sl.LoadRaftTruncatedState(ctx, stateEng)
+		require.NoError(t, err)
+		require.Equal(t, kvserverpb.RaftTruncatedState{}, ts)
+		// ... but not the RaftReplicaID.
+		id, err := sl.LoadRaftReplicaID(ctx, stateEng)
+		require.NoError(t, err)
+		require.Equal(t, roachpb.ReplicaID(1), id.ReplicaID)
+	}
+	sl := logstore.NewStateLoader(1)
+	last, err := sl.LoadLastIndex(ctx, logEng)
+	require.NoError(t, err)
+	require.Equal(t, kvpb.RaftIndex(20), last)
+	ts, err := sl.LoadRaftTruncatedState(ctx, logEng)
+	require.NoError(t, err)
+	require.Equal(t, kvserverpb.RaftTruncatedState{Index: 10, Term: 5}, ts)
+
+	logIdent, err := ReadStoreIdent(ctx, logEng)
+	require.NoError(t, err)
+	require.Equal(t, ident, logIdent)
+
+	// Starting again is a no-op, and the replicas load from both engines.
+	require.NoError(t, InitRaftLogEngine(ctx, engs))
+	replicas, err := LoadAndReconcileReplicas(ctx, engs)
+	require.NoError(t, err)
+	require.Len(t, replicas, 2)
+	require.Equal(t, uint64(20), replicas[0].hardState.Commit)
+
+	// The store can no longer start without its log engine.
+	require.ErrorContains(t, InitRaftLogEngine(ctx, MakeEngines(stateEng)),
+		"keeps its Raft log in a separate engine")
+}
+
+func TestReconcileRaftLogState(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		name         string
+		applied      kvpb.RaftIndex
+		appliedTerm  kvpb.RaftTerm
+		expReset     bool
+		expCommit    uint64
+		expLastIndex kvpb.RaftIndex
+	}{
+		{name: "consistent", applied: 15, appliedTerm: 5, expCommit: 15, expLastIndex: 20},
+		{name: "applied ahead", applied: 25, appliedTerm: 7, expReset: true, expCommit: 25, expLastIndex: 25},
+		{name: "diverged", applied: 18, appliedTerm: 6, expReset: true, expCommit: 18, expLastIndex: 18},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			eng := storage.NewDefaultInMemForTesting()
+			defer eng.Close()
+			writeTestRaftState(t, eng, 1, 5, 10, 20, 15)
+			// Only the entries up to index 15 are committed.
+			require.NoError(t, logstore.NewStateLoader(1).SetHardState(ctx, eng,
+				raftpb.HardState{Term: 5, Commit: 15}))
+
+			require.NoError(t, ReconcileRaftLogState(ctx, eng, 1, tc.applied, tc.appliedTerm))
+
+			sl := logstore.NewStateLoader(1)
+			hs, err := sl.LoadHardState(ctx, eng)
+			require.NoError(t, err)
+			require.Equal(t, tc.expCommit, hs.Commit)
+			last, err := sl.LoadLastIndex(ctx, eng)
+			require.NoError(t, err)
+			require.Equal(t, tc.expLastIndex, last)
+			ts, err := sl.LoadRaftTruncatedState(ctx, eng)
+			require.NoError(t, err)
+			if tc.expReset {
+				require.Equal(t, kvserverpb.RaftTruncatedState{Index: tc.applied, Term: tc.appliedTerm}, ts)
+			} else {
+				require.Equal(t, kvserverpb.RaftTruncatedState{Index: 10, Term: 5}, ts)
+			}
+		})
+	}
+
+	// A log truncated beyond the applied index, or diverging below the commit
+	// index, can not be reconciled.
+	eng := storage.NewDefaultInMemForTesting()
+	defer eng.Close()
+	writeTestRaftState(t, eng, 1, 5, 10, 20, 15)
+	require.Error(t, ReconcileRaftLogState(ctx, eng, 1, 5, 5))
+	require.Error(t, ReconcileRaftLogState(ctx, eng, 1, 18, 6))
+}
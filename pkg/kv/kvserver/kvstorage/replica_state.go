@@ -41,13 +41,13 @@ type LoadedReplicaState struct {
 // TODO(pavelkalinnikov): integrate with stateloader.
 func LoadReplicaState(
 	ctx context.Context,
-	eng storage.Reader,
+	engs Engines,
 	storeID roachpb.StoreID,
 	desc *roachpb.RangeDescriptor,
 	replicaID roachpb.ReplicaID,
 ) (LoadedReplicaState, error) {
 	sl := stateloader.Make(desc.RangeID)
-	id, err := sl.LoadRaftReplicaID(ctx, eng)
+	id, err := sl.LoadRaftReplicaID(ctx, engs.StateEngine())
 	if err != nil {
 		return LoadedReplicaState{}, err
 	}
@@ -57,13 +57,13 @@ func LoadReplicaState(
 	}
 
 	ls := LoadedReplicaState{ReplicaID: replicaID}
-	if ls.hardState, err = sl.LoadHardState(ctx, eng); err != nil {
+	if ls.hardState, err = sl.LoadHardState(ctx, engs.LogEngine()); err != nil {
 		return LoadedReplicaState{}, err
 	}
-	if ls.LastIndex, err = sl.LoadLastIndex(ctx, eng); err != nil {
+	if ls.LastIndex, err = sl.LoadLastIndex(ctx, engs.LogEngine()); err != nil {
 		return LoadedReplicaState{}, err
 	}
-	if ls.ReplState, err = sl.Load(ctx, eng, desc); err != nil {
+	if ls.ReplState, err = LoadReplState(ctx, engs, desc); err != nil {
 		return LoadedReplicaState{}, err
 	}
 
@@ -108,7 +108,7 @@ func (r LoadedReplicaState) check(storeID roachpb.StoreID) error {
 // because it has been deleted.
 func CreateUninitializedReplica(
 	ctx context.Context,
-	eng storage.Engine,
+	engs Engines,
 	storeID roachpb.StoreID,
 	rangeID roachpb.RangeID,
 	replicaID roachpb.ReplicaID,
@@ -118,7 +118,7 @@ func CreateUninitializedReplica(
 	tombstoneKey := keys.RangeTombstoneKey(rangeID)
 	var tombstone kvserverpb.RangeTombstone
 	if ok, err := storage.MVCCGetProto(
-		ctx, eng, tombstoneKey, hlc.Timestamp{}, &tombstone, storage.MVCCGetOptions{},
+		ctx, engs.StateEngine(), tombstoneKey, hlc.Timestamp{}, &tombstone, storage.MVCCGetOptions{},
 	); err != nil {
 		return err
 	} else if ok && replicaID < tombstone.NextReplicaID {
@@ -138,12 +138,12 @@ func CreateUninitializedReplica(
 	//   this newer replica is harmless since it just limits the votes for
 	//   this replica.
 	sl := stateloader.Make(rangeID)
-	if err := sl.SetRaftReplicaID(ctx, eng, replicaID); err != nil {
+	if err := sl.SetRaftReplicaID(ctx, engs.StateEngine(), replicaID); err != nil {
 		return err
 	}
 
 	// Make sure that storage invariants for this uninitialized replica hold.
 	uninitDesc := roachpb.RangeDescriptor{RangeID: rangeID}
-	_, err := LoadReplicaState(ctx, eng, storeID, &uninitDesc, replicaID)
+	_, err := LoadReplicaState(ctx, engs, storeID, &uninitDesc, replicaID)
 	return err
 }
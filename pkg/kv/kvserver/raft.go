@@ -25,8 +25,10 @@ import (
 	"github.com/cockroachdb/redact"
 )
 
-// maxRaftMsgType is the maximum value in the raft.MessageType enum.
-const maxRaftMsgType = raftpb.MsgForgetLeader
+// maxRaftMsgType is the maximum value in the raft.MessageType enum. Note that
+// MsgFortifyLeader and MsgDeFortifyLeader are only sent once the
+// V24_1_LeaderLeases cluster version is active, see SupportFromEnabled.
+const maxRaftMsgType = raftpb.MsgDeFortifyLeader
 
 func init() {
 	for v := range raftpb.MessageType_name {
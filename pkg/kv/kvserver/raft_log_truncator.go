@@ -228,8 +228,11 @@ type storeForTruncator interface {
 	acquireReplicaForTruncator(rangeID roachpb.RangeID) replicaForTruncator
 	// releaseReplicaForTruncator releases the replica.
 	releaseReplicaForTruncator(r replicaForTruncator)
-	// Engine accessor.
+	// Engine accessor. This is the engine holding the state machine, whose
+	// durable RaftAppliedIndex bounds the truncations that can be enacted.
 	getEngine() storage.Engine
+	// getLogEngine returns the engine holding the raft log.
+	getLogEngine() storage.Engine
 }
 
 // replicaForTruncator abstracts the interface of Replica needed by the
@@ -555,7 +558,7 @@ func (t *raftLogTruncator) tryEnactTruncations(
 	}
 	// Do the truncation of persistent raft entries, specified by enactIndex
 	// (this subsumes all the preceding queued truncations).
-	batch := t.store.getEngine().NewUnindexedBatch()
+	batch := t.store.getLogEngine().NewUnindexedBatch()
 	defer batch.Close()
 	apply, err := handleTruncatedStateBelowRaftPreApply(ctx, &truncState,
 		&pendingTruncs.mu.truncs[enactIndex].RaftTruncatedState, stateLoader, batch)
@@ -584,6 +587,10 @@ func (t *raftLogTruncator) tryEnactTruncations(
 	}
 	// Truncation done. Need to update the Replica state. This requires iterating
 	// over all the enacted entries.
+	//
+	// When the raft log lives in a separate engine, the log delta computed
+	// during evaluation did not see the log entries, so it is never trusted.
+	logSeparated := t.store.getLogEngine() != t.store.getEngine()
 	pendingTruncs.iterateLocked(func(index int, trunc pendingTruncation) {
 		if index > enactIndex {
 			return
@@ -591,7 +598,7 @@ func (t *raftLogTruncator) tryEnactTruncations(
 		isDeltaTrusted := true
 		expectedFirstIndexWasAccurate := r.setTruncatedStateAndSideEffects(
 			ctx, &trunc.RaftTruncatedState, trunc.expectedFirstIndex)
-		if !expectedFirstIndexWasAccurate || !trunc.isDeltaTrusted {
+		if !expectedFirstIndexWasAccurate || !trunc.isDeltaTrusted || logSeparated {
 			isDeltaTrusted = false
 		}
 		r.setTruncationDeltaAndTrusted(trunc.logDeltaBytes, isDeltaTrusted)
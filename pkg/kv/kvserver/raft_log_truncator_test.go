@@ -262,6 +262,10 @@ func (s *storeTruncatorTest) getEngine() storage.Engine {
 	return s.eng
 }
 
+func (s *storeTruncatorTest) getLogEngine() storage.Engine {
+	return s.eng
+}
+
 func (s *storeTruncatorTest) acquireReplicaForTruncator(
 	rangeID roachpb.RangeID,
 ) replicaForTruncator {
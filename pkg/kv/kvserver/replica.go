@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/gc"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/hotkeys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/load"
@@ -270,6 +271,11 @@ type Replica struct {
 	// inform load based lease and replica rebalancing decisions.
 	loadStats *load.ReplicaLoad
 
+	// hotKeys estimates the hottest keys of this replica from a sample of its
+	// requests, and rate limits requests to them according to the span
+	// config's max_qps_per_key.
+	hotKeys *hotkeys.Finder
+
 	// Held in read mode during read-only commands. Held in exclusive mode to
 	// prevent read-only commands from executing. Acquired before the embedded
 	// RWMutex.
@@ -1700,6 +1706,14 @@ func (r *Replica) assertStateRaftMuLockedReplicaMuRLocked(
 	if err != nil {
 		log.Fatalf(ctx, "%v", err)
 	}
+	if r.store.engines().Separated() {
+		// The RaftTruncatedState lives in the log engine.
+		ts, err := r.mu.stateLoader.LoadRaftTruncatedState(ctx, r.store.LogEngine())
+		if err != nil {
+			log.Fatalf(ctx, "%v", err)
+		}
+		diskState.TruncatedState = &ts
+	}
 
 	// We don't care about this field; see comment on
 	// DeprecatedUsingAppliedStateKey for more details. This can be removed once
@@ -64,6 +64,11 @@ type replicaAppBatch struct {
 	// sideloaded storage file. Such commands may apply side effects only after
 	// their application to state machine is synced.
 	changeTruncatesSideloadedFiles bool
+	// changeWritesRaftLogEngine tracks whether the command in the batch (there
+	// must be only one) is a split or merge whose Raft state changes are written
+	// to a separate Raft log engine once the batch has been applied. Such
+	// commands must sync the state machine first.
+	changeWritesRaftLogEngine bool
 
 	start                   time.Time // time at NewBatch()
 	followerStoreWriteBytes kvadmission.FollowerStoreWriteBytes
@@ -136,8 +141,17 @@ func (b *replicaAppBatch) Stage(
 		return nil, err
 	}
 
-	// Stage the command's write batch in the application batch.
-	if err := b.ab.addWriteBatch(ctx, b.batch, cmd); err != nil {
+	// Stage the command's write batch in the application batch. Witnesses
+	// only stage the parts of it which don't contain user data, and don't
+	// ingest SSTables.
+	if isWitness(b.state.Desc, b.r.replicaID) {
+		if err := stageWitnessWriteBatch(b.batch, cmd.Cmd.WriteBatch); err != nil {
+			return nil, err
+		}
+		res := cmd.ReplicatedResult()
+		res.AddSSTable = nil
+		res.LinkExternalSSTable = nil
+	} else if err := b.ab.addWriteBatch(ctx, b.batch, cmd); err != nil {
 		return nil, err
 	}
 
@@ -187,6 +201,19 @@ func changeRemovesStore(
 	return !existsInChange
 }
 
+// changePromotesStoreToWitness returns true if the change turns the replica on
+// the given store into a witness.
+func changePromotesStoreToWitness(
+	desc *roachpb.RangeDescriptor, change *kvserverpb.ChangeReplicas, storeID roachpb.StoreID,
+) bool {
+	prev, ok := desc.GetReplicaDescriptor(storeID)
+	if !ok || prev.IsWitness() {
+		return false
+	}
+	next, ok := change.Desc.GetReplicaDescriptor(storeID)
+	return ok && next.IsWitness()
+}
+
 // runPreAddTriggersReplicaOnly is like (appBatch).runPreAddTriggers (and is
 // called right after it), except that it must only contain ephemeral side
 // effects that have no influence on durable state. It is not invoked during
@@ -295,6 +322,10 @@ func (b *replicaAppBatch) runPostAddTriggersReplicaOnly(
 		res.LinkExternalSSTable = nil
 	}
 
+	if (res.Split != nil || res.Merge != nil) && b.r.store.engines().Separated() {
+		b.changeWritesRaftLogEngine = true
+	}
+
 	if res.Split != nil {
 		// Splits require a new HardState to be written to the new RHS
 		// range (and this needs to be atomic with the main batch). This
@@ -419,7 +450,13 @@ func (b *replicaAppBatch) runPostAddTriggersReplicaOnly(
 		// it, the loosely coupled code will mark the log size as untrusted and
 		// will recompute the size. This has no correctness impact, so we are not
 		// going to bother with a long-running migration.
-		apply := !looselyCoupledTruncation || res.RaftExpectedFirstIndex == 0
+		//
+		// When the raft log lives in a separate engine, truncations are always
+		// loosely coupled: the log engine batch can not commit atomically with
+		// this one, and must not remove entries before the applied state that
+		// makes them unnecessary is durable.
+		apply := !b.r.store.engines().Separated() &&
+			(!looselyCoupledTruncation || res.RaftExpectedFirstIndex == 0)
 		if apply {
 			if apply, err = handleTruncatedStateBelowRaftPreApply(
 				ctx, b.state.TruncatedState, res.State.TruncatedState, b.r.raftMu.stateLoader, b.batch,
@@ -515,6 +552,16 @@ func (b *replicaAppBatch) runPostAddTriggersReplicaOnly(
 		}
 	}
 
+	// Detect if this command promotes us from a learner to a witness. If so, we
+	// stage the removal of the user data we received in our initial snapshot
+	// and applied since.
+	if change := res.ChangeReplicas; change != nil && !b.changeRemovesReplica &&
+		changePromotesStoreToWitness(b.state.Desc, change, b.r.store.StoreID()) {
+		if err := clearWitnessUserData(b.batch, b.state.Desc); err != nil {
+			return errors.Wrapf(err, "unable to clear user data of witness")
+		}
+	}
+
 	// Provide the command's corresponding logical operations to the Replica's
 	// rangefeed. Only do so if the WriteBatch is non-nil, in which case the
 	// rangefeed requires there to be a corresponding logical operation log or
@@ -600,13 +647,16 @@ func (b *replicaAppBatch) ApplyToStateMachine(ctx context.Context) error {
 	// cluster setting is the default, we will no longer need to sync here upon
 	// log truncations. The sync will happen by other means with a lag.
 	//
+	// Similarly, with a separate Raft log engine, splits and merges are synced
+	// because they update the Raft state in the log engine after this batch.
+	//
 	// TODO(sep-raft-log): when the log and state machine engines are completely
 	// separated, we must either sync here unconditionally upon log truncation
 	// (which would be expensive), or apply the side effects (remove the entries)
 	// asynchronously when sure that the state machine engine has synced the
 	// application of this command. I.e. the loosely coupled truncation migration
 	// mentioned above likely needs to be done first.
-	sync := b.changeRemovesReplica || b.changeTruncatesSideloadedFiles
+	sync := b.changeRemovesReplica || b.changeTruncatesSideloadedFiles || b.changeWritesRaftLogEngine
 	if err := b.batch.Commit(sync); err != nil {
 		return errors.Wrapf(err, "unable to commit Raft entry batch")
 	}
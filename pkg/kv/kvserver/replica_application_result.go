@@ -551,6 +551,11 @@ func (r *Replica) handleVersionResult(ctx context.Context, version *roachpb.Vers
 }
 
 func (r *Replica) handleComputeChecksumResult(ctx context.Context, cc *kvserverpb.ComputeChecksum) {
+	if isWitness(r.Desc(), r.replicaID) {
+		// Witnesses don't store user data, so they aren't checked for
+		// consistency.
+		return
+	}
 	err := r.computeChecksumPostApply(ctx, *cc)
 	// Don't log errors caused by the store quiescing, they are expected.
 	if err != nil && !errors.Is(err, stop.ErrUnavailable) {
@@ -1163,6 +1163,10 @@ func (r *Replica) changeReplicasImpl(
 		return nil, errors.Mark(err, errMarkInvalidReplicationChange)
 	}
 	targets := SynthesizeTargetsByChangeType(chgs)
+	if len(targets.WitnessAdditions) > 0 &&
+		!r.store.ClusterSettings().Version.IsActive(ctx, clusterversion.V24_1_WitnessReplicas) {
+		return nil, errors.Newf("cannot add witness replicas until the cluster version is finalized")
+	}
 
 	// NB: As of the time of this writing,`AdminRelocateRange` will only execute
 	// replication changes one by one. Thus, the order in which we execute the
@@ -1175,6 +1179,8 @@ func (r *Replica) changeReplicasImpl(
 	// 3. Voter removals
 	// 4. Non-voter additions
 	// 5. Non-voter removals
+	// 6. Witness additions
+	// 7. Witness removals
 	//
 	// This order is meant to be symmetric with how the allocator prioritizes
 	// these actions. Broadly speaking, we first want to add a missing voter (and
@@ -1286,6 +1292,58 @@ func (r *Replica) changeReplicasImpl(
 		}
 	}
 
+	if adds := targets.WitnessAdditions; len(adds) > 0 {
+		// Like voters, witnesses are first added as LEARNER replicas and only
+		// promoted once they have caught up using their initial snapshot, so
+		// that they don't count towards the quorum before then. The user data
+		// in that snapshot is dropped by the replica when it is promoted.
+		desc, err = r.initializeRaftLearners(
+			ctx, desc, senderName, senderQueuePriority, reason, details, adds, roachpb.LEARNER,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, add := range adds {
+			iChgs := []internalReplicationChange{{target: add, typ: internalChangeTypePromoteLearnerToWitness}}
+			var err error
+			desc, err = execChangeReplicasTxn(ctx, r.store.cfg.Tracer(), desc, reason, details, iChgs,
+				changeReplicasTxnArgs{
+					db:                                   r.store.DB(),
+					liveAndDeadReplicas:                  r.store.cfg.StorePool.LiveAndDeadReplicas,
+					logChange:                            r.store.logChange,
+					testForceJointConfig:                 r.store.TestingKnobs().ReplicationAlwaysUseJointConfig,
+					testAllowDangerousReplicationChanges: r.store.TestingKnobs().AllowDangerousReplicationChanges,
+				})
+			if err != nil {
+				// Don't leave learners lying around if we didn't succeed in
+				// promoting them to witnesses.
+				log.Infof(ctx, "could not promote %v to witness, rolling back: %v", adds, err)
+				for _, target := range adds {
+					r.tryRollbackRaftLearner(ctx, r.Desc(), target, reason, details)
+				}
+				return nil, err
+			}
+		}
+	}
+
+	if removals := targets.WitnessRemovals; len(removals) > 0 {
+		for _, rem := range removals {
+			iChgs := []internalReplicationChange{{target: rem, typ: internalChangeTypeRemoveWitness}}
+			var err error
+			desc, err = execChangeReplicasTxn(ctx, r.store.cfg.Tracer(), desc, reason, details, iChgs,
+				changeReplicasTxnArgs{
+					db:                                   r.store.DB(),
+					liveAndDeadReplicas:                  r.store.cfg.StorePool.LiveAndDeadReplicas,
+					logChange:                            r.store.logChange,
+					testForceJointConfig:                 r.store.TestingKnobs().ReplicationAlwaysUseJointConfig,
+					testAllowDangerousReplicationChanges: r.store.TestingKnobs().AllowDangerousReplicationChanges,
+				})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if len(targets.VoterDemotions) > 0 {
 		// If we demoted or swapped any voters with non-voters, we likely are in a
 		// joint config or have learners on the range. Let's exit the joint config
@@ -1302,6 +1360,7 @@ type TargetsForReplicationChanges struct {
 	VoterDemotions, NonVoterPromotions  []roachpb.ReplicationTarget
 	VoterAdditions, VoterRemovals       []roachpb.ReplicationTarget
 	NonVoterAdditions, NonVoterRemovals []roachpb.ReplicationTarget
+	WitnessAdditions, WitnessRemovals   []roachpb.ReplicationTarget
 }
 
 // SynthesizeTargetsByChangeType groups replication changes in the
@@ -1328,6 +1387,10 @@ func SynthesizeTargetsByChangeType(
 	result.NonVoterAdditions = subtractTargets(chgs.NonVoterAdditions(), chgs.VoterRemovals())
 	result.NonVoterRemovals = subtractTargets(chgs.NonVoterRemovals(), chgs.VoterAdditions())
 
+	// Witnesses are never promoted or demoted.
+	result.WitnessAdditions = chgs.WitnessAdditions()
+	result.WitnessRemovals = chgs.WitnessRemovals()
+
 	return result
 }
 
@@ -1586,6 +1649,9 @@ func validateAdditionsPerStore(
 					return errors.AssertionFailedf(
 						"trying to add a non-voter to a store that already has a %s", t)
 				}
+			case roachpb.WITNESS:
+				return errors.AssertionFailedf(
+					"trying to add(%+v) to a store that already has a %s", chg, t)
 			default:
 				return errors.AssertionFailedf("store(%d) being added to already contains a"+
 					" replica of an unexpected type: %s", storeID, t)
@@ -1620,6 +1686,11 @@ func validateRemovals(desc *roachpb.RangeDescriptor, chgsByStoreID changesByStor
 					return errors.AssertionFailedf("type of replica being removed (%s) does not match"+
 						" expectation for change: %+v", t, chg)
 				}
+			case roachpb.WITNESS:
+				if chg.ChangeType != roachpb.REMOVE_WITNESS {
+					return errors.AssertionFailedf("type of replica being removed (%s) does not match"+
+						" expectation for change: %+v", t, chg)
+				}
 			default:
 				return errors.AssertionFailedf("unexpected replica type for removal %+v: %s", chg, t)
 			}
@@ -2164,6 +2235,12 @@ const (
 	// https://github.com/cockroachdb/cockroach/pull/40268
 	internalChangeTypeRemoveLearner
 	internalChangeTypeRemoveNonVoter
+	// internalChangeTypePromoteLearnerToWitness promotes a learner to a
+	// witness, and internalChangeTypeRemoveWitness removes a witness. Since
+	// witnesses are never part of a joint config, these are always simple
+	// changes when executed on their own.
+	internalChangeTypePromoteLearnerToWitness
+	internalChangeTypeRemoveWitness
 )
 
 // internalReplicationChange is a replication target together with an internal
@@ -2217,6 +2294,16 @@ func prepareChangeReplicasTrigger(
 			case internalChangeTypeAddNonVoter:
 				added = append(added,
 					updatedDesc.AddReplica(chg.target.NodeID, chg.target.StoreID, roachpb.NON_VOTER))
+			case internalChangeTypePromoteLearnerToWitness:
+				if useJoint {
+					return nil, errors.Errorf("cannot promote %v to witness in a joint config", chg.target)
+				}
+				rDesc, prevTyp, ok := updatedDesc.SetReplicaType(chg.target.NodeID, chg.target.StoreID, roachpb.WITNESS)
+				if !ok || prevTyp != roachpb.LEARNER {
+					return nil, errors.Errorf("cannot promote target %v which is missing as LEARNER",
+						chg.target)
+				}
+				added = append(added, rDesc)
 			case internalChangeTypePromoteLearner:
 				typ := roachpb.VOTER_FULL
 				if useJoint {
@@ -2257,6 +2344,19 @@ func prepareChangeReplicasTrigger(
 					rDesc, _, _ = updatedDesc.SetReplicaType(chg.target.NodeID, chg.target.StoreID, roachpb.VOTER_OUTGOING)
 				}
 				removed = append(removed, rDesc)
+			case internalChangeTypeRemoveWitness:
+				rDesc, ok := updatedDesc.GetReplicaDescriptor(chg.target.StoreID)
+				if !ok {
+					return nil, errors.Errorf("target %s not found", chg.target)
+				}
+				if useJoint {
+					return nil, errors.Errorf("cannot remove witness %v in a joint config", chg.target)
+				}
+				if prevTyp := rDesc.Type; prevTyp != roachpb.WITNESS {
+					return nil, errors.Errorf("cannot remove target %v which is missing as WITNESS", chg.target)
+				}
+				rDesc, _ = updatedDesc.RemoveReplica(chg.target.NodeID, chg.target.StoreID)
+				removed = append(removed, rDesc)
 			case internalChangeTypeDemoteVoterToLearner:
 				// Demotion is similar to removal, except that a demotion
 				// cannot apply to a learner, and that the resulting type is
@@ -2645,16 +2745,21 @@ func recordRangeEventsInLog(
 ) error {
 	for _, repDesc := range repDescs {
 		isNonVoter := repDesc.Type == roachpb.NON_VOTER
+		isWitness := repDesc.Type == roachpb.WITNESS
 		var typ roachpb.ReplicaChangeType
 		if added {
 			typ = roachpb.ADD_VOTER
 			if isNonVoter {
 				typ = roachpb.ADD_NON_VOTER
+			} else if isWitness {
+				typ = roachpb.ADD_WITNESS
 			}
 		} else {
 			typ = roachpb.REMOVE_VOTER
 			if isNonVoter {
 				typ = roachpb.REMOVE_NON_VOTER
+			} else if isWitness {
+				typ = roachpb.REMOVE_WITNESS
 			}
 		}
 		if err := logChange(
@@ -3269,13 +3374,15 @@ func (r *Replica) followerSendSnapshot(
 	// a snapshot for a non-system range. This allows us to send metadata of
 	// sstables in shared storage as opposed to streaming their contents. Keys
 	// in higher levels of the LSM are still streamed in the snapshot.
+	// Witnesses don't receive any user data, see kvBatchSnapshotStrategy.Send.
 	nonSystemRange := snap.State.Desc.StartKey.AsRawKey().Compare(keys.TableDataMin) >= 0
-	sharedReplicate := r.store.cfg.SharedStorageEnabled && nonSystemRange
+	witness := req.RecipientReplica.IsWitness()
+	sharedReplicate := r.store.cfg.SharedStorageEnabled && nonSystemRange && !witness
 
 	// Use external replication if we aren't using shared
 	// replication, are dealing with a non-system range, are on at
 	// least 24.1, and our store has external files.
-	externalReplicate := !sharedReplicate && nonSystemRange &&
+	externalReplicate := !sharedReplicate && nonSystemRange && !witness &&
 		r.store.ClusterSettings().Version.IsActive(ctx, clusterversion.V24_1) &&
 		externalFileSnapshotting.Get(&r.store.ClusterSettings().SV)
 	if externalReplicate {
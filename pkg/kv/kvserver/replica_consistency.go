@@ -15,6 +15,7 @@ import (
 	"crypto/sha512"
 	"encoding/binary"
 	"fmt"
+	"hash/fnv"
 	"os"
 	"sync"
 	"time"
@@ -63,7 +64,10 @@ type replicaChecksum struct {
 //
 // When req.Mode is CHECK_VIA_QUEUE and an inconsistency is detected, the
 // consistency check will be re-run to save storage engine checkpoints and
-// terminate suspicious nodes. This behavior should be lifted to the consistency
+// terminate suspicious nodes, or, if server.consistency_check.auto_repair.enabled
+// is set and the diverged replicas can be identified with confidence in both
+// rounds, to remove them from the range. The second round also computes a
+// key-level diff between the replicas. This behavior should be lifted to the consistency
 // checker queue in the future.
 func (r *Replica) CheckConsistency(
 	ctx context.Context, req kvpb.CheckConsistencyRequest,
@@ -80,10 +84,152 @@ func (r *Replica) checkConsistencyImpl(
 ) (kvpb.CheckConsistencyResponse, *kvpb.Error) {
 	isQueue := args.Mode == kvpb.ChecksumMode_CHECK_VIA_QUEUE
 
-	results, err := r.runConsistencyCheck(ctx, args)
+	res, round, err := r.runConsistencyCheckRound(ctx, args)
 	if err != nil {
 		return kvpb.CheckConsistencyResponse{}, kvpb.NewError(err)
 	}
+	results, shaToIdxs, missing, minoritySHA := round.results, round.shaToIdxs, round.missing, round.minoritySHA
+
+	var resp kvpb.CheckConsistencyResponse
+	resp.Result = append(resp.Result, res)
+
+	// Bail out at this point except if the queue is the caller. All of the stuff
+	// below should really happen in the consistency queue to keep CheckConsistency
+	// itself self-contained.
+	if !isQueue {
+		return resp, nil
+	}
+
+	if minoritySHA == "" {
+		// The replicas were in sync. Check that the MVCCStats haven't diverged from
+		// what they should be. This code originated in the realization that there
+		// were many bugs in our stats computations. These are being fixed, but it
+		// is through this mechanism that existing ranges are updated. Hence, the
+		// logging below is relatively timid.
+
+		// If there's no delta, there's nothing else to do.
+		if !round.haveDelta {
+			return resp, nil
+		}
+
+		// We've found that there's something to correct; send an RecomputeStatsRequest. Note that this
+		// code runs only on the lease holder (at the time of initiating the computation), so this work
+		// isn't duplicated except in rare leaseholder change scenarios (and concurrent invocation of
+		// RecomputeStats is allowed because these requests block on one another). Also, we're
+		// essentially paced by the consistency checker so we won't call this too often.
+		log.Infof(ctx, "triggering stats recomputation to resolve delta of %+v", results[0].Response.Delta)
+
+		var b kv.Batch
+		b.AddRawRequest(&kvpb.RecomputeStatsRequest{
+			RequestHeader: kvpb.RequestHeader{Key: args.Key},
+		})
+		err := r.store.db.Run(ctx, &b)
+		return resp, kvpb.NewError(err)
+	}
+
+	if args.Checkpoint {
+		// A checkpoint/termination request has already been sent. Return because
+		// all the code below will do is request another consistency check, with
+		// instructions to make a checkpoint and to terminate the minority nodes.
+		log.Errorf(ctx, "consistency check failed")
+		return resp, nil
+	}
+
+	// Decide whether the diverged replicas can be repaired rather than having
+	// their nodes terminated.
+	var repair []roachpb.ReplicaDescriptor
+	if consistencyCheckAutoRepair.Get(&r.store.ClusterSettings().SV) {
+		var reason redact.RedactableString
+		repair, reason = r.replicasToRepair(results, shaToIdxs, len(missing))
+		if repair == nil {
+			log.Errorf(ctx, "consistency check failed; not repairing automatically: %s", reason)
+		}
+	}
+
+	// No checkpoint was requested, so we want to re-run the check with
+	// checkpoints and termination of suspicious nodes (unless we are going to
+	// repair them). Note that this recursive call will be terminated in the
+	// `args.Checkpoint` branch above.
+	args.Checkpoint = true
+	if repair != nil {
+		log.Errorf(ctx, "consistency check failed; fetching details and repairing %v",
+			roachpb.MakeReplicaSet(repair))
+		// The second round saves checkpoints and computes the key-level diff. Only
+		// the replicas that diverged in both rounds are repaired. If the second
+		// round doesn't confirm the inconsistency with confidence, leave the
+		// replicas alone and let the next check of the range decide.
+		res2, round2, err := r.runConsistencyCheckRound(ctx, args)
+		if err != nil {
+			log.Errorf(ctx, "replica inconsistency detected; second round failed, not repairing: %s", err)
+			return resp, nil
+		}
+		resp.Result[0].Detail += "checkpoint round:" + res2.Detail
+		confirmed, reason := r.confirmReplicasToRepair(repair, round2)
+		if confirmed == nil {
+			log.Errorf(ctx, "replica inconsistency detected; not repairing automatically: %s", reason)
+			return resp, nil
+		}
+		if err := r.repairInconsistentReplicas(ctx, confirmed, resp.Result[0].Detail); err != nil {
+			log.Errorf(ctx, "unable to repair replica inconsistency: %v", err)
+		}
+		return resp, nil
+	}
+	for _, idxs := range shaToIdxs[minoritySHA] {
+		args.Terminate = append(args.Terminate, results[idxs].Replica)
+	}
+	// args.Terminate is a slice of properly redactable values, but
+	// with %v `redact` will not realize that and will redact the
+	// whole thing. Wrap it as a ReplicaSet which is a SafeFormatter
+	// and will get the job done.
+	//
+	// TODO(knz): clean up after https://github.com/cockroachdb/redact/issues/5.
+	{
+		var tmp redact.SafeFormatter = roachpb.MakeReplicaSet(args.Terminate)
+		log.Errorf(ctx, "consistency check failed; fetching details and shutting down minority %v", tmp)
+	}
+
+	// We've noticed in practice that if the snapshot diff is large, the
+	// log file to which it is printed is promptly rotated away, so up
+	// the limits while the diff printing occurs.
+	//
+	// See:
+	// https://github.com/cockroachdb/cockroach/issues/36861
+	// TODO(pavelkalinnikov): remove this now that diffs are not printed?
+	defer log.TemporarilyDisableFileGCForMainLogger()()
+
+	if _, pErr := r.checkConsistencyImpl(ctx, args); pErr != nil {
+		log.Errorf(ctx, "replica inconsistency detected; second round failed: %s", pErr)
+	}
+
+	return resp, nil
+}
+
+// consistencyCheckRound holds the per-replica outcome of a round of
+// ComputeChecksum/CollectChecksum.
+type consistencyCheckRound struct {
+	results []ConsistencyCheckResult
+	// shaToIdxs maps each checksum to the indexes of the results carrying it.
+	shaToIdxs map[string][]int
+	// missing are the results of the replicas that failed to return a checksum.
+	missing []ConsistencyCheckResult
+	// minoritySHA is non-empty if and only if the replicas are inconsistent.
+	minoritySHA string
+	// haveDelta is set if the recomputed stats differ from the persisted ones.
+	haveDelta bool
+}
+
+// runConsistencyCheckRound runs a round of the consistency check and assembles
+// its result. In the checkpoint round, the result details also contain the
+// key-level diff between the diverged replicas.
+func (r *Replica) runConsistencyCheckRound(
+	ctx context.Context, args kvpb.ComputeChecksumRequest,
+) (kvpb.CheckConsistencyResponse_Result, consistencyCheckRound, error) {
+	isQueue := args.Mode == kvpb.ChecksumMode_CHECK_VIA_QUEUE
+
+	results, err := r.runConsistencyCheck(ctx, args)
+	if err != nil {
+		return kvpb.CheckConsistencyResponse_Result{}, consistencyCheckRound{}, err
+	}
 
 	res := kvpb.CheckConsistencyResponse_Result{RangeID: r.RangeID}
 
@@ -133,6 +279,9 @@ func (r *Replica) checkConsistencyImpl(
 				)
 			}
 		}
+		if args.Checkpoint {
+			writeKeyDiffs(&buf, results, shaToIdxs)
+		}
 
 		if isQueue {
 			log.Errorf(ctx, "%v", &buf)
@@ -185,83 +334,226 @@ func (r *Replica) checkConsistencyImpl(
 		// No inconsistency was detected, but we didn't manage to inspect all replicas.
 		res.Status = kvpb.CheckConsistencyResponse_RANGE_INDETERMINATE
 	}
-	var resp kvpb.CheckConsistencyResponse
-	resp.Result = append(resp.Result, res)
+	return res, consistencyCheckRound{
+		results:     results,
+		shaToIdxs:   shaToIdxs,
+		missing:     missing,
+		minoritySHA: minoritySHA,
+		haveDelta:   haveDelta,
+	}, nil
+}
 
-	// Bail out at this point except if the queue is the caller. All of the stuff
-	// below should really happen in the consistency queue to keep CheckConsistency
-	// itself self-contained.
-	if !isQueue {
-		return resp, nil
+// replicasToRepair returns the replicas that need to be repaired after a failed
+// consistency check, or nil along with an explanation if the check results do
+// not identify the diverged replicas with confidence. That is the case unless
+// all replicas responded, a strict majority of them agree on the checksum, and
+// the local replica (which sends the snapshots used to replace the diverged
+// ones) is part of that majority.
+func (r *Replica) replicasToRepair(
+	results []ConsistencyCheckResult, shaToIdxs map[string][]int, numMissing int,
+) ([]roachpb.ReplicaDescriptor, redact.RedactableString) {
+	if numMissing > 0 {
+		return nil, redact.Sprintf("%d replicas did not return a checksum", numMissing)
 	}
-
-	if minoritySHA == "" {
-		// The replicas were in sync. Check that the MVCCStats haven't diverged from
-		// what they should be. This code originated in the realization that there
-		// were many bugs in our stats computations. These are being fixed, but it
-		// is through this mechanism that existing ranges are updated. Hence, the
-		// logging below is relatively timid.
-
-		// If there's no delta, there's nothing else to do.
-		if !haveDelta {
-			return resp, nil
+	var majoritySHA string
+	for sha, idxs := range shaToIdxs {
+		if 2*len(idxs) > len(results) {
+			majoritySHA = sha
+		}
+	}
+	if majoritySHA == "" {
+		return nil, "no majority of replicas agrees on the range contents"
+	}
+	var repair []roachpb.ReplicaDescriptor
+	for sha, idxs := range shaToIdxs {
+		if sha == majoritySHA {
+			continue
+		}
+		for _, idx := range idxs {
+			if results[idx].Replica.StoreID == r.store.StoreID() {
+				return nil, "the local replica diverged from the majority"
+			}
+			repair = append(repair, results[idx].Replica)
 		}
+	}
+	return repair, ""
+}
 
-		// We've found that there's something to correct; send an RecomputeStatsRequest. Note that this
-		// code runs only on the lease holder (at the time of initiating the computation), so this work
-		// isn't duplicated except in rare leaseholder change scenarios (and concurrent invocation of
-		// RecomputeStats is allowed because these requests block on one another). Also, we're
-		// essentially paced by the consistency checker so we won't call this too often.
-		log.Infof(ctx, "triggering stats recomputation to resolve delta of %+v", results[0].Response.Delta)
+// confirmReplicasToRepair returns the replicas picked for repair after the
+// first round of a failed consistency check that diverged again in the
+// checkpoint round, or nil along with an explanation if there are none. A
+// replica whose divergence is not confirmed by the second round is left alone.
+func (r *Replica) confirmReplicasToRepair(
+	repair []roachpb.ReplicaDescriptor, round consistencyCheckRound,
+) ([]roachpb.ReplicaDescriptor, redact.RedactableString) {
+	if round.minoritySHA == "" {
+		return nil, "the checkpoint round found no inconsistency"
+	}
+	repair2, reason := r.replicasToRepair(round.results, round.shaToIdxs, len(round.missing))
+	if repair2 == nil {
+		return nil, redact.Sprintf("checkpoint round: %s", reason)
+	}
+	var confirmed []roachpb.ReplicaDescriptor
+	for _, rDesc := range repair {
+		for _, rDesc2 := range repair2 {
+			if rDesc.ReplicaID == rDesc2.ReplicaID {
+				confirmed = append(confirmed, rDesc)
+				break
+			}
+		}
+	}
+	if confirmed == nil {
+		return nil, "no replica diverged in both rounds"
+	}
+	return confirmed, ""
+}
 
-		var b kv.Batch
-		b.AddRawRequest(&kvpb.RecomputeStatsRequest{
-			RequestHeader: kvpb.RequestHeader{Key: args.Key},
+// repairInconsistentReplicas removes the given diverged replicas from the
+// range, recording each removal along with the consistency check details in
+// system.rangelog, and then queues the range for up-replication so that the
+// removed replicas are replaced by snapshots of a healthy one.
+func (r *Replica) repairInconsistentReplicas(
+	ctx context.Context, repair []roachpb.ReplicaDescriptor, detail string,
+) error {
+	for _, rDesc := range repair {
+		desc := r.Desc()
+		repl, ok := desc.GetReplicaDescriptorByID(rDesc.ReplicaID)
+		if !ok {
+			// Removed concurrently.
+			continue
+		}
+		changeType := roachpb.REMOVE_VOTER
+		if repl.IsNonVoter() {
+			changeType = roachpb.REMOVE_NON_VOTER
+		}
+		chgs := kvpb.MakeReplicationChanges(changeType, roachpb.ReplicationTarget{
+			NodeID: repl.NodeID, StoreID: repl.StoreID,
 		})
-		err := r.store.db.Run(ctx, &b)
-		return resp, kvpb.NewError(err)
+		updatedDesc, err := r.changeReplicasImpl(ctx, desc, kvserverpb.SnapshotRequest_OTHER,
+			0.0 /* senderQueuePriority */, kvserverpb.ReasonConsistencyRepair, detail, chgs)
+		if err != nil {
+			return errors.Wrapf(err, "removing diverged replica %s", repl)
+		}
+		log.Warningf(ctx, "removed diverged replica %s", repl)
+
+		if err := r.store.cfg.RangeLogWriter.WriteRangeLogEvent(ctx, r.store.db, kvserverpb.RangeLogEvent{
+			Timestamp: r.store.Clock().PhysicalTime(),
+			RangeID:   r.RangeID,
+			EventType: kvserverpb.RangeLogEventType_consistency_repair,
+			StoreID:   r.store.StoreID(),
+			Info: &kvserverpb.RangeLogEvent_Info{
+				RemovedReplica: &repl,
+				UpdatedDesc:    updatedDesc,
+				Reason:         kvserverpb.ReasonConsistencyRepair,
+				Details:        detail,
+			},
+		}); err != nil {
+			log.Warningf(ctx, "error logging to system.rangelog: %v", err)
+		}
 	}
+	r.store.replicateQueue.MaybeAddAsync(ctx, r, r.store.Clock().NowAsClockTimestamp())
+	return nil
+}
 
-	if args.Checkpoint {
-		// A checkpoint/termination request has already been sent. Return because
-		// all the code below will do is request another consistency check, with
-		// instructions to make a checkpoint and to terminate the minority nodes.
-		log.Errorf(ctx, "consistency check failed")
-		return resp, nil
+const (
+	// maxKeyFingerprintBytes bounds the size of the key fingerprints that each
+	// replica collects in the checkpoint round of a failed consistency check.
+	maxKeyFingerprintBytes = 16 << 20 // 16 MiB
+	// keyFingerprintOverhead approximates the size of a KeyFingerprint, not
+	// counting its key.
+	keyFingerprintOverhead = 32
+	// maxKeyDiffsPerReplica bounds the number of differing keys reported for
+	// each diverged replica.
+	maxKeyDiffsPerReplica = 100
+)
+
+// keyDiff is a difference between the point keys of two replicas.
+type keyDiff struct {
+	KeyFingerprint
+	// reason is "missing" if only the reference replica has the key version,
+	// "extra" if only the other replica has it, and "value differs" if both
+	// have it with different values.
+	reason redact.SafeString
+}
+
+// diffKeyFingerprints returns the differences between the point keys of the
+// reference and the other replica. Keys past the end of a truncated list of
+// fingerprints are not compared.
+func diffKeyFingerprints(ref, other *KeyFingerprints) []keyDiff {
+	var diffs []keyDiff
+	i, j := 0, 0
+	for {
+		if (i == len(ref.Keys) && (ref.Truncated || j == len(other.Keys))) ||
+			(j == len(other.Keys) && other.Truncated) {
+			return diffs
+		}
+		var cmp int
+		if i == len(ref.Keys) {
+			cmp = 1
+		} else if j == len(other.Keys) {
+			cmp = -1
+		} else {
+			cmp = storage.MVCCKey{Key: ref.Keys[i].Key, Timestamp: ref.Keys[i].Timestamp}.Compare(
+				storage.MVCCKey{Key: other.Keys[j].Key, Timestamp: other.Keys[j].Timestamp})
+		}
+		switch {
+		case cmp < 0:
+			diffs = append(diffs, keyDiff{KeyFingerprint: ref.Keys[i], reason: "missing"})
+			i++
+		case cmp > 0:
+			diffs = append(diffs, keyDiff{KeyFingerprint: other.Keys[j], reason: "extra"})
+			j++
+		default:
+			if ref.Keys[i].ValueHash != other.Keys[j].ValueHash {
+				diffs = append(diffs, keyDiff{KeyFingerprint: other.Keys[j], reason: "value differs"})
+			}
+			i++
+			j++
+		}
 	}
+}
 
-	// No checkpoint was requested, so we want to re-run the check with
-	// checkpoints and termination of suspicious nodes. Note that this recursive
-	// call will be terminated in the `args.Checkpoint` branch above.
-	args.Checkpoint = true
-	for _, idxs := range shaToIdxs[minoritySHA] {
-		args.Terminate = append(args.Terminate, results[idxs].Replica)
+// writeKeyDiffs writes the key-level diff of each diverged replica against a
+// replica of the largest group of replicas that agree on the checksum.
+func writeKeyDiffs(
+	buf *redact.StringBuilder, results []ConsistencyCheckResult, shaToIdxs map[string][]int,
+) {
+	var refSHA string
+	for sha, idxs := range shaToIdxs {
+		if refSHA == "" || len(idxs) > len(shaToIdxs[refSHA]) {
+			refSHA = sha
+		}
 	}
-	// args.Terminate is a slice of properly redactable values, but
-	// with %v `redact` will not realize that and will redact the
-	// whole thing. Wrap it as a ReplicaSet which is a SafeFormatter
-	// and will get the job done.
-	//
-	// TODO(knz): clean up after https://github.com/cockroachdb/redact/issues/5.
-	{
-		var tmp redact.SafeFormatter = roachpb.MakeReplicaSet(args.Terminate)
-		log.Errorf(ctx, "consistency check failed; fetching details and shutting down minority %v", tmp)
+	ref := &results[shaToIdxs[refSHA][0]]
+	if ref.Response.Fingerprints == nil {
+		buf.Printf("%s: no key fingerprints, key-level diff unavailable\n", &ref.Replica)
+		return
 	}
-
-	// We've noticed in practice that if the snapshot diff is large, the
-	// log file to which it is printed is promptly rotated away, so up
-	// the limits while the diff printing occurs.
-	//
-	// See:
-	// https://github.com/cockroachdb/cockroach/issues/36861
-	// TODO(pavelkalinnikov): remove this now that diffs are not printed?
-	defer log.TemporarilyDisableFileGCForMainLogger()()
-
-	if _, pErr := r.checkConsistencyImpl(ctx, args); pErr != nil {
-		log.Errorf(ctx, "replica inconsistency detected; second round failed: %s", pErr)
+	for sha, idxs := range shaToIdxs {
+		if sha == refSHA {
+			continue
+		}
+		for _, idx := range idxs {
+			other := &results[idx]
+			if other.Response.Fingerprints == nil {
+				buf.Printf("%s: no key fingerprints, key-level diff unavailable\n", &other.Replica)
+				continue
+			}
+			diffs := diffKeyFingerprints(ref.Response.Fingerprints, other.Response.Fingerprints)
+			buf.Printf("%s: key-level diff against %s", &other.Replica, &ref.Replica)
+			if ref.Response.Fingerprints.Truncated || other.Response.Fingerprints.Truncated {
+				buf.Printf(" (diff truncated)")
+			}
+			buf.Printf("\n")
+			for k, d := range diffs {
+				if k == maxKeyDiffsPerReplica {
+					buf.Printf("- ... and %d more\n", redact.Safe(len(diffs)-k))
+					break
+				}
+				buf.Printf("- %s: %s\n", storage.MVCCKey{Key: d.Key, Timestamp: d.Timestamp}, d.reason)
+			}
+		}
 	}
-
-	return resp, nil
 }
 
 // A ConsistencyCheckResult contains the outcome of a CollectChecksum call.
@@ -307,7 +599,10 @@ func (r *Replica) runConsistencyCheck(
 	}
 	ccRes := res.(*kvpb.ComputeChecksumResponse)
 
-	replicas := r.Desc().Replicas().Descriptors()
+	// Witnesses don't store user data, so there is nothing to compare them to.
+	replicas := r.Desc().Replicas().FilterToDescriptors(func(rDesc roachpb.ReplicaDescriptor) bool {
+		return !rDesc.IsWitness()
+	})
 	resultCh := make(chan ConsistencyCheckResult, len(replicas))
 	results := make([]ConsistencyCheckResult, 0, len(replicas))
 
@@ -455,6 +750,7 @@ func (*Replica) computeChecksumDone(rc *replicaChecksum, result *ReplicaDigest)
 		delta.Subtract(result.RecomputedMS)
 		c.Delta = enginepb.MVCCStatsDelta(delta)
 		c.Persisted = result.PersistedMS
+		c.Fingerprints = result.Fingerprints
 	}
 
 	// Sending succeeds because the channel is buffered, and there is at most one
@@ -469,6 +765,9 @@ type ReplicaDigest struct {
 	SHA512       [sha512.Size]byte
 	PersistedMS  enginepb.MVCCStats
 	RecomputedMS enginepb.MVCCStats
+	// Fingerprints is only populated by calcReplicaDigest with a non-zero
+	// fingerprint budget.
+	Fingerprints *KeyFingerprints
 }
 
 // CalcReplicaDigest computes the SHA512 hash and MVCC stats of the replica data
@@ -481,9 +780,47 @@ func CalcReplicaDigest(
 	mode kvpb.ChecksumMode,
 	limiter *quotapool.RateLimiter,
 	settings *cluster.Settings,
+) (*ReplicaDigest, error) {
+	return calcReplicaDigest(ctx, desc, snap, mode, limiter, settings, 0 /* maxFingerprintBytes */)
+}
+
+// calcReplicaDigest is like CalcReplicaDigest, but if maxFingerprintBytes is
+// positive and the full replicated state is hashed, it also collects up to
+// roughly maxFingerprintBytes worth of fingerprints of the point keys, which
+// can be used to compute a key-level diff against another replica.
+func calcReplicaDigest(
+	ctx context.Context,
+	desc roachpb.RangeDescriptor,
+	snap storage.Reader,
+	mode kvpb.ChecksumMode,
+	limiter *quotapool.RateLimiter,
+	settings *cluster.Settings,
+	maxFingerprintBytes int64,
 ) (*ReplicaDigest, error) {
 	statsOnly := mode == kvpb.ChecksumMode_CHECK_STATS
 
+	var fingerprints *KeyFingerprints
+	var fingerprintBytes int64
+	if maxFingerprintBytes > 0 && !statsOnly {
+		fingerprints = &KeyFingerprints{}
+	}
+	fingerprint := func(unsafeKey storage.MVCCKey, unsafeValue []byte) {
+		if fingerprints == nil || fingerprints.Truncated {
+			return
+		}
+		if fingerprintBytes += int64(len(unsafeKey.Key) + keyFingerprintOverhead); fingerprintBytes > maxFingerprintBytes {
+			fingerprints.Truncated = true
+			return
+		}
+		h := fnv.New64a()
+		_, _ = h.Write(unsafeValue)
+		fingerprints.Keys = append(fingerprints.Keys, KeyFingerprint{
+			Key:       append(roachpb.Key(nil), unsafeKey.Key...),
+			Timestamp: unsafeKey.Timestamp,
+			ValueHash: h.Sum64(),
+		})
+	}
+
 	// Iterate over all the data in the range.
 	var intBuf [8]byte
 	var timestamp hlc.Timestamp
@@ -511,6 +848,7 @@ func CalcReplicaDigest(
 		if err := wait(int64(len(unsafeKey.Key) + len(unsafeValue))); err != nil {
 			return err
 		}
+		fingerprint(unsafeKey, unsafeValue)
 		// Encode the length of the key and value.
 		binary.LittleEndian.PutUint64(intBuf[:], uint64(len(unsafeKey.Key)))
 		if _, err := hasher.Write(intBuf[:]); err != nil {
@@ -658,6 +996,7 @@ func CalcReplicaDigest(
 	}
 
 	hasher.Sum(result.SHA512[:0])
+	result.Fingerprints = fingerprints
 
 	// We're not required to do so, but it looks nicer if both stats are aged to
 	// the same timestamp.
@@ -759,7 +1098,14 @@ func (r *Replica) computeChecksumPostApply(
 		); err != nil {
 			log.Errorf(ctx, "checksum collection did not join: %v", err)
 		} else {
-			result, err := CalcReplicaDigest(ctx, desc, snap, cc.Mode, r.store.consistencyLimiter, r.ClusterSettings())
+			// The checkpoint round runs only after an inconsistency was detected, so
+			// collect the key fingerprints needed to tell where the replicas differ.
+			var maxFingerprintBytes int64
+			if cc.Checkpoint {
+				maxFingerprintBytes = maxKeyFingerprintBytes
+			}
+			result, err := calcReplicaDigest(ctx, desc, snap, cc.Mode,
+				r.store.consistencyLimiter, r.ClusterSettings(), maxFingerprintBytes)
 			if err != nil {
 				log.Errorf(ctx, "checksum computation failed: %v", err)
 				result = nil
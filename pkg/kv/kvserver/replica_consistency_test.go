@@ -350,3 +350,65 @@ func TestReplicaChecksumSHA512(t *testing.T) {
 
 	echotest.Require(t, sb.String(), datapathutils.TestDataPath(t, "replica_consistency_sha512"))
 }
+
+// TestDiffKeyFingerprints tests the key-level diff computed between replicas
+// in the checkpoint round of a failed consistency check.
+func TestDiffKeyFingerprints(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	fp := func(key string, wallTime int64, valueHash uint64) KeyFingerprint {
+		return KeyFingerprint{
+			Key:       roachpb.Key(key),
+			Timestamp: hlc.Timestamp{WallTime: wallTime},
+			ValueHash: valueHash,
+		}
+	}
+	ref := &KeyFingerprints{Keys: []KeyFingerprint{
+		fp("a", 0, 1), fp("a", 2, 2), fp("b", 1, 3), fp("c", 1, 4), fp("d", 1, 5),
+	}}
+
+	for _, tc := range []struct {
+		name  string
+		ref   *KeyFingerprints
+		other *KeyFingerprints
+		want  []keyDiff
+	}{{
+		name:  "identical",
+		ref:   ref,
+		other: ref,
+	}, {
+		name: "missing, extra and changed",
+		ref:  ref,
+		other: &KeyFingerprints{Keys: []KeyFingerprint{
+			fp("a", 0, 1), fp("a", 3, 9), fp("a", 2, 2), fp("c", 1, 7), fp("d", 1, 5), fp("e", 1, 6),
+		}},
+		want: []keyDiff{
+			{KeyFingerprint: fp("a", 3, 9), reason: "extra"},
+			{KeyFingerprint: fp("b", 1, 3), reason: "missing"},
+			{KeyFingerprint: fp("c", 1, 7), reason: "value differs"},
+			{KeyFingerprint: fp("e", 1, 6), reason: "extra"},
+		},
+	}, {
+		name: "other truncated",
+		ref:  ref,
+		other: &KeyFingerprints{
+			Keys:      []KeyFingerprint{fp("a", 0, 1), fp("a", 2, 2)},
+			Truncated: true,
+		},
+	}, {
+		name: "ref truncated",
+		ref: &KeyFingerprints{
+			Keys:      []KeyFingerprint{fp("a", 0, 1)},
+			Truncated: true,
+		},
+		other: &KeyFingerprints{Keys: []KeyFingerprint{fp("a", 0, 8), fp("z", 1, 1)}},
+		want: []keyDiff{
+			{KeyFingerprint: fp("a", 0, 8), reason: "value differs"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, diffKeyFingerprints(tc.ref, tc.other))
+		})
+	}
+}
@@ -94,6 +94,20 @@ func (r *Replica) postDestroyRaftMuLocked(ctx context.Context, ms enginepb.MVCCS
 		}
 	}
 
+	// With a separate Raft log engine, the Raft state of the replica is removed
+	// only now that its removal from the state engine is durable. Leftovers
+	// after a crash are removed when the store restarts.
+	if engs := r.store.engines(); engs.Separated() {
+		batch := engs.LogEngine().NewWriteBatch()
+		defer batch.Close()
+		if err := kvstorage.ClearRaftLogState(batch, r.RangeID); err != nil {
+			return err
+		}
+		if err := batch.Commit(false /* sync */); err != nil {
+			return err
+		}
+	}
+
 	// Release the reference to this tenant in metrics, we know the tenant ID is
 	// valid if the replica is initialized.
 	if r.tenantMetricsRef != nil {
@@ -115,7 +129,8 @@ func (r *Replica) destroyRaftMuLocked(ctx context.Context, nextReplicaID roachpb
 	startTime := timeutil.Now()
 
 	ms := r.GetMVCCStats()
-	batch := r.store.TODOEngine().NewWriteBatch()
+	stateEngine := r.store.engines().StateEngine()
+	batch := stateEngine.NewWriteBatch()
 	defer batch.Close()
 	desc := r.Desc()
 	inited := desc.IsInitialized()
@@ -130,8 +145,9 @@ func (r *Replica) destroyRaftMuLocked(ctx context.Context, nextReplicaID roachpb
 		ClearReplicatedByRangeID:   inited,
 		ClearUnreplicatedByRangeID: true,
 	}
-	// TODO(sep-raft-log): need both engines separately here.
-	if err := kvstorage.DestroyReplica(ctx, r.RangeID, r.store.TODOEngine(), batch, nextReplicaID, opts); err != nil {
+	// The Raft state in a separate log engine is cleared by
+	// postDestroyRaftMuLocked.
+	if err := kvstorage.DestroyReplica(ctx, r.RangeID, stateEngine, batch, nextReplicaID, opts); err != nil {
 		return err
 	}
 	preTime := timeutil.Now()
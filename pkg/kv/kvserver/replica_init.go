@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/allocator/plan"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/closedts/tracker"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/hotkeys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvstorage"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/load"
@@ -63,7 +64,7 @@ func loadInitializedReplicaForTesting(
 	if !desc.IsInitialized() {
 		return nil, errors.AssertionFailedf("can not load with uninitialized descriptor: %s", desc)
 	}
-	state, err := kvstorage.LoadReplicaState(ctx, store.TODOEngine(), store.StoreID(), desc, replicaID)
+	state, err := kvstorage.LoadReplicaState(ctx, store.engines(), store.StoreID(), desc, replicaID)
 	if err != nil {
 		return nil, err
 	}
@@ -174,6 +175,9 @@ func newUninitializedReplicaWithoutRaftGroup(
 		r.leaseHistory = newLeaseHistory(leaseHistoryMaxEntries)
 	}
 
+	r.hotKeys = hotkeys.NewFinder(
+		hotkeys.DefaultCapacity, hotkeys.DefaultWindow, timeutil.DefaultTimeSource{})
+
 	if store.cfg.StorePool != nil {
 		r.loadStats = load.NewReplicaLoad(store.Clock(), store.cfg.StorePool.GetNodeLocalityString)
 		split.Init(
@@ -298,6 +302,7 @@ func (r *Replica) initRaftGroupRaftMuLockedReplicaMuLocked() error {
 		r.mu.state.RaftAppliedIndex,
 		r.store.cfg,
 		&raftLogger{ctx: ctx},
+		(*replicaRLockedStoreLiveness)(r),
 	))
 	if err != nil {
 		return err
@@ -442,6 +442,7 @@ func (r *Replica) leasePostApplyLocked(
 			r.loadStats.Reset()
 		}
 		r.loadBasedSplitter.Reset(r.Clock().PhysicalTime())
+		r.hotKeys.Reset()
 	}
 
 	// Inform the concurrency manager that the lease holder has been updated.
@@ -478,11 +479,13 @@ func (r *Replica) leasePostApplyLocked(
 
 	st := r.leaseStatusAtRLocked(ctx, now)
 	if leaseChangingHands && newLease.Type() == roachpb.LeaseExpiration &&
-		r.ownsValidLeaseRLocked(ctx, now) && !r.shouldUseExpirationLeaseRLocked() {
+		r.ownsValidLeaseRLocked(ctx, now) && !r.hasCorrectLeaseTypeRLocked(*newLease) {
 		// We've received and applied an expiration lease for a range that shouldn't
 		// keep using it, most likely as part of a lease transfer (which is always
 		// expiration-based). The lease is also still valid. Upgrade this lease to
-		// the more efficient epoch-based one.
+		// the more efficient epoch-based one. With leader leases, a leaseholder
+		// that isn't the raft leader keeps the expiration lease until leadership
+		// follows it, at which point the replica's tick upgrades it.
 		if log.V(1) {
 			log.VEventf(ctx, 1, "upgrading expiration lease %s to an epoch-based one", newLease)
 		}
@@ -625,7 +628,7 @@ func (r *Replica) maybeLogLeaseAcquisition(
 			"applied lease after ~%.2fs replication lag, client traffic may have been delayed [lease=%v prev=%v]",
 			newLeaseAppDelay.Seconds(), newLease, prevLease)
 	} else if prevLease.Type() == roachpb.LeaseExpiration &&
-		newLease.Type() == roachpb.LeaseEpoch &&
+		newLease.Type() != roachpb.LeaseExpiration &&
 		prevLease.Expiration != nil && // nil when there is no previous lease
 		prevLease.Expiration.LessEq(newLease.Start.ToTimestamp()) {
 		// If the previous lease is expiration-based, but the new lease is not and
@@ -964,9 +964,11 @@ func (r *Replica) handleRaftReadyRaftMuLocked(
 
 			snap := *msgStorageAppend.Snapshot
 			hs := raftpb.HardState{
-				Term:   msgStorageAppend.Term,
-				Vote:   msgStorageAppend.Vote,
-				Commit: msgStorageAppend.Commit,
+				Term:          msgStorageAppend.Term,
+				Vote:          msgStorageAppend.Vote,
+				Commit:        msgStorageAppend.Commit,
+				FortifiedLead: msgStorageAppend.FortifiedLead,
+				LeadEpoch:     msgStorageAppend.LeadEpoch,
 			}
 			if len(msgStorageAppend.Entries) != 0 {
 				log.Fatalf(ctx, "found Entries in MsgStorageAppend with non-empty Snapshot")
@@ -1033,7 +1035,7 @@ func (r *Replica) handleRaftReadyRaftMuLocked(
 			// ranges, so can be passed to LogStore methods instead of being stored in it.
 			s := logstore.LogStore{
 				RangeID:     r.RangeID,
-				Engine:      r.store.TODOEngine(),
+				Engine:      r.store.LogEngine(),
 				Sideload:    r.raftMu.sideloaded,
 				StateLoader: r.raftMu.stateLoader.StateLoader,
 				SyncWaiter:  r.store.syncWaiter,
@@ -2345,6 +2347,12 @@ func shouldCampaignOnLeaseRequestRedirect(
 // also grant any number of pre-votes, both for themselves and anyone else
 // that's eligible.
 func (r *Replica) campaignLocked(ctx context.Context) {
+	if isWitness(r.mu.state.Desc, r.replicaID) {
+		// Witnesses only campaign as a last resort, once raft's election timeout
+		// has elapsed without any other voter becoming leader.
+		log.VEventf(ctx, 3, "not campaigning as witness")
+		return
+	}
 	log.VEventf(ctx, 3, "campaigning")
 	if err := r.mu.internalRaftGroup.Campaign(); err != nil {
 		log.VEventf(ctx, 1, "failed to campaign: %s", err)
@@ -2361,6 +2369,10 @@ func (r *Replica) campaignLocked(ctx context.Context) {
 // caller is certain that the current leader is actually dead, and we're not
 // simply partitioned away from it and/or liveness.
 func (r *Replica) forceCampaignLocked(ctx context.Context) {
+	if isWitness(r.mu.state.Desc, r.replicaID) {
+		log.VEventf(ctx, 3, "not force campaigning as witness")
+		return
+	}
 	log.VEventf(ctx, 3, "force campaigning")
 	msg := raftpb.Message{To: uint64(r.replicaID), Type: raftpb.MsgTimeoutNow}
 	if err := r.mu.internalRaftGroup.Step(msg); err != nil {
@@ -2762,7 +2774,7 @@ func (r *Replica) printRaftTail(
 	end := keys.RaftLogPrefix(r.RangeID).PrefixEnd()
 
 	// NB: raft log does not have intents.
-	it, err := r.store.TODOEngine().NewEngineIterator(
+	it, err := r.store.LogEngine().NewEngineIterator(
 		ctx, storage.IterOptions{LowerBound: start, UpperBound: end})
 	if err != nil {
 		return "", err
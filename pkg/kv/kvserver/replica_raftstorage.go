@@ -80,7 +80,7 @@ var _ raft.Storage = (*replicaRaftStorage)(nil)
 // exclusive access to r.mu.stateLoader.
 func (r *replicaRaftStorage) InitialState() (raftpb.HardState, raftpb.ConfState, error) {
 	ctx := r.AnnotateCtx(context.TODO())
-	hs, err := r.mu.stateLoader.LoadHardState(ctx, r.store.TODOEngine())
+	hs, err := r.mu.stateLoader.LoadHardState(ctx, r.store.LogEngine())
 	// For uninitialized ranges, membership is unknown at this point.
 	if raft.IsEmptyHardState(hs) || err != nil {
 		if err != nil {
@@ -114,7 +114,7 @@ func (r *replicaRaftStorage) TypedEntries(
 	if r.raftMu.sideloaded == nil {
 		return nil, errors.New("sideloaded storage is uninitialized")
 	}
-	ents, _, loadedSize, err := logstore.LoadEntries(ctx, r.mu.stateLoader.StateLoader, r.store.TODOEngine(), r.RangeID,
+	ents, _, loadedSize, err := logstore.LoadEntries(ctx, r.mu.stateLoader.StateLoader, r.store.LogEngine(), r.RangeID,
 		r.store.raftEntryCache, r.raftMu.sideloaded, lo, hi, maxBytes, &r.raftMu.bytesAccount)
 	r.store.metrics.RaftStorageReadBytes.Inc(int64(loadedSize))
 	return ents, err
@@ -150,7 +150,7 @@ func (r *replicaRaftStorage) TypedTerm(i kvpb.RaftIndex) (kvpb.RaftTerm, error)
 		return r.mu.lastTermNotDurable, nil
 	}
 	ctx := r.AnnotateCtx(context.TODO())
-	return logstore.LoadTerm(ctx, r.mu.stateLoader.StateLoader, r.store.TODOEngine(), r.RangeID,
+	return logstore.LoadTerm(ctx, r.mu.stateLoader.StateLoader, r.store.LogEngine(), r.RangeID,
 		r.store.raftEntryCache, i)
 }
 
@@ -562,6 +562,10 @@ func (r *Replica) applySnapshot(
 					r.store.metrics.RangeSnapshotsAppliedByVoters.Inc(1)
 				case roachpb.NON_VOTER:
 					r.store.metrics.RangeSnapshotsAppliedByNonVoters.Inc(1)
+				case roachpb.WITNESS:
+					// Witnesses don't keep range data, but they may need a snapshot to
+					// catch up on a raft log that was truncated past their position.
+					r.store.metrics.RangeSnapshotsAppliedByWitnesses.Inc(1)
 				default:
 					log.Fatalf(ctx, "unexpected replica type %s while applying snapshot", desc.Type)
 				}
@@ -607,9 +611,14 @@ func (r *Replica) applySnapshot(
 		log.Infof(ctx, "applied %s %s(%s)", inSnap, appliedAsWriteStr, logDetails)
 	}(timeutil.Now())
 
+	// When the Raft log lives in a separate engine, the HardState and
+	// RaftTruncatedState are written to it only once the state machine has been
+	// durably updated below.
+	engs := r.store.engines()
 	clearedSpans := inSnap.clearedSpans
 	unreplicatedSSTFile, clearedSpan, err := writeUnreplicatedSST(
 		ctx, r.ID(), r.ClusterSettings(), nonemptySnap.Metadata, hs, &r.raftMu.stateLoader.StateLoader,
+		!engs.Separated(), /* includeRaftLogState */
 	)
 	if err != nil {
 		return err
@@ -651,8 +660,7 @@ func (r *Replica) applySnapshot(
 	// of the removed range. In this case, however, it's copacetic, as subsumed
 	// ranges _can't_ have new replicas.
 	clearedSubsumedSpans, err := clearSubsumedReplicaDiskData(
-		// TODO(sep-raft-log): needs access to both engines.
-		ctx, r.store.ClusterSettings(), r.store.TODOEngine(), inSnap.SSTStorageScratch.WriteSST,
+		ctx, r.store.ClusterSettings(), engs.StateEngine(), inSnap.SSTStorageScratch.WriteSST,
 		desc, subsumedDescs, mergedTombstoneReplicaID,
 	)
 	if err != nil {
@@ -713,11 +721,23 @@ func (r *Replica) applySnapshot(
 	}
 	stats.ingestion = timeutil.Now()
 
+	// Both ingestion and the write batch above are synced, so the state machine
+	// is durable and the Raft state can be replaced in the log engine. A crash
+	// in between leaves a log engine that lags the applied index, which is
+	// reconciled when the store restarts.
+	if engs.Separated() {
+		if err := writeSnapshotRaftLogState(
+			ctx, engs.LogEngine(), desc.RangeID, nonemptySnap.Metadata, hs, subsumedDescs,
+		); err != nil {
+			return err
+		}
+	}
+
 	// The on-disk state is now committed, but the corresponding in-memory state
 	// has not yet been updated. Any errors past this point must therefore be
 	// treated as fatal.
 
-	state, err := stateloader.Make(desc.RangeID).Load(ctx, r.store.TODOEngine(), desc)
+	state, err := kvstorage.LoadReplState(ctx, engs, desc)
 	if err != nil {
 		log.Fatalf(ctx, "unable to load replica state: %s", err)
 	}
@@ -872,6 +892,7 @@ func writeUnreplicatedSST(
 	meta raftpb.SnapshotMetadata,
 	hs raftpb.HardState,
 	sl *logstore.StateLoader,
+	includeRaftLogState bool,
 ) (_ *storage.MemObject, clearedSpan roachpb.Span, _ error) {
 	unreplicatedSSTFile := &storage.MemObject{}
 	unreplicatedSST := storage.MakeIngestionSSTWriter(
@@ -894,8 +915,10 @@ func writeUnreplicatedSST(
 	}
 
 	// Update HardState.
-	if err := sl.SetHardState(ctx, &unreplicatedSST, hs); err != nil {
-		return nil, roachpb.Span{}, errors.Wrapf(err, "unable to write HardState to unreplicated SST writer")
+	if includeRaftLogState {
+		if err := sl.SetHardState(ctx, &unreplicatedSST, hs); err != nil {
+			return nil, roachpb.Span{}, errors.Wrapf(err, "unable to write HardState to unreplicated SST writer")
+		}
 	}
 	// We've cleared all the raft state above, so we are forced to write the
 	// RaftReplicaID again here.
@@ -904,14 +927,16 @@ func writeUnreplicatedSST(
 		return nil, roachpb.Span{}, errors.Wrapf(err, "unable to write RaftReplicaID to unreplicated SST writer")
 	}
 
-	if err := sl.SetRaftTruncatedState(
-		ctx, &unreplicatedSST,
-		&kvserverpb.RaftTruncatedState{
-			Index: kvpb.RaftIndex(meta.Index),
-			Term:  kvpb.RaftTerm(meta.Term),
-		},
-	); err != nil {
-		return nil, roachpb.Span{}, errors.Wrapf(err, "unable to write TruncatedState to unreplicated SST writer")
+	if includeRaftLogState {
+		if err := sl.SetRaftTruncatedState(
+			ctx, &unreplicatedSST,
+			&kvserverpb.RaftTruncatedState{
+				Index: kvpb.RaftIndex(meta.Index),
+				Term:  kvpb.RaftTerm(meta.Term),
+			},
+		); err != nil {
+			return nil, roachpb.Span{}, errors.Wrapf(err, "unable to write TruncatedState to unreplicated SST writer")
+		}
 	}
 
 	if err := unreplicatedSST.Finish(); err != nil {
@@ -920,6 +945,41 @@ func writeUnreplicatedSST(
 	return unreplicatedSSTFile, clearedSpan, nil
 }
 
+// writeSnapshotRaftLogState replaces the Raft state of the snapshot's replica
+// and of the replicas it subsumes in a separate Raft log engine. The log of
+// the replica is emptied, and it is left with the given HardState and a
+// RaftTruncatedState at the snapshot index.
+func writeSnapshotRaftLogState(
+	ctx context.Context,
+	logEngine storage.Engine,
+	rangeID roachpb.RangeID,
+	meta raftpb.SnapshotMetadata,
+	hs raftpb.HardState,
+	subsumedDescs []*roachpb.RangeDescriptor,
+) error {
+	batch := logEngine.NewWriteBatch()
+	defer batch.Close()
+	for _, sd := range subsumedDescs {
+		if err := kvstorage.ClearRaftLogState(batch, sd.RangeID); err != nil {
+			return err
+		}
+	}
+	if err := kvstorage.ClearRaftLogState(batch, rangeID); err != nil {
+		return err
+	}
+	sl := logstore.NewStateLoader(rangeID)
+	if err := sl.SetHardState(ctx, batch, hs); err != nil {
+		return errors.Wrapf(err, "unable to write HardState to the Raft log engine")
+	}
+	if err := sl.SetRaftTruncatedState(ctx, batch, &kvserverpb.RaftTruncatedState{
+		Index: kvpb.RaftIndex(meta.Index),
+		Term:  kvpb.RaftTerm(meta.Term),
+	}); err != nil {
+		return errors.Wrapf(err, "unable to write TruncatedState to the Raft log engine")
+	}
+	return batch.Commit(true /* sync */)
+}
+
 // clearSubsumedReplicaDiskData clears the on disk data of the subsumed
 // replicas by creating SSTs with range deletion tombstones. We have to be
 // careful here not to have overlapping ranges with the SSTs we have already
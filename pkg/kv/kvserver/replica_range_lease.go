@@ -156,6 +156,20 @@ var RejectLeaseOnLeaderUnknown = settings.RegisterBoolSetting(
 	false,
 )
 
+// LeaderLeasesEnabled controls whether leases are backed by fortified raft
+// leadership and store liveness, instead of node liveness. Leader leases are
+// acquired by the raft leader and remain valid for as long as its leadership
+// is supported by a quorum of stores, without the need for a node liveness
+// heartbeat. Ranges that require expiration-based leases keep using them.
+var LeaderLeasesEnabled = settings.RegisterBoolSetting(
+	settings.SystemOnly,
+	"kv.lease.leader_leases.enabled",
+	"controls whether raft leaders fortify their leadership through store liveness "+
+		"and acquire leader leases backed by it instead of epoch-based leases; "+
+		"takes effect once the cluster version is finalized",
+	false,
+)
+
 var leaseStatusLogLimiter = func() *log.EveryN {
 	e := log.Every(15 * time.Second)
 	e.ShouldLog() // waste the first shot
@@ -326,9 +340,11 @@ func (p *pendingLeaseRequest) InitOrJoinRequest(
 		ProposedTS: &status.Now,
 	}
 
+	leaderLeases := p.repl.leaderLeasesEnabledRLocked()
 	if p.repl.shouldUseExpirationLeaseRLocked() ||
 		(transfer &&
-			TransferExpirationLeasesFirstEnabled.Get(&p.repl.store.ClusterSettings().SV)) {
+			(leaderLeases || TransferExpirationLeasesFirstEnabled.Get(&p.repl.store.ClusterSettings().SV))) ||
+		(leaderLeases && !p.repl.shouldUseLeaderLeaseRLocked()) {
 		// In addition to ranges that should be using expiration-based leases
 		// (typically the meta and liveness ranges), we also use them during lease
 		// transfers for all other ranges. After acquiring these expiration based
@@ -342,8 +358,26 @@ func (p *pendingLeaseRequest) InitOrJoinRequest(
 		// leaseholder that's delayed in applying the lease transfer to maintain its
 		// lease (assuming the node it's on is able to heartbeat its liveness
 		// record).
+		//
+		// With leader leases, a lease can only be backed by the leadership of
+		// the replica that acquires it. Transfer targets and leaseholders that
+		// aren't the raft leader hold expiration-based leases until raft
+		// leadership follows the lease, and then upgrade to leader leases.
 		reqLease.Expiration = &hlc.Timestamp{}
 		*reqLease.Expiration = status.Now.ToTimestamp().Add(int64(p.repl.store.cfg.RangeLeaseDuration), 0)
+	} else if leaderLeases {
+		// The local replica is the raft leader and acquires a lease backed by its
+		// leadership in the current term. If it already holds the lease, the
+		// leader lease is valid at least until the current lease expires.
+		reqLease.Term = p.repl.mu.internalRaftGroup.BasicStatus().Term
+		if !acquisition {
+			switch status.Lease.Type() {
+			case roachpb.LeaseExpiration:
+				reqLease.MinExpiration = status.Lease.GetExpiration()
+			case roachpb.LeaseLeader:
+				reqLease.MinExpiration = status.Lease.MinExpiration
+			}
+		}
 	} else {
 		// Get the liveness for the next lease holder and set the epoch in the lease request.
 		l, ok := p.repl.store.cfg.NodeLiveness.GetLiveness(nextLeaseHolder.NodeID)
@@ -681,7 +715,9 @@ func (p *pendingLeaseRequest) newResolvedHandle(pErr *kvpb.Error) *leaseRequestH
 // to give up or to retry.
 //
 // If the lease is expired according to the now timestamp (and, in the case of
-// epoch-based leases, the liveness epoch), a status of EXPIRED is returned.
+// epoch-based leases, the liveness epoch, or in the case of leader leases, the
+// raft leadership, see leaderLeaseExpirationRLocked), a status of EXPIRED is
+// returned.
 // Note that this ignores the timestamp of the request, which may well
 // technically be eligible to be served under the lease. The key feature of an
 // EXPIRED status is that it reflects that a new lease with a start timestamp
@@ -754,6 +790,9 @@ func (r *Replica) leaseStatus(
 	var expiration hlc.Timestamp
 	if lease.Type() == roachpb.LeaseExpiration {
 		expiration = lease.GetExpiration()
+	} else if lease.Type() == roachpb.LeaseLeader {
+		expiration = r.leaderLeaseExpirationRLocked(lease)
+		status.LeaderLeaseExpiration = expiration
 	} else {
 		l, ok := r.store.cfg.NodeLiveness.GetLiveness(lease.Replica.NodeID)
 		status.Liveness = l.Liveness
@@ -1593,8 +1632,18 @@ func (r *Replica) HasCorrectLeaseType(lease roachpb.Lease) bool {
 }
 
 func (r *Replica) hasCorrectLeaseTypeRLocked(lease roachpb.Lease) bool {
-	hasExpirationLease := lease.Type() == roachpb.LeaseExpiration
-	return hasExpirationLease == r.shouldUseExpirationLeaseRLocked()
+	switch {
+	case r.shouldUseExpirationLeaseRLocked():
+		return lease.Type() == roachpb.LeaseExpiration
+	case r.shouldUseLeaderLeaseRLocked():
+		return lease.Type() == roachpb.LeaseLeader
+	case r.leaderLeasesEnabledRLocked():
+		// The leaseholder isn't the raft leader, so it can't hold a leader lease.
+		// It upgrades its expiration-based lease once leadership follows it.
+		return lease.Type() == roachpb.LeaseExpiration
+	default:
+		return lease.Type() == roachpb.LeaseEpoch
+	}
 }
 
 // LeasePreferencesStatus represents the state of satisfying lease preferences.
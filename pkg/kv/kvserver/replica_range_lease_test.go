@@ -66,6 +66,15 @@ func TestReplicaLeaseStatus(t *testing.T) {
 		Epoch:      5,
 		ProposedTS: expLease.ProposedTS,
 	}
+	leaderLease := roachpb.Lease{
+		Replica:       expLease.Replica,
+		Start:         expLease.Start,
+		Term:          5,
+		MinExpiration: ts[4].ToTimestamp(),
+		ProposedTS:    expLease.ProposedTS,
+	}
+	remoteLeaderLease := leaderLease
+	remoteLeaderLease.Replica = roachpb.ReplicaDescriptor{NodeID: 2, StoreID: 2, ReplicaID: 2}
 
 	oldLiveness := livenesspb.Liveness{
 		NodeID: 1, Epoch: 4, Expiration: hlc.LegacyTimestamp{WallTime: ts[1].WallTime},
@@ -83,6 +92,7 @@ func TestReplicaLeaseStatus(t *testing.T) {
 		minProposedTS hlc.ClockTimestamp
 		reqTS         hlc.Timestamp
 		liveness      livenesspb.Liveness
+		leaderExp     hlc.Timestamp
 		want          kvserverpb.LeaseState
 		wantErr       string
 	}{
@@ -121,6 +131,29 @@ func TestReplicaLeaseStatus(t *testing.T) {
 			wantErr: "liveness record not found"},
 		{lease: epoLease, now: ts[2], liveness: oldLiveness, want: kvserverpb.LeaseState_ERROR,
 			wantErr: "node liveness info for n1 is stale"},
+
+		// Leader lease without raft leadership, EXPIRED at its min expiration.
+		{lease: leaderLease, now: ts[5], leaderExp: ts[4].ToTimestamp(),
+			want: kvserverpb.LeaseState_EXPIRED},
+		{lease: leaderLease, now: ts[4], leaderExp: ts[4].ToTimestamp(),
+			want: kvserverpb.LeaseState_EXPIRED},
+		// Leader lease, PROSCRIBED.
+		{lease: leaderLease, now: ts[3], minProposedTS: ts[1], leaderExp: ts[4].ToTimestamp(),
+			want: kvserverpb.LeaseState_PROSCRIBED},
+		// Leader lease, UNUSABLE.
+		{lease: leaderLease, now: ts[3], reqTS: inStasis, leaderExp: ts[4].ToTimestamp(),
+			want: kvserverpb.LeaseState_UNUSABLE},
+		// Leader lease, VALID.
+		{lease: leaderLease, now: ts[2], leaderExp: ts[4].ToTimestamp(),
+			want: kvserverpb.LeaseState_VALID},
+		// Leader lease held elsewhere without known support, VALID until its min
+		// expiration.
+		{lease: remoteLeaderLease, now: ts[2], leaderExp: ts[4].ToTimestamp(),
+			want: kvserverpb.LeaseState_VALID},
+		// Leader lease held elsewhere without known support, EXPIRED after its min
+		// expiration.
+		{lease: remoteLeaderLease, now: ts[5], leaderExp: ts[4].ToTimestamp(),
+			want: kvserverpb.LeaseState_EXPIRED},
 	} {
 		t.Run("", func(t *testing.T) {
 			cache :=
@@ -150,6 +183,7 @@ func TestReplicaLeaseStatus(t *testing.T) {
 			}
 			assert.Equal(t, kvserverpb.LeaseStatus{
 				Lease: tc.lease, Now: tc.now, RequestTime: tc.reqTS, State: tc.want, Liveness: tc.liveness,
+				LeaderLeaseExpiration: tc.leaderExp,
 			}, got)
 		})
 	}
@@ -13,13 +13,28 @@ package kvserver
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/hotkeys"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/tenantcostmodel"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
 	"github.com/cockroachdb/errors"
 )
 
+// hotKeysSampleRate is the fraction of point requests whose keys are recorded
+// to estimate the hottest keys of each replica.
+var hotKeysSampleRate = settings.RegisterFloatSetting(
+	settings.SystemOnly,
+	"kv.hot_keys.sample_rate",
+	"fraction of point requests sampled to estimate the hottest keys of each range, "+
+		"or 0 to disable hot key detection and per-key rate limits",
+	0.01,
+	settings.Fraction,
+)
+
 // maybeRateLimitBatch may block the batch waiting to be rate-limited. Note that
 // the replica must be initialized and thus there is no synchronization issue
 // on the tenantRateLimiter.
@@ -61,3 +76,59 @@ func (r *Replica) recordImpactOnRateLimiter(
 	// readMultiplier isn't needed here since it's only used to calculate RUs.
 	r.tenantLimiter.RecordRead(ctx, tenantcostmodel.MakeResponseInfo(br, isReadOnly, 1))
 }
+
+// isHotKeyRequest returns whether the request is a point read or write of user
+// data, whose key is recorded to estimate the replica's hottest keys and which
+// is subject to per-key rate limits. Requests that manage transactions and
+// their intents, such as EndTxn, HeartbeatTxn, PushTxn, ResolveIntent and
+// QueryIntent, are never rate limited: they are needed for transactions that
+// already accessed a hot key to finish and release their locks.
+func isHotKeyRequest(req kvpb.Request) bool {
+	switch req.Method() {
+	case kvpb.Get, kvpb.Put, kvpb.ConditionalPut, kvpb.InitPut, kvpb.Increment, kvpb.Delete:
+		return true
+	default:
+		return false
+	}
+}
+
+// maybeRateLimitHotKeys records the keys of the batch's point reads and writes
+// to estimate the replica's hottest keys, and rejects the batch with a
+// KeyRateLimitedError if it accesses a hot key whose request rate exceeds the
+// span config's max_qps_per_key. The request is rejected before evaluation, so
+// the DistSender backs off and retries it, see sendToReplicas.
+func (r *Replica) maybeRateLimitHotKeys(ctx context.Context, ba *kvpb.BatchRequest) error {
+	st := r.ClusterSettings()
+	sampleRate := hotKeysSampleRate.Get(&st.SV)
+	if sampleRate == 0 {
+		return nil
+	}
+	r.mu.RLock()
+	maxQPS := r.mu.conf.MaxQPSPerKey
+	r.mu.RUnlock()
+	// Nodes running older versions can't decode a KeyRateLimitedError.
+	if !st.Version.IsActive(ctx, clusterversion.V24_1_HotKeys) {
+		maxQPS = 0
+	}
+
+	for _, ru := range ba.Requests {
+		req := ru.GetInner()
+		if !isHotKeyRequest(req) {
+			continue
+		}
+		h := req.Header()
+		r.hotKeys.Record(h.Key, sampleRate)
+		if ok, _ := r.hotKeys.Admit(h.Key, maxQPS); !ok {
+			r.store.metrics.HotKeyRateLimitedRequests.Inc(1)
+			log.VEventf(ctx, 2, "rate limited request to hot key %s", h.Key)
+			return kvpb.NewKeyRateLimitedError(h.Key, maxQPS)
+		}
+	}
+	return nil
+}
+
+// HotKeys returns up to k of the replica's hottest keys, in decreasing order
+// of estimated load.
+func (r *Replica) HotKeys(k int) []hotkeys.KeyLoad {
+	return r.hotKeys.HotKeys(k)
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvserver
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIsHotKeyRequest tests that only point reads and writes of user data are
+// subject to per-key rate limits, and requests that manage transactions and
+// their intents are not.
+func TestIsHotKeyRequest(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, req := range []kvpb.Request{
+		&kvpb.GetRequest{},
+		&kvpb.PutRequest{},
+		&kvpb.ConditionalPutRequest{},
+		&kvpb.InitPutRequest{},
+		&kvpb.IncrementRequest{},
+		&kvpb.DeleteRequest{},
+	} {
+		require.True(t, isHotKeyRequest(req), "%s", req.Method())
+	}
+	for _, req := range []kvpb.Request{
+		&kvpb.EndTxnRequest{},
+		&kvpb.HeartbeatTxnRequest{},
+		&kvpb.PushTxnRequest{},
+		&kvpb.RecoverTxnRequest{},
+		&kvpb.QueryTxnRequest{},
+		&kvpb.ResolveIntentRequest{},
+		&kvpb.QueryIntentRequest{},
+		&kvpb.ScanRequest{},
+		&kvpb.LeaseInfoRequest{},
+	} {
+		require.False(t, isHotKeyRequest(req), "%s", req.Method())
+	}
+}
@@ -80,6 +80,9 @@ var optimisticEvalLimitedScans = settings.RegisterBoolSetting(
 //	Replica.maybeRateLimitBatch (tenant rate limits)
 //	                       │
 //	                       ▼
+//	Replica.maybeRateLimitHotKeys (per-key rate limits)
+//	                       │
+//	                       ▼
 //	  Replica.maybeCommitWaitBeforeCommitTrigger (if committing with commit-trigger)
 //	                       │
 //
@@ -156,6 +159,9 @@ func (r *Replica) SendWithWriteBytes(
 	if err := r.maybeRateLimitBatch(ctx, ba); err != nil {
 		return nil, nil, kvpb.NewError(err)
 	}
+	if err := r.maybeRateLimitHotKeys(ctx, ba); err != nil {
+		return nil, nil, kvpb.NewError(err)
+	}
 	if err := r.maybeCommitWaitBeforeCommitTrigger(ctx, ba); err != nil {
 		return nil, nil, kvpb.NewError(err)
 	}
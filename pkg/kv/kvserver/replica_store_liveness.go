@@ -0,0 +1,141 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvserver
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	slpb "github.com/cockroachdb/cockroach/pkg/kv/kvserver/storeliveness/storelivenesspb"
+	"github.com/cockroachdb/cockroach/pkg/raft"
+	"github.com/cockroachdb/cockroach/pkg/raft/raftpb"
+	"github.com/cockroachdb/cockroach/pkg/raft/raftstoreliveness"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+)
+
+// replicaRLockedStoreLiveness implements the raftstoreliveness.StoreLiveness
+// interface by translating the replica IDs used by raft to the stores of the
+// range's replicas. Its methods are called by raft, with Replica.mu held.
+type replicaRLockedStoreLiveness Replica
+
+var _ raftstoreliveness.StoreLiveness = (*replicaRLockedStoreLiveness)(nil)
+
+func (r *replicaRLockedStoreLiveness) getStoreIdent(replicaID uint64) (slpb.StoreIdent, bool) {
+	r.mu.AssertRHeld()
+	desc, ok := r.mu.state.Desc.GetReplicaDescriptorByID(roachpb.ReplicaID(replicaID))
+	if !ok {
+		return slpb.StoreIdent{}, false
+	}
+	return slpb.StoreIdent{NodeID: desc.NodeID, StoreID: desc.StoreID}, true
+}
+
+// SupportFor implements the raftstoreliveness.StoreLiveness interface.
+func (r *replicaRLockedStoreLiveness) SupportFor(replicaID uint64) (raftpb.Epoch, bool) {
+	if r.store.storeLiveness == nil {
+		return 0, false
+	}
+	storeID, ok := r.getStoreIdent(replicaID)
+	if !ok {
+		return 0, false
+	}
+	epoch, ok := r.store.storeLiveness.SupportFor(storeID)
+	return raftpb.Epoch(epoch), ok
+}
+
+// SupportFrom implements the raftstoreliveness.StoreLiveness interface.
+func (r *replicaRLockedStoreLiveness) SupportFrom(replicaID uint64) (raftpb.Epoch, hlc.Timestamp) {
+	if r.store.storeLiveness == nil {
+		return 0, hlc.Timestamp{}
+	}
+	storeID, ok := r.getStoreIdent(replicaID)
+	if !ok {
+		return 0, hlc.Timestamp{}
+	}
+	epoch, exp := r.store.storeLiveness.SupportFrom(storeID)
+	return raftpb.Epoch(epoch), exp
+}
+
+// SupportFromEnabled implements the raftstoreliveness.StoreLiveness interface.
+// Leaders only fortify their leadership if leader leases are enabled, since
+// fortified followers refuse to campaign and vote, which delays elections
+// after a leader failure until store liveness support is withdrawn. Nodes
+// running older versions don't know about fortification messages, the
+// corresponding HardState fields and leader leases, so none of these are used
+// before the cluster version allows it.
+func (r *replicaRLockedStoreLiveness) SupportFromEnabled() bool {
+	st := r.store.ClusterSettings()
+	return r.store.storeLiveness != nil && LeaderLeasesEnabled.Get(&st.SV) &&
+		st.Version.IsActive(context.Background(), clusterversion.V24_1_LeaderLeases)
+}
+
+// leaderLeasesEnabledRLocked returns whether leader leases are enabled and
+// allowed by the cluster version.
+func (r *Replica) leaderLeasesEnabledRLocked() bool {
+	return (*replicaRLockedStoreLiveness)(r).SupportFromEnabled()
+}
+
+// shouldUseLeaderLeaseRLocked returns whether the replica should acquire a
+// leader lease, rather than an epoch-based lease. This is the case if leader
+// leases are enabled and the replica is the raft leader.
+func (r *Replica) shouldUseLeaderLeaseRLocked() bool {
+	return r.leaderLeasesEnabledRLocked() && r.isRaftLeaderRLocked()
+}
+
+// leaderLeaseExpirationRLocked returns the expiration of the given leader
+// lease, as observed by the replica.
+//
+// A leader lease is valid for as long as its replica is the raft leader of the
+// lease's term and a quorum supports its leadership through store liveness, or
+// until its minimum expiration, whichever is later. Only the leaseholder knows
+// the expiration of the support it receives. Other replicas consider the lease
+// valid until its minimum expiration or, if they are fortifying the
+// leaseholder's leadership in the lease's term, until the support provided by
+// their own store expires. Their view only determines whether they redirect
+// requests to the leaseholder or try to acquire the lease, which only the raft
+// leader of a later term can do. Electing that leader requires a quorum to
+// have withdrawn its support for the leaseholder first.
+func (r *Replica) leaderLeaseExpirationRLocked(lease roachpb.Lease) hlc.Timestamp {
+	exp := lease.MinExpiration
+	rg := r.mu.internalRaftGroup
+	if rg == nil {
+		return exp
+	}
+	st := rg.BasicStatus()
+	isLeader := st.RaftState == raft.StateLeader
+	switch {
+	case st.Term != lease.Term:
+	case lease.OwnedBy(r.store.StoreID()):
+		if isLeader {
+			exp.Forward(rg.LeadSupportUntil())
+		}
+	case st.FortifiedLead == uint64(lease.Replica.ReplicaID):
+		exp.Forward(r.supportForExpirationRLocked(lease.Replica, st.LeadEpoch))
+	}
+	return exp
+}
+
+// supportForExpirationRLocked returns the expiration of the support provided by
+// the local store to the store of the given replica at the given epoch. An
+// empty timestamp is returned if no such support is provided.
+func (r *Replica) supportForExpirationRLocked(
+	repl roachpb.ReplicaDescriptor, epoch raftpb.Epoch,
+) hlc.Timestamp {
+	if r.store.storeLiveness == nil {
+		return hlc.Timestamp{}
+	}
+	supportEpoch, exp := r.store.storeLiveness.SupportForExpiration(
+		slpb.StoreIdent{NodeID: repl.NodeID, StoreID: repl.StoreID})
+	if raftpb.Epoch(supportEpoch) != epoch {
+		return hlc.Timestamp{}
+	}
+	return exp
+}
@@ -0,0 +1,150 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvserver
+
+// This file contains the pieces that let a replica act as a witness.
+//
+// A witness is a voter which doesn't store any range data: it persists its raft
+// log and HardState, and so takes part in elections and log replication like
+// any other voter, which allows a range to tolerate the failure of a full voter
+// with less storage. A witness can't hold the lease or serve reads, and is only
+// raft leader for as long as it takes to catch up another voter; see
+// roachpb.WITNESS.
+//
+// A witness still applies committed commands, since it needs to track the
+// range's descriptor, lease and applied state to handle replication changes,
+// splits and merges and to truncate its raft log. However, it only applies
+// the parts of a command's write batch which are not derived from user data,
+// that is, the range-ID local keys and the range-local keys other than the
+// lock table. SSTables are not ingested. Similarly, snapshots sent to a
+// witness don't contain any user keys. The MVCC stats of a witness therefore
+// don't match the data it stores, which is why witnesses are excluded from
+// consistency checks.
+//
+// Witnesses are added as learners first, like voters, so that they only count
+// towards the quorum once they have caught up. The learner receives a regular
+// snapshot and applies commands in full, so its user data is cleared when it
+// is promoted to a witness.
+
+import (
+	"bytes"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rditer"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble"
+)
+
+// isWitness returns true if the replica with the given ID is a witness in the
+// given descriptor.
+func isWitness(desc *roachpb.RangeDescriptor, replicaID roachpb.ReplicaID) bool {
+	repDesc, ok := desc.GetReplicaDescriptorByID(replicaID)
+	return ok && repDesc.IsWitness()
+}
+
+// isWitnessKey returns true if the given key is applied by witnesses. Keys
+// which store user data, either directly or as intents in the lock table, are
+// not.
+func isWitnessKey(key roachpb.Key) bool {
+	return key.Compare(keys.LocalMax) < 0 && !bytes.HasPrefix(key, keys.LocalRangeLockTablePrefix)
+}
+
+// clearWitnessUserData stages the removal of the range's user keys and lock
+// table in the given batch, i.e. of all the keys which witnesses don't apply.
+func clearWitnessUserData(batch storage.Writer, desc *roachpb.RangeDescriptor) error {
+	for _, filter := range []rditer.ReplicatedSpansFilter{
+		rditer.ReplicatedSpansLocksOnly, rditer.ReplicatedSpansUserOnly,
+	} {
+		for _, span := range rditer.Select(desc.RangeID, rditer.SelectOpts{
+			ReplicatedBySpan:      desc.RSpan(),
+			ReplicatedSpansFilter: filter,
+		}) {
+			if err := batch.ClearRawRange(
+				span.Key, span.EndKey, true /* pointKeys */, true, /* rangeKeys */
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// stageWitnessWriteBatch stages the entries of a command's write batch which
+// are applied by witnesses in the given batch. MVCC range keys are never
+// staged, since they only exist for user keys.
+func stageWitnessWriteBatch(batch storage.Writer, wb *kvserverpb.WriteBatch) error {
+	if wb == nil {
+		return nil
+	}
+	r, err := storage.NewBatchReader(wb.Data)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read WriteBatch")
+	}
+	for r.Next() {
+		switch r.KeyKind() {
+		case pebble.InternalKeyKindRangeKeySet, pebble.InternalKeyKindRangeKeyUnset,
+			pebble.InternalKeyKindRangeKeyDelete:
+			continue
+		case pebble.InternalKeyKindRangeDelete:
+			start, err := r.EngineKey()
+			if err != nil {
+				return err
+			}
+			end, err := r.EngineEndKey()
+			if err != nil {
+				return err
+			}
+			// Range deletions may span both local and user keys, for example
+			// when the data of a subsumed range is cleared. Only the local part
+			// is staged.
+			if end.Key.Compare(keys.LocalMax) > 0 {
+				end.Key = keys.LocalMax
+			}
+			if start.Key.Compare(end.Key) >= 0 {
+				continue
+			}
+			if err := batch.ClearRawRange(start.Key, end.Key, true /* pointKeys */, false /* rangeKeys */); err != nil {
+				return err
+			}
+			continue
+		}
+
+		ek, err := r.EngineKey()
+		if err != nil {
+			return err
+		}
+		if !isWitnessKey(ek.Key) {
+			continue
+		}
+		switch r.KeyKind() {
+		case pebble.InternalKeyKindSet, pebble.InternalKeyKindSetWithDelete:
+			err = batch.PutEngineKey(ek, r.Value())
+		case pebble.InternalKeyKindDelete, pebble.InternalKeyKindDeleteSized:
+			err = batch.ClearEngineKey(ek, storage.ClearOptions{})
+		case pebble.InternalKeyKindSingleDelete:
+			err = batch.SingleClearEngineKey(ek)
+		case pebble.InternalKeyKindMerge:
+			var key storage.MVCCKey
+			if key, err = ek.ToMVCCKey(); err == nil {
+				err = batch.Merge(key, r.Value())
+			}
+		default:
+			err = errors.AssertionFailedf("unexpected WriteBatch entry key kind %d", r.KeyKind())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return r.Error()
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/allocator/storepool"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/closedts/sidetransport"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/hotkeys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/idalloc"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/intentresolver"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvadmission"
@@ -53,12 +54,15 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/raftentry"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rangefeed"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rditer"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/storeliveness"
+	slpb "github.com/cockroachdb/cockroach/pkg/kv/kvserver/storeliveness/storelivenesspb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantrate"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tscache"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/txnrecovery"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/txnwait"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/tenantcapabilities/tenantcapabilitiesauthorizer"
 	"github.com/cockroachdb/cockroach/pkg/raft"
+	"github.com/cockroachdb/cockroach/pkg/raft/raftstoreliveness"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/rpc"
 	"github.com/cockroachdb/cockroach/pkg/rpc/nodedialer"
@@ -360,6 +364,7 @@ func newRaftConfig(
 	appliedIndex kvpb.RaftIndex,
 	storeCfg StoreConfig,
 	logger raft.Logger,
+	storeLiveness raftstoreliveness.StoreLiveness,
 ) *raft.Config {
 	return &raft.Config{
 		ID:                          id,
@@ -375,6 +380,7 @@ func newRaftConfig(
 		MaxInflightBytes:            storeCfg.RaftMaxInflightBytes,
 		Storage:                     strg,
 		Logger:                      logger,
+		StoreLiveness:               storeLiveness,
 
 		// StepDownOnRemoval requires 23.2. Otherwise, in a mixed-version cluster, a
 		// 23.2 leader may step down when it demotes itself to learner, but a
@@ -554,10 +560,9 @@ func (rs *storeReplicaVisitor) EstimatedCount() int {
 }
 
 // internalEngines contains the engines that support the operations of
-// this Store. At the time of writing, all three fields will be populated
-// with the same Engine. As work on CRDB-220 (separate raft log) proceeds,
-// we will be able to experimentally run with a separate log engine, and
-// ultimately allow doing so in production deployments.
+// this Store. Unless the store is configured with a separate Raft log engine
+// (see kvstorage.Engines), all three fields are populated with the same
+// Engine. Otherwise, todoEngine is the state engine.
 type internalEngines struct {
 	// stateEngine is the engine that materializes the raft logs on the system.
 	stateEngine storage.Engine
@@ -881,6 +886,7 @@ type Store struct {
 	sstSnapshotStorage  SSTSnapshotStorage
 	protectedtsReader   spanconfig.ProtectedTSReader
 	ctSender            *sidetransport.Sender
+	storeLiveness       *storeliveness.SupportManager // nil if disabled
 	storeGossip         *StoreGossip
 	rebalanceObjManager *RebalanceObjectiveManager
 	// raftTransportForFlowControl exposes the set of (remote) stores the raft
@@ -1141,6 +1147,15 @@ type StoreConfig struct {
 	ClosedTimestampSender   *sidetransport.Sender
 	ClosedTimestampReceiver sidetransportReceiver
 
+	// StoreLivenessTransport is used by the store's liveness SupportManager to
+	// exchange heartbeats with other stores. If nil, the store does not take
+	// part in the store liveness fabric and raft leaders are not fortified.
+	StoreLivenessTransport *storeliveness.Transport
+
+	// RaftLogEngines maps the engine of each store that keeps its Raft log in a
+	// separate engine to that engine. See StoreEngines.
+	RaftLogEngines map[storage.Engine]storage.Engine
+
 	// TimeSeriesDataStore is an interface used by the store's time series
 	// maintenance queue to dispatch individual maintenance tasks.
 	TimeSeriesDataStore TimeSeriesDataStore
@@ -1410,6 +1425,15 @@ func (sc *StoreConfig) Tracer() *tracing.Tracer {
 	return sc.AmbientCtx.Tracer
 }
 
+// StoreEngines returns the engines backing the store whose state machine is
+// kept in the given engine.
+func (sc *StoreConfig) StoreEngines(eng storage.Engine) kvstorage.Engines {
+	if logEng, ok := sc.RaftLogEngines[eng]; ok {
+		return kvstorage.MakeSeparatedEngines(eng, logEng)
+	}
+	return kvstorage.MakeEngines(eng)
+}
+
 // NewStore returns a new instance of a store.
 func NewStore(
 	ctx context.Context, cfg StoreConfig, eng storage.Engine, nodeDesc *roachpb.NodeDescriptor,
@@ -1417,6 +1441,7 @@ func NewStore(
 	if !cfg.Valid() {
 		log.Fatalf(ctx, "invalid store configuration: %+v", &cfg)
 	}
+	engs := cfg.StoreEngines(eng)
 	iot := ioThresholds{}
 	iot.Replace(nil, 1.0) // init as empty
 	s := &Store{
@@ -1425,9 +1450,9 @@ func NewStore(
 		// This simplifies going through references to these
 		// engines.
 		internalEngines: internalEngines{
-			stateEngine: eng,
-			todoEngine:  eng,
-			logEngine:   eng,
+			stateEngine: engs.StateEngine(),
+			todoEngine:  engs.StateEngine(),
+			logEngine:   engs.LogEngine(),
 		},
 		cfg:                               cfg,
 		db:                                cfg.DB, // TODO(tschottdorf): remove redundancy.
@@ -2032,9 +2057,9 @@ func (s *Store) Start(ctx context.Context, stopper *stop.Stopper) error {
 	s.stopper = stopper
 
 	// Populate the store ident. If not bootstrapped, ReadStoreIntent will
-	// return an error.
-	// TODO(sep-raft-log): which engine holds the ident?
-	ident, err := kvstorage.ReadStoreIdent(ctx, s.TODOEngine())
+	// return an error. The state engine holds the authoritative ident; a
+	// separate log engine holds a copy, which InitRaftLogEngine verifies.
+	ident, err := kvstorage.ReadStoreIdent(ctx, s.StateEngine())
 	if err != nil {
 		return err
 	}
@@ -2043,14 +2068,46 @@ func (s *Store) Start(ctx context.Context, stopper *stop.Stopper) error {
 	// Set the store ID for logging.
 	s.cfg.AmbientCtx.AddLogTag("s", s.StoreID())
 	ctx = s.AnnotateCtx(ctx)
+
+	// Join the store liveness fabric before any replica is initialized, so that
+	// the support provided by the store before a restart is restored before
+	// raft consults it.
+	if s.cfg.StoreLivenessTransport != nil {
+		s.storeLiveness = storeliveness.NewSupportManager(
+			slpb.StoreIdent{NodeID: s.NodeID(), StoreID: s.StoreID()},
+			s.StateEngine(),
+			storeliveness.NewOptions(
+				s.cfg.RaftTickInterval*time.Duration(s.cfg.RaftHeartbeatIntervalTicks),
+				s.cfg.RangeLeaseDuration,
+			),
+			s.cfg.Clock,
+			stopper,
+			s.cfg.StoreLivenessTransport,
+		)
+		s.cfg.StoreLivenessTransport.ListenMessages(s.StoreID(), s.storeLiveness)
+		if err := s.storeLiveness.Start(ctx); err != nil {
+			return errors.Wrap(err, "starting store liveness")
+		}
+	}
 	log.Event(ctx, "read store identity")
 
-	// Communicate store ID to engine.
-	// TODO(sep-raft-log): do for both engines.
-	if err := s.TODOEngine().SetStoreID(ctx, int32(s.StoreID())); err != nil {
+	// Communicate store ID to engines.
+	if err := s.StateEngine().SetStoreID(ctx, int32(s.StoreID())); err != nil {
 		return err
 	}
 
+	// Move the Raft log into a separate engine if one was configured, and
+	// reconcile it with the state machine. This must happen before any Raft
+	// state is read.
+	if err := kvstorage.InitRaftLogEngine(ctx, s.engines()); err != nil {
+		return err
+	}
+	if s.engines().Separated() {
+		if err := s.LogEngine().SetStoreID(ctx, int32(s.StoreID())); err != nil {
+			return err
+		}
+	}
+
 	{
 		m := rangefeed.NewSchedulerMetrics(s.cfg.HistogramWindowInterval)
 		rfs := rangefeed.NewScheduler(rangefeed.SchedulerConfig{
@@ -2126,7 +2183,7 @@ func (s *Store) Start(ctx context.Context, stopper *stop.Stopper) error {
 	{
 		truncator := s.raftTruncator
 		// When state machine has persisted new RaftAppliedIndex, fire callback.
-		s.TODOEngine().RegisterFlushCompletedCallback(func() {
+		s.StateEngine().RegisterFlushCompletedCallback(func() {
 			truncator.durabilityAdvancedCallback()
 		})
 	}
@@ -2152,10 +2209,7 @@ func (s *Store) Start(ctx context.Context, stopper *stop.Stopper) error {
 	// concurrently. Note that while we can perform this initialization
 	// concurrently, all initialization must be performed before we start
 	// listening for Raft messages and starting the process Raft loop.
-	//
-	// TODO(sep-raft-log): this will need to learn to stitch and reconcile data from
-	// both engines.
-	repls, err := kvstorage.LoadAndReconcileReplicas(ctx, s.TODOEngine())
+	repls, err := kvstorage.LoadAndReconcileReplicas(ctx, s.engines())
 	if err != nil {
 		return err
 	}
@@ -2172,7 +2226,7 @@ func (s *Store) Start(ctx context.Context, stopper *stop.Stopper) error {
 			continue
 		}
 		// TODO(pavelkalinnikov): integrate into kvstorage.LoadAndReconcileReplicas.
-		state, err := repl.Load(ctx, s.TODOEngine(), s.StoreID())
+		state, err := repl.Load(ctx, s.engines(), s.StoreID())
 		if err != nil {
 			return err
 		}
@@ -2931,6 +2985,14 @@ func (s *Store) LogEngine() storage.Engine {
 	return s.internalEngines.logEngine
 }
 
+// engines returns the state and log engines of the store.
+func (s *Store) engines() kvstorage.Engines {
+	if s.internalEngines.logEngine == s.internalEngines.stateEngine {
+		return kvstorage.MakeEngines(s.internalEngines.stateEngine)
+	}
+	return kvstorage.MakeSeparatedEngines(s.internalEngines.stateEngine, s.internalEngines.logEngine)
+}
+
 // DB accessor.
 func (s *Store) DB() *kv.DB { return s.cfg.DB }
 
@@ -3159,6 +3221,7 @@ func (s *Store) updateReplicationGauges(ctx context.Context) error {
 		leaseHolderCount               int64
 		leaseExpirationCount           int64
 		leaseEpochCount                int64
+		leaseLeaderCount               int64
 		leaseLivenessCount             int64
 		leaseViolatingPreferencesCount int64
 		leaseLessPreferredCount        int64
@@ -3231,6 +3294,8 @@ func (s *Store) updateReplicationGauges(ctx context.Context) error {
 				leaseExpirationCount++
 			case roachpb.LeaseEpoch:
 				leaseEpochCount++
+			case roachpb.LeaseLeader:
+				leaseLeaderCount++
 			}
 			if metrics.LivenessLease {
 				leaseLivenessCount++
@@ -3300,6 +3365,7 @@ func (s *Store) updateReplicationGauges(ctx context.Context) error {
 	s.metrics.LeaseHolderCount.Update(leaseHolderCount)
 	s.metrics.LeaseExpirationCount.Update(leaseExpirationCount)
 	s.metrics.LeaseEpochCount.Update(leaseEpochCount)
+	s.metrics.LeaseLeaderCount.Update(leaseLeaderCount)
 	s.metrics.LeaseViolatingPreferencesCount.Update(leaseViolatingPreferencesCount)
 	s.metrics.LeaseLessPreferredCount.Update(leaseLessPreferredCount)
 	s.metrics.LeaseLivenessCount.Update(leaseLivenessCount)
@@ -3585,8 +3651,13 @@ type HotReplicaInfo struct {
 	WriteBytesPerSecond float64
 	ReadBytesPerSecond  float64
 	CPUTimePerSecond    float64
+	// HotKeys are the replica's hottest keys, in decreasing order of load.
+	HotKeys []hotkeys.KeyLoad
 }
 
+// hotKeysPerReplica is the number of hot keys reported for each hot replica.
+const hotKeysPerReplica = 5
+
 // HottestReplicas returns the hottest replicas on a store, sorted by their
 // QPS. Only contains ranges for which this store is the leaseholder.
 //
@@ -3617,6 +3688,9 @@ func mapToHotReplicasInfo(repls []CandidateReplica) []HotReplicaInfo {
 		hotRepls[i].WriteBytesPerSecond = ri.WriteBytesPerSecond
 		hotRepls[i].ReadBytesPerSecond = ri.ReadBytesPerSecond
 		hotRepls[i].CPUTimePerSecond = ri.RaftCPUNanosPerSecond + ri.RequestCPUNanosPerSecond
+		if r := repls[i].Repl(); r != nil {
+			hotRepls[i].HotKeys = r.HotKeys(hotKeysPerReplica)
+		}
 	}
 	return hotRepls
 }
@@ -3974,9 +4048,11 @@ func (s *storeForTruncatorImpl) releaseReplicaForTruncator(r replicaForTruncator
 }
 
 func (s *storeForTruncatorImpl) getEngine() storage.Engine {
-	// TODO(sep-raft-log): we'll need the log engine here but need
-	// to read code to see if more needs to be done.
-	return (*Store)(s).TODOEngine()
+	return (*Store)(s).StateEngine()
+}
+
+func (s *storeForTruncatorImpl) getLogEngine() storage.Engine {
+	return (*Store)(s).LogEngine()
 }
 
 func init() {
@@ -803,6 +803,12 @@ func (kvSS *kvBatchSnapshotStrategy) Send(
 	if sharedReplicate || externalReplicate {
 		replicatedFilter = rditer.ReplicatedSpansExcludeUser
 	}
+	// Witnesses don't store user data, so none is sent to them. The user key
+	// span is still cleared on the recipient.
+	if header.RaftMessageRequest.ToReplica.IsWitness() {
+		sharedReplicate, externalReplicate = false, false
+		replicatedFilter = rditer.ReplicatedSpansExcludeUser
+	}
 
 	iterateRKSpansVisitor := func(iter storage.EngineIterator, _ roachpb.Span, keyType storage.IterKeyType) error {
 		timingTag.start("iter")
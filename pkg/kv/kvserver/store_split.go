@@ -78,6 +78,10 @@ func splitPreApply(
 		// promote replicas without ensuring that a snapshot has been received. So
 		// we write it back (and the RaftReplicaID too, since it's an invariant that
 		// it's always present).
+		//
+		// When the Raft log lives in a separate engine, the batch can't touch the
+		// HardState, which is left alone in the log engine.
+		separated := r.store.engines().Separated()
 		var hs raftpb.HardState
 		if rightRepl != nil {
 			rightRepl.raftMu.Lock()
@@ -88,10 +92,12 @@ func splitPreApply(
 			if rightRepl.IsInitialized() {
 				log.Fatalf(ctx, "unexpectedly found initialized newer RHS of split: %v", rightRepl.Desc())
 			}
-			var err error
-			hs, err = rightRepl.raftMu.stateLoader.LoadHardState(ctx, readWriter)
-			if err != nil {
-				log.Fatalf(ctx, "failed to load hard state for removed rhs: %v", err)
+			if !separated {
+				var err error
+				hs, err = rightRepl.raftMu.stateLoader.LoadHardState(ctx, readWriter)
+				if err != nil {
+					log.Fatalf(ctx, "failed to load hard state for removed rhs: %v", err)
+				}
 			}
 		}
 		if err := kvstorage.ClearRangeData(ctx, split.RightDesc.RangeID, readWriter, readWriter, kvstorage.ClearRangeDataOptions{
@@ -124,8 +130,10 @@ func splitPreApply(
 			// Cleared the HardState and RaftReplicaID, so rewrite them to the current
 			// values. NB: rightRepl.raftMu is still locked since HardState was read,
 			// so it can't have been rewritten in the meantime (fixed in #75918).
-			if err := rightRepl.raftMu.stateLoader.SetHardState(ctx, readWriter, hs); err != nil {
-				log.Fatalf(ctx, "failed to set hard state with 0 commit index for removed rhs: %v", err)
+			if !separated {
+				if err := rightRepl.raftMu.stateLoader.SetHardState(ctx, readWriter, hs); err != nil {
+					log.Fatalf(ctx, "failed to set hard state with 0 commit index for removed rhs: %v", err)
+				}
 			}
 			if err := rightRepl.raftMu.stateLoader.SetRaftReplicaID(
 				ctx, readWriter, rightRepl.ReplicaID()); err != nil {
@@ -138,8 +146,17 @@ func splitPreApply(
 	// Update the raft HardState with the new Commit value now that the
 	// replica is initialized (combining it with existing or default
 	// Term and Vote). This is the common case.
+	//
+	// If the Raft log lives in a separate engine, the RaftTruncatedState staged
+	// by the split trigger is dropped from the batch instead, and the Raft state
+	// is written to the log engine once the batch has been committed; see
+	// prepareRightReplicaForSplit.
 	rsl := stateloader.Make(split.RightDesc.RangeID)
-	if err := rsl.SynthesizeRaftState(ctx, readWriter); err != nil {
+	if r.store.engines().Separated() {
+		if err := kvstorage.ClearRaftLogState(readWriter, split.RightDesc.RangeID); err != nil {
+			log.Fatalf(ctx, "%v", err)
+		}
+	} else if err := rsl.SynthesizeRaftState(ctx, readWriter); err != nil {
 		log.Fatalf(ctx, "%v", err)
 	}
 	// Write the RaftReplicaID for the RHS to maintain the invariant that any
@@ -244,8 +261,19 @@ func prepareRightReplicaForSplit(
 	}
 	// Finish initialization of the RHS replica.
 
+	// With a separate Raft log engine, the split trigger has only written the
+	// state machine of the RHS. Bring its Raft state in line with it before
+	// loading it. A crash before this point is handled by the reconciliation
+	// at store startup in the same way.
+	engs := r.store.engines()
+	if engs.Separated() {
+		if err := initSplitRaftLogState(ctx, engs, split.RightDesc.RangeID); err != nil {
+			log.Fatalf(ctx, "%v", err)
+		}
+	}
+
 	state, err := kvstorage.LoadReplicaState(
-		ctx, r.store.TODOEngine(), r.StoreID(), &split.RightDesc, rightRepl.replicaID)
+		ctx, engs, r.StoreID(), &split.RightDesc, rightRepl.replicaID)
 	if err != nil {
 		log.Fatalf(ctx, "%v", err)
 	}
@@ -287,6 +315,26 @@ func prepareRightReplicaForSplit(
 	return rightRepl
 }
 
+// initSplitRaftLogState initializes the Raft state of the RHS of a split in a
+// separate Raft log engine, using the applied state written by the split
+// trigger.
+func initSplitRaftLogState(
+	ctx context.Context, engs kvstorage.Engines, rangeID roachpb.RangeID,
+) error {
+	as, err := stateloader.Make(rangeID).LoadRangeAppliedState(ctx, engs.StateEngine())
+	if err != nil {
+		return err
+	}
+	batch := engs.LogEngine().NewBatch()
+	defer batch.Close()
+	if err := kvstorage.ReconcileRaftLogState(
+		ctx, batch, rangeID, as.RaftAppliedIndex, as.RaftAppliedIndexTerm,
+	); err != nil {
+		return err
+	}
+	return batch.Commit(true /* sync */)
+}
+
 // SplitRange shortens the original range to accommodate the new range. The new
 // range is added to the ranges map and the replicasByKey btree. origRng.raftMu
 // and newRng.raftMu must be held.
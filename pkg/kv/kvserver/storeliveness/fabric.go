@@ -0,0 +1,47 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package storeliveness implements the store liveness fabric: a mechanism by
+// which stores request and provide time-bounded support to each other.
+//
+// A store requests support from another store by periodically heartbeating it
+// at its current epoch. The supporting store grants support for the epoch
+// until an expiration, and extends it with every heartbeat. Once support for
+// an epoch expires, the supporter withdraws it by moving on to a later epoch,
+// and never provides support for the withdrawn epoch again. The requester
+// learns of the withdrawal through the next heartbeat response and starts
+// requesting support at the later epoch.
+//
+// Raft leaders use the fabric to fortify their leadership: followers promise
+// not to vote for other candidates while their store supports the leader's
+// store at a given epoch, which allows the leader to hold a lease until the
+// support of a quorum expires. See raftstoreliveness.StoreLiveness.
+package storeliveness
+
+import (
+	slpb "github.com/cockroachdb/cockroach/pkg/kv/kvserver/storeliveness/storelivenesspb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+)
+
+// Fabric is the interface exposed by the store liveness fabric to its clients.
+type Fabric interface {
+	// SupportFor returns the epoch of the current uninterrupted period of
+	// support that the local store provides for the given store, along with
+	// whether that support is currently active.
+	SupportFor(id slpb.StoreIdent) (slpb.Epoch, bool)
+
+	// SupportFrom returns the epoch of the current uninterrupted period of
+	// support that the local store receives from the given store, along with
+	// the time at which that support expires. The returned timestamp is empty
+	// if no support is being received. Calling SupportFrom for a store that
+	// the local store isn't requesting support from yet makes it start doing
+	// so.
+	SupportFrom(id slpb.StoreIdent) (slpb.Epoch, hlc.Timestamp)
+}
@@ -0,0 +1,31 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package storelivenesspb
+
+import "github.com/cockroachdb/redact"
+
+// Epoch is an epoch in the store liveness fabric. A store requests support
+// from other stores at its current epoch; once support for an epoch has been
+// withdrawn, it is never provided again.
+type Epoch int64
+
+// SafeValue implements the redact.SafeValue interface.
+func (Epoch) SafeValue() {}
+
+// SafeFormat implements the redact.SafeFormatter interface.
+func (s StoreIdent) SafeFormat(w redact.SafePrinter, _ rune) {
+	w.Printf("n%d,s%d", s.NodeID, s.StoreID)
+}
+
+// String implements the fmt.Stringer interface.
+func (s StoreIdent) String() string {
+	return redact.StringWithoutMarkers(s)
+}
@@ -0,0 +1,387 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package storeliveness
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	slpb "github.com/cockroachdb/cockroach/pkg/kv/kvserver/storeliveness/storelivenesspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// Options configures a SupportManager.
+type Options struct {
+	// HeartbeatInterval is the interval at which a store heartbeats the stores
+	// it requests support from.
+	HeartbeatInterval time.Duration
+	// SupportDuration is the duration of the support requested by each
+	// heartbeat. It must be larger than HeartbeatInterval for support to be
+	// uninterrupted.
+	SupportDuration time.Duration
+	// SupportExpiryInterval is the interval at which a store checks whether the
+	// support it provides has expired and should be withdrawn.
+	SupportExpiryInterval time.Duration
+}
+
+// NewOptions returns Options for the given support duration, heartbeating
+// and checking for expired support at the given interval.
+func NewOptions(heartbeatInterval, supportDuration time.Duration) Options {
+	return Options{
+		HeartbeatInterval:     heartbeatInterval,
+		SupportDuration:       supportDuration,
+		SupportExpiryInterval: heartbeatInterval,
+	}
+}
+
+// MessageSender is the interface used by a SupportManager to send messages to
+// other stores. It is implemented by Transport.
+type MessageSender interface {
+	// SendAsync sends the message asynchronously, returning false if it was
+	// dropped. Messages may be lost; heartbeats are retried periodically.
+	SendAsync(ctx context.Context, msg slpb.Message) (sent bool)
+}
+
+// SupportManager implements the store liveness fabric for a single store. It
+// requests support from other stores (the requester role) and provides
+// support to other stores (the supporter role).
+type SupportManager struct {
+	storeID slpb.StoreIdent
+	engine  storage.Engine
+	options Options
+	clock   *hlc.Clock
+	stopper *stop.Stopper
+	sender  MessageSender
+
+	mu struct {
+		syncutil.RWMutex
+		// epoch is the epoch under which the store requests support.
+		epoch slpb.Epoch
+		// supportFrom is the support received from other stores, keyed by the
+		// supporting store. All entries are at the current epoch. Every store
+		// in the map is heartbeated.
+		supportFrom map[slpb.StoreIdent]slpb.SupportState
+		// supportFor is the support provided to other stores, keyed by the
+		// supported store. An empty expiration denotes that support for the
+		// entry's epoch has not been granted (yet).
+		supportFor map[slpb.StoreIdent]slpb.SupportState
+	}
+}
+
+var _ Fabric = (*SupportManager)(nil)
+
+// NewSupportManager creates a SupportManager for the given store. Start must
+// be called before the SupportManager is used.
+func NewSupportManager(
+	storeID slpb.StoreIdent,
+	engine storage.Engine,
+	options Options,
+	clock *hlc.Clock,
+	stopper *stop.Stopper,
+	sender MessageSender,
+) *SupportManager {
+	sm := &SupportManager{
+		storeID: storeID,
+		engine:  engine,
+		options: options,
+		clock:   clock,
+		stopper: stopper,
+		sender:  sender,
+	}
+	sm.mu.supportFrom = map[slpb.StoreIdent]slpb.SupportState{}
+	sm.mu.supportFor = map[slpb.StoreIdent]slpb.SupportState{}
+	return sm
+}
+
+// Start loads the persisted state of the store and starts heartbeating other
+// stores and withdrawing expired support.
+func (sm *SupportManager) Start(ctx context.Context) error {
+	if err := sm.loadState(ctx); err != nil {
+		return err
+	}
+	if err := sm.stopper.RunAsyncTask(ctx, "storeliveness.SupportManager: heartbeats",
+		func(ctx context.Context) {
+			sm.runLoop(ctx, sm.options.HeartbeatInterval, sm.sendHeartbeats)
+		}); err != nil {
+		return err
+	}
+	return sm.stopper.RunAsyncTask(ctx, "storeliveness.SupportManager: support expiry",
+		func(ctx context.Context) {
+			sm.runLoop(ctx, sm.options.SupportExpiryInterval, sm.withdrawSupport)
+		})
+}
+
+func (sm *SupportManager) runLoop(
+	ctx context.Context, interval time.Duration, f func(context.Context),
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			f(ctx)
+		case <-sm.stopper.ShouldQuiesce():
+			return
+		}
+	}
+}
+
+// loadState loads the persisted requester and supporter state. The requester
+// epoch is incremented, since any support received before the restart may
+// have been relied upon by the previous incarnation of the store. Support
+// provided before the restart is conservatively assumed to be valid until a
+// full SupportDuration has passed.
+func (sm *SupportManager) loadState(ctx context.Context) error {
+	var requesterMeta slpb.RequesterMeta
+	if _, err := storage.MVCCGetProto(ctx, sm.engine, keys.StoreLivenessRequesterMetaKey(),
+		hlc.Timestamp{}, &requesterMeta, storage.MVCCGetOptions{}); err != nil {
+		return errors.Wrap(err, "loading store liveness requester state")
+	}
+	var supporterMeta slpb.SupporterMeta
+	if _, err := storage.MVCCGetProto(ctx, sm.engine, keys.StoreLivenessSupporterMetaKey(),
+		hlc.Timestamp{}, &supporterMeta, storage.MVCCGetOptions{}); err != nil {
+		return errors.Wrap(err, "loading store liveness supporter state")
+	}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.mu.epoch = requesterMeta.Epoch + 1
+	if err := sm.persistRequesterMetaLocked(ctx); err != nil {
+		return err
+	}
+	exp := sm.clock.Now().AddDuration(sm.options.SupportDuration)
+	for _, ss := range supporterMeta.SupportFor {
+		ss.Expiration = exp
+		sm.mu.supportFor[ss.Target] = ss
+	}
+	log.Infof(ctx, "store liveness started at epoch %d, supporting %d stores",
+		sm.mu.epoch, len(sm.mu.supportFor))
+	return nil
+}
+
+// SupportFor implements the Fabric interface.
+func (sm *SupportManager) SupportFor(id slpb.StoreIdent) (slpb.Epoch, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	if id == sm.storeID {
+		return sm.mu.epoch, true
+	}
+	ss, ok := sm.mu.supportFor[id]
+	if !ok || ss.Expiration.IsEmpty() {
+		return 0, false
+	}
+	return ss.Epoch, true
+}
+
+// SupportForExpiration is like SupportFor, but returns the expiration of the
+// support provided for the given store instead of whether it is active. The
+// returned timestamp is empty if no support is provided.
+func (sm *SupportManager) SupportForExpiration(id slpb.StoreIdent) (slpb.Epoch, hlc.Timestamp) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	if id == sm.storeID {
+		return sm.mu.epoch, hlc.MaxTimestamp
+	}
+	ss, ok := sm.mu.supportFor[id]
+	if !ok {
+		return 0, hlc.Timestamp{}
+	}
+	return ss.Epoch, ss.Expiration
+}
+
+// SupportFrom implements the Fabric interface.
+func (sm *SupportManager) SupportFrom(id slpb.StoreIdent) (slpb.Epoch, hlc.Timestamp) {
+	sm.mu.RLock()
+	if id == sm.storeID {
+		defer sm.mu.RUnlock()
+		return sm.mu.epoch, hlc.MaxTimestamp
+	}
+	ss, ok := sm.mu.supportFrom[id]
+	sm.mu.RUnlock()
+	if ok {
+		return ss.Epoch, ss.Expiration
+	}
+
+	// Start requesting support from the store with the next heartbeat.
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if _, ok := sm.mu.supportFrom[id]; !ok {
+		sm.mu.supportFrom[id] = slpb.SupportState{Target: id, Epoch: sm.mu.epoch}
+	}
+	return sm.mu.epoch, hlc.Timestamp{}
+}
+
+// sendHeartbeats heartbeats all stores that the local store requests support
+// from.
+func (sm *SupportManager) sendHeartbeats(ctx context.Context) {
+	// NB: the messages are sent outside of the lock, since responses from local
+	// stores are delivered synchronously.
+	var msgs []slpb.Message
+	func() {
+		sm.mu.RLock()
+		defer sm.mu.RUnlock()
+		exp := sm.clock.Now().AddDuration(sm.options.SupportDuration)
+		for id := range sm.mu.supportFrom {
+			msgs = append(msgs, slpb.Message{
+				Type:       slpb.MsgHeartbeat,
+				From:       sm.storeID,
+				To:         id,
+				Epoch:      sm.mu.epoch,
+				Expiration: exp,
+			})
+		}
+	}()
+	for _, msg := range msgs {
+		sm.sender.SendAsync(ctx, msg)
+	}
+}
+
+// withdrawSupport withdraws the support for all stores whose support has
+// expired, by moving on to the next epoch.
+func (sm *SupportManager) withdrawSupport(ctx context.Context) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	now := sm.clock.Now()
+	var withdrawn int
+	for id, ss := range sm.mu.supportFor {
+		if ss.Expiration.IsEmpty() || now.LessEq(ss.Expiration) {
+			continue
+		}
+		ss.Epoch++
+		ss.Expiration = hlc.Timestamp{}
+		sm.mu.supportFor[id] = ss
+		withdrawn++
+	}
+	if withdrawn == 0 {
+		return
+	}
+	// NB: the withdrawal must be durable before a vote relying on it can be
+	// cast. Raft consults SupportFor synchronously, so persist while holding
+	// the lock.
+	if err := sm.persistSupporterMetaLocked(ctx); err != nil {
+		log.Fatalf(ctx, "failed to persist store liveness support withdrawal: %v", err)
+	}
+	log.VEventf(ctx, 2, "withdrew store liveness support for %d stores", withdrawn)
+}
+
+// HandleMessage handles a message from another store.
+func (sm *SupportManager) HandleMessage(ctx context.Context, msg slpb.Message) {
+	switch msg.Type {
+	case slpb.MsgHeartbeat:
+		sm.handleHeartbeat(ctx, msg)
+	case slpb.MsgHeartbeatResp:
+		sm.handleHeartbeatResp(ctx, msg)
+	default:
+		log.Errorf(ctx, "unexpected store liveness message type %s", msg.Type)
+	}
+}
+
+// handleHeartbeat handles a request for support from another store and
+// responds with the support provided.
+func (sm *SupportManager) handleHeartbeat(ctx context.Context, msg slpb.Message) {
+	resp := func() slpb.Message {
+		sm.mu.Lock()
+		defer sm.mu.Unlock()
+		// Never grant support beyond what a heartbeat sent by the local store
+		// would request, which bounds the support provided before a restart.
+		exp := msg.Expiration
+		exp.Backward(sm.clock.Now().AddDuration(sm.options.SupportDuration))
+		ss, ok := sm.mu.supportFor[msg.From]
+		switch {
+		case !ok || ss.Epoch < msg.Epoch:
+			// The requester moved on to a new epoch. Support for its previous
+			// epoch is no longer needed, because the requester restarted or
+			// learned that the support had been withdrawn.
+			ss = slpb.SupportState{Target: msg.From, Epoch: msg.Epoch, Expiration: exp}
+			sm.mu.supportFor[msg.From] = ss
+			if err := sm.persistSupporterMetaLocked(ctx); err != nil {
+				log.Fatalf(ctx, "failed to persist store liveness support: %v", err)
+			}
+		case ss.Epoch == msg.Epoch:
+			ss.Expiration.Forward(exp)
+			sm.mu.supportFor[msg.From] = ss
+		default:
+			// The heartbeat is for an epoch for which support was withdrawn.
+			// Respond with the current epoch to inform the requester.
+		}
+		return slpb.Message{
+			Type:       slpb.MsgHeartbeatResp,
+			From:       sm.storeID,
+			To:         msg.From,
+			Epoch:      ss.Epoch,
+			Expiration: ss.Expiration,
+		}
+	}()
+	sm.sender.SendAsync(ctx, resp)
+}
+
+// handleHeartbeatResp records the support provided by another store.
+func (sm *SupportManager) handleHeartbeatResp(ctx context.Context, msg slpb.Message) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	switch {
+	case msg.Epoch > sm.mu.epoch:
+		// The supporter withdrew its support for the current epoch. Move on to
+		// its epoch, which invalidates the support received from all stores.
+		log.VEventf(ctx, 2, "store liveness support withdrawn by %s, moving from epoch %d to %d",
+			msg.From, sm.mu.epoch, msg.Epoch)
+		sm.mu.epoch = msg.Epoch
+		if err := sm.persistRequesterMetaLocked(ctx); err != nil {
+			log.Fatalf(ctx, "failed to persist store liveness epoch: %v", err)
+		}
+		for id := range sm.mu.supportFrom {
+			sm.mu.supportFrom[id] = slpb.SupportState{Target: id, Epoch: sm.mu.epoch}
+		}
+	case msg.Epoch == sm.mu.epoch:
+		ss := sm.mu.supportFrom[msg.From]
+		ss.Target = msg.From
+		ss.Epoch = msg.Epoch
+		ss.Expiration.Forward(msg.Expiration)
+		sm.mu.supportFrom[msg.From] = ss
+	default:
+		// A stale response for an epoch that the local store moved on from.
+	}
+}
+
+func (sm *SupportManager) persistRequesterMetaLocked(ctx context.Context) error {
+	meta := slpb.RequesterMeta{Epoch: sm.mu.epoch}
+	return sm.persist(ctx, keys.StoreLivenessRequesterMetaKey(), &meta)
+}
+
+func (sm *SupportManager) persistSupporterMetaLocked(ctx context.Context) error {
+	var meta slpb.SupporterMeta
+	for _, ss := range sm.mu.supportFor {
+		// Expirations are not persisted, see loadState.
+		meta.SupportFor = append(meta.SupportFor, slpb.SupportState{Target: ss.Target, Epoch: ss.Epoch})
+	}
+	return sm.persist(ctx, keys.StoreLivenessSupporterMetaKey(), &meta)
+}
+
+func (sm *SupportManager) persist(
+	ctx context.Context, key roachpb.Key, msg protoutil.Message,
+) error {
+	batch := sm.engine.NewBatch()
+	defer batch.Close()
+	if err := storage.MVCCPutProto(
+		ctx, batch, key, hlc.Timestamp{}, msg, storage.MVCCWriteOptions{},
+	); err != nil {
+		return err
+	}
+	return batch.Commit(true /* sync */)
+}
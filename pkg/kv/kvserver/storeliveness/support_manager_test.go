@@ -0,0 +1,180 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package storeliveness
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	slpb "github.com/cockroachdb/cockroach/pkg/kv/kvserver/storeliveness/storelivenesspb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/stretchr/testify/require"
+)
+
+// testSender delivers messages synchronously to the SupportManagers of a test,
+// unless the recipient is partitioned away.
+type testSender struct {
+	managers    map[slpb.StoreIdent]*SupportManager
+	partitioned map[slpb.StoreIdent]bool
+}
+
+func (s *testSender) SendAsync(ctx context.Context, msg slpb.Message) bool {
+	if s.partitioned[msg.From] || s.partitioned[msg.To] {
+		return false
+	}
+	s.managers[msg.To].HandleMessage(ctx, msg)
+	return true
+}
+
+var (
+	store1 = slpb.StoreIdent{NodeID: 1, StoreID: 1}
+	store2 = slpb.StoreIdent{NodeID: 2, StoreID: 2}
+)
+
+const testSupportDuration = 3 * time.Second
+
+func newTestSupportManager(
+	t *testing.T,
+	id slpb.StoreIdent,
+	eng storage.Engine,
+	clock *hlc.Clock,
+	stopper *stop.Stopper,
+	sender *testSender,
+) *SupportManager {
+	sm := NewSupportManager(
+		id, eng, NewOptions(time.Second, testSupportDuration), clock, stopper, sender,
+	)
+	require.NoError(t, sm.loadState(context.Background()))
+	sender.managers[id] = sm
+	return sm
+}
+
+func TestSupportManager(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	manual := timeutil.NewManualTime(timeutil.Unix(1, 0))
+	clock := hlc.NewClockForTesting(manual)
+	sender := &testSender{
+		managers:    map[slpb.StoreIdent]*SupportManager{},
+		partitioned: map[slpb.StoreIdent]bool{},
+	}
+	eng1 := storage.NewDefaultInMemForTesting()
+	defer eng1.Close()
+	eng2 := storage.NewDefaultInMemForTesting()
+	defer eng2.Close()
+	sm1 := newTestSupportManager(t, store1, eng1, clock, stopper, sender)
+	sm2 := newTestSupportManager(t, store2, eng2, clock, stopper, sender)
+
+	// A store always supports itself.
+	epoch, exp := sm1.SupportFrom(store1)
+	require.Equal(t, slpb.Epoch(1), epoch)
+	require.Equal(t, hlc.MaxTimestamp, exp)
+
+	// Support is requested lazily and received with the next heartbeat.
+	epoch, exp = sm1.SupportFrom(store2)
+	require.Equal(t, slpb.Epoch(1), epoch)
+	require.True(t, exp.IsEmpty())
+	_, ok := sm2.SupportFor(store1)
+	require.False(t, ok)
+
+	sm1.sendHeartbeats(ctx)
+	epoch, ok = sm2.SupportFor(store1)
+	require.True(t, ok)
+	require.Equal(t, slpb.Epoch(1), epoch)
+	epoch, exp = sm1.SupportFrom(store2)
+	require.Equal(t, slpb.Epoch(1), epoch)
+	require.Equal(t, clock.Now().AddDuration(testSupportDuration).WallTime, exp.WallTime)
+
+	// Support isn't withdrawn before it expires.
+	manual.Advance(testSupportDuration)
+	sm2.withdrawSupport(ctx)
+	_, ok = sm2.SupportFor(store1)
+	require.True(t, ok)
+
+	// Support is withdrawn once it expires, and the requester learns of the
+	// withdrawal through the next heartbeat.
+	sender.partitioned[store1] = true
+	manual.Advance(time.Second)
+	sm2.withdrawSupport(ctx)
+	_, ok = sm2.SupportFor(store1)
+	require.False(t, ok)
+
+	delete(sender.partitioned, store1)
+	sm1.sendHeartbeats(ctx)
+	epoch, exp = sm1.SupportFrom(store2)
+	require.Equal(t, slpb.Epoch(2), epoch)
+	require.True(t, exp.IsEmpty())
+
+	// Support is granted again at the new epoch.
+	sm1.sendHeartbeats(ctx)
+	epoch, ok = sm2.SupportFor(store1)
+	require.True(t, ok)
+	require.Equal(t, slpb.Epoch(2), epoch)
+	epoch, exp = sm1.SupportFrom(store2)
+	require.Equal(t, slpb.Epoch(2), epoch)
+	require.False(t, exp.IsEmpty())
+}
+
+func TestSupportManagerRestart(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	manual := timeutil.NewManualTime(timeutil.Unix(1, 0))
+	clock := hlc.NewClockForTesting(manual)
+	sender := &testSender{
+		managers:    map[slpb.StoreIdent]*SupportManager{},
+		partitioned: map[slpb.StoreIdent]bool{},
+	}
+	eng1 := storage.NewDefaultInMemForTesting()
+	defer eng1.Close()
+	eng2 := storage.NewDefaultInMemForTesting()
+	defer eng2.Close()
+	sm1 := newTestSupportManager(t, store1, eng1, clock, stopper, sender)
+	newTestSupportManager(t, store2, eng2, clock, stopper, sender)
+
+	sm1.SupportFrom(store2)
+	sm1.sendHeartbeats(ctx)
+
+	// The requester's epoch is incremented on restart, since support received
+	// by the previous incarnation may have been relied upon.
+	sm1 = newTestSupportManager(t, store1, eng1, clock, stopper, sender)
+	epoch, _ := sm1.SupportFrom(store1)
+	require.Equal(t, slpb.Epoch(2), epoch)
+
+	// The supporter continues to provide support for the epoch it supported
+	// before the restart, for a full support duration.
+	sm2 := newTestSupportManager(t, store2, eng2, clock, stopper, sender)
+	epoch, ok := sm2.SupportFor(store1)
+	require.True(t, ok)
+	require.Equal(t, slpb.Epoch(1), epoch)
+
+	manual.Advance(testSupportDuration)
+	sm2.withdrawSupport(ctx)
+	_, ok = sm2.SupportFor(store1)
+	require.True(t, ok)
+	manual.Advance(time.Second)
+	sm2.withdrawSupport(ctx)
+	_, ok = sm2.SupportFor(store1)
+	require.False(t, ok)
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package storeliveness
+
+import (
+	"context"
+	"unsafe"
+
+	slpb "github.com/cockroachdb/cockroach/pkg/kv/kvserver/storeliveness/storelivenesspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/rpc"
+	"github.com/cockroachdb/cockroach/pkg/rpc/nodedialer"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"google.golang.org/grpc"
+)
+
+// sendBufferSize is the maximum number of messages queued for a node. Messages
+// beyond that are dropped; heartbeats are retried periodically.
+const sendBufferSize = 1000
+
+// MessageHandler is the interface used by Transport to deliver messages to a
+// store.
+type MessageHandler interface {
+	HandleMessage(ctx context.Context, msg slpb.Message)
+}
+
+// Transport sends and receives store liveness messages over gRPC. Messages to
+// the same node are batched into a single RPC.
+type Transport struct {
+	log.AmbientContext
+	stopper *stop.Stopper
+	dialer  *nodedialer.Dialer
+
+	handlers syncutil.IntMap // map[roachpb.StoreID]*MessageHandler
+	queues   syncutil.IntMap // map[roachpb.NodeID]*chan slpb.Message
+}
+
+var _ MessageSender = (*Transport)(nil)
+var _ slpb.StoreLivenessServer = (*Transport)(nil)
+
+// NewTransport creates a new Transport and registers it with the gRPC server.
+func NewTransport(
+	ambient log.AmbientContext,
+	stopper *stop.Stopper,
+	dialer *nodedialer.Dialer,
+	grpcServer *grpc.Server,
+) *Transport {
+	t := &Transport{
+		AmbientContext: ambient,
+		stopper:        stopper,
+		dialer:         dialer,
+	}
+	if grpcServer != nil {
+		slpb.RegisterStoreLivenessServer(grpcServer, t)
+	}
+	return t
+}
+
+// ListenMessages registers a MessageHandler to receive the messages sent to
+// the given store.
+func (t *Transport) ListenMessages(storeID roachpb.StoreID, handler MessageHandler) {
+	t.handlers.Store(int64(storeID), unsafe.Pointer(&handler))
+}
+
+// StopMessages unregisters the MessageHandler of the given store.
+func (t *Transport) StopMessages(storeID roachpb.StoreID) {
+	t.handlers.Delete(int64(storeID))
+}
+
+func (t *Transport) getHandler(storeID roachpb.StoreID) (MessageHandler, bool) {
+	if value, ok := t.handlers.Load(int64(storeID)); ok {
+		return *(*MessageHandler)(value), true
+	}
+	return nil, false
+}
+
+// SendMessages implements the StoreLivenessServer interface.
+func (t *Transport) SendMessages(
+	ctx context.Context, batch *slpb.MessageBatch,
+) (*slpb.MessageBatchResponse, error) {
+	for _, msg := range batch.Messages {
+		handler, ok := t.getHandler(msg.To.StoreID)
+		if !ok {
+			log.VEventf(ctx, 2, "unable to deliver store liveness message to unknown store %s", msg.To)
+			continue
+		}
+		handler.HandleMessage(ctx, msg)
+	}
+	return &slpb.MessageBatchResponse{}, nil
+}
+
+// SendAsync implements the MessageSender interface. Messages to local stores
+// are delivered directly.
+func (t *Transport) SendAsync(ctx context.Context, msg slpb.Message) (sent bool) {
+	if handler, ok := t.getHandler(msg.To.StoreID); ok {
+		handler.HandleMessage(ctx, msg)
+		return true
+	}
+	nodeID := msg.To.NodeID
+	value, ok := t.queues.Load(int64(nodeID))
+	if !ok {
+		newQ := make(chan slpb.Message, sendBufferSize)
+		var loaded bool
+		value, loaded = t.queues.LoadOrStore(int64(nodeID), unsafe.Pointer(&newQ))
+		if !loaded {
+			if err := t.stopper.RunAsyncTask(
+				t.AnnotateCtx(context.Background()), "storeliveness.Transport: sending messages",
+				func(ctx context.Context) { t.processQueue(ctx, nodeID, newQ) },
+			); err != nil {
+				t.queues.Delete(int64(nodeID))
+				return false
+			}
+		}
+	}
+	select {
+	case *(*chan slpb.Message)(value) <- msg:
+		return true
+	default:
+		log.VEventf(ctx, 2, "dropping store liveness message to %s: queue full", msg.To)
+		return false
+	}
+}
+
+// processQueue sends the messages queued for the given node until the stopper
+// quiesces. Messages are batched while a previous RPC is in flight.
+func (t *Transport) processQueue(ctx context.Context, nodeID roachpb.NodeID, q chan slpb.Message) {
+	defer t.queues.Delete(int64(nodeID))
+	var batch slpb.MessageBatch
+	for {
+		select {
+		case <-t.stopper.ShouldQuiesce():
+			return
+		case msg := <-q:
+			batch.Messages = append(batch.Messages[:0], msg)
+		}
+		for done := false; !done && len(batch.Messages) < sendBufferSize; {
+			select {
+			case msg := <-q:
+				batch.Messages = append(batch.Messages, msg)
+			default:
+				done = true
+			}
+		}
+		conn, err := t.dialer.Dial(ctx, nodeID, rpc.SystemClass)
+		if err != nil {
+			log.VEventf(ctx, 2, "unable to dial n%d for store liveness: %v", nodeID, err)
+			continue
+		}
+		if _, err := slpb.NewStoreLivenessClient(conn).SendMessages(ctx, &batch); err != nil {
+			log.VEventf(ctx, 2, "unable to send store liveness messages to n%d: %v", nodeID, err)
+		}
+	}
+}
@@ -89,6 +89,7 @@ type Entry struct {
 	Name               roachpb.TenantName
 	DataState          mtinfopb.TenantDataState
 	ServiceMode        mtinfopb.TenantServiceMode
+	Admission          mtinfopb.AdmissionConfig
 }
 
 // Ready indicates whether the metadata record is populated.
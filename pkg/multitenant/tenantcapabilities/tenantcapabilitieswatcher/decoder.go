@@ -90,6 +90,7 @@ func (d *decoder) decode(
 		Name:               info.Name,
 		DataState:          info.DataState,
 		ServiceMode:        info.ServiceMode,
+		Admission:          info.Admission,
 	}, nil
 }
 
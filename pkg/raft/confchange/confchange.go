@@ -117,6 +117,7 @@ func (c Changer) LeaveJoint() (tracker.Config, tracker.ProgressMap, error) {
 
 		if !isVoter && !isLearner {
 			delete(trk, id)
+			nilAwareDelete(&cfg.Witnesses, id)
 		}
 	}
 	*outgoingPtr(&cfg.Voters) = nil
@@ -163,10 +164,21 @@ func (c Changer) apply(
 			continue
 		}
 		switch cc.Type {
-		case pb.ConfChangeAddNode:
-			c.makeVoter(cfg, trk, cc.NodeID)
-		case pb.ConfChangeAddLearnerNode:
-			c.makeLearner(cfg, trk, cc.NodeID)
+		case pb.ConfChangeAddNode, pb.ConfChangeAddLearnerNode:
+			// A witness does not have the state of a voter or learner, so it
+			// has to be removed and added back instead.
+			if pr := trk[cc.NodeID]; pr != nil && pr.IsWitness {
+				return fmt.Errorf("%d is a witness and can't be turned into a voter or learner", cc.NodeID)
+			}
+			if cc.Type == pb.ConfChangeAddNode {
+				c.makeVoter(cfg, trk, cc.NodeID)
+			} else {
+				c.makeLearner(cfg, trk, cc.NodeID)
+			}
+		case pb.ConfChangeAddWitnessNode:
+			if err := c.makeWitness(cfg, trk, cc.NodeID); err != nil {
+				return err
+			}
 		case pb.ConfChangeRemoveNode:
 			c.remove(cfg, trk, cc.NodeID)
 		case pb.ConfChangeUpdateNode:
@@ -195,6 +207,36 @@ func (c Changer) makeVoter(cfg *tracker.Config, trk tracker.ProgressMap, id uint
 	incoming(cfg.Voters)[id] = struct{}{}
 }
 
+// makeWitness adds or promotes the given ID to be a witness in the incoming
+// majority config. New peers and learners can become witnesses, the latter
+// being how witnesses are usually added: as learners first, so that they
+// catch up before they count towards the quorum. Voters can't be turned into
+// witnesses.
+func (c Changer) makeWitness(cfg *tracker.Config, trk tracker.ProgressMap, id uint64) error {
+	pr := trk[id]
+	if pr == nil {
+		c.initProgress(cfg, trk, id, false /* isLearner */)
+		trk[id].IsWitness = true
+		nilAwareAdd(&cfg.Witnesses, id)
+		return nil
+	}
+	if pr.IsLearner {
+		pr.IsLearner = false
+		pr.IsWitness = true
+		nilAwareDelete(&cfg.Learners, id)
+		nilAwareAdd(&cfg.Witnesses, id)
+		incoming(cfg.Voters)[id] = struct{}{}
+		return nil
+	}
+	if !pr.IsWitness {
+		return fmt.Errorf("%d is a voter and can't be turned into a witness", id)
+	}
+	// The witness may be in the outgoing config only, if it was removed
+	// when entering the joint config.
+	incoming(cfg.Voters)[id] = struct{}{}
+	return nil
+}
+
 // makeLearner makes the given ID a learner or stages it to be a learner once
 // an active joint configuration is exited.
 //
@@ -247,6 +289,7 @@ func (c Changer) remove(cfg *tracker.Config, trk tracker.ProgressMap, id uint64)
 	// If the peer is still a voter in the outgoing config, keep the Progress.
 	if _, onRight := outgoing(cfg.Voters)[id]; !onRight {
 		delete(trk, id)
+		nilAwareDelete(&cfg.Witnesses, id)
 	}
 }
 
@@ -323,6 +366,21 @@ func checkInvariants(cfg tracker.Config, trk tracker.ProgressMap) error {
 			return fmt.Errorf("%d is in Learners, but is not marked as learner", id)
 		}
 	}
+	// Witnesses are voters, and are exactly the peers marked as witnesses.
+	voters := cfg.Voters.IDs()
+	for id := range cfg.Witnesses {
+		if _, ok := voters[id]; !ok {
+			return fmt.Errorf("%d is in Witnesses, but not in Voters", id)
+		}
+		if !trk[id].IsWitness {
+			return fmt.Errorf("%d is in Witnesses, but is not marked as witness", id)
+		}
+	}
+	for id, pr := range trk {
+		if _, ok := cfg.Witnesses[id]; pr.IsWitness && !ok {
+			return fmt.Errorf("%d is marked as witness, but is not in Witnesses", id)
+		}
+	}
 
 	if !joint(cfg) {
 		// We enforce that empty maps are nil instead of zero.
@@ -45,6 +45,7 @@ func TestConfChangeDataDriven(t *testing.T) {
 		// syntax:
 		// - vn: make n a voter,
 		// - ln: make n a learner,
+		// - wn: make n a witness,
 		// - rn: remove n, and
 		// - un: update n.
 		datadriven.RunTest(t, path, func(t *testing.T, d *datadriven.TestData) string {
@@ -66,6 +67,8 @@ func TestConfChangeDataDriven(t *testing.T) {
 					cc.Type = pb.ConfChangeAddNode
 				case 'l':
 					cc.Type = pb.ConfChangeAddLearnerNode
+				case 'w':
+					cc.Type = pb.ConfChangeAddWitnessNode
 				case 'r':
 					cc.Type = pb.ConfChangeRemoveNode
 				case 'u':
@@ -105,6 +105,7 @@ func TestRestore(t *testing.T) {
 			cs.Learners,
 			cs.VotersOutgoing,
 			cs.LearnersNext,
+			cs.Witnesses,
 		} {
 			sort.Slice(sl, func(i, j int) bool { return sl[i] < sl[j] })
 		}
@@ -131,6 +132,8 @@ after:  %+#v`, cs, cs2)
 		{Voters: ids(1, 2, 3)},
 		{Voters: ids(1, 2, 3), Learners: ids(4, 5, 6)},
 		{Voters: ids(1, 2, 3), Learners: ids(5), VotersOutgoing: ids(1, 2, 4, 6), LearnersNext: ids(4)},
+		{Voters: ids(1, 2, 3), Witnesses: ids(3)},
+		{Voters: ids(1, 2, 3), VotersOutgoing: ids(1, 2, 4), Witnesses: ids(3, 4)},
 	} {
 		if !f(cs) {
 			t.FailNow() // f() already logged a nice t.Error()
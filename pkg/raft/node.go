@@ -118,7 +118,8 @@ type Ready struct {
 }
 
 func isHardStateEqual(a, b pb.HardState) bool {
-	return a.Term == b.Term && a.Vote == b.Vote && a.Commit == b.Commit
+	return a.Term == b.Term && a.Vote == b.Vote && a.Commit == b.Commit &&
+		a.FortifiedLead == b.FortifiedLead && a.LeadEpoch == b.LeadEpoch
 }
 
 // IsEmptyHardState returns true if the given HardState is empty.
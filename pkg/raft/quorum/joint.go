@@ -14,6 +14,8 @@
 
 package quorum
 
+import "github.com/cockroachdb/cockroach/pkg/util/hlc"
+
 // JointConfig is a configuration of two groups of (possibly overlapping)
 // majority configurations. Decisions require the support of both majorities.
 type JointConfig [2]MajorityConfig
@@ -55,6 +57,14 @@ func (c JointConfig) CommittedIndex(l AckedIndexer) Index {
 	return idx1
 }
 
+// LeadSupportExpiration returns the time until which the leader is supported
+// by both constituent majorities of the joint quorum.
+func (c JointConfig) LeadSupportExpiration(supported func(id uint64) hlc.Timestamp) hlc.Timestamp {
+	exp := c[0].LeadSupportExpiration(supported)
+	exp.Backward(c[1].LeadSupportExpiration(supported))
+	return exp
+}
+
 // VoteResult takes a mapping of voters to yes/no (true/false) votes and returns
 // a result indicating whether the vote is pending, lost, or won. A joint quorum
 // requires both majority quorums to vote in favor.
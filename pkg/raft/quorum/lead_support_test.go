@@ -0,0 +1,58 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package quorum
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeadSupportExpiration(t *testing.T) {
+	ts := func(wall int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wall} }
+	support := map[uint64]hlc.Timestamp{
+		1: ts(10),
+		2: ts(20),
+		3: ts(30),
+		4: ts(40),
+		// 5 does not support the leader.
+	}
+	supported := func(id uint64) hlc.Timestamp { return support[id] }
+	majority := func(ids ...uint64) MajorityConfig {
+		c := MajorityConfig{}
+		for _, id := range ids {
+			c[id] = struct{}{}
+		}
+		return c
+	}
+
+	testCases := []struct {
+		cfg JointConfig
+		exp hlc.Timestamp
+	}{
+		{cfg: JointConfig{majority(1)}, exp: ts(10)},
+		{cfg: JointConfig{majority(1, 2, 3)}, exp: ts(20)},
+		{cfg: JointConfig{majority(1, 2, 3, 4)}, exp: ts(20)},
+		{cfg: JointConfig{majority(3, 4, 5)}, exp: ts(30)},
+		{cfg: JointConfig{majority(1, 5)}, exp: hlc.Timestamp{}},
+		// Joint quorums require the support of both majorities.
+		{cfg: JointConfig{majority(3, 4, 5), majority(1, 2, 3)}, exp: ts(20)},
+		{cfg: JointConfig{majority(1, 2, 3), majority(1, 5)}, exp: hlc.Timestamp{}},
+		// The zero majority config defers to the other half.
+		{cfg: JointConfig{majority(), majority(2, 3, 4)}, exp: ts(30)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.cfg.String(), func(t *testing.T) {
+			require.Equal(t, tc.exp, tc.cfg.LeadSupportExpiration(supported))
+		})
+	}
+}
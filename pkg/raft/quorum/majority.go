@@ -20,6 +20,8 @@ import (
 	"slices"
 	"sort"
 	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 )
 
 // MajorityConfig is a set of IDs that uses majority quorums to make decisions.
@@ -160,6 +162,38 @@ func (c MajorityConfig) CommittedIndex(l AckedIndexer) Index {
 	return Index(srt[pos])
 }
 
+// LeadSupportExpiration computes the time until which a quorum of voters in the
+// config supports the leader, given the expiration of each voter's support. A
+// zero timestamp denotes that the voter is not supporting the leader.
+func (c MajorityConfig) LeadSupportExpiration(supported func(id uint64) hlc.Timestamp) hlc.Timestamp {
+	n := len(c)
+	if n == 0 {
+		// Like CommittedIndex, the zero MajorityConfig is ignored by joint
+		// quorums by letting the other half dictate the outcome.
+		return hlc.MaxTimestamp
+	}
+
+	// Avoid allocating for the common replication factors, see CommittedIndex.
+	var stk [7]hlc.Timestamp
+	var srt []hlc.Timestamp
+	if len(stk) >= n {
+		srt = stk[:0]
+	} else {
+		srt = make([]hlc.Timestamp, 0, n)
+	}
+	for id := range c {
+		srt = append(srt, supported(id))
+	}
+	slices.SortFunc(srt, func(a, b hlc.Timestamp) int {
+		return a.Compare(b)
+	})
+
+	// The largest expiration that is reached by a quorum, analogous to the
+	// position computed by CommittedIndex.
+	pos := n - (n/2 + 1)
+	return srt[pos]
+}
+
 // VoteResult takes a mapping of voters to yes/no (true/false) votes and returns
 // a result indicating whether the vote is pending (i.e. neither a quorum of
 // yes/no has been reached), won (a quorum of yes has been reached), or lost (a
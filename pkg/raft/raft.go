@@ -31,7 +31,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/raft/confchange"
 	"github.com/cockroachdb/cockroach/pkg/raft/quorum"
 	pb "github.com/cockroachdb/cockroach/pkg/raft/raftpb"
+	"github.com/cockroachdb/cockroach/pkg/raft/raftstoreliveness"
 	"github.com/cockroachdb/cockroach/pkg/raft/tracker"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 )
 
 const (
@@ -286,6 +288,14 @@ type Config struct {
 	// This behavior will become unconditional in the future. See:
 	// https://github.com/etcd-io/raft/issues/83
 	StepDownOnRemoval bool
+
+	// StoreLiveness is a reference to the store liveness fabric. When it
+	// reports that support is enabled, leaders fortify their leadership by
+	// asking followers to promise not to campaign or vote for other candidates
+	// while the follower's store supports the leader's store. The leader can
+	// then rely on its leadership until the support of a quorum expires, see
+	// RawNode.LeadSupportUntil. If nil, fortification is disabled.
+	StoreLiveness raftstoreliveness.StoreLiveness
 }
 
 func (c *Config) validate() error {
@@ -335,6 +345,10 @@ func (c *Config) validate() error {
 		return errors.New("CheckQuorum must be enabled when ReadOnlyOption is ReadOnlyLeaseBased")
 	}
 
+	if c.StoreLiveness == nil {
+		c.StoreLiveness = raftstoreliveness.Disabled{}
+	}
+
 	return nil
 }
 
@@ -378,6 +392,20 @@ type raft struct {
 
 	// the leader id
 	lead uint64
+	// leadEpoch, if non-zero, is the store liveness epoch under which this
+	// follower supports lead after having been fortified by it. While the
+	// local store continues to support the leader's store at this epoch, the
+	// follower neither campaigns nor votes for other candidates.
+	leadEpoch pb.Epoch
+	// fortifiedEpochs tracks, on the leader, the store liveness epoch under
+	// which each follower has acknowledged the leader's fortification. It is
+	// non-nil iff the leader has started fortifying its leadership.
+	fortifiedEpochs map[uint64]pb.Epoch
+	// deFortifyTerm is the term of the fortified leadership that this peer
+	// most recently stepped down from. While set, the peer periodically asks
+	// its former followers to stop supporting it, so that they can elect a
+	// new leader without waiting for store liveness support to be withdrawn.
+	deFortifyTerm uint64
 	// leadTransferee is id of the leader transfer target when its value is not zero.
 	// Follow the procedure defined in raft thesis 3.10.
 	leadTransferee uint64
@@ -420,6 +448,8 @@ type raft struct {
 	disableProposalForwarding bool
 	stepDownOnRemoval         bool
 
+	storeLiveness raftstoreliveness.StoreLiveness
+
 	tick func()
 	step stepFunc
 
@@ -459,6 +489,7 @@ func newRaft(c *Config) *raft {
 		disableProposalForwarding:   c.DisableProposalForwarding,
 		disableConfChangeValidation: c.DisableConfChangeValidation,
 		stepDownOnRemoval:           c.StepDownOnRemoval,
+		storeLiveness:               c.StoreLiveness,
 	}
 
 	lastID := r.raftLog.lastEntryID()
@@ -477,7 +508,9 @@ func newRaft(c *Config) *raft {
 	if c.Applied > 0 {
 		raftlog.appliedTo(c.Applied, 0 /* size */)
 	}
-	r.becomeFollower(r.Term, None)
+	// NB: a follower that had been fortified before restarting continues to
+	// support its leader, which loadState restored from the HardState.
+	r.becomeFollower(r.Term, r.lead)
 
 	var nodesStrs []string
 	for _, n := range r.trk.VoterNodes() {
@@ -495,11 +528,16 @@ func (r *raft) hasLeader() bool { return r.lead != None }
 func (r *raft) softState() SoftState { return SoftState{Lead: r.lead, RaftState: r.state} }
 
 func (r *raft) hardState() pb.HardState {
-	return pb.HardState{
+	hs := pb.HardState{
 		Term:   r.Term,
 		Vote:   r.Vote,
 		Commit: r.raftLog.committed,
 	}
+	if r.leadEpoch != 0 {
+		hs.FortifiedLead = r.lead
+		hs.LeadEpoch = r.leadEpoch
+	}
+	return hs
 }
 
 // send schedules persisting state to a stable storage and AFTER that
@@ -536,7 +574,8 @@ func (r *raft) send(m pb.Message) {
 			m.Term = r.Term
 		}
 	}
-	if m.Type == pb.MsgAppResp || m.Type == pb.MsgVoteResp || m.Type == pb.MsgPreVoteResp {
+	if m.Type == pb.MsgAppResp || m.Type == pb.MsgVoteResp || m.Type == pb.MsgPreVoteResp ||
+		m.Type == pb.MsgFortifyLeaderResp {
 		// If async storage writes are enabled, messages added to the msgs slice
 		// are allowed to be sent out before unstable state (e.g. log entry
 		// writes and election votes) have been durably synced to the local
@@ -582,6 +621,10 @@ func (r *raft) send(m pb.Message) {
 		// because the safety of such behavior has not been formally verified,
 		// we err on the side of safety and omit a `&& !m.Reject` condition
 		// above.
+		//
+		// MsgFortifyLeaderResp messages are subject to the same requirement:
+		// the follower's promise to support the leader (HardState.LeadEpoch)
+		// must survive a restart before the leader is allowed to rely on it.
 		r.msgsAfterAppend = append(r.msgsAfterAppend, m)
 	} else {
 		if m.To == r.id {
@@ -655,6 +698,12 @@ func (r *raft) maybeSendAppend(to uint64, sendIfEmpty bool) bool {
 // maybeSendSnapshot fetches a snapshot from Storage, and sends it to the given
 // node. Returns true iff the snapshot message has been emitted successfully.
 func (r *raft) maybeSendSnapshot(to uint64, pr *tracker.Progress) bool {
+	if r.isWitness() {
+		// A witness doesn't have the state machine's data, so its snapshots
+		// can't be used to catch up other peers.
+		r.logger.Debugf("%x is witness. Ignored sending snapshot to %x", r.id, to)
+		return false
+	}
 	if !pr.RecentActive {
 		r.logger.Debugf("ignore sending snapshot to %x since it is not recently active", to)
 		return false
@@ -730,6 +779,96 @@ func (r *raft) bcastHeartbeatWithCtx(ctx []byte) {
 	})
 }
 
+// fortificationEnabled returns whether the leader should fortify its
+// leadership using store liveness.
+func (r *raft) fortificationEnabled() bool {
+	return r.storeLiveness.SupportFromEnabled()
+}
+
+// sendFortify sends a MsgFortifyLeader message to the given peer.
+func (r *raft) sendFortify(to uint64) {
+	r.send(pb.Message{To: to, Type: pb.MsgFortifyLeader})
+}
+
+// maybeBcastFortify sends a MsgFortifyLeader message to all voters that have
+// not acknowledged the leader's fortification at the epoch under which the
+// leader's store currently receives support from their store.
+func (r *raft) maybeBcastFortify() {
+	if !r.fortificationEnabled() {
+		return
+	}
+	if r.fortifiedEpochs == nil {
+		r.fortifiedEpochs = map[uint64]pb.Epoch{}
+	}
+	r.trk.Visit(func(id uint64, pr *tracker.Progress) {
+		if id == r.id || pr.IsLearner {
+			return
+		}
+		epoch, _ := r.storeLiveness.SupportFrom(id)
+		if fortified, ok := r.fortifiedEpochs[id]; ok && fortified == epoch {
+			return
+		}
+		r.sendFortify(id)
+	})
+}
+
+// maybeBcastDeFortify asks the voters of the group to stop supporting this
+// peer's former fortified leadership, until a new leader has been elected.
+func (r *raft) maybeBcastDeFortify() {
+	if r.deFortifyTerm == 0 {
+		return
+	}
+	if r.Term > r.deFortifyTerm && r.lead != None {
+		// A leader was elected in a later term, which required a quorum of
+		// voters to stop supporting the former leadership.
+		r.deFortifyTerm = 0
+		return
+	}
+	r.trk.Visit(func(id uint64, pr *tracker.Progress) {
+		if id == r.id || pr.IsLearner {
+			return
+		}
+		r.send(pb.Message{To: id, Type: pb.MsgDeFortifyLeader})
+	})
+}
+
+// supportingFortifiedLeader returns whether this peer is a follower that is
+// supporting a fortified leader. While it is, it must not campaign or vote for
+// another candidate, because the leader may rely on that support to serve a
+// lease.
+func (r *raft) supportingFortifiedLeader() bool {
+	if r.leadEpoch == 0 {
+		return false
+	}
+	epoch, live := r.storeLiveness.SupportFor(r.lead)
+	return live && epoch == r.leadEpoch
+}
+
+// leadSupportUntil returns the time until which a quorum of voters supports
+// the leader's fortified leadership, or an empty timestamp if this peer is
+// not a fortified leader. The leader trivially supports itself.
+func (r *raft) leadSupportUntil() hlc.Timestamp {
+	if r.state != StateLeader || r.fortifiedEpochs == nil || !r.fortificationEnabled() {
+		return hlc.Timestamp{}
+	}
+	return r.trk.Voters.LeadSupportExpiration(func(id uint64) hlc.Timestamp {
+		if id == r.id {
+			return hlc.MaxTimestamp
+		}
+		fortified, ok := r.fortifiedEpochs[id]
+		if !ok {
+			return hlc.Timestamp{}
+		}
+		epoch, exp := r.storeLiveness.SupportFrom(id)
+		if epoch != fortified {
+			// The follower's store has withdrawn the support that the
+			// fortification relied on.
+			return hlc.Timestamp{}
+		}
+		return exp
+	})
+}
+
 func (r *raft) appliedTo(index uint64, size entryEncodingSize) {
 	oldApplied := r.raftLog.applied
 	newApplied := max(index, oldApplied)
@@ -776,8 +915,10 @@ func (r *raft) reset(term uint64) {
 	if r.Term != term {
 		r.Term = term
 		r.Vote = None
+		r.leadEpoch = 0
 	}
 	r.lead = None
+	r.fortifiedEpochs = nil
 
 	r.electionElapsed = 0
 	r.heartbeatElapsed = 0
@@ -792,6 +933,7 @@ func (r *raft) reset(term uint64) {
 			Next:      r.raftLog.lastIndex() + 1,
 			Inflights: tracker.NewInflights(r.trk.MaxInflight, r.trk.MaxInflightBytes),
 			IsLearner: pr.IsLearner,
+			IsWitness: pr.IsWitness,
 		}
 		if id == r.id {
 			pr.Match = r.raftLog.lastIndex()
@@ -838,6 +980,21 @@ func (r *raft) appendEntry(es ...pb.Entry) (accepted bool) {
 func (r *raft) tickElection() {
 	r.electionElapsed++
 
+	if r.deFortifyTerm != 0 {
+		r.heartbeatElapsed++
+		if r.heartbeatElapsed >= r.heartbeatTimeout {
+			r.heartbeatElapsed = 0
+			r.maybeBcastDeFortify()
+		}
+	}
+
+	// Witnesses don't have the state machine's data, so they only campaign as
+	// a last resort, when no other voter has become leader within an additional
+	// election timeout. See maybeTransferLeadershipFromWitness.
+	if r.isWitness() && r.electionElapsed < r.randomizedElectionTimeout+r.electionTimeout {
+		return
+	}
+
 	if r.promotable() && r.pastElectionTimeout() {
 		r.electionElapsed = 0
 		if err := r.Step(pb.Message{From: r.id, Type: pb.MsgHup}); err != nil {
@@ -877,6 +1034,11 @@ func (r *raft) tickHeartbeat() {
 }
 
 func (r *raft) becomeFollower(term uint64, lead uint64) {
+	if r.state == StateLeader && r.fortifiedEpochs != nil {
+		// Followers may continue to support the leadership being stepped down
+		// from. Make sure they learn that they can elect a new leader.
+		r.deFortifyTerm = r.Term
+	}
 	r.step = stepFollower
 	r.reset(term)
 	r.tick = r.tickElection
@@ -910,6 +1072,7 @@ func (r *raft) becomePreCandidate() {
 	r.trk.ResetVotes()
 	r.tick = r.tickElection
 	r.lead = None
+	r.leadEpoch = 0
 	r.state = StatePreCandidate
 	r.logger.Infof("%x became pre-candidate at term %d", r.id, r.Term)
 }
@@ -951,6 +1114,8 @@ func (r *raft) becomeLeader() {
 	// quota of the new leader. In other words, after the call to appendEntry,
 	// r.uncommittedSize is still 0.
 	r.logger.Infof("%x became leader at term %d", r.id, r.Term)
+
+	r.maybeBcastFortify()
 }
 
 func (r *raft) hup(t CampaignType) {
@@ -967,6 +1132,12 @@ func (r *raft) hup(t CampaignType) {
 		r.logger.Warningf("%x cannot campaign at term %d since there are still pending configuration changes to apply", r.id, r.Term)
 		return
 	}
+	if t != campaignTransfer && r.supportingFortifiedLeader() {
+		// Leadership transfers are initiated by the fortified leader itself,
+		// which steps down before asking the transferee to campaign.
+		r.logger.Debugf("%x ignoring MsgHup since it supports fortified leader %x at term %d", r.id, r.lead, r.Term)
+		return
+	}
 
 	r.logger.Infof("%x is starting a new election at term %d", r.id, r.Term)
 	r.campaign(t)
@@ -1085,6 +1256,13 @@ func (r *raft) Step(m pb.Message) error {
 					r.id, last.term, last.index, r.Vote, m.Type, m.From, m.LogTerm, m.Index, r.Term, r.electionTimeout-r.electionElapsed)
 				return nil
 			}
+			if !force && r.supportingFortifiedLeader() {
+				// A fortified follower promised the leader not to vote for other
+				// candidates while its store supports the leader's store.
+				r.logger.Infof("%x [vote: %x] ignored %s from %x at term %d: supporting fortified leader %x at epoch %d",
+					r.id, r.Vote, m.Type, m.From, r.Term, r.lead, r.leadEpoch)
+				return nil
+			}
 		}
 		switch {
 		case m.Type == pb.MsgPreVote:
@@ -1098,7 +1276,8 @@ func (r *raft) Step(m pb.Message) error {
 		default:
 			r.logger.Infof("%x [term: %d] received a %s message with higher term from %x [term: %d]",
 				r.id, r.Term, m.Type, m.From, m.Term)
-			if m.Type == pb.MsgApp || m.Type == pb.MsgHeartbeat || m.Type == pb.MsgSnap {
+			if m.Type == pb.MsgApp || m.Type == pb.MsgHeartbeat || m.Type == pb.MsgSnap ||
+				m.Type == pb.MsgFortifyLeader {
 				r.becomeFollower(m.Term, m.From)
 			} else {
 				r.becomeFollower(m.Term, None)
@@ -1252,6 +1431,7 @@ func stepLeader(r *raft, m pb.Message) error {
 	switch m.Type {
 	case pb.MsgBeat:
 		r.bcastHeartbeat()
+		r.maybeBcastFortify()
 		return nil
 	case pb.MsgCheckQuorum:
 		if !r.trk.QuorumActive() {
@@ -1547,6 +1727,8 @@ func stepLeader(r *raft, m pb.Message) error {
 				if m.From == r.leadTransferee && pr.Match == r.raftLog.lastIndex() {
 					r.logger.Infof("%x sent MsgTimeoutNow to %x after received MsgAppResp", r.id, m.From)
 					r.sendTimeoutNow(m.From)
+				} else {
+					r.maybeTransferLeadershipFromWitness(m.From, pr)
 				}
 			}
 		}
@@ -1570,6 +1752,7 @@ func stepLeader(r *raft, m pb.Message) error {
 		if pr.Match < r.raftLog.lastIndex() || pr.State == tracker.StateProbe {
 			r.sendAppend(m.From)
 		}
+		r.maybeTransferLeadershipFromWitness(m.From, pr)
 
 		if r.readOnly.option != ReadOnlySafe || len(m.Context) == 0 {
 			return nil
@@ -1585,6 +1768,17 @@ func stepLeader(r *raft, m pb.Message) error {
 				r.send(resp)
 			}
 		}
+	case pb.MsgFortifyLeaderResp:
+		pr.RecentActive = true
+		if m.Reject {
+			// The follower's store does not currently support the leader's
+			// store. Fortification is retried on the next heartbeat.
+			r.logger.Debugf("%x fortification rejected by %x at term %d", r.id, m.From, r.Term)
+			return nil
+		}
+		if r.fortifiedEpochs != nil {
+			r.fortifiedEpochs[m.From] = m.LeadEpoch
+		}
 	case pb.MsgSnapStatus:
 		if pr.State != tracker.StateSnapshot {
 			return nil
@@ -1615,6 +1809,10 @@ func stepLeader(r *raft, m pb.Message) error {
 			r.logger.Debugf("%x is learner. Ignored transferring leadership", r.id)
 			return nil
 		}
+		if pr.IsWitness {
+			r.logger.Debugf("%x is witness. Ignored transferring leadership", m.From)
+			return nil
+		}
 		leadTransferee := m.From
 		lastLeadTransferee := r.leadTransferee
 		if lastLeadTransferee != None {
@@ -1670,6 +1868,9 @@ func stepCandidate(r *raft, m pb.Message) error {
 	case pb.MsgSnap:
 		r.becomeFollower(m.Term, m.From) // always m.Term == r.Term
 		r.handleSnapshot(m)
+	case pb.MsgFortifyLeader:
+		r.becomeFollower(m.Term, m.From) // always m.Term == r.Term
+		r.handleFortify(m)
 	case myVoteRespType:
 		gr, rj, res := r.poll(m.From, m.Type, !m.Reject)
 		r.logger.Infof("%x has received %d %s votes and %d vote rejections", r.id, gr, m.Type, rj)
@@ -1716,6 +1917,15 @@ func stepFollower(r *raft, m pb.Message) error {
 		r.electionElapsed = 0
 		r.lead = m.From
 		r.handleSnapshot(m)
+	case pb.MsgFortifyLeader:
+		r.electionElapsed = 0
+		r.lead = m.From
+		r.handleFortify(m)
+	case pb.MsgDeFortifyLeader:
+		if m.From == r.lead && r.leadEpoch != 0 {
+			r.logger.Infof("%x no longer supports leader %x at term %d", r.id, r.lead, r.Term)
+			r.leadEpoch = 0
+		}
 	case pb.MsgTransferLeader:
 		if r.lead == None {
 			r.logger.Infof("%x no leader at term %d; dropping leader transfer msg", r.id, r.Term)
@@ -1728,9 +1938,14 @@ func stepFollower(r *raft, m pb.Message) error {
 			r.logger.Error("ignoring MsgForgetLeader due to ReadOnlyLeaseBased")
 			return nil
 		}
+		if r.supportingFortifiedLeader() {
+			r.logger.Infof("%x not forgetting fortified leader %x at term %d", r.id, r.lead, r.Term)
+			return nil
+		}
 		if r.lead != None {
 			r.logger.Infof("%x forgetting leader %x at term %d", r.id, r.lead, r.Term)
 			r.lead = None
+			r.leadEpoch = 0
 		}
 	case pb.MsgTimeoutNow:
 		r.logger.Infof("%x [term %d] received MsgTimeoutNow from %x and starts an election to get leadership.", r.id, r.Term, m.From)
@@ -1814,6 +2029,21 @@ func (r *raft) handleHeartbeat(m pb.Message) {
 	r.send(pb.Message{To: m.From, Type: pb.MsgHeartbeatResp, Context: m.Context})
 }
 
+// handleFortify handles a MsgFortifyLeader from the leader. The follower
+// promises to support the leader for as long as its store supports the
+// leader's store at the current store liveness epoch.
+func (r *raft) handleFortify(m pb.Message) {
+	epoch, live := r.storeLiveness.SupportFor(m.From)
+	if !live {
+		// The leader's store is not supported by the local store, so the
+		// follower can't promise anything. The leader will retry.
+		r.send(pb.Message{To: m.From, Type: pb.MsgFortifyLeaderResp, Reject: true})
+		return
+	}
+	r.leadEpoch = epoch
+	r.send(pb.Message{To: m.From, Type: pb.MsgFortifyLeaderResp, LeadEpoch: epoch})
+}
+
 func (r *raft) handleSnapshot(m pb.Message) {
 	// MsgSnap messages should always carry a non-nil Snapshot, but err on the
 	// side of safety and treat a nil Snapshot as a zero-valued Snapshot.
@@ -1920,12 +2150,42 @@ func (r *raft) restore(s pb.Snapshot) bool {
 }
 
 // promotable indicates whether state machine can be promoted to leader,
-// which is true when its own id is in progress list.
+// which is true when its own id is in progress list and it is not a learner.
+// Witnesses are promotable, but only become leader temporarily, see
+// maybeTransferLeadershipFromWitness.
 func (r *raft) promotable() bool {
 	pr := r.trk.Progress[r.id]
 	return pr != nil && !pr.IsLearner && !r.raftLog.hasNextOrInProgressSnapshot()
 }
 
+// isWitness returns true if this peer is a witness.
+func (r *raft) isWitness() bool {
+	pr := r.trk.Progress[r.id]
+	return pr != nil && pr.IsWitness
+}
+
+// maybeTransferLeadershipFromWitness transfers leadership to the given peer if
+// this peer is a witness leader and the given peer is a voter, other than a
+// witness, whose log is up to date.
+//
+// A witness only becomes leader when no other voter could, for example
+// because the witness holds committed entries which the other voters are
+// missing. Since it can't serve requests, it only remains leader until it has
+// caught up one of the other voters.
+func (r *raft) maybeTransferLeadershipFromWitness(id uint64, pr *tracker.Progress) {
+	if !r.isWitness() || r.leadTransferee != None || id == r.id || pr.IsLearner || pr.IsWitness ||
+		pr.Match != r.raftLog.lastIndex() {
+		return
+	}
+	r.logger.Infof("%x [term %d] is witness and transfers leadership to %x which has up-to-date log",
+		r.id, r.Term, id)
+	// Transfer leadership should be finished in one electionTimeout, so reset
+	// r.electionElapsed. If it isn't, it is retried with the next response.
+	r.electionElapsed = 0
+	r.leadTransferee = id
+	r.sendTimeoutNow(id)
+}
+
 func (r *raft) applyConfChange(cc pb.ConfChangeV2) pb.ConfState {
 	cfg, trk, err := func() (tracker.Config, tracker.ProgressMap, error) {
 		changer := confchange.Changer{
@@ -2017,6 +2277,8 @@ func (r *raft) loadState(state pb.HardState) {
 	r.raftLog.committed = state.Commit
 	r.Term = state.Term
 	r.Vote = state.Vote
+	r.lead = state.FortifiedLead
+	r.leadEpoch = state.LeadEpoch
 }
 
 // pastElectionTimeout returns true if r.electionElapsed is greater
@@ -2032,6 +2294,13 @@ func (r *raft) resetRandomizedElectionTimeout() {
 
 func (r *raft) sendTimeoutNow(to uint64) {
 	r.send(pb.Message{To: to, Type: pb.MsgTimeoutNow})
+	if r.fortifiedEpochs != nil {
+		// A fortified leader must stop relying on its leadership once it has
+		// asked another peer to campaign, since that peer may be elected
+		// before the leader learns of it.
+		r.logger.Infof("%x stepped down to follower after sending MsgTimeoutNow to %x", r.id, to)
+		r.becomeFollower(r.Term, None)
+	}
 }
 
 func (r *raft) abortLeaderTransfer() {
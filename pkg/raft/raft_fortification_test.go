@@ -0,0 +1,221 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package raft
+
+import (
+	"testing"
+
+	pb "github.com/cockroachdb/cockroach/pkg/raft/raftpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/stretchr/testify/require"
+)
+
+// testLivenessFabric is a store liveness fabric shared by all peers of a test
+// network, where each peer is assumed to live on its own store.
+type testLivenessFabric struct {
+	// epochs is the current epoch of each store.
+	epochs map[uint64]pb.Epoch
+	// support tracks the epoch at which a supporter (first) supports a
+	// target (second). Missing entries denote no support.
+	support    map[[2]uint64]pb.Epoch
+	expiration hlc.Timestamp
+}
+
+func newTestLivenessFabric(ids ...uint64) *testLivenessFabric {
+	f := &testLivenessFabric{
+		epochs:     map[uint64]pb.Epoch{},
+		support:    map[[2]uint64]pb.Epoch{},
+		expiration: hlc.Timestamp{WallTime: 100},
+	}
+	for _, id := range ids {
+		f.epochs[id] = 1
+	}
+	for _, s := range ids {
+		for _, t := range ids {
+			f.support[[2]uint64{s, t}] = 1
+		}
+	}
+	return f
+}
+
+// withdraw makes the supporter withdraw its support for the target.
+func (f *testLivenessFabric) withdraw(supporter, target uint64) {
+	delete(f.support, [2]uint64{supporter, target})
+}
+
+func (f *testLivenessFabric) storeLiveness(id uint64) *testStoreLiveness {
+	return &testStoreLiveness{id: id, fabric: f}
+}
+
+type testStoreLiveness struct {
+	id     uint64
+	fabric *testLivenessFabric
+}
+
+func (l *testStoreLiveness) SupportFor(id uint64) (pb.Epoch, bool) {
+	epoch, ok := l.fabric.support[[2]uint64{l.id, id}]
+	return epoch, ok
+}
+
+func (l *testStoreLiveness) SupportFrom(id uint64) (pb.Epoch, hlc.Timestamp) {
+	epoch, ok := l.fabric.support[[2]uint64{id, l.id}]
+	if !ok {
+		return l.fabric.epochs[l.id], hlc.Timestamp{}
+	}
+	return epoch, l.fabric.expiration
+}
+
+func (l *testStoreLiveness) SupportFromEnabled() bool { return true }
+
+func newFortifiedNetwork(fabric *testLivenessFabric, size int) *network {
+	return newNetworkWithConfig(func(c *Config) {
+		c.StoreLiveness = fabric.storeLiveness(c.ID)
+	}, make([]stateMachine, size)...)
+}
+
+// TestFortifyLeader verifies that a newly elected leader fortifies its
+// leadership and that the followers persist their support.
+func TestFortifyLeader(t *testing.T) {
+	fabric := newTestLivenessFabric(1, 2, 3)
+	nt := newFortifiedNetwork(fabric, 3)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+
+	lead := nt.peers[1].(*raft)
+	require.Equal(t, StateLeader, lead.state)
+	require.Equal(t, map[uint64]pb.Epoch{2: 1, 3: 1}, lead.fortifiedEpochs)
+	require.Equal(t, fabric.expiration, lead.leadSupportUntil())
+
+	for _, id := range []uint64{2, 3} {
+		r := nt.peers[id].(*raft)
+		require.Equal(t, pb.Epoch(1), r.leadEpoch)
+		require.True(t, r.supportingFortifiedLeader())
+		hs := r.hardState()
+		require.Equal(t, uint64(1), hs.FortifiedLead)
+		require.Equal(t, pb.Epoch(1), hs.LeadEpoch)
+		require.Equal(t, hlc.Timestamp{}, r.leadSupportUntil())
+	}
+
+	// The leader continues to be supported by a quorum if a single follower
+	// withdraws its support.
+	fabric.withdraw(3, 1)
+	require.Equal(t, fabric.expiration, lead.leadSupportUntil())
+	fabric.withdraw(2, 1)
+	require.Equal(t, hlc.Timestamp{}, lead.leadSupportUntil())
+}
+
+// TestFortifiedFollowerDoesNotCampaignOrVote verifies that followers which
+// support a fortified leader neither campaign nor vote for other candidates,
+// and that they do both once support for the leader is withdrawn.
+func TestFortifiedFollowerDoesNotCampaignOrVote(t *testing.T) {
+	fabric := newTestLivenessFabric(1, 2, 3)
+	nt := newFortifiedNetwork(fabric, 3)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+
+	lead := nt.peers[1].(*raft)
+	b := nt.peers[2].(*raft)
+	c := nt.peers[3].(*raft)
+	nt.isolate(1)
+
+	// Ticking past the election timeout doesn't trigger a campaign.
+	for i := 0; i < 2*c.electionTimeout; i++ {
+		c.tick()
+	}
+	require.Equal(t, StateFollower, c.state)
+	require.Equal(t, lead.Term, c.Term)
+
+	// Neither does an explicit MsgHup.
+	nt.send(pb.Message{From: 3, To: 3, Type: pb.MsgHup})
+	require.Equal(t, StateFollower, c.state)
+
+	// Once c stops supporting the leader it campaigns, but b continues to
+	// support the leader and refuses to vote.
+	fabric.withdraw(3, 1)
+	nt.send(pb.Message{From: 3, To: 3, Type: pb.MsgHup})
+	require.Equal(t, StateCandidate, c.state)
+	require.Equal(t, StateFollower, b.state)
+	require.Equal(t, lead.Term, b.Term)
+
+	// Once b stops supporting the leader as well, c can be elected. The former
+	// leader is no longer supported by a quorum at this point.
+	fabric.withdraw(2, 1)
+	require.Equal(t, hlc.Timestamp{}, lead.leadSupportUntil())
+	nt.send(pb.Message{From: 3, To: 3, Type: pb.MsgHup})
+	require.Equal(t, StateLeader, c.state)
+	require.Equal(t, uint64(3), b.lead)
+}
+
+// TestFortifiedLeaderStepsDownOnTransfer verifies that a fortified leader steps
+// down as soon as it asks the transferee to campaign, and that leadership can
+// be transferred even though the other followers support the former leader.
+func TestFortifiedLeaderStepsDownOnTransfer(t *testing.T) {
+	fabric := newTestLivenessFabric(1, 2, 3)
+	nt := newFortifiedNetwork(fabric, 3)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+
+	lead := nt.peers[1].(*raft)
+	nt.ignore(pb.MsgTimeoutNow)
+	nt.send(pb.Message{From: 2, To: 1, Type: pb.MsgTransferLeader})
+	require.Equal(t, StateFollower, lead.state)
+	require.Equal(t, hlc.Timestamp{}, lead.leadSupportUntil())
+	require.Equal(t, lead.Term, lead.deFortifyTerm)
+
+	nt.recover()
+	nt.send(pb.Message{From: 1, To: 2, Type: pb.MsgTimeoutNow})
+	b := nt.peers[2].(*raft)
+	require.Equal(t, StateLeader, b.state)
+	require.Equal(t, pb.Epoch(1), nt.peers[3].(*raft).leadEpoch)
+	require.Equal(t, uint64(2), nt.peers[3].(*raft).lead)
+}
+
+// TestDeFortifyLeader verifies that a fortified leader which steps down asks
+// its followers to stop supporting it, allowing them to elect a new leader.
+func TestDeFortifyLeader(t *testing.T) {
+	fabric := newTestLivenessFabric(1, 2, 3)
+	nt := newFortifiedNetwork(fabric, 3)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+
+	lead := nt.peers[1].(*raft)
+	c := nt.peers[3].(*raft)
+	lead.becomeFollower(lead.Term, None)
+	require.True(t, c.supportingFortifiedLeader())
+
+	lead.tick()
+	nt.send(lead.readMessages()...)
+	require.False(t, c.supportingFortifiedLeader())
+	nt.send(pb.Message{From: 3, To: 3, Type: pb.MsgHup})
+	require.Equal(t, StateLeader, c.state)
+
+	// Once a new leader has been elected, the former leader stops asking.
+	lead.tick()
+	require.Zero(t, lead.deFortifyTerm)
+}
+
+// TestFortificationRestart verifies that a follower continues to support a
+// fortified leader after restarting.
+func TestFortificationRestart(t *testing.T) {
+	fabric := newTestLivenessFabric(1, 2, 3)
+	nt := newFortifiedNetwork(fabric, 3)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+
+	b := nt.peers[2].(*raft)
+	s := nt.storage[2]
+	hs := b.hardState()
+	// The test network doesn't persist log entries to storage.
+	hs.Commit = 0
+	require.NoError(t, s.SetHardState(hs))
+
+	cfg := newTestConfig(2, 10, 1, s)
+	cfg.StoreLiveness = fabric.storeLiveness(2)
+	restarted := newRaft(cfg)
+	require.Equal(t, uint64(1), restarted.lead)
+	require.Equal(t, pb.Epoch(1), restarted.leadEpoch)
+	require.True(t, restarted.supportingFortifiedLeader())
+}
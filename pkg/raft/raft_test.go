@@ -349,6 +349,82 @@ func TestLearnerElectionTimeout(t *testing.T) {
 	assert.Equal(t, StateFollower, n2.state)
 }
 
+// TestWitnessElectionTimeout verifies that a witness votes and counts towards
+// the quorum, but doesn't start an election within the election timeout of
+// the other voters, nor accepts leadership.
+func TestWitnessElectionTimeout(t *testing.T) {
+	newWitnessRaft := func(id uint64) *raft {
+		return newTestRaft(id, 10, 1, newTestMemoryStorage(withPeers(1, 2, 3), withWitnesses(3)))
+	}
+	n1, n2, n3 := newWitnessRaft(1), newWitnessRaft(2), newWitnessRaft(3)
+	nt := newNetwork(n1, n2, n3)
+
+	// n3 is a witness. It should not start an election even when it times out.
+	setRandomizedElectionTimeout(n3, n3.electionTimeout)
+	for i := 0; i < n3.electionTimeout; i++ {
+		n3.tick()
+	}
+	assert.Equal(t, StateFollower, n3.state)
+
+	// With n2 isolated, n1 is elected with the vote of the witness.
+	nt.isolate(2)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+	assert.Equal(t, StateLeader, n1.state)
+	assert.Equal(t, uint64(1), n3.lead)
+
+	// Leadership can't be transferred to the witness.
+	nt.send(pb.Message{From: 3, To: 1, Type: pb.MsgTransferLeader})
+	checkLeaderTransferState(t, n1, StateLeader, 1)
+}
+
+// TestWitnessFailover verifies that a witness which holds a committed entry
+// that the only other live voter is missing becomes leader, catches up that
+// voter and transfers leadership to it.
+func TestWitnessFailover(t *testing.T) {
+	newWitnessRaft := func(id uint64) *raft {
+		return newTestRaft(id, 10, 1, newTestMemoryStorage(withPeers(1, 2, 3), withWitnesses(3)))
+	}
+	n1, n2, n3 := newWitnessRaft(1), newWitnessRaft(2), newWitnessRaft(3)
+	nt := newNetwork(n1, n2, n3)
+
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+	require.Equal(t, StateLeader, n1.state)
+
+	// An entry is committed by n1 and the witness while n2 is partitioned away.
+	nt.isolate(2)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: []pb.Entry{{Data: []byte("somedata")}}})
+	index := n1.raftLog.lastIndex()
+	require.Equal(t, index, n1.raftLog.committed)
+	require.Less(t, n2.raftLog.lastIndex(), index)
+
+	// n1 fails and n2 recovers. n2 can't become leader since the witness
+	// rejects its vote.
+	nt.recover()
+	nt.isolate(1)
+	nt.send(pb.Message{From: 2, To: 2, Type: pb.MsgHup})
+	require.Equal(t, StateCandidate, n2.state)
+
+	// The witness only campaigns once an additional election timeout has
+	// elapsed.
+	setRandomizedElectionTimeout(n3, n3.electionTimeout)
+	for i := 0; i < 2*n3.electionTimeout-1; i++ {
+		n3.tick()
+	}
+	require.Equal(t, StateFollower, n3.state)
+	n3.tick()
+	require.Equal(t, StateCandidate, n3.state)
+
+	// The witness is elected, catches n2 up and transfers leadership to it.
+	nt.send(n3.readMessages()...)
+	require.Equal(t, StateLeader, n2.state)
+	require.Equal(t, StateFollower, n3.state)
+	require.Equal(t, uint64(2), n3.lead)
+	require.GreaterOrEqual(t, n2.raftLog.committed, index)
+	ents, err := n2.raftLog.slice(index, index+1, noLimit)
+	require.NoError(t, err)
+	require.Equal(t, []byte("somedata"), ents[0].Data)
+}
+
 // TestLearnerPromotion verifies that the learner should not election until
 // it is promoted to a normal peer.
 func TestLearnerPromotion(t *testing.T) {
@@ -3966,17 +4042,20 @@ func newNetworkWithConfig(configFunc func(*Config), peers ...stateMachine) *netw
 			for i := range v.trk.Learners {
 				learners[i] = true
 			}
+			witnesses := v.trk.Witnesses
 			v.id = id
 			v.trk = tracker.MakeProgressTracker(v.trk.MaxInflight, v.trk.MaxInflightBytes)
 			if len(learners) > 0 {
 				v.trk.Learners = map[uint64]struct{}{}
 			}
+			v.trk.Witnesses = witnesses
 			for i := 0; i < size; i++ {
 				pr := &tracker.Progress{}
 				if _, ok := learners[peerAddrs[i]]; ok {
 					pr.IsLearner = true
 					v.trk.Learners[peerAddrs[i]] = struct{}{}
 				} else {
+					_, pr.IsWitness = witnesses[peerAddrs[i]]
 					v.trk.Voters[0][peerAddrs[i]] = struct{}{}
 				}
 				v.trk.Progress[peerAddrs[i]] = pr
@@ -4126,6 +4205,12 @@ func withLearners(learners ...uint64) testMemoryStorageOptions {
 	}
 }
 
+func withWitnesses(witnesses ...uint64) testMemoryStorageOptions {
+	return func(ms *MemoryStorage) {
+		ms.snapshot.Metadata.ConfState.Witnesses = witnesses
+	}
+}
+
 func newTestMemoryStorage(opts ...testMemoryStorageOptions) *MemoryStorage {
 	ms := NewMemoryStorage()
 	for _, o := range opts {
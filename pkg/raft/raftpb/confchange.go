@@ -116,6 +116,7 @@ func (c ConfChangeV2) LeaveJoint() bool {
 // slice of ConfChangeSingle. The supported operations are:
 // - vn: make n a voter,
 // - ln: make n a learner,
+// - wn: make n a witness,
 // - rn: remove n, and
 // - un: update n.
 func ConfChangesFromString(s string) ([]ConfChangeSingle, error) {
@@ -134,6 +135,8 @@ func ConfChangesFromString(s string) ([]ConfChangeSingle, error) {
 			cc.Type = ConfChangeAddNode
 		case 'l':
 			cc.Type = ConfChangeAddLearnerNode
+		case 'w':
+			cc.Type = ConfChangeAddWitnessNode
 		case 'r':
 			cc.Type = ConfChangeRemoveNode
 		case 'u':
@@ -163,6 +166,8 @@ func ConfChangesToString(ccs []ConfChangeSingle) string {
 			buf.WriteByte('v')
 		case ConfChangeAddLearnerNode:
 			buf.WriteByte('l')
+		case ConfChangeAddWitnessNode:
+			buf.WriteByte('w')
 		case ConfChangeRemoveNode:
 			buf.WriteByte('r')
 		case ConfChangeUpdateNode:
@@ -0,0 +1,58 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package raftstoreliveness
+
+import (
+	pb "github.com/cockroachdb/cockroach/pkg/raft/raftpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+)
+
+// StoreLiveness is a representation of the Store Liveness fabric. It provides
+// information about uninterrupted periods of "support" between stores, which
+// raft leaders use to fortify their leadership.
+//
+// Support is provided by a store for another store at a given epoch. Once
+// support for an epoch has been withdrawn, it is never provided again for
+// that epoch. A raft follower that supports the leader's store at the epoch
+// recorded in its fortification will neither campaign nor vote for another
+// candidate. This allows the leader to serve as the leaseholder until the
+// minimum expiration of the support it receives from a quorum of peers.
+type StoreLiveness interface {
+	// SupportFor returns the epoch of the current uninterrupted period of
+	// support that the local store is providing to the store of the given raft
+	// peer, along with whether that support is currently active.
+	SupportFor(id uint64) (pb.Epoch, bool)
+
+	// SupportFrom returns the epoch of the current uninterrupted period of
+	// support that the local store is receiving from the store of the given
+	// raft peer, along with the time at which that support expires. An empty
+	// timestamp is returned if no support is being received.
+	SupportFrom(id uint64) (pb.Epoch, hlc.Timestamp)
+
+	// SupportFromEnabled returns whether leaders should fortify their
+	// leadership using store liveness.
+	SupportFromEnabled() bool
+}
+
+// Disabled is a StoreLiveness implementation that never provides or receives
+// support. It is used when no store liveness fabric is configured.
+type Disabled struct{}
+
+var _ StoreLiveness = Disabled{}
+
+// SupportFor implements the StoreLiveness interface.
+func (Disabled) SupportFor(uint64) (pb.Epoch, bool) { return 0, false }
+
+// SupportFrom implements the StoreLiveness interface.
+func (Disabled) SupportFrom(uint64) (pb.Epoch, hlc.Timestamp) { return 0, hlc.Timestamp{} }
+
+// SupportFromEnabled implements the StoreLiveness interface.
+func (Disabled) SupportFromEnabled() bool { return false }
@@ -22,6 +22,7 @@ import (
 
 	pb "github.com/cockroachdb/cockroach/pkg/raft/raftpb"
 	"github.com/cockroachdb/cockroach/pkg/raft/tracker"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 )
 
 // ErrStepLocalMsg is returned when try to step a local raft message
@@ -199,7 +200,9 @@ func MustSync(st, prevst pb.HardState, entsnum int) bool {
 	// currentTerm
 	// votedFor
 	// log entries[]
-	return entsnum != 0 || st.Vote != prevst.Vote || st.Term != prevst.Term
+	// leadEpoch (the follower's promise to support a fortified leader)
+	return entsnum != 0 || st.Vote != prevst.Vote || st.Term != prevst.Term ||
+		st.LeadEpoch != prevst.LeadEpoch
 }
 
 func needStorageAppendMsg(r *raft, rd Ready) bool {
@@ -243,6 +246,8 @@ func newStorageAppendMsg(r *raft, rd Ready) pb.Message {
 		m.Term = rd.Term
 		m.Vote = rd.Vote
 		m.Commit = rd.Commit
+		m.FortifiedLead = rd.FortifiedLead
+		m.LeadEpoch = rd.LeadEpoch
 	}
 	if !IsEmptySnap(rd.Snapshot) {
 		snap := rd.Snapshot
@@ -501,6 +506,15 @@ func (rn *RawNode) Status() Status {
 	return status
 }
 
+// LeadSupportUntil returns the time until which the leader's fortified
+// leadership is supported by a quorum of voters. An empty timestamp is
+// returned if the local peer is not the leader or did not fortify its
+// leadership. The leader can rely on its leadership (for instance, to serve
+// a lease) until this time, even if it loses contact with its followers.
+func (rn *RawNode) LeadSupportUntil() hlc.Timestamp {
+	return rn.raft.leadSupportUntil()
+}
+
 // BasicStatus returns a BasicStatus. Notably this does not contain the
 // Progress map; see WithProgress for an allocation-free way to inspect it.
 func (rn *RawNode) BasicStatus() BasicStatus {
@@ -22,6 +22,7 @@ import (
 
 	pb "github.com/cockroachdb/cockroach/pkg/raft/raftpb"
 	"github.com/cockroachdb/cockroach/pkg/raft/tracker"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 )
 
 // Status contains information about this Raft peer and its view of the system.
@@ -30,6 +31,9 @@ type Status struct {
 	BasicStatus
 	Config   tracker.Config
 	Progress map[uint64]tracker.Progress
+	// LeadSupportUntil is only populated on a fortified leader, see
+	// RawNode.LeadSupportUntil.
+	LeadSupportUntil hlc.Timestamp
 }
 
 // BasicStatus contains basic information about the Raft peer. It does not allocate.
@@ -73,6 +77,7 @@ func getStatus(r *raft) Status {
 	s.BasicStatus = getBasicStatus(r)
 	if s.RaftState == StateLeader {
 		s.Progress = getProgressCopy(r)
+		s.LeadSupportUntil = r.leadSupportUntil()
 	}
 	s.Config = r.trk.Config.Clone()
 	return s
@@ -114,6 +114,9 @@ type Progress struct {
 
 	// IsLearner is true if this progress is tracked for a learner.
 	IsLearner bool
+	// IsWitness is true if this progress is tracked for a witness, which is a
+	// voter that is only leader temporarily.
+	IsWitness bool
 }
 
 // ResetState moves the Progress into the specified State, resetting MsgAppFlowPaused,
@@ -278,6 +281,9 @@ func (pr *Progress) String() string {
 	if pr.IsLearner {
 		fmt.Fprint(&buf, " learner")
 	}
+	if pr.IsWitness {
+		fmt.Fprint(&buf, " witness")
+	}
 	if pr.IsPaused() {
 		fmt.Fprint(&buf, " paused")
 	}
@@ -79,6 +79,13 @@ type Config struct {
 	// right away when entering the joint configuration, so that it is caught up
 	// as soon as possible.
 	LearnersNext map[uint64]struct{}
+	// Witnesses is the set of IDs of the voters, in either half of the joint
+	// config, that are witnesses. A witness votes and counts towards the
+	// quorum like any other voter, but only campaigns as a last resort, and
+	// hands leadership over to another voter as soon as it has caught it up.
+	//
+	// Invariant: Witnesses is a subset of the voters.
+	Witnesses map[uint64]struct{}
 }
 
 func (c Config) String() string {
@@ -90,6 +97,9 @@ func (c Config) String() string {
 	if c.LearnersNext != nil {
 		fmt.Fprintf(&buf, " learners_next=%s", quorum.MajorityConfig(c.LearnersNext).String())
 	}
+	if c.Witnesses != nil {
+		fmt.Fprintf(&buf, " witnesses=%s", quorum.MajorityConfig(c.Witnesses).String())
+	}
 	if c.AutoLeave {
 		fmt.Fprint(&buf, " autoleave")
 	}
@@ -112,6 +122,7 @@ func (c *Config) Clone() Config {
 		Voters:       quorum.JointConfig{clone(c.Voters[0]), clone(c.Voters[1])},
 		Learners:     clone(c.Learners),
 		LearnersNext: clone(c.LearnersNext),
+		Witnesses:    clone(c.Witnesses),
 	}
 }
 
@@ -141,6 +152,7 @@ func MakeProgressTracker(maxInflight int, maxBytes uint64) ProgressTracker {
 			},
 			Learners:     nil, // only populated when used
 			LearnersNext: nil, // only populated when used
+			Witnesses:    nil, // only populated when used
 		},
 		Votes:    map[uint64]bool{},
 		Progress: map[uint64]*Progress{},
@@ -156,6 +168,7 @@ func (p *ProgressTracker) ConfState() pb.ConfState {
 		Learners:       quorum.MajorityConfig(p.Learners).Slice(),
 		LearnersNext:   quorum.MajorityConfig(p.LearnersNext).Slice(),
 		AutoLeave:      p.AutoLeave,
+		Witnesses:      quorum.MajorityConfig(p.Witnesses).Slice(),
 	}
 }
 
@@ -50,6 +50,7 @@ var isResponseMsg = [...]bool{
 	pb.MsgPreVoteResp:       true,
 	pb.MsgStorageAppendResp: true,
 	pb.MsgStorageApplyResp:  true,
+	pb.MsgFortifyLeaderResp: true,
 }
 
 func isMsgInArray(msgt pb.MessageType, arr []bool) bool {
@@ -88,6 +89,9 @@ func DescribeHardState(hs pb.HardState) string {
 		fmt.Fprintf(&buf, " Vote:%d", hs.Vote)
 	}
 	fmt.Fprintf(&buf, " Commit:%d", hs.Commit)
+	if hs.LeadEpoch != 0 {
+		fmt.Fprintf(&buf, " FortifiedLead:%d LeadEpoch:%d", hs.FortifiedLead, hs.LeadEpoch)
+	}
 	return buf.String()
 }
 
@@ -96,10 +100,16 @@ func DescribeSoftState(ss SoftState) string {
 }
 
 func DescribeConfState(state pb.ConfState) string {
-	return fmt.Sprintf(
+	s := fmt.Sprintf(
 		"Voters:%v VotersOutgoing:%v Learners:%v LearnersNext:%v AutoLeave:%v",
 		state.Voters, state.VotersOutgoing, state.Learners, state.LearnersNext, state.AutoLeave,
 	)
+	// Witnesses are only described when present, which keeps the output
+	// stable for the vast majority of configurations.
+	if len(state.Witnesses) > 0 {
+		s += fmt.Sprintf(" Witnesses:%v", state.Witnesses)
+	}
+	return s
 }
 
 func DescribeSnapshot(snap pb.Snapshot) string {
@@ -167,6 +177,12 @@ func describeMessageWithIndent(indent string, m pb.Message, f EntryFormatter) st
 	if m.Vote != 0 {
 		fmt.Fprintf(&buf, " Vote:%d", m.Vote)
 	}
+	if m.FortifiedLead != 0 {
+		fmt.Fprintf(&buf, " FortifiedLead:%d", m.FortifiedLead)
+	}
+	if m.LeadEpoch != 0 {
+		fmt.Fprintf(&buf, " LeadEpoch:%d", m.LeadEpoch)
+	}
 	if ln := len(m.Entries); ln == 1 {
 		fmt.Fprintf(&buf, " Entries:[%s]", DescribeEntry(m.Entries[0], f))
 	} else if ln > 1 {
@@ -98,6 +98,10 @@ func TestIsLocalMsg(t *testing.T) {
 		{pb.MsgStorageAppendResp, true},
 		{pb.MsgStorageApply, true},
 		{pb.MsgStorageApplyResp, true},
+		{pb.MsgForgetLeader, false},
+		{pb.MsgFortifyLeader, false},
+		{pb.MsgFortifyLeaderResp, false},
+		{pb.MsgDeFortifyLeader, false},
 	}
 
 	for _, tt := range tests {
@@ -135,6 +139,10 @@ func TestIsResponseMsg(t *testing.T) {
 		{pb.MsgStorageAppendResp, true},
 		{pb.MsgStorageApply, false},
 		{pb.MsgStorageApplyResp, true},
+		{pb.MsgForgetLeader, false},
+		{pb.MsgFortifyLeader, false},
+		{pb.MsgFortifyLeaderResp, true},
+		{pb.MsgDeFortifyLeader, false},
 	}
 
 	for i, tt := range tests {
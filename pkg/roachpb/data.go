@@ -1641,6 +1641,12 @@ func confChangeImpl(
 			if err := checkNotExists(rDesc); err != nil {
 				return nil, err
 			}
+		case WITNESS:
+			// Witnesses are never part of a joint config, so they're removed
+			// directly and must be gone from the descriptor.
+			if err := checkNotExists(rDesc); err != nil {
+				return nil, err
+			}
 		default:
 			return nil, errors.Errorf("can't remove replica in state %v", rDesc.Type)
 		}
@@ -1670,6 +1676,9 @@ func confChangeImpl(
 			// transitioning from voter to learner/non-voter) are not represented in
 			// `added`; they're handled in `removed` above.
 			changeType = raftpb.ConfChangeAddLearnerNode
+		case WITNESS:
+			// We're adding a witness, either new or promoted from a learner.
+			changeType = raftpb.ConfChangeAddWitnessNode
 		default:
 			// A voter that is demoting was just removed and re-added in the
 			// `removals` handler. We should not see it again here.
@@ -1797,6 +1806,8 @@ func confChangesToRedactableString(ccs []raftpb.ConfChangeSingle) redact.Redacta
 				w.SafeRune('v')
 			case raftpb.ConfChangeAddLearnerNode:
 				w.SafeRune('l')
+			case raftpb.ConfChangeAddWitnessNode:
+				w.SafeRune('w')
 			case raftpb.ConfChangeRemoveNode:
 				w.SafeRune('r')
 			case raftpb.ConfChangeUpdateNode:
@@ -1840,10 +1851,14 @@ func (l Lease) SafeFormat(w redact.SafePrinter, _ rune) {
 		w.SafeString("<empty>")
 		return
 	}
-	if l.Type() == LeaseExpiration {
+	switch l.Type() {
+	case LeaseExpiration:
 		w.Printf("repl=%s seq=%d start=%s exp=%s", l.Replica, l.Sequence, l.Start, l.Expiration)
-	} else {
+	case LeaseEpoch:
 		w.Printf("repl=%s seq=%d start=%s epo=%d", l.Replica, l.Sequence, l.Start, l.Epoch)
+	case LeaseLeader:
+		w.Printf("repl=%s seq=%d start=%s term=%d min-exp=%s",
+			l.Replica, l.Sequence, l.Start, l.Term, l.MinExpiration)
 	}
 	if l.ProposedTS != nil {
 		w.Printf(" pro=%s", l.ProposedTS)
@@ -1872,10 +1887,17 @@ const (
 	// LeaseEpoch allows range operations while the node liveness epoch
 	// is equal to the lease epoch.
 	LeaseEpoch
+	// LeaseLeader allows range operations while the replica is the raft leader
+	// in the lease term and its leadership is supported by a quorum of stores
+	// through store liveness, or until the lease's minimum expiration.
+	LeaseLeader
 )
 
 // Type returns the lease type.
 func (l Lease) Type() LeaseType {
+	if l.Term != 0 {
+		return LeaseLeader
+	}
 	if l.Epoch == 0 {
 		return LeaseExpiration
 	}
@@ -1970,6 +1992,28 @@ func (l Lease) Equivalent(newL Lease, expToEpochEquiv bool) bool {
 			if l.GetExpiration().LessEq(newL.GetExpiration()) {
 				l.Expiration, newL.Expiration = nil, nil
 			}
+
+		case LeaseLeader:
+			// An expiration-based lease being promoted to a leader lease. As with
+			// the promotion to an epoch-based lease above, the leader lease
+			// carries the expiration of the previous lease as its minimum
+			// expiration, so it can only be later.
+			if expToEpochEquiv {
+				l.Expiration, newL.Expiration = nil, nil
+				l.Term, newL.Term = 0, 0
+				l.MinExpiration, newL.MinExpiration = hlc.Timestamp{}, hlc.Timestamp{}
+			}
+		}
+	case LeaseLeader:
+		// Leader leases are extended by raft leadership rather than by new
+		// leases, so only an extension of the minimum expiration is considered
+		// equivalent.
+		l.Expiration, newL.Expiration = nil, nil
+		if l.Term == newL.Term {
+			l.Term, newL.Term = 0, 0
+		}
+		if l.MinExpiration.LessEq(newL.MinExpiration) {
+			l.MinExpiration, newL.MinExpiration = hlc.Timestamp{}, hlc.Timestamp{}
 		}
 	}
 	return l == newL
@@ -2044,6 +2088,12 @@ func (l *Lease) Equal(that interface{}) bool {
 	if l.Sequence != that1.Sequence {
 		return false
 	}
+	if l.Term != that1.Term {
+		return false
+	}
+	if !l.MinExpiration.Equal(&that1.MinExpiration) {
+		return false
+	}
 	return true
 }
 
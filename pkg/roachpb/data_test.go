@@ -1085,6 +1085,10 @@ func TestLeaseEquivalence(t *testing.T) {
 	expire1TS2 := Lease{Replica: r1, Start: ts2, Expiration: ts2.ToTimestamp().Clone()}
 	expire2 := Lease{Replica: r1, Start: ts1, Expiration: ts3.ToTimestamp().Clone()}
 	expire2R2TS2 := Lease{Replica: r2, Start: ts2, Expiration: ts3.ToTimestamp().Clone()}
+	leader1 := Lease{Replica: r1, Start: ts1, Term: 1}
+	leader1R2 := Lease{Replica: r2, Start: ts1, Term: 1}
+	leader1MinExp := Lease{Replica: r1, Start: ts1, Term: 1, MinExpiration: ts2.ToTimestamp()}
+	leader2 := Lease{Replica: r1, Start: ts1, Term: 2}
 
 	proposed1 := Lease{Replica: r1, Start: ts1, Epoch: 1, ProposedTS: &ts1}
 	proposed2 := Lease{Replica: r1, Start: ts1, Epoch: 2, ProposedTS: &ts1}
@@ -1127,13 +1131,21 @@ func TestLeaseEquivalence(t *testing.T) {
 		{epoch1, epoch1Voter, true},        // same epoch lease, different replica type
 		{epoch1, epoch1Learner, true},      // same epoch lease, different replica type
 		{epoch1Voter, epoch1Learner, true}, // same epoch lease, different replica type
+		{leader1, leader1, true},           // same leader lease
+		{leader1, leader1R2, false},        // different leader leases
+		{leader1, leader2, false},          // different leader leases
+		{leader1, leader1MinExp, true},     // same leader lease, extended min expiration
+		{leader1MinExp, leader1, false},    // same leader lease, min expiration backwards
+		{leader1, epoch1, false},           // leader and epoch leases
+		{leader1, expire1, false},          // leader and expiration leases
+		{expire1, leader1MinExp, true},     // expiration and leader leases, same replica and start time
 	}
 
 	for i, tc := range testCases {
 		// Test expToEpochEquiv = true.
 		require.Equal(t, tc.expSuccess, tc.l.Equivalent(tc.ol, true /* expToEpochEquiv */), "%d", i)
-		if tc.l == expire1 && tc.ol == epoch1 {
-			// The one case where expToEpochEquiv = false makes a difference.
+		if tc.l == expire1 && (tc.ol == epoch1 || tc.ol == leader1MinExp) {
+			// The cases where expToEpochEquiv = false makes a difference.
 			require.Equal(t, !tc.expSuccess, tc.l.Equivalent(tc.ol, false /* expToEpochEquiv */), "%d", i)
 		} else {
 			require.Equal(t, tc.expSuccess, tc.l.Equivalent(tc.ol, false /* expToEpochEquiv */), "%d", i)
@@ -89,6 +89,10 @@ func predNonVoter(rDesc ReplicaDescriptor) bool {
 	return rDesc.Type == NON_VOTER
 }
 
+func predWitness(rDesc ReplicaDescriptor) bool {
+	return rDesc.Type == WITNESS
+}
+
 func predVoterOrNonVoter(rDesc ReplicaDescriptor) bool {
 	return predVoterFullOrIncoming(rDesc) || predNonVoter(rDesc)
 }
@@ -226,6 +230,14 @@ func (d ReplicaSet) NonVoterDescriptors() []ReplicaDescriptor {
 	return d.FilterToDescriptors(predNonVoter)
 }
 
+// WitnessDescriptors returns the witness replica descriptors in the set.
+// Witnesses vote and count towards the quorum, but don't apply committed
+// entries, so they hold no range data. They are not returned by Voters() since
+// they can't hold the lease or serve any requests.
+func (d ReplicaSet) WitnessDescriptors() []ReplicaDescriptor {
+	return d.FilterToDescriptors(predWitness)
+}
+
 // VoterFullAndNonVoterDescriptors returns the descriptors of
 // VOTER_FULL/NON_VOTER replicas in the set. This set will not contain learners
 // or, during an atomic replication change, incoming or outgoing voters.
@@ -322,7 +334,7 @@ func (d ReplicaSet) InAtomicReplicationChange() bool {
 		case VOTER_INCOMING, VOTER_OUTGOING, VOTER_DEMOTING_LEARNER,
 			VOTER_DEMOTING_NON_VOTER:
 			return true
-		case VOTER_FULL, LEARNER, NON_VOTER:
+		case VOTER_FULL, LEARNER, NON_VOTER, WITNESS:
 		default:
 			panic(fmt.Sprintf("unknown replica type %d", rDesc.Type))
 		}
@@ -356,6 +368,13 @@ func (d ReplicaSet) ConfState() raftpb.ConfState {
 			cs.Learners = append(cs.Learners, id)
 		case NON_VOTER:
 			cs.Learners = append(cs.Learners, id)
+		case WITNESS:
+			// Witnesses are voters in both configs.
+			cs.Voters = append(cs.Voters, id)
+			if joint {
+				cs.VotersOutgoing = append(cs.VotersOutgoing, id)
+			}
+			cs.Witnesses = append(cs.Witnesses, id)
 		default:
 			panic(fmt.Sprintf("unknown ReplicaType %d", rep.Type))
 		}
@@ -406,6 +425,18 @@ type RangeStatusReport struct {
 	UnderReplicatedNonVoters, OverReplicatedNonVoters bool
 }
 
+// isQuorumMemberOldConfig returns true if the replica counts towards the
+// quorum of the outgoing config, which includes witnesses.
+func isQuorumMemberOldConfig(rDesc ReplicaDescriptor) bool {
+	return rDesc.IsVoterOldConfig() || rDesc.IsWitness()
+}
+
+// isQuorumMemberNewConfig returns true if the replica counts towards the
+// quorum of the incoming config, which includes witnesses.
+func isQuorumMemberNewConfig(rDesc ReplicaDescriptor) bool {
+	return rDesc.IsVoterNewConfig() || rDesc.IsWitness()
+}
+
 // ReplicationStatus returns availability and over/under-replication
 // determinations for the range.
 //
@@ -430,21 +461,23 @@ func (d ReplicaSet) ReplicationStatus(
 	// This functions handles regular, or joint-consensus replica groups. In the
 	// joint-consensus case, we'll independently consider the health of the
 	// outgoing group ("old") and the incoming group ("new"). In the regular case,
-	// the two groups will be identical.
-
-	votersOldGroup := d.FilterToDescriptors(ReplicaDescriptor.IsVoterOldConfig)
-	liveVotersOldGroup := d.FilterToDescriptors(isBoth(ReplicaDescriptor.IsVoterOldConfig, liveFunc))
+	// the two groups will be identical. Witnesses count towards the quorum of
+	// both groups, but not towards the replication factor of voters.
 
-	n := len(votersOldGroup)
+	n := len(d.FilterToDescriptors(isQuorumMemberOldConfig))
+	liveQuorumOldGroup := d.FilterToDescriptors(isBoth(isQuorumMemberOldConfig, liveFunc))
 	// Empty groups succeed by default, to match the Raft implementation.
-	availableOutgoingGroup := (n == 0) || (len(liveVotersOldGroup) >= n/2+1)
+	availableOutgoingGroup := (n == 0) || (len(liveQuorumOldGroup) >= n/2+1)
 
+	n = len(d.FilterToDescriptors(isQuorumMemberNewConfig))
+	liveQuorumNewGroup := d.FilterToDescriptors(isBoth(isQuorumMemberNewConfig, liveFunc))
+	availableIncomingGroup := len(liveQuorumNewGroup) >= n/2+1
+
+	votersOldGroup := d.FilterToDescriptors(ReplicaDescriptor.IsVoterOldConfig)
+	liveVotersOldGroup := d.FilterToDescriptors(isBoth(ReplicaDescriptor.IsVoterOldConfig, liveFunc))
 	votersNewGroup := d.FilterToDescriptors(ReplicaDescriptor.IsVoterNewConfig)
 	liveVotersNewGroup := d.FilterToDescriptors(isBoth(ReplicaDescriptor.IsVoterNewConfig, liveFunc))
 
-	n = len(votersNewGroup)
-	availableIncomingGroup := len(liveVotersNewGroup) >= n/2+1
-
 	res.Available = availableIncomingGroup && availableOutgoingGroup
 
 	// Determine over/under-replication of voting replicas. Note that learners
@@ -506,9 +539,9 @@ func (d ReplicaSet) Subtract(o ReplicaSet) []ReplicaDescriptor {
 // IsAddition returns true if `c` refers to a replica addition operation.
 func (c ReplicaChangeType) IsAddition() bool {
 	switch c {
-	case ADD_NON_VOTER, ADD_VOTER:
+	case ADD_NON_VOTER, ADD_VOTER, ADD_WITNESS:
 		return true
-	case REMOVE_NON_VOTER, REMOVE_VOTER:
+	case REMOVE_NON_VOTER, REMOVE_VOTER, REMOVE_WITNESS:
 		return false
 	default:
 		panic(fmt.Sprintf("unexpected ReplicaChangeType %s", c))
@@ -518,9 +551,9 @@ func (c ReplicaChangeType) IsAddition() bool {
 // IsRemoval returns true if `c` refers a replica removal operation.
 func (c ReplicaChangeType) IsRemoval() bool {
 	switch c {
-	case ADD_NON_VOTER, ADD_VOTER:
+	case ADD_NON_VOTER, ADD_VOTER, ADD_WITNESS:
 		return false
-	case REMOVE_NON_VOTER, REMOVE_VOTER:
+	case REMOVE_NON_VOTER, REMOVE_VOTER, REMOVE_WITNESS:
 		return true
 	default:
 		panic(fmt.Sprintf("unexpected ReplicaChangeType %s", c))
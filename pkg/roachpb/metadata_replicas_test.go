@@ -182,6 +182,17 @@ func TestReplicaDescriptorsConfState(t *testing.T) {
 			[]ReplicaDescriptor{rd(VOTER_OUTGOING, 1), rd(VOTER_DEMOTING_LEARNER, 2), rd(VOTER_INCOMING, 3), rd(VOTER_INCOMING, 4), rd(LEARNER, 5)},
 			"Voters:[3 4] VotersOutgoing:[1 2] Learners:[5] LearnersNext:[2] AutoLeave:false",
 		},
+		// A witness is a voter that never campaigns.
+		{
+			[]ReplicaDescriptor{rd(VOTER_FULL, 1), rd(VOTER_FULL, 2), rd(WITNESS, 3)},
+			"Voters:[1 2 3] VotersOutgoing:[] Learners:[] LearnersNext:[] AutoLeave:false Witnesses:[3]",
+		},
+		// A witness is part of both the incoming and outgoing configs while a voter
+		// is rebalanced.
+		{
+			[]ReplicaDescriptor{rd(VOTER_FULL, 1), rd(VOTER_OUTGOING, 2), rd(VOTER_INCOMING, 3), rd(WITNESS, 4)},
+			"Voters:[1 3 4] VotersOutgoing:[1 2 4] Learners:[] LearnersNext:[] AutoLeave:false Witnesses:[4]",
+		},
 	}
 
 	for _, test := range tests {
@@ -285,6 +296,18 @@ func TestReplicaDescriptorsCanMakeProgress(t *testing.T) {
 			{false, rd(VOTER_FULL, 4)},
 			{false, rd(LEARNER, 4)},
 		}, true},
+		// A witness counts towards the quorum.
+		{[]descWithLiveness{
+			{true, rd(VOTER_FULL, 1)},
+			{false, rd(VOTER_FULL, 2)},
+			{true, rd(WITNESS, 3)},
+		}, true},
+		// Only the witness is alive.
+		{[]descWithLiveness{
+			{false, rd(VOTER_FULL, 1)},
+			{false, rd(VOTER_FULL, 2)},
+			{true, rd(WITNESS, 3)},
+		}, false},
 	} {
 		t.Run("", func(t *testing.T) {
 			rds := make([]ReplicaDescriptor, 0, len(test.rds))
@@ -701,13 +701,29 @@ func (e *Engines) Close() {
 	*e = nil
 }
 
-// CreateEngines creates Engines based on the specs in cfg.Stores.
-func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
+// RaftLogEngines maps the engine of each store that keeps its Raft log in a
+// separate engine (see base.StoreSpec.RaftLogPath) to that engine.
+type RaftLogEngines map[storage.Engine]storage.Engine
+
+// Close closes all the RaftLogEngines. Like Engines.Close, it has a pointer
+// receiver so that the map can be neutralized once ownership is passed on.
+func (e *RaftLogEngines) Close() {
+	for _, eng := range *e {
+		eng.Close()
+	}
+	*e = nil
+}
+
+// CreateEngines creates Engines based on the specs in cfg.Stores, along with
+// the Raft log engines of the stores configured with a RaftLogPath.
+func (cfg *Config) CreateEngines(ctx context.Context) (Engines, RaftLogEngines, error) {
 	var engines Engines
 	defer engines.Close()
+	var raftLogEngines RaftLogEngines
+	defer raftLogEngines.Close()
 
 	if cfg.enginesCreated {
-		return Engines{}, errors.Errorf("engines already created")
+		return Engines{}, nil, errors.Errorf("engines already created")
 	}
 	cfg.enginesCreated = true
 
@@ -728,7 +744,7 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 			base.ExternalIODirConfig{}, cfg.Settings, nil, cfg.User, nil,
 			nil, cloud.NilMetrics)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
@@ -740,7 +756,7 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 	}
 	openFileLimitPerStore, err := setOpenFileLimit(physicalStores)
 	if err != nil {
-		return Engines{}, err
+		return Engines{}, nil, err
 	}
 
 	log.Event(ctx, "initializing engines")
@@ -765,7 +781,7 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 
 	storeEnvs, err := fs.InitEnvsFromStoreSpecs(ctx, cfg.Stores.Specs, fs.ReadWrite, stickyRegistry)
 	if err != nil {
-		return Engines{}, err
+		return Engines{}, nil, err
 	}
 	defer storeEnvs.CloseAll()
 
@@ -791,12 +807,12 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 			if spec.Size.Percent > 0 {
 				sysMem, err := status.GetTotalMemory(ctx)
 				if err != nil {
-					return Engines{}, errors.Errorf("could not retrieve system memory")
+					return Engines{}, nil, errors.Errorf("could not retrieve system memory")
 				}
 				sizeInBytes = int64(float64(sysMem) * spec.Size.Percent / 100)
 			}
 			if sizeInBytes != 0 && !storeKnobs.SkipMinSizeCheck && sizeInBytes < base.MinimumStoreSize {
-				return Engines{}, errors.Errorf("%f%% of memory is only %s bytes, which is below the minimum requirement of %s",
+				return Engines{}, nil, errors.Errorf("%f%% of memory is only %s bytes, which is below the minimum requirement of %s",
 					spec.Size.Percent, humanizeutil.IBytes(sizeInBytes), humanizeutil.IBytes(base.MinimumStoreSize))
 			}
 			addCfgOpt(storage.MaxSize(sizeInBytes))
@@ -810,14 +826,14 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 			// data directory if it didn't already exist.
 			du, err := storeEnvs[i].UnencryptedFS.GetDiskUsage(spec.Path)
 			if err != nil {
-				return Engines{}, errors.Wrap(err, "retrieving disk usage")
+				return Engines{}, nil, errors.Wrap(err, "retrieving disk usage")
 			}
 			var sizeInBytes = spec.Size.InBytes
 			if spec.Size.Percent > 0 {
 				sizeInBytes = int64(float64(du.TotalBytes) * spec.Size.Percent / 100)
 			}
 			if sizeInBytes != 0 && !storeKnobs.SkipMinSizeCheck && sizeInBytes < base.MinimumStoreSize {
-				return Engines{}, errors.Errorf("%f%% of %s's total free space is only %s bytes, which is below the minimum requirement of %s",
+				return Engines{}, nil, errors.Errorf("%f%% of %s's total free space is only %s bytes, which is below the minimum requirement of %s",
 					spec.Size.Percent, spec.Path, humanizeutil.IBytes(sizeInBytes), humanizeutil.IBytes(base.MinimumStoreSize))
 			}
 
@@ -849,12 +865,12 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 				}))
 			}
 			if len(spec.RocksDBOptions) > 0 {
-				return nil, errors.Errorf("store %d: using Pebble storage engine but StoreSpec provides RocksDB options", i)
+				return nil, nil, errors.Errorf("store %d: using Pebble storage engine but StoreSpec provides RocksDB options", i)
 			}
 		}
 		eng, err := storage.Open(ctx, storeEnvs[i], cfg.Settings, storageConfigOpts...)
 		if err != nil {
-			return Engines{}, err
+			return Engines{}, nil, err
 		}
 		// Nil out the store env; the engine has taken responsibility for Closing
 		// it.
@@ -863,12 +879,24 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 		storeEnvs[i] = nil
 		detail(redact.Sprintf("store %d: %s", i, eng.Properties()))
 		engines = append(engines, eng)
+
+		if spec.RaftLogPath != "" {
+			logEng, err := openRaftLogEngine(ctx, cfg.Settings, spec, pebbleCache, tableCache)
+			if err != nil {
+				return Engines{}, nil, errors.Wrapf(err, "store %d: opening raft log engine", i)
+			}
+			if raftLogEngines == nil {
+				raftLogEngines = RaftLogEngines{}
+			}
+			raftLogEngines[eng] = logEng
+			detail(redact.Sprintf("store %d: raft log engine at %s", i, spec.RaftLogPath))
+		}
 	}
 
 	if tableCache != nil {
 		// Unref the table cache now that the engines hold references to it.
 		if err := tableCache.Unref(); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
@@ -879,9 +907,37 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 	}
 
 	// Clear out engines because we have deferred engines.Close().
-	enginesCopy := engines
-	engines = nil
-	return enginesCopy, nil
+	enginesCopy, raftLogEnginesCopy := engines, raftLogEngines
+	engines, raftLogEngines = nil, nil
+	return enginesCopy, raftLogEnginesCopy, nil
+}
+
+// openRaftLogEngine opens the engine holding the Raft log of the store with the
+// given spec. It shares the block and table caches of the store engines.
+func openRaftLogEngine(
+	ctx context.Context,
+	st *cluster.Settings,
+	spec base.StoreSpec,
+	pebbleCache *pebble.Cache,
+	tableCache *pebble.TableCache,
+) (storage.Engine, error) {
+	env, err := fs.InitEnv(ctx, vfs.Default, spec.RaftLogPath, fs.EnvConfig{
+		RW:                fs.ReadWrite,
+		EncryptionOptions: spec.EncryptionOptions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	eng, err := storage.Open(ctx, env, st,
+		storage.RaftLogEngine,
+		storage.Caches(pebbleCache, tableCache),
+		storage.MaxWriterConcurrency(2),
+	)
+	if err != nil {
+		env.Close()
+		return nil, err
+	}
+	return eng, nil
 }
 
 // InitSQLServer finalizes the configuration of a SQL-only node.
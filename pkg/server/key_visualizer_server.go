@@ -130,6 +130,7 @@ func (s *KeyVisualizerServer) getSamplesFromFanOut(
 		samples = append(samples, keyvispb.Sample{
 			SampleTime: timeutil.Unix(0, sampleTimeNanos),
 			SpanStats:  cumulativeStats(sampleFragments),
+			HotKeys:    cumulativeHotKeys(sampleFragments),
 		})
 	}
 
@@ -206,6 +207,32 @@ func cumulativeStats(fragments []keyvispb.Sample) []keyvispb.SpanStats {
 	return ret
 }
 
+// maxHotKeysPerSample is the maximum number of hot keys kept in a sample that
+// is aggregated from across the cluster.
+const maxHotKeysPerSample = 10
+
+// cumulativeHotKeys accumulates the load of each of a sample's hot keys from
+// across the cluster, and returns the hottest ones in decreasing order of load.
+func cumulativeHotKeys(fragments []keyvispb.Sample) []keyvispb.HotKey {
+	unique := make(map[string]int)
+	var ret []keyvispb.HotKey
+	for _, sampleFragment := range fragments {
+		for _, hk := range sampleFragment.HotKeys {
+			if i, ok := unique[string(hk.Key)]; ok {
+				ret[i].QPS += hk.QPS
+				continue
+			}
+			unique[string(hk.Key)] = len(ret)
+			ret = append(ret, hk)
+		}
+	}
+	sort.Slice(ret, func(a, b int) bool { return ret[a].QPS > ret[b].QPS })
+	if len(ret) > maxHotKeysPerSample {
+		ret = ret[:maxHotKeysPerSample]
+	}
+	return ret
+}
+
 // GetSamples implements the keyvispb.KeyVisualizerServer interface.
 func (s *KeyVisualizerServer) GetSamples(
 	ctx context.Context, req *keyvispb.GetSamplesRequest,
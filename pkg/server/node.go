@@ -39,6 +39,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvstorage"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness/livenesspb"
 	"github.com/cockroachdb/cockroach/pkg/multitenant"
+	"github.com/cockroachdb/cockroach/pkg/multitenant/mtinfopb"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/tenantcapabilities"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/tenantcapabilities/tenantcapabilitieswatcher"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -1200,6 +1201,35 @@ func (n *Node) GetTenantWeights() kvadmission.TenantWeights {
 	return weights
 }
 
+// GetTenantAdmissionConfigs implements kvadmission.TenantWeightProvider.
+func (n *Node) GetTenantAdmissionConfigs() (
+	map[uint64]kvadmission.TenantAdmissionConfig,
+	<-chan struct{},
+) {
+	if n.tenantInfoWatcher == nil {
+		return nil, nil
+	}
+	entries, changeCh := n.tenantInfoWatcher.GetAllTenants()
+	var configs map[uint64]kvadmission.TenantAdmissionConfig
+	for _, e := range entries {
+		c := kvadmission.TenantAdmissionConfig{Weight: e.Admission.Weight}
+		switch e.Admission.PriorityClass {
+		case mtinfopb.AdmissionConfig_PREMIUM:
+			c.PriorityClass = admission.TenantPriorityClassPremium
+		case mtinfopb.AdmissionConfig_BEST_EFFORT:
+			c.PriorityClass = admission.TenantPriorityClassBestEffort
+		}
+		if c == (kvadmission.TenantAdmissionConfig{}) {
+			continue
+		}
+		if configs == nil {
+			configs = make(map[uint64]kvadmission.TenantAdmissionConfig)
+		}
+		configs[e.TenantID.ToUint64()] = c
+	}
+	return configs, changeCh
+}
+
 func startGraphiteStatsExporter(
 	ctx context.Context,
 	stopper *stop.Stopper,
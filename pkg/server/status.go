@@ -2801,6 +2801,7 @@ func (s *systemStatusServer) HotRangesV2(
 						WriteBytesPerSecond: r.WriteBytesPerSecond,
 						ReadBytesPerSecond:  r.ReadBytesPerSecond,
 						CPUTimePerSecond:    r.CPUTimePerSecond,
+						HotKeys:             r.HotKeys,
 						TableName:           tableName,
 						SchemaName:          schemaName,
 						DatabaseName:        dbName,
@@ -2898,6 +2899,12 @@ func (s *systemStatusServer) localHotRanges(
 			storeResp.HotRanges[i].WriteBytesPerSecond = r.WriteBytesPerSecond
 			storeResp.HotRanges[i].ReadBytesPerSecond = r.ReadBytesPerSecond
 			storeResp.HotRanges[i].CPUTimePerSecond = r.CPUTimePerSecond
+			if len(r.HotKeys) > 0 {
+				storeResp.HotRanges[i].HotKeys = make([]serverpb.HotKey, len(r.HotKeys))
+				for j, k := range r.HotKeys {
+					storeResp.HotRanges[i].HotKeys[j] = serverpb.HotKey{Key: k.Key, QPS: k.QPS}
+				}
+			}
 		}
 		resp.Stores = append(resp.Stores, storeResp)
 		return nil
@@ -3767,6 +3774,18 @@ func (s *systemStatusServer) Stores(
 		if props.WalFailoverPath != nil {
 			storeDetails.WalFailoverPath = *props.WalFailoverPath
 		}
+		for _, k := range envStats.Keys {
+			storeDetails.EncryptionKeys = append(storeDetails.EncryptionKeys, serverpb.EncryptionKeyStats{
+				KeyID:          k.KeyID,
+				ParentKeyID:    k.ParentKeyID,
+				StoreKey:       k.StoreKey,
+				Active:         k.Active,
+				EncryptionType: k.EncryptionType,
+				CreationTime:   k.CreationTime,
+				Files:          k.Files,
+				Bytes:          k.Bytes,
+			})
+		}
 		resp.Stores = append(resp.Stores, storeDetails)
 		return nil
 	})
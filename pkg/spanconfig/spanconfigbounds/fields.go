@@ -53,6 +53,8 @@ var fields = [config.NumFields]Field{
 	constraints,
 	voterConstraints,
 	leasePreferences,
+	numWitnesses,
+	maxQPSPerKey,
 }
 
 const (
@@ -65,4 +67,6 @@ const (
 	constraints      = constraintsConjunctionField(config.Constraints)
 	voterConstraints = constraintsConjunctionField(config.VoterConstraints)
 	leasePreferences = leasePreferencesField(config.LeasePreferences)
+	numWitnesses     = int32Field(config.NumWitnesses)
+	maxQPSPerKey     = int32Field(config.MaxQPSPerKey)
 )
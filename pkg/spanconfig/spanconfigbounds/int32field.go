@@ -35,6 +35,11 @@ func (f int32Field) FieldBound(b *Bounds) ValueBounds {
 			return b.NumReplicas
 		case numVoters:
 			return b.NumVoters
+		case numWitnesses:
+			return b.NumWitnesses
+		case maxQPSPerKey:
+			// Tenants may limit the rate of requests to their keys freely.
+			return nil
 		case gcTTLSeconds:
 			return b.GCTTLSeconds
 		default:
@@ -60,6 +65,10 @@ func (f int32Field) fieldValue(c *roachpb.SpanConfig) *int32 {
 		return &c.NumReplicas
 	case numVoters:
 		return &c.NumVoters
+	case numWitnesses:
+		return &c.NumWitnesses
+	case maxQPSPerKey:
+		return &c.MaxQPSPerKey
 	case gcTTLSeconds:
 		return &c.GCPolicy.TTLSeconds
 	default:
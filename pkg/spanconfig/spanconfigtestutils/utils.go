@@ -640,6 +640,12 @@ func PrintSpanConfigDiffedAgainstDefaults(conf roachpb.SpanConfig) string {
 	if conf.NumVoters != defaultConf.NumVoters {
 		diffs = append(diffs, fmt.Sprintf("num_voters=%d", conf.NumVoters))
 	}
+	if conf.NumWitnesses != defaultConf.NumWitnesses {
+		diffs = append(diffs, fmt.Sprintf("num_witnesses=%d", conf.NumWitnesses))
+	}
+	if conf.MaxQPSPerKey != defaultConf.MaxQPSPerKey {
+		diffs = append(diffs, fmt.Sprintf("max_qps_per_key=%d", conf.MaxQPSPerKey))
+	}
 	if conf.RangefeedEnabled != defaultConf.RangefeedEnabled {
 		diffs = append(diffs, fmt.Sprintf("rangefeed_enabled=%t", conf.RangefeedEnabled))
 	}
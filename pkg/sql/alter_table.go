@@ -706,6 +706,22 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 			descriptorChanged = descriptorChanged || changed
 
+		case *tree.AlterTableSetRowLevelSecurity:
+			if err := params.p.checkCanManagePolicies(params.ctx, n.tableDesc); err != nil {
+				return err
+			}
+			if (t.Mode == tree.RowLevelSecurityEnable || t.Mode == tree.RowLevelSecurityForce) &&
+				!params.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.V24_1_RowLevelSecurity) {
+				return pgerror.New(pgcode.FeatureNotSupported,
+					"row-level security is not supported until the cluster upgrade is finalized",
+				)
+			}
+			changed, err := n.tableDesc.SetRowLevelSecurity(t.Mode)
+			if err != nil {
+				return err
+			}
+			descriptorChanged = descriptorChanged || changed
+
 		case *tree.AlterTableInjectStats:
 			sd, ok := n.statsData[i]
 			if !ok {
@@ -1501,6 +1517,19 @@ StatsLoop:
 				return err
 			}
 		}
+		ext, err := s.GetExtendedStats(params.ctx, &params.p.semaCtx, params.EvalContext())
+		if err != nil {
+			return err
+		}
+		// Likewise, extendedStats should be a nil interface{} if there are no
+		// extended statistics.
+		var extendedStats interface{}
+		if ext != nil {
+			extendedStats, err = protoutil.Marshal(ext)
+			if err != nil {
+				return err
+			}
+		}
 
 		columnIDs := tree.NewDArray(types.Int)
 		for _, colName := range s.Columns {
@@ -1517,7 +1546,9 @@ StatsLoop:
 			}
 		}
 
-		if err := insertJSONStatistic(params, desc.GetID(), columnIDs, s, histogram); err != nil {
+		if err := insertJSONStatistic(
+			params, desc.GetID(), columnIDs, s, histogram, extendedStats,
+		); err != nil {
 			return errors.Wrap(err, "failed to insert stats")
 		}
 	}
@@ -1536,6 +1567,7 @@ func insertJSONStatistic(
 	columnIDs *tree.DArray,
 	s *stats.JSONStatistic,
 	histogram interface{},
+	extendedStats interface{},
 ) error {
 	var (
 		ctx = params.ctx
@@ -1557,6 +1589,29 @@ func insertJSONStatistic(
 		fullStatisticIDValue = s.FullStatisticID
 	}
 
+	args := []interface{}{
+		tableID,
+		name,
+		columnIDs,
+		s.CreatedAt,
+		s.RowCount,
+		s.DistinctCount,
+		s.NullCount,
+		s.AvgSize,
+		histogram,
+		predicateValue,
+		fullStatisticIDValue,
+	}
+	// The extendedStats column only exists once the cluster upgrade that adds
+	// it has run, so extended statistics are dropped until then.
+	var extendedStatsColumn, extendedStatsValue string
+	if extendedStats != nil &&
+		params.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1_TableStatisticsExtendedStats) {
+		extendedStatsColumn = `, "extendedStats"`
+		extendedStatsValue = ", $12"
+		args = append(args, extendedStats)
+	}
+
 	_ /* rows */, err := txn.Exec(
 		ctx,
 		"insert-stats",
@@ -1572,19 +1627,9 @@ func insertJSONStatistic(
 					"avgSize",
 					histogram,
 					"partialPredicate",
-					"fullStatisticID"
-				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
-		tableID,
-		name,
-		columnIDs,
-		s.CreatedAt,
-		s.RowCount,
-		s.DistinctCount,
-		s.NullCount,
-		s.AvgSize,
-		histogram,
-		predicateValue,
-		fullStatisticIDValue,
+					"fullStatisticID"`+extendedStatsColumn+`
+				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11`+extendedStatsValue+`)`,
+		args...,
 	)
 	return err
 }
@@ -1843,14 +1888,17 @@ func dropColumnImpl(
 		return nil, err
 	}
 
-	// We cannot remove this column if there are computed columns or a TTL
-	// expiration expression that use it.
+	// We cannot remove this column if there are computed columns, a TTL
+	// expiration expression or row-level security policies that use it.
 	if err := schemaexpr.ValidateColumnHasNoDependents(tableDesc, colToDrop); err != nil {
 		return nil, err
 	}
 	if err := schemaexpr.ValidateTTLExpressionDoesNotDependOnColumn(tableDesc, rowLevelTTL, colToDrop); err != nil {
 		return nil, err
 	}
+	if err := schemaexpr.ValidatePoliciesDoNotDependOnColumn(tableDesc, colToDrop); err != nil {
+		return nil, err
+	}
 
 	if tableDesc.GetPrimaryIndex().CollectKeyColumnIDs().Contains(colToDrop.GetID()) {
 		return nil, sqlerrors.NewColumnReferencedByPrimaryKeyError(colToDrop.GetName())
@@ -342,7 +342,7 @@ func runPlanInsidePlan(
 
 	distributePlan := getPlanDistribution(
 		ctx, plannerCopy.Descriptors().HasUncommittedTypes(),
-		plannerCopy.SessionData().DistSQLMode, plan.main,
+		plannerCopy.SessionData().DistSQLMode, plan.mainDistributionCosts, plan.main,
 	)
 	distributeType := DistributionType(LocalDistribution)
 	if distributePlan.WillDistribute() {
@@ -203,6 +203,7 @@ func (s *StatementStatistics) Add(other *StatementStatistics) {
 
 	if len(other.IndexRecommendations) > 0 {
 		s.IndexRecommendations = other.IndexRecommendations
+		s.IndexRecommendationsCostReduction = other.IndexRecommendationsCostReduction
 	}
 
 	s.Count += other.Count
@@ -86,6 +86,20 @@ var indexBackfillBatchSize = settings.RegisterIntSetting(
 	settings.NonNegativeInt, /* validateFn */
 )
 
+// indexBackfillNumWorkers is the number of workers each index backfiller
+// processor uses to build and ingest index entries concurrently. When it is
+// larger than one, the spans assigned to every node are also sub-split using
+// keys sampled from the table statistics (or from the table itself), so that
+// the workers have enough work even if the table consists of only a few large
+// ranges.
+var indexBackfillNumWorkers = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"bulkio.index_backfill.num_workers",
+	"the number of parallel workers building and ingesting index entries per node during an index backfill",
+	4,
+	settings.PositiveInt,
+)
+
 // columnBackfillBatchSize is the maximum number of rows we update at once when
 // adding or removing columns.
 var columnBackfillBatchSize = settings.RegisterIntSetting(
@@ -1049,7 +1063,8 @@ func (sc *SchemaChanger) distIndexBackfill(
 		)
 		indexBatchSize := indexBackfillBatchSize.Get(&sc.execCfg.Settings.SV)
 		chunkSize := sc.getChunkSize(indexBatchSize)
-		spec, err := initIndexBackfillerSpec(*tableDesc.TableDesc(), writeAsOf, readAsOf, writeAtRequestTimestamp, chunkSize, addedIndexes)
+		numWorkers := int32(indexBackfillNumWorkers.Get(&sc.execCfg.Settings.SV))
+		spec, err := initIndexBackfillerSpec(*tableDesc.TableDesc(), writeAsOf, readAsOf, writeAtRequestTimestamp, chunkSize, numWorkers, addedIndexes)
 		if err != nil {
 			return err
 		}
@@ -756,9 +756,21 @@ type TableDescriptor interface {
 	// GetInProgressImportStartTime returns the start wall time of the in progress import,
 	// if it exists.
 	GetInProgressImportStartTime() int64
+	// IsOnlineImportInProgress returns true if the table is being imported into
+	// while remaining public. Such tables can be read, but not written to.
+	IsOnlineImportInProgress() bool
 	// IsSchemaLocked returns true if we don't allow performing schema changes
 	// on this table descriptor.
 	IsSchemaLocked() bool
+	// GetPolicies returns the row-level security policies defined on the
+	// table.
+	GetPolicies() []descpb.PolicyDescriptor
+	// IsRowLevelSecurityEnabled returns true if row-level security is enabled
+	// on the table.
+	IsRowLevelSecurityEnabled() bool
+	// IsRowLevelSecurityForced returns true if row-level security policies
+	// also apply to the owner of the table.
+	IsRowLevelSecurityForced() bool
 	// IsPrimaryKeySwapMutation returns true if the mutation is a primary key
 	// swap mutation or a secondary index used by the declarative schema changer
 	// for a primary index swap.
@@ -0,0 +1,95 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// ValidatePolicyExpr verifies that an expression is a valid USING or WITH
+// CHECK expression of a row-level security policy. If the expression is
+// valid, it returns the serialized expression with the columns dequalified.
+//
+// A policy expression is valid if all of the following are true:
+//
+//   - It results in a boolean.
+//   - It refers only to columns in the table.
+//   - It does not include subqueries or user-defined functions.
+//   - It does not include volatile, aggregate, window, or set returning
+//     functions. Stable functions are allowed so that policies can refer to
+//     current_user and session variables.
+func ValidatePolicyExpr(
+	ctx context.Context,
+	desc catalog.TableDescriptor,
+	e tree.Expr,
+	tn *tree.TableName,
+	semaCtx *tree.SemaContext,
+	version clusterversion.ClusterVersion,
+) (string, error) {
+	expr, _, _, err := DequalifyAndValidateExpr(
+		ctx,
+		desc,
+		e,
+		types.Bool,
+		tree.PolicyExpr,
+		semaCtx,
+		volatility.Stable,
+		tn,
+		version,
+	)
+	if err != nil {
+		return "", err
+	}
+	return expr, nil
+}
+
+// ValidatePoliciesDoNotDependOnColumn verifies that the expressions of the
+// row-level security policies on the table do not reference the given column.
+func ValidatePoliciesDoNotDependOnColumn(
+	desc catalog.TableDescriptor, col catalog.Column,
+) error {
+	policies := desc.GetPolicies()
+	for i := range policies {
+		p := &policies[i]
+		for _, exprStr := range []string{p.UsingExpr, p.WithCheckExpr} {
+			if exprStr == "" {
+				continue
+			}
+			expr, err := parser.ParseExpr(exprStr)
+			if err != nil {
+				// At this point, we should be able to parse the policy expression.
+				return errors.WithAssertionFailure(err)
+			}
+			referencedCols, err := ExtractColumnIDs(desc, expr)
+			if err != nil {
+				return err
+			}
+			if referencedCols.Contains(col.GetID()) {
+				return pgerror.Newf(
+					pgcode.DependentObjectsStillExist,
+					"cannot drop column %q because policy %q on table %q depends on it",
+					col.GetName(), p.Name, desc.GetName(),
+				)
+			}
+		}
+	}
+	return nil
+}
@@ -294,8 +294,9 @@ CREATE TABLE system.table_statistics (
 	"avgSize"            INT8       NOT NULL DEFAULT 0,
 	"partialPredicate"   STRING,
 	"fullStatisticID"    INT8,
+	"extendedStats"      BYTES,
 	CONSTRAINT "primary" PRIMARY KEY ("tableID", "statisticID"),
-	FAMILY "fam_0_tableID_statisticID_name_columnIDs_createdAt_rowCount_distinctCount_nullCount_histogram" ("tableID", "statisticID", name, "columnIDs", "createdAt", "rowCount", "distinctCount", "nullCount", histogram, "avgSize", "partialPredicate", "fullStatisticID")
+	FAMILY "fam_0_tableID_statisticID_name_columnIDs_createdAt_rowCount_distinctCount_nullCount_histogram" ("tableID", "statisticID", name, "columnIDs", "createdAt", "rowCount", "distinctCount", "nullCount", histogram, "avgSize", "partialPredicate", "fullStatisticID", "extendedStats")
 );`
 
 	// locations are used to map a locality specified by a node to geographic
@@ -1159,6 +1160,20 @@ CREATE TABLE system.mvcc_statistics (
 			created
 		)
 	);`
+
+	// StatementPlanHintsTableSchema stores the plan baselines created with
+	// CREATE PLAN BASELINE. Each row pins the plan of the statements with the
+	// given fingerprint, either to a captured plan gist or to a set of index
+	// hints.
+	StatementPlanHintsTableSchema = `
+CREATE TABLE system.statement_plan_hints (
+	fingerprint STRING NOT NULL,
+	plan_gist   STRING NULL,
+	hints       STRING NULL,
+	created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+	CONSTRAINT "primary" PRIMARY KEY (fingerprint),
+	FAMILY "primary" (fingerprint, plan_gist, hints, created_at)
+);`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -1208,7 +1223,7 @@ const SystemDatabaseName = catconstants.SystemDatabaseName
 // SystemDatabaseSchemaBootstrapVersion is the system database schema version
 // that should be used during bootstrap. It should be bumped up alongside any
 // upgrade that creates or modifies the schema of a system table.
-var SystemDatabaseSchemaBootstrapVersion = clusterversion.V24_1_SessionBasedLeasingUpgradeDescriptor.Version()
+var SystemDatabaseSchemaBootstrapVersion = clusterversion.V24_1_TableStatisticsExtendedStats.Version()
 
 // MakeSystemDatabaseDesc constructs a copy of the system database
 // descriptor.
@@ -1399,6 +1414,7 @@ func MakeSystemTables() []SystemTable {
 		SystemMVCCStatisticsTable,
 		StatementExecInsightsTable,
 		TransactionExecInsightsTable,
+		StatementPlanHintsTable,
 	}
 }
 
@@ -2113,6 +2129,7 @@ var (
 				{Name: "avgSize", ID: 10, Type: types.Int, DefaultExpr: &zeroIntString},
 				{Name: "partialPredicate", ID: 11, Type: types.String, Nullable: true},
 				{Name: "fullStatisticID", ID: 12, Type: types.Int, Nullable: true},
+				{Name: "extendedStats", ID: 13, Type: types.Bytes, Nullable: true},
 			},
 			[]descpb.ColumnFamilyDescriptor{
 				{
@@ -2131,8 +2148,9 @@ var (
 						"avgSize",
 						"partialPredicate",
 						"fullStatisticID",
+						"extendedStats",
 					},
-					ColumnIDs: []descpb.ColumnID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+					ColumnIDs: []descpb.ColumnID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13},
 				},
 			},
 			descpb.IndexDescriptor{
@@ -4715,6 +4733,29 @@ var (
 			tbl.NextConstraintID++
 		},
 	)
+
+	StatementPlanHintsTable = makeSystemTable(
+		StatementPlanHintsTableSchema,
+		systemTable(
+			catconstants.StatementPlanHintsTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "fingerprint", ID: 1, Type: types.String},
+				{Name: "plan_gist", ID: 2, Type: types.String, Nullable: true},
+				{Name: "hints", ID: 3, Type: types.String, Nullable: true},
+				{Name: "created_at", ID: 4, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"fingerprint", "plan_gist", "hints", "created_at"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4},
+				},
+			},
+			pk("fingerprint"),
+		),
+	)
 )
 
 // SpanConfigurationsTableName represents system.span_configurations.
@@ -2245,6 +2245,49 @@ func (desc *Mutable) SetAuditMode(mode tree.AuditMode) (bool, error) {
 	return prev != desc.AuditMode, nil
 }
 
+// SetRowLevelSecurity applies an ALTER TABLE ... ROW LEVEL SECURITY command to
+// the descriptor. It returns whether the descriptor changed.
+func (desc *Mutable) SetRowLevelSecurity(mode tree.RowLevelSecurityMode) (bool, error) {
+	prevEnabled, prevForced := desc.RowLevelSecurityEnabled, desc.RowLevelSecurityForced
+	switch mode {
+	case tree.RowLevelSecurityEnable:
+		desc.RowLevelSecurityEnabled = true
+	case tree.RowLevelSecurityDisable:
+		desc.RowLevelSecurityEnabled = false
+	case tree.RowLevelSecurityForce:
+		desc.RowLevelSecurityForced = true
+	case tree.RowLevelSecurityNoForce:
+		desc.RowLevelSecurityForced = false
+	default:
+		return false, pgerror.Newf(pgcode.InvalidParameterValue,
+			"unknown row-level security mode: %s (%d)", mode, mode)
+	}
+	return prevEnabled != desc.RowLevelSecurityEnabled || prevForced != desc.RowLevelSecurityForced, nil
+}
+
+// AddPolicy adds a row-level security policy to the descriptor, allocating an
+// ID for it.
+func (desc *Mutable) AddPolicy(policy descpb.PolicyDescriptor) {
+	if desc.NextPolicyID == 0 {
+		desc.NextPolicyID = 1
+	}
+	policy.ID = desc.NextPolicyID
+	desc.NextPolicyID++
+	desc.Policies = append(desc.Policies, policy)
+}
+
+// RemovePolicy removes the row-level security policy with the given name from
+// the descriptor. It returns false if there is no such policy.
+func (desc *Mutable) RemovePolicy(name string) bool {
+	for i := range desc.Policies {
+		if desc.Policies[i].Name == name {
+			desc.Policies = append(desc.Policies[:i], desc.Policies[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // FindAllReferences returns all the references from a table.
 func (desc *wrapper) FindAllReferences() (map[descpb.ID]struct{}, error) {
 	refs := map[descpb.ID]struct{}{}
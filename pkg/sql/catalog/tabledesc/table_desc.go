@@ -214,6 +214,14 @@ func (desc *Mutable) OfflineForImport() {
 	desc.ImportEpoch++
 }
 
+// PrepareForOnlineImport bumps the ImportEpoch in advance of an import
+// which keeps the table public. Unlike OfflineForImport, the table remains
+// readable.
+func (desc *Mutable) PrepareForOnlineImport() {
+	desc.ImportType = descpb.ImportType_IMPORT_ONLINE
+	desc.ImportEpoch++
+}
+
 // InitializeImport binds the import start time to the table descriptor.
 func (desc *Mutable) InitializeImport(startWallTime int64) error {
 	if desc.ImportStartWallTime != 0 {
@@ -635,6 +643,11 @@ func (desc *wrapper) GetInProgressImportStartTime() int64 {
 	return desc.ImportStartWallTime
 }
 
+// IsOnlineImportInProgress implements the TableDescriptor interface.
+func (desc *wrapper) IsOnlineImportInProgress() bool {
+	return desc.ImportType == descpb.ImportType_IMPORT_ONLINE
+}
+
 // ForEachUDTDependentForHydration implements the catalog.Descriptor interface.
 func (desc *wrapper) ForEachUDTDependentForHydration(fn func(t *types.T) error) error {
 	for _, c := range desc.UserDefinedTypeColumns() {
@@ -650,6 +663,21 @@ func (desc *wrapper) IsSchemaLocked() bool {
 	return desc.SchemaLocked
 }
 
+// GetPolicies implements the TableDescriptor interface.
+func (desc *wrapper) GetPolicies() []descpb.PolicyDescriptor {
+	return desc.Policies
+}
+
+// IsRowLevelSecurityEnabled implements the TableDescriptor interface.
+func (desc *wrapper) IsRowLevelSecurityEnabled() bool {
+	return desc.RowLevelSecurityEnabled
+}
+
+// IsRowLevelSecurityForced implements the TableDescriptor interface.
+func (desc *wrapper) IsRowLevelSecurityForced() bool {
+	return desc.RowLevelSecurityForced
+}
+
 // IsPrimaryKeySwapMutation implements the TableDescriptor interface.
 func (desc *wrapper) IsPrimaryKeySwapMutation(m *descpb.DescriptorMutation) bool {
 	switch t := m.Descriptor_.(type) {
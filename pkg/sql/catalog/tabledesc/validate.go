@@ -871,6 +871,7 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			desc.validateUniqueWithoutIndexConstraints(columnsByID),
 			desc.validateTableIndexes(columnsByID, vea.IsActive),
 			desc.validatePartitioning(),
+			desc.validatePolicies(),
 		}
 		hasErrs := false
 		for _, err := range newErrs {
@@ -1325,6 +1326,49 @@ func (desc *wrapper) validateCheckConstraints(
 	return nil
 }
 
+// validatePolicies validates that row-level security policies are well formed.
+// Checks include validating the policy names and IDs and verifying that the
+// policy expressions do not reference non-existent columns.
+func (desc *wrapper) validatePolicies() error {
+	names := make(map[string]struct{}, len(desc.Policies))
+	ids := make(map[descpb.PolicyID]struct{}, len(desc.Policies))
+	for i := range desc.Policies {
+		p := &desc.Policies[i]
+		if p.ID == 0 || p.ID >= desc.NextPolicyID {
+			return errors.AssertionFailedf(
+				"policy %q has ID %d, which is not less than the next policy ID %d",
+				p.Name, p.ID, desc.NextPolicyID)
+		}
+		if _, ok := ids[p.ID]; ok {
+			return errors.Newf("duplicate policy ID: %d", p.ID)
+		}
+		ids[p.ID] = struct{}{}
+		if _, ok := names[p.Name]; ok {
+			return errors.Newf("duplicate policy name: %q", p.Name)
+		}
+		names[p.Name] = struct{}{}
+
+		for _, exprStr := range []string{p.UsingExpr, p.WithCheckExpr} {
+			if exprStr == "" {
+				continue
+			}
+			expr, err := parser.ParseExpr(exprStr)
+			if err != nil {
+				return err
+			}
+			valid, err := schemaexpr.HasValidColumnReferences(desc, expr)
+			if err != nil {
+				return err
+			}
+			if !valid {
+				return errors.Newf("policy %q refers to unknown columns in expression: %s",
+					p.Name, exprStr)
+			}
+		}
+	}
+	return nil
+}
+
 // validateUniqueWithoutIndexConstraints validates that unique without index
 // constraints are well formed. Checks include validating the column IDs and
 // column names.
@@ -140,6 +140,22 @@ var validationMap = []struct {
 			"SchemaLocked":                  {status: thisFieldReferencesNoObjects},
 			"ImportEpoch":                   {status: thisFieldReferencesNoObjects},
 			"ImportType":                    {status: thisFieldReferencesNoObjects},
+			"Policies":                      {status: iSolemnlySwearThisFieldIsValidated},
+			"NextPolicyID":                  {status: iSolemnlySwearThisFieldIsValidated},
+			"RowLevelSecurityEnabled":       {status: thisFieldReferencesNoObjects},
+			"RowLevelSecurityForced":        {status: thisFieldReferencesNoObjects},
+		},
+	},
+	{
+		obj: descpb.PolicyDescriptor{},
+		fieldMap: map[string]validationStatusInfo{
+			"ID":            {status: iSolemnlySwearThisFieldIsValidated},
+			"Name":          {status: iSolemnlySwearThisFieldIsValidated},
+			"Type":          {status: thisFieldReferencesNoObjects},
+			"Command":       {status: thisFieldReferencesNoObjects},
+			"RoleNames":     {status: thisFieldReferencesNoObjects},
+			"UsingExpr":     {status: iSolemnlySwearThisFieldIsValidated},
+			"WithCheckExpr": {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -2623,6 +2639,71 @@ func TestValidateTableDesc(t *testing.T) {
 					ExpirationExpr: catpb.Expression("missing_col"),
 				},
 			}},
+		{err: `duplicate policy name: "p"`,
+			desc: descpb.TableDescriptor{
+				ID:            2,
+				ParentID:      1,
+				Name:          "foo",
+				FormatVersion: descpb.InterleavedFormatVersion,
+				Columns: []descpb.ColumnDescriptor{
+					{ID: 1, Name: "a"},
+				},
+				Families: []descpb.ColumnFamilyDescriptor{
+					{ID: 0, Name: "fam", ColumnIDs: []descpb.ColumnID{1}, ColumnNames: []string{"a"}},
+				},
+				PrimaryIndex: descpb.IndexDescriptor{
+					ID:                  1,
+					Name:                "primary",
+					Unique:              true,
+					KeyColumnIDs:        []descpb.ColumnID{1},
+					KeyColumnNames:      []string{"a"},
+					KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_ASC},
+					Version:             descpb.PrimaryIndexWithStoredColumnsVersion,
+					EncodingType:        catenumpb.PrimaryIndexEncoding,
+					ConstraintID:        1,
+				},
+				NextColumnID:     2,
+				NextFamilyID:     1,
+				NextIndexID:      2,
+				NextConstraintID: 2,
+				NextPolicyID:     3,
+				Policies: []descpb.PolicyDescriptor{
+					{ID: 1, Name: "p", UsingExpr: "a > 0"},
+					{ID: 2, Name: "p", UsingExpr: "a < 10"},
+				},
+			}},
+		{err: `policy "p" refers to unknown columns in expression: missing_col > 0`,
+			desc: descpb.TableDescriptor{
+				ID:            2,
+				ParentID:      1,
+				Name:          "foo",
+				FormatVersion: descpb.InterleavedFormatVersion,
+				Columns: []descpb.ColumnDescriptor{
+					{ID: 1, Name: "a"},
+				},
+				Families: []descpb.ColumnFamilyDescriptor{
+					{ID: 0, Name: "fam", ColumnIDs: []descpb.ColumnID{1}, ColumnNames: []string{"a"}},
+				},
+				PrimaryIndex: descpb.IndexDescriptor{
+					ID:                  1,
+					Name:                "primary",
+					Unique:              true,
+					KeyColumnIDs:        []descpb.ColumnID{1},
+					KeyColumnNames:      []string{"a"},
+					KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_ASC},
+					Version:             descpb.PrimaryIndexWithStoredColumnsVersion,
+					EncodingType:        catenumpb.PrimaryIndexEncoding,
+					ConstraintID:        1,
+				},
+				NextColumnID:     2,
+				NextFamilyID:     1,
+				NextIndexID:      2,
+				NextConstraintID: 2,
+				NextPolicyID:     2,
+				Policies: []descpb.PolicyDescriptor{
+					{ID: 1, Name: "p", WithCheckExpr: "missing_col > 0"},
+				},
+			}},
 		{err: `"ttl_expire_after" and/or "ttl_expiration_expression" must be set`,
 			desc: descpb.TableDescriptor{
 				ID:            2,
@@ -91,6 +91,16 @@ func init() {
 			RequiredType: types.Int,
 			Setter:       func(c *zonepb.ZoneConfig, d tree.Datum) { c.NumVoters = proto.Int32(int32(tree.MustBeDInt(d))) },
 		},
+		{
+			Field:        config.NumWitnesses,
+			RequiredType: types.Int,
+			Setter:       func(c *zonepb.ZoneConfig, d tree.Datum) { c.NumWitnesses = proto.Int32(int32(tree.MustBeDInt(d))) },
+		},
+		{
+			Field:        config.MaxQPSPerKey,
+			RequiredType: types.Int,
+			Setter:       func(c *zonepb.ZoneConfig, d tree.Datum) { c.MaxQPSPerKey = proto.Int32(int32(tree.MustBeDInt(d))) },
+		},
 		{
 			Field:        config.GCTTL,
 			RequiredType: types.Int,
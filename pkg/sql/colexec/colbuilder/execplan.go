@@ -179,7 +179,7 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 		return nil
 
 	case core.JoinReader != nil:
-		if !core.JoinReader.IsIndexJoin() {
+		if !core.JoinReader.IsIndexJoin() && core.JoinReader.AdaptiveThreshold == 0 {
 			return errLookupJoinUnsupported
 		}
 		return nil
@@ -221,14 +221,10 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 
 	case core.Windower != nil:
 		for _, wf := range core.Windower.WindowFns {
-			if wf.FilterColIdx != tree.NoColumnIdx {
+			// Only aggregate window functions can have a FILTER clause.
+			if wf.FilterColIdx != tree.NoColumnIdx && wf.Func.AggregateFunc == nil {
 				return errWindowFunctionFilterClause
 			}
-			if wf.Func.AggregateFunc != nil {
-				if !colexecagg.IsAggOptimized(*wf.Func.AggregateFunc) {
-					return errDefaultAggregateWindowFunction
-				}
-			}
 		}
 		return nil
 
@@ -263,8 +259,7 @@ var (
 	errFilteringAggregation           = errors.New("filtering aggregation not supported")
 	errNonInnerHashJoinWithOnExpr     = errors.New("can't plan vectorized non-inner hash joins with ON expressions")
 	errNonInnerMergeJoinWithOnExpr    = errors.New("can't plan vectorized non-inner merge joins with ON expressions")
-	errWindowFunctionFilterClause     = errors.New("window functions with FILTER clause are not supported")
-	errDefaultAggregateWindowFunction = errors.New("default aggregate window functions not supported")
+	errWindowFunctionFilterClause     = errors.New("non-aggregate window functions with FILTER clause are not supported")
 	errStreamIngestionWrap            = errors.New("core.StreamIngestion{Data,Frontier} is not supported because of #55758")
 )
 
@@ -634,6 +629,8 @@ func makeNewHashJoinerArgs(
 	args *colexecargs.NewColOperatorArgs,
 	opName redact.RedactableString,
 	core *execinfrapb.HashJoinerSpec,
+	leftSource, rightSource colexecop.Operator,
+	leftTypes, rightTypes []*types.T,
 	factory coldata.ColumnFactory,
 ) (colexecjoin.NewHashJoinerArgs, redact.RedactableString) {
 	hashJoinerMemAccount, hashJoinerMemMonitorName := args.MonitorRegistry.CreateMemAccountForSpillStrategy(
@@ -648,20 +645,220 @@ func makeNewHashJoinerArgs(
 		core.Type,
 		core.LeftEqColumns,
 		core.RightEqColumns,
-		args.Spec.Input[0].ColumnTypes,
-		args.Spec.Input[1].ColumnTypes,
+		leftTypes,
+		rightTypes,
 		core.RightEqColumnsAreKey,
 	)
 	return colexecjoin.NewHashJoinerArgs{
 		BuildSideAllocator:       colmem.NewLimitedAllocator(ctx, hashJoinerMemAccount, accounts[0], factory),
 		OutputUnlimitedAllocator: colmem.NewAllocator(ctx, accounts[1], factory),
 		Spec:                     spec,
-		LeftSource:               args.Inputs[0].Root,
-		RightSource:              args.Inputs[1].Root,
+		LeftSource:               leftSource,
+		RightSource:              rightSource,
 		InitialNumBuckets:        colexecjoin.HashJoinerInitialNumBuckets,
 	}, hashJoinerMemMonitorName
 }
 
+// planHashJoiner plans a hash joiner (that spills to disk unless disk spilling
+// is disabled by the testing knobs) for the given spec which must have
+// non-empty equality columns.
+func (r opResult) planHashJoiner(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	args *colexecargs.NewColOperatorArgs,
+	core *execinfrapb.HashJoinerSpec,
+	leftSource, rightSource colexecop.Operator,
+	leftTypes, rightTypes []*types.T,
+	factory coldata.ColumnFactory,
+) colexecop.Operator {
+	opName := redact.RedactableString("hash-joiner")
+	hjArgs, hashJoinerMemMonitorName := makeNewHashJoinerArgs(
+		ctx,
+		flowCtx,
+		args,
+		opName,
+		core,
+		leftSource, rightSource,
+		leftTypes, rightTypes,
+		factory,
+	)
+	inMemoryHashJoiner := colexecjoin.NewHashJoiner(hjArgs)
+	if args.TestingKnobs.DiskSpillingDisabled {
+		// We will not be creating a disk-backed hash joiner because we're
+		// running a test that explicitly asked for only in-memory hash joiner.
+		return inMemoryHashJoiner
+	}
+	opName = "external-hash-joiner"
+	diskAccount := args.MonitorRegistry.CreateDiskAccount(ctx, flowCtx, opName, args.Spec.ProcessorID)
+	diskSpiller := colexecdisk.NewTwoInputDiskSpiller(
+		leftSource, rightSource, inMemoryHashJoiner.(colexecop.BufferingInMemoryOperator),
+		[]redact.RedactableString{hashJoinerMemMonitorName},
+		func(inputOne, inputTwo colexecop.Operator) colexecop.Operator {
+			accounts := args.MonitorRegistry.CreateUnlimitedMemAccounts(
+				ctx, flowCtx, opName, args.Spec.ProcessorID, 2, /* numAccounts */
+			)
+			unlimitedAllocator := colmem.NewAllocator(ctx, accounts[0], factory)
+			ehj := colexecdisk.NewExternalHashJoiner(
+				unlimitedAllocator,
+				flowCtx,
+				args,
+				hjArgs.Spec,
+				inputOne, inputTwo,
+				r.makeDiskBackedSorterConstructor(ctx, flowCtx, args, opName, factory),
+				diskAccount,
+				accounts[1],
+			)
+			r.ToClose = append(r.ToClose, ehj)
+			return ehj
+		},
+		args.TestingKnobs.SpillingCallbackFn,
+	)
+	r.ToClose = append(r.ToClose, diskSpiller)
+	return diskSpiller
+}
+
+// planAdaptiveJoin plans an AdaptiveJoiner for the lookup join described by
+// args.Spec (see JoinReaderSpec.AdaptiveThreshold). The lookup join strategy
+// is performed by a wrapped row-execution join reader whereas the hash join
+// strategy is performed by a vectorized hash joiner that is built on the input
+// and probed with a full scan of the lookup index. Either way, the internal
+// columns of the join reader are produced, and the post-processing is left to
+// the caller.
+func (r opResult) planAdaptiveJoin(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	args *colexecargs.NewColOperatorArgs,
+	inputs []colexecargs.OpWithMetaInfo,
+	factory coldata.ColumnFactory,
+) error {
+	spec := args.Spec
+	jr := spec.Core.JoinReader
+	if args.ProcessorConstructor == nil {
+		return errors.AssertionFailedf("processorConstructor is nil")
+	}
+	fetchedEqCols, ok := jr.LookupColumnFetchedOrdinals()
+	if !ok {
+		return errors.AssertionFailedf("lookup columns of an adaptive join are not fetched")
+	}
+	inputTypes := spec.Input[0].ColumnTypes
+	aj := colexecjoin.NewAdaptiveJoiner(
+		colmem.NewAllocator(ctx, args.MonitorRegistry.CreateUnlimitedMemAccount(
+			ctx, flowCtx, "adaptive-joiner" /* opName */, spec.ProcessorID,
+		), factory),
+		inputs[0].Root, inputTypes, jr.AdaptiveThreshold, flowCtx.ProcessorComponentID(spec.ProcessorID),
+	)
+
+	// Plan the lookup join strategy.
+	lookupSpec := *jr
+	lookupSpec.AdaptiveThreshold = 0
+	lookupSpec.AdaptiveScanSpans = nil
+	lookupCore := &execinfrapb.ProcessorCoreUnion{JoinReader: &lookupSpec}
+	lookupJoin, err := wrapRowSources(
+		ctx,
+		flowCtx,
+		[]colexecargs.OpWithMetaInfo{{Root: aj.BufferedInput()}},
+		[][]*types.T{inputTypes},
+		args.MonitorRegistry,
+		spec.ProcessorID,
+		func(inputs []execinfra.RowSource) (execinfra.RowSource, error) {
+			proc, err := args.ProcessorConstructor(
+				ctx, flowCtx, spec.ProcessorID, lookupCore, &execinfrapb.PostProcessSpec{},
+				inputs, args.LocalProcessors,
+			)
+			if err != nil {
+				return nil, err
+			}
+			rs, ok := proc.(execinfra.RowSource)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"processor %s is not an execinfra.RowSource", lookupCore.String(),
+				)
+			}
+			if releasable, ok := rs.(execreleasable.Releasable); ok {
+				r.Releasables = append(r.Releasables, releasable)
+			}
+			return rs, nil
+		},
+		factory,
+		&r.Releasables,
+	)
+	if err != nil {
+		return err
+	}
+	r.ToClose = append(r.ToClose, lookupJoin)
+	r.Releasables = append(r.Releasables, lookupJoin)
+
+	// Plan the hash join strategy. The scan of the lookup index is the probe
+	// side, so the join type is mirrored.
+	accounts := args.MonitorRegistry.CreateUnlimitedMemAccounts(
+		ctx, flowCtx, "adaptive-join-scan" /* opName */, spec.ProcessorID, 2, /* numAccounts */
+	)
+	scan, scanTypes, err := colfetcher.NewColBatchScan(
+		ctx, colmem.NewAllocator(ctx, accounts[0], factory), accounts[1], flowCtx, spec.ProcessorID,
+		&execinfrapb.TableReaderSpec{
+			FetchSpec:         jr.FetchSpec,
+			Spans:             jr.AdaptiveScanSpans,
+			LockingStrength:   jr.LockingStrength,
+			LockingWaitPolicy: jr.LockingWaitPolicy,
+			LockingDurability: jr.LockingDurability,
+			// The scan is not planned according to the range placement, so
+			// there is no point in reporting the misplanned ranges.
+			IgnoreMisplannedRanges: true,
+		},
+		&execinfrapb.PostProcessSpec{}, 0 /* estimatedRowCount */, args.TypeResolver,
+	)
+	if err != nil {
+		return err
+	}
+	r.ToClose = append(r.ToClose, scan)
+	r.Releasables = append(r.Releasables, scan)
+	hjSpec := &execinfrapb.HashJoinerSpec{
+		LeftEqColumns:  fetchedEqCols,
+		RightEqColumns: jr.LookupColumns,
+	}
+	switch jr.Type {
+	case descpb.InnerJoin:
+		hjSpec.Type = descpb.InnerJoin
+	case descpb.LeftOuterJoin:
+		hjSpec.Type = descpb.RightOuterJoin
+	case descpb.LeftSemiJoin:
+		hjSpec.Type = descpb.RightSemiJoin
+	case descpb.LeftAntiJoin:
+		hjSpec.Type = descpb.RightAntiJoin
+	default:
+		return errors.AssertionFailedf("unexpected join type %s of an adaptive join", jr.Type)
+	}
+	hashJoin := r.planHashJoiner(
+		ctx, flowCtx, args, hjSpec, colexecutils.NewCancelChecker(scan), aj.BufferedInput(),
+		scanTypes, inputTypes, factory,
+	)
+	r.ColumnTypes = inputTypes
+	if hjSpec.Type.ShouldIncludeLeftColsInOutput() {
+		// The hash joiner outputs the fetched columns first whereas the join
+		// reader outputs the input columns first.
+		projection := make([]uint32, 0, len(inputTypes)+len(scanTypes))
+		for i := range inputTypes {
+			projection = append(projection, uint32(len(scanTypes)+i))
+		}
+		for i := range scanTypes {
+			projection = append(projection, uint32(i))
+		}
+		hashJoin = colexecbase.NewSimpleProjectOp(hashJoin, len(scanTypes)+len(inputTypes), projection)
+		r.ColumnTypes = make([]*types.T, 0, len(inputTypes)+len(scanTypes))
+		r.ColumnTypes = append(r.ColumnTypes, inputTypes...)
+		r.ColumnTypes = append(r.ColumnTypes, scanTypes...)
+	}
+
+	aj.SetJoins(lookupJoin, hashJoin, scan)
+	r.Root = aj
+	// The AdaptiveJoiner exposes the stats of the wrapped join reader when
+	// that strategy is chosen, so it needs the same handling as a root
+	// Columnarizer.
+	r.Columnarizer = aj
+	r.MetadataSources = append(r.MetadataSources, aj)
+	return nil
+}
+
 func makeNewHashAggregatorArgs(
 	ctx context.Context,
 	flowCtx *execinfra.FlowCtx,
@@ -915,7 +1112,13 @@ func NewColOperator(
 				return r, err
 			}
 			if !core.JoinReader.IsIndexJoin() {
-				return r, errors.AssertionFailedf("lookup join reader is unsupported in vectorized")
+				if core.JoinReader.AdaptiveThreshold == 0 {
+					return r, errors.AssertionFailedf("lookup join reader is unsupported in vectorized")
+				}
+				if err = result.planAdaptiveJoin(ctx, flowCtx, args, inputs, factory); err != nil {
+					return r, err
+				}
+				break
 			}
 			// We have to create a separate account in order for the cFetcher to
 			// be able to precisely track the size of its output batch. This
@@ -1180,50 +1383,10 @@ func NewColOperator(
 				)
 				result.ToClose = append(result.ToClose, result.Root.(colexecop.Closer))
 			} else {
-				opName := redact.RedactableString("hash-joiner")
-				hjArgs, hashJoinerMemMonitorName := makeNewHashJoinerArgs(
-					ctx,
-					flowCtx,
-					args,
-					opName,
-					core.HashJoiner,
-					factory,
+				result.Root = result.planHashJoiner(
+					ctx, flowCtx, args, core.HashJoiner, inputs[0].Root, inputs[1].Root,
+					spec.Input[0].ColumnTypes, spec.Input[1].ColumnTypes, factory,
 				)
-				inMemoryHashJoiner := colexecjoin.NewHashJoiner(hjArgs)
-				if args.TestingKnobs.DiskSpillingDisabled {
-					// We will not be creating a disk-backed hash joiner because
-					// we're running a test that explicitly asked for only
-					// in-memory hash joiner.
-					result.Root = inMemoryHashJoiner
-				} else {
-					opName := redact.RedactableString("external-hash-joiner")
-					diskAccount := args.MonitorRegistry.CreateDiskAccount(ctx, flowCtx, opName, spec.ProcessorID)
-					diskSpiller := colexecdisk.NewTwoInputDiskSpiller(
-						inputs[0].Root, inputs[1].Root, inMemoryHashJoiner.(colexecop.BufferingInMemoryOperator),
-						[]redact.RedactableString{hashJoinerMemMonitorName},
-						func(inputOne, inputTwo colexecop.Operator) colexecop.Operator {
-							accounts := args.MonitorRegistry.CreateUnlimitedMemAccounts(
-								ctx, flowCtx, opName, spec.ProcessorID, 2, /* numAccounts */
-							)
-							unlimitedAllocator := colmem.NewAllocator(ctx, accounts[0], factory)
-							ehj := colexecdisk.NewExternalHashJoiner(
-								unlimitedAllocator,
-								flowCtx,
-								args,
-								hjArgs.Spec,
-								inputOne, inputTwo,
-								result.makeDiskBackedSorterConstructor(ctx, flowCtx, args, opName, factory),
-								diskAccount,
-								accounts[1],
-							)
-							result.ToClose = append(result.ToClose, ehj)
-							return ehj
-						},
-						args.TestingKnobs.SpillingCallbackFn,
-					)
-					result.Root = diskSpiller
-					result.ToClose = append(result.ToClose, diskSpiller)
-				}
 			}
 
 			result.ColumnTypes = core.HashJoiner.Type.MakeOutputTypes(spec.Input[0].ColumnTypes, spec.Input[1].ColumnTypes)
@@ -1290,7 +1453,8 @@ func NewColOperator(
 			hjSpec, aggSpec := &hgjSpec.HashJoinerSpec, &hgjSpec.AggregatorSpec
 			opName := redact.RedactableString("hash-group-joiner")
 			hjArgs, hashJoinerMemMonitorName := makeNewHashJoinerArgs(
-				ctx, flowCtx, args, opName, hjSpec, factory,
+				ctx, flowCtx, args, opName, hjSpec, inputs[0].Root, inputs[1].Root,
+				spec.Input[0].ColumnTypes, spec.Input[1].ColumnTypes, factory,
 			)
 			hjOutputTypes := hjSpec.Type.MakeOutputTypes(spec.Input[0].ColumnTypes, spec.Input[1].ColumnTypes)
 			joinOutputTypes := hjOutputTypes
@@ -1619,8 +1783,31 @@ func NewColOperator(
 						spec.ProcessorID, factory, true, /* needsBuffer */
 					)
 					aggType := *wf.Func.AggregateFunc
-					switch *wf.Func.AggregateFunc {
-					case execinfrapb.CountRows:
+					switch {
+					case wf.FilterColIdx != tree.NoColumnIdx || !colexecagg.IsAggOptimized(aggType):
+						// Aggregates with a FILTER clause and aggregates without an
+						// optimized implementation use the row-by-row implementation
+						// of the aggregate function.
+						colIdx := make([]uint32, len(argTypes))
+						for i := range colIdx {
+							colIdx[i] = uint32(i)
+						}
+						aggregations := []execinfrapb.AggregatorSpec_Aggregation{{
+							Func:   aggType,
+							ColIdx: colIdx,
+						}}
+						constructors, constArgs, outputTypes, err := colexecagg.ProcessAggregations(
+							ctx, flowCtx.EvalCtx, args.ExprHelper.SemaCtx, aggregations, argTypes,
+						)
+						if err != nil {
+							return r, err
+						}
+						result.Root = colexecwindow.NewDefaultWindowAggregatorOperator(
+							windowArgs, wf.Frame, &wf.Ordering, argIdxs, int(wf.FilterColIdx),
+							outputTypes[0], constructors[0], constArgs[0],
+						)
+						returnType = outputTypes[0]
+					case aggType == execinfrapb.CountRows:
 						// count_rows has a specialized implementation.
 						result.Root = colexecwindow.NewCountRowsOperator(windowArgs, wf.Frame, &wf.Ordering)
 					default:
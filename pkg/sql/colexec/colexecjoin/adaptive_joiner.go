@@ -0,0 +1,287 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexecjoin
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execopnode"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// AdaptiveJoiner is an operator that performs a lookup join whose strategy is
+// chosen at runtime. It buffers up to a threshold number of tuples from its
+// input (the build side of the join); if the input is exhausted before that,
+// the join is performed by looking up every input tuple in the index. If the
+// input turns out to be larger, issuing that many lookups is likely to be
+// more expensive than scanning the whole index once, so a hash join that is
+// built on the input and probed with a full scan of the index is performed
+// instead.
+//
+// Both joins consume BufferedInput() which emits the buffered tuples before
+// the rest of the input, and only the chosen join is ever initialized.
+type AdaptiveJoiner struct {
+	colexecop.InitHelper
+
+	input       *adaptiveJoinInput
+	componentID execinfrapb.ComponentID
+
+	lookupJoin AdaptiveLookupJoin
+	hashJoin   colexecop.Operator
+	scan       AdaptiveJoinScan
+
+	// strategy is the strategy that was chosen on the first call to Next, and
+	// chosen is the corresponding join operator.
+	strategy execinfrapb.AdaptiveJoinStats_Strategy
+	chosen   colexecop.Operator
+}
+
+// AdaptiveLookupJoin is the operator that performs the lookup join strategy of
+// an AdaptiveJoiner (usually a Columnarizer wrapping a row-execution join
+// reader).
+type AdaptiveLookupJoin interface {
+	colexecop.VectorizedStatsCollector
+	colexecop.MetadataSource
+}
+
+// AdaptiveJoinScan is the full scan of the index that probes the hash table in
+// the hash join strategy of an AdaptiveJoiner.
+type AdaptiveJoinScan interface {
+	colexecop.KVReader
+	colexecop.MetadataSource
+}
+
+var _ colexecop.Operator = &AdaptiveJoiner{}
+var _ colexecop.MetadataSource = &AdaptiveJoiner{}
+var _ colexecop.VectorizedStatsCollector = &AdaptiveJoiner{}
+
+// NewAdaptiveJoiner returns a new AdaptiveJoiner that chooses the hash join
+// strategy if input has more than threshold tuples. The join operators must be
+// set with SetJoins before the AdaptiveJoiner is initialized.
+// - allocator must use a memory account that is not shared with any other
+// user.
+// - componentID is the ID of the join reader processor that the AdaptiveJoiner
+// is planned for.
+func NewAdaptiveJoiner(
+	allocator *colmem.Allocator,
+	input colexecop.Operator,
+	inputTypes []*types.T,
+	threshold uint64,
+	componentID execinfrapb.ComponentID,
+) *AdaptiveJoiner {
+	return &AdaptiveJoiner{
+		input: &adaptiveJoinInput{
+			OneInputHelper: colexecop.OneInputHelper{OneInputNode: colexecop.NewOneInputNode(input)},
+			allocator:      allocator,
+			inputTypes:     inputTypes,
+			maxBuffered:    int(threshold) + 1,
+		},
+		componentID: componentID,
+	}
+}
+
+// BufferedInput returns the operator that must be used as the input to both
+// joins passed to SetJoins. It emits all tuples from the input of the
+// AdaptiveJoiner, starting with those that were buffered.
+func (j *AdaptiveJoiner) BufferedInput() colexecop.Operator {
+	return j.input
+}
+
+// SetJoins sets the operators performing each of the join strategies.
+// - lookupJoin must output the input columns followed by the fetched columns
+// of the index (only the input columns for semi and anti joins).
+// - hashJoin must produce the same columns as lookupJoin. It consumes the
+// tuples from scan.
+func (j *AdaptiveJoiner) SetJoins(
+	lookupJoin AdaptiveLookupJoin, hashJoin colexecop.Operator, scan AdaptiveJoinScan,
+) {
+	j.lookupJoin = lookupJoin
+	j.hashJoin = hashJoin
+	j.scan = scan
+}
+
+// Init is part of the colexecop.Operator interface.
+func (j *AdaptiveJoiner) Init(ctx context.Context) {
+	if !j.InitHelper.Init(ctx) {
+		return
+	}
+	if j.lookupJoin == nil || j.hashJoin == nil || j.scan == nil {
+		colexecerror.InternalError(errors.AssertionFailedf("joins of the adaptive joiner are not set"))
+	}
+	j.input.Init(j.Ctx)
+}
+
+// Next is part of the colexecop.Operator interface.
+func (j *AdaptiveJoiner) Next() coldata.Batch {
+	if j.chosen == nil {
+		if j.input.buffer() {
+			j.strategy, j.chosen = execinfrapb.AdaptiveJoinStats_HASH_JOIN, j.hashJoin
+		} else {
+			j.strategy, j.chosen = execinfrapb.AdaptiveJoinStats_LOOKUP_JOIN, j.lookupJoin
+		}
+		log.VEventf(j.Ctx, 2, "adaptive joiner chose %s after buffering %d tuples",
+			j.strategy, j.input.numBuffered)
+		j.chosen.Init(j.Ctx)
+	}
+	return j.chosen.Next()
+}
+
+// DrainMeta is part of the colexecop.MetadataSource interface. Only the chosen
+// join is drained since the other one was never initialized.
+func (j *AdaptiveJoiner) DrainMeta() []execinfrapb.ProducerMetadata {
+	switch j.strategy {
+	case execinfrapb.AdaptiveJoinStats_LOOKUP_JOIN:
+		return j.lookupJoin.DrainMeta()
+	case execinfrapb.AdaptiveJoinStats_HASH_JOIN:
+		return j.scan.DrainMeta()
+	}
+	return nil
+}
+
+// GetStats is part of the colexecop.VectorizedStatsCollector interface. It
+// returns the statistics of the chosen join along with the decision that was
+// made.
+func (j *AdaptiveJoiner) GetStats() *execinfrapb.ComponentStats {
+	var s *execinfrapb.ComponentStats
+	switch j.strategy {
+	case execinfrapb.AdaptiveJoinStats_LOOKUP_JOIN:
+		s = j.lookupJoin.GetStats()
+	case execinfrapb.AdaptiveJoinStats_HASH_JOIN:
+		s = &execinfrapb.ComponentStats{Component: j.componentID}
+		s.KV.BytesRead.Set(uint64(j.scan.GetBytesRead()))
+		s.KV.KVPairsRead.Set(uint64(j.scan.GetKVPairsRead()))
+		s.KV.TuplesRead.Set(uint64(j.scan.GetRowsRead()))
+		s.KV.BatchRequestsIssued.Set(uint64(j.scan.GetBatchRequestsIssued()))
+		s.KV.ContentionTime.Set(j.scan.GetContentionTime())
+		scanStats := j.scan.GetScanStats()
+		execstats.PopulateKVMVCCStats(&s.KV, &scanStats)
+		s.Exec.ConsumedRU.Set(j.scan.GetConsumedRU())
+		// The KV CPU time is subtracted from the CPU time of the operator by
+		// the stats collector.
+		s.KV.KVCPUTime.Set(j.scan.GetKVCPUTime())
+	default:
+		return &execinfrapb.ComponentStats{Component: j.componentID}
+	}
+	s.AdaptiveJoin.Strategy = j.strategy
+	s.AdaptiveJoin.BufferedRows.Set(uint64(j.input.numBuffered))
+	return s
+}
+
+// ChildCount is part of the execopnode.OpNode interface.
+func (j *AdaptiveJoiner) ChildCount(verbose bool) int {
+	return 2
+}
+
+// Child is part of the execopnode.OpNode interface.
+func (j *AdaptiveJoiner) Child(nth int, verbose bool) execopnode.OpNode {
+	switch nth {
+	case 0:
+		return j.lookupJoin
+	case 1:
+		return j.hashJoin
+	}
+	colexecerror.InternalError(errors.AssertionFailedf("invalid index %d", nth))
+	// This code is unreachable, but the compiler cannot infer that.
+	return nil
+}
+
+// adaptiveJoinInput is the input to both joins of an AdaptiveJoiner. It
+// buffers up to maxBuffered tuples when buffer is called, and then emits the
+// buffered tuples followed by the remaining tuples of its input.
+type adaptiveJoinInput struct {
+	colexecop.OneInputHelper
+
+	allocator   *colmem.Allocator
+	inputTypes  []*types.T
+	maxBuffered int
+
+	buffered *colexecutils.AppendOnlyBufferedBatch
+	// numBuffered is the number of tuples that were buffered. Unlike the
+	// length of buffered, it is kept after the buffer is released.
+	numBuffered int
+	// leftover is the last batch read from the input while buffering, of which
+	// only the tuples before leftoverIdx were buffered. It is nil if all the
+	// tuples of that batch were buffered.
+	leftover    coldata.Batch
+	leftoverIdx int
+	// inputDone is true if the input was exhausted while buffering.
+	inputDone bool
+	// emitted is the number of buffered tuples that have been emitted.
+	emitted int
+	window  coldata.Batch
+}
+
+var _ colexecop.Operator = &adaptiveJoinInput{}
+
+// buffer reads tuples from the input until maxBuffered tuples are buffered or
+// the input is exhausted, and returns whether maxBuffered tuples were
+// buffered.
+func (i *adaptiveJoinInput) buffer() bool {
+	i.buffered = colexecutils.NewAppendOnlyBufferedBatch(i.allocator, i.inputTypes, nil /* colsToStore */)
+	i.window = i.allocator.NewMemBatchNoCols(i.inputTypes, coldata.BatchSize())
+	for i.buffered.Length() < i.maxBuffered {
+		batch := i.Input.Next()
+		n := batch.Length()
+		if n == 0 {
+			i.inputDone = true
+			break
+		}
+		if toBuffer := i.maxBuffered - i.buffered.Length(); toBuffer < n {
+			// Only buffer as many tuples as needed so that the decision (as well
+			// as the number of buffered tuples that is reported) doesn't depend
+			// on the batch sizes.
+			i.leftover, i.leftoverIdx = batch, toBuffer
+			n = toBuffer
+		}
+		i.buffered.AppendTuples(batch, 0 /* startIdx */, n)
+	}
+	i.numBuffered = i.buffered.Length()
+	return i.numBuffered >= i.maxBuffered
+}
+
+// Next is part of the colexecop.Operator interface.
+func (i *adaptiveJoinInput) Next() coldata.Batch {
+	if i.buffered != nil {
+		if i.emitted < i.buffered.Length() {
+			end := i.emitted + coldata.BatchSize()
+			if end > i.buffered.Length() {
+				end = i.buffered.Length()
+			}
+			colexecutils.MakeWindowIntoBatch(i.window, i.buffered, i.emitted, end, i.inputTypes)
+			i.emitted = end
+			return i.window
+		}
+		// All buffered tuples have been emitted and are no longer referenced by
+		// the consumer, so the memory can be released.
+		i.buffered = nil
+		i.allocator.ReleaseAll()
+	}
+	if i.leftover != nil {
+		colexecutils.MakeWindowIntoBatch(
+			i.window, i.leftover, i.leftoverIdx, i.leftover.Length(), i.inputTypes,
+		)
+		i.leftover = nil
+		return i.window
+	}
+	if i.inputDone {
+		return coldata.ZeroBatch
+	}
+	return i.Input.Next()
+}
@@ -0,0 +1,125 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexecjoin
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexectestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/stretchr/testify/require"
+)
+
+// testAdaptiveLookupJoin is a lookup join for the AdaptiveJoiner that simply
+// passes its input through.
+type testAdaptiveLookupJoin struct {
+	colexecop.Operator
+}
+
+func (testAdaptiveLookupJoin) GetStats() *execinfrapb.ComponentStats {
+	return &execinfrapb.ComponentStats{}
+}
+
+func (testAdaptiveLookupJoin) DrainMeta() []execinfrapb.ProducerMetadata {
+	return nil
+}
+
+// testAdaptiveJoinScan is a scan for the AdaptiveJoiner that doesn't read
+// anything.
+type testAdaptiveJoinScan struct{}
+
+func (testAdaptiveJoinScan) GetBytesRead() int64                       { return 0 }
+func (testAdaptiveJoinScan) GetKVPairsRead() int64                     { return 0 }
+func (testAdaptiveJoinScan) GetRowsRead() int64                        { return 0 }
+func (testAdaptiveJoinScan) GetBatchRequestsIssued() int64             { return 0 }
+func (testAdaptiveJoinScan) GetContentionTime() time.Duration          { return 0 }
+func (testAdaptiveJoinScan) GetScanStats() execstats.ScanStats         { return execstats.ScanStats{} }
+func (testAdaptiveJoinScan) GetConsumedRU() uint64                     { return 0 }
+func (testAdaptiveJoinScan) GetKVCPUTime() time.Duration               { return 0 }
+func (testAdaptiveJoinScan) UsedStreamer() bool                        { return false }
+func (testAdaptiveJoinScan) DrainMeta() []execinfrapb.ProducerMetadata { return nil }
+
+func TestAdaptiveJoiner(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	typs := []*types.T{types.Int}
+	const numTuples = 10
+	var tups colexectestutils.Tuples
+	for i := 0; i < numTuples; i++ {
+		tups = append(tups, colexectestutils.Tuple{i})
+	}
+
+	var accounts []*mon.BoundAccount
+	defer func() {
+		for _, acc := range accounts {
+			acc.Close(ctx)
+		}
+	}()
+	newAdaptiveJoiner := func(input colexecop.Operator, threshold uint64) *AdaptiveJoiner {
+		acc := testMemMonitor.MakeBoundAccount()
+		accounts = append(accounts, &acc)
+		allocator := colmem.NewAllocator(ctx, &acc, testColumnFactory)
+		aj := NewAdaptiveJoiner(allocator, input, typs, threshold, execinfrapb.ComponentID{})
+		// Both joins pass the buffered input through, so the output must be the
+		// same as the input regardless of the chosen strategy.
+		aj.SetJoins(
+			testAdaptiveLookupJoin{Operator: colexecop.NewNoop(aj.BufferedInput())},
+			colexecop.NewNoop(aj.BufferedInput()),
+			testAdaptiveJoinScan{},
+		)
+		return aj
+	}
+
+	for _, tc := range []struct {
+		threshold   uint64
+		expStrategy execinfrapb.AdaptiveJoinStats_Strategy
+		expBuffered uint64
+	}{
+		{threshold: 0, expStrategy: execinfrapb.AdaptiveJoinStats_HASH_JOIN, expBuffered: 1},
+		{threshold: 5, expStrategy: execinfrapb.AdaptiveJoinStats_HASH_JOIN, expBuffered: 6},
+		{threshold: numTuples - 1, expStrategy: execinfrapb.AdaptiveJoinStats_HASH_JOIN, expBuffered: numTuples},
+		{threshold: numTuples, expStrategy: execinfrapb.AdaptiveJoinStats_LOOKUP_JOIN, expBuffered: numTuples},
+		{threshold: 100, expStrategy: execinfrapb.AdaptiveJoinStats_LOOKUP_JOIN, expBuffered: numTuples},
+	} {
+		t.Run(fmt.Sprintf("threshold=%d", tc.threshold), func(t *testing.T) {
+			colexectestutils.RunTests(
+				t, testAllocator, []colexectestutils.Tuples{tups}, tups, colexectestutils.OrderedVerifier,
+				func(inputs []colexecop.Operator) (colexecop.Operator, error) {
+					return newAdaptiveJoiner(inputs[0], tc.threshold), nil
+				},
+			)
+
+			// Check the decision that is reported in the stats.
+			aj := newAdaptiveJoiner(
+				colexectestutils.NewOpTestInput(testAllocator, coldata.BatchSize(), tups, typs), tc.threshold,
+			)
+			aj.Init(ctx)
+			for b := aj.Next(); b.Length() != 0; b = aj.Next() {
+			}
+			s := aj.GetStats()
+			require.Equal(t, tc.expStrategy, s.AdaptiveJoin.Strategy)
+			require.Equal(t, tc.expBuffered, s.AdaptiveJoin.BufferedRows.Value())
+		})
+	}
+}
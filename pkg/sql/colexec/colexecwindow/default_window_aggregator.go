@@ -0,0 +1,301 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexecwindow
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colconv"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execagg"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// NewDefaultWindowAggregatorOperator creates a new Operator that computes an
+// aggregate window function using the row-by-row implementation of the
+// aggregate (eval.AggregateFunc). It is used for aggregates that don't have an
+// optimized vectorized implementation as well as for aggregates with a FILTER
+// clause. filterColIdx is the index of the boolean FILTER column, or
+// tree.NoColumnIdx if there is no FILTER clause.
+//
+// Like the other buffered window operators, the operator buffers each
+// partition in a SpillingBuffer, so it supports all frame modes and exclusion
+// clauses and spills to disk when the partition doesn't fit in memory.
+func NewDefaultWindowAggregatorOperator(
+	args *WindowArgs,
+	frame *execinfrapb.WindowerSpec_Frame,
+	ordering *execinfrapb.Ordering,
+	argIdxs []int,
+	filterColIdx int,
+	outputType *types.T,
+	constructor execagg.AggregateConstructor,
+	constArgs tree.Datums,
+) colexecop.ClosableOperator {
+	// Because the buffer is used multiple times per-row, it is important to
+	// prevent it from spilling to disk if possible. For this reason, we give the
+	// buffer half of the memory budget even though it will generally store less
+	// columns than the queue.
+	bufferMemLimit := int64(float64(args.MemoryLimit) * 0.5)
+	mainMemLimit := args.MemoryLimit - bufferMemLimit
+	framer := newWindowFramer(args.EvalCtx, frame, ordering, args.InputTypes, args.PeersColIdx)
+	colsToStore := append([]int{}, argIdxs...)
+	if filterColIdx != tree.NoColumnIdx {
+		colsToStore = append(colsToStore, filterColIdx)
+	}
+	colsToStore = framer.getColsToStore(colsToStore)
+	buffer := colexecutils.NewSpillingBuffer(
+		args.BufferAllocator, bufferMemLimit, args.QueueCfg, args.FdSemaphore,
+		args.InputTypes, args.DiskAcc, args.ConverterMemAcc, colsToStore...,
+	)
+	// The arg columns are stored first in the buffer, followed by the filter
+	// column.
+	filterIdx := -1
+	if filterColIdx != tree.NoColumnIdx {
+		filterIdx = len(argIdxs)
+	}
+	fn := constructor(args.EvalCtx, constArgs)
+	args.MainAllocator.AdjustMemoryUsage(fn.Size())
+	a := &defaultWindowAggregator{
+		partitionSeekerBase: partitionSeekerBase{
+			partitionColIdx: args.PartitionColIdx,
+			buffer:          buffer,
+		},
+		allocator:       args.MainAllocator,
+		outputColIdx:    args.OutputColIdx,
+		numArgs:         len(argIdxs),
+		filterIdx:       filterIdx,
+		framer:          framer,
+		canShrink:       WindowFrameCanShrink(frame, ordering),
+		fn:              fn,
+		resultConverter: colconv.GetDatumToPhysicalFn(outputType),
+		converted:       make([]tree.Datums, len(argIdxs)),
+	}
+	if len(argIdxs) > 1 {
+		a.otherArgs = make(tree.Datums, len(argIdxs)-1)
+	}
+	return newBufferedWindowOperator(args, a, outputType, mainMemLimit)
+}
+
+// defaultWindowAggregator computes an aggregate window function by converting
+// the rows in the window frame to tree.Datums and adding them to an
+// eval.AggregateFunc.
+type defaultWindowAggregator struct {
+	partitionSeekerBase
+	colexecop.CloserHelper
+	allocator     *colmem.Allocator
+	cancelChecker colexecutils.CancelChecker
+
+	outputColIdx int
+	// numArgs is the number of arguments to the aggregate function. The
+	// arguments are stored in the first numArgs columns of the buffer.
+	numArgs int
+	// filterIdx is the index of the FILTER column in the buffer, or -1 if there
+	// is no FILTER clause.
+	filterIdx int
+	framer    windowFramer
+	// canShrink is true if the window frame for a row may not include all rows
+	// from the frame of the previous row. In this case the aggregate has to be
+	// recomputed from scratch whenever the frame changes, since an
+	// eval.AggregateFunc doesn't support removing rows.
+	canShrink bool
+
+	fn              eval.AggregateFunc
+	resultConverter func(tree.Datum) interface{}
+
+	// prevIntervals and prevResult store the frame (only when canShrink is
+	// true) and the result of the previous row. Consecutive rows frequently
+	// have the same frame (for example, peers in RANGE and GROUPS mode), in
+	// which case the previous result is reused.
+	prevIntervals []windowInterval
+	prevResult    tree.Datum
+	hasPrevResult bool
+
+	da        tree.DatumAlloc
+	converted []tree.Datums
+	otherArgs tree.Datums
+	sel       []int
+}
+
+var _ bufferedWindower = &defaultWindowAggregator{}
+
+// transitionToProcessing implements the bufferedWindower interface.
+func (a *defaultWindowAggregator) transitionToProcessing() {
+	a.framer.startPartition(a.Ctx, a.partitionSize, a.buffer)
+}
+
+// startNewPartition implements the bufferedWindower interface.
+func (a *defaultWindowAggregator) startNewPartition() {
+	a.partitionSize = 0
+	a.buffer.Reset(a.Ctx)
+	a.fn.Reset(a.Ctx)
+	a.prevIntervals = a.prevIntervals[:0]
+	a.prevResult = nil
+	a.hasPrevResult = false
+}
+
+// Init implements the bufferedWindower interface.
+func (a *defaultWindowAggregator) Init(ctx context.Context) {
+	a.InitHelper.Init(ctx)
+	a.cancelChecker.Init(a.Ctx)
+}
+
+// Close implements the bufferedWindower interface.
+func (a *defaultWindowAggregator) Close(ctx context.Context) {
+	if !a.CloserHelper.Close() {
+		return
+	}
+	a.framer.close()
+	a.buffer.Close(ctx)
+	a.fn.Close(ctx)
+	*a = defaultWindowAggregator{}
+}
+
+// processBatch implements the bufferedWindower interface.
+func (a *defaultWindowAggregator) processBatch(batch coldata.Batch, startIdx, endIdx int) {
+	outVec := batch.ColVec(a.outputColIdx)
+	a.allocator.PerformOperation([]coldata.Vec{outVec}, func() {
+		for i := startIdx; i < endIdx; i++ {
+			a.framer.next(a.Ctx)
+			var res tree.Datum
+			if a.canShrink {
+				intervals := a.framer.frameIntervals()
+				if a.hasPrevResult && intervalsEqual(intervals, a.prevIntervals) {
+					res = a.prevResult
+				} else {
+					a.fn.Reset(a.Ctx)
+					a.addIntervals(intervals)
+					res = a.result()
+					a.prevIntervals = append(a.prevIntervals[:0], intervals...)
+					a.prevResult = res
+					a.hasPrevResult = true
+				}
+			} else {
+				// The frame can only grow, so only the new rows need to be added.
+				// If there are none, the frame is the same as for the previous
+				// row.
+				toAdd, _ := a.framer.slidingWindowIntervals()
+				if a.hasPrevResult && len(toAdd) == 0 {
+					res = a.prevResult
+				} else {
+					a.addIntervals(toAdd)
+					res = a.result()
+					a.prevResult = res
+					a.hasPrevResult = true
+				}
+			}
+			if res == tree.DNull {
+				outVec.Nulls().SetNull(i)
+			} else {
+				coldata.SetValueAt(outVec, a.resultConverter(res), i)
+			}
+		}
+	})
+}
+
+// result returns the current result of the aggregate function.
+func (a *defaultWindowAggregator) result() tree.Datum {
+	res, err := a.fn.Result()
+	if err != nil {
+		colexecerror.ExpectedError(err)
+	}
+	return res
+}
+
+// addIntervals adds the rows in the given intervals to the aggregate function,
+// skipping the rows that don't pass the FILTER clause.
+func (a *defaultWindowAggregator) addIntervals(intervals []windowInterval) {
+	for _, interval := range intervals {
+		// intervalIdx maintains the index up to which the current interval has
+		// already been processed.
+		intervalIdx := interval.start
+		intervalLen := interval.end - interval.start
+		for intervalLen > 0 {
+			a.cancelChecker.Check()
+			var vec coldata.Vec
+			var start, end int
+			if a.filterIdx >= 0 {
+				vec, start, end = a.buffer.GetVecWithTuple(a.Ctx, a.filterIdx, intervalIdx)
+			} else {
+				// There is at least one argument, or else the aggregate would
+				// be count_rows, which has an optimized implementation.
+				vec, start, end = a.buffer.GetVecWithTuple(a.Ctx, 0 /* colIdx */, intervalIdx)
+			}
+			if intervalLen < (end - start) {
+				// This is the last batch in the current interval.
+				end = start + intervalLen
+			}
+			// tupleIdx is the position of the first tuple of this batch within
+			// the partition; the buffer is indexed by it, not by start.
+			tupleIdx := intervalIdx
+			intervalIdx += end - start
+			intervalLen -= end - start
+			a.sel = a.sel[:0]
+			if a.filterIdx >= 0 {
+				nulls, filter := vec.Nulls(), vec.Bool()
+				for j := start; j < end; j++ {
+					if !nulls.NullAt(j) && filter[j] {
+						a.sel = append(a.sel, j)
+					}
+				}
+			} else {
+				for j := start; j < end; j++ {
+					a.sel = append(a.sel, j)
+				}
+			}
+			if len(a.sel) == 0 {
+				continue
+			}
+			// The argument vectors of a tuple are stored in the same batch as the
+			// filter vector, so they share the same [start, end) range.
+			for j := range a.converted {
+				argVec, _, _ := a.buffer.GetVecWithTuple(a.Ctx, j, tupleIdx)
+				if cap(a.converted[j]) < end {
+					a.converted[j] = make(tree.Datums, end)
+				}
+				a.converted[j] = a.converted[j][:end]
+				colconv.ColVecToDatum(a.converted[j], argVec, len(a.sel), a.sel, &a.da)
+			}
+			for _, j := range a.sel {
+				// COUNT_ROWS takes no arguments.
+				var firstArg tree.Datum
+				if a.numArgs > 0 {
+					firstArg = a.converted[0][j]
+					for k := range a.otherArgs {
+						a.otherArgs[k] = a.converted[k+1][j]
+					}
+				}
+				if err := a.fn.Add(a.Ctx, firstArg, a.otherArgs...); err != nil {
+					colexecerror.ExpectedError(err)
+				}
+			}
+		}
+	}
+}
+
+// intervalsEqual returns whether the two given sets of intervals are the same.
+func intervalsEqual(a, b []windowInterval) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -39,9 +39,15 @@ type windowFnTestCase struct {
 	tuples       []colexectestutils.Tuple
 	expected     []colexectestutils.Tuple
 	windowerSpec execinfrapb.WindowerSpec
+	// hasFilter indicates that FilterColIdx of the window functions is set and
+	// refers to a boolean column.
+	hasFilter bool
 }
 
 func (tc *windowFnTestCase) init() {
+	if tc.hasFilter {
+		return
+	}
 	for i := range tc.windowerSpec.WindowFns {
 		tc.windowerSpec.WindowFns[i].FilterColIdx = tree.NoColumnIdx
 	}
@@ -93,6 +99,42 @@ func TestWindowFunctions(t *testing.T) {
 	countFn := execinfrapb.AggregatorSpec_COUNT
 	avgFn := execinfrapb.AggregatorSpec_AVG
 	maxFn := execinfrapb.AggregatorSpec_MAX
+	// bit_or doesn't have an optimized implementation.
+	bitOrFn := execinfrapb.AggregatorSpec_BIT_OR
+	countRowsFn := execinfrapb.AggregatorSpec_COUNT_ROWS
+	rowsOnePreceding := &execinfrapb.WindowerSpec_Frame{
+		Mode: execinfrapb.WindowerSpec_Frame_ROWS,
+		Bounds: execinfrapb.WindowerSpec_Frame_Bounds{
+			Start: execinfrapb.WindowerSpec_Frame_Bound{
+				BoundType: execinfrapb.WindowerSpec_Frame_OFFSET_PRECEDING,
+				IntOffset: 1,
+			},
+			End: &execinfrapb.WindowerSpec_Frame_Bound{
+				BoundType: execinfrapb.WindowerSpec_Frame_CURRENT_ROW,
+			},
+		},
+	}
+
+	// bigTuples is a single partition spanning several batches, so that the
+	// aggregates have to read the buffered tuples back from more than one batch
+	// once the partition spills to disk.
+	nBigRows := 2*coldata.BatchSize() + 3
+	bigTuples := make(colexectestutils.Tuples, nBigRows)
+	bigExpected := make(colexectestutils.Tuples, nBigRows)
+	for i := range bigTuples {
+		passesFilter := i%3 != 0
+		bigTuples[i] = colexectestutils.Tuple{i, passesFilter}
+		var res interface{}
+		for j := i - 1; j <= i; j++ {
+			if j >= 0 && j%3 != 0 {
+				if res == nil {
+					res = 0
+				}
+				res = res.(int) | j
+			}
+		}
+		bigExpected[i] = colexectestutils.Tuple{i, passesFilter, res}
+	}
 
 	for _, spillForced := range []bool{false, true} {
 		flowCtx.Cfg.TestingKnobs.ForceDiskSpill = spillForced
@@ -1036,6 +1078,102 @@ func TestWindowFunctions(t *testing.T) {
 					},
 				},
 			},
+			// Aggregates without an optimized implementation.
+			{
+				tuples:   colexectestutils.Tuples{{1}, {2}, {nil}, {4}},
+				expected: colexectestutils.Tuples{{1, 7}, {2, 7}, {nil, 7}, {4, 7}},
+				windowerSpec: execinfrapb.WindowerSpec{
+					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
+						{
+							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &bitOrFn},
+							ArgsIdxs:     []uint32{0},
+							OutputColIdx: 1,
+						},
+					},
+				},
+			},
+			{
+				tuples:   colexectestutils.Tuples{{4}, {1}, {2}, {4}, {nil}},
+				expected: colexectestutils.Tuples{{nil, nil}, {1, 1}, {2, 3}, {4, 7}, {4, 7}},
+				windowerSpec: execinfrapb.WindowerSpec{
+					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
+						{
+							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &bitOrFn},
+							ArgsIdxs:     []uint32{0},
+							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							OutputColIdx: 1,
+						},
+					},
+				},
+			},
+			{
+				tuples:   colexectestutils.Tuples{{4}, {1}, {2}, {8}, {nil}},
+				expected: colexectestutils.Tuples{{nil, nil}, {1, 1}, {2, 3}, {4, 6}, {8, 12}},
+				windowerSpec: execinfrapb.WindowerSpec{
+					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
+						{
+							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &bitOrFn},
+							ArgsIdxs:     []uint32{0},
+							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							Frame:        rowsOnePreceding,
+							OutputColIdx: 1,
+						},
+					},
+				},
+			},
+			// Aggregates with a FILTER clause.
+			{
+				tuples: colexectestutils.Tuples{{1, true}, {2, false}, {3, nil}, {4, true}},
+				expected: colexectestutils.Tuples{
+					{1, true, dec("5")}, {2, false, dec("5")}, {3, nil, dec("5")}, {4, true, dec("5")},
+				},
+				windowerSpec: execinfrapb.WindowerSpec{
+					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
+						{
+							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &sumFn},
+							ArgsIdxs:     []uint32{0},
+							FilterColIdx: 1,
+							OutputColIdx: 2,
+						},
+					},
+				},
+				hasFilter: true,
+			},
+			{
+				tuples: colexectestutils.Tuples{{1, true}, {2, false}, {3, true}, {4, true}},
+				expected: colexectestutils.Tuples{
+					{1, true, 1}, {2, false, 1}, {3, true, 1}, {4, true, 2},
+				},
+				windowerSpec: execinfrapb.WindowerSpec{
+					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
+						{
+							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &countRowsFn},
+							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							Frame:        rowsOnePreceding,
+							FilterColIdx: 1,
+							OutputColIdx: 2,
+						},
+					},
+				},
+				hasFilter: true,
+			},
+			{
+				tuples:   bigTuples,
+				expected: bigExpected,
+				windowerSpec: execinfrapb.WindowerSpec{
+					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
+						{
+							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &bitOrFn},
+							ArgsIdxs:     []uint32{0},
+							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							Frame:        rowsOnePreceding,
+							FilterColIdx: 1,
+							OutputColIdx: 2,
+						},
+					},
+				},
+				hasFilter: true,
+			},
 		} {
 			log.Infof(ctx, "spillForced=%t/%s", spillForced, tc.windowerSpec.WindowFns[0].Func.String())
 			var toClose []colexecop.Closers
@@ -1046,6 +1184,9 @@ func TestWindowFunctions(t *testing.T) {
 				for i := range ct {
 					ct[i] = types.Int
 				}
+				if tc.hasFilter {
+					ct[tc.windowerSpec.WindowFns[0].FilterColIdx] = types.Bool
+				}
 				resultType := types.Int
 				fun := tc.windowerSpec.WindowFns[0].Func
 				if fun.WindowFunc != nil {
@@ -1966,7 +1966,7 @@ func (ex *connExecutor) dispatchToExecutionEngine(
 	}
 	distributePlan := getPlanDistribution(
 		ctx, planner.Descriptors().HasUncommittedTypes(),
-		distSQLMode, planner.curPlan.main,
+		distSQLMode, planner.curPlan.mainDistributionCosts, planner.curPlan.main,
 	)
 	ex.sessionTracing.TracePlanCheckEnd(ctx, nil, distributePlan.WillDistribute())
 
@@ -2017,9 +2017,16 @@ func (ex *connExecutor) dispatchToExecutionEngine(
 		distribute = FullDistribution
 	}
 	ex.sessionTracing.TraceExecStart(ctx, "distributed")
-	stats, err = ex.execWithDistSQLEngine(
-		ctx, planner, stmt.AST.StatementReturnType(), res, distribute, progAtomic,
-	)
+	execFn := func(res RestrictedCommandResult) (topLevelQueryStats, error) {
+		return ex.execWithDistSQLEngine(
+			ctx, planner, stmt.AST.StatementReturnType(), res, distribute, progAtomic,
+		)
+	}
+	if ex.shouldUseResultCache(planner) {
+		stats, err = ex.execWithResultCache(ctx, planner, res, execFn)
+	} else {
+		stats, err = execFn(res)
+	}
 	if ppInfo := getPausablePortalInfo(); ppInfo != nil {
 		// For pausable portals, we log the stats when closing the portal, so we need
 		// to aggregate the stats for all executions.
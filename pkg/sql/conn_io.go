@@ -981,6 +981,10 @@ type CopyOutResult interface {
 	// SendCopyData adds a COPY data row to the result.
 	SendCopyData(ctx context.Context, copyData []byte, isHeader bool) error
 
+	// SendCopyBinaryRow adds a row, encoded in the binary COPY format, to the
+	// result.
+	SendCopyBinaryRow(ctx context.Context, row tree.Datums, cols colinfo.ResultColumns) error
+
 	// SendCopyDone sends the copy done response to the client.
 	SendCopyDone(ctx context.Context) error
 }
@@ -1236,6 +1240,13 @@ func (r *streamingCommandResult) SendCopyData(
 	return errors.AssertionFailedf("streamingCommandResult does not implement SendCopyData")
 }
 
+// SendCopyBinaryRow is part of the sql.CopyOutResult interface.
+func (r *streamingCommandResult) SendCopyBinaryRow(
+	ctx context.Context, row tree.Datums, cols colinfo.ResultColumns,
+) error {
+	return errors.AssertionFailedf("streamingCommandResult does not implement SendCopyBinaryRow")
+}
+
 // SendCopyDone is part of the pgwirebase.Conn interface.
 func (r *streamingCommandResult) SendCopyDone(ctx context.Context) error {
 	return errors.AssertionFailedf("streamingCommandResult does not implement SendCopyDone")
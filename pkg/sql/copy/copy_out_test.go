@@ -13,7 +13,9 @@ package copy
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -197,4 +199,48 @@ func TestCopyOutRandom(t *testing.T) {
 			}
 		}
 	})
+	t.Run("binary", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err = conn.PgConn().CopyTo(ctx, &buf, "COPY t TO STDOUT BINARY")
+		require.NoError(t, err)
+
+		// The output starts with the signature, flags and header extension
+		// length, and ends with a field count of -1.
+		data := buf.Bytes()
+		const headerLen = 19
+		require.Greater(t, len(data), headerLen)
+		require.Equal(t, "PGCOPY\n\377\r\n\000", string(data[:11]))
+		data = data[headerLen:]
+		readInt := func(n int) int {
+			require.GreaterOrEqual(t, len(data), n)
+			var v int
+			switch n {
+			case 2:
+				v = int(int16(binary.BigEndian.Uint16(data)))
+			case 4:
+				v = int(int32(binary.BigEndian.Uint32(data)))
+			}
+			data = data[n:]
+			return v
+		}
+		for lineNum := range rowOutput {
+			require.Equal(t, len(colTypes), readInt(2))
+			for fieldNum := range colTypes {
+				l := readInt(4)
+				if l == -1 {
+					// NULLs are returned as empty values by the query above.
+					require.Emptyf(t, rowOutput[lineNum][fieldNum],
+						"error line %d, field %d (%s)", lineNum, fieldNum, colTypes[fieldNum].SQLString())
+					continue
+				}
+				require.GreaterOrEqual(t, len(data), l)
+				if fieldNum == 0 {
+					require.Equal(t, string(rowOutput[lineNum][0]), strconv.FormatInt(int64(binary.BigEndian.Uint64(data)), 10))
+				}
+				data = data[l:]
+			}
+		}
+		require.Equal(t, -1, readInt(2))
+		require.Empty(t, data)
+	})
 }
@@ -51,6 +51,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/errors"
 	"github.com/dustin/go-humanize"
+	xencoding "golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
 )
 
 // CopyBatchRowSizeDefault is the number of rows we insert in one insert
@@ -92,12 +94,65 @@ type copyOptions struct {
 	format    tree.CopyFormat
 	null      string
 	encoding  string
+	// textEncoding is the encoding of the data if it is not UTF8. It is only
+	// used by the text and CSV formats.
+	textEncoding *charmap.Charmap
+}
+
+// copyEncodings maps the names of the single-byte encodings supported by the
+// ENCODING option of COPY, normalized by normalizeCopyEncodingName, to their
+// character maps.
+var copyEncodings = map[string]*charmap.Charmap{
+	"LATIN1":   charmap.ISO8859_1,
+	"LATIN2":   charmap.ISO8859_2,
+	"LATIN3":   charmap.ISO8859_3,
+	"LATIN4":   charmap.ISO8859_4,
+	"LATIN5":   charmap.ISO8859_9,
+	"LATIN6":   charmap.ISO8859_10,
+	"LATIN7":   charmap.ISO8859_13,
+	"LATIN8":   charmap.ISO8859_14,
+	"LATIN9":   charmap.ISO8859_15,
+	"LATIN10":  charmap.ISO8859_16,
+	"ISO88591": charmap.ISO8859_1,
+	"ISO88595": charmap.ISO8859_5,
+	"ISO88596": charmap.ISO8859_6,
+	"ISO88597": charmap.ISO8859_7,
+	"ISO88598": charmap.ISO8859_8,
+	"WIN866":   charmap.CodePage866,
+	"WIN874":   charmap.Windows874,
+	"WIN1250":  charmap.Windows1250,
+	"WIN1251":  charmap.Windows1251,
+	"WIN1252":  charmap.Windows1252,
+	"WIN1253":  charmap.Windows1253,
+	"WIN1254":  charmap.Windows1254,
+	"WIN1255":  charmap.Windows1255,
+	"WIN1256":  charmap.Windows1256,
+	"WIN1257":  charmap.Windows1257,
+	"WIN1258":  charmap.Windows1258,
+	"KOI8R":    charmap.KOI8R,
+	"KOI8U":    charmap.KOI8U,
+}
+
+// normalizeCopyEncodingName normalizes an encoding name the way Postgres does,
+// by ignoring case and any non-alphanumeric characters, so that, for example,
+// 'utf-8' and 'UTF8' name the same encoding.
+func normalizeCopyEncodingName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return -1
+		}
+	}, name)
 }
 
 // TODO(#sql-sessions): copy all pre-condition checks from the PG code
 // https://github.com/postgres/postgres/blob/1de58df4fec7325d91f5a8345757314be7ac05da/src/backend/commands/copy.c#L405
 func processCopyOptions(
-	ctx context.Context, p *planner, opts tree.CopyOptions,
+	ctx context.Context, p *planner, opts tree.CopyOptions, isCopyFrom bool,
 ) (copyOptions, error) {
 	c := copyOptions{
 		format:          opts.CopyFormat,
@@ -190,15 +245,86 @@ func processCopyOptions(
 		if err != nil {
 			return c, err
 		}
-		if strings.ToUpper(e) != "UTF8" {
-			return c, pgerror.New(pgcode.FeatureNotSupported, "only 'utf8' ENCODING is supported")
+		switch name := normalizeCopyEncodingName(e); name {
+		case "UTF8", "UNICODE":
+			c.encoding = "utf8"
+		default:
+			cm, ok := copyEncodings[name]
+			if !ok {
+				return c, pgerror.Newf(pgcode.InvalidParameterValue, "%q is not a valid encoding name", e)
+			}
+			c.encoding = strings.ToLower(name)
+			c.textEncoding = cm
 		}
-		c.encoding = "utf8"
+	}
+
+	// Postgres only allows the FORCE options in CSV mode, and each of them only
+	// in one direction.
+	for _, o := range []struct {
+		name     string
+		set      bool
+		fromOnly bool
+	}{
+		{name: "FORCE_QUOTE", set: opts.ForceQuote != nil, fromOnly: false},
+		{name: "FORCE_NOT_NULL", set: opts.ForceNotNull != nil, fromOnly: true},
+		{name: "FORCE_NULL", set: opts.ForceNull != nil, fromOnly: true},
+	} {
+		if !o.set {
+			continue
+		}
+		if c.format != tree.CopyFormatCSV {
+			return c, pgerror.Newf(pgcode.FeatureNotSupported, "COPY %s requires CSV mode", o.name)
+		}
+		if o.fromOnly != isCopyFrom {
+			direction := "COPY TO"
+			if isCopyFrom {
+				direction = "COPY FROM"
+			}
+			return c, pgerror.Newf(pgcode.FeatureNotSupported,
+				"COPY %s cannot be used with %s", o.name, direction)
+		}
+	}
+
+	// Rows written by COPY are visible to other transactions as soon as it
+	// commits, so FREEZE doesn't need to do anything.
+	if opts.Freeze && !isCopyFrom {
+		return c, pgerror.New(pgcode.FeatureNotSupported, "COPY FREEZE cannot be used with COPY TO")
 	}
 
 	return c, nil
 }
 
+// resolveCopyColumns returns, for each of the columns a COPY reads or writes,
+// whether the given FORCE option applies to it.
+func resolveCopyColumns(
+	option string, l *tree.CopyColumnList, cols colinfo.ResultColumns,
+) ([]bool, error) {
+	if l == nil {
+		return nil, nil
+	}
+	ret := make([]bool, len(cols))
+	if l.All {
+		for i := range ret {
+			ret[i] = true
+		}
+		return ret, nil
+	}
+	for _, name := range l.Columns {
+		found := false
+		for i := range cols {
+			if cols[i].Name == string(name) {
+				ret[i] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, pgerror.Newf(pgcode.InvalidColumnReference,
+				"%s column %q not referenced by COPY", option, name)
+		}
+	}
+	return ret, nil
+}
+
 // copyMachine supports the Copy-in pgwire subprotocol (COPY...FROM STDIN). The
 // machine is created by the Executor when that statement is executed; from that
 // moment on, the machine takes control of the pgwire connection until
@@ -226,8 +352,14 @@ type copyMachine struct {
 	// NULL. The spec says this is only supported for CSV, and also must specify
 	// which columns it applies to.
 	forceNotNull bool
-	csvInput     bytes.Buffer
-	csvReader    *csv.Reader
+	// forceNotNullCols and forceNullCols indicate, for each column, whether the
+	// FORCE_NOT_NULL and FORCE_NULL options apply to it.
+	forceNotNullCols []bool
+	forceNullCols    []bool
+	// decoder converts the data to UTF8 if a different ENCODING was specified.
+	decoder   *xencoding.Decoder
+	csvInput  bytes.Buffer
+	csvReader *csv.Reader
 	// buf is used to parse input data into rows. It also accumulates a partial
 	// row between protocol messages.
 	buf []byte
@@ -290,7 +422,7 @@ func newCopyMachine(
 	implicitTxn bool,
 	execInsertPlan func(ctx context.Context, p *planner, res RestrictedCommandResult) error,
 ) (_ *copyMachine, retErr error) {
-	cOpts, err := processCopyOptions(ctx, p, n.Options)
+	cOpts, err := processCopyOptions(ctx, p, n.Options, true /* isCopyFrom */)
 	if err != nil {
 		return nil, err
 	}
@@ -344,6 +476,15 @@ func newCopyMachine(
 		typs[i] = col.GetType()
 	}
 	c.typs = typs
+	if c.forceNotNullCols, err = resolveCopyColumns("FORCE_NOT_NULL", n.Options.ForceNotNull, c.resultColumns); err != nil {
+		return nil, err
+	}
+	if c.forceNullCols, err = resolveCopyColumns("FORCE_NULL", n.Options.ForceNull, c.resultColumns); err != nil {
+		return nil, err
+	}
+	if c.textEncoding != nil && c.format != tree.CopyFormatBinary {
+		c.decoder = c.textEncoding.NewDecoder()
+	}
 	// If there are no column specifiers and we expect non-visible columns
 	// to have field data then we have to populate the expectedHiddenColumnIdxs
 	// field with the columns indexes we expect to be hidden.
@@ -633,6 +774,14 @@ func (c *copyMachine) processCopyData(ctx context.Context, data string, final bo
 		}
 	}()
 
+	if c.decoder != nil {
+		// The supported encodings use a single byte per character, so each
+		// chunk of data can be converted on its own.
+		var err error
+		if data, err = c.decoder.String(data); err != nil {
+			return pgerror.Wrapf(err, pgcode.CharacterNotInRepertoire, "converting COPY data from %s", c.encoding)
+		}
+	}
 	if len(data) > (cap(c.buf) - len(c.buf)) {
 		// If it looks like the buffer will need to allocate to accommodate data,
 		// account for the memory here. This is not particularly accurate - we don't
@@ -837,6 +986,20 @@ func (c *copyMachine) maybeIgnoreHiddenColumnsStr(in []csv.Record) []csv.Record
 	return ret
 }
 
+// csvFieldIsNull returns whether the field of the i-th column of a CSV record
+// is NULL. Unquoted fields which match the null string are NULL unless
+// FORCE_NOT_NULL applies to the column, and quoted fields which match it are
+// NULL only if FORCE_NULL applies to the column.
+func (c *copyMachine) csvFieldIsNull(i int, s csv.Record) bool {
+	if s.Val != c.null {
+		return false
+	}
+	if s.Quoted {
+		return c.forceNullCols != nil && c.forceNullCols[i]
+	}
+	return c.forceNotNullCols == nil || !c.forceNotNullCols[i]
+}
+
 func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) error {
 	if expected := len(c.resultColumns) + len(c.expectedHiddenColumnIdxs); expected != len(record) {
 		return pgerror.Newf(pgcode.BadCopyFileFormat,
@@ -846,9 +1009,7 @@ func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) err
 	if c.vectorized {
 		vh := c.valueHandlers
 		for i, s := range record {
-			// NB: When we implement FORCE_NULL, then quoted values also are allowed
-			// to be treated as NULL.
-			if !s.Quoted && s.Val == c.null {
+			if c.csvFieldIsNull(i, s) {
 				vh[i].Null()
 				continue
 			}
@@ -860,9 +1021,7 @@ func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) err
 	} else {
 		datums := c.scratchRow
 		for i, s := range record {
-			// NB: When we implement FORCE_NULL, then quoted values also are allowed
-			// to be treated as NULL.
-			if !s.Quoted && s.Val == c.null {
+			if c.csvFieldIsNull(i, s) {
 				datums[i] = tree.DNull
 				continue
 			}
@@ -17,11 +17,12 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding/csv"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 )
 
@@ -76,6 +77,9 @@ type csvCopyToTranslater struct {
 	b      bytes.Buffer
 	fmtCtx *tree.FmtCtx
 	w      *csv.Writer
+	// forceQuoteCols indicates, for each column, whether its non-NULL values
+	// are always quoted, as requested by FORCE_QUOTE.
+	forceQuoteCols []bool
 }
 
 func (c *csvCopyToTranslater) translateRow(
@@ -83,7 +87,7 @@ func (c *csvCopyToTranslater) translateRow(
 ) ([]byte, error) {
 	c.b.Reset()
 	c.fmtCtx.Buffer.Reset()
-	for _, d := range datums {
+	for i, d := range datums {
 		if d == tree.DNull {
 			if err := c.w.WriteField(bytes.NewBufferString(c.null)); err != nil {
 				return nil, err
@@ -92,7 +96,11 @@ func (c *csvCopyToTranslater) translateRow(
 		}
 
 		c.fmtCtx.FormatNode(d)
-		if c.fmtCtx.Buffer.Len() == 0 {
+		if c.forceQuoteCols != nil && c.forceQuoteCols[i] {
+			if err := c.w.WriteQuotedField(bytes.NewBuffer(c.fmtCtx.Buffer.Bytes())); err != nil {
+				return nil, err
+			}
+		} else if c.fmtCtx.Buffer.Len() == 0 {
 			// Empty fields must force an empty quote to differentiate from NULL.
 			if err := c.w.ForceEmptyField(); err != nil {
 				return nil, err
@@ -131,39 +139,11 @@ func (c *csvCopyToTranslater) headerRow(rcs colinfo.ResultColumns) ([]byte, bool
 func runCopyTo(
 	ctx context.Context, p *planner, txn *kv.Txn, cmd CopyOut, res CopyOutResult,
 ) (numOutputRows int, retErr error) {
-	copyOptions, err := processCopyOptions(ctx, p, cmd.Stmt.Options)
+	copyOptions, err := processCopyOptions(ctx, p, cmd.Stmt.Options, false /* isCopyFrom */)
 	if err != nil {
 		return 0, err
 	}
 
-	wireFormat := pgwirebase.FormatText
-	var t copyToTranslater
-	switch cmd.Stmt.Options.CopyFormat {
-	case tree.CopyFormatBinary:
-		// wireFormat = pgwirebase.FormatBinary
-		return 0, unimplemented.NewWithIssue(
-			97180,
-			"binary format for COPY TO not implemented",
-		)
-	case tree.CopyFormatCSV:
-		csvTranslater := &csvCopyToTranslater{
-			copyOptions: copyOptions,
-			fmtCtx:      p.EvalContext().FmtCtx(tree.FmtPgwireText),
-		}
-		csvTranslater.w = csv.NewWriter(&csvTranslater.b)
-		csvTranslater.w.Comma = rune(copyOptions.delimiter)
-		if copyOptions.csvEscape != 0 {
-			csvTranslater.w.Escape = copyOptions.csvEscape
-		}
-		t = csvTranslater
-	default:
-		textTranslater := &textCopyToTranslater{
-			copyOptions: copyOptions,
-			fmtCtx:      p.EvalContext().FmtCtx(tree.FmtPgwireText),
-		}
-		t = textTranslater
-	}
-
 	var q string
 	if cmd.Stmt.Statement != nil {
 		q = cmd.Stmt.Statement.String()
@@ -202,22 +182,75 @@ func runCopyTo(
 		}
 	}()
 
+	wireFormat := pgwirebase.FormatText
+	var t copyToTranslater
+	switch cmd.Stmt.Options.CopyFormat {
+	case tree.CopyFormatBinary:
+		// Rows in the binary format are encoded by the result, since the
+		// encoding of each value is the one used by the pgwire protocol.
+		wireFormat = pgwirebase.FormatBinary
+	case tree.CopyFormatCSV:
+		csvTranslater := &csvCopyToTranslater{
+			copyOptions: copyOptions,
+			fmtCtx:      p.EvalContext().FmtCtx(tree.FmtPgwireText),
+		}
+		csvTranslater.w = csv.NewWriter(&csvTranslater.b)
+		csvTranslater.w.Comma = rune(copyOptions.delimiter)
+		if copyOptions.csvEscape != 0 {
+			csvTranslater.w.Escape = copyOptions.csvEscape
+		}
+		if csvTranslater.forceQuoteCols, err = resolveCopyColumns(
+			"FORCE_QUOTE", cmd.Stmt.Options.ForceQuote, it.Types(),
+		); err != nil {
+			return 0, err
+		}
+		t = csvTranslater
+	default:
+		textTranslater := &textCopyToTranslater{
+			copyOptions: copyOptions,
+			fmtCtx:      p.EvalContext().FmtCtx(tree.FmtPgwireText),
+		}
+		t = textTranslater
+	}
+
+	// encode converts the data to the requested ENCODING, if any.
+	encode := func(data []byte) ([]byte, error) { return data, nil }
+	if copyOptions.textEncoding != nil && t != nil {
+		encoder := copyOptions.textEncoding.NewEncoder()
+		encode = func(data []byte) ([]byte, error) {
+			out, err := encoder.Bytes(data)
+			if err != nil {
+				return nil, pgerror.Wrapf(err, pgcode.UntranslatableCharacter,
+					"converting COPY data to %s", copyOptions.encoding)
+			}
+			return out, nil
+		}
+	}
+
 	// Send the message describing the columns to the client.
 	if err := res.SendCopyOut(ctx, it.Types(), wireFormat); err != nil {
 		return 0, err
 	}
 
 	if err := func() error {
-		// Send header row if requested.
-		// Send all the rows out to the client.
-		if row, ok, err := t.headerRow(it.Types()); err != nil {
+		if t == nil {
+			// The binary format starts with a signature instead of a header row.
+			if err := res.SendCopyData(ctx, copyBinarySignature[:], true /* isHeader */); err != nil {
+				return err
+			}
+		} else if row, ok, err := t.headerRow(it.Types()); err != nil {
+			// Send header row if requested.
 			return err
 		} else if ok {
+			if row, err = encode(row); err != nil {
+				return err
+			}
 			if err := res.SendCopyData(ctx, row, true /* isHeader */); err != nil {
 				return err
 			}
 		}
 
+		// Send all the rows out to the client.
 		for {
 			next, err := it.Next(ctx)
 			if err != nil {
@@ -227,14 +260,29 @@ func runCopyTo(
 				break
 			}
 			numOutputRows++
+			if t == nil {
+				if err := res.SendCopyBinaryRow(ctx, it.Cur(), it.Types()); err != nil {
+					return err
+				}
+				continue
+			}
 			row, err := t.translateRow(it.Cur(), it.Types())
 			if err != nil {
 				return err
 			}
+			if row, err = encode(row); err != nil {
+				return err
+			}
 			if err := res.SendCopyData(ctx, row, false /* isHeader */); err != nil {
 				return err
 			}
 		}
+
+		if t == nil {
+			if err := res.SendCopyData(ctx, copyBinaryTrailer, true /* isHeader */); err != nil {
+				return err
+			}
+		}
 		return nil
 	}(); err != nil {
 		return 0, err
@@ -242,6 +290,10 @@ func runCopyTo(
 	return numOutputRows, res.SendCopyDone(ctx)
 }
 
+// copyBinaryTrailer is the 16-bit field count of -1 which ends data in the
+// binary COPY format.
+var copyBinaryTrailer = []byte{0xff, 0xff}
+
 var encodeMap = func() map[byte]byte {
 	ret := make(map[byte]byte, len(decodeMap))
 	for k, v := range decodeMap {
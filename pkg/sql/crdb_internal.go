@@ -225,6 +225,8 @@ var crdbInternal = virtualSchema{
 		catconstants.CrdbInternalPCRStreamsTableID:                  crdbInternalPCRStreamsTable,
 		catconstants.CrdbInternalPCRStreamSpansTableID:              crdbInternalPCRStreamSpansTable,
 		catconstants.CrdbInternalPCRStreamCheckpointsTableID:        crdbInternalPCRStreamCheckpointsTable,
+		catconstants.CrdbInternalHotKeysTableID:                     crdbInternalHotKeysTable,
+		catconstants.CrdbInternalEncryptionStatusTableID:            crdbInternalEncryptionStatusTable,
 	},
 	validWithNoDatabaseContext: true,
 }
@@ -9108,3 +9110,123 @@ CREATE TABLE crdb_internal.cluster_replication_node_stream_checkpoints (
 		return nil
 	},
 }
+
+var crdbInternalHotKeysTable = virtualSchemaTable{
+	comment: `cluster-wide view of the estimated hottest keys of the hottest ranges`,
+	schema: `
+CREATE TABLE crdb_internal.hot_keys (
+	range_id      INT NOT NULL,
+	node_id       INT NOT NULL,
+	store_id      INT NOT NULL,
+	database_name STRING NOT NULL,
+	schema_name   STRING NOT NULL,
+	table_name    STRING NOT NULL,
+	index_name    STRING NOT NULL,
+	key           STRING NOT NULL,
+	qps           FLOAT NOT NULL
+);`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.CheckPrivilege(ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.VIEWCLUSTERMETADATA); err != nil {
+			return err
+		}
+		resp, err := p.ExecCfg().TenantStatusServer.HotRangesV2(ctx, &serverpb.HotRangesRequest{})
+		if err != nil {
+			return err
+		}
+		for _, r := range resp.Ranges {
+			for _, k := range r.HotKeys {
+				if err := addRow(
+					tree.NewDInt(tree.DInt(r.RangeID)),
+					tree.NewDInt(tree.DInt(r.NodeID)),
+					tree.NewDInt(tree.DInt(r.StoreID)),
+					tree.NewDString(r.DatabaseName),
+					tree.NewDString(r.SchemaName),
+					tree.NewDString(r.TableName),
+					tree.NewDString(r.IndexName),
+					tree.NewDString(keys.PrettyPrint(nil /* valDirs */, k.Key)),
+					tree.NewDFloat(tree.DFloat(k.QPS)),
+				); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	},
+}
+
+var crdbInternalEncryptionStatusTable = virtualSchemaTable{
+	comment: `encryption-at-rest keys of every store and the files encrypted with each key (cluster RPC; expensive!)`,
+	schema: `
+CREATE TABLE crdb_internal.encryption_status (
+	node_id         INT NOT NULL,
+	store_id        INT NOT NULL,
+	key_id          STRING NOT NULL,
+	key_type        STRING NOT NULL,
+	parent_key_id   STRING,
+	encryption_type STRING,
+	active          BOOL NOT NULL,
+	created         TIMESTAMPTZ,
+	files           INT NOT NULL,
+	bytes           INT NOT NULL
+);`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.CheckPrivilege(ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.VIEWCLUSTERMETADATA); err != nil {
+			return err
+		}
+		ss, err := p.ExecCfg().NodesStatusServer.OptionalNodesStatusServer()
+		if err != nil {
+			return err
+		}
+		response, err := ss.ListNodesInternal(ctx, &serverpb.NodesRequest{})
+		if err != nil {
+			return err
+		}
+
+		stringOrNull := func(s string) tree.Datum {
+			if s == "" {
+				return tree.DNull
+			}
+			return tree.NewDString(s)
+		}
+		for _, n := range response.Nodes {
+			nodeID := n.Desc.NodeID
+			// Decommissioned nodes no longer hold any data, so there is nothing
+			// to report for them; every other node must answer, since silently
+			// omitting a store would hide files encrypted with stale keys.
+			if response.LivenessByNodeID[nodeID] == livenesspb.NodeLivenessStatus_DECOMMISSIONED {
+				continue
+			}
+			stores, err := ss.Stores(ctx, &serverpb.StoresRequest{NodeId: strconv.Itoa(int(nodeID))})
+			if err != nil {
+				return errors.Wrapf(err, "fetching encryption status of n%d", nodeID)
+			}
+			for _, s := range stores.Stores {
+				for _, k := range s.EncryptionKeys {
+					keyType := "data"
+					if k.StoreKey {
+						keyType = "store"
+					}
+					created := tree.DNull
+					if k.CreationTime != 0 {
+						created = tree.MustMakeDTimestampTZ(timeutil.Unix(k.CreationTime, 0), time.Second)
+					}
+					if err := addRow(
+						tree.NewDInt(tree.DInt(nodeID)),
+						tree.NewDInt(tree.DInt(s.StoreID)),
+						tree.NewDString(k.KeyID),
+						tree.NewDString(keyType),
+						stringOrNull(k.ParentKeyID),
+						stringOrNull(k.EncryptionType),
+						tree.MakeDBool(tree.DBool(k.Active)),
+						created,
+						tree.NewDInt(tree.DInt(k.Files)),
+						tree.NewDInt(tree.DInt(k.Bytes)),
+					); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	},
+}
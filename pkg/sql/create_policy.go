@@ -0,0 +1,185 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/decodeusername"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+)
+
+type createPolicyNode struct {
+	n         *tree.CreatePolicy
+	tableName tree.TableName
+	tableDesc *tabledesc.Mutable
+	roleNames []string
+}
+
+// CreatePolicy creates a row-level security policy on a table.
+// Privileges: ownership of the table.
+func (p *planner) CreatePolicy(ctx context.Context, n *tree.CreatePolicy) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE POLICY",
+	); err != nil {
+		return nil, err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1_RowLevelSecurity) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"row-level security is not supported until the cluster upgrade is finalized",
+		)
+	}
+
+	tn := n.TableName.ToTableName()
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkCanManagePolicies(ctx, tableDesc); err != nil {
+		return nil, err
+	}
+
+	for _, policy := range tableDesc.GetPolicies() {
+		if policy.Name == string(n.PolicyName) {
+			return nil, pgerror.Newf(pgcode.DuplicateObject,
+				"policy %q for table %q already exists", n.PolicyName, tableDesc.GetName())
+		}
+	}
+
+	switch n.Cmd {
+	case tree.PolicyCommandSelect, tree.PolicyCommandDelete:
+		if n.WithCheck != nil {
+			return nil, pgerror.New(pgcode.Syntax,
+				"WITH CHECK cannot be applied to SELECT or DELETE")
+		}
+	case tree.PolicyCommandInsert:
+		if n.Using != nil {
+			return nil, pgerror.New(pgcode.Syntax,
+				"only WITH CHECK expression allowed for INSERT")
+		}
+	}
+
+	// A policy without a TO clause applies to all roles.
+	roleNames := []string{username.PublicRole}
+	if len(n.Roles) > 0 {
+		roles, err := decodeusername.FromRoleSpecList(
+			p.SessionData(), username.PurposeValidation, n.Roles,
+		)
+		if err != nil {
+			return nil, err
+		}
+		roleNames = make([]string, 0, len(roles))
+		for _, role := range roles {
+			if !role.IsPublicRole() {
+				if err := p.CheckRoleExists(ctx, role); err != nil {
+					return nil, err
+				}
+			}
+			roleNames = append(roleNames, role.Normalized())
+		}
+	}
+
+	return &createPolicyNode{
+		n:         n,
+		tableName: tn,
+		tableDesc: tableDesc,
+		roleNames: roleNames,
+	}, nil
+}
+
+func (n *createPolicyNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("policy"))
+
+	policy := descpb.PolicyDescriptor{
+		Name:      string(n.n.PolicyName),
+		Type:      descpb.PolicyDescriptor_PERMISSIVE,
+		Command:   policyCommandToDescriptor(n.n.Cmd),
+		RoleNames: n.roleNames,
+	}
+	if n.n.Type == tree.PolicyTypeRestrictive {
+		policy.Type = descpb.PolicyDescriptor_RESTRICTIVE
+	}
+
+	version := params.ExecCfg().Settings.Version.ActiveVersion(params.ctx)
+	if n.n.Using != nil {
+		expr, err := schemaexpr.ValidatePolicyExpr(
+			params.ctx, n.tableDesc, n.n.Using, &n.tableName, params.p.SemaCtx(), version,
+		)
+		if err != nil {
+			return err
+		}
+		policy.UsingExpr = expr
+	}
+	if n.n.WithCheck != nil {
+		expr, err := schemaexpr.ValidatePolicyExpr(
+			params.ctx, n.tableDesc, n.n.WithCheck, &n.tableName, params.p.SemaCtx(), version,
+		)
+		if err != nil {
+			return err
+		}
+		policy.WithCheckExpr = expr
+	}
+
+	n.tableDesc.AddPolicy(policy)
+	return params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *createPolicyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createPolicyNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createPolicyNode) Close(context.Context)        {}
+
+// checkCanManagePolicies returns an error if the current user is not allowed
+// to create or drop the row-level security policies of the given table, or to
+// enable or disable row-level security on it. As in Postgres, this requires
+// ownership of the table.
+func (p *planner) checkCanManagePolicies(ctx context.Context, tableDesc *tabledesc.Mutable) error {
+	hasOwnership, err := p.HasOwnership(ctx, tableDesc)
+	if err != nil {
+		return err
+	}
+	if !hasOwnership {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", tree.Name(tableDesc.GetName()))
+	}
+	return nil
+}
+
+// policyCommandToDescriptor converts the command of a CREATE POLICY statement
+// to its descriptor representation.
+func policyCommandToDescriptor(cmd tree.PolicyCommand) descpb.PolicyDescriptor_Command {
+	switch cmd {
+	case tree.PolicyCommandSelect:
+		return descpb.PolicyDescriptor_SELECT
+	case tree.PolicyCommandInsert:
+		return descpb.PolicyDescriptor_INSERT
+	case tree.PolicyCommandUpdate:
+		return descpb.PolicyDescriptor_UPDATE
+	case tree.PolicyCommandDelete:
+		return descpb.PolicyDescriptor_DELETE
+	default:
+		return descpb.PolicyDescriptor_ALL
+	}
+}
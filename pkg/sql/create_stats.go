@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/featureflag"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -77,7 +78,7 @@ func StubTableStats(
 ) ([]*stats.TableStatisticProto, error) {
 	colStats, err := createStatsDefaultColumns(
 		context.Background(), desc, false /* virtColEnabled */, false, /* multiColEnabled */
-		nonIndexColHistogramBuckets, nil, /* evalCtx */
+		false /* extendedStatsEnabled */, nonIndexColHistogramBuckets, nil, /* evalCtx */
 	)
 	if err != nil {
 		return nil, err
@@ -228,6 +229,22 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 		return nil, err
 	}
 
+	hasDependencies, hasMostCommonValues, err := parseStatsKinds(n.Kinds)
+	if err != nil {
+		return nil, err
+	}
+	if (hasDependencies || hasMostCommonValues) && len(n.ColumnNames) < 2 {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			"extended statistics require a list of at least two columns",
+		)
+	}
+	if (hasDependencies || hasMostCommonValues) &&
+		!n.p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1_TableStatisticsExtendedStats) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"extended statistics are not supported until the cluster upgrade is finalized",
+		)
+	}
+
 	var colStats []jobspb.CreateStatsDetails_ColStat
 	var deleteOtherStats bool
 	if len(n.ColumnNames) == 0 {
@@ -235,14 +252,18 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 		// Disable multi-column stats and deleting stats if partial statistics at
 		// the extremes are requested.
 		// TODO(faizaanmadhani): Add support for multi-column stats.
-		var multiColEnabled bool
+		var multiColEnabled, extendedStatsEnabled bool
 		if !n.Options.UsingExtremes {
 			multiColEnabled = stats.MultiColumnStatisticsClusterMode.Get(n.p.ExecCfg().SV())
+			extendedStatsEnabled = multiColEnabled &&
+				stats.ExtendedStatisticsClusterMode.Get(n.p.ExecCfg().SV()) &&
+				n.p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1_TableStatisticsExtendedStats)
 			deleteOtherStats = true
 		}
 		defaultHistogramBuckets := stats.GetDefaultHistogramBuckets(n.p.ExecCfg().SV(), tableDesc)
 		if colStats, err = createStatsDefaultColumns(
-			ctx, tableDesc, virtColEnabled, multiColEnabled, defaultHistogramBuckets, n.p.EvalContext(),
+			ctx, tableDesc, virtColEnabled, multiColEnabled, extendedStatsEnabled,
+			defaultHistogramBuckets, n.p.EvalContext(),
 		); err != nil {
 			return nil, err
 		}
@@ -282,6 +303,8 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 			// with a single column that doesn't use an inverted index.
 			HasHistogram:        len(columnIDs) == 1 && !isInvIndex,
 			HistogramMaxBuckets: defaultHistogramBuckets,
+			HasDependencies:     hasDependencies,
+			HasMostCommonValues: hasMostCommonValues,
 		}}
 		// Make histograms for inverted index column types.
 		if len(columnIDs) == 1 && isInvIndex {
@@ -336,6 +359,28 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 	}, nil
 }
 
+// parseStatsKinds validates the kinds of extended statistics requested with
+// CREATE STATISTICS <name> (<kind>, ...) and returns which of them should be
+// collected.
+func parseStatsKinds(kinds tree.NameList) (hasDependencies, hasMostCommonValues bool, _ error) {
+	for _, kind := range kinds {
+		switch kind {
+		case "dependencies":
+			hasDependencies = true
+		case "mcv":
+			hasMostCommonValues = true
+		case "ndistinct":
+			// Multi-column distinct counts are always collected, so there is
+			// nothing extra to do. This is accepted for compatibility with
+			// Postgres.
+		default:
+			return false, false, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized statistics kind %q", string(kind))
+		}
+	}
+	return hasDependencies, hasMostCommonValues, nil
+}
+
 // maxNonIndexCols is the maximum number of non-index columns that we will use
 // when choosing a default set of column statistics.
 const maxNonIndexCols = 100
@@ -350,7 +395,10 @@ const maxNonIndexCols = 100
 // useful to have statistics on prefixes of those columns. For example, if a
 // table abc contains indexes on (a ASC, b ASC) and (b ASC, c ASC), we will
 // collect statistics on a, {a, b}, b, and {b, c}. (But if multiColEnabled is
-// false, we will only collect stats on a and b). Columns in partial index
+// false, we will only collect stats on a and b). If extendedStatsEnabled is
+// true, the multi-column statistics also include functional dependencies and
+// most common values, since correlated index prefixes are exactly where the
+// optimizer's independence assumption hurts the most. Columns in partial index
 // predicate expressions are also likely to appear in query filters, so stats
 // are collected for those columns as well.
 //
@@ -360,7 +408,7 @@ const maxNonIndexCols = 100
 func createStatsDefaultColumns(
 	ctx context.Context,
 	desc catalog.TableDescriptor,
-	virtColEnabled, multiColEnabled bool,
+	virtColEnabled, multiColEnabled, extendedStatsEnabled bool,
 	defaultHistogramBuckets uint32,
 	evalCtx *eval.Context,
 ) ([]jobspb.CreateStatsDetails_ColStat, error) {
@@ -504,8 +552,10 @@ func createStatsDefaultColumns(
 
 		// Only generate non-histogram multi-column stats.
 		colStats = append(colStats, jobspb.CreateStatsDetails_ColStat{
-			ColumnIDs:    colIDs,
-			HasHistogram: false,
+			ColumnIDs:           colIDs,
+			HasHistogram:        false,
+			HasDependencies:     extendedStatsEnabled,
+			HasMostCommonValues: extendedStatsEnabled,
 		})
 	}
 
@@ -558,8 +608,10 @@ func createStatsDefaultColumns(
 
 			// Only generate non-histogram multi-column stats.
 			colStats = append(colStats, jobspb.CreateStatsDetails_ColStat{
-				ColumnIDs:    colIDs,
-				HasHistogram: false,
+				ColumnIDs:           colIDs,
+				HasHistogram:        false,
+				HasDependencies:     extendedStatsEnabled,
+				HasMostCommonValues: extendedStatsEnabled,
 			})
 		}
 
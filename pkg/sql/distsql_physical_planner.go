@@ -121,6 +121,10 @@ type DistSQLPlanner struct {
 	// goroutines that can be used to run check postqueries (FK and UNIQUE
 	// constraint checks) in parallel.
 	parallelChecksSem *quotapool.IntPool
+	// parallelSubqueriesSem is a node-wide semaphore on the number of
+	// additional goroutines that can be used to run independent subqueries in
+	// parallel.
+	parallelSubqueriesSem *quotapool.IntPool
 
 	// distSender is used to construct the spanResolver upon SetSQLInstanceInfo.
 	distSender *kvcoord.DistSender
@@ -210,10 +214,16 @@ func NewDistSQLPlanner(
 	parallelChecksConcurrencyLimit.SetOnChange(&st.SV, func(ctx context.Context) {
 		dsp.parallelChecksSem.UpdateCapacity(uint64(parallelChecksConcurrencyLimit.Get(&st.SV)))
 	})
+	dsp.parallelSubqueriesSem = quotapool.NewIntPool("parallel subqueries concurrency",
+		uint64(parallelSubqueriesConcurrencyLimit.Get(&st.SV)))
+	parallelSubqueriesConcurrencyLimit.SetOnChange(&st.SV, func(ctx context.Context) {
+		dsp.parallelSubqueriesSem.UpdateCapacity(uint64(parallelSubqueriesConcurrencyLimit.Get(&st.SV)))
+	})
 	if rpcCtx != nil {
 		// rpcCtx might be nil in some tests.
 		rpcCtx.Stopper.AddCloser(dsp.parallelLocalScansSem.Closer("stopper"))
 		rpcCtx.Stopper.AddCloser(dsp.parallelChecksSem.Closer("stopper"))
+		rpcCtx.Stopper.AddCloser(dsp.parallelSubqueriesSem.Closer("stopper"))
 	}
 
 	dsp.runnerCoordinator.init(ctx, stopper, &st.SV)
@@ -3071,6 +3081,33 @@ func (dsp *DistSQLPlanner) createPlanForIndexJoin(
 	return plan, nil
 }
 
+// canPlanAdaptiveLookupJoin returns whether the lookup join described by spec
+// can also be executed as a hash join between its input and a full scan of
+// the lookup index, which is required for the join to be adaptive (see
+// JoinReaderSpec.AdaptiveThreshold).
+func canPlanAdaptiveLookupJoin(spec *execinfrapb.JoinReaderSpec, inputTypes []*types.T) bool {
+	if len(spec.LookupColumns) == 0 || !spec.LookupExpr.Empty() || !spec.OnExpr.Empty() ||
+		spec.MaintainOrdering || spec.LeftJoinWithPairedJoiner || spec.LimitHint != 0 ||
+		spec.LockingStrength != descpb.ScanLockingStrength_FOR_NONE {
+		return false
+	}
+	switch spec.Type {
+	case descpb.InnerJoin, descpb.LeftOuterJoin, descpb.LeftSemiJoin, descpb.LeftAntiJoin:
+	default:
+		return false
+	}
+	fetchedOrds, ok := spec.LookupColumnFetchedOrdinals()
+	if !ok {
+		return false
+	}
+	for i, ord := range fetchedOrds {
+		if !inputTypes[spec.LookupColumns[i]].Identical(spec.FetchSpec.FetchedColumns[ord].Type) {
+			return false
+		}
+	}
+	return true
+}
+
 // createPlanForLookupJoin creates a distributed plan for a lookupJoinNode.
 func (dsp *DistSQLPlanner) createPlanForLookupJoin(
 	ctx context.Context, planCtx *PlanningCtx, n *lookupJoinNode,
@@ -3205,6 +3242,14 @@ func (dsp *DistSQLPlanner) createPlanForLookupJoin(
 		}
 	}
 
+	if threshold := planCtx.ExtendedEvalCtx.SessionData().AdaptiveJoinRowThreshold; threshold > 0 &&
+		canPlanAdaptiveLookupJoin(&joinReaderSpec, inputTypes) {
+		joinReaderSpec.AdaptiveThreshold = uint64(threshold)
+		joinReaderSpec.AdaptiveScanSpans = []roachpb.Span{
+			n.table.desc.IndexSpan(planCtx.ExtendedEvalCtx.Codec, n.table.index.GetID()),
+		}
+	}
+
 	// Instantiate one join reader for every stream. This is also necessary for
 	// correctness of paired-joins where this join is the second join -- it is
 	// necessary to have a one-to-one relationship between the first and second
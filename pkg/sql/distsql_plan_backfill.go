@@ -12,17 +12,28 @@ package sql
 
 import (
 	"context"
+	"encoding/binary"
+	"sort"
 	"time"
 	"unsafe"
 
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/interval"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
 )
 
@@ -48,6 +59,7 @@ func initIndexBackfillerSpec(
 	writeAsOf, readAsOf hlc.Timestamp,
 	writeAtBatchTimestamp bool,
 	chunkSize int64,
+	numWorkers int32,
 	indexesToBackfill []descpb.IndexID,
 ) (execinfrapb.BackfillerSpec, error) {
 	return execinfrapb.BackfillerSpec{
@@ -57,6 +69,7 @@ func initIndexBackfillerSpec(
 		ReadAsOf:              readAsOf,
 		Type:                  execinfrapb.BackfillerSpec_Index,
 		ChunkSize:             chunkSize,
+		NumWorkers:            numWorkers,
 		IndexesToBackfill:     indexesToBackfill,
 	}, nil
 }
@@ -78,6 +91,10 @@ func initIndexBackfillMergerSpec(
 // createBackfiller generates a plan consisting of index/column backfiller
 // processors, one for each node that has spans that we are reading. The plan is
 // finalized.
+//
+// If the index backfiller processors use multiple workers, then the spans of
+// each node are sub-split using sampled keys so that each worker has a span to
+// process, even when the node has only a few (large) ranges.
 func (dsp *DistSQLPlanner) createBackfillerPhysicalPlan(
 	ctx context.Context, planCtx *PlanningCtx, spec execinfrapb.BackfillerSpec, spans []roachpb.Span,
 ) (*PhysicalPlan, error) {
@@ -85,6 +102,32 @@ func (dsp *DistSQLPlanner) createBackfillerPhysicalPlan(
 	if err != nil {
 		return nil, err
 	}
+	if knobs := &dsp.distSQLSrv.TestingKnobs; knobs.RunBeforeBackfillChunk != nil ||
+		knobs.SerializeIndexBackfillCreationAndIngestion != nil {
+		// The testing knobs intercepting the backfill chunks expect the spans to
+		// be processed serially and in order.
+		spec.NumWorkers = 1
+	}
+	if spec.Type == execinfrapb.BackfillerSpec_Index && spec.NumWorkers > 1 {
+		desc := tabledesc.NewBuilder(&spec.Table).BuildImmutableTable()
+		readAsOf := spec.ReadAsOf
+		if readAsOf.IsEmpty() {
+			readAsOf = spec.WriteAsOf
+		}
+		for i := range spanPartitions {
+			splitKeys, err := sampleIndexBackfillSplitKeys(
+				ctx, planCtx, desc, spanPartitions[i].Spans, readAsOf, int(spec.NumWorkers),
+			)
+			if err != nil {
+				// The sub-splitting is only an optimization, so we proceed with
+				// the original spans.
+				log.Warningf(ctx, "unable to sample split keys for index backfill: %v", err)
+			}
+			spanPartitions[i].Spans = subSplitSpans(
+				spanPartitions[i].Spans, splitKeys, int(spec.NumWorkers),
+			)
+		}
+	}
 
 	p := planCtx.NewPhysicalPlan()
 	p.ResultRouters = make([]physicalplan.ProcessorIdx, len(spanPartitions))
@@ -182,6 +225,225 @@ func (dsp *DistSQLPlanner) createIndexBackfillerMergePhysicalPlan(
 	return p, nil
 }
 
+// sampleIndexBackfillSplitKeys returns sorted keys of the primary index of the
+// given table that can be used to sub-split the given spans read by an index
+// backfill into (at least) target spans. The keys are preferably taken from
+// the histogram on the leading column of the primary key, which is itself
+// built from a sample of the table's rows, so there is roughly the same number
+// of rows between consecutive keys. If no such histogram is available, the
+// keys are sampled from the spans as of readAsOf instead.
+func sampleIndexBackfillSplitKeys(
+	ctx context.Context,
+	planCtx *PlanningCtx,
+	desc catalog.TableDescriptor,
+	spans roachpb.Spans,
+	readAsOf hlc.Timestamp,
+	target int,
+) ([]roachpb.Key, error) {
+	execCfg := planCtx.ExtendedEvalCtx.ExecCfg
+	splitKeys, err := histogramSplitKeys(ctx, execCfg, desc)
+	if err != nil {
+		log.Warningf(ctx, "unable to use the histogram to split an index backfill: %v", err)
+	}
+	if len(splitKeys) > 0 {
+		return splitKeys, nil
+	}
+	return probeSplitKeys(ctx, execCfg.DB, spans, readAsOf, target)
+}
+
+// histogramSplitKeys returns the sorted upper bounds of the buckets of the
+// most recent histogram on the leading column of the primary key of the given
+// table, encoded as keys of the primary index. nil is returned if there is no
+// such histogram.
+func histogramSplitKeys(
+	ctx context.Context, execCfg *ExecutorConfig, desc catalog.TableDescriptor,
+) ([]roachpb.Key, error) {
+	tableStats, err := execCfg.TableStatsCache.GetTableStats(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	pk := desc.GetPrimaryIndex()
+	colID := pk.GetKeyColumnID(0)
+	dir := encoding.Ascending
+	if pk.GetKeyColumnDirection(0) == catenumpb.IndexColumn_DESC {
+		dir = encoding.Descending
+	}
+	prefix := rowenc.MakeIndexKeyPrefix(execCfg.Codec, desc.GetID(), pk.GetID())
+	// The statistics are ordered from the newest to the oldest, so we use the
+	// first suitable one.
+	for _, stat := range tableStats {
+		if len(stat.ColumnIDs) != 1 || stat.ColumnIDs[0] != colID ||
+			stat.PartialPredicate != "" || len(stat.Histogram) == 0 {
+			continue
+		}
+		splitKeys := make([]roachpb.Key, 0, len(stat.Histogram))
+		for _, bucket := range stat.Histogram {
+			if bucket.UpperBound == tree.DNull {
+				continue
+			}
+			key := make(roachpb.Key, len(prefix), len(prefix)+16)
+			copy(key, prefix)
+			key, err = keyside.Encode(key, bucket.UpperBound, dir)
+			if err != nil {
+				return nil, err
+			}
+			splitKeys = append(splitKeys, key)
+		}
+		sort.Slice(splitKeys, func(i, j int) bool {
+			return splitKeys[i].Compare(splitKeys[j]) < 0
+		})
+		return splitKeys, nil
+	}
+	return nil, nil
+}
+
+// probeSplitKeys samples the keys of the rows within the given spans as of
+// readAsOf, so that the spans can be split into roughly target spans. The
+// first and the last row of every span are looked up, and the span is then
+// probed for the first row at or after each of the keys evenly spaced between
+// those two. Only a handful of rows is read, so the sampled keys are spread
+// evenly over the key space rather than over the rows. The returned keys are
+// sorted and unique.
+func probeSplitKeys(
+	ctx context.Context, db *kv.DB, spans roachpb.Spans, readAsOf hlc.Timestamp, target int,
+) ([]roachpb.Key, error) {
+	if len(spans) == 0 || len(spans) >= target {
+		return nil, nil
+	}
+	// Probe every span enough times to find all the needed split keys.
+	numSplits := target - len(spans)
+	numProbes := (numSplits + len(spans) - 1) / len(spans)
+	var splitKeys []roachpb.Key
+	if err := db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		splitKeys = splitKeys[:0]
+		if !readAsOf.IsEmpty() {
+			if err := txn.SetFixedTimestamp(ctx, readAsOf); err != nil {
+				return err
+			}
+		}
+		for _, sp := range spans {
+			first, err := txn.Scan(ctx, sp.Key, sp.EndKey, 1 /* maxRows */)
+			if err != nil {
+				return err
+			}
+			last, err := txn.ReverseScan(ctx, sp.Key, sp.EndKey, 1 /* maxRows */)
+			if err != nil {
+				return err
+			}
+			if len(first) == 0 || len(last) == 0 {
+				continue
+			}
+			for _, probe := range interpolateKeys(first[0].Key, last[0].Key, numProbes) {
+				kvs, err := txn.Scan(ctx, probe, sp.EndKey, 1 /* maxRows */)
+				if err != nil {
+					return err
+				}
+				if len(kvs) == 0 {
+					continue
+				}
+				// Never split a row between two spans.
+				key, err := keys.EnsureSafeSplitKey(kvs[0].Key)
+				if err != nil {
+					return err
+				}
+				if key.Compare(sp.Key) > 0 && key.Compare(sp.EndKey) < 0 {
+					splitKeys = append(splitKeys, key)
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(splitKeys, func(i, j int) bool {
+		return splitKeys[i].Compare(splitKeys[j]) < 0
+	})
+	res := splitKeys[:0]
+	for _, key := range splitKeys {
+		if len(res) == 0 || !res[len(res)-1].Equal(key) {
+			res = append(res, key)
+		}
+	}
+	return res, nil
+}
+
+// interpolateKeys returns n keys evenly spaced between the keys lo and hi,
+// which must be ordered. Only the (up to) eight bytes following the longest
+// common prefix of lo and hi are taken into account, so fewer keys are
+// returned if lo and hi are too close to each other.
+func interpolateKeys(lo, hi roachpb.Key, n int) []roachpb.Key {
+	var prefixLen int
+	for prefixLen < len(lo) && prefixLen < len(hi) && lo[prefixLen] == hi[prefixLen] {
+		prefixLen++
+	}
+	suffixValue := func(key roachpb.Key) uint64 {
+		var buf [8]byte
+		copy(buf[:], key[prefixLen:])
+		return binary.BigEndian.Uint64(buf[:])
+	}
+	loValue, hiValue := suffixValue(lo), suffixValue(hi)
+	if hiValue <= loValue {
+		return nil
+	}
+	step := (hiValue - loValue) / uint64(n+1)
+	var res []roachpb.Key
+	for i := 1; i <= n && step > 0; i++ {
+		key := make(roachpb.Key, prefixLen, prefixLen+8)
+		copy(key, lo[:prefixLen])
+		res = append(res, binary.BigEndian.AppendUint64(key, loValue+uint64(i)*step))
+	}
+	return res
+}
+
+// subSplitSpans splits the given non-overlapping spans at some of the sorted
+// split keys so that there are roughly target spans in total. Only the keys
+// strictly within the spans are used, and they are picked evenly among all
+// such keys. The spans are returned unchanged if there are already at least
+// target of them.
+func subSplitSpans(spans roachpb.Spans, splitKeys []roachpb.Key, target int) roachpb.Spans {
+	if len(spans) >= target || len(splitKeys) == 0 {
+		return spans
+	}
+	// Find the split keys within each span.
+	keysWithin := make([][]roachpb.Key, len(spans))
+	var numCandidates int
+	for i, sp := range spans {
+		lo := sort.Search(len(splitKeys), func(j int) bool {
+			return splitKeys[j].Compare(sp.Key) > 0
+		})
+		hi := sort.Search(len(splitKeys), func(j int) bool {
+			return splitKeys[j].Compare(sp.EndKey) >= 0
+		})
+		if lo < hi {
+			keysWithin[i] = splitKeys[lo:hi]
+			numCandidates += hi - lo
+		}
+	}
+	if numCandidates == 0 {
+		return spans
+	}
+	numSplits := target - len(spans)
+	if numSplits > numCandidates {
+		numSplits = numCandidates
+	}
+	res := make(roachpb.Spans, 0, len(spans)+numSplits)
+	var ordinal int
+	for i, sp := range spans {
+		for _, key := range keysWithin[i] {
+			ordinal++
+			// Pick the candidate if it is the first one to reach the next
+			// multiple of numCandidates/(numSplits+1).
+			if ordinal*(numSplits+1)/(numCandidates+1) == (ordinal-1)*(numSplits+1)/(numCandidates+1) {
+				continue
+			}
+			res = append(res, roachpb.Span{Key: sp.Key, EndKey: key})
+			sp.Key = key
+		}
+		res = append(res, sp)
+	}
+	return res
+}
+
 type spanAndIndex struct {
 	roachpb.Span
 	idx int
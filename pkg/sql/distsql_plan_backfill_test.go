@@ -12,11 +12,13 @@ package sql
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/desctestutils"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
@@ -25,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDistBackfill(t *testing.T) {
@@ -105,3 +108,146 @@ func TestDistBackfill(t *testing.T) {
 		curr = str[0]
 	}
 }
+
+func TestSubSplitSpans(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	sp := func(start, end string) roachpb.Span {
+		return roachpb.Span{Key: roachpb.Key(start), EndKey: roachpb.Key(end)}
+	}
+	var splitKeys []roachpb.Key
+	for _, k := range []string{"b", "c", "d", "e", "f", "g", "h", "i", "j", "k"} {
+		splitKeys = append(splitKeys, roachpb.Key(k))
+	}
+
+	testCases := []struct {
+		spans    roachpb.Spans
+		target   int
+		expected roachpb.Spans
+	}{
+		{
+			// Already enough spans.
+			spans:    roachpb.Spans{sp("a", "c"), sp("c", "z")},
+			target:   2,
+			expected: roachpb.Spans{sp("a", "c"), sp("c", "z")},
+		},
+		{
+			// The split keys are picked evenly.
+			spans:    roachpb.Spans{sp("a", "z")},
+			target:   4,
+			expected: roachpb.Spans{sp("a", "d"), sp("d", "g"), sp("g", "j"), sp("j", "z")},
+		},
+		{
+			// Only the keys strictly within the spans are used.
+			spans:    roachpb.Spans{sp("a", "c"), sp("k", "z")},
+			target:   10,
+			expected: roachpb.Spans{sp("a", "b"), sp("b", "c"), sp("k", "z")},
+		},
+		{
+			// No split keys within the spans.
+			spans:    roachpb.Spans{sp("x", "z")},
+			target:   4,
+			expected: roachpb.Spans{sp("x", "z")},
+		},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, subSplitSpans(tc.spans, splitKeys, tc.target))
+	}
+}
+
+func TestInterpolateKeys(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	keys := func(ks ...string) []roachpb.Key {
+		var res []roachpb.Key
+		for _, k := range ks {
+			res = append(res, roachpb.Key(k))
+		}
+		return res
+	}
+	testCases := []struct {
+		lo, hi   string
+		n        int
+		expected []roachpb.Key
+	}{
+		{
+			// The keys are evenly spaced after the common prefix.
+			lo: "p\x00", hi: "p\x08", n: 3,
+			expected: keys("p\x02\x00\x00\x00\x00\x00\x00\x00", "p\x04\x00\x00\x00\x00\x00\x00\x00",
+				"p\x06\x00\x00\x00\x00\x00\x00\x00"),
+		},
+		{
+			// The keys are too close to each other when only the first eight
+			// bytes following the common prefix are considered.
+			lo: "p\x00\xff\xff\xff\xff\xff\xff\xff\x01", hi: "p\x01\x00\x00\x00\x00\x00\x00\x00", n: 3,
+		},
+		{
+			// The keys must be ordered.
+			lo: "b", hi: "a", n: 3,
+		},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, interpolateKeys(roachpb.Key(tc.lo), roachpb.Key(tc.hi), tc.n))
+	}
+}
+
+// TestDistBackfillMultipleWorkers runs index backfills of a table that consists
+// of a single range with multiple workers per index backfiller, so that the
+// range is sub-split using keys sampled from the table or the histogram on the
+// primary key.
+func TestDistBackfillMultipleWorkers(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	n := 10000
+	if util.RaceEnabled {
+		// Race builds are a lot slower, so use a smaller number of rows.
+		n = 1000
+	}
+
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{
+		UseDatabase: "test",
+		Knobs: base.TestingKnobs{
+			SQLSchemaChanger: &SchemaChangerTestingKnobs{
+				// Aggressively write checkpoints, so that we test checkpointing
+				// of the sub-split spans while the backfill is progressing.
+				WriteCheckpointInterval: time.Nanosecond,
+			},
+		},
+	})
+	defer s.Stopper().Stop(context.Background())
+	r := sqlutils.MakeSQLRunner(db)
+	r.Exec(t, `SET CLUSTER SETTING sql.stats.automatic_collection.enabled = false`)
+	r.Exec(t, `SET CLUSTER SETTING bulkio.index_backfill.num_workers = 4`)
+	r.Exec(t, `CREATE DATABASE test`)
+	r.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v INT, w INT)`)
+	r.Exec(t, `INSERT INTO t SELECT i, i % 100, i FROM generate_series(1, $1) AS g(i)`, n)
+
+	// Without statistics, the keys for sub-splitting are sampled from the table.
+	r.Exec(t, `CREATE INDEX t_w_idx ON t (w)`)
+	r.CheckQueryResults(t,
+		`SELECT count(*) FROM (SELECT k, w FROM t@t_pkey EXCEPT ALL SELECT k, w FROM t@t_w_idx)`,
+		[][]string{{"0"}},
+	)
+	r.Exec(t, `DROP INDEX t_w_idx`)
+
+	// The histogram on the primary key provides the keys for sub-splitting.
+	r.Exec(t, `CREATE STATISTICS s FROM t`)
+
+	r.Exec(t, `CREATE INDEX t_v_idx ON t (v)`)
+	r.CheckQueryResults(t, `SELECT count(*) FROM t@t_v_idx`, [][]string{{fmt.Sprint(n)}})
+	r.CheckQueryResults(t,
+		`SELECT count(*) FROM (SELECT k, v FROM t@t_pkey EXCEPT ALL SELECT k, v FROM t@t_v_idx)`,
+		[][]string{{"0"}},
+	)
+
+	// Duplicates are detected even if they are built by different workers.
+	r.Exec(t, `UPDATE t SET w = 1 WHERE k = $1`, n)
+	r.ExpectErr(t, `duplicate key value`, `CREATE UNIQUE INDEX t_w_key ON t (w)`)
+
+	r.Exec(t, `UPDATE t SET w = k WHERE k = $1`, n)
+	r.Exec(t, `CREATE UNIQUE INDEX t_w_key ON t (w)`)
+	r.CheckQueryResults(t, `SELECT count(*) FROM t@t_w_key`, [][]string{{fmt.Sprint(n)}})
+}
@@ -45,6 +45,8 @@ type requestedStat struct {
 	histogramMaxBuckets uint32
 	name                string
 	inverted            bool
+	dependencies        bool
+	mostCommonValues    bool
 }
 
 // histogramSamples is the number of sample rows to be collected for histogram
@@ -537,6 +539,9 @@ func (dsp *DistSQLPlanner) createStatsPlan(
 			HistogramMaxBuckets: s.histogramMaxBuckets,
 			Columns:             make([]uint32, len(s.columns)),
 			StatName:            s.name,
+			// Extended statistics only make sense for multi-column stats.
+			GenerateDependencies:     s.dependencies && len(s.columns) > 1,
+			GenerateMostCommonValues: s.mostCommonValues && len(s.columns) > 1,
 		}
 		for i, colID := range s.columns {
 			colIdx, ok := colIdxMap.Get(colID)
@@ -616,6 +621,8 @@ func (dsp *DistSQLPlanner) createPlanForCreateStats(
 			histogramMaxBuckets: histogramMaxBuckets,
 			name:                details.Name,
 			inverted:            details.ColumnStats[i].Inverted,
+			dependencies:        details.ColumnStats[i].HasDependencies,
+			mostCommonValues:    details.ColumnStats[i].HasMostCommonValues,
 		}
 	}
 
@@ -1665,18 +1665,26 @@ func (dsp *DistSQLPlanner) PlanAndRunAll(
 		// return from this method (after the main query is executed).
 		subqueryResultMemAcc := planner.Mon().MakeBoundAccount()
 		defer subqueryResultMemAcc.Close(ctx)
-		if !dsp.PlanAndRunSubqueries(
-			ctx,
-			planner,
-			func() *extendedEvalContext { return evalCtxFactory(false /* usedConcurrently */) },
-			planner.curPlan.subqueryPlans,
-			recv,
-			&subqueryResultMemAcc,
-			// Skip the diagram generation since on this "main" query path we
-			// can get it via the statement bundle.
-			true,  /* skipDistSQLDiagramGeneration */
-			false, /* mustUseLeafTxn */
-		) {
+		var ok bool
+		if dsp.shouldRunSubqueriesInParallel(planner) {
+			ok = dsp.planAndRunSubqueriesInParallel(
+				ctx, planner, evalCtxFactory, planner.curPlan.subqueryPlans, recv, &subqueryResultMemAcc,
+			)
+		} else {
+			ok = dsp.PlanAndRunSubqueries(
+				ctx,
+				planner,
+				func() *extendedEvalContext { return evalCtxFactory(false /* usedConcurrently */) },
+				planner.curPlan.subqueryPlans,
+				recv,
+				&subqueryResultMemAcc,
+				// Skip the diagram generation since on this "main" query path we
+				// can get it via the statement bundle.
+				true,  /* skipDistSQLDiagramGeneration */
+				false, /* mustUseLeafTxn */
+			)
+		}
+		if !ok {
 			return recv.commErr
 		}
 	}
@@ -1743,6 +1751,9 @@ func (dsp *DistSQLPlanner) PlanAndRunSubqueries(
 	skipDistSQLDiagramGeneration bool,
 	mustUseLeafTxn bool,
 ) bool {
+	getSaveFlowsFunc := func() func(map[base.SQLInstanceID]*execinfrapb.FlowSpec, execopnode.OpChains, []execinfra.LocalProcessor, bool) error {
+		return getDefaultSaveFlowsFunc(ctx, planner, planComponentTypeSubquery)
+	}
 	for planIdx, subqueryPlan := range subqueryPlans {
 		if err := dsp.planAndRunSubquery(
 			ctx,
@@ -1755,6 +1766,9 @@ func (dsp *DistSQLPlanner) PlanAndRunSubqueries(
 			subqueryResultMemAcc,
 			skipDistSQLDiagramGeneration,
 			mustUseLeafTxn,
+			getSaveFlowsFunc,
+			planner.instrumentation.getAssociateNodeWithComponentsFn(),
+			recv.stats.add,
 		); err != nil {
 			recv.SetError(err)
 			return false
@@ -1768,6 +1782,12 @@ func (dsp *DistSQLPlanner) PlanAndRunSubqueries(
 // subquery's evaluation will be registered with. It is the caller's
 // responsibility to shrink it (or close it) accordingly, once the references to
 // those results are lost.
+//
+// If the subquery runs concurrently with other subqueries, then
+// getSaveFlowsFunc, associateNodeWithComponents, and addTopLevelQueryStats must
+// be concurrency-safe (if non-nil), and subqueryResultMemAcc must not be shared
+// with the other subqueries. getSaveFlowsFunc will only be called if
+// planner.instrumentation.ShouldSaveFlows() returns true.
 func (dsp *DistSQLPlanner) planAndRunSubquery(
 	ctx context.Context,
 	planIdx int,
@@ -1779,10 +1799,13 @@ func (dsp *DistSQLPlanner) planAndRunSubquery(
 	subqueryResultMemAcc *mon.BoundAccount,
 	skipDistSQLDiagramGeneration bool,
 	mustUseLeafTxn bool,
+	getSaveFlowsFunc func() func(map[base.SQLInstanceID]*execinfrapb.FlowSpec, execopnode.OpChains, []execinfra.LocalProcessor, bool) error,
+	associateNodeWithComponents func(exec.Node, execComponents),
+	addTopLevelQueryStats func(stats *topLevelQueryStats),
 ) error {
 	distributeSubquery := getPlanDistribution(
 		ctx, planner.Descriptors().HasUncommittedTypes(),
-		planner.SessionData().DistSQLMode, subqueryPlan.plan,
+		planner.SessionData().DistSQLMode, nil /* costs */, subqueryPlan.plan,
 	).WillDistribute()
 	distribute := DistributionType(LocalDistribution)
 	if distributeSubquery {
@@ -1794,9 +1817,9 @@ func (dsp *DistSQLPlanner) planAndRunSubquery(
 	subqueryPlanCtx.subOrPostQuery = true
 	subqueryPlanCtx.mustUseLeafTxn = mustUseLeafTxn
 	if planner.instrumentation.ShouldSaveFlows() {
-		subqueryPlanCtx.saveFlows = getDefaultSaveFlowsFunc(ctx, planner, planComponentTypeSubquery)
+		subqueryPlanCtx.saveFlows = getSaveFlowsFunc()
 	}
-	subqueryPlanCtx.associateNodeWithComponents = planner.instrumentation.getAssociateNodeWithComponentsFn()
+	subqueryPlanCtx.associateNodeWithComponents = associateNodeWithComponents
 	subqueryPlanCtx.collectExecStats = planner.instrumentation.ShouldCollectExecStats()
 	subqueryPhysPlan, physPlanCleanup, err := dsp.createPhysPlan(ctx, subqueryPlanCtx, subqueryPlan.plan)
 	defer physPlanCleanup()
@@ -1810,7 +1833,7 @@ func (dsp *DistSQLPlanner) planAndRunSubquery(
 	// of the results stored in the container depends on the type of the subquery.
 	subqueryRecv := recv.clone()
 	defer subqueryRecv.Release()
-	defer recv.stats.add(&subqueryRecv.stats)
+	defer addTopLevelQueryStats(&subqueryRecv.stats)
 	var typs []*types.T
 	if subqueryPlan.execMode == rowexec.SubqueryExecModeExists {
 		subqueryRecv.existsMode = true
@@ -1925,6 +1948,260 @@ func (dsp *DistSQLPlanner) planAndRunSubquery(
 	return nil
 }
 
+// parallelSubqueriesConcurrencyLimit controls the maximum number of additional
+// goroutines that can be used to run subqueries in parallel.
+var parallelSubqueriesConcurrencyLimit = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.distsql.parallelize_subqueries.concurrency_limit",
+	"maximum number of additional goroutines to run independent subqueries in parallel",
+	// The default here mirrors the one of the parallel checks.
+	int64(16*runtime.GOMAXPROCS(0)),
+	settings.NonNegativeInt,
+)
+
+// shouldRunSubqueriesInParallel returns whether the subqueries of the current
+// plan should be considered for the concurrent evaluation. We only do so when
+// enabled via the session variable, when we have multiple subqueries, and when
+// the statement has no side effects (i.e. no mutations nor locking).
+func (dsp *DistSQLPlanner) shouldRunSubqueriesInParallel(planner *planner) bool {
+	return planner.SessionData().MaxParallelSubqueries > 1 &&
+		len(planner.curPlan.subqueryPlans) > 1 &&
+		!planner.curPlan.flags.IsSet(planFlagContainsMutation) &&
+		!planner.curPlan.flags.IsSet(planFlagContainsLocking) &&
+		dsp.parallelSubqueriesSem.ApproximateQuota() > 0
+}
+
+// subqueryCanRunInParallel returns whether the given subquery plan can be run
+// concurrently with other subqueries. This is the case when the whole plan
+// (except for the buffer at the root of a WITH clause) is supported natively
+// by DistSQL: this guarantees that no planNode that might use the RootTxn (e.g.
+// via the internal executor) is wrapped into the flow and that no routines are
+// evaluated.
+func subqueryCanRunInParallel(plan planMaybePhysical) bool {
+	// At the moment, we rely on not using the newer DistSQL spec factory to
+	// enable parallelization.
+	if plan.isPhysicalPlan() {
+		return false
+	}
+	n := plan.planNode
+	if b, ok := n.(*bufferNode); ok {
+		n = b.plan
+	}
+	_, err := checkSupportForPlanNode(n)
+	return err == nil
+}
+
+// planAndRunSubqueriesInParallel is similar to PlanAndRunSubqueries but
+// evaluates the subqueries that don't depend on each other concurrently. The
+// subqueries are processed in order, in "waves": each wave consists of
+// consecutive subqueries that can run in parallel and that don't depend on
+// other subqueries of the same wave, and it has at most max_parallel_subqueries
+// members. All subqueries of a wave are done before the next wave starts.
+func (dsp *DistSQLPlanner) planAndRunSubqueriesInParallel(
+	ctx context.Context,
+	planner *planner,
+	evalCtxFactory func(usedConcurrently bool) *extendedEvalContext,
+	subqueryPlans []subquery,
+	recv *DistSQLReceiver,
+	subqueryResultMemAcc *mon.BoundAccount,
+) bool {
+	maxParallel := int(planner.SessionData().MaxParallelSubqueries)
+	canRunInParallel := make([]bool, len(subqueryPlans))
+	for i := range subqueryPlans {
+		canRunInParallel[i] = subqueryCanRunInParallel(subqueryPlans[i].plan)
+	}
+	getSaveFlowsFunc := func() func(map[base.SQLInstanceID]*execinfrapb.FlowSpec, execopnode.OpChains, []execinfra.LocalProcessor, bool) error {
+		return getDefaultSaveFlowsFunc(ctx, planner, planComponentTypeSubquery)
+	}
+	for start := 0; start < len(subqueryPlans); {
+		end := start + 1
+		if canRunInParallel[start] {
+			for end < len(subqueryPlans) && end-start < maxParallel && canRunInParallel[end] {
+				// All dependencies of a subquery have smaller ordinals, so we
+				// only need to check whether it depends on any subquery in
+				// [start, end).
+				if _, dependsOnWave := subqueryPlans[end].deps.Next(start); dependsOnWave {
+					break
+				}
+				end++
+			}
+		}
+		var err error
+		if end-start == 1 {
+			err = dsp.planAndRunSubquery(
+				ctx,
+				start,
+				subqueryPlans[start],
+				planner,
+				evalCtxFactory(false /* usedConcurrently */),
+				subqueryPlans,
+				recv,
+				subqueryResultMemAcc,
+				true,  /* skipDistSQLDiagramGeneration */
+				false, /* mustUseLeafTxn */
+				getSaveFlowsFunc,
+				planner.instrumentation.getAssociateNodeWithComponentsFn(),
+				recv.stats.add,
+			)
+		} else {
+			err = dsp.planAndRunSubqueryWave(
+				ctx, start, end, planner, evalCtxFactory, subqueryPlans, recv, subqueryResultMemAcc,
+			)
+		}
+		if err != nil {
+			recv.SetError(err)
+			return false
+		}
+		start = end
+	}
+	return true
+}
+
+// planAndRunSubqueryWave executes subqueries in [start, end) concurrently. The
+// function blocks until all subqueries that start executing return (i.e. when
+// this function returns, it is guaranteed that the txn is no longer used by the
+// subqueries).
+//
+// Note that it is assumed that all subquery plans use the old planNode
+// representation.
+func (dsp *DistSQLPlanner) planAndRunSubqueryWave(
+	ctx context.Context,
+	start, end int,
+	planner *planner,
+	evalCtxFactory func(usedConcurrently bool) *extendedEvalContext,
+	subqueryPlans []subquery,
+	recv *DistSQLReceiver,
+	subqueryResultMemAcc *mon.BoundAccount,
+) error {
+	ctx, cancelCtx := context.WithCancel(ctx)
+	defer cancelCtx()
+
+	// Similar to the parallel checks, we use a single mutex to synchronize
+	// the operations that aren't concurrency-safe.
+	var mu syncutil.Mutex
+	var getSaveFlowsFunc func() func(map[base.SQLInstanceID]*execinfrapb.FlowSpec, execopnode.OpChains, []execinfra.LocalProcessor, bool) error
+	if planner.instrumentation.ShouldSaveFlows() {
+		getSaveFlowsFunc = func() func(map[base.SQLInstanceID]*execinfrapb.FlowSpec, execopnode.OpChains, []execinfra.LocalProcessor, bool) error {
+			fn := getDefaultSaveFlowsFunc(ctx, planner, planComponentTypeSubquery)
+			return func(flowSpec map[base.SQLInstanceID]*execinfrapb.FlowSpec, opChains execopnode.OpChains, localProcessors []execinfra.LocalProcessor, vectorized bool) error {
+				mu.Lock()
+				defer mu.Unlock()
+				return fn(flowSpec, opChains, localProcessors, vectorized)
+			}
+		}
+	}
+	var associateNodeWithComponents func(exec.Node, execComponents)
+	if fn := planner.instrumentation.getAssociateNodeWithComponentsFn(); fn != nil {
+		associateNodeWithComponents = func(node exec.Node, components execComponents) {
+			mu.Lock()
+			defer mu.Unlock()
+			fn(node, components)
+		}
+	}
+	addTopLevelQueryStats := func(other *topLevelQueryStats) {
+		mu.Lock()
+		defer mu.Unlock()
+		recv.stats.add(other)
+	}
+
+	// Memory accounts aren't safe for concurrent use, so each subquery
+	// registers its result with a separate account. Once all subqueries are
+	// done, the memory is transferred to subqueryResultMemAcc.
+	memAccs := make([]mon.BoundAccount, end-start)
+	for i := range memAccs {
+		memAccs[i] = planner.Mon().MakeBoundAccount()
+	}
+	defer func() {
+		for i := range memAccs {
+			memAccs[i].Close(ctx)
+		}
+	}()
+
+	// We track errors according to the corresponding subqueries in order to
+	// return the error for the "earliest" one.
+	errs := make([]error, end-start)
+	runSubquery := func(ctx context.Context, planIdx int) {
+		log.VEventf(ctx, 3, "begin subquery %d", planIdx)
+		errs[planIdx-start] = dsp.planAndRunSubquery(
+			ctx,
+			planIdx,
+			subqueryPlans[planIdx],
+			planner,
+			evalCtxFactory(true /* usedConcurrently */),
+			subqueryPlans,
+			recv,
+			&memAccs[planIdx-start],
+			true, /* skipDistSQLDiagramGeneration */
+			true, /* mustUseLeafTxn */
+			getSaveFlowsFunc,
+			associateNodeWithComponents,
+			addTopLevelQueryStats,
+		)
+		log.VEventf(ctx, 3, "end subquery %d", planIdx)
+	}
+
+	// Determine the concurrency we're allowed to use based on the node-wide
+	// semaphore. We will always run at least one subquery in the current
+	// goroutine.
+	numParallel := end - start - 1
+	if quota := int(dsp.parallelSubqueriesSem.ApproximateQuota()); numParallel > quota {
+		numParallel = quota
+	}
+	for numParallel > 0 {
+		alloc, err := dsp.parallelSubqueriesSem.TryAcquire(ctx, uint64(numParallel))
+		if err == nil {
+			defer alloc.Release()
+			break
+		}
+		numParallel--
+	}
+
+	log.VEventf(
+		ctx, 2, "executing %d subqueries in parallel and %d serially",
+		numParallel, end-start-numParallel,
+	)
+
+	var wg sync.WaitGroup
+	for i := start; i < start+numParallel; i++ {
+		planIdx := i
+		wg.Add(1)
+		if err := dsp.stopper.RunAsyncTaskEx(
+			ctx,
+			stop.TaskOpts{
+				TaskName: "parallel-subquery-runner",
+				SpanOpt:  stop.ChildSpan,
+			},
+			func(ctx context.Context) {
+				defer wg.Done()
+				runSubquery(ctx, planIdx)
+			}); err != nil {
+			// The server is quiescing, so we just make sure to wait for all
+			// already started subqueries to complete after canceling them.
+			cancelCtx()
+			wg.Done()
+			wg.Wait()
+			return err
+		}
+	}
+	for planIdx := start + numParallel; planIdx < end; planIdx++ {
+		runSubquery(ctx, planIdx)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	for i := range memAccs {
+		used := memAccs[i].Used()
+		memAccs[i].Clear(ctx)
+		if err := subqueryResultMemAcc.Grow(ctx, used); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 var distributedQueryRerunAsLocalEnabled = settings.RegisterBoolSetting(
 	settings.ApplicationLevel,
 	"sql.distsql.distributed_query_rerun_locally.enabled",
@@ -2278,7 +2555,7 @@ func (dsp *DistSQLPlanner) planAndRunPostquery(
 ) error {
 	distributePostquery := getPlanDistribution(
 		ctx, planner.Descriptors().HasUncommittedTypes(),
-		planner.SessionData().DistSQLMode, postqueryPlan,
+		planner.SessionData().DistSQLMode, nil /* costs */, postqueryPlan,
 	).WillDistribute()
 	distribute := DistributionType(LocalDistribution)
 	if distributePostquery {
@@ -67,6 +67,7 @@ func constructPlan(
 			}
 			out.expanded = true
 			out.rowCount = in.RowCount
+			out.deps = in.Deps
 			assignPlan(&out.plan, in.Root)
 		}
 	}
@@ -76,6 +76,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/xform"
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
@@ -85,7 +86,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirecancel"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/planbaseline"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/scheduledlogging"
@@ -1272,6 +1275,10 @@ type ExecutorConfig struct {
 	TableStatsCache    *stats.TableStatisticsCache
 	StatsRefresher     *stats.Refresher
 	QueryCache         *querycache.C
+	// ResultCache caches the results of read-only statements executed with
+	// the result_cache_enabled session variable or a RESULT_CACHE hint. It may
+	// be nil.
+	ResultCache *resultcache.Cache
 
 	SchemaChangerMetrics *SchemaChangerMetrics
 	FeatureFlagMetrics   *featureflag.DenialMetrics
@@ -1327,6 +1334,9 @@ type ExecutorConfig struct {
 	// StmtDiagnosticsRecorder deals with recording statement diagnostics.
 	StmtDiagnosticsRecorder *stmtdiagnostics.Registry
 
+	// PlanBaselines maintains the plan baselines used to pin query plans.
+	PlanBaselines *planbaseline.Registry
+
 	ExternalIODirConfig base.ExternalIODirConfig
 
 	GCJobNotifier *gcjobnotifier.Notifier
@@ -1859,6 +1869,72 @@ func shouldDistributeGivenRecAndMode(
 	panic(errors.AssertionFailedf("unhandled distsql mode %v", mode))
 }
 
+// costBasedDistribution controls whether the distsql auto mode distributes a
+// plan based on its estimated cost.
+var costBasedDistribution = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.distsql.cost_based_distribution.enabled",
+	"when true, the distsql auto mode distributes a plan only if that is estimated "+
+		"to be cheaper than running it on the gateway",
+	true,
+)
+
+// distributedPlanSetupCost is the estimated fixed cost of running a plan with
+// remote flows, expressed as the number of rows that could be processed on the
+// gateway in the same time. It accounts for setting up the flows on the remote
+// nodes and for the additional network round trips.
+var distributedPlanSetupCost = settings.RegisterFloatSetting(
+	settings.ApplicationLevel,
+	"sql.distsql.cost_based_distribution.setup_cost",
+	"the estimated fixed cost of running a distributed plan, expressed as the "+
+		"number of rows that could be processed on the gateway in the same time",
+	10000,
+	settings.NonNegativeFloat,
+)
+
+// distributedPlanSpeedup is the factor by which processing the rows read by a
+// plan is assumed to get faster when the plan is distributed. The number of
+// nodes that will take part in the plan is not known until physical planning,
+// so the default is a conservative estimate.
+var distributedPlanSpeedup = settings.RegisterFloatSetting(
+	settings.ApplicationLevel,
+	"sql.distsql.cost_based_distribution.speedup",
+	"the factor by which processing the rows read by a plan is assumed to get "+
+		"faster when the plan is distributed",
+	2,
+	settings.FloatWithMinimum(1),
+)
+
+// planDistributionCosts are the estimated costs of running the main query of
+// a plan on the gateway and distributed, expressed as numbers of rows
+// processed on the gateway.
+type planDistributionCosts struct {
+	rowsRead    float64
+	local       float64
+	distributed float64
+}
+
+// makePlanDistributionCosts computes the planDistributionCosts from the
+// optimizer's estimates. Running the plan on the gateway costs processing all
+// the rows read and transferring the ones read in remote regions to the
+// gateway. A distributed plan processes the rows faster, and it only transfers
+// the rows that the plan returns, since the others are filtered or aggregated
+// away where they are read. However, it has a fixed setup cost.
+func makePlanDistributionCosts(
+	est xform.DistributionEstimate, sv *settings.Values,
+) planDistributionCosts {
+	transferredFraction := 1.0
+	if est.RowsRead > 0 && est.RowsReturned < est.RowsRead {
+		transferredFraction = est.RowsReturned / est.RowsRead
+	}
+	return planDistributionCosts{
+		rowsRead: est.RowsRead,
+		local:    est.RowsRead + est.NetworkTransferCost,
+		distributed: est.RowsRead/distributedPlanSpeedup.Get(sv) +
+			distributedPlanSetupCost.Get(sv) + est.NetworkTransferCost*transferredFraction,
+	}
+}
+
 // getPlanDistribution returns the PlanDistribution that plan will have. If
 // plan already has physical representation, then the stored PlanDistribution
 // is reused, but if plan has logical representation (i.e. it is a planNode
@@ -1873,43 +1949,85 @@ func shouldDistributeGivenRecAndMode(
 // remote node to the gateway.
 // TODO(yuzefovich): this will be easy to solve once the DistSQL spec factory is
 // completed but is quite annoying to do at the moment.
+//
+// If costs is non-nil and the distsql mode is auto, then a plan that can be
+// distributed is distributed if that is estimated to be cheaper (see
+// makePlanDistributionCosts). costs is nil when cost-based distribution is
+// disabled or the optimizer has no estimates for the plan, in which case the
+// heuristics are used.
 func getPlanDistribution(
 	ctx context.Context,
 	txnHasUncommittedTypes bool,
 	distSQLMode sessiondatapb.DistSQLExecMode,
+	costs *planDistributionCosts,
 	plan planMaybePhysical,
 ) physicalplan.PlanDistribution {
+	distribution, _ := getPlanDistributionWithReason(
+		ctx, txnHasUncommittedTypes, distSQLMode, costs, plan,
+	)
+	return distribution
+}
+
+// getPlanDistributionWithReason is like getPlanDistribution, but it also
+// returns a human-readable explanation of why the plan was or was not
+// distributed. The explanation is shown by EXPLAIN (DISTRIBUTION).
+func getPlanDistributionWithReason(
+	ctx context.Context,
+	txnHasUncommittedTypes bool,
+	distSQLMode sessiondatapb.DistSQLExecMode,
+	costs *planDistributionCosts,
+	plan planMaybePhysical,
+) (_ physicalplan.PlanDistribution, reason string) {
 	if plan.isPhysicalPlan() {
-		return plan.physPlan.Distribution
+		return plan.physPlan.Distribution, "determined by the physical planner"
 	}
 
 	// If this transaction has modified or created any types, it is not safe to
 	// distribute due to limitations around leasing descriptors modified in the
 	// current transaction.
 	if txnHasUncommittedTypes {
-		return physicalplan.LocalPlan
+		return physicalplan.LocalPlan, "transaction has modified or created types"
 	}
 
 	if distSQLMode == sessiondatapb.DistSQLOff {
-		return physicalplan.LocalPlan
+		return physicalplan.LocalPlan, "distsql session setting is off"
 	}
 
 	// Don't try to run empty nodes (e.g. SET commands) with distSQL.
 	if _, ok := plan.planNode.(*zeroNode); ok {
-		return physicalplan.LocalPlan
+		return physicalplan.LocalPlan, "plan produces no rows"
 	}
 
 	rec, err := checkSupportForPlanNode(plan.planNode)
 	if err != nil {
 		// Don't use distSQL for this request.
 		log.VEventf(ctx, 1, "query not supported for distSQL: %s", err)
-		return physicalplan.LocalPlan
+		return physicalplan.LocalPlan, fmt.Sprintf("plan cannot be distributed: %s", err)
+	}
+
+	if costs != nil && distSQLMode == sessiondatapb.DistSQLAuto && rec != cannotDistribute {
+		if costs.distributed < costs.local {
+			return physicalplan.FullyDistributedPlan, fmt.Sprintf(
+				"distributed execution is estimated to be cheaper for %.0f rows read", costs.rowsRead,
+			)
+		}
+		return physicalplan.LocalPlan, fmt.Sprintf(
+			"local execution is estimated to be cheaper for %.0f rows read", costs.rowsRead,
+		)
 	}
 
 	if shouldDistributeGivenRecAndMode(rec, distSQLMode) {
-		return physicalplan.FullyDistributedPlan
+		if distSQLMode == sessiondatapb.DistSQLAuto {
+			return physicalplan.FullyDistributedPlan,
+				"plan contains operations that benefit from distribution"
+		}
+		return physicalplan.FullyDistributedPlan,
+			fmt.Sprintf("distsql session setting is %s", distSQLMode)
 	}
-	return physicalplan.LocalPlan
+	if rec == cannotDistribute {
+		return physicalplan.LocalPlan, "plan contains operations that cannot be distributed"
+	}
+	return physicalplan.LocalPlan, "no operation in the plan benefits from distribution"
 }
 
 // golangFillQueryArguments transforms Go values into datums.
@@ -3748,6 +3866,30 @@ func (m *sessionDataMutator) SetOptimizerUseVirtualComputedColumnStats(val bool)
 	m.data.OptimizerUseVirtualComputedColumnStats = val
 }
 
+func (m *sessionDataMutator) SetOptimizerUseExtendedStats(val bool) {
+	m.data.OptimizerUseExtendedStats = val
+}
+
+func (m *sessionDataMutator) SetPlanCacheMode(val sessiondatapb.PlanCacheMode) {
+	m.data.PlanCacheMode = val
+}
+
+func (m *sessionDataMutator) SetAdaptiveJoinRowThreshold(val int64) {
+	m.data.AdaptiveJoinRowThreshold = val
+}
+
+func (m *sessionDataMutator) SetMaxParallelSubqueries(val int64) {
+	m.data.MaxParallelSubqueries = val
+}
+
+func (m *sessionDataMutator) SetOptimizerUseNetworkTransferCost(val bool) {
+	m.data.OptimizerUseNetworkTransferCost = val
+}
+
+func (m *sessionDataMutator) SetResultCacheEnabled(val bool) {
+	m.data.ResultCacheEnabled = val
+}
+
 // Utility functions related to scrubbing sensitive information on SQL Stats.
 
 // quantizeCounts ensures that the Count field in the
@@ -93,6 +93,9 @@ var _ redact.SafeFormatter = (*ComponentStats)(nil)
 // SafeValue implements redact.SafeValue.
 func (ComponentID_Type) SafeValue() {}
 
+// SafeValue implements redact.SafeValue.
+func (AdaptiveJoinStats_Strategy) SafeValue() {}
+
 // SafeFormat implements redact.SafeFormatter.
 func (s *ComponentStats) SafeFormat(w redact.SafePrinter, _ rune) {
 	w.Printf("ComponentStats{ID: %v", s.Component)
@@ -201,6 +204,14 @@ func (s *ComponentStats) formatStats(fn func(suffix string, value interface{}))
 		fn("used streamer", nil)
 	}
 
+	// Adaptive join stats.
+	if s.AdaptiveJoin.Strategy != AdaptiveJoinStats_UNDECIDED {
+		fn("adaptive join strategy", s.AdaptiveJoin.Strategy)
+	}
+	if s.AdaptiveJoin.BufferedRows.HasValue() {
+		fn("adaptive join buffered rows", humanizeutil.Count(s.AdaptiveJoin.BufferedRows.Value()))
+	}
+
 	// Exec stats.
 	if s.Exec.ExecTime.HasValue() {
 		fn("execution time", humanizeutil.Duration(s.Exec.ExecTime.Value()))
@@ -321,6 +332,11 @@ func (s *ComponentStats) Union(other *ComponentStats) *ComponentStats {
 		result.KV.KVPairsRead = other.KV.KVPairsRead
 	}
 
+	// Adaptive join stats.
+	if result.AdaptiveJoin.Strategy == AdaptiveJoinStats_UNDECIDED {
+		result.AdaptiveJoin = other.AdaptiveJoin
+	}
+
 	// Exec stats.
 	if !result.Exec.ExecTime.HasValue() {
 		result.Exec.ExecTime = other.Exec.ExecTime
@@ -104,6 +104,17 @@ rows output: 100`,
 input rows: 100
 input stall time: 0µs`,
 		},
+		{ // 7
+			stats: ComponentStats{
+				AdaptiveJoin: AdaptiveJoinStats{
+					Strategy:     AdaptiveJoinStats_HASH_JOIN,
+					BufferedRows: optional.MakeUint(101),
+				},
+			},
+			expected: `
+adaptive join strategy: HASH_JOIN
+adaptive join buffered rows: 101`,
+		},
 	}
 
 	for i, tc := range testCases {
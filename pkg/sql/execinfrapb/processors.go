@@ -381,6 +381,36 @@ func (spec *JoinReaderSpec) IsIndexJoin() bool {
 	return len(spec.LookupColumns) == 0 && spec.LookupExpr.Empty()
 }
 
+// LookupColumnFetchedOrdinals returns, for each of the LookupColumns, the
+// ordinal among the fetched columns of the index key column that it is looked
+// up in. ok is false if any of those key columns is not fetched or is
+// composite (values of composite types that are equal can have different
+// representations, so we don't rely on them being joined the same way by the
+// hash joiner).
+func (spec *JoinReaderSpec) LookupColumnFetchedOrdinals() (ords []uint32, ok bool) {
+	keyCols := spec.FetchSpec.KeyFullColumns()
+	if len(spec.LookupColumns) > len(keyCols) {
+		return nil, false
+	}
+	ords = make([]uint32, len(spec.LookupColumns))
+	for i := range spec.LookupColumns {
+		if keyCols[i].IsComposite {
+			return nil, false
+		}
+		found := false
+		for j := range spec.FetchSpec.FetchedColumns {
+			if spec.FetchSpec.FetchedColumns[j].ColumnID == keyCols[i].ColumnID {
+				ords[i], found = uint32(j), true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return ords, true
+}
+
 // init performs some sanity checks for the invariants required by the
 // upperBuffer type.
 func init() {
@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/contentionpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/idxrecommendations"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessionphase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats"
@@ -161,6 +162,14 @@ func (ex *connExecutor) recordStatementSummary(
 		}
 	}
 
+	if flags.IsSet(planFlagCustomPlan) && stmt.Prepared != nil && stmtErr == nil && planner.curPlan.mem != nil {
+		// The measured latencies of custom plans are used to choose between a
+		// custom and a generic plan when plan_cache_mode is auto.
+		stmt.Prepared.customPlanCosts.add(
+			planner.curPlan.mem.RootExpr().(memo.RelExpr).Cost(), phaseTimes.GetPlanningLatency(), runLatRaw,
+		)
+	}
+
 	fullScan := flags.IsSet(planFlagContainsFullIndexScan) || flags.IsSet(planFlagContainsFullTableScan)
 	recordedStmtStatsKey := appstatspb.StatementStatisticsKey{
 		Query:        stmt.StmtNoConstants,
@@ -174,6 +183,10 @@ func (ex *connExecutor) recordStatementSummary(
 	}
 
 	idxRecommendations := idxrecommendations.FormatIdxRecommendations(planner.instrumentation.indexRecs)
+	var idxRecommendationsCostReduction float64
+	if len(planner.instrumentation.indexRecs) > 0 {
+		idxRecommendationsCostReduction = planner.instrumentation.indexRecs[0].CostReduction
+	}
 	queryLevelStats, queryLevelStatsOk := planner.instrumentation.GetQueryLevelStats()
 
 	var sqlInstanceIds []int64
@@ -189,34 +202,35 @@ func (ex *connExecutor) recordStatementSummary(
 	}
 
 	recordedStmtStats := sqlstats.RecordedStmtStats{
-		SessionID:            ex.planner.extendedEvalCtx.SessionID,
-		StatementID:          stmt.QueryID,
-		AutoRetryCount:       automaticRetryCount,
-		Failed:               stmtErr != nil,
-		AutoRetryReason:      ex.state.mu.autoRetryReason,
-		RowsAffected:         rowsAffected,
-		IdleLatencySec:       idleLatSec,
-		ParseLatencySec:      parseLatSec,
-		PlanLatencySec:       planLatSec,
-		RunLatencySec:        runLatSec,
-		ServiceLatencySec:    svcLatSec,
-		OverheadLatencySec:   execOverheadSec,
-		BytesRead:            stats.bytesRead,
-		RowsRead:             stats.rowsRead,
-		RowsWritten:          stats.rowsWritten,
-		Nodes:                sqlInstanceIds,
-		StatementType:        stmt.AST.StatementType(),
-		Plan:                 planner.instrumentation.PlanForStats(ctx),
-		PlanGist:             planner.instrumentation.planGist.String(),
-		StatementError:       stmtErr,
-		IndexRecommendations: idxRecommendations,
-		Query:                stmt.StmtNoConstants,
-		StartTime:            phaseTimes.GetSessionPhaseTime(sessionphase.PlannerStartExecStmt),
-		EndTime:              phaseTimes.GetSessionPhaseTime(sessionphase.PlannerStartExecStmt).Add(svcLatRaw),
-		FullScan:             fullScan,
-		ExecStats:            queryLevelStats,
-		Indexes:              planner.instrumentation.indexesUsed,
-		Database:             planner.SessionData().Database,
+		SessionID:                         ex.planner.extendedEvalCtx.SessionID,
+		StatementID:                       stmt.QueryID,
+		AutoRetryCount:                    automaticRetryCount,
+		Failed:                            stmtErr != nil,
+		AutoRetryReason:                   ex.state.mu.autoRetryReason,
+		RowsAffected:                      rowsAffected,
+		IdleLatencySec:                    idleLatSec,
+		ParseLatencySec:                   parseLatSec,
+		PlanLatencySec:                    planLatSec,
+		RunLatencySec:                     runLatSec,
+		ServiceLatencySec:                 svcLatSec,
+		OverheadLatencySec:                execOverheadSec,
+		BytesRead:                         stats.bytesRead,
+		RowsRead:                          stats.rowsRead,
+		RowsWritten:                       stats.rowsWritten,
+		Nodes:                             sqlInstanceIds,
+		StatementType:                     stmt.AST.StatementType(),
+		Plan:                              planner.instrumentation.PlanForStats(ctx),
+		PlanGist:                          planner.instrumentation.planGist.String(),
+		StatementError:                    stmtErr,
+		IndexRecommendations:              idxRecommendations,
+		IndexRecommendationsCostReduction: idxRecommendationsCostReduction,
+		Query:                             stmt.StmtNoConstants,
+		StartTime:                         phaseTimes.GetSessionPhaseTime(sessionphase.PlannerStartExecStmt),
+		EndTime:                           phaseTimes.GetSessionPhaseTime(sessionphase.PlannerStartExecStmt).Add(svcLatRaw),
+		FullScan:                          fullScan,
+		ExecStats:                         queryLevelStats,
+		Indexes:                           planner.instrumentation.indexesUsed,
+		Database:                          planner.SessionData().Database,
 	}
 
 	stmtFingerprintID, err :=
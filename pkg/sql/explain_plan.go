@@ -62,10 +62,12 @@ func (e *explainPlanNode) startExec(params runParams) error {
 		// Note that we delay adding the annotation about the distribution until
 		// after the plan is finalized (when the physical plan is successfully
 		// created).
-		distribution := getPlanDistribution(
+		distribution, distributionReason := getPlanDistributionWithReason(
 			params.ctx, params.p.Descriptors().HasUncommittedTypes(),
-			params.extendedEvalCtx.SessionData().DistSQLMode, plan.main,
+			params.extendedEvalCtx.SessionData().DistSQLMode, params.p.curPlan.mainDistributionCosts,
+			plan.main,
 		)
+		showDistributionReason := e.options.Flags[tree.ExplainFlagDistribution]
 
 		outerSubqueries := params.p.curPlan.subqueryPlans
 		distSQLPlanner := params.extendedEvalCtx.DistSQLPlanner
@@ -86,12 +88,23 @@ func (e *explainPlanNode) startExec(params runParams) error {
 				return err
 			}
 			ob.AddDistribution(distribution.String())
+			if showDistributionReason {
+				ob.AddDistributionReason(distributionReason)
+			}
 			// For regular EXPLAIN, simply skip emitting the "vectorized" information.
 		} else {
 			// There might be an issue making the physical plan, but that should not
 			// cause an error or panic, so swallow the error. See #40677 for example.
 			finalizePlanWithRowCount(params.ctx, planCtx, physicalPlan, plan.mainRowCount)
 			ob.AddDistribution(physicalPlan.Distribution.String())
+			if showDistributionReason {
+				if distribution.WillDistribute() && !physicalPlan.Distribution.WillDistribute() {
+					// The plan might have ended up with a single flow that was
+					// moved to the gateway during finalization.
+					distributionReason = "all processors are placed on the gateway node"
+				}
+				ob.AddDistributionReason(distributionReason)
+			}
 			flows := physicalPlan.GenerateFlowSpecs()
 
 			ctxSessionData := planCtx.EvalContext().SessionData()
@@ -83,6 +83,8 @@ const (
 	exportSnappyCodec     = "snappy"
 	csvSuffix             = "csv"
 	parquetSuffix         = "parquet"
+	arrowSuffix           = "arrow"
+	jsonlSuffix           = "jsonl"
 )
 
 var exportOptionExpectValues = map[string]exprutil.KVStringOptValidate{
@@ -134,7 +136,9 @@ func (ef *execFactory) ConstructExport(
 		return nil, errors.Errorf("EXPORT cannot be used inside a multi-statement transaction")
 	}
 
-	if fileSuffix != csvSuffix && fileSuffix != parquetSuffix {
+	switch fileSuffix {
+	case csvSuffix, parquetSuffix, arrowSuffix, jsonlSuffix:
+	default:
 		return nil, errors.Errorf("unsupported export format: %q", fileSuffix)
 	}
 
@@ -211,6 +215,10 @@ func (ef *execFactory) ConstructExport(
 		}
 		format.Format = roachpb.IOFileFormat_Parquet
 		format.Parquet = parquetOpts
+	case arrowSuffix:
+		format.Format = roachpb.IOFileFormat_Arrow
+	case jsonlSuffix:
+		format.Format = roachpb.IOFileFormat_JSONL
 	}
 
 	chunkRows := exportChunkRowsDefault
@@ -240,7 +248,7 @@ func (ef *execFactory) ConstructExport(
 	var codec roachpb.IOFileFormat_Compression
 	if name, ok := optVals[exportOptionCompression]; ok && len(name) != 0 {
 		switch {
-		case strings.EqualFold(name, exportGzipCodec):
+		case strings.EqualFold(name, exportGzipCodec) && fileSuffix != arrowSuffix:
 			codec = roachpb.IOFileFormat_Gzip
 		case strings.EqualFold(name, exportSnappyCodec) && fileSuffix == parquetSuffix:
 			codec = roachpb.IOFileFormat_Snappy
@@ -0,0 +1,303 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/col/coldataext"
+	"github.com/cockroachdb/cockroach/pkg/col/colserde"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+const exportArrowFilePatternDefault = exportFilePatternPart + ".arrow"
+
+// arrowExportType returns the type that a column of type t is written as in an
+// exported Arrow file. Types with a native Arrow representation are kept as
+// is; everything else is exported using its string representation, since the
+// columnar serialization of those types is specific to CockroachDB and
+// couldn't be decoded by other Arrow readers.
+func arrowExportType(t *types.T) *types.T {
+	switch t.Family() {
+	case types.BoolFamily, types.IntFamily, types.FloatFamily,
+		types.StringFamily, types.BytesFamily:
+		return t
+	default:
+		return types.String
+	}
+}
+
+func arrowFileName(spec execinfrapb.ExportSpec, part string) string {
+	pattern := exportArrowFilePatternDefault
+	if spec.NamePattern != "" {
+		pattern = spec.NamePattern
+	}
+	return strings.Replace(pattern, exportFilePatternPart, part, -1)
+}
+
+func newArrowWriterProcessor(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec execinfrapb.ExportSpec,
+	post *execinfrapb.PostProcessSpec,
+	input execinfra.RowSource,
+) (execinfra.Processor, error) {
+	c := &arrowWriterProcessor{
+		flowCtx:     flowCtx,
+		processorID: processorID,
+		spec:        spec,
+		input:       input,
+	}
+	semaCtx := tree.MakeSemaContext()
+	if err := c.out.Init(ctx, post, colinfo.ExportColumnTypes, &semaCtx, flowCtx.NewEvalCtx()); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// arrowWriterProcessor writes its input rows to files in the Arrow IPC file
+// format. Rows are accumulated into a coldata.Batch which is then serialized
+// with the same machinery that the vectorized engine uses to spill to disk.
+type arrowWriterProcessor struct {
+	flowCtx     *execinfra.FlowCtx
+	processorID int32
+	spec        execinfrapb.ExportSpec
+	input       execinfra.RowSource
+	out         execinfra.ProcOutputHelper
+}
+
+var _ execinfra.Processor = &arrowWriterProcessor{}
+
+func (sp *arrowWriterProcessor) OutputTypes() []*types.T {
+	return sp.out.OutputTypes
+}
+
+func (sp *arrowWriterProcessor) MustBeStreaming() bool {
+	return false
+}
+
+func (sp *arrowWriterProcessor) Run(ctx context.Context, output execinfra.RowReceiver) {
+	ctx, span := tracing.ChildSpan(ctx, "arrowWriter")
+	defer span.Finish()
+
+	knobs := sp.testingKnobsOrNil()
+	mon := sp.flowCtx.Mon
+	if knobs != nil && knobs.MemoryMonitor != nil {
+		mon = knobs.MemoryMonitor
+	}
+	memAcc := mon.MakeBoundAccount()
+	defer memAcc.Close(ctx)
+
+	instanceID := sp.flowCtx.EvalCtx.NodeID.SQLInstanceID()
+	uniqueID := builtins.GenerateUniqueInt(builtins.ProcessUniqueID(instanceID))
+
+	err := func() error {
+		typs := sp.input.OutputTypes()
+		if len(typs) != len(sp.spec.ColNames) {
+			return errors.AssertionFailedf(
+				"expected %d column names, found %d", len(typs), len(sp.spec.ColNames),
+			)
+		}
+		sp.input.Start(ctx)
+		input := execinfra.MakeNoMetadataRowSource(sp.input, output)
+
+		alloc := &tree.DatumAlloc{}
+		f := tree.NewFmtCtx(tree.FmtExport)
+		defer f.Close()
+
+		exportTyps := make([]*types.T, len(typs))
+		for i, t := range typs {
+			exportTyps[i] = arrowExportType(t)
+		}
+		exportRow := make(rowenc.EncDatumRow, len(typs))
+
+		capacity := coldata.BatchSize()
+		if err := memAcc.Grow(
+			ctx, colmem.SelVectorSize(capacity)+colmem.EstimateBatchSizeBytes(exportTyps, capacity),
+		); err != nil {
+			return err
+		}
+		batch := coldata.NewMemBatchWithCapacity(
+			exportTyps, capacity, coldataext.NewExtendedColumnFactory(sp.flowCtx.EvalCtx),
+		)
+		var vecs coldata.TypedVecs
+		vecs.SetBatch(batch)
+
+		var buf bytes.Buffer
+		chunk := 0
+		done := false
+		for {
+			var rows int64
+			buf.Reset()
+			s, err := colserde.NewFileSerializerWithFieldNames(&buf, exportTyps, sp.spec.ColNames, &memAcc)
+			if err != nil {
+				return err
+			}
+			flush := func() error {
+				if batch.Length() == 0 {
+					return nil
+				}
+				if err := s.AppendBatch(ctx, batch); err != nil {
+					return err
+				}
+				batch.ResetInternalBatch()
+				return nil
+			}
+			err = func() error {
+				defer s.Close(ctx)
+				for {
+					// If the bytes.Buffer sink exceeds the target size of an Arrow
+					// file, we flush before exporting any additional rows.
+					if int64(buf.Len()) >= sp.spec.ChunkSize {
+						break
+					}
+					if sp.spec.ChunkRows > 0 && rows >= sp.spec.ChunkRows {
+						break
+					}
+					row, err := input.NextRow()
+					if err != nil {
+						return err
+					}
+					if row == nil {
+						done = true
+						break
+					}
+					for i, ed := range row {
+						if err := ed.EnsureDecoded(typs[i], alloc); err != nil {
+							return err
+						}
+						d := tree.UnwrapDOidWrapper(ed.Datum)
+						if exportTyps[i] != typs[i] && d != tree.DNull {
+							d.Format(f)
+							d = tree.NewDString(f.String())
+							f.Reset()
+						}
+						exportRow[i] = rowenc.DatumToEncDatum(exportTyps[i], d)
+					}
+					n := batch.Length()
+					if err := colexecerror.CatchVectorizedRuntimeError(func() {
+						colexec.EncDatumRowToColVecs(exportRow, n, vecs, exportTyps, alloc)
+					}); err != nil {
+						return err
+					}
+					batch.SetLength(n + 1)
+					rows++
+					if batch.Length() == capacity {
+						if err := flush(); err != nil {
+							return err
+						}
+					}
+				}
+				if err := flush(); err != nil {
+					return err
+				}
+				return s.Finish()
+			}()
+			if err != nil {
+				return err
+			}
+			if rows < 1 {
+				break
+			}
+
+			part := fmt.Sprintf("n%d.%d", uniqueID, chunk)
+			chunk++
+			filename := arrowFileName(sp.spec, part)
+
+			size := buf.Len()
+
+			conf, err := cloud.ExternalStorageConfFromURI(sp.spec.Destination, sp.spec.User())
+			if err != nil {
+				return err
+			}
+			es, err := sp.flowCtx.Cfg.ExternalStorage(ctx, conf)
+			if err != nil {
+				return err
+			}
+			// The storage is closed once the chunk is written rather than
+			// deferred, which would keep one open per chunk until the export
+			// finishes.
+			err = cloud.WriteFile(ctx, es, filename, &buf)
+			if closeErr := es.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+			res := rowenc.EncDatumRow{
+				rowenc.DatumToEncDatum(
+					types.String,
+					tree.NewDString(filename),
+				),
+				rowenc.DatumToEncDatum(
+					types.Int,
+					tree.NewDInt(tree.DInt(rows)),
+				),
+				rowenc.DatumToEncDatum(
+					types.Int,
+					tree.NewDInt(tree.DInt(size)),
+				),
+			}
+
+			cs, err := sp.out.EmitRow(ctx, res, output)
+			if err != nil {
+				return err
+			}
+			if cs != execinfra.NeedMoreRows {
+				// We don't return an error here because we want the error (if any) that
+				// actually caused the consumer to enter a closed/draining state to take precendence.
+				return nil
+			}
+			if done {
+				break
+			}
+		}
+
+		return nil
+	}()
+
+	execinfra.DrainAndClose(
+		ctx, output, err, func(context.Context, execinfra.RowReceiver) {} /* pushTrailingMeta */, sp.input)
+}
+
+// Resume is part of the execinfra.Processor interface.
+func (sp *arrowWriterProcessor) Resume(output execinfra.RowReceiver) {
+	panic("not implemented")
+}
+
+func (sp *arrowWriterProcessor) testingKnobsOrNil() *ExportTestingKnobs {
+	if sp.flowCtx.TestingKnobs().Export == nil {
+		return nil
+	}
+	return sp.flowCtx.TestingKnobs().Export.(*ExportTestingKnobs)
+}
+
+func init() {
+	rowexec.NewArrowWriterProcessor = newArrowWriterProcessor
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/apache/arrow/go/v11/arrow"
+	"github.com/apache/arrow/go/v11/arrow/array"
+	"github.com/apache/arrow/go/v11/arrow/ipc"
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportArrow(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	dir, cleanupDir := testutils.TempDir(t)
+	defer cleanupDir()
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{ExternalIODir: dir})
+	defer srv.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	sqlDB.Exec(t, `CREATE TABLE foo (i INT PRIMARY KEY, s STRING, d DECIMAL, b BOOL)`)
+	sqlDB.Exec(t, `INSERT INTO foo SELECT g, 'row' || g::STRING, g::DECIMAL / 2, g % 3 = 0 FROM generate_series(1, 3000) AS g`)
+	sqlDB.Exec(t, `UPDATE foo SET s = NULL, b = NULL WHERE i = 2`)
+
+	sqlDB.Exec(t, `EXPORT INTO ARROW 'nodelocal://1/arrow' FROM SELECT * FROM foo ORDER BY i`)
+	content := readFileByGlob(t, filepath.Join(dir, "arrow", "export*-n*.0.arrow"))
+
+	// Read the file with the reference Go implementation of arrow to make sure
+	// that it can be consumed by tools outside of CockroachDB.
+	r, err := ipc.NewFileReader(bytes.NewReader(content))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, r.Close()) }()
+
+	// Types without a native arrow representation are exported as strings.
+	expected := arrow.NewSchema([]arrow.Field{
+		{Name: "i", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "s", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "d", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "b", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+	}, nil /* metadata */)
+	require.True(t, expected.Equal(r.Schema()), "unexpected schema %s", r.Schema())
+
+	var rows int
+	for recordIdx := 0; recordIdx < r.NumRecords(); recordIdx++ {
+		rec, err := r.Record(recordIdx)
+		require.NoError(t, err)
+		ints := rec.Column(0).(*array.Int64)
+		strs := rec.Column(1).(*array.String)
+		decs := rec.Column(2).(*array.String)
+		bools := rec.Column(3).(*array.Boolean)
+		for i := 0; i < int(rec.NumRows()); i++ {
+			rows++
+			require.Equal(t, int64(rows), ints.Value(i))
+			dec, err := strconv.ParseFloat(decs.Value(i), 64)
+			require.NoError(t, err)
+			require.Equal(t, float64(rows)/2, dec)
+			if rows == 2 {
+				require.True(t, strs.IsNull(i))
+				require.True(t, bools.IsNull(i))
+				continue
+			}
+			require.False(t, strs.IsNull(i))
+			require.Equal(t, "row"+strconv.Itoa(rows), strs.Value(i))
+			require.False(t, bools.IsNull(i))
+			require.Equal(t, rows%3 == 0, bools.Value(i))
+		}
+	}
+	require.Equal(t, 3000, rows)
+
+	sqlDB.ExpectErr(t, `unsupported compression codec gzip`,
+		`EXPORT INTO ARROW 'nodelocal://1/arrow-gzip' WITH compression = 'gzip' FROM SELECT * FROM foo`)
+}
@@ -0,0 +1,293 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+const exportJSONLFilePatternDefault = exportFilePatternPart + ".jsonl"
+
+// jsonlExporter buffers newline delimited JSON objects, one per row, and
+// optionally compresses them.
+type jsonlExporter struct {
+	compressor *gzip.Writer
+	buf        *bytes.Buffer
+	w          io.Writer
+	// keys holds the JSON encoded column names, which are the same for every
+	// row.
+	keys    [][]byte
+	scratch bytes.Buffer
+}
+
+func newJSONLExporter(sp execinfrapb.ExportSpec) *jsonlExporter {
+	buf := bytes.NewBuffer([]byte{})
+	e := &jsonlExporter{buf: buf, w: buf}
+	if sp.Format.Compression == roachpb.IOFileFormat_Gzip {
+		e.compressor = gzip.NewWriter(buf)
+		e.w = e.compressor
+	}
+	e.keys = make([][]byte, len(sp.ColNames))
+	for i, name := range sp.ColNames {
+		var keyBuf bytes.Buffer
+		json.FromString(name).Format(&keyBuf)
+		e.keys[i] = keyBuf.Bytes()
+	}
+	return e
+}
+
+// Write appends a single JSON object, built from the given column values, as a
+// line to the file.
+func (e *jsonlExporter) Write(values []json.JSON) error {
+	e.scratch.Reset()
+	e.scratch.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			e.scratch.WriteString(", ")
+		}
+		e.scratch.Write(e.keys[i])
+		e.scratch.WriteString(": ")
+		v.Format(&e.scratch)
+	}
+	e.scratch.WriteString("}\n")
+	_, err := e.w.Write(e.scratch.Bytes())
+	return err
+}
+
+// Close closes the compressor writer which appends archive footers.
+func (e *jsonlExporter) Close() error {
+	if e.compressor != nil {
+		return e.compressor.Close()
+	}
+	return nil
+}
+
+// ResetBuffer resets the buffer and compressor state.
+func (e *jsonlExporter) ResetBuffer() {
+	e.buf.Reset()
+	if e.compressor != nil {
+		e.compressor.Reset(e.buf)
+	}
+}
+
+// Len returns length of the buffer with content.
+func (e *jsonlExporter) Len() int {
+	return e.buf.Len()
+}
+
+func (e *jsonlExporter) FileName(spec execinfrapb.ExportSpec, part string) string {
+	pattern := exportJSONLFilePatternDefault
+	if spec.NamePattern != "" {
+		pattern = spec.NamePattern
+	}
+	fileName := strings.Replace(pattern, exportFilePatternPart, part, -1)
+	if e.compressor != nil {
+		fileName += ".gz"
+	}
+	return fileName
+}
+
+func newJSONLWriterProcessor(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec execinfrapb.ExportSpec,
+	post *execinfrapb.PostProcessSpec,
+	input execinfra.RowSource,
+) (execinfra.Processor, error) {
+	c := &jsonlWriter{
+		flowCtx:     flowCtx,
+		processorID: processorID,
+		spec:        spec,
+		input:       input,
+	}
+	semaCtx := tree.MakeSemaContext()
+	if err := c.out.Init(ctx, post, colinfo.ExportColumnTypes, &semaCtx, flowCtx.NewEvalCtx()); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type jsonlWriter struct {
+	flowCtx     *execinfra.FlowCtx
+	processorID int32
+	spec        execinfrapb.ExportSpec
+	input       execinfra.RowSource
+	out         execinfra.ProcOutputHelper
+}
+
+var _ execinfra.Processor = &jsonlWriter{}
+
+func (sp *jsonlWriter) OutputTypes() []*types.T {
+	return sp.out.OutputTypes
+}
+
+func (sp *jsonlWriter) MustBeStreaming() bool {
+	return false
+}
+
+func (sp *jsonlWriter) Run(ctx context.Context, output execinfra.RowReceiver) {
+	ctx, span := tracing.ChildSpan(ctx, "jsonlWriter")
+	defer span.Finish()
+
+	instanceID := sp.flowCtx.EvalCtx.NodeID.SQLInstanceID()
+	uniqueID := builtins.GenerateUniqueInt(builtins.ProcessUniqueID(instanceID))
+
+	err := func() error {
+		typs := sp.input.OutputTypes()
+		if len(typs) != len(sp.spec.ColNames) {
+			return errors.AssertionFailedf(
+				"expected %d column names, found %d", len(typs), len(sp.spec.ColNames),
+			)
+		}
+		sp.input.Start(ctx)
+		input := execinfra.MakeNoMetadataRowSource(sp.input, output)
+
+		alloc := &tree.DatumAlloc{}
+		dcc := sp.flowCtx.EvalCtx.SessionData().DataConversionConfig
+		loc := sp.flowCtx.EvalCtx.GetLocation()
+
+		writer := newJSONLExporter(sp.spec)
+		jsonRow := make([]json.JSON, len(typs))
+
+		chunk := 0
+		done := false
+		for {
+			var rows int64
+			writer.ResetBuffer()
+			for {
+				// If the bytes.Buffer sink exceeds the target size of a JSONL file, we
+				// flush before exporting any additional rows.
+				if int64(writer.Len()) >= sp.spec.ChunkSize {
+					break
+				}
+				if sp.spec.ChunkRows > 0 && rows >= sp.spec.ChunkRows {
+					break
+				}
+				row, err := input.NextRow()
+				if err != nil {
+					return err
+				}
+				if row == nil {
+					done = true
+					break
+				}
+				rows++
+
+				for i, ed := range row {
+					if err := ed.EnsureDecoded(typs[i], alloc); err != nil {
+						return err
+					}
+					j, err := tree.AsJSON(ed.Datum, dcc, loc)
+					if err != nil {
+						return err
+					}
+					jsonRow[i] = j
+				}
+				if err := writer.Write(jsonRow); err != nil {
+					return err
+				}
+			}
+			if rows < 1 {
+				break
+			}
+
+			part := fmt.Sprintf("n%d.%d", uniqueID, chunk)
+			chunk++
+			filename := writer.FileName(sp.spec, part)
+			// Close writer to ensure buffer and any compression footer is flushed.
+			if err := writer.Close(); err != nil {
+				return errors.Wrapf(err, "failed to close exporting writer")
+			}
+
+			size := writer.Len()
+
+			conf, err := cloud.ExternalStorageConfFromURI(sp.spec.Destination, sp.spec.User())
+			if err != nil {
+				return err
+			}
+			es, err := sp.flowCtx.Cfg.ExternalStorage(ctx, conf)
+			if err != nil {
+				return err
+			}
+			// The storage is closed once the chunk is written rather than
+			// deferred, which would keep one open per chunk until the export
+			// finishes.
+			err = cloud.WriteFile(ctx, es, filename, bytes.NewReader(writer.buf.Bytes()))
+			if closeErr := es.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+			res := rowenc.EncDatumRow{
+				rowenc.DatumToEncDatum(
+					types.String,
+					tree.NewDString(filename),
+				),
+				rowenc.DatumToEncDatum(
+					types.Int,
+					tree.NewDInt(tree.DInt(rows)),
+				),
+				rowenc.DatumToEncDatum(
+					types.Int,
+					tree.NewDInt(tree.DInt(size)),
+				),
+			}
+
+			cs, err := sp.out.EmitRow(ctx, res, output)
+			if err != nil {
+				return err
+			}
+			if cs != execinfra.NeedMoreRows {
+				// We don't return an error here because we want the error (if any) that
+				// actually caused the consumer to enter a closed/draining state to take precendence.
+				return nil
+			}
+			if done {
+				break
+			}
+		}
+
+		return nil
+	}()
+
+	execinfra.DrainAndClose(
+		ctx, output, err, func(context.Context, execinfra.RowReceiver) {} /* pushTrailingMeta */, sp.input)
+}
+
+// Resume is part of the execinfra.Processor interface.
+func (sp *jsonlWriter) Resume(output execinfra.RowReceiver) {
+	panic("not implemented")
+}
+
+func init() {
+	rowexec.NewJSONLWriterProcessor = newJSONLWriterProcessor
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportJSONL(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	dir, cleanupDir := testutils.TempDir(t)
+	defer cleanupDir()
+
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{ExternalIODir: dir})
+	defer srv.Stopper().Stop(context.Background())
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	sqlDB.Exec(t, `CREATE TABLE foo (i INT PRIMARY KEY, s STRING, d DECIMAL, j JSONB)`)
+	sqlDB.Exec(t, `INSERT INTO foo VALUES
+		(1, 'a"b', 1.50, '{"k": [1, 2]}'),
+		(2, NULL, NULL, NULL)`)
+
+	const expected = `{"i": 1, "s": "a\"b", "d": 1.50, "j": {"k": [1, 2]}}
+{"i": 2, "s": null, "d": null, "j": null}
+`
+
+	sqlDB.Exec(t, `EXPORT INTO JSONL 'nodelocal://1/jsonl' FROM SELECT * FROM foo ORDER BY i`)
+	content := readFileByGlob(t, filepath.Join(dir, "jsonl", "export*-n*.0.jsonl"))
+	require.Equal(t, expected, string(content))
+
+	sqlDB.Exec(t, `EXPORT INTO JSONL 'nodelocal://1/jsonl-gzip' WITH compression = 'gzip'
+		FROM SELECT * FROM foo ORDER BY i`)
+	compressed := readFileByGlob(t, filepath.Join(dir, "jsonl-gzip", "export*-n*.0.jsonl.gz"))
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	content, err = io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(content))
+
+	sqlDB.ExpectErr(t, `unsupported compression codec snappy`,
+		`EXPORT INTO JSONL 'nodelocal://1/jsonl-snappy' WITH compression = 'snappy' FROM SELECT * FROM foo`)
+}
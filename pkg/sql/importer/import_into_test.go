@@ -21,10 +21,12 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/jobutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
@@ -243,3 +245,58 @@ INSERT INTO shifts VALUES ('Bob', ARRAY['Tuesday', 'Thursday'], ARRAY['Tu', 'Th'
 		"IMPORT INTO shifts CSV DATA ('nodelocal://1/export2/export*-n*.0.csv');",
 	)
 }
+
+// TestImportIntoOnline verifies that a table imported into online remains
+// readable, but not writable, while the import is in progress, and that the
+// imported rows become visible together with the existing rows once it
+// completes.
+func TestImportIntoOnline(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	dir, dirCleanupFn := testutils.TempDir(t)
+	defer dirCleanupFn()
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{
+		ExternalIODir: dir,
+	})
+	defer srv.Stopper().Stop(ctx)
+
+	runner := sqlutils.MakeSQLRunner(db)
+	runner.Exec(t, `
+CREATE TABLE src (k INT PRIMARY KEY, v STRING);
+INSERT INTO src SELECT i, i::STRING FROM generate_series(101, 200) AS g(i);
+CREATE TABLE t (k INT PRIMARY KEY, v STRING, INDEX (v));
+INSERT INTO t SELECT i, i::STRING FROM generate_series(1, 100) AS g(i);
+`)
+	runner.Exec(t, `EXPORT INTO CSV 'nodelocal://1/online/' FROM SELECT * FROM src`)
+	const importStmt = `IMPORT INTO t CSV DATA ('nodelocal://1/online/export*-n*.0.csv')`
+
+	runner.ExpectErr(t, "the online option is only supported by IMPORT INTO",
+		`IMPORT PGDUMP ('nodelocal://1/online/dump.sql') WITH online`)
+
+	// Pause the import after the rows were ingested, but before the table is
+	// published.
+	runner.Exec(t, `SET CLUSTER SETTING jobs.debug.pausepoints = 'import.after_ingest'`)
+	var jobID jobspb.JobID
+	runner.QueryRow(t, importStmt+` WITH online, detached`).Scan(&jobID)
+	jobutils.WaitForJobToPause(t, runner, jobID)
+
+	// The table only contains the existing rows, and rejects writes.
+	runner.CheckQueryResults(t, `SELECT count(*), sum(k) FROM t`, [][]string{{"100", "5050"}})
+	runner.CheckQueryResults(t, `SELECT count(*) FROM t@t_v_idx`, [][]string{{"100"}})
+	runner.ExpectErr(t, "read-only while an online IMPORT", `INSERT INTO t VALUES (1000, 'x')`)
+	runner.ExpectErr(t, "read-only while an online IMPORT", `DELETE FROM t WHERE k = 1`)
+	runner.ExpectErr(t, "another online IMPORT is currently in progress", importStmt+` WITH online`)
+	runner.ExpectErr(t, "another online IMPORT is currently in progress", importStmt)
+
+	runner.Exec(t, `SET CLUSTER SETTING jobs.debug.pausepoints = ''`)
+	runner.Exec(t, `RESUME JOB $1`, jobID)
+	jobutils.WaitForJobToSucceed(t, runner, jobID)
+
+	runner.CheckQueryResults(t, `SELECT count(*), sum(k) FROM t`, [][]string{{"200", "20100"}})
+	runner.CheckQueryResults(t, `SELECT count(*) FROM t@t_v_idx`, [][]string{{"200"}})
+	runner.Exec(t, `INSERT INTO t VALUES (1000, 'x')`)
+	runner.CheckQueryResults(t, `SELECT k FROM t@t_v_idx WHERE v = 'x'`, [][]string{{"1000"}})
+}
@@ -249,8 +249,16 @@ func (r *importResumer) Resume(ctx context.Context, execCtx interface{}) error {
 				}
 				tableName = fmt.Sprintf("%s.%s", schemaName, tableName)
 			}
+			desc := i.Desc
+			if details.Online && !i.IsNew {
+				// Ingest the rows of an online import into the shadow indexes.
+				var err error
+				if desc, err = shadowTableDesc(i); err != nil {
+					return err
+				}
+			}
 			tables[tableName] = &execinfrapb.ReadImportDataSpec_ImportTable{
-				Desc:       i.Desc,
+				Desc:       desc,
 				TargetCols: i.TargetCols,
 			}
 		}
@@ -309,6 +317,30 @@ func (r *importResumer) Resume(ctx context.Context, execCtx interface{}) error {
 		}
 	}
 
+	if details.Online {
+		// Copy the rows the table contained when the import started into the
+		// shadow indexes, so that they are retained once the shadow indexes
+		// replace the table's indexes.
+		for i := range details.Tables {
+			tbl := &details.Tables[i]
+			if tbl.IsNew || tbl.ShadowIndexesPopulated {
+				continue
+			}
+			if err := populateShadowIndexes(
+				ctx, p.ExecCfg(), r.job, *tbl, hlc.Timestamp{WallTime: details.Walltime},
+			); err != nil {
+				return errors.Wrap(err, "copying existing rows")
+			}
+			tbl.ShadowIndexesPopulated = true
+			// The copy released the protected timestamp record that it wrote
+			// to the job, which this copy of the details may still refer to.
+			details.ProtectedTimestampRecord = nil
+			if err := r.job.NoTxn().SetDetails(ctx, details); err != nil {
+				return err
+			}
+		}
+	}
+
 	procsPerNode := int(processorsPerNode.Get(&p.ExecCfg().Settings.SV))
 
 	res, err := ingestWithRetry(ctx, p, r.job, tables, typeDescs, files, format, details.Walltime,
@@ -319,7 +351,13 @@ func (r *importResumer) Resume(ctx context.Context, execCtx interface{}) error {
 
 	pkIDs := make(map[uint64]struct{}, len(details.Tables))
 	for _, t := range details.Tables {
-		pkIDs[kvpb.BulkOpSummaryID(uint64(t.Desc.ID), uint64(t.Desc.PrimaryIndex.ID))] = struct{}{}
+		pkID := t.Desc.PrimaryIndex.ID
+		for _, s := range t.ShadowIndexes {
+			if s.IndexID == pkID {
+				pkID = s.ShadowIndexID
+			}
+		}
+		pkIDs[kvpb.BulkOpSummaryID(uint64(t.Desc.ID), uint64(pkID))] = struct{}{}
 	}
 	r.res.DataSize = res.DataSize
 	for id, count := range res.EntryCounts {
@@ -361,11 +399,13 @@ func (r *importResumer) Resume(ctx context.Context, execCtx interface{}) error {
 		return err
 	}
 
-	// As of 21.2 we do not write a protected timestamp record during IMPORT INTO.
-	// In case of a mixed version cluster with 21.1 and 21.2 nodes, it is possible
-	// that the job was planned on an older node and then resumed on a 21.2 node.
-	// Thus, we still need to clear the timestamp record that was written when the
-	// IMPORT INTO was planned on the older node.
+	// As of 21.2 we do not write a protected timestamp record during IMPORT INTO,
+	// other than the one an online import holds while it copies the existing
+	// rows, which is released once the copy is done. In case of a mixed version
+	// cluster with 21.1 and 21.2 nodes, it is possible that the job was planned
+	// on an older node and then resumed on a 21.2 node. Thus, we still need to
+	// clear the timestamp record that was written when the IMPORT INTO was
+	// planned on the older node.
 	//
 	// TODO(adityamaru): Remove in 22.1.
 	if err := p.ExecCfg().InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
@@ -424,16 +464,19 @@ func (r *importResumer) prepareTablesForIngestion(
 	useImportEpochs = useImportEpochs && importEpochs.Get(&p.ExecCfg().Settings.SV)
 	for i, table := range details.Tables {
 		if !table.IsNew {
-			desc, err = prepareExistingTablesForIngestion(ctx, txn, descsCol, table.Desc, useImportEpochs)
+			var shadowIndexes []jobspb.ImportDetails_ShadowIndex
+			desc, shadowIndexes, err = prepareExistingTablesForIngestion(
+				ctx, txn, descsCol, table.Desc, useImportEpochs, details.Online)
 			if err != nil {
 				return importDetails, err
 			}
 			importDetails.Tables[i] = jobspb.ImportDetails_Table{
-				Desc:       desc,
-				Name:       table.Name,
-				SeqVal:     table.SeqVal,
-				IsNew:      table.IsNew,
-				TargetCols: table.TargetCols,
+				Desc:          desc,
+				Name:          table.Name,
+				SeqVal:        table.SeqVal,
+				IsNew:         table.IsNew,
+				TargetCols:    table.TargetCols,
+				ShadowIndexes: shadowIndexes,
 			}
 			hasExistingTables = true
 		} else {
@@ -500,36 +543,51 @@ func (r *importResumer) prepareTablesForIngestion(
 }
 
 // prepareExistingTablesForIngestion prepares descriptors for existing tables
-// being imported into.
+// being imported into. For an online import, the table remains public and the
+// shadow indexes the data is ingested into are returned.
 func prepareExistingTablesForIngestion(
 	ctx context.Context,
 	txn *kv.Txn,
 	descsCol *descs.Collection,
 	desc *descpb.TableDescriptor,
 	useImportEpochs bool,
-) (*descpb.TableDescriptor, error) {
+	online bool,
+) (*descpb.TableDescriptor, []jobspb.ImportDetails_ShadowIndex, error) {
 	if len(desc.Mutations) > 0 {
-		return nil, errors.Errorf("cannot IMPORT INTO a table with schema changes in progress -- try again later (pending mutation %s)", desc.Mutations[0].String())
+		return nil, nil, errors.Errorf("cannot IMPORT INTO a table with schema changes in progress -- try again later (pending mutation %s)", desc.Mutations[0].String())
 	}
 
 	// Note that desc is just used to verify that the version matches.
 	importing, err := descsCol.MutableByID(txn).Table(ctx, desc.ID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// Ensure that the version of the table has not been modified since this
 	// job was created.
 	if got, exp := importing.Version, desc.Version; got != exp {
-		return nil, errors.Errorf("another operation is currently operating on the table")
+		return nil, nil, errors.Errorf("another operation is currently operating on the table")
+	}
+	// Writes are re-enabled when any import into the table completes, and
+	// rows written after that are lost when an online import swaps in its
+	// shadow indexes, so no other import can run alongside an online one.
+	if importing.IsOnlineImportInProgress() {
+		return nil, nil, errors.Errorf("another online IMPORT is currently in progress on the table")
 	}
 
-	// Take the table offline for import.
-	// TODO(dt): audit everywhere we get table descs (leases or otherwise) to
-	// ensure that filtering by state handles IMPORTING correctly.
+	var shadowIndexes []jobspb.ImportDetails_ShadowIndex
+	if online {
+		// The table stays public, but rejects writes until the import completes.
+		shadowIndexes, err = prepareTableForOnlineImport(ctx, txn, descsCol, importing)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else if useImportEpochs {
+		// Take the table offline for import.
+		// TODO(dt): audit everywhere we get table descs (leases or otherwise) to
+		// ensure that filtering by state handles IMPORTING correctly.
 
-	// We only use the new OfflineForImport on 24.1, which bumps
-	// the ImportEpoch, if we are completely on 24.1.
-	if useImportEpochs {
+		// We only use the new OfflineForImport on 24.1, which bumps
+		// the ImportEpoch, if we are completely on 24.1.
 		importing.OfflineForImport()
 	} else {
 		importing.SetOffline(tabledesc.OfflineReasonImporting)
@@ -539,10 +597,10 @@ func prepareExistingTablesForIngestion(
 	if err := descsCol.WriteDesc(
 		ctx, false /* kvTrace */, importing, txn,
 	); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return importing.TableDesc(), nil
+	return importing.TableDesc(), shadowIndexes, nil
 }
 
 // prepareNewTablesForIngestion prepares descriptors for newly created
@@ -1022,6 +1080,19 @@ func (r *importResumer) publishTables(
 					}
 				}
 			}
+			if details.Online && !tbl.IsNew {
+				// Swap in the shadow indexes, which contain both the existing and
+				// the imported rows, and clean up the indexes they replace.
+				replaced, err := publishShadowIndexes(newTableDesc, tbl)
+				if err != nil {
+					return err
+				}
+				if err := queueIndexGCJob(
+					ctx, execCfg, txn, r.job, newTableDesc.ID, replaced, timeutil.Now().UnixNano(),
+				); err != nil {
+					return errors.Wrapf(err, "queueing GC job for table %d", newTableDesc.ID)
+				}
+			}
 			newTableDesc.FinalizeImport()
 			// TODO(dt): re-validate any FKs?
 			if err := descsCol.WriteDescToBatch(
@@ -1145,8 +1216,18 @@ func (r *importResumer) publishSchemas(ctx context.Context, execCfg *sql.Executo
 func (r *importResumer) checkVirtualConstraints(
 	ctx context.Context, execCfg *sql.ExecutorConfig, job *jobs.Job, user username.SQLUsername,
 ) error {
-	for _, tbl := range job.Details().(jobspb.ImportDetails).Tables {
-		desc := tabledesc.NewBuilder(tbl.Desc).BuildExistingMutableTable()
+	details := job.Details().(jobspb.ImportDetails)
+	for _, tbl := range details.Tables {
+		tblDesc := tbl.Desc
+		if details.Online && !tbl.IsNew {
+			// The imported rows of an online import are only present in the
+			// shadow indexes, so validate those.
+			var err error
+			if tblDesc, err = shadowTableDesc(tbl); err != nil {
+				return err
+			}
+		}
+		desc := tabledesc.NewBuilder(tblDesc).BuildExistingMutableTable()
 		desc.SetPublic()
 
 		if sql.HasVirtualUniqueConstraints(desc) {
@@ -1593,7 +1674,21 @@ func (r *importResumer) dropTables(
 	//
 	// In this case, we don't want to rollback the data since data ingestion has
 	// not yet begun (since we have not chosen a timestamp at which to ingest.)
-	if details.Walltime != 0 && !tableWasEmpty {
+	if details.Online {
+		// An online import only writes to the shadow indexes, which were never
+		// visible to users, so they can be removed as soon as possible.
+		var shadowIDs []descpb.IndexID
+		for _, tbl := range details.Tables {
+			for _, s := range tbl.ShadowIndexes {
+				shadowIDs = append(shadowIDs, s.ShadowIndexID)
+			}
+		}
+		if err := queueIndexGCJob(
+			ctx, execCfg, txn, r.job, intoTable.GetID(), shadowIDs, 1, /* dropTime */
+		); err != nil {
+			return errors.Wrap(err, "queueing GC job for shadow indexes")
+		}
+	} else if details.Walltime != 0 && !tableWasEmpty {
 		// NB: if a revert fails it will abort the rest of this failure txn, which is
 		// also what brings tables back online. We _could_ change the error handling
 		// or just move the revert into Resume()'s error return path, however it isn't
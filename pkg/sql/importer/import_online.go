@@ -0,0 +1,307 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+// This file contains the pieces of IMPORT INTO ... WITH online.
+//
+// An online import keeps the table it imports into public. Instead of taking
+// the table offline and ingesting into its indexes, every index of the table
+// is assigned a shadow index ID when the import is prepared. Shadow indexes
+// are not part of the descriptor, so they are never read by queries. Writes to
+// the table are rejected for the duration of the import, the existing rows are
+// copied into the shadow indexes and the imported rows are then ingested into
+// them, using a copy of the descriptor in which the IDs of the indexes are
+// replaced with the shadow IDs. When the import completes, the shadow indexes
+// replace the table's indexes in a single descriptor write, so readers observe
+// either none or all of the imported rows. The replaced indexes are cleaned up
+// by a GC job, as are the shadow indexes if the import fails.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/backfill"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scexec"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// prepareTableForOnlineImport marks the table as being imported into online
+// and allocates a shadow index for each of its indexes.
+func prepareTableForOnlineImport(
+	ctx context.Context, txn *kv.Txn, descsCol *descs.Collection, importing *tabledesc.Mutable,
+) ([]jobspb.ImportDetails_ShadowIndex, error) {
+	// Index zone configurations and references from other descriptors
+	// identify an index by its ID, which changes when the shadow indexes
+	// replace the table's indexes.
+	zc, err := descsCol.GetZoneConfig(ctx, txn, importing.GetID())
+	if err != nil {
+		return nil, err
+	}
+	if zc != nil && len(zc.ZoneConfigProto().Subzones) > 0 {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"online IMPORT is not supported on tables with index or partition zone configurations")
+	}
+	for _, ref := range importing.DependedOnBy {
+		if ref.IndexID != 0 {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"online IMPORT is not supported on tables with indexes referenced by relation %d", ref.ID)
+		}
+	}
+
+	importing.PrepareForOnlineImport()
+	var shadowIndexes []jobspb.ImportDetails_ShadowIndex
+	for _, idx := range importing.ActiveIndexes() {
+		shadowIndexes = append(shadowIndexes, jobspb.ImportDetails_ShadowIndex{
+			IndexID:       idx.GetID(),
+			ShadowIndexID: importing.NextIndexID,
+		})
+		importing.NextIndexID++
+	}
+	return shadowIndexes, nil
+}
+
+// replaceIndexIDs replaces the IDs of the indexes of desc with the IDs of
+// their shadow indexes, and returns the IDs that were replaced. An error is
+// returned if the indexes of desc do not match the shadow indexes, which
+// happens if the table was modified by a concurrent schema change.
+func replaceIndexIDs(
+	desc *descpb.TableDescriptor, shadowIndexes []jobspb.ImportDetails_ShadowIndex,
+) ([]descpb.IndexID, error) {
+	shadowIDs := make(map[descpb.IndexID]descpb.IndexID, len(shadowIndexes))
+	for _, s := range shadowIndexes {
+		shadowIDs[s.IndexID] = s.ShadowIndexID
+	}
+	if len(desc.Mutations) > 0 || len(shadowIDs) != len(desc.Indexes)+1 {
+		return nil, errors.Errorf("table %q was modified by a concurrent schema change", desc.Name)
+	}
+	replaced := make([]descpb.IndexID, 0, len(shadowIDs))
+	replace := func(idx *descpb.IndexDescriptor) error {
+		shadowID, ok := shadowIDs[idx.ID]
+		if !ok {
+			return errors.Errorf("table %q was modified by a concurrent schema change", desc.Name)
+		}
+		replaced = append(replaced, idx.ID)
+		idx.ID = shadowID
+		return nil
+	}
+	if err := replace(&desc.PrimaryIndex); err != nil {
+		return nil, err
+	}
+	for i := range desc.Indexes {
+		if err := replace(&desc.Indexes[i]); err != nil {
+			return nil, err
+		}
+	}
+	return replaced, nil
+}
+
+// publishShadowIndexes replaces the indexes of a table which was imported into
+// online with their shadow indexes, and returns the IDs of the replaced
+// indexes.
+func publishShadowIndexes(
+	desc *tabledesc.Mutable, tbl jobspb.ImportDetails_Table,
+) ([]descpb.IndexID, error) {
+	// The columns of the table are not expected to change while writes are
+	// rejected, but the imported rows would not match them if they did.
+	if desc.NextColumnID != tbl.Desc.NextColumnID || len(desc.Columns) != len(tbl.Desc.Columns) {
+		return nil, errors.Errorf("table %q was modified by a concurrent schema change", desc.Name)
+	}
+	return replaceIndexIDs(desc.TableDesc(), tbl.ShadowIndexes)
+}
+
+// shadowTableDesc returns a copy of the descriptor of a table which is
+// imported into online, in which the indexes are replaced by their shadow
+// indexes. Rows encoded using this descriptor are written to the shadow
+// indexes.
+func shadowTableDesc(tbl jobspb.ImportDetails_Table) (*descpb.TableDescriptor, error) {
+	desc := protoutil.Clone(tbl.Desc).(*descpb.TableDescriptor)
+	if _, err := replaceIndexIDs(desc, tbl.ShadowIndexes); err != nil {
+		return nil, err
+	}
+	return desc, nil
+}
+
+// shadowBackfillTableDesc returns a copy of the descriptor of a table which
+// is imported into online, in which the shadow indexes are added as index
+// mutations. The index backfiller populates these mutations from the table's
+// primary index, which copies the existing rows into the shadow indexes.
+func shadowBackfillTableDesc(tbl jobspb.ImportDetails_Table) (catalog.TableDescriptor, error) {
+	shadow, err := shadowTableDesc(tbl)
+	if err != nil {
+		return nil, err
+	}
+	desc := protoutil.Clone(tbl.Desc).(*descpb.TableDescriptor)
+	addMutation := func(idx descpb.IndexDescriptor) {
+		desc.Mutations = append(desc.Mutations, descpb.DescriptorMutation{
+			Descriptor_: &descpb.DescriptorMutation_Index{Index: &idx},
+			State:       descpb.DescriptorMutation_BACKFILLING,
+			Direction:   descpb.DescriptorMutation_ADD,
+			MutationID:  desc.NextMutationID,
+		})
+	}
+	addMutation(shadow.PrimaryIndex)
+	for _, idx := range shadow.Indexes {
+		addMutation(idx)
+	}
+	return tabledesc.NewBuilder(desc).BuildImmutableTable(), nil
+}
+
+// populateShadowIndexes copies the rows of the table, as of ts, into its
+// shadow indexes. The copy is planned as a distributed index backfill from
+// the table's primary index. The read timestamp is protected from GC while the
+// copy runs, and the spans of the primary index that have been copied are
+// checkpointed in the progress of the job, so that a resumed job only copies
+// the remaining spans.
+func populateShadowIndexes(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	job *jobs.Job,
+	tbl jobspb.ImportDetails_Table,
+	ts hlc.Timestamp,
+) (err error) {
+	desc, err := shadowBackfillTableDesc(tbl)
+	if err != nil {
+		return err
+	}
+	cleanup, err := execCfg.ProtectedTimestampManager.Protect(
+		ctx, job, ptpb.MakeSchemaObjectsTarget(descpb.IDs{desc.GetID()}), ts,
+	)
+	if err != nil {
+		return errors.Wrap(err, "protecting the read timestamp")
+	}
+	if cleanup != nil {
+		defer func() {
+			if cleanupErr := cleanup(ctx); cleanupErr != nil {
+				err = errors.CombineErrors(err, cleanupErr)
+			}
+		}()
+	}
+
+	destIndexIDs := make([]descpb.IndexID, len(tbl.ShadowIndexes))
+	for i, s := range tbl.ShadowIndexes {
+		destIndexIDs[i] = s.ShadowIndexID
+	}
+	progress := scexec.BackfillProgress{
+		Backfill: scexec.Backfill{
+			TableID:       desc.GetID(),
+			SourceIndexID: desc.GetPrimaryIndexID(),
+			DestIndexIDs:  destIndexIDs,
+		},
+		MinimumWriteTimestamp: ts,
+		CompletedSpans:        job.Progress().GetImport().ShadowIndexCompletedSpans,
+	}
+	tracker := &shadowIndexProgressWriter{
+		job:   job,
+		every: util.Every(backfill.IndexBackfillCheckpointInterval.Get(&execCfg.Settings.SV)),
+	}
+	if err := sql.NewIndexBackfiller(execCfg).BackfillIndexes(
+		ctx, progress, tracker, job, desc,
+	); err != nil {
+		return err
+	}
+	return tracker.flush(ctx)
+}
+
+// shadowIndexProgressWriter is the scexec.BackfillerProgressWriter for the
+// backfill of the shadow indexes of an online import. It records the spans of
+// the primary index which have been copied in the progress of the import job.
+type shadowIndexProgressWriter struct {
+	job   *jobs.Job
+	every util.EveryN
+	// completed is the latest set of completed spans, which is written
+	// to the job at most once per checkpoint interval.
+	completed []roachpb.Span
+}
+
+var _ scexec.BackfillerProgressWriter = (*shadowIndexProgressWriter)(nil)
+
+// SetBackfillProgress is part of the scexec.BackfillerProgressWriter
+// interface.
+func (w *shadowIndexProgressWriter) SetBackfillProgress(
+	ctx context.Context, progress scexec.BackfillProgress,
+) error {
+	w.completed = progress.CompletedSpans
+	if !w.every.ShouldProcess(timeutil.Now()) {
+		return nil
+	}
+	return w.flush(ctx)
+}
+
+// SetMergeProgress is part of the scexec.BackfillerProgressWriter interface.
+func (w *shadowIndexProgressWriter) SetMergeProgress(
+	ctx context.Context, progress scexec.MergeProgress,
+) error {
+	return errors.AssertionFailedf("unexpected merge while copying rows into shadow indexes")
+}
+
+// flush writes the completed spans to the progress of the job.
+func (w *shadowIndexProgressWriter) flush(ctx context.Context) error {
+	if len(w.completed) == 0 {
+		return nil
+	}
+	return w.job.NoTxn().Update(ctx, func(
+		txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+	) error {
+		md.Progress.GetImport().ShadowIndexCompletedSpans = w.completed
+		ju.UpdateProgress(md.Progress)
+		return nil
+	})
+}
+
+// queueIndexGCJob creates a job which removes the data of the given indexes
+// of a table once the GC TTL has passed since dropTime.
+func queueIndexGCJob(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	txn isql.Txn,
+	job *jobs.Job,
+	tableID descpb.ID,
+	indexIDs []descpb.IndexID,
+	dropTime int64,
+) error {
+	if len(indexIDs) == 0 {
+		return nil
+	}
+	gcDetails := jobspb.SchemaChangeGCDetails{ParentID: tableID}
+	for _, id := range indexIDs {
+		gcDetails.Indexes = append(gcDetails.Indexes, jobspb.SchemaChangeGCDetails_DroppedIndex{
+			IndexID:  id,
+			DropTime: dropTime,
+		})
+	}
+	gcJobRecord := jobs.Record{
+		Description:   fmt.Sprintf("GC for %s", job.Payload().Description),
+		Username:      job.Payload().UsernameProto.Decode(),
+		DescriptorIDs: descpb.IDs{tableID},
+		Details:       gcDetails,
+		Progress:      jobspb.SchemaChangeGCProgress{},
+		NonCancelable: true,
+	}
+	_, err := execCfg.JobRegistry.CreateJobWithTxn(ctx, gcJobRecord, execCfg.JobRegistry.MakeJobID(), txn)
+	return err
+}
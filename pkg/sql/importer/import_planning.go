@@ -23,6 +23,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/cloud"
 	"github.com/cockroachdb/cockroach/pkg/cloud/cloudprivilege"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/docs"
 	"github.com/cockroachdb/cockroach/pkg/featureflag"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
@@ -81,6 +82,7 @@ const (
 	importOptionDisableGlobMatch = "disable_glob_matching"
 	importOptionSaveRejected     = "experimental_save_rejected"
 	importOptionDetached         = "detached"
+	importOptionOnline           = "online"
 
 	pgCopyDelimiter = "delimiter"
 	pgCopyNull      = "nullif"
@@ -132,6 +134,7 @@ var importOptionExpectValues = map[string]exprutil.KVStringOptValidate{
 	importOptionSkipFKs:          exprutil.KVStringOptRequireNoValue,
 	importOptionDisableGlobMatch: exprutil.KVStringOptRequireNoValue,
 	importOptionDetached:         exprutil.KVStringOptRequireNoValue,
+	importOptionOnline:           exprutil.KVStringOptRequireNoValue,
 
 	optMaxRowSize: exprutil.KVStringOptRequireValue,
 
@@ -167,7 +170,8 @@ func makeStringSet(opts ...string) map[string]struct{} {
 // Options common to all formats.
 var allowedCommonOptions = makeStringSet(
 	importOptionSSTSize, importOptionDecompress, importOptionOversample,
-	importOptionSaveRejected, importOptionDisableGlobMatch, importOptionDetached)
+	importOptionSaveRejected, importOptionDisableGlobMatch, importOptionDetached,
+	importOptionOnline)
 
 // Format specific allowed options.
 var avroAllowedOptions = makeStringSet(
@@ -175,6 +179,11 @@ var avroAllowedOptions = makeStringSet(
 	avroRecordsSeparatedBy, avroSchema, avroSchemaURI, optMaxRowSize, csvRowLimit,
 )
 
+var (
+	parquetAllowedOptions = makeStringSet(avroStrict, csvRowLimit)
+	jsonlAllowedOptions   = makeStringSet(avroStrict, csvRowLimit, optMaxRowSize)
+)
+
 var csvAllowedOptions = makeStringSet(
 	csvDelimiter, csvComment, csvNullIf, csvSkip, csvStrictQuotes, csvRowLimit, csvAllowQuotedNulls,
 )
@@ -201,6 +210,8 @@ var allowedIntoFormats = map[string]struct{}{
 	"AVRO":      {},
 	"DELIMITED": {},
 	"PGCOPY":    {},
+	"PARQUET":   {},
+	"JSONL":     {},
 }
 
 // featureImportEnabled is used to enable and disable the IMPORT feature.
@@ -751,6 +762,58 @@ func importPlanHook(
 			if err != nil {
 				return err
 			}
+		case "PARQUET":
+			if err = validateFormatOptions(importStmt.FileFormat, opts, parquetAllowedOptions); err != nil {
+				return err
+			}
+			format.Format = roachpb.IOFileFormat_Parquet
+			_, format.Parquet.StrictMode = opts[avroStrict]
+			if override, ok := opts[csvRowLimit]; ok {
+				rowLimit, err := strconv.Atoi(override)
+				if err != nil {
+					return pgerror.Wrapf(err, pgcode.Syntax, "invalid numeric %s value", csvRowLimit)
+				}
+				if rowLimit <= 0 {
+					return pgerror.Newf(pgcode.Syntax, "%s must be > 0", csvRowLimit)
+				}
+				format.Parquet.RowLimit = int64(rowLimit)
+			}
+			// Parquet files compress their pages internally, and EXPORT names
+			// compressed parquet files with a .gz suffix, so the file itself
+			// must never be decompressed.
+			if _, ok := opts[importOptionDecompress]; ok {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"%s is not supported for parquet files", importOptionDecompress)
+			}
+			format.Compression = roachpb.IOFileFormat_None
+		case "JSONL":
+			if err = validateFormatOptions(importStmt.FileFormat, opts, jsonlAllowedOptions); err != nil {
+				return err
+			}
+			format.Format = roachpb.IOFileFormat_JSONL
+			_, format.Jsonl.StrictMode = opts[avroStrict]
+			if override, ok := opts[csvRowLimit]; ok {
+				rowLimit, err := strconv.Atoi(override)
+				if err != nil {
+					return pgerror.Wrapf(err, pgcode.Syntax, "invalid numeric %s value", csvRowLimit)
+				}
+				if rowLimit <= 0 {
+					return pgerror.Newf(pgcode.Syntax, "%s must be > 0", csvRowLimit)
+				}
+				format.Jsonl.RowLimit = int64(rowLimit)
+			}
+			maxRowSize := int32(defaultScanBuffer)
+			if override, ok := opts[optMaxRowSize]; ok {
+				sz, err := humanizeutil.ParseBytes(override)
+				if err != nil {
+					return err
+				}
+				if sz < 1 || sz > math.MaxInt32 {
+					return errors.Errorf("%s out of range: %d", override, sz)
+				}
+				maxRowSize = int32(sz)
+			}
+			format.Jsonl.MaxRowSize = maxRowSize
 		default:
 			return unimplemented.Newf("import.format", "unsupported import format: %q", importStmt.FileFormat)
 		}
@@ -801,6 +864,19 @@ func importPlanHook(
 			return err
 		}
 
+		_, online := opts[importOptionOnline]
+		if online {
+			if !importStmt.Into {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"the %s option is only supported by IMPORT INTO", importOptionOnline)
+			}
+			if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1) ||
+				!importEpochs.Get(&p.ExecCfg().Settings.SV) {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"the %s option requires import epochs to be enabled", importOptionOnline)
+			}
+		}
+
 		if importStmt.Into {
 			if _, ok := allowedIntoFormats[importStmt.FileFormat]; !ok {
 				return errors.Newf(
@@ -952,6 +1028,7 @@ func importPlanHook(
 			ParseBundleSchema:     importStmt.Bundle,
 			DefaultIntSize:        p.SessionData().DefaultIntSize,
 			DatabasePrimaryRegion: databasePrimaryRegion,
+			Online:                online,
 		}
 
 		jr := jobs.Record{
@@ -338,6 +338,12 @@ func makeInputConverter(
 		return newAvroInputReader(
 			semaCtx, kvCh, singleTable, spec.Format.Avro, spec.WalltimeNanos,
 			readerParallelism, evalCtx, db)
+	case roachpb.IOFileFormat_Parquet:
+		return newParquetInputReader(semaCtx, spec.Format.Parquet, kvCh, spec.WalltimeNanos,
+			readerParallelism, singleTable, singleTableTargetCols, evalCtx, db)
+	case roachpb.IOFileFormat_JSONL:
+		return newJSONLInputReader(semaCtx, spec.Format.Jsonl, kvCh, spec.WalltimeNanos,
+			readerParallelism, singleTable, singleTableTargetCols, evalCtx, db)
 	default:
 		return nil, errors.Errorf(
 			"Requested IMPORT format (%d) not supported by this node", spec.Format.Format)
@@ -44,6 +44,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
 )
@@ -234,6 +235,11 @@ func readInputFiles(
 			defer raw.Close(ctx)
 
 			src := &fileReader{total: fileSizes[dataFileIndex], counter: byteCounter{r: ioctx.ReaderCtxAdapter(ctx, raw)}}
+			if src.total > 0 && guessCompressionFromName(dataFile, format.Compression) == roachpb.IOFileFormat_None {
+				src.randomAccess = io.NewSectionReader(
+					&externalStorageReaderAt{ctx: ctx, es: es, counter: &src.counter}, 0, src.total,
+				)
+			}
 			decompressed, err := decompressingReader(&src.counter, dataFile, format.Compression)
 			if err != nil {
 				return err
@@ -364,10 +370,105 @@ func (b *byteCounter) Read(p []byte) (int, error) {
 	return n, err
 }
 
+const (
+	// externalStorageReadBlockSize is the size of the aligned blocks that
+	// externalStorageReaderAt reads from external storage.
+	externalStorageReadBlockSize = 1 << 20
+	// externalStorageMaxCachedBlocks is the number of blocks that
+	// externalStorageReaderAt keeps in memory.
+	externalStorageMaxCachedBlocks = 4
+)
+
+// externalStorageReaderAt implements io.ReaderAt for a file in external
+// storage. Rather than issuing a ranged read for every call, which would mean
+// one request per page header for formats like parquet, it reads aligned
+// blocks of externalStorageReadBlockSize bytes and caches the most recently
+// read ones. The bytes read from storage are added to the counter so that they
+// are reflected in the progress of the file.
+type externalStorageReaderAt struct {
+	ctx     context.Context
+	es      cloud.ExternalStorage
+	counter *byteCounter
+
+	mu syncutil.Mutex
+	// blocks are the cached blocks, from least to most recently used.
+	blocks []externalStorageBlock
+}
+
+// externalStorageBlock is a block of a file read by externalStorageReaderAt.
+// Only the last block of the file is shorter than
+// externalStorageReadBlockSize.
+type externalStorageBlock struct {
+	off  int64
+	data []byte
+}
+
+var _ io.ReaderAt = &externalStorageReaderAt{}
+
+// ReadAt implements the io.ReaderAt interface.
+func (r *externalStorageReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int
+	for n < len(p) {
+		pos := off + int64(n)
+		b, err := r.getBlockLocked(pos - pos%externalStorageReadBlockSize)
+		if err != nil {
+			return n, err
+		}
+		if pos-b.off >= int64(len(b.data)) {
+			return n, io.EOF
+		}
+		n += copy(p[n:], b.data[pos-b.off:])
+	}
+	return n, nil
+}
+
+// getBlockLocked returns the block starting at the given offset, reading it
+// from external storage if it isn't cached.
+func (r *externalStorageReaderAt) getBlockLocked(off int64) (externalStorageBlock, error) {
+	for i, b := range r.blocks {
+		if b.off == off {
+			// Move the block to the end to mark it as the most recently used.
+			copy(r.blocks[i:], r.blocks[i+1:])
+			r.blocks[len(r.blocks)-1] = b
+			return b, nil
+		}
+	}
+	var buf []byte
+	if len(r.blocks) == externalStorageMaxCachedBlocks {
+		// Evict the least recently used block and reuse its buffer.
+		buf = r.blocks[0].data[:cap(r.blocks[0].data)]
+		r.blocks = append(r.blocks[:0], r.blocks[1:]...)
+	} else {
+		buf = make([]byte, externalStorageReadBlockSize)
+	}
+	raw, _, err := r.es.ReadFile(r.ctx, "", cloud.ReadOptions{
+		Offset:     off,
+		LengthHint: externalStorageReadBlockSize,
+		NoFileSize: true,
+	})
+	if err != nil {
+		return externalStorageBlock{}, err
+	}
+	defer raw.Close(r.ctx)
+	n, err := io.ReadFull(ioctx.ReaderCtxAdapter(r.ctx, raw), buf)
+	r.counter.n += int64(n)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return externalStorageBlock{}, err
+	}
+	b := externalStorageBlock{off: off, data: buf[:n]}
+	r.blocks = append(r.blocks, b)
+	return b, nil
+}
+
 type fileReader struct {
 	io.Reader
 	total   int64
 	counter byteCounter
+	// randomAccess, if set, provides random access to the raw contents of the
+	// file. It is only set if the file isn't compressed and its size is known.
+	randomAccess *io.SectionReader
 }
 
 func (f fileReader) ReadFraction() float32 {
@@ -389,7 +490,9 @@ func formatHasNamedColumns(format roachpb.IOFileFormat_FileFormat) bool {
 	switch format {
 	case roachpb.IOFileFormat_Avro,
 		roachpb.IOFileFormat_Mysqldump,
-		roachpb.IOFileFormat_PgDump:
+		roachpb.IOFileFormat_PgDump,
+		roachpb.IOFileFormat_Parquet,
+		roachpb.IOFileFormat_JSONL:
 		return true
 	}
 	return false
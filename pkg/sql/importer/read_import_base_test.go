@@ -11,19 +11,27 @@
 package importer
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"math/rand"
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/cloud/cloudpb"
+	"github.com/cockroachdb/cockroach/pkg/cloud/nodelocal"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/require"
 )
@@ -200,3 +208,63 @@ func TestParallelImportProducerHandlesCancellation(t *testing.T) {
 			return nil
 		}))
 }
+
+// readCountingStorage counts the ReadFile calls issued to the wrapped storage.
+type readCountingStorage struct {
+	cloud.ExternalStorage
+	reads int
+}
+
+func (s *readCountingStorage) ReadFile(
+	ctx context.Context, basename string, opts cloud.ReadOptions,
+) (ioctx.ReadCloserCtx, int64, error) {
+	s.reads++
+	return s.ExternalStorage.ReadFile(ctx, basename, opts)
+}
+
+func TestExternalStorageReaderAt(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	rng, _ := randutil.NewTestRand()
+	store := nodelocal.TestingMakeNodelocalStorage(
+		t.TempDir(), cluster.MakeTestingClusterSettings(), cloudpb.ExternalStorage{
+			LocalFileConfig: cloudpb.ExternalStorage_LocalFileConfig{Path: "data"},
+		})
+	defer store.Close()
+	data := randutil.RandBytes(rng, 5*externalStorageReadBlockSize/2)
+	require.NoError(t, cloud.WriteFile(ctx, store, "", bytes.NewReader(data)))
+
+	es := &readCountingStorage{ExternalStorage: store}
+	r := io.NewSectionReader(
+		&externalStorageReaderAt{ctx: ctx, es: es, counter: &byteCounter{}}, 0, int64(len(data)),
+	)
+
+	// Many small reads within a block only read the block once.
+	for i := 0; i < 100; i++ {
+		p := make([]byte, 1+rng.Intn(100))
+		off := rng.Int63n(externalStorageReadBlockSize - int64(len(p)))
+		n, err := r.ReadAt(p, off)
+		require.NoError(t, err)
+		require.Equal(t, len(p), n)
+		require.Equal(t, data[off:off+int64(n)], p)
+	}
+	require.Equal(t, 1, es.reads)
+
+	// Reads spanning blocks and reaching the end of the file.
+	for i := 0; i < 100; i++ {
+		p := make([]byte, rng.Intn(2*externalStorageReadBlockSize))
+		off := rng.Int63n(int64(len(data)))
+		n, err := r.ReadAt(p, off)
+		expected := data[off:]
+		if len(expected) > len(p) {
+			expected = expected[:len(p)]
+			require.NoError(t, err)
+		} else {
+			require.ErrorIs(t, err, io.EOF)
+		}
+		require.Equal(t, len(expected), n)
+		require.Equal(t, expected, p[:n])
+	}
+}
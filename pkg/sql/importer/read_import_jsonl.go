@@ -0,0 +1,295 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"bufio"
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+// namedColumnIndexes returns the columns targeted by an import, as well as a
+// mapping from column name to the index of that column in the
+// DatumRowConverter created for the given target columns. If no target columns
+// are specified, all visible columns of the table are targeted.
+func namedColumnIndexes(
+	tableDesc catalog.TableDescriptor, targetCols tree.NameList,
+) ([]catalog.Column, map[string]int, error) {
+	cols := tableDesc.VisibleColumns()
+	if len(targetCols) != 0 {
+		var err error
+		if cols, err = colinfo.ProcessTargetColumns(tableDesc, targetCols,
+			true /* ensureColumns */, false /* allowMutations */); err != nil {
+			return nil, nil, err
+		}
+	}
+	// The converter places the target columns first, in order, so their
+	// indexes match the ones in conv.Datums.
+	idxByName := make(map[string]int, len(cols))
+	for idx, col := range cols {
+		idxByName[col.GetName()] = idx
+	}
+	return cols, idxByName, nil
+}
+
+// jsonToDatum converts a JSON value to a datum of type targetT.
+//
+// JSON nulls are imported as NULL, and JSONB columns receive the value as is.
+// JSON arrays can be imported into array columns, in which case each element
+// is converted to the element type. Strings, numbers and booleans are parsed
+// using the textual representation of the target type, so that, for example,
+// a timestamp can be specified as a JSON string and a decimal as a JSON
+// number.
+func jsonToDatum(
+	ctx context.Context, j json.JSON, targetT *types.T, evalCtx *eval.Context, semaCtx *tree.SemaContext,
+) (tree.Datum, error) {
+	if j.Type() == json.NullJSONType {
+		return tree.DNull, nil
+	}
+	if targetT.Family() == types.JsonFamily {
+		return tree.NewDJSON(j), nil
+	}
+	switch j.Type() {
+	case json.StringJSONType:
+		s, err := j.AsText()
+		if err != nil {
+			return nil, err
+		}
+		return rowenc.ParseDatumStringAs(ctx, targetT, *s, evalCtx, semaCtx)
+	case json.NumberJSONType, json.TrueJSONType, json.FalseJSONType:
+		return rowenc.ParseDatumStringAs(ctx, targetT, j.String(), evalCtx, semaCtx)
+	case json.ArrayJSONType:
+		if targetT.Family() != types.ArrayFamily {
+			return nil, errors.Newf("cannot convert JSON array to non-array type %s", targetT)
+		}
+		arr := tree.NewDArray(targetT.ArrayContents())
+		for i := 0; i < j.Len(); i++ {
+			elt, err := j.FetchValIdx(i)
+			if err != nil {
+				return nil, err
+			}
+			d, err := jsonToDatum(ctx, elt, targetT.ArrayContents(), evalCtx, semaCtx)
+			if err != nil {
+				return nil, err
+			}
+			if err := arr.Append(d); err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	default:
+		return nil, errors.Newf("cannot convert JSON %s to %s", j.Type(), targetT)
+	}
+}
+
+// jsonlConsumer implements importRowConsumer interface.
+type jsonlConsumer struct {
+	fieldNameToIdx map[string]int
+	strict         bool
+}
+
+var _ importRowConsumer = &jsonlConsumer{}
+
+// FillDatums implements importRowConsumer interface.
+func (c *jsonlConsumer) FillDatums(
+	ctx context.Context, native interface{}, rowNum int64, conv *row.DatumRowConverter,
+) error {
+	line := native.(string)
+	j, err := json.ParseJSON(line)
+	if err != nil {
+		return newImportRowError(err, line, rowNum)
+	}
+	if j.Type() != json.ObjectJSONType {
+		return newImportRowError(
+			errors.Newf("expected a JSON object, found %s", j.Type()), line, rowNum)
+	}
+	it, err := j.ObjectIter()
+	if err != nil {
+		return newImportRowError(err, line, rowNum)
+	}
+	// The datums are reused across rows, so clear the values of the previous
+	// row; a key that is missing from this object must not inherit them.
+	for i := range conv.Datums {
+		if conv.TargetColOrds.Contains(i) {
+			conv.Datums[i] = nil
+		}
+	}
+	for it.Next() {
+		field := lexbase.NormalizeName(it.Key())
+		idx, ok := c.fieldNameToIdx[field]
+		if !ok {
+			if c.strict {
+				return newImportRowError(
+					errors.Newf("could not find column for key %s", field), line, rowNum)
+			}
+			continue
+		}
+		col := conv.VisibleCols[idx]
+		conv.Datums[idx], err = jsonToDatum(ctx, it.Value(), conv.VisibleColTypes[idx], conv.EvalCtx, conv.SemaCtx)
+		if err != nil {
+			return newImportRowError(errors.Wrapf(
+				err,
+				"encountered error when attempting to parse %q as %s",
+				col.GetName(), col.GetType().SQLString(),
+			), line, rowNum)
+		}
+	}
+
+	// Set any nil datums to DNull (in case the object didn't have the key set
+	// at all).
+	for i := range conv.Datums {
+		if conv.TargetColOrds.Contains(i) && conv.Datums[i] == nil {
+			if c.strict {
+				return newImportRowError(
+					errors.Newf("key %s was not set in the JSON object", conv.VisibleCols[i].GetName()),
+					line, rowNum)
+			}
+			conv.Datums[i] = tree.DNull
+		}
+	}
+	return nil
+}
+
+// jsonlProducer returns the non-blank lines of a newline delimited JSON file.
+type jsonlProducer struct {
+	input *fileReader
+	s     *bufio.Scanner
+	err   error
+}
+
+var _ importRowProducer = &jsonlProducer{}
+
+// Scan implements importRowProducer interface.
+func (p *jsonlProducer) Scan() bool {
+	for p.s.Scan() {
+		if strings.TrimSpace(p.s.Text()) != "" {
+			return true
+		}
+	}
+	p.err = p.s.Err()
+	if errors.Is(p.err, bufio.ErrTooLong) {
+		p.err = wrapWithLineTooLongHint(errors.New("line too long"))
+	}
+	return false
+}
+
+// Err implements importRowProducer interface.
+func (p *jsonlProducer) Err() error {
+	return p.err
+}
+
+// Skip implements importRowProducer interface.
+func (p *jsonlProducer) Skip() error {
+	return nil // no-op
+}
+
+// Row implements importRowProducer interface.
+func (p *jsonlProducer) Row() (interface{}, error) {
+	return p.s.Text(), nil
+}
+
+// Progress implements importRowProducer interface.
+func (p *jsonlProducer) Progress() float32 {
+	return p.input.ReadFraction()
+}
+
+type jsonlInputReader struct {
+	importCtx *parallelImportContext
+	opts      roachpb.JSONLOptions
+}
+
+var _ inputConverter = &jsonlInputReader{}
+
+func newJSONLInputReader(
+	semaCtx *tree.SemaContext,
+	opts roachpb.JSONLOptions,
+	kvCh chan row.KVBatch,
+	walltime int64,
+	parallelism int,
+	tableDesc catalog.TableDescriptor,
+	targetCols tree.NameList,
+	evalCtx *eval.Context,
+	db *kv.DB,
+) (*jsonlInputReader, error) {
+	return &jsonlInputReader{
+		importCtx: &parallelImportContext{
+			semaCtx:    semaCtx,
+			walltime:   walltime,
+			numWorkers: parallelism,
+			evalCtx:    evalCtx,
+			tableDesc:  tableDesc,
+			targetCols: targetCols,
+			kvCh:       kvCh,
+			db:         db,
+		},
+		opts: opts,
+	}, nil
+}
+
+func (r *jsonlInputReader) start(group ctxgroup.Group) {}
+
+func (r *jsonlInputReader) readFiles(
+	ctx context.Context,
+	dataFiles map[int32]string,
+	resumePos map[int32]int64,
+	format roachpb.IOFileFormat,
+	makeExternalStorage cloud.ExternalStorageFactory,
+	user username.SQLUsername,
+) error {
+	return readInputFiles(ctx, dataFiles, resumePos, format, r.readFile, makeExternalStorage, user)
+}
+
+func (r *jsonlInputReader) readFile(
+	ctx context.Context, input *fileReader, inputIdx int32, resumePos int64, rejected chan string,
+) error {
+	_, fieldNameToIdx, err := namedColumnIndexes(r.importCtx.tableDesc, r.importCtx.targetCols)
+	if err != nil {
+		return err
+	}
+
+	maxRowSize := int(r.opts.MaxRowSize)
+	if maxRowSize <= 0 {
+		maxRowSize = defaultScanBuffer
+	}
+	s := bufio.NewScanner(input)
+	s.Split(bufio.ScanLines)
+	s.Buffer(nil, maxRowSize)
+
+	producer := &jsonlProducer{input: input, s: s}
+	consumer := &jsonlConsumer{
+		fieldNameToIdx: fieldNameToIdx,
+		strict:         r.opts.StrictMode,
+	}
+
+	fileCtx := &importFileContext{
+		source:   inputIdx,
+		skip:     resumePos,
+		rejected: rejected,
+		rowLimit: r.opts.RowLimit,
+	}
+	return runParallelImport(ctx, r.importCtx, fileCtx, producer, consumer)
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportJSONL(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	dir, cleanupDir := testutils.TempDir(t)
+	defer cleanupDir()
+
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{ExternalIODir: dir})
+	defer srv.Stopper().Stop(context.Background())
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	const data = `{"i": 1, "s": "a", "d": 1.50, "a": [1, null], "j": {"k": [1, 2]}, "extra": true}
+
+{"i": 2, "S": null, "ts": "2024-01-02 03:04:05"}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data.jsonl"), []byte(data), 0644))
+
+	sqlDB.Exec(t, `CREATE TABLE t (i INT PRIMARY KEY, s STRING, d DECIMAL, a INT[], j JSONB, ts TIMESTAMP)`)
+	sqlDB.Exec(t, `IMPORT INTO t JSONL DATA ('nodelocal://1/data.jsonl')`)
+	sqlDB.CheckQueryResults(t, `SELECT i, s, d, a, j, ts::STRING FROM t ORDER BY i`, [][]string{
+		{"1", "a", "1.50", "{1,NULL}", `{"k": [1, 2]}`, "NULL"},
+		{"2", "NULL", "NULL", "NULL", "NULL", "2024-01-02 03:04:05"},
+	})
+
+	t.Run("target-columns", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE TABLE t2 (i INT PRIMARY KEY, s STRING DEFAULT 'def', d DECIMAL)`)
+		sqlDB.Exec(t, `IMPORT INTO t2 (i, d) JSONL DATA ('nodelocal://1/data.jsonl')`)
+		sqlDB.CheckQueryResults(t, `SELECT * FROM t2 ORDER BY i`, [][]string{
+			{"1", "def", "1.50"},
+			{"2", "def", "NULL"},
+		})
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE TABLE t3 (i INT PRIMARY KEY, s STRING, d DECIMAL, a INT[], j JSONB, ts TIMESTAMP)`)
+		sqlDB.ExpectErr(t, `could not find column for key extra`,
+			`IMPORT INTO t3 JSONL DATA ('nodelocal://1/data.jsonl') WITH strict_validation`)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.jsonl"), []byte(`{"i": {"x": 1}}`), 0644))
+		sqlDB.Exec(t, `CREATE TABLE t4 (i INT PRIMARY KEY)`)
+		sqlDB.ExpectErr(t, `cannot convert JSON object`,
+			`IMPORT INTO t4 JSONL DATA ('nodelocal://1/bad.jsonl')`)
+		sqlDB.ExpectErr(t, `invalid option "delimiter"`,
+			`IMPORT INTO t4 JSONL DATA ('nodelocal://1/bad.jsonl') WITH delimiter = '|'`)
+	})
+
+	t.Run("export-roundtrip", func(t *testing.T) {
+		sqlDB.Exec(t, `EXPORT INTO JSONL 'nodelocal://1/export-jsonl' FROM SELECT * FROM t`)
+		sqlDB.Exec(t, `CREATE TABLE t5 (LIKE t INCLUDING ALL)`)
+		sqlDB.Exec(t, `IMPORT INTO t5 JSONL DATA ('nodelocal://1/export-jsonl/*')`)
+		sqlDB.CheckQueryResults(t, `SELECT * FROM t5 ORDER BY i`, sqlDB.QueryStr(t, `SELECT * FROM t ORDER BY i`))
+	})
+}
@@ -0,0 +1,285 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/parquet"
+	"github.com/cockroachdb/errors"
+)
+
+// parquetDatumToDatum converts a datum decoded from a parquet file to a datum
+// of type targetT. Values written by EXPORT INTO PARQUET are usually decoded
+// with the correct type already. Other values are either parsed from their
+// string representation or cast to the target type.
+func parquetDatumToDatum(
+	ctx context.Context, d tree.Datum, targetT *types.T, evalCtx *eval.Context, semaCtx *tree.SemaContext,
+) (tree.Datum, error) {
+	if d == tree.DNull || d.ResolvedType().Identical(targetT) {
+		return d, nil
+	}
+	if s, ok := d.(*tree.DString); ok {
+		return rowenc.ParseDatumStringAs(ctx, targetT, string(*s), evalCtx, semaCtx)
+	}
+	return eval.PerformCast(ctx, evalCtx, d, targetT)
+}
+
+// parquetConsumer implements importRowConsumer interface.
+type parquetConsumer struct {
+	// convIdx maps the position of a value in the rows returned by the
+	// producer to the index of the corresponding column in the
+	// DatumRowConverter.
+	convIdx []int
+}
+
+var _ importRowConsumer = &parquetConsumer{}
+
+// FillDatums implements importRowConsumer interface.
+func (c *parquetConsumer) FillDatums(
+	ctx context.Context, native interface{}, rowNum int64, conv *row.DatumRowConverter,
+) error {
+	datums := native.(tree.Datums)
+	for i, d := range datums {
+		idx := c.convIdx[i]
+		var err error
+		conv.Datums[idx], err = parquetDatumToDatum(ctx, d, conv.VisibleColTypes[idx], conv.EvalCtx, conv.SemaCtx)
+		if err != nil {
+			col := conv.VisibleCols[idx]
+			return newImportRowError(errors.Wrapf(
+				err,
+				"encountered error when attempting to convert %q to %s",
+				col.GetName(), col.GetType().SQLString(),
+			), tree.AsString(&datums), rowNum)
+		}
+	}
+
+	// Set the target columns which are not present in the file to NULL. Strict
+	// mode rejects such files before any rows are read.
+	for i := range conv.Datums {
+		if conv.TargetColOrds.Contains(i) && conv.Datums[i] == nil {
+			conv.Datums[i] = tree.DNull
+		}
+	}
+	return nil
+}
+
+// parquetProducer returns the rows of a parquet file, one row group at a time.
+type parquetProducer struct {
+	r    *parquet.Reader
+	cols []int
+	typs []*types.T
+
+	rowGroup int
+	// values holds the current row group, indexed by column and then by row.
+	values  [][]tree.Datum
+	rowIdx  int
+	numRead int64
+	err     error
+}
+
+var _ importRowProducer = &parquetProducer{}
+
+// Scan implements importRowProducer interface.
+func (p *parquetProducer) Scan() bool {
+	for p.values == nil || p.rowIdx >= len(p.values[0]) {
+		if p.rowGroup >= p.r.NumRowGroups() {
+			return false
+		}
+		p.values, p.err = p.r.ReadRowGroup(p.rowGroup, p.cols, p.typs)
+		if p.err != nil {
+			return false
+		}
+		p.rowGroup++
+		p.rowIdx = 0
+	}
+	return true
+}
+
+// Err implements importRowProducer interface.
+func (p *parquetProducer) Err() error {
+	return p.err
+}
+
+// Skip implements importRowProducer interface.
+func (p *parquetProducer) Skip() error {
+	p.rowIdx++
+	p.numRead++
+	return nil
+}
+
+// Row implements importRowProducer interface.
+func (p *parquetProducer) Row() (interface{}, error) {
+	row := make(tree.Datums, len(p.values))
+	for i := range p.values {
+		row[i] = p.values[i][p.rowIdx]
+	}
+	p.rowIdx++
+	p.numRead++
+	return row, nil
+}
+
+// Progress implements importRowProducer interface.
+func (p *parquetProducer) Progress() float32 {
+	if total := p.r.NumRows(); total > 0 {
+		return float32(p.numRead) / float32(total)
+	}
+	return 0
+}
+
+type parquetInputReader struct {
+	importCtx *parallelImportContext
+	opts      roachpb.ParquetOptions
+}
+
+var _ inputConverter = &parquetInputReader{}
+
+func newParquetInputReader(
+	semaCtx *tree.SemaContext,
+	opts roachpb.ParquetOptions,
+	kvCh chan row.KVBatch,
+	walltime int64,
+	parallelism int,
+	tableDesc catalog.TableDescriptor,
+	targetCols tree.NameList,
+	evalCtx *eval.Context,
+	db *kv.DB,
+) (*parquetInputReader, error) {
+	return &parquetInputReader{
+		importCtx: &parallelImportContext{
+			semaCtx:    semaCtx,
+			walltime:   walltime,
+			numWorkers: parallelism,
+			evalCtx:    evalCtx,
+			tableDesc:  tableDesc,
+			targetCols: targetCols,
+			kvCh:       kvCh,
+			db:         db,
+		},
+		opts: opts,
+	}, nil
+}
+
+func (r *parquetInputReader) start(group ctxgroup.Group) {}
+
+func (r *parquetInputReader) readFiles(
+	ctx context.Context,
+	dataFiles map[int32]string,
+	resumePos map[int32]int64,
+	format roachpb.IOFileFormat,
+	makeExternalStorage cloud.ExternalStorageFactory,
+	user username.SQLUsername,
+) error {
+	return readInputFiles(ctx, dataFiles, resumePos, format, r.readFile, makeExternalStorage, user)
+}
+
+// newParquetPipeline matches the columns in the parquet file with the target
+// columns of the import and validates that their types are compatible.
+func (r *parquetInputReader) newParquetPipeline(
+	pr *parquet.Reader,
+) (*parquetProducer, *parquetConsumer, error) {
+	targetCols, idxByName, err := namedColumnIndexes(r.importCtx.tableDesc, r.importCtx.targetCols)
+	if err != nil {
+		return nil, nil, err
+	}
+	names, err := pr.ColumnNames()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	producer := &parquetProducer{r: pr}
+	consumer := &parquetConsumer{}
+	found := make([]bool, len(targetCols))
+	for i, name := range names {
+		field := lexbase.NormalizeName(name)
+		idx, ok := idxByName[field]
+		if !ok {
+			if r.opts.StrictMode {
+				return nil, nil, errors.Newf("could not find column for parquet column %s", field)
+			}
+			continue
+		}
+		if found[idx] {
+			return nil, nil, errors.Newf("parquet column %s is specified more than once", field)
+		}
+		found[idx] = true
+
+		typ := targetCols[idx].GetType()
+		decTyp, err := pr.DecodedType(i, typ)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !decTyp.Identical(typ) && decTyp.Family() != types.StringFamily &&
+			!cast.ValidCast(decTyp, typ, cast.ContextExplicit) {
+			return nil, nil, errors.Newf(
+				"cannot convert parquet column %s of type %s to %s", field, decTyp.SQLString(), typ.SQLString())
+		}
+		producer.cols = append(producer.cols, i)
+		producer.typs = append(producer.typs, typ)
+		consumer.convIdx = append(consumer.convIdx, idx)
+	}
+	if len(producer.cols) == 0 {
+		return nil, nil, errors.New("none of the parquet columns match a target column")
+	}
+	if r.opts.StrictMode {
+		for idx, ok := range found {
+			if !ok {
+				return nil, nil, errors.Newf(
+					"column %s was not found in the parquet file", targetCols[idx].GetName())
+			}
+		}
+	}
+	return producer, consumer, nil
+}
+
+func (r *parquetInputReader) readFile(
+	ctx context.Context, input *fileReader, inputIdx int32, resumePos int64, rejected chan string,
+) error {
+	// The metadata of a parquet file is stored in its footer, so the file
+	// needs to support random access. The parts of the file needed by the
+	// reader are fetched with ranged reads, rather than buffering the whole
+	// file in memory.
+	if input.randomAccess == nil {
+		return errors.New(
+			"parquet files must not be compressed and their size must be known to be imported")
+	}
+	pr, err := parquet.NewReader(input.randomAccess)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = pr.Close() }()
+
+	producer, consumer, err := r.newParquetPipeline(pr)
+	if err != nil {
+		return err
+	}
+
+	fileCtx := &importFileContext{
+		source:   inputIdx,
+		skip:     resumePos,
+		rejected: rejected,
+		rowLimit: r.opts.RowLimit,
+	}
+	return runParallelImport(ctx, r.importCtx, fileCtx, producer, consumer)
+}
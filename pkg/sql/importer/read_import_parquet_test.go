@@ -0,0 +1,91 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+func TestImportParquet(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	dir, cleanupDir := testutils.TempDir(t)
+	defer cleanupDir()
+
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{ExternalIODir: dir})
+	defer srv.Stopper().Stop(context.Background())
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	sqlDB.Exec(t, `CREATE TYPE e AS ENUM ('x', 'y')`)
+	sqlDB.Exec(t, `CREATE TABLE src (
+		i INT PRIMARY KEY, s STRING, d DECIMAL, a INT[], ts TIMESTAMPTZ, u UUID, en e, extra BOOL
+	)`)
+	sqlDB.Exec(t, `INSERT INTO src VALUES
+		(1, 'a', 1.50, ARRAY[1, NULL], '2024-01-02 03:04:05+00', gen_random_uuid(), 'x', true),
+		(2, NULL, NULL, ARRAY[], NULL, NULL, NULL, NULL),
+		(3, 'c', -2, NULL, '1970-01-01 00:00:00+00', gen_random_uuid(), 'y', false)`)
+	sqlDB.Exec(t, `EXPORT INTO PARQUET 'nodelocal://1/parquet' FROM SELECT * FROM src`)
+	sqlDB.Exec(t, `EXPORT INTO PARQUET 'nodelocal://1/parquet-gzip' WITH compression = 'gzip'
+		FROM SELECT * FROM src`)
+
+	const cols = `i, s, d, a, ts, u, en`
+	expected := sqlDB.QueryStr(t, `SELECT `+cols+` FROM src ORDER BY i`)
+
+	t.Run("roundtrip", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE TABLE t (i INT PRIMARY KEY, s STRING, d DECIMAL, a INT[], ts TIMESTAMPTZ, u UUID, en e)`)
+		sqlDB.Exec(t, `IMPORT INTO t PARQUET DATA ('nodelocal://1/parquet/*')`)
+		sqlDB.CheckQueryResults(t, `SELECT `+cols+` FROM t ORDER BY i`, expected)
+	})
+
+	t.Run("compressed", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE TABLE t2 (i INT PRIMARY KEY, s STRING, d DECIMAL, a INT[], ts TIMESTAMPTZ, u UUID, en e)`)
+		sqlDB.Exec(t, `IMPORT INTO t2 PARQUET DATA ('nodelocal://1/parquet-gzip/*')`)
+		sqlDB.CheckQueryResults(t, `SELECT `+cols+` FROM t2 ORDER BY i`, expected)
+		sqlDB.ExpectErr(t, `decompress is not supported for parquet files`,
+			`IMPORT INTO t2 PARQUET DATA ('nodelocal://1/parquet-gzip/*') WITH decompress = 'gzip'`)
+	})
+
+	t.Run("conversion", func(t *testing.T) {
+		// Columns are matched by name and values are converted to the type of
+		// the target column.
+		sqlDB.Exec(t, `CREATE TABLE t3 (s STRING, i DECIMAL PRIMARY KEY, extra STRING DEFAULT 'def')`)
+		sqlDB.Exec(t, `IMPORT INTO t3 (i, s) PARQUET DATA ('nodelocal://1/parquet/*')`)
+		sqlDB.CheckQueryResults(t, `SELECT * FROM t3 ORDER BY i`, [][]string{
+			{"a", "1", "def"},
+			{"NULL", "2", "def"},
+			{"c", "3", "def"},
+		})
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE TABLE t4 (i INT PRIMARY KEY, s STRING, d DECIMAL, a INT[], ts TIMESTAMPTZ, u UUID, en e)`)
+		sqlDB.ExpectErr(t, `could not find column for parquet column extra`,
+			`IMPORT INTO t4 PARQUET DATA ('nodelocal://1/parquet/*') WITH strict_validation`)
+		sqlDB.Exec(t, `CREATE TABLE t5 (i INT PRIMARY KEY, s STRING, d DECIMAL, a INT[], ts TIMESTAMPTZ, u UUID, en e,
+			extra BOOL, missing INT)`)
+		sqlDB.ExpectErr(t, `column missing was not found in the parquet file`,
+			`IMPORT INTO t5 PARQUET DATA ('nodelocal://1/parquet/*') WITH strict_validation`)
+	})
+
+	t.Run("incompatible", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE TABLE t6 (i INT PRIMARY KEY, a INT)`)
+		sqlDB.ExpectErr(t, `cannot decode repeated column "a"`,
+			`IMPORT INTO t6 PARQUET DATA ('nodelocal://1/parquet/*')`)
+	})
+}
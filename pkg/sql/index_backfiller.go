@@ -198,10 +198,11 @@ func (ib *IndexBackfillPlanner) plan(
 		// TODO(ajwerner): Adopt util.ConstantWithMetamorphicTestRange for the
 		// batch size. Also plumb in a testing knob.
 		chunkSize := indexBackfillBatchSize.Get(&ib.execCfg.Settings.SV)
+		numWorkers := int32(indexBackfillNumWorkers.Get(&ib.execCfg.Settings.SV))
 		const writeAtRequestTimestamp = true
 		spec, err := initIndexBackfillerSpec(
 			*td.TableDesc(), writeAsOf, readAsOf, writeAtRequestTimestamp, chunkSize,
-			indexesToBackfill,
+			numWorkers, indexesToBackfill,
 		)
 		if err != nil {
 			return err
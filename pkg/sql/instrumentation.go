@@ -986,6 +986,12 @@ func (m execNodeTraceMetadata) annotateExplain(
 				nodeStats.VectorizedBatchCount.MaybeAdd(stats.Output.NumBatches)
 				nodeStats.MaxAllocatedMem.MaybeAdd(stats.Exec.MaxAllocatedMem)
 				nodeStats.MaxAllocatedDisk.MaybeAdd(stats.Exec.MaxAllocatedDisk)
+				switch stats.AdaptiveJoin.Strategy {
+				case execinfrapb.AdaptiveJoinStats_LOOKUP_JOIN:
+					nodeStats.AdaptiveLookupJoins.Add(1)
+				case execinfrapb.AdaptiveJoinStats_HASH_JOIN:
+					nodeStats.AdaptiveHashJoins.Add(1)
+				}
 				if noMutations && !makeDeterministic {
 					// Currently we cannot separate SQL CPU time from local KV CPU time
 					// for mutations, since they do not collect statistics. Additionally,
@@ -129,6 +129,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.AlterTableOwner(ctx, n)
 	case *tree.AlterTableSetSchema:
 		return p.AlterTableSetSchema(ctx, n)
+	case *tree.AlterTenantAdmission:
+		return p.AlterTenantAdmission(ctx, n)
 	case *tree.AlterTenantCapability:
 		return p.AlterTenantCapability(ctx, n)
 	case *tree.AlterTenantSetClusterSetting:
@@ -180,10 +182,18 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.CreateExtension(ctx, n)
 	case *tree.CreateExternalConnection:
 		return p.CreateExternalConnection(ctx, n)
+	case *tree.CreatePlanBaseline:
+		return p.CreatePlanBaseline(ctx, n)
+	case *tree.CreatePolicy:
+		return p.CreatePolicy(ctx, n)
 	case *tree.CreateTenant:
 		return p.CreateTenantNode(ctx, n)
 	case *tree.DropExternalConnection:
 		return p.DropExternalConnection(ctx, n)
+	case *tree.DropPlanBaseline:
+		return p.DropPlanBaseline(ctx, n)
+	case *tree.DropPolicy:
+		return p.DropPolicy(ctx, n)
 	case *tree.Deallocate:
 		return p.Deallocate(ctx, n)
 	case *tree.DeclareCursor:
@@ -324,6 +334,7 @@ func init() {
 		&tree.AlterTableLocality{},
 		&tree.AlterTableOwner{},
 		&tree.AlterTableSetSchema{},
+		&tree.AlterTenantAdmission{},
 		&tree.AlterTenantCapability{},
 		&tree.AlterTenantRename{},
 		&tree.AlterTenantSetClusterSetting{},
@@ -343,6 +354,8 @@ func init() {
 		&tree.CreateDatabase{},
 		&tree.CreateExtension{},
 		&tree.CreateExternalConnection{},
+		&tree.CreatePlanBaseline{},
+		&tree.CreatePolicy{},
 		&tree.CreateTenant{},
 		&tree.CreateIndex{},
 		&tree.CreateSchema{},
@@ -354,6 +367,8 @@ func init() {
 		&tree.Discard{},
 		&tree.DropDatabase{},
 		&tree.DropExternalConnection{},
+		&tree.DropPlanBaseline{},
+		&tree.DropPolicy{},
 		&tree.DropRoutine{},
 		&tree.DropIndex{},
 		&tree.DropOwnedBy{},
@@ -0,0 +1,102 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cat
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Policy is a row-level security policy defined on a table. When row-level
+// security is enabled on the table, the USING expressions of the policies
+// determine which existing rows are visible to a statement, and the WITH CHECK
+// expressions determine which new rows can be written by it. For example:
+//
+//	CREATE POLICY p ON t USING (tenant_id = current_setting('app.tenant')::INT)
+type Policy struct {
+	// Name is the name of the policy, which is unique within the table.
+	Name string
+
+	// Restrictive is true if the policy is restrictive. A row must pass all the
+	// restrictive policies and at least one of the permissive policies that
+	// apply to a statement.
+	Restrictive bool
+
+	// Command is the kind of statement the policy applies to. PolicyCommandAll
+	// indicates that it applies to all statements.
+	Command tree.PolicyCommand
+
+	// Roles are the roles the policy applies to. It contains the public role if
+	// the policy applies to all users.
+	Roles []username.SQLUsername
+
+	// UsingExpr is the SQL text of the USING expression of the policy, or the
+	// empty string if it has none.
+	UsingExpr string
+
+	// WithCheckExpr is the SQL text of the WITH CHECK expression of the policy,
+	// or the empty string if it has none.
+	WithCheckExpr string
+}
+
+// AppliesToCommand returns true if the policy applies to statements of the
+// given kind.
+func (p *Policy) AppliesToCommand(cmd tree.PolicyCommand) bool {
+	return p.Command == tree.PolicyCommandAll || p.Command == cmd
+}
+
+// ApplicablePolicies returns the ordinals (see Table.Policy) of the row-level
+// security policies of the given table that apply to statements of the given
+// kind run by the current user. enforced is false if row-level security does
+// not restrict the current user, which is the case if it is not enabled on the
+// table, if the user is an admin or has the BYPASSRLS role option, or if the
+// user owns the table and row-level security is not forced on it.
+//
+// Note that if enforced is true and none of the returned policies is
+// permissive, no rows can be read or written by the statement.
+func ApplicablePolicies(
+	ctx context.Context, catalog Catalog, tab Table, cmd tree.PolicyCommand,
+) (ords []int, enforced bool, err error) {
+	if !tab.IsRowLevelSecurityEnabled() {
+		return nil, false, nil
+	}
+	if isAdmin, err := catalog.HasAdminRole(ctx); err != nil || isAdmin {
+		return nil, false, err
+	}
+	if bypass, err := catalog.HasRoleOption(ctx, roleoption.BYPASSRLS); err != nil || bypass {
+		return nil, false, err
+	}
+	if !tab.IsRowLevelSecurityForced() {
+		if isOwner, err := catalog.HasOwnership(ctx, tab); err != nil || isOwner {
+			return nil, false, err
+		}
+	}
+	for i, n := 0, tab.PolicyCount(); i < n; i++ {
+		policy := tab.Policy(i)
+		if !policy.AppliesToCommand(cmd) {
+			continue
+		}
+		for _, role := range policy.Roles {
+			isMember, err := catalog.IsMemberOfRole(ctx, role)
+			if err != nil {
+				return nil, false, err
+			}
+			if isMember {
+				ords = append(ords, i)
+				break
+			}
+		}
+	}
+	return ords, true, nil
+}
@@ -180,6 +180,22 @@ type Table interface {
 	// IsHypothetical returns true if this is a hypothetical table (used when
 	// searching for index recommendations).
 	IsHypothetical() bool
+
+	// IsRowLevelSecurityEnabled returns true if row-level security is enabled
+	// on the table, in which case the rows that can be read and written by
+	// users other than the owner are restricted by the table's policies.
+	IsRowLevelSecurityEnabled() bool
+
+	// IsRowLevelSecurityForced returns true if the row-level security policies
+	// of the table also apply to its owner.
+	IsRowLevelSecurityForced() bool
+
+	// PolicyCount returns the number of row-level security policies defined on
+	// the table.
+	PolicyCount() int
+
+	// Policy returns the ith row-level security policy, where i < PolicyCount.
+	Policy(i int) *Policy
 }
 
 // CheckConstraint represents a check constraint on a table. Check constraints
@@ -242,6 +258,16 @@ type TableStatistic interface {
 	// inverted index histograms, this will always return types.Bytes.
 	HistogramType() *types.T
 
+	// Dependencies returns the functional dependencies between pairs of columns
+	// of the statistic. It is only used for multi-column stats that collected
+	// extended statistics. See StatisticDependency for more details.
+	Dependencies() []StatisticDependency
+
+	// MostCommonValues returns the most common combinations of values of the
+	// columns of the statistic, ordered by descending frequency. It is only
+	// used for multi-column stats that collected extended statistics.
+	MostCommonValues() []StatisticMCV
+
 	// IsPartial returns true if this statistic was collected with a where
 	// clause. (If the where clause was something like "WHERE 1 = 1" or "WHERE
 	// true" this could technically be a full statistic rather than a partial
@@ -280,6 +306,30 @@ type HistogramBucket struct {
 	UpperBound tree.Datum
 }
 
+// StatisticDependency describes a functional dependency between two columns
+// of a multi-column statistic. From and To are indexes into the columns of the
+// statistic (i.e., 0 <= From, To < TableStatistic.ColumnCount()).
+type StatisticDependency struct {
+	From, To int
+
+	// Degree is the fraction of rows for which the value of the From column
+	// determines the value of the To column. A degree of 1 means that the
+	// dependency holds for every row, and 0 means the columns are unrelated.
+	Degree float64
+}
+
+// StatisticMCV is one of the most common combinations of values of the columns
+// of a multi-column statistic.
+type StatisticMCV struct {
+	// Values contains the value of each column of the statistic, in the order
+	// of the statistic's columns. It never contains NULL.
+	Values tree.Datums
+
+	// Frequency is the fraction of rows in the table that have this
+	// combination of values.
+	Frequency float64
+}
+
 // ForeignKeyConstraint represents a foreign key constraint. A foreign key
 // constraint has an origin (or referencing) side and a referenced side. For
 // example:
@@ -88,6 +88,11 @@ type Builder struct {
 	// rather than scans.
 	withExprs []builtWithExpr
 
+	// subqueryDeps, if non-nil, accumulates the ordinals (in b.subqueries) of
+	// the With buffers referenced by the subquery input that is currently being
+	// built. See buildSubqueryInput.
+	subqueryDeps *intsets.Fast
+
 	// allowAutoCommit is passed through to factory methods for mutation
 	// operators. It allows execution to commit the transaction as part of the
 	// mutation itself. See canAutoCommit().
@@ -329,16 +334,38 @@ type builtWithExpr struct {
 	// positions they are output to. See execPlan.outputCols for more details.
 	outputCols colOrdMap
 	bufferNode exec.Node
+	// subqueryIdx is the 1-based index of the subquery that populates the
+	// buffer, or zero if the buffer isn't populated by a subquery (e.g. it is
+	// the input to a mutation that is referenced by cascades).
+	subqueryIdx int
 }
 
-func (b *Builder) addBuiltWithExpr(id opt.WithID, outputCols colOrdMap, bufferNode exec.Node) {
+func (b *Builder) addBuiltWithExpr(
+	id opt.WithID, outputCols colOrdMap, bufferNode exec.Node, subqueryIdx int,
+) {
 	b.withExprs = append(b.withExprs, builtWithExpr{
-		id:         id,
-		outputCols: outputCols,
-		bufferNode: bufferNode,
+		id:          id,
+		outputCols:  outputCols,
+		bufferNode:  bufferNode,
+		subqueryIdx: subqueryIdx,
 	})
 }
 
+// addAllWithExprsToSubqueryDeps records that the subquery input currently being
+// built depends on all subqueries that populate With buffers built so far. It
+// is used when the input contains lazily-planned expressions (e.g. routines or
+// apply joins) which can reference any of the buffers in scope.
+func (b *Builder) addAllWithExprsToSubqueryDeps() {
+	if b.subqueryDeps == nil {
+		return
+	}
+	for i := range b.withExprs {
+		if idx := b.withExprs[i].subqueryIdx; idx > 0 {
+			b.subqueryDeps.Add(idx - 1)
+		}
+	}
+}
+
 func (b *Builder) findBuiltWithExpr(id opt.WithID) *builtWithExpr {
 	for i := range b.withExprs {
 		if b.withExprs[i].id == id {
@@ -302,7 +302,7 @@ func (cb *cascadeBuilder) planCascade(
 	)
 	if bufferRef != nil {
 		// Set up the With binding.
-		eb.addBuiltWithExpr(cascadeInputWithID, bufferColMap, bufferRef)
+		eb.addBuiltWithExpr(cascadeInputWithID, bufferColMap, bufferRef, 0 /* subqueryIdx */)
 	}
 	plan, err := eb.Build()
 	if err != nil {
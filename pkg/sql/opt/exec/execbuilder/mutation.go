@@ -81,7 +81,7 @@ func (b *Builder) buildMutationInput(
 			return execPlan{}, colOrdMap{}, err
 		}
 
-		b.addBuiltWithExpr(p.WithID, inputCols, bufferNode)
+		b.addBuiltWithExpr(p.WithID, inputCols, bufferNode, 0 /* subqueryIdx */)
 		input.root = bufferNode
 	}
 	return input, inputCols, nil
@@ -1115,6 +1115,7 @@ func (b *Builder) buildApplyJoin(join memo.RelExpr) (_ execPlan, outputCols colO
 	// We will pre-populate the withExprs of the right-hand side execbuilder.
 	withExprs := make([]builtWithExpr, len(b.withExprs))
 	copy(withExprs, b.withExprs)
+	b.addAllWithExprsToSubqueryDeps()
 
 	leftPlan, leftCols, err := b.buildRelational(leftExpr)
 	if err != nil {
@@ -3141,7 +3142,7 @@ func (b *Builder) buildMax1Row(
 }
 
 func (b *Builder) buildWith(with *memo.WithExpr) (_ execPlan, outputCols colOrdMap, err error) {
-	value, valuesCols, err := b.buildRelational(with.Binding)
+	value, valuesCols, deps, err := b.buildSubqueryInput(with.Binding)
 	if err != nil {
 		return execPlan{}, colOrdMap{}, err
 	}
@@ -3173,9 +3174,10 @@ func (b *Builder) buildWith(with *memo.WithExpr) (_ execPlan, outputCols colOrdM
 		Mode:     exec.SubqueryAllRows,
 		Root:     buffer,
 		RowCount: int64(with.Relational().Statistics().RowCountIfAvailable()),
+		Deps:     deps,
 	})
 
-	b.addBuiltWithExpr(with.ID, valuesCols, buffer)
+	b.addBuiltWithExpr(with.ID, valuesCols, buffer, len(b.subqueries))
 
 	return b.buildRelational(with.Main)
 }
@@ -3218,12 +3220,13 @@ func (b *Builder) buildRecursiveCTE(
 		// original builder.
 		withExprs: b.withExprs[:len(b.withExprs):len(b.withExprs)],
 	}
+	b.addAllWithExprsToSubqueryDeps()
 
 	fn := func(ef exec.Factory, bufferRef exec.Node) (exec.Plan, error) {
 		// Use a separate builder each time.
 		innerBld := *innerBldTemplate
 		innerBld.factory = ef
-		innerBld.addBuiltWithExpr(rec.WithID, initialCols, bufferRef)
+		innerBld.addBuiltWithExpr(rec.WithID, initialCols, bufferRef, 0 /* subqueryIdx */)
 		// TODO(mgartner): I think colOrdsAlloc can be reused for each recursive
 		// iteration.
 		innerBld.colOrdsAlloc.Init(innerBld.mem.Metadata().MaxColumn())
@@ -3268,6 +3271,9 @@ func (b *Builder) buildWithScan(
 			"couldn't find With expression with ID %d", withScan.With,
 		)
 	}
+	if b.subqueryDeps != nil && e.subqueryIdx > 0 {
+		b.subqueryDeps.Add(e.subqueryIdx - 1)
+	}
 
 	var label bytes.Buffer
 	fmt.Fprintf(&label, "buffer %d", withScan.With)
@@ -32,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
@@ -518,7 +519,7 @@ func (b *Builder) buildArrayFlatten(
 		return nil, b.decorrelationError()
 	}
 
-	root, _, err := b.buildRelational(af.Input)
+	root, _, deps, err := b.buildSubqueryInput(af.Input)
 	if err != nil {
 		return nil, err
 	}
@@ -526,7 +527,7 @@ func (b *Builder) buildArrayFlatten(
 	typ := b.mem.Metadata().ColumnMeta(af.RequestedCol).Type
 	e := b.addSubquery(
 		exec.SubqueryAllRows, typ, root.root, af.OriginalExpr,
-		int64(af.Input.Relational().Statistics().RowCountIfAvailable()),
+		int64(af.Input.Relational().Statistics().RowCountIfAvailable()), deps,
 	)
 
 	return tree.NewTypedArrayFlattenExpr(e), nil
@@ -578,7 +579,7 @@ func (b *Builder) buildAny(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 	}
 
 	// Build the execution plan for the input subquery.
-	plan, planCols, err := b.buildRelational(any.Input)
+	plan, planCols, deps, err := b.buildSubqueryInput(any.Input)
 	if err != nil {
 		return nil, err
 	}
@@ -591,7 +592,7 @@ func (b *Builder) buildAny(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 	typs := types.MakeTuple(contents)
 	subqueryExpr := b.addSubquery(
 		exec.SubqueryAnyRows, typs, plan.root, any.OriginalExpr,
-		int64(any.Input.Relational().Statistics().RowCountIfAvailable()),
+		int64(any.Input.Relational().Statistics().RowCountIfAvailable()), deps,
 	)
 
 	// Build the scalar value that is compared against each row.
@@ -724,14 +725,14 @@ func (b *Builder) buildExistsSubquery(
 	// ConvertUncorrelatedExistsToCoalesceSubquery converts all uncorrelated
 	// Exists with Coalesce+Subquery expressions. Remove this and the execution
 	// support for the Exists mode.
-	plan, _, err := b.buildRelational(exists.Input)
+	plan, _, deps, err := b.buildSubqueryInput(exists.Input)
 	if err != nil {
 		return nil, err
 	}
 
 	return b.addSubquery(
 		exec.SubqueryExists, types.Bool, plan.root, exists.OriginalExpr,
-		int64(exists.Input.Relational().Statistics().RowCountIfAvailable()),
+		int64(exists.Input.Relational().Statistics().RowCountIfAvailable()), deps,
 	), nil
 }
 
@@ -888,7 +889,7 @@ func (b *Builder) buildSubquery(
 
 	// Build the execution plan for the subquery. Note that the subquery could
 	// have subqueries of its own which are added to b.subqueries.
-	plan, _, err := b.buildRelational(input)
+	plan, _, deps, err := b.buildSubqueryInput(input)
 	if err != nil {
 		return nil, err
 	}
@@ -896,14 +897,43 @@ func (b *Builder) buildSubquery(
 	// Build a subquery that is eagerly evaluated before the main query.
 	return b.addSubquery(
 		exec.SubqueryOneRow, subquery.Typ, plan.root, subquery.OriginalExpr,
-		int64(input.Relational().Statistics().RowCountIfAvailable()),
+		int64(input.Relational().Statistics().RowCountIfAvailable()), deps,
 	), nil
 }
 
+// buildSubqueryInput builds the plan for the input of a subquery (or the
+// binding of a With expression) that is evaluated eagerly before the main
+// query. It also returns the set of ordinals (in b.subqueries) of the
+// subqueries that must be evaluated before this one: the subqueries nested
+// within the input as well as the subqueries populating With buffers that the
+// input reads from.
+func (b *Builder) buildSubqueryInput(
+	input memo.RelExpr,
+) (_ execPlan, outputCols colOrdMap, deps intsets.Fast, err error) {
+	start := len(b.subqueries)
+	defer func(prev *intsets.Fast) { b.subqueryDeps = prev }(b.subqueryDeps)
+	b.subqueryDeps = &deps
+	plan, outputCols, err := b.buildRelational(input)
+	if err != nil {
+		return execPlan{}, colOrdMap{}, intsets.Fast{}, err
+	}
+	// Any subqueries added while building the input are nested within it.
+	if len(b.subqueries) > start {
+		deps.AddRange(start, len(b.subqueries)-1)
+	}
+	return plan, outputCols, deps, nil
+}
+
 // addSubquery adds an entry to b.subqueries and creates a tree.Subquery
-// expression node associated with it.
+// expression node associated with it. deps is the set of ordinals of the
+// subqueries that must be evaluated before this one.
 func (b *Builder) addSubquery(
-	mode exec.SubqueryMode, typ *types.T, root exec.Node, originalExpr *tree.Subquery, rowCount int64,
+	mode exec.SubqueryMode,
+	typ *types.T,
+	root exec.Node,
+	originalExpr *tree.Subquery,
+	rowCount int64,
+	deps intsets.Fast,
 ) *tree.Subquery {
 	var originalSelect tree.SelectStatement
 	if originalExpr != nil {
@@ -919,6 +949,7 @@ func (b *Builder) addSubquery(
 		Mode:     mode,
 		Root:     root,
 		RowCount: rowCount,
+		Deps:     deps,
 	})
 	// Associate the tree.Subquery expression node with this subquery
 	// by index (1-based).
@@ -1092,6 +1123,7 @@ func (b *Builder) buildRoutinePlanGenerator(
 	if allowOuterWithRefs {
 		withExprs = make([]builtWithExpr, len(b.withExprs))
 		copy(withExprs, b.withExprs)
+		b.addAllWithExprsToSubqueryDeps()
 	}
 
 	// Plan the statements in a separate memo. We use an exec.Factory passed to
@@ -491,6 +491,20 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 		if s.SQLCPUTime.HasValue() {
 			e.ob.AddField("sql cpu time", string(humanizeutil.Duration(s.SQLCPUTime.Value())))
 		}
+		if s.AdaptiveLookupJoins.HasValue() || s.AdaptiveHashJoins.HasValue() {
+			lookupJoins, hashJoins := s.AdaptiveLookupJoins.Value(), s.AdaptiveHashJoins.Value()
+			switch {
+			case hashJoins == 0:
+				e.ob.AddField("adaptive join strategy", "lookup join")
+			case lookupJoins == 0:
+				e.ob.AddField("adaptive join strategy", "hash join")
+			default:
+				// Each processor of a distributed join decides on its own.
+				e.ob.AddField("adaptive join strategy", fmt.Sprintf(
+					"lookup join (%d processors), hash join (%d processors)", lookupJoins, hashJoins,
+				))
+			}
+		}
 		if e.ob.flags.Verbose {
 			if s.StepCount.HasValue() {
 				e.ob.AddField("MVCC step count (ext/int)", fmt.Sprintf("%s/%s",
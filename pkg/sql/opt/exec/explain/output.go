@@ -301,6 +301,12 @@ func (ob *OutputBuilder) AddDistribution(value string) {
 	ob.AddFlakyTopLevelField(DeflakeDistribution, "distribution", value)
 }
 
+// AddDistributionReason adds a top-level field that explains the value of the
+// distribution field. Cannot be called while inside a node.
+func (ob *OutputBuilder) AddDistributionReason(reason string) {
+	ob.AddFlakyTopLevelField(DeflakeDistribution, "distribution reason", reason)
+}
+
 // AddVectorized adds a top-level vectorized field. Cannot be called
 // while inside a node.
 func (ob *OutputBuilder) AddVectorized(value bool) {
@@ -15,6 +15,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/binary"
+	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -235,6 +236,217 @@ func DecodePlanGistToPlan(s string, cat cat.Catalog) (plan *Plan, retErr error)
 	return plan, nil
 }
 
+// IndexAccess identifies an index of a table that is read by a plan.
+type IndexAccess struct {
+	Table cat.Table
+	Index cat.Index
+}
+
+// DecodePlanGistToIndexAccesses decodes a gist and returns the indexes read by
+// the scans, lookup joins, inverted joins and zigzag joins of the plan. The
+// inputs of a node are visited before the node itself. Tables and indexes that
+// no longer exist in the catalog are omitted.
+func DecodePlanGistToIndexAccesses(
+	gist string, catalog cat.Catalog,
+) (_ []IndexAccess, retErr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// This code allows us to propagate internal errors without having
+			// to add error checks everywhere throughout the code. This is only
+			// possible because the code does not update shared state and does
+			// not manipulate locks.
+			if ok, e := errorutil.ShouldCatch(r); ok {
+				retErr = e
+			} else {
+				// Other panic objects can't be considered "safe" and thus are
+				// propagated as crashes that terminate the session.
+				panic(r)
+			}
+		}
+	}()
+
+	plan, err := DecodePlanGistToPlan(gist, catalog)
+	if err != nil {
+		return nil, err
+	}
+	var res []IndexAccess
+	add := func(table cat.Table, index cat.Index) {
+		if table == nil || index == nil {
+			return
+		}
+		if _, ok := table.(*unknownTable); ok {
+			return
+		}
+		if _, ok := index.(*unknownIndex); ok {
+			return
+		}
+		res = append(res, IndexAccess{Table: table, Index: index})
+	}
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		if n == nil {
+			return
+		}
+		for _, c := range n.children {
+			walk(c)
+		}
+		switch n.op {
+		case scanOp:
+			a := n.args.(*scanArgs)
+			add(a.Table, a.Index)
+		case lookupJoinOp:
+			a := n.args.(*lookupJoinArgs)
+			add(a.Table, a.Index)
+		case invertedJoinOp:
+			a := n.args.(*invertedJoinArgs)
+			add(a.Table, a.Index)
+		case zigzagJoinOp:
+			a := n.args.(*zigzagJoinArgs)
+			add(a.LeftTable, a.LeftIndex)
+			add(a.RightTable, a.RightIndex)
+		}
+	}
+	walk(plan.Root)
+	for i := range plan.Subqueries {
+		if n, ok := plan.Subqueries[i].Root.(*Node); ok {
+			walk(n)
+		}
+	}
+	for _, n := range plan.Checks {
+		walk(n)
+	}
+	return res, nil
+}
+
+// JoinShape describes a join of a plan: the algorithm that executes it and the
+// tables read by each of its inputs, sorted by ID. For lookup and inverted
+// joins, Right is the table whose index is looked up.
+type JoinShape struct {
+	Algorithm   exec.JoinAlgorithm
+	Left, Right []cat.StableID
+}
+
+// DecodePlanGistToJoins decodes a gist and returns the shapes of the hash,
+// merge, lookup and inverted joins of the plan. The inputs of a node are
+// visited before the node itself. Cross joins are reported as hash joins, and
+// lookup joins into a table that is already read by their input (which stand
+// in for index joins) are omitted. ok is false if the gist references a table
+// that no longer exists in the catalog or contains an apply join, in which
+// case the joins of the plan cannot be reproduced.
+func DecodePlanGistToJoins(
+	gist string, catalog cat.Catalog,
+) (_ []JoinShape, ok bool, retErr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// This code allows us to propagate internal errors without having
+			// to add error checks everywhere throughout the code. This is only
+			// possible because the code does not update shared state and does
+			// not manipulate locks.
+			if ok, e := errorutil.ShouldCatch(r); ok {
+				retErr = e
+			} else {
+				// Other panic objects can't be considered "safe" and thus are
+				// propagated as crashes that terminate the session.
+				panic(r)
+			}
+		}
+	}()
+
+	plan, err := DecodePlanGistToPlan(gist, catalog)
+	if err != nil {
+		return nil, false, err
+	}
+	ok = true
+	tableID := func(table cat.Table) cat.StableID {
+		if _, unknown := table.(*unknownTable); unknown || table == nil {
+			ok = false
+			return 0
+		}
+		return table.ID()
+	}
+	var res []JoinShape
+	// walk returns the sorted, deduplicated IDs of the tables read by the
+	// subtree rooted at n.
+	var walk func(n *Node) []cat.StableID
+	walk = func(n *Node) []cat.StableID {
+		if n == nil {
+			return nil
+		}
+		inputs := make([][]cat.StableID, len(n.children))
+		var tables []cat.StableID
+		for i, c := range n.children {
+			inputs[i] = walk(c)
+			tables = append(tables, inputs[i]...)
+		}
+		tables = sortedTableIDs(tables)
+		switch n.op {
+		case applyJoinOp:
+			ok = false
+		case scanOp:
+			tables = sortedTableIDs(append(tables, tableID(n.args.(*scanArgs).Table)))
+		case indexJoinOp:
+			tables = sortedTableIDs(append(tables, tableID(n.args.(*indexJoinArgs).Table)))
+		case zigzagJoinOp:
+			a := n.args.(*zigzagJoinArgs)
+			tables = sortedTableIDs(append(tables, tableID(a.LeftTable), tableID(a.RightTable)))
+		case hashJoinOp, mergeJoinOp:
+			algorithm := exec.HashJoin
+			if n.op == mergeJoinOp {
+				algorithm = exec.MergeJoin
+			}
+			if len(inputs[0]) > 0 && len(inputs[1]) > 0 {
+				res = append(res, JoinShape{Algorithm: algorithm, Left: inputs[0], Right: inputs[1]})
+			}
+		case lookupJoinOp, invertedJoinOp:
+			algorithm, table := exec.LookupJoin, cat.Table(nil)
+			if n.op == lookupJoinOp {
+				table = n.args.(*lookupJoinArgs).Table
+			} else {
+				algorithm, table = exec.InvertedJoin, n.args.(*invertedJoinArgs).Table
+			}
+			id := tableID(table)
+			if len(tables) > 0 && !containsTableID(tables, id) {
+				res = append(res, JoinShape{
+					Algorithm: algorithm, Left: tables, Right: []cat.StableID{id},
+				})
+			}
+			tables = sortedTableIDs(append(append([]cat.StableID(nil), tables...), id))
+		}
+		return tables
+	}
+	walk(plan.Root)
+	for i := range plan.Subqueries {
+		if n, ok := plan.Subqueries[i].Root.(*Node); ok {
+			walk(n)
+		}
+	}
+	for _, n := range plan.Checks {
+		walk(n)
+	}
+	if !ok {
+		return nil, false, nil
+	}
+	return res, true, nil
+}
+
+// sortedTableIDs sorts and deduplicates the given table IDs in place.
+func sortedTableIDs(ids []cat.StableID) []cat.StableID {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	res := ids[:0]
+	for i, id := range ids {
+		if i == 0 || id != ids[i-1] {
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+// containsTableID returns whether the sorted table IDs contain the given ID.
+func containsTableID(ids []cat.StableID, id cat.StableID) bool {
+	i := sort.Search(len(ids), func(i int) bool { return ids[i] >= id })
+	return i < len(ids) && ids[i] == id
+}
+
 func (f *PlanGistFactory) decodeOp() execOperator {
 	val, err := f.buffer.ReadByte()
 	if err != nil || val == 0 {
@@ -628,6 +840,26 @@ func (u *unknownTable) IsHypothetical() bool {
 	return false
 }
 
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (u *unknownTable) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (u *unknownTable) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (u *unknownTable) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (u *unknownTable) Policy(i int) *cat.Policy {
+	panic(errors.AssertionFailedf("no policies"))
+}
+
 var _ cat.Table = &unknownTable{}
 
 // unknownTable implements the cat.Index interface and is used to represent
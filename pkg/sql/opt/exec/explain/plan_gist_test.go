@@ -13,6 +13,7 @@ package explain_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
@@ -151,3 +152,112 @@ func TestPlanGistHashEquivalency(t *testing.T) {
 		t.Errorf("gists should be different! %s == %s", gist1.String(), gist2.String())
 	}
 }
+
+func TestDecodePlanGistToIndexAccesses(t *testing.T) {
+	catalog := testcat.New()
+	if _, err := catalog.ExecuteDDL(
+		"CREATE TABLE abc (a INT PRIMARY KEY, b INT, c INT, INDEX b_idx (b), INDEX c_idx (c));",
+	); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := catalog.ExecuteDDL("CREATE TABLE xy (x INT PRIMARY KEY, y INT);"); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		query    string
+		expected string
+	}{
+		{query: "SELECT * FROM abc", expected: "abc@abc_pkey"},
+		{query: "SELECT a FROM abc@b_idx WHERE b > 1", expected: "abc@b_idx"},
+		{query: "SELECT a FROM abc@c_idx WHERE c = 1", expected: "abc@c_idx"},
+		{
+			query:    "SELECT x, b FROM xy INNER LOOKUP JOIN abc@b_idx ON y = b",
+			expected: "xy@xy_pkey abc@b_idx",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			ot := opttester.New(catalog, tc.query)
+			gist := makeGist(ot, t)
+			accesses, err := explain.DecodePlanGistToIndexAccesses(gist.String(), catalog)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var res []string
+			for _, ia := range accesses {
+				res = append(res, fmt.Sprintf("%s@%s", ia.Table.Name(), ia.Index.Name()))
+			}
+			if actual := strings.Join(res, " "); actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDecodePlanGistToJoins(t *testing.T) {
+	catalog := testcat.New()
+	for _, ddl := range []string{
+		"CREATE TABLE abc (a INT PRIMARY KEY, b INT, c INT, INDEX b_idx (b));",
+		"CREATE TABLE xy (x INT PRIMARY KEY, y INT);",
+		"CREATE TABLE uv (u INT PRIMARY KEY, v INT);",
+	} {
+		if _, err := catalog.ExecuteDDL(ddl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	names := make(map[cat.StableID]string)
+	for _, tab := range catalog.Tables() {
+		names[tab.ID()] = string(tab.Name())
+	}
+	algorithms := map[exec.JoinAlgorithm]string{
+		exec.HashJoin:     "hash",
+		exec.MergeJoin:    "merge",
+		exec.LookupJoin:   "lookup",
+		exec.InvertedJoin: "inverted",
+	}
+	tables := func(ids []cat.StableID) string {
+		var res []string
+		for _, id := range ids {
+			res = append(res, names[id])
+		}
+		return strings.Join(res, " ")
+	}
+
+	testCases := []struct {
+		query    string
+		expected string
+	}{
+		{query: "SELECT * FROM abc", expected: ""},
+		{query: "SELECT * FROM xy INNER HASH JOIN abc ON y = a", expected: "hash(xy, abc)"},
+		{query: "SELECT * FROM abc INNER MERGE JOIN xy ON a = x", expected: "merge(abc, xy)"},
+		{query: "SELECT x, b FROM xy INNER LOOKUP JOIN abc@b_idx ON y = b", expected: "lookup(xy, abc)"},
+		// The lookup into the primary index of abc stands in for an index join
+		// and is omitted.
+		{query: "SELECT * FROM xy INNER LOOKUP JOIN abc@b_idx ON y = b", expected: "lookup(xy, abc)"},
+		{
+			query:    "SELECT * FROM xy INNER HASH JOIN abc ON y = a INNER HASH JOIN uv ON v = c",
+			expected: "hash(xy, abc) hash(abc xy, uv)",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			ot := opttester.New(catalog, tc.query)
+			gist := makeGist(ot, t)
+			joins, ok, err := explain.DecodePlanGistToJoins(gist.String(), catalog)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Fatal("expected the joins of the plan to be decoded")
+			}
+			var res []string
+			for _, j := range joins {
+				res = append(res, fmt.Sprintf("%s(%s, %s)", algorithms[j.Algorithm], tables(j.Left), tables(j.Right)))
+			}
+			if actual := strings.Join(res, " "); actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
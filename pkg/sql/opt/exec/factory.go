@@ -155,6 +155,12 @@ type Subquery struct {
 	// RowCount is the estimated number of rows that Root will output, negative
 	// if the stats weren't available to make a good estimate.
 	RowCount int64
+	// Deps contains the ordinals (in the list of subqueries of the plan) of
+	// the subqueries that must be evaluated before this one, either because
+	// they are nested within it or because it reads from the buffers they
+	// populate. Subqueries that are not in each other's Deps (transitively)
+	// are independent and can be evaluated concurrently.
+	Deps intsets.Fast
 }
 
 // SubqueryMode indicates how the results of the subquery are to be processed.
@@ -499,6 +505,12 @@ type ExecutionStats struct {
 	MaxAllocatedDisk optional.Uint
 	SQLCPUTime       optional.Duration
 
+	// AdaptiveLookupJoins and AdaptiveHashJoins are the number of processors
+	// of an adaptive join that chose the lookup join and the hash join
+	// strategies, respectively.
+	AdaptiveLookupJoins optional.Uint
+	AdaptiveHashJoins   optional.Uint
+
 	// Nodes on which this operator was executed.
 	Nodes []string
 
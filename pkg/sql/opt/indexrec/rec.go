@@ -52,6 +52,12 @@ type Rec struct {
 	// Replacement is true if SQL replaces an existing index, i.e., it contains
 	// both a CREATE INDEX and DROP INDEX statement.
 	RecType Type
+	// CostReduction is the fraction of the estimated cost of the statement's
+	// plan that is saved once all the recommendations for the statement are
+	// applied, as found by costing the statement with hypothetical indexes. It
+	// is the same for all the recommendations of a statement. It is set by the
+	// caller of FindRecs.
+	CostReduction float64
 }
 
 // FindRecs finds index candidates that are scanned in an expression to
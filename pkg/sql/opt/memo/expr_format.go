@@ -413,6 +413,11 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			tp.Childf("error: \"%s\"", t.ErrorText)
 		}
 
+	case *BarrierExpr:
+		if t.LeakproofPermeable && !f.HasFlags(ExprFmtHideMiscProps) {
+			tp.Child("leakproof-permeable")
+		}
+
 	// Special-case handling for set operators to show the left and right
 	// input columns that correspond to the output columns.
 	case *UnionExpr, *IntersectExpr, *ExceptExpr,
@@ -189,6 +189,8 @@ type Memo struct {
 	mergeJoinsEnabled                          bool
 	plpgsqlUseStrictInto                       bool
 	useVirtualComputedColumnStats              bool
+	useExtendedStats                           bool
+	useNetworkTransferCost                     bool
 
 	// txnIsoLevel is the isolation level under which the plan was created. This
 	// affects the planning of some locking operations, so it must be included in
@@ -203,6 +205,12 @@ type Memo struct {
 
 	newGroupFn func(opt.Expr)
 
+	// pinnedVersion identifies the version of the pinned access paths that were
+	// applied while building the memo, such as those of a plan baseline, or is
+	// empty if there were none. It is not derived from the session, so callers
+	// that cache the memo must compare it with PinnedVersion on reuse.
+	pinnedVersion string
+
 	// disableCheckExpr disables expression validation performed by CheckExpr,
 	// if the crdb_test build tag is set. If the crdb_test build tag is not set,
 	// CheckExpr is always a no-op, so disableCheckExpr has no effect. This is
@@ -264,6 +272,8 @@ func (m *Memo) Init(ctx context.Context, evalCtx *eval.Context) {
 		mergeJoinsEnabled:                          evalCtx.SessionData().OptimizerMergeJoinsEnabled,
 		plpgsqlUseStrictInto:                       evalCtx.SessionData().PLpgSQLUseStrictInto,
 		useVirtualComputedColumnStats:              evalCtx.SessionData().OptimizerUseVirtualComputedColumnStats,
+		useExtendedStats:                           evalCtx.SessionData().OptimizerUseExtendedStats,
+		useNetworkTransferCost:                     evalCtx.SessionData().OptimizerUseNetworkTransferCost,
 		txnIsoLevel:                                evalCtx.TxnIsoLevel,
 	}
 	m.metadata.Init()
@@ -343,6 +353,17 @@ func (m *Memo) SetRoot(e RelExpr, phys *physical.Required) {
 	}
 }
 
+// SetPinnedVersion records the version of the pinned access paths that were
+// applied while building the memo.
+func (m *Memo) SetPinnedVersion(v string) {
+	m.pinnedVersion = v
+}
+
+// PinnedVersion returns the version recorded with SetPinnedVersion.
+func (m *Memo) PinnedVersion() string {
+	return m.pinnedVersion
+}
+
 // HasPlaceholders returns true if the memo contains at least one placeholder
 // operator.
 func (m *Memo) HasPlaceholders() bool {
@@ -417,6 +438,8 @@ func (m *Memo) IsStale(
 		m.mergeJoinsEnabled != evalCtx.SessionData().OptimizerMergeJoinsEnabled ||
 		m.plpgsqlUseStrictInto != evalCtx.SessionData().PLpgSQLUseStrictInto ||
 		m.useVirtualComputedColumnStats != evalCtx.SessionData().OptimizerUseVirtualComputedColumnStats ||
+		m.useExtendedStats != evalCtx.SessionData().OptimizerUseExtendedStats ||
+		m.useNetworkTransferCost != evalCtx.SessionData().OptimizerUseNetworkTransferCost ||
 		m.txnIsoLevel != evalCtx.TxnIsoLevel {
 		return true, nil
 	}
@@ -442,6 +442,18 @@ func TestMemoIsStale(t *testing.T) {
 	evalCtx.SessionData().OptimizerUseVirtualComputedColumnStats = false
 	notStale()
 
+	// Stale optimizer_use_extended_stats.
+	evalCtx.SessionData().OptimizerUseExtendedStats = true
+	stale()
+	evalCtx.SessionData().OptimizerUseExtendedStats = false
+	notStale()
+
+	// Stale optimizer_use_network_transfer_cost.
+	evalCtx.SessionData().OptimizerUseNetworkTransferCost = true
+	stale()
+	evalCtx.SessionData().OptimizerUseNetworkTransferCost = false
+	notStale()
+
 	// User no longer has access to view.
 	catalog.View(tree.NewTableNameWithSchema("t", catconstants.PublicSchemaName, "abcview")).Revoked = true
 	_, err = o.Memo().IsStale(ctx, &evalCtx, catalog)
@@ -750,6 +750,19 @@ func (sb *statisticsBuilder) makeTableStatistics(tabID opt.TableID) *props.Stati
 				// were added at different times (and therefore have a different row
 				// count).
 				sb.finalizeFromRowCountAndDistinctCounts(colStat, stats)
+
+				if ok && cols.Len() > 1 && sb.evalCtx.SessionData().OptimizerUseExtendedStats &&
+					(len(stat.Dependencies()) > 0 || len(stat.MostCommonValues()) > 0) {
+					ext := props.ExtendedStatistic{
+						Cols:             make([]opt.ColumnID, stat.ColumnCount()),
+						Dependencies:     stat.Dependencies(),
+						MostCommonValues: stat.MostCommonValues(),
+					}
+					for i := range ext.Cols {
+						ext.Cols[i] = tabID.ColumnID(stat.ColumnOrdinal(i))
+					}
+					stats.ExtendedStats = append(stats.ExtendedStats, ext)
+				}
 			}
 
 			// Add inverted histograms if necessary.
@@ -1017,7 +1030,27 @@ func (sb *statisticsBuilder) constrainScan(
 	// Calculate row count and selectivity
 	// -----------------------------------
 	corr := sb.correlationFromMultiColDistinctCounts(constrainedCols, scan, s)
-	s.ApplySelectivity(sb.selectivityFromConstrainedCols(constrainedCols, histCols, scan, s, corr))
+	selectivity := sb.selectivityFromConstrainedCols(constrainedCols, histCols, scan, s, corr)
+	// Most common values can only be used if the index constraint alone fixes
+	// all of the constrained columns to constant values.
+	if constraint != nil && pred == nil && scan.InvertedConstraint == nil &&
+		constrainedCols.SubsetOf(constraint.ExtractConstCols(sb.evalCtx)) {
+		if mcvSel, ok := sb.selectivityFromMostCommonValues(
+			constrainedCols,
+			func(col opt.ColumnID) tree.Datum {
+				for i := 0; i < constraint.Columns.Count(); i++ {
+					if constraint.Columns.Get(i).ID() == col {
+						return constraint.Spans.Get(0).StartKey().Value(i)
+					}
+				}
+				return nil
+			},
+			selectivity,
+		); ok {
+			selectivity = mcvSel
+		}
+	}
+	s.ApplySelectivity(selectivity)
 	s.ApplySelectivity(sb.selectivityFromUnappliedConjuncts(numUnappliedConjuncts))
 	s.ApplySelectivity(sb.selectivityFromNullsRemoved(scan, notNullCols, constrainedCols))
 }
@@ -3270,7 +3303,24 @@ func (sb *statisticsBuilder) filterRelExpr(
 	// Calculate row count and selectivity
 	// -----------------------------------
 	corr := sb.correlationFromMultiColDistinctCounts(constrainedCols, e, s)
-	s.ApplySelectivity(sb.selectivityFromConstrainedCols(constrainedCols, histCols, e, s, corr))
+	selectivity := sb.selectivityFromConstrainedCols(constrainedCols, histCols, e, s, corr)
+	if sel, ok := e.(*SelectExpr); ok {
+		// Most common values are relative to the entire table, so they can only
+		// be used if the input is an unfiltered scan.
+		if scan, ok := sel.Input.(*ScanExpr); ok && scan.IsUnfiltered(sb.md) &&
+			constrainedCols.SubsetOf(ExtractConstColumns(filters, sb.evalCtx)) {
+			if mcvSel, ok := sb.selectivityFromMostCommonValues(
+				constrainedCols,
+				func(col opt.ColumnID) tree.Datum {
+					return ExtractValueForConstColumn(filters, sb.evalCtx, col)
+				},
+				selectivity,
+			); ok {
+				selectivity = mcvSel
+			}
+		}
+	}
+	s.ApplySelectivity(selectivity)
 	s.ApplySelectivity(sb.selectivityFromEquivalencies(equivReps, &relProps.FuncDeps, e, s))
 	s.ApplySelectivity(sb.selectivityFromUnappliedConjuncts(numUnappliedConjuncts))
 	s.ApplySelectivity(sb.selectivityFromNullsRemoved(e, notNullCols, constrainedCols))
@@ -4199,12 +4249,121 @@ func (sb *statisticsBuilder) correlationFromMultiColDistinctCounts(
 		return 0
 	}
 
+	// Functional dependencies from extended statistics may indicate a stronger
+	// correlation than the distinct counts do.
+	depCorr := sb.correlationFromDependencies(cols)
+
 	lowerBound, _ := sb.selectivityFromSingleColDistinctCounts(cols, e, s)
 	selectivity, upperBound := sb.selectivityFromMultiColDistinctCounts(cols, e, s)
 	if upperBound == lowerBound {
+		return depCorr
+	}
+	corr := (selectivity.AsFloat() - lowerBound.AsFloat()) / (upperBound.AsFloat() - lowerBound.AsFloat())
+	return max(corr, depCorr)
+}
+
+// correlationFromDependencies returns the correlation between the given set of
+// columns implied by the functional dependencies in the extended statistics of
+// their table. The columns are completely correlated if the value of one of
+// them determines the values of all the others, so the correlation is the
+// lowest degree with which each column but one is determined by another column
+// in the set. It returns 0 if the columns are not all from the same table or
+// if there are no dependencies between them.
+func (sb *statisticsBuilder) correlationFromDependencies(cols opt.ColSet) float64 {
+	if cols.Len() < 2 || !sb.evalCtx.SessionData().OptimizerUseExtendedStats {
 		return 0
 	}
-	return (selectivity.AsFloat() - lowerBound.AsFloat()) / (upperBound.AsFloat() - lowerBound.AsFloat())
+	colList := cols.ToList()
+	tabID := sb.md.ColumnMeta(colList[0]).Table
+	if tabID == 0 {
+		return 0
+	}
+	for _, col := range colList[1:] {
+		if sb.md.ColumnMeta(col).Table != tabID {
+			return 0
+		}
+	}
+	tableStats := sb.makeTableStatistics(tabID)
+	if len(tableStats.ExtendedStats) == 0 {
+		return 0
+	}
+
+	// determined[i] is the highest degree with which colList[i] is determined
+	// by another column in cols.
+	determined := make([]float64, len(colList))
+	for i := range tableStats.ExtendedStats {
+		ext := &tableStats.ExtendedStats[i]
+		for _, dep := range ext.Dependencies {
+			from, to := ext.Cols[dep.From], ext.Cols[dep.To]
+			if !cols.Contains(from) {
+				continue
+			}
+			for j, col := range colList {
+				if col == to {
+					determined[j] = max(determined[j], dep.Degree)
+				}
+			}
+		}
+	}
+	// The column that is least determined by the others can be the one that
+	// determines the rest, so it is excluded and the correlation is the second
+	// lowest degree.
+	lowest, secondLowest := math.Inf(1), math.Inf(1)
+	for _, degree := range determined {
+		if degree < lowest {
+			lowest, secondLowest = degree, lowest
+		} else if degree < secondLowest {
+			secondLowest = degree
+		}
+	}
+	return min(secondLowest, 1)
+}
+
+// selectivityFromMostCommonValues returns the selectivity of a filter that
+// constrains each of the given columns to a single constant value, estimated
+// from the most common values of a multi-column statistic on exactly those
+// columns. constValue returns the constant value of each column. The
+// selectivity is relative to the entire table, so callers must only use it
+// when the filter is applied directly to an unfiltered scan. If the constant
+// values are not among the most common values, the given fallback selectivity
+// is capped by the frequency of the least common value in the list, since any
+// value missing from the list is less common than those in it. ok is false if
+// there is no applicable statistic.
+func (sb *statisticsBuilder) selectivityFromMostCommonValues(
+	cols opt.ColSet, constValue func(opt.ColumnID) tree.Datum, fallback props.Selectivity,
+) (_ props.Selectivity, ok bool) {
+	if cols.Len() < 2 || !sb.evalCtx.SessionData().OptimizerUseExtendedStats {
+		return props.Selectivity{}, false
+	}
+	firstCol, _ := cols.Next(0)
+	tabID := sb.md.ColumnMeta(firstCol).Table
+	if tabID == 0 {
+		return props.Selectivity{}, false
+	}
+	tableStats := sb.makeTableStatistics(tabID)
+	for i := range tableStats.ExtendedStats {
+		ext := &tableStats.ExtendedStats[i]
+		if len(ext.MostCommonValues) == 0 || !ext.ColSet().Equals(cols) {
+			continue
+		}
+		values := make(tree.Datums, len(ext.Cols))
+		for j, col := range ext.Cols {
+			values[j] = constValue(col)
+			if values[j] == nil || values[j] == tree.DNull {
+				return props.Selectivity{}, false
+			}
+		}
+		minFrequency := 1.0
+		for j := range ext.MostCommonValues {
+			mcv := &ext.MostCommonValues[j]
+			if values.Compare(sb.evalCtx, mcv.Values) == 0 {
+				return props.MakeSelectivity(mcv.Frequency), true
+			}
+			minFrequency = min(minFrequency, mcv.Frequency)
+		}
+		return props.MinSelectivity(fallback, props.MakeSelectivity(minFrequency)), true
+	}
+	return props.Selectivity{}, false
 }
 
 // correlationFromMultiColDistinctCountsForJoin is similar to
@@ -146,6 +146,17 @@ type Metadata struct {
 	// as a builtin function.
 	builtinRefsByName map[tree.UnresolvedName]struct{}
 
+	// rlsDeps stores the row-level security policies that were applied to the
+	// tables with row-level security enabled that the query depends on. It is
+	// needed because the policies that apply depend on the current user and its
+	// role memberships, which can change without the tables changing.
+	rlsDeps []rlsDep
+
+	// resultCacheHint is true if the query contains a RESULT_CACHE hint, which
+	// allows its results to be cached regardless of the result_cache_enabled
+	// session variable.
+	resultCacheHint bool
+
 	// NOTE! When adding fields here, update Init (if reusing allocated
 	// data structures is desired), CopyFrom and TestMetadata.
 }
@@ -219,6 +230,11 @@ func (md *Metadata) Init() {
 		delete(md.builtinRefsByName, name)
 	}
 
+	rlsDeps := md.rlsDeps
+	for i := range rlsDeps {
+		rlsDeps[i] = rlsDep{}
+	}
+
 	// This initialization pattern ensures that fields are not unwittingly
 	// reused. Field reuse must be explicit.
 	*md = Metadata{}
@@ -232,6 +248,7 @@ func (md *Metadata) Init() {
 	md.objectRefsByName = objectRefsByName
 	md.privileges = privileges
 	md.builtinRefsByName = builtinRefsByName
+	md.rlsDeps = rlsDeps[:0]
 }
 
 // CopyFrom initializes the metadata with a copy of the provided metadata.
@@ -247,7 +264,7 @@ func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 		len(md.sequences) != 0 || len(md.views) != 0 || len(md.userDefinedTypes) != 0 ||
 		len(md.userDefinedTypesSlice) != 0 || len(md.dataSourceDeps) != 0 ||
 		len(md.udfDeps) != 0 || len(md.objectRefsByName) != 0 || len(md.privileges) != 0 ||
-		len(md.builtinRefsByName) != 0 {
+		len(md.builtinRefsByName) != 0 || len(md.rlsDeps) != 0 {
 		panic(errors.AssertionFailedf("CopyFrom requires empty destination"))
 	}
 	md.schemas = append(md.schemas, from.schemas...)
@@ -320,9 +337,11 @@ func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 		md.builtinRefsByName[name] = struct{}{}
 	}
 
+	md.rlsDeps = append(md.rlsDeps, from.rlsDeps...)
 	md.sequences = append(md.sequences, from.sequences...)
 	md.views = append(md.views, from.views...)
 	md.currUniqueID = from.currUniqueID
+	md.resultCacheHint = from.resultCacheHint
 
 	// We cannot copy the bound expressions; they must be rebuilt in the new memo.
 	md.withBindings = nil
@@ -512,6 +531,19 @@ func (md *Metadata) CheckDependencies(
 		}
 	}
 
+	// Check that the same row-level security policies still apply to the
+	// current user.
+	for i := range md.rlsDeps {
+		dep := &md.rlsDeps[i]
+		ords, enforced, err := cat.ApplicablePolicies(ctx, optCatalog, dep.tab, dep.cmd)
+		if err != nil {
+			return false, err
+		}
+		if !dep.matches(ords, enforced) {
+			return false, nil
+		}
+	}
+
 	return true, nil
 }
 
@@ -630,6 +662,45 @@ func (md *Metadata) AddBuiltin(name *tree.UnresolvedObjectName) {
 	md.builtinRefsByName[*name.ToUnresolvedName()] = struct{}{}
 }
 
+// rlsDep records the row-level security policies that were applied to
+// statements of the given kind on the given table when the query was built.
+type rlsDep struct {
+	tab      cat.Table
+	cmd      tree.PolicyCommand
+	ords     []int
+	enforced bool
+}
+
+// matches returns true if the dependency records the given result of
+// cat.ApplicablePolicies.
+func (dep *rlsDep) matches(ords []int, enforced bool) bool {
+	if dep.enforced != enforced || len(dep.ords) != len(ords) {
+		return false
+	}
+	for i := range ords {
+		if dep.ords[i] != ords[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// AddRowLevelSecurityDependency tracks the row-level security policies (see
+// cat.ApplicablePolicies) that were applied to statements of the given kind on
+// the given table. If the Memo using this metadata is cached, then a call to
+// CheckDependencies can detect if different policies apply now, for example
+// because the current user or its role memberships have changed.
+func (md *Metadata) AddRowLevelSecurityDependency(
+	tab cat.Table, cmd tree.PolicyCommand, ords []int, enforced bool,
+) {
+	for i := range md.rlsDeps {
+		if dep := &md.rlsDeps[i]; dep.tab == tab && dep.cmd == cmd {
+			return
+		}
+	}
+	md.rlsDeps = append(md.rlsDeps, rlsDep{tab: tab, cmd: cmd, ords: ords, enforced: enforced})
+}
+
 // AddTable indexes a new reference to a table within the query. Separate
 // references to the same table are assigned different table ids (e.g.  in a
 // self-join query). All columns are added to the metadata. If mutation columns
@@ -968,6 +1039,16 @@ func (md *Metadata) AddView(v cat.View) {
 	md.views = append(md.views, v)
 }
 
+// SetResultCacheHint records that the query contains a RESULT_CACHE hint.
+func (md *Metadata) SetResultCacheHint() {
+	md.resultCacheHint = true
+}
+
+// HasResultCacheHint returns true if the query contains a RESULT_CACHE hint.
+func (md *Metadata) HasResultCacheHint() bool {
+	return md.resultCacheHint
+}
+
 // AllViews returns the metadata for all views. The result must not be
 // modified.
 func (md *Metadata) AllViews() []cat.View {
@@ -1197,3 +1278,9 @@ func (md *Metadata) TestingObjectRefsByName() map[cat.StableID][]*tree.Unresolve
 func (md *Metadata) TestingPrivileges() map[cat.StableID]privilegeBitmap {
 	return md.privileges
 }
+
+// TestingRowLevelSecurityDepCount returns the number of row-level security
+// dependencies for testing.
+func (md *Metadata) TestingRowLevelSecurityDepCount() int {
+	return len(md.rlsDeps)
+}
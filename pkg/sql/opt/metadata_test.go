@@ -114,6 +114,8 @@ func TestMetadata(t *testing.T) {
 		types.OneIntCol,
 		udfName.ToUnresolvedObjectName(),
 	)
+	md.AddRowLevelSecurityDependency(tab, tree.PolicyCommandSelect, []int{0}, true /* enforced */)
+	md.SetResultCacheHint()
 
 	// Call CopyFrom and verify that same objects are present in new metadata.
 	expr := &memo.ProjectExpr{}
@@ -201,6 +203,14 @@ func TestMetadata(t *testing.T) {
 		}
 	}
 
+	if mdNew.TestingRowLevelSecurityDepCount() != 1 {
+		t.Fatalf("expected row-level security dependency to be copied")
+	}
+
+	if !mdNew.HasResultCacheHint() {
+		t.Fatalf("expected result cache hint to be copied")
+	}
+
 	depsUpToDate, err = md.CheckDependencies(context.Background(), &evalCtx, testCat)
 	if err == nil || depsUpToDate {
 		t.Fatalf("expected table privilege to be revoked in metadata copy")
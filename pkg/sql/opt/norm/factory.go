@@ -400,6 +400,31 @@ func (f *Factory) AssignPlaceholders(from *memo.Memo) (err error) {
 	return nil
 }
 
+// CopyMemoWithoutAssigningPlaceholders makes a copy of the given memo, leaving
+// any placeholders unassigned. It is used to build a generic query plan for a
+// prepared statement, which is optimized once and then reused for any
+// placeholder values. The given memo is not modified.
+func (f *Factory) CopyMemoWithoutAssigningPlaceholders(from *memo.Memo) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// This code allows us to propagate errors without adding lots of checks
+			// for `if err != nil` throughout the construction code. This is only
+			// possible because the code does not update shared state and does not
+			// manipulate locks.
+			if ok, e := errorutil.ShouldCatch(r); ok {
+				err = e
+			} else {
+				panic(r)
+			}
+		}
+	}()
+
+	f.CopyAndReplace(
+		from.RootExpr().(memo.RelExpr), from.RootProps(), f.CopyWithoutAssigningPlaceholders,
+	)
+	return nil
+}
+
 // CheckConstructorStackDepth panics in test builds if the constructor stack
 // depth is not zero. The stack depth should be 0 after a top-level constructor
 // function returns. It is used to verify that the stack depth is correctly
@@ -624,6 +624,17 @@ func (c *CustomFuncs) DerivePruneCols(e memo.RelExpr, disabledRules intsets.Fast
 		ordering := e.Private().(*props.OrderingChoice).ColSet()
 		relProps.Rule.PruneCols = inputPruneCols.Difference(ordering)
 
+	case opt.BarrierOp:
+		if disabledRules.Contains(int(opt.PruneBarrierCols)) {
+			// Avoid rule cycles.
+			break
+		}
+		// Only barriers that let leakproof filters through allow columns to be
+		// pruned below them.
+		if e.(*memo.BarrierExpr).LeakproofPermeable {
+			relProps.Rule.PruneCols = c.DerivePruneCols(e.Child(0).(memo.RelExpr), disabledRules).Copy()
+		}
+
 	case opt.OrdinalityOp:
 		if disabledRules.Contains(int(opt.PruneOrdinalityCols)) {
 			// Avoid rule cycles.
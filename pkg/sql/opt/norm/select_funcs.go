@@ -424,3 +424,51 @@ func (c *CustomFuncs) addConjuncts(
 func (c *CustomFuncs) ForDuplicateRemoval(private *memo.OrdinalityPrivate) (ok bool) {
 	return private.ForDuplicateRemoval
 }
+
+// IsLeakproofPermeable returns true if leakproof filters may be pushed through
+// a Barrier with the given LeakproofPermeable field.
+func (c *CustomFuncs) IsLeakproofPermeable(leakproofPermeable bool) bool {
+	return leakproofPermeable
+}
+
+// IsLeakproof returns true if the filter cannot reveal anything about the rows
+// it is evaluated on other than through its result. See
+// volatility.Leakproof.
+func (c *CustomFuncs) IsLeakproof(item *memo.FiltersItem) bool {
+	return item.ScalarProps().VolatilitySet.IsLeakproof()
+}
+
+// FiltersAreLeakproof returns true if all the given filters are leakproof. See
+// IsLeakproof.
+func (c *CustomFuncs) FiltersAreLeakproof(filters memo.FiltersExpr) bool {
+	for i := range filters {
+		if !c.IsLeakproof(&filters[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ExtractLeakproofFilters returns a new list containing only the leakproof
+// filters from the given list.
+func (c *CustomFuncs) ExtractLeakproofFilters(filters memo.FiltersExpr) memo.FiltersExpr {
+	newFilters := make(memo.FiltersExpr, 0, len(filters))
+	for i := range filters {
+		if c.IsLeakproof(&filters[i]) {
+			newFilters = append(newFilters, filters[i])
+		}
+	}
+	return newFilters
+}
+
+// ExtractNonLeakproofFilters is the opposite of ExtractLeakproofFilters. It
+// returns a new list containing only the filters that are not leakproof.
+func (c *CustomFuncs) ExtractNonLeakproofFilters(filters memo.FiltersExpr) memo.FiltersExpr {
+	newFilters := make(memo.FiltersExpr, 0, len(filters))
+	for i := range filters {
+		if !c.IsLeakproof(&filters[i]) {
+			newFilters = append(newFilters, filters[i])
+		}
+	}
+	return newFilters
+}
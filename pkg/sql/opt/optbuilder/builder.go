@@ -77,6 +77,13 @@ type Builder struct {
 	// a statement during session migration.
 	SkipAOST bool
 
+	// PinnedIndexFlags is a control knob: if set, it maps the StableID of a
+	// table to the index flags used when scanning that table without index
+	// flags of its own. This is used to reproduce the access paths of a plan
+	// baseline. The join order and the join algorithms of a baseline are
+	// pinned separately while optimizing (see xform.NewPinnedJoinCoster).
+	PinnedIndexFlags map[cat.StableID]*tree.IndexFlags
+
 	// -- Results --
 	//
 	// These fields are set during the building process and can be used after
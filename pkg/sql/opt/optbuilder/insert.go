@@ -376,6 +376,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 //     values specified for them.
 //  4. Each update value is the same as the corresponding insert value.
 //  5. There are no inbound foreign keys containing non-key columns.
+//  6. Row-level security does not restrict the current user on the table.
 //
 // TODO(andyk): The fast path is currently only enabled when the UPSERT alias
 // is explicitly selected by the user. It's possible to fast path some queries
@@ -383,6 +384,12 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 // of edge cases (that caused real correctness bugs #13437 #13962). As a result,
 // this support was removed and needs to re-enabled. See #14482.
 func (mb *mutationBuilder) needExistingRows() bool {
+	// Row-level security policies are checked differently for inserted and
+	// updated rows (see addRowLevelSecurityChecksForUpsert).
+	if _, enforced := mb.b.applicablePolicies(mb.tab, tree.PolicyCommandUpdate); enforced {
+		return true
+	}
+
 	if mb.tab.DeletableIndexCount() > 1 {
 		return true
 	}
@@ -694,6 +701,9 @@ func (mb *mutationBuilder) buildInsert(returning *tree.ReturningExprs) {
 	// check constraint, refer to the correct columns.
 	mb.disambiguateColumns()
 
+	// Reject rows that violate the row-level security policies of the table.
+	mb.addRowLevelSecurityChecks(tree.PolicyCommandInsert)
+
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(false /* isUpdate */)
 
@@ -910,6 +920,9 @@ func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
 	// check constraint, refer to the correct columns.
 	mb.disambiguateColumns()
 
+	// Reject rows that violate the row-level security policies of the table.
+	mb.addRowLevelSecurityChecksForUpsert()
+
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(false /* isUpdate */)
 
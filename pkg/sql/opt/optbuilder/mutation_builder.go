@@ -293,6 +293,8 @@ func (mb *mutationBuilder) buildInputForUpdate(
 		indexFlags = source.IndexFlags
 		telemetry.Inc(sqltelemetry.IndexHintUseCounter)
 		telemetry.Inc(sqltelemetry.IndexHintUpdateUseCounter)
+	} else {
+		indexFlags = mb.b.PinnedIndexFlags[mb.tab.ID()]
 	}
 
 	// Fetch columns from different instance of the table metadata, so that it's
@@ -314,6 +316,7 @@ func (mb *mutationBuilder) buildInputForUpdate(
 		inScope,
 		false, /* disableNotVisibleIndex */
 	)
+	mb.b.addRowLevelSecurityFilter(mb.tab, mb.fetchScope, tree.PolicyCommandUpdate)
 
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
@@ -403,6 +406,8 @@ func (mb *mutationBuilder) buildInputForDelete(
 		indexFlags = source.IndexFlags
 		telemetry.Inc(sqltelemetry.IndexHintUseCounter)
 		telemetry.Inc(sqltelemetry.IndexHintDeleteUseCounter)
+	} else {
+		indexFlags = mb.b.PinnedIndexFlags[mb.tab.ID()]
 	}
 
 	// Fetch columns from different instance of the table metadata, so that it's
@@ -425,6 +430,7 @@ func (mb *mutationBuilder) buildInputForDelete(
 		inScope,
 		false, /* disableNotVisibleIndex */
 	)
+	mb.b.addRowLevelSecurityFilter(mb.tab, mb.fetchScope, tree.PolicyCommandDelete)
 
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
@@ -1816,7 +1816,7 @@ func (b *plpgsqlBuilder) addBarrierIfVolatile(s *scope, expr opt.ScalarExpr) {
 // addBarrier adds an optimization barrier to the given scope, in order to
 // prevent side effects from being duplicated, eliminated, or reordered.
 func (b *plpgsqlBuilder) addBarrier(s *scope) {
-	s.expr = b.ob.factory.ConstructBarrier(s.expr)
+	s.expr = b.ob.factory.ConstructBarrier(s.expr, false /* leakproofPermeable */)
 }
 
 // buildPLpgSQLExpr parses and builds the given SQL expression into a ScalarExpr
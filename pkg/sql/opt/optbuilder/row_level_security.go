@@ -0,0 +1,229 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// applicablePolicies returns the row-level security policies of the given
+// table that apply to statements of the given kind run by the current user.
+// enforced is false if row-level security does not restrict the current user
+// on the table (see cat.ApplicablePolicies). The result is tracked in the
+// metadata, so that a cached memo is invalidated if different policies apply
+// when it is reused.
+func (b *Builder) applicablePolicies(
+	tab cat.Table, cmd tree.PolicyCommand,
+) (policies []*cat.Policy, enforced bool) {
+	if !tab.IsRowLevelSecurityEnabled() {
+		return nil, false
+	}
+	ords, enforced, err := cat.ApplicablePolicies(b.ctx, b.catalog, tab, cmd)
+	if err != nil {
+		panic(err)
+	}
+	b.factory.Metadata().AddRowLevelSecurityDependency(tab, cmd, ords, enforced)
+	if !enforced {
+		return nil, false
+	}
+	policies = make([]*cat.Policy, len(ords))
+	for i, ord := range ords {
+		policies[i] = tab.Policy(ord)
+	}
+	return policies, true
+}
+
+// buildPolicyExpr combines the expressions of the given row-level security
+// policies into a boolean expression that is true for the rows that pass the
+// policies: a row must pass at least one of the permissive policies and all of
+// the restrictive policies. exprFn returns the SQL text of the expression to
+// use for a policy, or the empty string if the policy has none, in which case
+// the policy is ignored. As in Postgres, if there is no permissive expression,
+// no rows pass the policies.
+func buildPolicyExpr(policies []*cat.Policy, exprFn func(*cat.Policy) string) tree.Expr {
+	var permissive, restrictive tree.Expr
+	for _, policy := range policies {
+		sql := exprFn(policy)
+		if sql == "" {
+			continue
+		}
+		expr, err := parser.ParseExpr(sql)
+		if err != nil {
+			panic(err)
+		}
+		expr = &tree.ParenExpr{Expr: expr}
+		if policy.Restrictive {
+			if restrictive == nil {
+				restrictive = expr
+			} else {
+				restrictive = &tree.AndExpr{Left: restrictive, Right: expr}
+			}
+		} else {
+			if permissive == nil {
+				permissive = expr
+			} else {
+				permissive = &tree.OrExpr{Left: permissive, Right: expr}
+			}
+		}
+	}
+	if permissive == nil {
+		return tree.DBoolFalse
+	}
+	if restrictive == nil {
+		return permissive
+	}
+	return &tree.AndExpr{Left: &tree.ParenExpr{Expr: permissive}, Right: restrictive}
+}
+
+// addRowLevelSecurityFilter filters out the rows of the given scan of a table
+// that are not visible to statements of the given kind run by the current
+// user, according to the USING expressions of the table's row-level security
+// policies. It is a no-op if row-level security does not restrict the current
+// user on the table.
+//
+// The filter is wrapped in a Barrier, so that the optimizer does not evaluate
+// other expressions of the query on the rows that the policies hide, which
+// could reveal them (e.g. by raising a division by zero error). Only leakproof
+// filters are pushed through the barrier, which still allows them to constrain
+// the scan.
+func (b *Builder) addRowLevelSecurityFilter(
+	tab cat.Table, scanScope *scope, cmd tree.PolicyCommand,
+) {
+	policies, enforced := b.applicablePolicies(tab, cmd)
+	if !enforced {
+		return
+	}
+	// The columns referenced by the policies are not dependencies of a view
+	// that scans the table, since the policies are applied when the view is
+	// queried rather than when it is created.
+	if b.trackSchemaDeps {
+		b.trackSchemaDeps = false
+		defer func() {
+			b.trackSchemaDeps = true
+		}()
+	}
+	expr := buildPolicyExpr(policies, func(policy *cat.Policy) string {
+		return policy.UsingExpr
+	})
+	texpr := scanScope.resolveAndRequireType(expr, types.Bool)
+	filter := b.buildScalar(texpr, scanScope, nil, nil, nil)
+	scanScope.expr = b.factory.ConstructBarrier(
+		b.factory.ConstructSelect(
+			scanScope.expr,
+			memo.FiltersExpr{b.factory.ConstructFiltersItem(filter)},
+		),
+		true, /* leakproofPermeable */
+	)
+}
+
+// policyUsingExpr returns the USING expression of a policy.
+func policyUsingExpr(policy *cat.Policy) string {
+	return policy.UsingExpr
+}
+
+// policyWithCheckExpr returns the expression that new rows must pass for a
+// policy. As in Postgres, the USING expression of a policy is used if it has no
+// WITH CHECK expression.
+func policyWithCheckExpr(policy *cat.Policy) string {
+	if policy.WithCheckExpr != "" {
+		return policy.WithCheckExpr
+	}
+	return policy.UsingExpr
+}
+
+// buildPolicyCheck builds the expression combined from the given policies by
+// buildPolicyExpr, resolving column references in the given scope.
+func (mb *mutationBuilder) buildPolicyCheck(
+	policies []*cat.Policy, exprFn func(*cat.Policy) string, s *scope,
+) opt.ScalarExpr {
+	texpr := s.resolveAndRequireType(buildPolicyExpr(policies, exprFn), types.Bool)
+	return mb.b.buildScalar(texpr, s, nil, nil, nil)
+}
+
+// addRowLevelSecurityChecks adds a filter to the input of the mutation that
+// raises an error for each new row that does not pass the WITH CHECK
+// expressions of the row-level security policies that apply to statements of
+// the given kind. It is a no-op if row-level security does not restrict the
+// current user on the table.
+func (mb *mutationBuilder) addRowLevelSecurityChecks(cmd tree.PolicyCommand) {
+	policies, enforced := mb.b.applicablePolicies(mb.tab, cmd)
+	if !enforced {
+		return
+	}
+	mb.addRowLevelSecurityCheckFilter(mb.buildPolicyCheck(policies, policyWithCheckExpr, mb.outScope))
+}
+
+// addRowLevelSecurityChecksForUpsert is similar to addRowLevelSecurityChecks,
+// but for the input of an UPSERT or INSERT ... ON CONFLICT DO UPDATE statement.
+// A row that is inserted must pass the WITH CHECK expressions of the INSERT
+// policies. A row that updates an existing row must pass the WITH CHECK
+// expressions of the UPDATE policies, and as in Postgres, the existing row must
+// pass their USING expressions, otherwise an error is raised rather than
+// silently skipping the row. It must be called after the upsert columns are
+// projected and disambiguated, and relies on needExistingRows to fetch the
+// existing rows when row-level security is enforced.
+func (mb *mutationBuilder) addRowLevelSecurityChecksForUpsert() {
+	insertPolicies, enforced := mb.b.applicablePolicies(mb.tab, tree.PolicyCommandInsert)
+	updatePolicies, _ := mb.b.applicablePolicies(mb.tab, tree.PolicyCommandUpdate)
+	if !enforced {
+		return
+	}
+	f := mb.b.factory
+	insertCheck := mb.buildPolicyCheck(insertPolicies, policyWithCheckExpr, mb.outScope)
+	updateCheck := f.ConstructAnd(
+		mb.buildPolicyCheck(updatePolicies, policyUsingExpr, mb.fetchScope),
+		mb.buildPolicyCheck(updatePolicies, policyWithCheckExpr, mb.outScope),
+	)
+	// The canary column is null if the row does not conflict with an existing
+	// row, in which case it is inserted.
+	isInsert := f.ConstructIs(f.ConstructVariable(mb.canaryColID), memo.NullSingleton)
+	mb.addRowLevelSecurityCheckFilter(f.ConstructCase(
+		memo.TrueSingleton,
+		memo.ScalarListExpr{f.ConstructWhen(isInsert, insertCheck)},
+		updateCheck,
+	))
+}
+
+// addRowLevelSecurityCheckFilter adds a filter to the input of the mutation
+// that raises an error for each row for which the given check does not
+// evaluate to true. Unlike for check constraints, a NULL result rejects the
+// row.
+func (mb *mutationBuilder) addRowLevelSecurityCheckFilter(check opt.ScalarExpr) {
+	// The error is raised by evaluating crdb_internal.force_error in the ELSE
+	// branch, which is only reached if the check does not evaluate to true.
+	msg := fmt.Sprintf("new row violates row-level security policy for table %q", string(mb.tab.Name()))
+	raise := &tree.IsNullExpr{Expr: &tree.FuncExpr{
+		Func: tree.WrapFunction("crdb_internal.force_error"),
+		Exprs: tree.Exprs{
+			tree.NewStrVal(pgcode.InsufficientPrivilege.String()),
+			tree.NewStrVal(msg),
+		},
+	}}
+	texpr := mb.outScope.resolveAndRequireType(raise, types.Bool)
+	f := mb.b.factory
+	filter := f.ConstructCase(
+		memo.TrueSingleton,
+		memo.ScalarListExpr{f.ConstructWhen(check, memo.TrueSingleton)},
+		mb.b.buildScalar(texpr, mb.outScope, nil, nil, nil),
+	)
+	mb.outScope.expr = f.ConstructSelect(
+		mb.outScope.expr,
+		memo.FiltersExpr{f.ConstructFiltersItem(filter)},
+	)
+}
@@ -159,6 +159,9 @@ func (b *Builder) buildDataSource(
 
 		switch t := ds.(type) {
 		case cat.Table:
+			if indexFlags == nil {
+				indexFlags = b.PinnedIndexFlags[t.ID()]
+			}
 			tabMeta := b.addTable(t, &resName)
 			locking := lockCtx.locking
 			if locking.isSet() {
@@ -170,7 +173,7 @@ func (b *Builder) buildDataSource(
 			if b.shouldBuildLockOp() {
 				locking = nil
 			}
-			return b.buildScan(
+			outScope = b.buildScan(
 				tabMeta,
 				tableOrdinals(t, columnKinds{
 					includeMutations: false,
@@ -180,6 +183,8 @@ func (b *Builder) buildDataSource(
 				indexFlags, locking, inScope,
 				false, /* disableNotVisibleIndex */
 			)
+			b.addRowLevelSecurityFilter(t, outScope, tree.PolicyCommandSelect)
+			return outScope
 
 		case cat.Sequence:
 			return b.buildSequenceSelect(t, &resName, inScope)
@@ -506,9 +511,11 @@ func (b *Builder) buildScanFromTableRef(
 	if b.shouldBuildLockOp() {
 		locking = nil
 	}
-	return b.buildScan(
+	outScope = b.buildScan(
 		tabMeta, ordinals, indexFlags, locking, inScope, false, /* disableNotVisibleIndex */
 	)
+	b.addRowLevelSecurityFilter(tab, outScope, tree.PolicyCommandSelect)
+	return outScope
 }
 
 // addTable adds a table to the metadata and returns the TableMeta. The table
@@ -585,6 +592,9 @@ func (b *Builder) buildScan(
 		if indexFlags.IgnoreUniqueWithoutIndexKeys {
 			tabMeta.IgnoreUniqueWithoutIndexKeys = true
 		}
+		if indexFlags.ResultCache {
+			b.factory.Metadata().SetResultCacheHint()
+		}
 	}
 
 	outScope = inScope.push()
@@ -337,6 +337,9 @@ func (mb *mutationBuilder) buildUpdate(returning *tree.ReturningExprs) {
 	// check constraint, refer to the correct columns.
 	mb.disambiguateColumns()
 
+	// Reject rows that violate the row-level security policies of the table.
+	mb.addRowLevelSecurityChecks(tree.PolicyCommandUpdate)
+
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(true /* isUpdate */)
 
@@ -0,0 +1,38 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package ordering
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+)
+
+func barrierCanProvideOrdering(expr memo.RelExpr, required *props.OrderingChoice) bool {
+	// A barrier that lets leakproof filters through can also pass through an
+	// ordering to its input: sorting the rows does not evaluate any expression
+	// on them. Other barriers must be kept opaque.
+	return expr.(*memo.BarrierExpr).LeakproofPermeable
+}
+
+func barrierBuildChildReqOrdering(
+	parent memo.RelExpr, required *props.OrderingChoice, childIdx int,
+) props.OrderingChoice {
+	if !parent.(*memo.BarrierExpr).LeakproofPermeable {
+		return props.OrderingChoice{}
+	}
+	return *required
+}
+
+func barrierBuildProvided(expr memo.RelExpr, required *props.OrderingChoice) opt.Ordering {
+	b := expr.(*memo.BarrierExpr)
+	return b.Input.ProvidedPhysical().Ordering
+}
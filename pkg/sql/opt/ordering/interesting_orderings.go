@@ -72,6 +72,13 @@ func DeriveInterestingOrderings(e memo.RelExpr) props.OrderingSet {
 	case opt.ProjectOp:
 		res = interestingOrderingsForProject(e.(*memo.ProjectExpr))
 
+	case opt.BarrierOp:
+		if e.(*memo.BarrierExpr).LeakproofPermeable {
+			res = interestingOrderingsForExpr(e)
+		} else {
+			res = props.OrderingSet{}
+		}
+
 	case opt.GroupByOp, opt.ScalarGroupByOp:
 		res = interestingOrderingsForGroupBy(e)
 
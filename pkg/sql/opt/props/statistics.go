@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/olekukonko/tablewriter"
 )
@@ -89,6 +90,30 @@ type Statistics struct {
 	// size of the column with ordinal i in its table. AvgSize is only non-nil
 	// when the statistics are built from a table.
 	AvgColSizes []uint64
+
+	// ExtendedStats contains the functional dependencies and most common
+	// values of the multi-column statistics on a table. Like AvgColSizes, it is
+	// only non-nil when the statistics are built from a table.
+	ExtendedStats []ExtendedStatistic
+}
+
+// ExtendedStatistic contains the extended statistics of a multi-column table
+// statistic, which describe correlations between its columns. The column
+// indexes in Dependencies and the values in MostCommonValues are in the order
+// of Cols.
+type ExtendedStatistic struct {
+	Cols             []opt.ColumnID
+	Dependencies     []cat.StatisticDependency
+	MostCommonValues []cat.StatisticMCV
+}
+
+// ColSet returns the set of columns of the extended statistic.
+func (e *ExtendedStatistic) ColSet() opt.ColSet {
+	var cols opt.ColSet
+	for _, col := range e.Cols {
+		cols.Add(col)
+	}
+	return cols
 }
 
 // Init initializes the data members of Statistics.
@@ -314,6 +314,7 @@ func New(catalog cat.Catalog, sql string) *OptTester {
 	ot.evalCtx.SessionData().OptimizerUseProvidedOrderingFix = true
 	ot.evalCtx.SessionData().OptimizerMergeJoinsEnabled = true
 	ot.evalCtx.SessionData().OptimizerUseVirtualComputedColumnStats = true
+	ot.evalCtx.SessionData().OptimizerUseExtendedStats = true
 
 	return ot
 }
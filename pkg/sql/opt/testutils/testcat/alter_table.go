@@ -28,6 +28,7 @@ import (
 // Supported commands:
 //   - INJECT STATISTICS: imports table statistics from a JSON object.
 //   - ADD CONSTRAINT FOREIGN KEY: add a foreign key reference.
+//   - ENABLE, DISABLE, FORCE and NO FORCE ROW LEVEL SECURITY.
 func (tc *Catalog) AlterTable(stmt *tree.AlterTable) {
 	tn := stmt.Table.ToTableName()
 	// Update the table name to include catalog and schema if not provided.
@@ -48,6 +49,18 @@ func (tc *Catalog) AlterTable(stmt *tree.AlterTable) {
 				panic(errors.AssertionFailedf("unsupported constraint type %v", d))
 			}
 
+		case *tree.AlterTableSetRowLevelSecurity:
+			switch t.Mode {
+			case tree.RowLevelSecurityEnable:
+				tab.rlsEnabled = true
+			case tree.RowLevelSecurityDisable:
+				tab.rlsEnabled = false
+			case tree.RowLevelSecurityForce:
+				tab.rlsForced = true
+			case tree.RowLevelSecurityNoForce:
+				tab.rlsForced = false
+			}
+
 		default:
 			panic(errors.AssertionFailedf("unsupported ALTER TABLE command %T", t))
 		}
@@ -0,0 +1,51 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package testcat
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// CreatePolicy adds a row-level security policy to a test table from a parsed
+// DDL statement. The policy only restricts queries once row-level security is
+// enabled and forced on the table with ALTER TABLE, since the test user owns
+// all tables.
+func (tc *Catalog) CreatePolicy(stmt *tree.CreatePolicy) {
+	tn := stmt.TableName.ToTableName()
+	// Update the table name to include catalog and schema if not provided.
+	tc.qualifyTableName(&tn)
+	tab := tc.Table(&tn)
+
+	policy := cat.Policy{
+		Name:        string(stmt.PolicyName),
+		Restrictive: stmt.Type == tree.PolicyTypeRestrictive,
+		Command:     stmt.Cmd,
+		Roles:       []username.SQLUsername{username.PublicRoleName()},
+	}
+	if policy.Command == tree.PolicyCommandDefault {
+		policy.Command = tree.PolicyCommandAll
+	}
+	if len(stmt.Roles) > 0 {
+		policy.Roles = make([]username.SQLUsername, len(stmt.Roles))
+		for i := range stmt.Roles {
+			policy.Roles[i] = username.MakeSQLUsernameFromPreNormalizedString(stmt.Roles[i].Name)
+		}
+	}
+	if stmt.Using != nil {
+		policy.UsingExpr = tree.Serialize(stmt.Using)
+	}
+	if stmt.WithCheck != nil {
+		policy.WithCheckExpr = tree.Serialize(stmt.WithCheck)
+	}
+	tab.policies = append(tab.policies, policy)
+}
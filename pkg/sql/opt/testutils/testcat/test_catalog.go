@@ -305,13 +305,25 @@ func (tc *Catalog) CheckExecutionPrivilege(ctx context.Context, oid oid.Oid) err
 	return nil
 }
 
-// HasAdminRole is part of the cat.Catalog interface.
+// HasAdminRole is part of the cat.Catalog interface. The test user is not an
+// admin, so that row-level security policies apply to it.
 func (tc *Catalog) HasAdminRole(ctx context.Context) (bool, error) {
-	return true, nil
+	return false, nil
 }
 
-// HasRoleOption is part of the cat.Catalog interface.
+// HasRoleOption is part of the cat.Catalog interface. The test user does not
+// have any role options, so that row-level security policies apply to it.
 func (tc *Catalog) HasRoleOption(ctx context.Context, roleOption roleoption.Option) (bool, error) {
+	return false, nil
+}
+
+// HasOwnership is part of the cat.Catalog interface.
+func (tc *Catalog) HasOwnership(ctx context.Context, o cat.Object) (bool, error) {
+	return true, nil
+}
+
+// IsMemberOfRole is part of the cat.Catalog interface.
+func (tc *Catalog) IsMemberOfRole(ctx context.Context, role username.SQLUsername) (bool, error) {
 	return true, nil
 }
 
@@ -510,6 +522,10 @@ func (tc *Catalog) executeDDLStmtWithIndexVersion(
 		tc.CreateView(stmt)
 		return "", nil
 
+	case *tree.CreatePolicy:
+		tc.CreatePolicy(stmt)
+		return "", nil
+
 	case *tree.AlterTable:
 		tc.AlterTable(stmt)
 		return "", nil
@@ -759,6 +775,13 @@ type Table struct {
 	implicitRBRIndexElem *tree.IndexElem
 
 	homeRegion string
+
+	// rlsEnabled and rlsForced are set by ALTER TABLE ... ROW LEVEL SECURITY.
+	rlsEnabled bool
+	rlsForced  bool
+
+	// policies are the row-level security policies added by CREATE POLICY.
+	policies []cat.Policy
 }
 
 var _ cat.Table = &Table{}
@@ -962,6 +985,26 @@ func (tt *Table) IsHypothetical() bool {
 	return false
 }
 
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (tt *Table) IsRowLevelSecurityEnabled() bool {
+	return tt.rlsEnabled
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (tt *Table) IsRowLevelSecurityForced() bool {
+	return tt.rlsForced
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (tt *Table) PolicyCount() int {
+	return len(tt.policies)
+}
+
+// Policy is part of the cat.Table interface.
+func (tt *Table) Policy(i int) *cat.Policy {
+	return &tt.policies[i]
+}
+
 // FindOrdinal returns the ordinal of the column with the given name.
 func (tt *Table) FindOrdinal(name string) int {
 	for i, col := range tt.Columns {
@@ -1282,6 +1325,7 @@ type TableStat struct {
 	evalCtx       *eval.Context
 	histogram     []cat.HistogramBucket
 	histogramType *types.T
+	mcvs          []cat.StatisticMCV
 	tc            *Catalog
 }
 
@@ -1396,6 +1440,63 @@ func (ts *TableStat) HistogramType() *types.T {
 	return ts.histogramType
 }
 
+// Dependencies is part of the cat.TableStatistic interface.
+func (ts *TableStat) Dependencies() []cat.StatisticDependency {
+	if len(ts.js.Dependencies) == 0 {
+		return nil
+	}
+	colIdx := func(name string) int {
+		for i := range ts.js.Columns {
+			if ts.js.Columns[i] == name {
+				return i
+			}
+		}
+		panic(errors.Newf("dependency column %q is not in the statistic", name))
+	}
+	deps := make([]cat.StatisticDependency, len(ts.js.Dependencies))
+	for i := range ts.js.Dependencies {
+		dep := &ts.js.Dependencies[i]
+		deps[i] = cat.StatisticDependency{
+			From:   colIdx(dep.From),
+			To:     colIdx(dep.To),
+			Degree: dep.Degree,
+		}
+	}
+	return deps
+}
+
+// MostCommonValues is part of the cat.TableStatistic interface. The values are
+// parsed using the types of the table columns.
+func (ts *TableStat) MostCommonValues() []cat.StatisticMCV {
+	if ts.mcvs != nil || len(ts.js.MostCommonValues) == 0 {
+		return ts.mcvs
+	}
+	evalCtx := ts.evalCtx
+	if evalCtx == nil {
+		evalCtxVal := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+		evalCtx = &evalCtxVal
+	}
+	ts.mcvs = make([]cat.StatisticMCV, len(ts.js.MostCommonValues))
+	for i := range ts.js.MostCommonValues {
+		item := &ts.js.MostCommonValues[i]
+		if len(item.Values) != len(ts.js.Columns) {
+			panic(errors.Newf("most common value has %d values, expected %d",
+				len(item.Values), len(ts.js.Columns)))
+		}
+		values := make(tree.Datums, len(item.Values))
+		for j, s := range item.Values {
+			colType := ts.tt.Column(ts.ColumnOrdinal(j)).DatumType()
+			datum, err := rowenc.ParseDatumStringAs(context.Background(), colType, s, evalCtx, nil /* semaCtx */)
+			if err != nil {
+				panic(err)
+			}
+			values[j] = datum
+		}
+		ts.mcvs[i] = cat.StatisticMCV{Values: values, Frequency: item.Frequency}
+	}
+	return ts.mcvs
+}
+
 // IsPartial is part of the cat.TableStatistic interface.
 func (ts *TableStat) IsPartial() bool {
 	return ts.js.IsPartial()
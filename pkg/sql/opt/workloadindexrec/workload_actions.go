@@ -0,0 +1,673 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package workloadindexrec
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/errors"
+)
+
+// ActionType is the type of an index action recommended for the workload.
+type ActionType int
+
+const (
+	// ActionCreate recommends creating a new index.
+	ActionCreate ActionType = iota
+	// ActionReplace recommends creating a new index and dropping the existing
+	// indexes that it makes redundant.
+	ActionReplace
+	// ActionDrop recommends dropping an existing index that is either unused by
+	// the workload or redundant with another index.
+	ActionDrop
+)
+
+// String implements the fmt.Stringer interface.
+func (t ActionType) String() string {
+	switch t {
+	case ActionCreate:
+		return "CREATE"
+	case ActionReplace:
+		return "REPLACE"
+	case ActionDrop:
+		return "DROP"
+	}
+	return "UNKNOWN"
+}
+
+// Action is an index action recommended for the whole workload.
+type Action struct {
+	Type ActionType
+	// SQL is the statement(s) to run that will apply the action.
+	SQL string
+	// Reason briefly explains why the action is recommended.
+	Reason string
+	// Benefit is the estimated net benefit of the action, in seconds of
+	// statement execution time saved over the workload. See
+	// FindWorkloadActions for how it is computed.
+	Benefit float64
+}
+
+// FindWorkloadActions finds the CREATE, REPLACE and DROP index actions for the
+// workload of the current database that was executed after the timestamp ts,
+// ranked by their estimated net benefit.
+//
+// The CREATE and REPLACE actions are derived from the index recommendations of
+// individual statements (which were found by costing the statements with
+// hypothetical indexes) in the same way as FindWorkloadRecs does. The DROP
+// actions target the secondary non-unique indexes that weren't read by the
+// workload, as well as those whose every use can be served by another index.
+// Indexes that back a foreign key constraint are never dropped.
+//
+// The benefit of an action is estimated as follows:
+//   - the execution time of every statement is scaled by the fraction of its
+//     plan cost that its recommendations save (as estimated by the optimizer
+//     when costing the statement with the hypothetical indexes), and the
+//     result is attributed evenly to the indexes that were recommended for it.
+//     That time is the read benefit of the CREATE or REPLACE action that
+//     covers those recommendations;
+//   - the execution time of the statements modifying a table is attributed
+//     evenly to the indexes of the table, and that share is the write cost of
+//     every index that is added to the table (as well as the benefit of every
+//     index that is dropped from it).
+//
+// Actions with a negative net benefit are omitted.
+func FindWorkloadActions(
+	ctx context.Context, evalCtx *eval.Context, ts *tree.DTimestampTZ,
+) ([]Action, error) {
+	stmts, err := collectWorkloadStmts(ctx, evalCtx, ts)
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := collectExistingIndexes(ctx, evalCtx, ts)
+	if err != nil {
+		return nil, err
+	}
+	fks, err := collectForeignKeys(ctx, evalCtx)
+	if err != nil {
+		return nil, err
+	}
+	w := workload{
+		evalCtx:   evalCtx,
+		tableIDs:  make(map[string]tree.ID),
+		indexes:   make(map[tree.ID][]*existingIndex),
+		read:      make(map[indexKey]bool),
+		writeCost: make(map[tree.ID]float64),
+	}
+	for _, idx := range indexes {
+		w.indexes[idx.key.tableID] = append(w.indexes[idx.key.tableID], idx)
+		if idx.readSince {
+			w.read[idx.key] = true
+		}
+	}
+	for i := range fks {
+		for _, idx := range w.indexes[fks[i].tableID] {
+			if idx.def != nil && backsForeignKey(idx.def, fks[i].columns) {
+				idx.backsForeignKey = true
+			}
+		}
+	}
+	for i := range stmts {
+		for _, key := range stmts[i].usedIndexes {
+			w.read[key] = true
+		}
+	}
+	if err := w.computeWriteCosts(ctx, stmts); err != nil {
+		return nil, err
+	}
+
+	actions, replaced, err := w.findCreateActions(ctx, stmts)
+	if err != nil {
+		return nil, err
+	}
+	actions = append(actions, w.findDropActions(replaced)...)
+
+	sort.Slice(actions, func(i, j int) bool {
+		if actions[i].Benefit != actions[j].Benefit {
+			return actions[i].Benefit > actions[j].Benefit
+		}
+		return actions[i].SQL < actions[j].SQL
+	})
+	return actions, nil
+}
+
+// indexKey identifies an existing index.
+type indexKey struct {
+	tableID tree.ID
+	indexID int64
+}
+
+// workloadStmt is a statement fingerprint executed by the workload.
+type workloadStmt struct {
+	// time is the total execution time of the statement in seconds.
+	time float64
+	// costReduction is the fraction of the estimated plan cost of the
+	// statement that is saved by applying all of recs.
+	costReduction float64
+	recs          []indexRec
+	// writtenTable is the name of the table modified by the statement, if any.
+	writtenTable *tree.TableName
+	// usedIndexes are the existing indexes that were used by the statement.
+	usedIndexes []indexKey
+}
+
+// indexRec is a CREATE INDEX recommendation for a single statement along with
+// the indexes that it replaces.
+type indexRec struct {
+	create   tree.CreateIndex
+	replaces []tree.TableIndexName
+}
+
+// existingIndex is an index of a table in the current database.
+type existingIndex struct {
+	key     indexKey
+	def     *tree.CreateIndex
+	primary bool
+	// readSince is true if the index usage statistics report a read of the
+	// index after the start of the workload.
+	readSince bool
+	// backsForeignKey is true if the index can serve the lookups for a
+	// foreign key constraint on (or referencing) the table.
+	backsForeignKey bool
+}
+
+// foreignKey is the set of columns on either side of a foreign key constraint.
+type foreignKey struct {
+	tableID tree.ID
+	columns []tree.Name
+}
+
+// workload contains the state used to find the workload actions.
+type workload struct {
+	evalCtx *eval.Context
+	// tableIDs caches the IDs of the resolved table names.
+	tableIDs map[string]tree.ID
+	indexes  map[tree.ID][]*existingIndex
+	// read contains the indexes that were read by the workload.
+	read map[indexKey]bool
+	// writeCost is the estimated cost of maintaining a single index of each
+	// table.
+	writeCost map[tree.ID]float64
+}
+
+// collectWorkloadStmts collects all the statements of the current database
+// stored in the system.statement_statistics with the time later than ts.
+func collectWorkloadStmts(
+	ctx context.Context, evalCtx *eval.Context, ts *tree.DTimestampTZ,
+) ([]workloadStmt, error) {
+	query := `SELECT COALESCE(index_recommendations, ARRAY[]::STRING[]),
+						 COALESCE((statistics -> 'statistics' ->> 'cnt')::INT8, 1),
+						 COALESCE((statistics -> 'statistics' -> 'svcLat' ->> 'mean')::FLOAT8, 0),
+						 ARRAY(SELECT jsonb_array_elements_text(statistics -> 'statistics' -> 'indexes')),
+						 COALESCE(metadata ->> 'query', ''),
+						 COALESCE((statistics -> 'statistics' ->> 'idxRecCostReduction')::FLOAT8, 0)
+						 FROM system.statement_statistics
+						 WHERE (statistics -> 'statistics' ->> 'lastExecAt')::TIMESTAMPTZ > $1
+						 AND COALESCE(metadata ->> 'db', current_database()) = current_database();`
+	it, err := evalCtx.Planner.QueryIteratorEx(ctx, "get-stmts-for-workload-index-actions",
+		sessiondata.NoSessionDataOverride, query, ts.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	var p parser.Parser
+	var stmts []workloadStmt
+	var ok bool
+	for ok, err = it.Next(ctx); ok; ok, err = it.Next(ctx) {
+		row := it.Cur()
+		stmt := workloadStmt{
+			time:          float64(tree.MustBeDInt(row[1])) * float64(tree.MustBeDFloat(row[2])),
+			costReduction: float64(tree.MustBeDFloat(row[5])),
+		}
+		for _, rec := range tree.MustBeDArray(row[0]).Array {
+			recStr, isString := rec.(*tree.DString)
+			if !isString {
+				return nil, errors.CombineErrors(errors.Newf("%s is not a string!", rec.String()), it.Close())
+			}
+			cis, dis, err := parseIndexRec(&p, string(*recStr))
+			if err != nil {
+				return nil, errors.CombineErrors(err, it.Close())
+			}
+			var replaces []tree.TableIndexName
+			for _, di := range dis {
+				for _, index := range di.IndexList {
+					replaces = append(replaces, *index)
+				}
+			}
+			for _, ci := range cis {
+				stmt.recs = append(stmt.recs, indexRec{create: ci, replaces: replaces})
+			}
+		}
+		for _, index := range tree.MustBeDArray(row[3]).Array {
+			if key, ok := parseUsedIndex(string(tree.MustBeDString(index))); ok {
+				stmt.usedIndexes = append(stmt.usedIndexes, key)
+			}
+		}
+		// The fingerprint of a statement can be parsed since the constants are
+		// replaced with placeholders or underscores. Failing to parse it only
+		// means that the write cost of the statement is not accounted for.
+		if parsed, err := p.Parse(string(tree.MustBeDString(row[4]))); err == nil && len(parsed) == 1 {
+			stmt.writtenTable = writtenTable(parsed[0].AST)
+		}
+		stmts = append(stmts, stmt)
+	}
+	return stmts, errors.CombineErrors(err, it.Close())
+}
+
+// collectExistingIndexes collects all the indexes of the tables in the current
+// database.
+func collectExistingIndexes(
+	ctx context.Context, evalCtx *eval.Context, ts *tree.DTimestampTZ,
+) ([]*existingIndex, error) {
+	query := `SELECT ti.descriptor_id, ti.index_id, ti.index_type = 'primary', ti.create_statement,
+						 COALESCE(us.last_read > $1, false)
+						 FROM crdb_internal.table_indexes AS ti
+						 LEFT JOIN crdb_internal.index_usage_statistics AS us
+						 ON us.table_id = ti.descriptor_id AND us.index_id = ti.index_id
+						 ORDER BY ti.descriptor_id, ti.index_id;`
+	it, err := evalCtx.Planner.QueryIteratorEx(ctx, "get-indexes-for-workload-index-actions",
+		sessiondata.NoSessionDataOverride, query, ts.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	var p parser.Parser
+	var indexes []*existingIndex
+	var ok bool
+	for ok, err = it.Next(ctx); ok; ok, err = it.Next(ctx) {
+		row := it.Cur()
+		idx := &existingIndex{
+			key: indexKey{
+				tableID: tree.ID(tree.MustBeDInt(row[0])),
+				indexID: int64(tree.MustBeDInt(row[1])),
+			},
+			primary:   bool(tree.MustBeDBool(row[2])),
+			readSince: bool(tree.MustBeDBool(row[4])),
+		}
+		// The index definition is only needed for DROP actions, so an index
+		// whose definition can't be parsed is still taken into account when
+		// estimating the write costs.
+		if parsed, err := p.Parse(string(tree.MustBeDString(row[3]))); err == nil && len(parsed) == 1 {
+			if def, isCreateIndex := parsed[0].AST.(*tree.CreateIndex); isCreateIndex {
+				idx.def = def
+			}
+		}
+		indexes = append(indexes, idx)
+	}
+	return indexes, errors.CombineErrors(err, it.Close())
+}
+
+// collectForeignKeys collects the columns on both sides of every foreign key
+// constraint in the current database.
+func collectForeignKeys(ctx context.Context, evalCtx *eval.Context) ([]foreignKey, error) {
+	query := `SELECT fk.table_id,
+						 ARRAY(SELECT tc.column_name FROM crdb_internal.table_columns AS tc
+									 WHERE tc.descriptor_id = fk.table_id AND tc.column_id = ANY (fk.column_ids))
+						 FROM (
+							 SELECT conrelid::INT8 AS table_id, conkey::INT8[] AS column_ids
+							 FROM pg_catalog.pg_constraint WHERE contype = 'f'
+							 UNION ALL
+							 SELECT confrelid::INT8, confkey::INT8[]
+							 FROM pg_catalog.pg_constraint WHERE contype = 'f'
+						 ) AS fk;`
+	it, err := evalCtx.Planner.QueryIteratorEx(ctx, "get-fks-for-workload-index-actions",
+		sessiondata.NoSessionDataOverride, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var fks []foreignKey
+	var ok bool
+	for ok, err = it.Next(ctx); ok; ok, err = it.Next(ctx) {
+		row := it.Cur()
+		fk := foreignKey{tableID: tree.ID(tree.MustBeDInt(row[0]))}
+		for _, col := range tree.MustBeDArray(row[1]).Array {
+			fk.columns = append(fk.columns, tree.Name(tree.MustBeDString(col)))
+		}
+		fks = append(fks, fk)
+	}
+	return fks, errors.CombineErrors(err, it.Close())
+}
+
+// backsForeignKey returns whether the leading indexed columns of the index are
+// exactly the given foreign key columns, in any order.
+func backsForeignKey(def *tree.CreateIndex, fkCols []tree.Name) bool {
+	if len(fkCols) == 0 || len(def.Columns) < len(fkCols) {
+		return false
+	}
+	for i := range fkCols {
+		found := false
+		for j := 0; !found && j < len(fkCols); j++ {
+			found = def.Columns[i].Column == fkCols[j]
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// parseUsedIndex parses an index reported in the statement statistics, which
+// has the form "tableID@indexID".
+func parseUsedIndex(s string) (_ indexKey, ok bool) {
+	tableStr, indexStr, found := strings.Cut(s, "@")
+	if !found {
+		return indexKey{}, false
+	}
+	tableID, err := strconv.ParseInt(tableStr, 10, 64)
+	if err != nil {
+		return indexKey{}, false
+	}
+	indexID, err := strconv.ParseInt(indexStr, 10, 64)
+	if err != nil {
+		return indexKey{}, false
+	}
+	return indexKey{tableID: tree.ID(tableID), indexID: indexID}, true
+}
+
+// writtenTable returns the table modified by the statement, or nil if the
+// statement doesn't modify a table.
+func writtenTable(stmt tree.Statement) *tree.TableName {
+	var expr tree.TableExpr
+	switch stmt := stmt.(type) {
+	case *tree.Insert:
+		expr = stmt.Table
+	case *tree.Update:
+		expr = stmt.Table
+	case *tree.Delete:
+		expr = stmt.Table
+	default:
+		return nil
+	}
+	if aliased, ok := expr.(*tree.AliasedTableExpr); ok {
+		expr = aliased.Expr
+	}
+	tn, _ := expr.(*tree.TableName)
+	return tn
+}
+
+// resolveTable returns the ID of the table with the given name, or ok=false if
+// the table doesn't exist (anymore).
+func (w *workload) resolveTable(ctx context.Context, tn tree.TableName) (_ tree.ID, ok bool, _ error) {
+	name := tn.String()
+	if id, ok := w.tableIDs[name]; ok {
+		return id, id != 0, nil
+	}
+	id, err := w.evalCtx.Planner.ResolveTableName(ctx, &tn)
+	if err != nil {
+		if !sqlerrors.IsUndefinedRelationError(err) {
+			return 0, false, err
+		}
+		id = 0
+	}
+	w.tableIDs[name] = id
+	return id, id != 0, nil
+}
+
+// computeWriteCosts populates writeCost for every table modified by the
+// workload.
+func (w *workload) computeWriteCosts(ctx context.Context, stmts []workloadStmt) error {
+	for i := range stmts {
+		if stmts[i].writtenTable == nil {
+			continue
+		}
+		tableID, ok, err := w.resolveTable(ctx, *stmts[i].writtenTable)
+		if err != nil {
+			return err
+		}
+		if numIndexes := len(w.indexes[tableID]); ok && numIndexes > 0 {
+			w.writeCost[tableID] += stmts[i].time / float64(numIndexes)
+		}
+	}
+	return nil
+}
+
+// findCreateActions returns the CREATE and REPLACE actions for the workload
+// along with the set of existing indexes replaced by them.
+func (w *workload) findCreateActions(
+	ctx context.Context, stmts []workloadStmt,
+) ([]Action, map[indexKey]bool, error) {
+	var cis []tree.CreateIndex
+	for i := range stmts {
+		for j := range stmts[i].recs {
+			cis = append(cis, stmts[i].recs[j].create)
+		}
+	}
+	newCis, err := extractIndexCovering(buildTrieForIndexRecs(cis))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Attribute the execution time saved for every statement to the new
+	// indexes that cover its recommendations.
+	readBenefit := make([]float64, len(newCis))
+	replaces := make([][]tree.TableIndexName, len(newCis))
+	for i := range stmts {
+		saved := stmts[i].time * stmts[i].costReduction
+		for _, rec := range stmts[i].recs {
+			for j := range newCis {
+				if newCis[j].Table != rec.create.Table {
+					continue
+				}
+				tableID, _, err := w.resolveTable(ctx, newCis[j].Table)
+				if err != nil {
+					return nil, nil, err
+				}
+				if covers(&newCis[j], &rec.create, false /* primary */, w.primaryKey(tableID)) {
+					readBenefit[j] += saved / float64(len(stmts[i].recs))
+					replaces[j] = append(replaces[j], rec.replaces...)
+					break
+				}
+			}
+		}
+	}
+
+	var actions []Action
+	replaced := make(map[indexKey]bool)
+	for i := range newCis {
+		tableID, ok, err := w.resolveTable(ctx, newCis[i].Table)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			continue
+		}
+		action := Action{
+			Type:    ActionCreate,
+			SQL:     newCis[i].String() + ";",
+			Reason:  "recommended for statements in the workload",
+			Benefit: readBenefit[i] - w.writeCost[tableID],
+		}
+		var drops []*tree.TableIndexName
+		for j := range replaces[i] {
+			idx := w.findIndexByName(tableID, replaces[i][j].Index)
+			if idx == nil || replaced[idx.key] {
+				continue
+			}
+			replaced[idx.key] = true
+			drops = append(drops, &replaces[i][j])
+			// The replaced index no longer has to be maintained.
+			action.Benefit += w.writeCost[tableID]
+		}
+		if len(drops) > 0 {
+			action.Type = ActionReplace
+			action.SQL += " " + (&tree.DropIndex{IndexList: drops}).String() + ";"
+			action.Reason = "recommended for statements in the workload, makes existing indexes redundant"
+		}
+		if action.Benefit >= 0 {
+			actions = append(actions, action)
+		}
+	}
+	return actions, replaced, nil
+}
+
+// primaryKey returns the indexed columns of the primary index of the table, or
+// nil if they are unknown.
+func (w *workload) primaryKey(tableID tree.ID) tree.IndexElemList {
+	for _, idx := range w.indexes[tableID] {
+		if idx.primary && idx.def != nil {
+			return idx.def.Columns
+		}
+	}
+	return nil
+}
+
+// findIndexByName returns the secondary index of the table with the given
+// name, or nil if there is no such index.
+func (w *workload) findIndexByName(tableID tree.ID, name tree.UnrestrictedName) *existingIndex {
+	for _, idx := range w.indexes[tableID] {
+		if !idx.primary && idx.def != nil && idx.def.Name == tree.Name(name) {
+			return idx
+		}
+	}
+	return nil
+}
+
+// findDropActions returns the DROP actions for the secondary non-unique
+// indexes that are either redundant or unused, skipping the indexes that are
+// already replaced and those backing a foreign key.
+func (w *workload) findDropActions(replaced map[indexKey]bool) []Action {
+	var tableIDs []tree.ID
+	for tableID := range w.indexes {
+		tableIDs = append(tableIDs, tableID)
+	}
+	sort.Slice(tableIDs, func(i, j int) bool { return tableIDs[i] < tableIDs[j] })
+
+	var actions []Action
+	for _, tableID := range tableIDs {
+		indexes := w.indexes[tableID]
+		pk := w.primaryKey(tableID)
+		dropped := make(map[indexKey]bool)
+		// kept contains the indexes that must not be dropped because they
+		// serve the reads of a redundant index that is dropped.
+		kept := make(map[indexKey]bool)
+		drop := func(idx *existingIndex, reason string) {
+			dropped[idx.key] = true
+			actions = append(actions, Action{
+				Type: ActionDrop,
+				SQL: (&tree.DropIndex{IndexList: []*tree.TableIndexName{{
+					Table: idx.def.Table, Index: tree.UnrestrictedName(idx.def.Name),
+				}}}).String() + ";",
+				Reason:  reason,
+				Benefit: w.writeCost[tableID],
+			})
+		}
+		isDroppable := func(idx *existingIndex) bool {
+			return !idx.primary && idx.def != nil && !idx.def.Unique && !idx.backsForeignKey &&
+				!replaced[idx.key] && !dropped[idx.key] && !kept[idx.key]
+		}
+
+		for _, idx := range indexes {
+			if !isDroppable(idx) || !isPlainIndex(idx.def) {
+				continue
+			}
+			for _, other := range indexes {
+				if other == idx || other.def == nil || dropped[other.key] || replaced[other.key] ||
+					!isPlainIndex(other.def) {
+					continue
+				}
+				// Out of two identical indexes, the older one is kept.
+				if covers(other.def, idx.def, other.primary, pk) &&
+					(!covers(idx.def, other.def, idx.primary, pk) || other.key.indexID < idx.key.indexID) {
+					kept[other.key] = true
+					drop(idx, "redundant with index "+other.def.Name.String())
+					break
+				}
+			}
+		}
+		for _, idx := range indexes {
+			if isDroppable(idx) && !w.read[idx.key] {
+				drop(idx, "unused by the workload")
+			}
+		}
+	}
+	return actions
+}
+
+// isPlainIndex returns whether the index is a forward index that is neither
+// partial nor hash-sharded, so that its ability to serve the reads can be
+// determined from the indexed and stored columns alone.
+func isPlainIndex(def *tree.CreateIndex) bool {
+	return !def.Inverted && def.Predicate == nil && def.Sharded == nil
+}
+
+// covers returns whether every read served by the index other can be served
+// by the index ci, that is the indexed columns of other are a prefix of the
+// key columns of ci, and all columns stored in other are also key columns of
+// or stored in ci. A primary index stores all columns. The key columns of a
+// secondary index are its indexed columns followed by the columns of the
+// primary key pk that it doesn't index, which it also stores implicitly.
+func covers(ci, other *tree.CreateIndex, primary bool, pk tree.IndexElemList) bool {
+	ciCols := ci.Columns
+	if !primary {
+		ciCols = keyColumns(ci.Columns, pk)
+	}
+	if len(other.Columns) > len(ciCols) {
+		return false
+	}
+	for i := range other.Columns {
+		if other.Columns[i].Column != ciCols[i].Column ||
+			normalizeDirection(other.Columns[i].Direction) != normalizeDirection(ciCols[i].Direction) {
+			return false
+		}
+	}
+	if primary {
+		return true
+	}
+	for _, col := range other.Storing {
+		found := false
+		for i := range ciCols {
+			if ciCols[i].Column == col {
+				found = true
+				break
+			}
+		}
+		for i := 0; !found && i < len(ci.Storing); i++ {
+			found = ci.Storing[i] == col
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// keyColumns returns the indexed columns of a secondary index followed by the
+// columns of the primary key pk that are not among them.
+func keyColumns(cols, pk tree.IndexElemList) tree.IndexElemList {
+	// Limit the capacity so that appending never modifies the index definition.
+	res := cols[:len(cols):len(cols)]
+	for i := range pk {
+		found := false
+		for j := 0; !found && j < len(cols); j++ {
+			found = cols[j].Column == pk[i].Column
+		}
+		if !found {
+			res = append(res, pk[i])
+		}
+	}
+	return res
+}
+
+func normalizeDirection(dir tree.Direction) tree.Direction {
+	if dir == tree.DefaultDirection {
+		return tree.Ascending
+	}
+	return dir
+}
@@ -82,10 +82,6 @@ func collectIndexRecs(
 	var dis []tree.DropIndex
 	var ok bool
 
-	// The index recommendation starts with "creation", "replacement" or
-	// "alteration".
-	var r = regexp.MustCompile(`\s*(creation|replacement|alteration)\s*:\s*(.*)`)
-
 	for ok, err = indexRecs.Next(ctx); ; ok, err = indexRecs.Next(ctx) {
 		if err != nil {
 			err = errors.CombineErrors(err, indexRecs.Close())
@@ -106,40 +102,57 @@ func collectIndexRecs(
 				return cis, dis, err
 			}
 
-			indexStrArr := r.FindStringSubmatch(string(*indexStr))
-			if indexStrArr == nil {
-				err = errors.CombineErrors(errors.Newf("%s is not a valid index recommendation!", string(*indexStr)), indexRecs.Close())
+			recCis, recDis, err := parseIndexRec(&p, string(*indexStr))
+			if err != nil {
+				err = errors.CombineErrors(err, indexRecs.Close())
 				indexRecs = nil
 				return cis, dis, err
 			}
+			cis = append(cis, recCis...)
+			dis = append(dis, recDis...)
+		}
+	}
 
-			// Since Alter index recommendation only makes invisible indexes visible,
-			// so we skip it for now.
-			if indexStrArr[1] == "alteration" {
-				continue
-			}
+	return cis, dis, nil
+}
 
-			stmts, err := p.Parse(indexStrArr[2])
-			if err != nil {
-				err = errors.CombineErrors(errors.Newf("%s is not a valid index operation!", indexStrArr[2]), indexRecs.Close())
-				indexRecs = nil
-				return cis, dis, err
-			}
+// indexRecRegexp matches an index recommendation, which starts with
+// "creation", "replacement" or "alteration".
+var indexRecRegexp = regexp.MustCompile(`\s*(creation|replacement|alteration)\s*:\s*(.*)`)
+
+// parseIndexRec parses a single index recommendation stored in the
+// system.statement_statistics into the CREATE INDEX and DROP INDEX statements
+// that it consists of.
+func parseIndexRec(
+	p *parser.Parser, rec string,
+) (cis []tree.CreateIndex, dis []tree.DropIndex, _ error) {
+	indexStrArr := indexRecRegexp.FindStringSubmatch(rec)
+	if indexStrArr == nil {
+		return nil, nil, errors.Newf("%s is not a valid index recommendation!", rec)
+	}
 
-			for _, stmt := range stmts {
-				switch stmt := stmt.AST.(type) {
-				case *tree.CreateIndex:
-					// Ignore all the inverted, partial and sharded indexes right now.
-					if !stmt.Inverted && stmt.Predicate == nil && stmt.Sharded == nil {
-						cis = append(cis, *stmt)
-					}
-				case *tree.DropIndex:
-					dis = append(dis, *stmt)
-				}
+	// Since Alter index recommendation only makes invisible indexes visible,
+	// so we skip it for now.
+	if indexStrArr[1] == "alteration" {
+		return nil, nil, nil
+	}
+
+	stmts, err := p.Parse(indexStrArr[2])
+	if err != nil {
+		return nil, nil, errors.Newf("%s is not a valid index operation!", indexStrArr[2])
+	}
+
+	for _, stmt := range stmts {
+		switch stmt := stmt.AST.(type) {
+		case *tree.CreateIndex:
+			// Ignore all the inverted, partial and sharded indexes right now.
+			if !stmt.Inverted && stmt.Predicate == nil && stmt.Sharded == nil {
+				cis = append(cis, *stmt)
 			}
+		case *tree.DropIndex:
+			dis = append(dis, *stmt)
 		}
 	}
-
 	return cis, dis, nil
 }
 
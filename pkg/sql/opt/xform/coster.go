@@ -187,6 +187,12 @@ const (
 	//               region in a distribution and the gateway region.
 	DistributeCost = 200
 
+	// networkTransferCostFactor is the cost of transferring 4 bytes (the default
+	// column size) of a row from a remote region to the gateway region. It is
+	// only used when the optimizer_use_network_transfer_cost session setting is
+	// enabled, in addition to the fixed latency overhead of DistributeCost.
+	networkTransferCostFactor = 4 * cpuCostFactor
+
 	// LargeDistributeCost is the cost to use for Distribute operations when a
 	// session mode is set to error out on access of rows from remote regions.
 	LargeDistributeCost = hugeCost
@@ -738,7 +744,9 @@ func (c *coster) computeDistributeCost(
 	}
 	if target, source, ok := distribute.GetDistributions(); ok {
 		if distributionIsLocal(target, c.evalCtx) {
-			return c.distributionCost(source)
+			input := distribute.Input.Relational()
+			return c.distributionCost(source) +
+				c.networkTransferCost(source, input.Statistics().RowCount, input.OutputCols)
 		}
 	}
 	if c.evalCtx != nil && c.evalCtx.Planner.EnforceHomeRegion() {
@@ -858,6 +866,7 @@ func (c *coster) computeScanCost(scan *memo.ScanExpr, required *physical.Require
 		return cost
 	}
 	extraCost := c.distributionCost(regionsAccessed)
+	extraCost += c.networkTransferCost(regionsAccessed, rowCount, scan.Cols)
 	cost += extraCost
 	return cost
 }
@@ -871,6 +880,135 @@ func distributionIsLocal(regionsAccessed physical.Distribution, evalCtx *eval.Co
 	return false
 }
 
+// networkTransferCost returns the cost of transferring rowCount rows containing
+// the given columns from the regions in source to the gateway region. The cost
+// is proportional to the estimated number of bytes transferred, which is
+// derived from the average size of each column. It is zero if the
+// optimizer_use_network_transfer_cost session setting is disabled, if the
+// regions are unknown, or if source is the gateway region.
+func (c *coster) networkTransferCost(
+	source physical.Distribution, rowCount float64, cols opt.ColSet,
+) memo.Cost {
+	if c.evalCtx == nil || !c.evalCtx.SessionData().OptimizerUseNetworkTransferCost {
+		return 0
+	}
+	return c.transferCost(source, rowCount, cols)
+}
+
+// transferCost is like networkTransferCost, but it doesn't depend on the
+// optimizer_use_network_transfer_cost session setting.
+func (c *coster) transferCost(
+	source physical.Distribution, rowCount float64, cols opt.ColSet,
+) memo.Cost {
+	if source.Any() || distributionIsLocal(source, c.evalCtx) {
+		return 0
+	}
+	// Divide the row width by the default column size (4 bytes), matching the
+	// normalization in rowScanCost.
+	return memo.Cost(rowCount*c.rowWidth(cols)/4) * networkTransferCostFactor
+}
+
+// DistributionEstimate contains the estimates, derived from the optimal plan in
+// a memo, that are used to decide whether to distribute the execution of the
+// plan.
+type DistributionEstimate struct {
+	// RowsRead is the estimated number of rows read from tables by the scans,
+	// index joins, lookup joins and inverted joins of the plan.
+	RowsRead float64
+	// RowsReturned is the estimated number of rows returned by the plan.
+	RowsReturned float64
+	// NetworkTransferCost is the estimated cost of transferring the rows read
+	// from remote regions to the gateway region, expressed as the number of
+	// rows that could be processed in the same time.
+	NetworkTransferCost float64
+}
+
+// EstimateDistribution returns the DistributionEstimate of the optimal plan in
+// the given optimized memo. If the memo is for an EXPLAIN statement, then the
+// estimate is for the explained plan. ok is false if some table read by the
+// plan has no statistics, in which case the row counts are not meaningful.
+func EstimateDistribution(
+	ctx context.Context, evalCtx *eval.Context, mem *memo.Memo,
+) (_ DistributionEstimate, ok bool) {
+	root, isRel := mem.RootExpr().(memo.RelExpr)
+	if !isRel {
+		return DistributionEstimate{}, false
+	}
+	if explain, isExplain := root.(*memo.ExplainExpr); isExplain {
+		root = explain.Input
+	}
+	var c coster
+	c.Init(ctx, evalCtx, mem, 0 /* perturbation */, nil /* rng */, nil /* o */)
+	md := mem.Metadata()
+	indexDistribution := func(tabID opt.TableID, idx cat.IndexOrdinal) physical.Distribution {
+		var dist physical.Distribution
+		dist.FromIndexScan(ctx, evalCtx, md.TableMeta(tabID), idx, nil /* constraint */)
+		return dist
+	}
+
+	est := DistributionEstimate{RowsReturned: root.Relational().Statistics().RowCount}
+	var transferCost memo.Cost
+	ok = true
+	var walk func(e opt.Expr)
+	walk = func(e opt.Expr) {
+		if rel, isRel := e.(memo.RelExpr); isRel {
+			var source physical.Distribution
+			var cols opt.ColSet
+			reads := true
+			switch t := rel.(type) {
+			case *memo.ScanExpr:
+				cols = t.Cols
+				if t.Distribution.Regions != nil {
+					source = t.Distribution
+				} else {
+					source.FromIndexScan(ctx, evalCtx, md.TableMeta(t.Table), t.Index, t.Constraint)
+				}
+			case *memo.IndexJoinExpr:
+				cols = t.Cols.Difference(t.Input.Relational().OutputCols)
+				source = indexDistribution(t.Table, cat.PrimaryIndex)
+			case *memo.LookupJoinExpr:
+				cols = t.Cols.Difference(t.Input.Relational().OutputCols)
+				source = indexDistribution(t.Table, t.Index)
+			case *memo.InvertedJoinExpr:
+				cols = t.Cols.Difference(t.Input.Relational().OutputCols)
+				source = indexDistribution(t.Table, t.Index)
+			default:
+				reads = false
+			}
+			if reads {
+				stats := rel.Relational().Statistics()
+				if !stats.Available {
+					ok = false
+				}
+				est.RowsRead += stats.RowCount
+				transferCost += c.transferCost(source, stats.RowCount, cols)
+			}
+		}
+		for i, n := 0, e.ChildCount(); i < n; i++ {
+			walk(e.Child(i))
+		}
+	}
+	walk(root)
+	est.NetworkTransferCost = float64(transferCost / cpuCostFactor)
+	return est, ok
+}
+
+// rowWidth returns the estimated average size in bytes of a row containing
+// the given columns. Columns that do not originate from a table are assumed to
+// have the default size of 4 bytes.
+func (c *coster) rowWidth(cols opt.ColSet) float64 {
+	md := c.mem.Metadata()
+	var width float64
+	cols.ForEach(func(col opt.ColumnID) {
+		if tabID := md.ColumnMeta(col).Table; tabID != 0 {
+			width += float64(c.mem.RequestColAvgSize(tabID, col))
+		} else {
+			width += 4
+		}
+	})
+	return width
+}
+
 // distributionCost returns the cost to perform a distribution from
 // `regionsAccessed` to the gateway region.
 func (c *coster) distributionCost(regionsAccessed physical.Distribution) (cost memo.Cost) {
@@ -1073,6 +1211,10 @@ func (c *coster) computeLookupJoinCost(
 	_, provided := distribution.BuildLookupJoinLookupTableDistribution(
 		c.ctx, c.evalCtx, join, required, c.MaybeGetBestCostRelation)
 	extraCost := c.distributionCost(provided)
+	lookupCols := join.Cols.Difference(join.Input.Relational().OutputCols)
+	extraCost += c.networkTransferCost(
+		provided, join.Relational().Statistics().RowCount, lookupCols,
+	)
 	cost += extraCost
 	return cost
 }
@@ -1231,6 +1373,7 @@ func (c *coster) computeInvertedJoinCost(
 
 	provided := distribution.BuildInvertedJoinLookupTableDistribution(c.ctx, c.evalCtx, join)
 	extraCost := c.distributionCost(provided)
+	extraCost += c.networkTransferCost(provided, rowsProcessed, lookupCols)
 	cost += extraCost
 	return cost
 }
@@ -11,14 +11,22 @@
 package xform
 
 import (
+	"context"
 	"math"
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 )
 
@@ -99,3 +107,44 @@ func TestLocalityMatchScore(t *testing.T) {
 		}
 	}
 }
+
+func TestNetworkTransferCost(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	evalCtx := eval.MakeTestingEvalContext(st)
+	defer evalCtx.Stop(ctx)
+	evalCtx.Locality = roachpb.Locality{Tiers: []roachpb.Tier{{Key: "region", Value: "us"}}}
+
+	var mem memo.Memo
+	mem.Init(ctx, &evalCtx)
+	md := mem.Metadata()
+	a := md.AddColumn("a", types.Int)
+	b := md.AddColumn("b", types.String)
+
+	c := MakeDefaultCoster(ctx, &evalCtx, &mem, nil /* o */).(*coster)
+	local := physical.Distribution{Regions: []string{"us"}}
+	remote := physical.Distribution{Regions: []string{"eu", "us"}}
+	unknown := physical.Distribution{}
+
+	// The network transfer cost is disabled by default.
+	require.Zero(t, c.networkTransferCost(remote, 100, opt.MakeColSet(a, b)))
+
+	evalCtx.SessionData().OptimizerUseNetworkTransferCost = true
+	require.Zero(t, c.networkTransferCost(local, 100, opt.MakeColSet(a, b)))
+	require.Zero(t, c.networkTransferCost(unknown, 100, opt.MakeColSet(a, b)))
+
+	// Columns that don't belong to a table use the default size, so each column
+	// of each row contributes networkTransferCostFactor.
+	narrow := c.networkTransferCost(remote, 100, opt.MakeColSet(a))
+	require.InDelta(t, 100*networkTransferCostFactor, float64(narrow), 1e-9)
+
+	// The cost grows with both the row width and the row count.
+	wide := c.networkTransferCost(remote, 100, opt.MakeColSet(a, b))
+	require.InDelta(t, 2*float64(narrow), float64(wide), 1e-9)
+	require.InDelta(
+		t, 10*float64(wide), float64(c.networkTransferCost(remote, 1000, opt.MakeColSet(a, b))), 1e-9,
+	)
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package xform
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// HasPlaceholders returns true if any of the given filters contains a
+// placeholder.
+func (c *CustomFuncs) HasPlaceholders(filters memo.FiltersExpr) bool {
+	for i := range filters {
+		if filters[i].ScalarProps().HasPlaceholder {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateParameterizedJoinValuesAndFilters returns a single-row Values
+// expression with a column for each distinct placeholder in the given filters,
+// and a copy of the filters where each placeholder is replaced by a reference
+// to its Values column. ok is false if none of the filters compares a column
+// with a placeholder in a way that a lookup join can use, since no lookup join
+// could be planned with the Values expression as its input.
+func (c *CustomFuncs) GenerateParameterizedJoinValuesAndFilters(
+	filters memo.FiltersExpr,
+) (values memo.RelExpr, newFilters memo.FiltersExpr, ok bool) {
+	hasPlaceholderComparison := false
+	for i := range filters {
+		if c.isPlaceholderComparison(filters[i].Condition) {
+			hasPlaceholderComparison = true
+			break
+		}
+	}
+	if !hasPlaceholderComparison {
+		return nil, nil, false
+	}
+
+	md := c.e.f.Metadata()
+	placeholderCols := make(map[tree.PlaceholderIdx]opt.ColumnID)
+	var cols opt.ColList
+	var elems memo.ScalarListExpr
+	var colTypes []*types.T
+	var replace norm.ReplaceFunc
+	replace = func(e opt.Expr) opt.Expr {
+		if p, ok := e.(*memo.PlaceholderExpr); ok {
+			idx := p.Value.(*tree.Placeholder).Idx
+			col, ok := placeholderCols[idx]
+			if !ok {
+				col = md.AddColumn(fmt.Sprintf("$%d", idx+1), p.DataType())
+				placeholderCols[idx] = col
+				cols = append(cols, col)
+				elems = append(elems, p)
+				colTypes = append(colTypes, p.DataType())
+			}
+			return c.e.f.ConstructVariable(col)
+		}
+		return c.e.f.Replace(e, replace)
+	}
+
+	newFilters = make(memo.FiltersExpr, len(filters))
+	for i := range filters {
+		cond := replace(filters[i].Condition).(opt.ScalarExpr)
+		newFilters[i] = c.e.f.ConstructFiltersItem(cond)
+	}
+
+	values = c.e.f.ConstructValues(
+		memo.ScalarListExpr{c.e.f.ConstructTuple(elems, types.MakeTuple(colTypes))},
+		&memo.ValuesPrivate{Cols: cols, ID: md.NextUniqueID()},
+	)
+	return values, newFilters, true
+}
+
+// isPlaceholderComparison returns true if the given condition compares a
+// column with a placeholder, and a lookup join can use the comparison to
+// constrain its lookups once the placeholder is replaced with an input column.
+// Equalities become lookup keys. Range comparisons such as k > $1 become
+// lookup expressions if variable inequality lookup joins are enabled.
+func (c *CustomFuncs) isPlaceholderComparison(cond opt.ScalarExpr) bool {
+	switch cond.Op() {
+	case opt.EqOp:
+	case opt.LtOp, opt.LeOp, opt.GtOp, opt.GeOp:
+		if !c.e.evalCtx.SessionData().VariableInequalityLookupJoinEnabled {
+			return false
+		}
+	default:
+		return false
+	}
+	_, leftIsVar := cond.Child(0).(*memo.VariableExpr)
+	_, leftIsPlaceholder := cond.Child(0).(*memo.PlaceholderExpr)
+	_, rightIsVar := cond.Child(1).(*memo.VariableExpr)
+	_, rightIsPlaceholder := cond.Child(1).(*memo.PlaceholderExpr)
+	return (leftIsVar && rightIsPlaceholder) || (leftIsPlaceholder && rightIsVar)
+}
+
+// ParameterizedJoinPrivate returns the JoinPrivate of a join built by
+// GenerateParameterizedJoin. Only a lookup join into the scanned table is
+// allowed, since any other join would be no better than the original Select.
+func (c *CustomFuncs) ParameterizedJoinPrivate() *memo.JoinPrivate {
+	return &memo.JoinPrivate{
+		Flags:            memo.AllowOnlyLookupJoinIntoRight,
+		SkipReorderJoins: true,
+	}
+}
@@ -347,7 +347,9 @@ func (c *CustomFuncs) canGenerateLookupJoins(
 
 // generateLookupJoinsImpl is the general implementation for generating lookup
 // joins. The rightCols argument must be the columns output by the right side of
-// matched join expression. projectedVirtualCols is the set of virtual columns
+// matched join expression, or the columns of the table it scans if the right
+// side discards some of them; in that case, the generated expressions are
+// wrapped in a Project that discards them too. projectedVirtualCols is the set of virtual columns
 // projected on the right side of the matched join expression.
 //
 // See GenerateLookupJoins and GenerateLookupJoinsWithVirtualCols for
@@ -725,6 +727,19 @@ func (c *CustomFuncs) generateLookupJoinsImpl(
 		// unneeded right-side columns.
 		if joinType == opt.SemiJoinOp || joinType == opt.AntiJoinOp {
 			indexJoin.Cols = inputProps.OutputCols.Union(indexJoin.On.OuterCols())
+		} else if !indexJoin.Cols.SubsetOf(grp.Relational().OutputCols) {
+			// The right side of the matched join discards some of the columns
+			// of its table (see GenerateLookupJoinsThroughBarrierWithProject),
+			// so wrap the index join in a Project that discards them too.
+			var project memo.ProjectExpr
+			project.Input = c.e.f.ConstructLookupJoin(
+				indexJoin.Input,
+				indexJoin.On,
+				&indexJoin.LookupJoinPrivate,
+			)
+			project.Passthrough = grp.Relational().OutputCols
+			c.e.mem.AddProjectToGroup(&project, grp)
+			return
 		}
 
 		// Create the LookupJoin for the index join in the same group.
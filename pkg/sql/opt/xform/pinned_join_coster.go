@@ -0,0 +1,146 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package xform
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+)
+
+// PinnedJoin is a join that the optimizer must reproduce: the algorithm that
+// executes it and the tables (as opt.TableIDs) read by each of its inputs. For
+// lookup and inverted joins, Right is the table whose index is looked up.
+type PinnedJoin struct {
+	Algorithm   exec.JoinAlgorithm
+	Left, Right intsets.Fast
+}
+
+// NewPinnedJoinCoster returns a Coster that wraps the given coster and makes
+// the optimizer reproduce the given joins, for example the joins of a plan
+// pinned by a plan baseline. A join expression whose inputs read the same
+// tables as a pinned join is only allowed if it uses the same algorithm and
+// the same input on each side; any other join expression is only allowed if
+// no pinned join reads its tables, which rejects the join orders that were not
+// pinned. Disallowed expressions are given a huge cost, so that they are only
+// chosen if no plan matches the pinned joins.
+//
+// Inner, outer, semi and anti joins are executed as hash joins (or as cross
+// joins, which are treated as hash joins). Apply joins are not pinned, and
+// lookup joins into a table that is already read by their input (which stand in
+// for index joins) are always allowed.
+func NewPinnedJoinCoster(wrapped Coster, joins []PinnedJoin) Coster {
+	return &pinnedJoinCoster{
+		Coster: wrapped,
+		joins:  joins,
+		tables: make(map[memo.RelExpr]intsets.Fast),
+	}
+}
+
+// pinnedJoinCoster is the Coster returned by NewPinnedJoinCoster.
+type pinnedJoinCoster struct {
+	Coster
+
+	joins []PinnedJoin
+
+	// tables caches the tables read by the expressions of each memo group,
+	// keyed by the first expression in the group.
+	tables map[memo.RelExpr]intsets.Fast
+}
+
+var _ Coster = &pinnedJoinCoster{}
+
+// ComputeCost is part of the Coster interface.
+func (c *pinnedJoinCoster) ComputeCost(
+	candidate memo.RelExpr, required *physical.Required,
+) memo.Cost {
+	cost := c.Coster.ComputeCost(candidate, required)
+	if !c.allowed(candidate) {
+		cost += hugeCost
+	}
+	return cost
+}
+
+// allowed returns false if the candidate is a join that does not match the
+// pinned joins.
+func (c *pinnedJoinCoster) allowed(candidate memo.RelExpr) bool {
+	var algorithm exec.JoinAlgorithm
+	var left, right intsets.Fast
+	switch t := candidate.(type) {
+	case *memo.MergeJoinExpr:
+		algorithm, left, right = exec.MergeJoin, c.tablesOf(t.Left), c.tablesOf(t.Right)
+	case *memo.LookupJoinExpr:
+		algorithm, left = exec.LookupJoin, c.tablesOf(t.Input)
+		right.Add(int(t.Table))
+		if right.SubsetOf(left) {
+			return true
+		}
+	case *memo.InvertedJoinExpr:
+		algorithm, left = exec.InvertedJoin, c.tablesOf(t.Input)
+		right.Add(int(t.Table))
+		if right.SubsetOf(left) {
+			return true
+		}
+	default:
+		if !opt.IsJoinNonApplyOp(candidate) {
+			return true
+		}
+		algorithm = exec.HashJoin
+		left = c.tablesOf(candidate.Child(0).(memo.RelExpr))
+		right = c.tablesOf(candidate.Child(1).(memo.RelExpr))
+	}
+	if left.Empty() || right.Empty() {
+		return true
+	}
+	tables := left.Union(right)
+	for i := range c.joins {
+		j := &c.joins[i]
+		if j.Left.Union(j.Right).Equals(tables) {
+			return j.Algorithm == algorithm && j.Left.Equals(left) && j.Right.Equals(right)
+		}
+	}
+	return false
+}
+
+// tablesOf returns the tables read by the given expression and its
+// relational inputs. Tables read by subqueries are not included, since they
+// are planned separately.
+func (c *pinnedJoinCoster) tablesOf(e memo.RelExpr) intsets.Fast {
+	e = e.FirstExpr()
+	if tables, ok := c.tables[e]; ok {
+		return tables
+	}
+	var tables intsets.Fast
+	switch t := e.(type) {
+	case *memo.ScanExpr:
+		tables.Add(int(t.Table))
+	case *memo.PlaceholderScanExpr:
+		tables.Add(int(t.Table))
+	case *memo.IndexJoinExpr:
+		tables.Add(int(t.Table))
+	case *memo.LookupJoinExpr:
+		tables.Add(int(t.Table))
+	case *memo.InvertedJoinExpr:
+		tables.Add(int(t.Table))
+	case *memo.ZigzagJoinExpr:
+		tables.Add(int(t.LeftTable))
+		tables.Add(int(t.RightTable))
+	}
+	for i, n := 0, e.ChildCount(); i < n; i++ {
+		if child, ok := e.Child(i).(memo.RelExpr); ok {
+			tables.UnionWith(c.tablesOf(child))
+		}
+	}
+	c.tables[e] = tables
+	return tables
+}
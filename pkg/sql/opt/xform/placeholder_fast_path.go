@@ -91,6 +91,11 @@ func (o *Optimizer) TryPlaceholderFastPath() (_ opt.Expr, ok bool, err error) {
 	if !isScan {
 		return nil, false, nil
 	}
+	if !scan.Flags.Empty() {
+		// The index choice below doesn't take index hints into account, such as
+		// the ones pinned by plan baselines.
+		return nil, false, nil
+	}
 
 	var constrainedCols opt.ColSet
 	for i := range sel.Filters {
@@ -450,6 +450,29 @@ func (oc *optCatalog) HasRoleOption(
 	return oc.planner.HasRoleOption(ctx, roleOption)
 }
 
+// HasOwnership is part of the cat.Catalog interface.
+func (oc *optCatalog) HasOwnership(ctx context.Context, o cat.Object) (bool, error) {
+	desc, err := getDescFromCatalogObjectForPermissions(o)
+	if err != nil {
+		return false, err
+	}
+	return oc.planner.HasOwnership(ctx, desc)
+}
+
+// IsMemberOfRole is part of the cat.Catalog interface.
+func (oc *optCatalog) IsMemberOfRole(ctx context.Context, role username.SQLUsername) (bool, error) {
+	user := oc.planner.User()
+	if role.IsPublicRole() || role == user {
+		return true, nil
+	}
+	memberOf, err := oc.planner.MemberOfWithAdminOption(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	_, ok := memberOf[role]
+	return ok, nil
+}
+
 // FullyQualifiedName is part of the cat.Catalog interface.
 func (oc *optCatalog) FullyQualifiedName(
 	ctx context.Context, ds cat.DataSource,
@@ -774,6 +797,9 @@ type optTable struct {
 	// constraints for user defined types.
 	checkConstraints []optCheckConstraint
 
+	// policies are the row-level security policies of the table.
+	policies []cat.Policy
+
 	// colMap is a mapping from unique ColumnID to column ordinal within the
 	// table. This is a common lookup that needs to be fast.
 	colMap catalog.TableColMap
@@ -1084,6 +1110,13 @@ func newOptTable(
 	}
 	ot.checkConstraints = append(ot.checkConstraints, synthesizedChecks...)
 
+	if policies := desc.GetPolicies(); len(policies) > 0 {
+		ot.policies = make([]cat.Policy, len(policies))
+		for i := range policies {
+			ot.policies[i] = makeOptPolicy(&policies[i])
+		}
+	}
+
 	// Add stats last, now that other metadata is initialized.
 	if stats != nil {
 		ot.stats = make([]optTableStat, len(stats))
@@ -1394,6 +1427,52 @@ func (ot *optTable) IsHypothetical() bool {
 	return false
 }
 
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityEnabled() bool {
+	return ot.desc.IsRowLevelSecurityEnabled()
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityForced() bool {
+	return ot.desc.IsRowLevelSecurityForced()
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optTable) PolicyCount() int {
+	return len(ot.policies)
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optTable) Policy(i int) *cat.Policy {
+	return &ot.policies[i]
+}
+
+// makeOptPolicy converts a policy descriptor to its cat.Policy representation.
+func makeOptPolicy(desc *descpb.PolicyDescriptor) cat.Policy {
+	policy := cat.Policy{
+		Name:          desc.Name,
+		Restrictive:   desc.Type == descpb.PolicyDescriptor_RESTRICTIVE,
+		Command:       tree.PolicyCommandAll,
+		Roles:         make([]username.SQLUsername, len(desc.RoleNames)),
+		UsingExpr:     desc.UsingExpr,
+		WithCheckExpr: desc.WithCheckExpr,
+	}
+	switch desc.Command {
+	case descpb.PolicyDescriptor_SELECT:
+		policy.Command = tree.PolicyCommandSelect
+	case descpb.PolicyDescriptor_INSERT:
+		policy.Command = tree.PolicyCommandInsert
+	case descpb.PolicyDescriptor_UPDATE:
+		policy.Command = tree.PolicyCommandUpdate
+	case descpb.PolicyDescriptor_DELETE:
+		policy.Command = tree.PolicyCommandDelete
+	}
+	for i, role := range desc.RoleNames {
+		policy.Roles[i] = username.MakeSQLUsernameFromPreNormalizedString(role)
+	}
+	return policy
+}
+
 // lookupColumnOrdinal returns the ordinal of the column with the given ID. A
 // cache makes the lookup O(1).
 func (ot *optTable) lookupColumnOrdinal(colID descpb.ColumnID) (int, error) {
@@ -1865,6 +1944,16 @@ func (os *optTableStat) HistogramType() *types.T {
 	return os.stat.HistogramData.ColumnType
 }
 
+// Dependencies is part of the cat.TableStatistic interface.
+func (os *optTableStat) Dependencies() []cat.StatisticDependency {
+	return os.stat.Dependencies
+}
+
+// MostCommonValues is part of the cat.TableStatistic interface.
+func (os *optTableStat) MostCommonValues() []cat.StatisticMCV {
+	return os.stat.MostCommonValues
+}
+
 // IsPartial is part of the cat.TableStatistic interface.
 func (os *optTableStat) IsPartial() bool {
 	return os.stat.IsPartial()
@@ -2430,6 +2519,26 @@ func (ot *optVirtualTable) IsHypothetical() bool {
 	return false
 }
 
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optVirtualTable) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optVirtualTable) Policy(i int) *cat.Policy {
+	panic(errors.AssertionFailedf("no policies"))
+}
+
 // CollectTypes is part of the cat.DataSource interface.
 func (ot *optVirtualTable) CollectTypes(ord int) (descpb.IDs, error) {
 	col := ot.desc.AllColumns()[ord]
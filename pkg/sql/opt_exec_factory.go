@@ -33,6 +33,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec/explain"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
@@ -1342,6 +1344,9 @@ func (ef *execFactory) ConstructInsert(
 	// Derive insert table and column descriptors.
 	rowsNeeded := !returnColOrdSet.Empty()
 	tabDesc := table.(*optTable).desc
+	if err := checkTableWritable(tabDesc); err != nil {
+		return nil, err
+	}
 	cols := makeColList(table, insertColOrdSet)
 
 	// Create the table inserter, which does the bulk of the work.
@@ -1414,6 +1419,9 @@ func (ef *execFactory) ConstructInsertFastPath(
 	// Derive insert table and column descriptors.
 	rowsNeeded := !returnColOrdSet.Empty()
 	tabDesc := table.(*optTable).desc
+	if err := checkTableWritable(tabDesc); err != nil {
+		return nil, err
+	}
 	cols := makeColList(table, insertColOrdSet)
 
 	// Create the table inserter, which does the bulk of the work.
@@ -1514,6 +1522,9 @@ func (ef *execFactory) ConstructUpdate(
 	// Derive table and column descriptors.
 	rowsNeeded := !returnColOrdSet.Empty()
 	tabDesc := table.(*optTable).desc
+	if err := checkTableWritable(tabDesc); err != nil {
+		return nil, err
+	}
 	fetchCols := makeColList(table, fetchColOrdSet)
 
 	// Add each column to update as a sourceSlot. The CBO only uses scalarSlot,
@@ -1624,6 +1635,9 @@ func (ef *execFactory) ConstructUpsert(
 	// Derive table and column descriptors.
 	rowsNeeded := !returnColOrdSet.Empty()
 	tabDesc := table.(*optTable).desc
+	if err := checkTableWritable(tabDesc); err != nil {
+		return nil, err
+	}
 	insertCols := makeColList(table, insertColOrdSet)
 	fetchCols := makeColList(table, fetchColOrdSet)
 	updateCols := makeColList(table, updateColOrdSet)
@@ -1720,6 +1734,9 @@ func (ef *execFactory) ConstructDelete(
 	// Derive table and column descriptors.
 	rowsNeeded := !returnColOrdSet.Empty()
 	tabDesc := table.(*optTable).desc
+	if err := checkTableWritable(tabDesc); err != nil {
+		return nil, err
+	}
 	fetchCols := makeColList(table, fetchColOrdSet)
 
 	// Create the table deleter, which does the bulk of the work. In the HP,
@@ -1783,6 +1800,9 @@ func (ef *execFactory) ConstructDeleteRange(
 	autoCommit bool,
 ) (exec.Node, error) {
 	tabDesc := table.(*optTable).desc
+	if err := checkTableWritable(tabDesc); err != nil {
+		return nil, err
+	}
 	var sb span.Builder
 	sb.Init(ef.planner.EvalContext(), ef.planner.ExecCfg().Codec, tabDesc, tabDesc.GetPrimaryIndex())
 
@@ -2253,3 +2273,13 @@ func makePublicToReturnColumnIndexMapping(
 ) []int {
 	return row.ColMapping(tableDesc.PublicColumns(), returnCols)
 }
+
+// checkTableWritable returns an error if rows cannot currently be written to
+// the table.
+func checkTableWritable(desc catalog.TableDescriptor) error {
+	if desc.IsOnlineImportInProgress() {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"table %q is read-only while an online IMPORT into it is in progress", desc.GetName())
+	}
+	return nil
+}
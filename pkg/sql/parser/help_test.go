@@ -95,6 +95,8 @@ func TestContextualHelp(t *testing.T) {
 		{`ALTER TENANT foo GRANT ??`, `ALTER VIRTUAL CLUSTER CAPABILITY`},
 		{`ALTER TENANT foo REVOKE ??`, `ALTER VIRTUAL CLUSTER CAPABILITY`},
 
+		{`ALTER VIRTUAL CLUSTER foo SET ADMISSION ??`, `ALTER VIRTUAL CLUSTER ADMISSION`},
+
 		{`ALTER VIRTUAL CLUSTER ??`, `ALTER VIRTUAL CLUSTER`},
 		{`ALTER TENANT ??`, `ALTER VIRTUAL CLUSTER`},
 
@@ -171,6 +173,10 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE INDEX blah ON bloh (x,y) STORING ??`, `CREATE INDEX`},
 		{`CREATE INDEX blah ON bloh (x) ??`, `CREATE INDEX`},
 
+		{`CREATE POLICY ??`, `CREATE POLICY`},
+		{`CREATE POLICY p ON t ??`, `CREATE POLICY`},
+		{`CREATE POLICY p ON t FOR SELECT USING ??`, `CREATE POLICY`},
+
 		{`CREATE DATABASE IF ??`, `CREATE DATABASE`},
 		{`CREATE DATABASE IF NOT ??`, `CREATE DATABASE`},
 		{`CREATE DATABASE blih ??`, `CREATE DATABASE`},
@@ -178,6 +184,8 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE EXTENSION ??`, `CREATE EXTENSION`},
 
 		{`CREATE EXTERNAL CONNECTION ??`, `CREATE EXTERNAL CONNECTION`},
+		{`CREATE PLAN BASELINE ??`, `CREATE PLAN BASELINE`},
+		{`CREATE PLAN BASELINE FOR 'a' USING ??`, `CREATE PLAN BASELINE`},
 
 		{`CREATE VIRTUAL CLUSTER ??`, `CREATE VIRTUAL CLUSTER`},
 		{`CREATE TENANT ??`, `CREATE VIRTUAL CLUSTER`},
@@ -228,7 +236,11 @@ func TestContextualHelp(t *testing.T) {
 		{`DROP INDEX blah, ??`, `DROP INDEX`},
 		{`DROP INDEX blah@blih ??`, `DROP INDEX`},
 
+		{`DROP POLICY ??`, `DROP POLICY`},
+		{`DROP POLICY p ON ??`, `DROP POLICY`},
+
 		{`DROP EXTERNAL CONNECTION blah ??`, `DROP EXTERNAL CONNECTION`},
+		{`DROP PLAN BASELINE ??`, `DROP PLAN BASELINE`},
 
 		{`DROP USER ??`, `DROP ROLE`},
 		{`DROP USER IF ??`, `DROP ROLE`},
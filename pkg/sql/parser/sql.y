@@ -260,6 +260,12 @@ func (u *sqlSymUnion) placeholder() *tree.Placeholder {
 func (u *sqlSymUnion) auditMode() tree.AuditMode {
     return u.val.(tree.AuditMode)
 }
+func (u *sqlSymUnion) policyType() tree.PolicyType {
+    return u.val.(tree.PolicyType)
+}
+func (u *sqlSymUnion) policyCommand() tree.PolicyCommand {
+    return u.val.(tree.PolicyCommand)
+}
 func (u *sqlSymUnion) bool() bool {
     return u.val.(bool)
 }
@@ -908,13 +914,13 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 // below; search this file for "Keyword category lists".
 
 // Ordinary key words in alphabetical order.
-%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADMIN AFTER AGGREGATE
+%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADMIN ADMISSION AFTER AGGREGATE
 %token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT
 %token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
 
-%token <str> BACKUP BACKUPS BACKWARD BATCH BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
+%token <str> BACKUP BACKUPS BACKWARD BASELINE BATCH BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
 %token <str> BUCKET_COUNT
-%token <str> BOOLEAN BOTH BOX2D BUNDLE BY
+%token <str> BOOLEAN BOTH BOX2D BUNDLE BY BYPASSRLS
 
 %token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CASCADE CASE CAST CBRT CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CHECK_FILES CLOSE
@@ -928,9 +934,9 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEBUG_IDS DEBUG_PAUSE_ON DEC DEBUG_DUMP_METADATA_SST DECIMAL DEFAULT DEFAULTS DEFINER
 %token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
-%token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
+%token <str> DISABLE DISCARD DISTINCT DO DOMAIN DOUBLE DROP
 
-%token <str> ELSE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
+%token <str> ELSE ENABLE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT EXPERIMENTAL_RELOCATE
@@ -946,7 +952,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> GEOMETRYCOLLECTION GEOMETRYCOLLECTIONM GEOMETRYCOLLECTIONZ GEOMETRYCOLLECTIONZM
 %token <str> GLOBAL GOAL GRANT GRANTEE GRANTS GREATEST GROUP GROUPING GROUPS
 
-%token <str> HAVING HASH HEADER HIGH HISTOGRAM HOLD HOUR
+%token <str> HAVING HASH HEADER HIGH HINTS HISTOGRAM HOLD HOUR
 
 %token <str> IDENTITY
 %token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMMEDIATELY IMMUTABLE IMPORT IN INCLUDE
@@ -959,7 +965,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 
 %token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS
 
-%token <str> KEY KEYS KMS KV
+%token <str> KEY KEYS KMS KMS_PER_DATABASE KV
 
 %token <str> LABEL LANGUAGE LAST LATERAL LATEST LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEAKPROOF LEFT LESS LEVEL LIKE LIMIT
@@ -971,7 +977,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
 
-%token <str> NAN NAME NAMES NATURAL NEVER NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
+%token <str> NAN NAME NAMES NATURAL NEVER NEW_DB_NAME NEW_KMS NEXT NO NOBYPASSRLS NOCANCELQUERY NOCONTROLCHANGEFEED
 %token <str> NOCONTROLJOB NOCREATEDB NOCREATELOGIN NOCREATEROLE NODE NOLOGIN NOMODIFYCLUSTERSETTING NOREPLICATION
 %token <str> NOSQLLOGIN NO_INDEX_JOIN NO_ZIGZAG_JOIN NO_FULL_SCAN NONE NONVOTERS NORMAL NOT
 %token <str> NOTHING NOTHING_AFTER_RETURNING
@@ -981,8 +987,8 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD_KMS ON ONLY OPT OPTION OPTIONS OR
 %token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
 
-%token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PER PHYSICAL PLACEMENT PLACING
-%token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLYGON POLYGONM POLYGONZ POLYGONZM
+%token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PER PERMISSIVE PHYSICAL PLACEMENT PLACING
+%token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLICY POLYGON POLYGONM POLYGONZ POLYGONZM
 %token <str> POSITION PRECEDING PRECISION PREPARE PRESERVE PRIMARY PRIOR PRIORITY PRIVILEGES
 %token <str> PROCEDURAL PROCEDURE PROCEDURES PUBLIC PUBLICATION
 
@@ -991,7 +997,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFRESH
 %token <str> REGCLASS REGION REGIONAL REGIONS REGNAMESPACE REGPROC REGPROCEDURE REGROLE REGTYPE REINDEX
 %token <str> RELATIVE RELOCATE REMOVE_PATH REMOVE_REGIONS RENAME REPEATABLE REPLACE REPLICATION
-%token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
+%token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESTRICTIVE RESULT_CACHE RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
 %token <str> REVOKE RIGHT ROLE ROLES ROLLBACK ROLLUP ROUTINES ROW ROWS RSHIFT RULE RUNNING
 
 %token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMA_ONLY SCHEMAS SCRUB
@@ -1016,7 +1022,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> VIEWCLUSTERMETADATA VIEWCLUSTERSETTING VIRTUAL VISIBLE INVISIBLE VISIBILITY VOLATILE VOTERS
 %token <str> VIRTUAL_CLUSTER_NAME VIRTUAL_CLUSTER
 
-%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
+%token <str> WEIGHT WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
 
 %token <str> YEAR
 
@@ -1107,6 +1113,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> alter_virtual_cluster_rename_stmt
 %type <tree.Statement> alter_virtual_cluster_reset_stmt
 %type <tree.Statement> alter_virtual_cluster_service_stmt
+%type <tree.Statement> alter_virtual_cluster_admission_stmt
 
 // ALTER PARTITION
 %type <tree.Statement> alter_zone_partition_stmt
@@ -1196,6 +1203,8 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> create_extension_stmt
 %type <tree.Statement> create_external_connection_stmt
 %type <tree.Statement> create_index_stmt
+%type <tree.Statement> create_plan_baseline_stmt
+%type <tree.Statement> create_policy_stmt
 %type <tree.Statement> create_role_stmt
 %type <tree.Statement> create_schedule_for_backup_stmt
 %type <tree.Statement> alter_backup_schedule
@@ -1224,6 +1233,8 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> drop_database_stmt
 %type <tree.Statement> drop_external_connection_stmt
 %type <tree.Statement> drop_index_stmt
+%type <tree.Statement> drop_plan_baseline_stmt
+%type <tree.Statement> drop_policy_stmt
 %type <tree.Statement> drop_role_stmt
 %type <tree.Statement> drop_schema_stmt
 %type <tree.Statement> drop_table_stmt
@@ -1462,7 +1473,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <empty> opt_privileges_clause
 %type <bool> distinct_clause opt_with_data
 %type <tree.DistinctOn> distinct_on_clause
-%type <tree.NameList> opt_column_list insert_column_list opt_stats_columns query_stats_cols
+%type <tree.NameList> opt_column_list insert_column_list opt_stats_kinds opt_stats_columns query_stats_cols
 // Note that "no index" variants exist to disable custom ORDER BY <index> syntax
 // in some places like function calls.
 %type <tree.OrderBy> sort_clause sort_clause_no_index single_sort_clause opt_sort_clause opt_sort_clause_no_index
@@ -1664,6 +1675,10 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <privilege.List> privileges
 %type <[]tree.KVOption> opt_role_options role_options
 %type <tree.AuditMode> audit_mode
+%type <tree.PolicyType> opt_policy_type
+%type <tree.PolicyCommand> opt_policy_command
+%type <tree.RoleSpecList> opt_policy_roles
+%type <tree.Expr> opt_policy_using opt_policy_with_check
 
 %type <str> relocate_kw
 %type <tree.RelocateSubject> relocate_subject relocate_subject_nonlease
@@ -1885,6 +1900,8 @@ alter_ddl_stmt:
 //   ALTER TABLE ... CONFIGURE ZONE <zoneconfig>
 //   ALTER TABLE ... SET SCHEMA <newschemaname>
 //   ALTER TABLE ... SET LOCALITY [REGIONAL BY [TABLE IN <region> | ROW] | GLOBAL]
+//   ALTER TABLE ... {ENABLE | DISABLE} ROW LEVEL SECURITY
+//   ALTER TABLE ... [NO] FORCE ROW LEVEL SECURITY
 //
 // Column qualifiers:
 //   [CONSTRAINT <constraintname>] {NULL | NOT NULL | UNIQUE | PRIMARY KEY | CHECK (<expr>) | DEFAULT <expr>}
@@ -2928,6 +2945,24 @@ alter_table_cmd:
   {
     $$.val = &tree.AlterTableSetAudit{Mode: $3.auditMode()}
   }
+  // ALTER TABLE <name> {ENABLE | DISABLE} ROW LEVEL SECURITY
+| ENABLE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableSetRowLevelSecurity{Mode: tree.RowLevelSecurityEnable}
+  }
+| DISABLE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableSetRowLevelSecurity{Mode: tree.RowLevelSecurityDisable}
+  }
+  // ALTER TABLE <name> [NO] FORCE ROW LEVEL SECURITY
+| FORCE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableSetRowLevelSecurity{Mode: tree.RowLevelSecurityForce}
+  }
+| NO FORCE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableSetRowLevelSecurity{Mode: tree.RowLevelSecurityNoForce}
+  }
   // ALTER TABLE <name> PARTITION BY ...
 | partition_by_table
   {
@@ -3303,6 +3338,7 @@ opt_clear_data:
 //    revision_history: enable revision history
 //    encryption_passphrase="secret": encrypt backups
 //    kms="[kms_provider]://[kms_host]/[master_key_identifier]?[parameters]" : encrypt backups using KMS
+//    kms_per_database=("<database>=<kms uri>", ...): encrypt the data of the given databases using a separate KMS
 //    detached: execute backup job asynchronously, without waiting for its completion
 //    incremental_location: specify a different path to store the incremental backup
 //    include_all_virtual_clusters: enable backups of all virtual clusters during a cluster backup
@@ -3423,6 +3459,10 @@ backup_options:
   {
     $$.val = &tree.BackupOptions{EncryptionKMSURI: $3.stringOrPlaceholderOptList()}
   }
+| KMS_PER_DATABASE '=' string_or_placeholder_opt_list
+  {
+    $$.val = &tree.BackupOptions{EncryptionKMSURIPerDatabase: $3.stringOrPlaceholderOptList()}
+  }
 | INCREMENTAL_LOCATION '=' string_or_placeholder_opt_list
   {
     $$.val = &tree.BackupOptions{IncrementalStorage: $3.stringOrPlaceholderOptList()}
@@ -3741,6 +3781,55 @@ drop_external_connection_stmt:
 	}
 	| DROP EXTERNAL CONNECTION error // SHOW HELP: DROP EXTERNAL CONNECTION
 
+// %Help: CREATE PLAN BASELINE - pin the query plan of a statement fingerprint
+// %Category: Misc
+// %Text:
+// CREATE PLAN BASELINE FOR <fingerprint> USING PLAN <plan gist>
+// CREATE PLAN BASELINE FOR <fingerprint> USING HINTS <hints>
+//
+// Fingerprint:
+//   Statement fingerprint, as shown in crdb_internal.statement_statistics.
+//
+// Plan gist:
+//   Plan gist whose indexes, join order and join algorithms are reproduced,
+//   as shown by EXPLAIN (GIST).
+//
+// Hints:
+//   Comma-separated list of table@index hints, for example 't@t_a_idx'. Only
+//   the indexes are pinned; the join order and the join algorithms are still
+//   chosen by the optimizer.
+create_plan_baseline_stmt:
+	CREATE PLAN BASELINE FOR string_or_placeholder USING PLAN string_or_placeholder
+	{
+		$$.val = &tree.CreatePlanBaseline{
+			Fingerprint: $5.expr(),
+			PlanGist:    $8.expr(),
+		}
+	}
+| CREATE PLAN BASELINE FOR string_or_placeholder USING HINTS string_or_placeholder
+	{
+		$$.val = &tree.CreatePlanBaseline{
+			Fingerprint: $5.expr(),
+			Hints:       $8.expr(),
+		}
+	}
+| CREATE PLAN BASELINE error // SHOW HELP: CREATE PLAN BASELINE
+
+// %Help: DROP PLAN BASELINE - remove the plan baseline of a statement fingerprint
+// %Category: Misc
+// %Text:
+// DROP PLAN BASELINE [IF EXISTS] FOR <fingerprint>
+drop_plan_baseline_stmt:
+	DROP PLAN BASELINE FOR string_or_placeholder
+	{
+		$$.val = &tree.DropPlanBaseline{Fingerprint: $5.expr()}
+	}
+| DROP PLAN BASELINE IF EXISTS FOR string_or_placeholder
+	{
+		$$.val = &tree.DropPlanBaseline{Fingerprint: $7.expr(), IfExists: true}
+	}
+| DROP PLAN BASELINE error // SHOW HELP: DROP PLAN BASELINE
+
 // %Help: RESTORE - restore data from external storage
 // %Category: CCL
 // %Text:
@@ -3768,6 +3857,7 @@ drop_external_connection_stmt:
 //    skip_missing_udfs: skip restoring
 //    encryption_passphrase=passphrase: decrypt BACKUP with specified passphrase
 //    kms="[kms_provider]://[kms_host]/[master_key_identifier]?[parameters]" : decrypt backups using KMS
+//    kms_per_database=("<database>=<kms uri>", ...): decrypt the data of the given databases using a separate KMS
 //    detached: execute restore job asynchronously, without waiting for its completion
 //    skip_localities_check: ignore difference of zone configuration between restore cluster and backup cluster
 //    debug_pause_on: describes the events that the job should pause itself on for debugging purposes.
@@ -3892,6 +3982,10 @@ restore_options:
 	{
     $$.val = &tree.RestoreOptions{DecryptionKMSURI: $3.stringOrPlaceholderOptList()}
 	}
+| KMS_PER_DATABASE '=' string_or_placeholder_opt_list
+  {
+    $$.val = &tree.RestoreOptions{DecryptionKMSURIPerDatabase: $3.stringOrPlaceholderOptList()}
+  }
 | INTO_DB '=' string_or_placeholder
   {
     $$.val = &tree.RestoreOptions{IntoDB: $3.expr()}
@@ -4055,6 +4149,8 @@ import_stmt:
 // Formats:
 //    CSV
 //    Parquet
+//    Arrow
+//    JSONL
 //
 // Options:
 //    delimiter = '...'   [CSV-specific]
@@ -4268,9 +4364,9 @@ copy_options:
   {
     return unimplementedWithIssueDetail(sqllex, 41608, "oids")
   }
-| FREEZE error
+| FREEZE
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "freeze")
+    $$.val = &tree.CopyOptions{Freeze: true, HasFreeze: true}
   }
 | HEADER
   {
@@ -4284,17 +4380,29 @@ copy_options:
   {
     $$.val = &tree.CopyOptions{Escape: tree.NewStrVal($2)}
   }
-| FORCE QUOTE error
+| FORCE QUOTE name_list
+  {
+    $$.val = &tree.CopyOptions{ForceQuote: &tree.CopyColumnList{Columns: $3.nameList()}}
+  }
+| FORCE QUOTE '*'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_quote")
+    $$.val = &tree.CopyOptions{ForceQuote: &tree.CopyColumnList{All: true}}
   }
-| FORCE NOT NULL error
+| FORCE NOT NULL name_list
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_not_null")
+    $$.val = &tree.CopyOptions{ForceNotNull: &tree.CopyColumnList{Columns: $4.nameList()}}
   }
-| FORCE NULL error
+| FORCE NOT NULL '*'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_null")
+    $$.val = &tree.CopyOptions{ForceNotNull: &tree.CopyColumnList{All: true}}
+  }
+| FORCE NULL name_list
+  {
+    $$.val = &tree.CopyOptions{ForceNull: &tree.CopyColumnList{Columns: $3.nameList()}}
+  }
+| FORCE NULL '*'
+  {
+    $$.val = &tree.CopyOptions{ForceNull: &tree.CopyColumnList{All: true}}
   }
 | ENCODING SCONST
   {
@@ -4345,9 +4453,17 @@ copy_generic_options:
   {
     return unimplementedWithIssueDetail(sqllex, 41608, "oids")
   }
-| FREEZE error
+| FREEZE
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "freeze")
+    $$.val = &tree.CopyOptions{Freeze: true, HasFreeze: true}
+  }
+| FREEZE TRUE
+  {
+    $$.val = &tree.CopyOptions{Freeze: true, HasFreeze: true}
+  }
+| FREEZE FALSE
+  {
+    $$.val = &tree.CopyOptions{Freeze: false, HasFreeze: true}
   }
 | HEADER
   {
@@ -4369,17 +4485,29 @@ copy_generic_options:
   {
     $$.val = &tree.CopyOptions{Escape: tree.NewStrVal($2)}
   }
-| FORCE_QUOTE error
+| FORCE_QUOTE '(' name_list ')'
+  {
+    $$.val = &tree.CopyOptions{ForceQuote: &tree.CopyColumnList{Columns: $3.nameList()}}
+  }
+| FORCE_QUOTE '*'
+  {
+    $$.val = &tree.CopyOptions{ForceQuote: &tree.CopyColumnList{All: true}}
+  }
+| FORCE_NOT_NULL '(' name_list ')'
+  {
+    $$.val = &tree.CopyOptions{ForceNotNull: &tree.CopyColumnList{Columns: $3.nameList()}}
+  }
+| FORCE_NOT_NULL '*'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_quote")
+    $$.val = &tree.CopyOptions{ForceNotNull: &tree.CopyColumnList{All: true}}
   }
-| FORCE_NOT_NULL error
+| FORCE_NULL '(' name_list ')'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_not_null")
+    $$.val = &tree.CopyOptions{ForceNull: &tree.CopyColumnList{Columns: $3.nameList()}}
   }
-| FORCE_NULL error
+| FORCE_NULL '*'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_null")
+    $$.val = &tree.CopyOptions{ForceNull: &tree.CopyColumnList{All: true}}
   }
 | ENCODING SCONST
   {
@@ -4573,6 +4701,7 @@ create_stmt:
 | create_changefeed_stmt // EXTEND WITH HELP: CREATE CHANGEFEED
 | create_extension_stmt  // EXTEND WITH HELP: CREATE EXTENSION
 | create_external_connection_stmt // EXTEND WITH HELP: CREATE EXTERNAL CONNECTION
+| create_plan_baseline_stmt       // EXTEND WITH HELP: CREATE PLAN BASELINE
 | create_virtual_cluster_stmt     // EXTEND WITH HELP: CREATE VIRTUAL CLUSTER
 | create_schedule_stmt   // help texts in sub-rule
 | create_unsupported     {}
@@ -5278,6 +5407,7 @@ drop_unsupported:
 create_ddl_stmt:
   create_database_stmt // EXTEND WITH HELP: CREATE DATABASE
 | create_index_stmt    // EXTEND WITH HELP: CREATE INDEX
+| create_policy_stmt   // EXTEND WITH HELP: CREATE POLICY
 | create_schema_stmt   // EXTEND WITH HELP: CREATE SCHEMA
 | create_table_stmt    // EXTEND WITH HELP: CREATE TABLE
 | create_table_as_stmt // EXTEND WITH HELP: CREATE TABLE
@@ -5292,21 +5422,36 @@ create_ddl_stmt:
 // %Help: CREATE STATISTICS - create a new table statistic
 // %Category: Misc
 // %Text:
-// CREATE STATISTICS <statisticname>
+// CREATE STATISTICS <statisticname> [(<kind> [, ...])]
 //   [ON <colname> [, ...]]
 //   FROM <tablename> [AS OF SYSTEM TIME <expr>]
+//
+// Kinds:
+//   dependencies: functional dependencies between the columns
+//   mcv:          most common combinations of values of the columns
 create_stats_stmt:
-  CREATE STATISTICS statistics_name opt_stats_columns FROM create_stats_target opt_create_stats_options
+  CREATE STATISTICS statistics_name opt_stats_kinds opt_stats_columns FROM create_stats_target opt_create_stats_options
   {
     $$.val = &tree.CreateStats{
       Name: tree.Name($3),
-      ColumnNames: $4.nameList(),
-      Table: $6.tblExpr(),
-      Options: *$7.createStatsOptions(),
+      Kinds: $4.nameList(),
+      ColumnNames: $5.nameList(),
+      Table: $7.tblExpr(),
+      Options: *$8.createStatsOptions(),
     }
   }
 | CREATE STATISTICS error // SHOW HELP: CREATE STATISTICS
 
+opt_stats_kinds:
+  '(' name_list ')'
+  {
+    $$.val = $2.nameList()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.NameList(nil)
+  }
+
 opt_stats_columns:
   ON name_list
   {
@@ -5660,6 +5805,7 @@ drop_stmt:
 | drop_role_stmt                // EXTEND WITH HELP: DROP ROLE
 | drop_schedule_stmt            // EXTEND WITH HELP: DROP SCHEDULES
 | drop_external_connection_stmt // EXTEND WITH HELP: DROP EXTERNAL CONNECTION
+| drop_plan_baseline_stmt       // EXTEND WITH HELP: DROP PLAN BASELINE
 | drop_virtual_cluster_stmt     // EXTEND WITH HELP: DROP VIRTUAL CLUSTER
 | drop_unsupported   {}
 | DROP error                    // SHOW HELP: DROP
@@ -5667,6 +5813,7 @@ drop_stmt:
 drop_ddl_stmt:
   drop_database_stmt // EXTEND WITH HELP: DROP DATABASE
 | drop_index_stmt    // EXTEND WITH HELP: DROP INDEX
+| drop_policy_stmt   // EXTEND WITH HELP: DROP POLICY
 | drop_table_stmt    // EXTEND WITH HELP: DROP TABLE
 | drop_view_stmt     // EXTEND WITH HELP: DROP VIEW
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
@@ -5763,6 +5910,30 @@ drop_index_stmt:
   }
 | DROP INDEX error // SHOW HELP: DROP INDEX
 
+// %Help: DROP POLICY - remove a row-level security policy
+// %Category: DDL
+// %Text: DROP POLICY [IF EXISTS] <policyname> ON <tablename> [CASCADE | RESTRICT]
+// %SeeAlso: CREATE POLICY
+drop_policy_stmt:
+  DROP POLICY name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropPolicy{
+      PolicyName: tree.Name($3),
+      TableName: $5.unresolvedObjectName(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP POLICY IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropPolicy{
+      PolicyName: tree.Name($5),
+      TableName: $7.unresolvedObjectName(),
+      IfExists: true,
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP POLICY error // SHOW HELP: DROP POLICY
+
 // %Help: DROP DATABASE - remove a database
 // %Category: DDL
 // %Text: DROP DATABASE [IF EXISTS] <databasename> [CASCADE | RESTRICT]
@@ -6884,7 +7055,8 @@ set_csetting_stmt:
 // %Text:
 // ALTER VIRTUAL CLUSTER REPLICATION, ALTER VIRTUAL CLUSTER SETTING,
 // ALTER VIRTUAL CLUSTER CAPABILITY, ALTER VIRTUAL CLUSTER RENAME,
-// ALTER VIRTUAL CLUSTER RESET, ALTER VIRTUAL CLUSTER SERVICE
+// ALTER VIRTUAL CLUSTER RESET, ALTER VIRTUAL CLUSTER SERVICE,
+// ALTER VIRTUAL CLUSTER ADMISSION
 alter_virtual_cluster_stmt:
   alter_virtual_cluster_replication_stmt // EXTEND WITH HELP: ALTER VIRTUAL CLUSTER REPLICATION
 | alter_virtual_cluster_csetting_stmt    // EXTEND WITH HELP: ALTER VIRTUAL CLUSTER SETTING
@@ -6892,6 +7064,7 @@ alter_virtual_cluster_stmt:
 | alter_virtual_cluster_rename_stmt      // EXTEND WITH HELP: ALTER VIRTUAL CLUSTER RENAME
 | alter_virtual_cluster_reset_stmt       // EXTEND WITH HELP: ALTER VIRTUAL CLUSTER RESET
 | alter_virtual_cluster_service_stmt     // EXTEND WITH HELP: ALTER VIRTUAL CLUSTER SERVICE
+| alter_virtual_cluster_admission_stmt   // EXTEND WITH HELP: ALTER VIRTUAL CLUSTER ADMISSION
 | ALTER virtual_cluster error   // SHOW HELP: ALTER VIRTUAL CLUSTER
 
 virtual_cluster_spec:
@@ -6963,6 +7136,30 @@ alter_virtual_cluster_service_stmt:
 | ALTER virtual_cluster virtual_cluster_spec START error // SHOW HELP: ALTER VIRTUAL CLUSTER SERVICE
 | ALTER virtual_cluster virtual_cluster_spec STOP error // SHOW HELP: ALTER VIRTUAL CLUSTER SERVICE
 
+// %Help: ALTER VIRTUAL CLUSTER ADMISSION - alter admission control configuration of a virtual cluster
+// %Category: Experimental
+// %Text:
+// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> SET ADMISSION WEIGHT { TO | = } <weight>
+// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> SET ADMISSION PRIORITY { TO | = } { 'premium' | 'standard' | 'best_effort' }
+alter_virtual_cluster_admission_stmt:
+  ALTER virtual_cluster virtual_cluster_spec SET ADMISSION WEIGHT to_or_eq a_expr
+  {
+    /* SKIP DOC */
+    $$.val = &tree.AlterTenantAdmission{
+      TenantSpec: $3.tenantSpec(),
+      Weight: $8.expr(),
+    }
+  }
+| ALTER virtual_cluster virtual_cluster_spec SET ADMISSION PRIORITY to_or_eq a_expr
+  {
+    /* SKIP DOC */
+    $$.val = &tree.AlterTenantAdmission{
+      TenantSpec: $3.tenantSpec(),
+      PriorityClass: $8.expr(),
+    }
+  }
+| ALTER virtual_cluster virtual_cluster_spec SET ADMISSION error // SHOW HELP: ALTER VIRTUAL CLUSTER ADMISSION
+
 
 // %Help: ALTER VIRTUAL CLUSTER REPLICATION - alter replication stream between virtual clusters
 // %Category: Experimental
@@ -11147,6 +11344,14 @@ role_option:
   {
     $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
   }
+| BYPASSRLS
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
+  }
+| NOBYPASSRLS
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
+  }
 
 role_options:
   role_option
@@ -11293,6 +11498,102 @@ composite_type_list:
     )
   }
 
+// %Help: CREATE POLICY - create a row-level security policy
+// %Category: DDL
+// %Text:
+// CREATE POLICY <policyname> ON <tablename>
+//        [AS {PERMISSIVE | RESTRICTIVE}]
+//        [FOR {ALL | SELECT | INSERT | UPDATE | DELETE}]
+//        [TO <rolename> [, ...]]
+//        [USING ( <expr> )]
+//        [WITH CHECK ( <expr> )]
+//
+// %SeeAlso: DROP POLICY, ALTER TABLE
+create_policy_stmt:
+  CREATE POLICY name ON table_name opt_policy_type opt_policy_command opt_policy_roles opt_policy_using opt_policy_with_check
+  {
+    $$.val = &tree.CreatePolicy{
+      PolicyName: tree.Name($3),
+      TableName: $5.unresolvedObjectName(),
+      Type: $6.policyType(),
+      Cmd: $7.policyCommand(),
+      Roles: $8.roleSpecList(),
+      Using: $9.expr(),
+      WithCheck: $10.expr(),
+    }
+  }
+| CREATE POLICY error // SHOW HELP: CREATE POLICY
+
+opt_policy_type:
+  AS PERMISSIVE
+  {
+    $$.val = tree.PolicyTypePermissive
+  }
+| AS RESTRICTIVE
+  {
+    $$.val = tree.PolicyTypeRestrictive
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.PolicyTypeDefault
+  }
+
+opt_policy_command:
+  FOR ALL
+  {
+    $$.val = tree.PolicyCommandAll
+  }
+| FOR SELECT
+  {
+    $$.val = tree.PolicyCommandSelect
+  }
+| FOR INSERT
+  {
+    $$.val = tree.PolicyCommandInsert
+  }
+| FOR UPDATE
+  {
+    $$.val = tree.PolicyCommandUpdate
+  }
+| FOR DELETE
+  {
+    $$.val = tree.PolicyCommandDelete
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.PolicyCommandDefault
+  }
+
+opt_policy_roles:
+  TO role_spec_list
+  {
+    $$.val = $2.roleSpecList()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.RoleSpecList(nil)
+  }
+
+opt_policy_using:
+  USING '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+opt_policy_with_check:
+  WITH CHECK '(' a_expr ')'
+  {
+    $$.val = $4.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
 // %Help: CREATE INDEX - create a new index
 // %Category: DDL
 // %Text:
@@ -13507,6 +13808,10 @@ index_flags_param:
     /* SKIP DOC */
      $$.val = &tree.IndexFlags{ForceInvertedIndex: true}
   }
+| RESULT_CACHE
+  {
+    $$.val = &tree.IndexFlags{ResultCache: true}
+  }
 | FORCE_ZIGZAG
   {
      $$.val = &tree.IndexFlags{ForceZigzag: true}
@@ -13588,6 +13893,7 @@ opt_index_flags:
 //   '{' NO_FULL_SCAN [, ...] '}'
 //   '{' IGNORE_FOREIGN_KEYS [, ...] '}'
 //   '{' FORCE_ZIGZAG = <idxname> [, ...]  '}'
+//   '{' RESULT_CACHE [, ...] '}'
 //
 // Join types:
 //   { INNER | { LEFT | RIGHT | FULL } [OUTER] } [ { HASH | MERGE | LOOKUP | INVERTED | STRAIGHT } ]
@@ -17026,6 +17332,7 @@ unreserved_keyword:
 | ACCESS
 | ADD
 | ADMIN
+| ADMISSION
 | AFTER
 | AGGREGATE
 | ALTER
@@ -17040,6 +17347,7 @@ unreserved_keyword:
 | BACKUP
 | BACKUPS
 | BACKWARD
+| BASELINE
 | BATCH
 | BEFORE
 | BEGIN
@@ -17047,6 +17355,7 @@ unreserved_keyword:
 | BUCKET_COUNT
 | BUNDLE
 | BY
+| BYPASSRLS
 | CACHE
 | CALL
 | CALLED
@@ -17108,10 +17417,12 @@ unreserved_keyword:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DISABLE
 | DISCARD
 | DOMAIN
 | DOUBLE
 | DROP
+| ENABLE
 | ENCODING
 | ENCRYPTED
 | ENCRYPTION_PASSPHRASE
@@ -17167,6 +17478,7 @@ unreserved_keyword:
 | HASH
 | HEADER
 | HIGH
+| HINTS
 | HISTOGRAM
 | HOLD
 | HOUR
@@ -17199,6 +17511,7 @@ unreserved_keyword:
 | KEY
 | KEYS
 | KMS
+| KMS_PER_DATABASE
 | KV
 | LABEL
 | LANGUAGE
@@ -17256,6 +17569,7 @@ unreserved_keyword:
 | NO_INDEX_JOIN
 | NO_ZIGZAG_JOIN
 | NO_FULL_SCAN
+| NOBYPASSRLS
 | NOCREATEDB
 | NOCREATELOGIN
 | NOCANCELQUERY
@@ -17297,6 +17611,7 @@ unreserved_keyword:
 | PAUSE
 | PAUSED
 | PER
+| PERMISSIVE
 | PHYSICAL
 | PLACEMENT
 | PLAN
@@ -17304,6 +17619,7 @@ unreserved_keyword:
 | POINTM
 | POINTZ
 | POINTZM
+| POLICY
 | POLYGONM
 | POLYGONZ
 | POLYGONZM
@@ -17347,6 +17663,8 @@ unreserved_keyword:
 | RESTORE
 | RESTRICT
 | RESTRICTED
+| RESTRICTIVE
+| RESULT_CACHE
 | RESUME
 | RETENTION
 | RETRY
@@ -17482,6 +17800,7 @@ unreserved_keyword:
 | VISIBILITY
 | VOLATILE
 | VOTERS
+| WEIGHT
 | WITHIN
 | WITHOUT
 | WRITE
@@ -17499,6 +17818,7 @@ bare_label_keywords:
 | ACTION
 | ADD
 | ADMIN
+| ADMISSION
 | AFTER
 | AGGREGATE
 | ALL
@@ -17522,6 +17842,7 @@ bare_label_keywords:
 | BACKUP
 | BACKUPS
 | BACKWARD
+| BASELINE
 | BATCH
 | BEFORE
 | BEGIN
@@ -17535,6 +17856,7 @@ bare_label_keywords:
 | BUCKET_COUNT
 | BUNDLE
 | BY
+| BYPASSRLS
 | CACHE
 | CALL
 | CALLED
@@ -17617,6 +17939,7 @@ bare_label_keywords:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DISABLE
 | DISCARD
 | DISTINCT
 | DO
@@ -17624,6 +17947,7 @@ bare_label_keywords:
 | DOUBLE
 | DROP
 | ELSE
+| ENABLE
 | ENCODING
 | ENCRYPTED
 | ENCRYPTION_INFO_DIR
@@ -17691,6 +18015,7 @@ bare_label_keywords:
 | HASH
 | HEADER
 | HIGH
+| HINTS
 | HISTOGRAM
 | HOLD
 | IDENTITY
@@ -17741,6 +18066,7 @@ bare_label_keywords:
 | KEY
 | KEYS
 | KMS
+| KMS_PER_DATABASE
 | KV
 | LABEL
 | LANGUAGE
@@ -17799,6 +18125,7 @@ bare_label_keywords:
 | NEW_KMS
 | NEXT
 | NO
+| NOBYPASSRLS
 | NOCANCELQUERY
 | NOCONTROLCHANGEFEED
 | NOCONTROLJOB
@@ -17853,6 +18180,7 @@ bare_label_keywords:
 | PAUSE
 | PAUSED
 | PER
+| PERMISSIVE
 | PHYSICAL
 | PLACEMENT
 | PLACING
@@ -17862,6 +18190,7 @@ bare_label_keywords:
 | POINTM
 | POINTZ
 | POINTZM
+| POLICY
 | POLYGON
 | POLYGONM
 | POLYGONZ
@@ -17910,6 +18239,8 @@ bare_label_keywords:
 | RESTORE
 | RESTRICT
 | RESTRICTED
+| RESTRICTIVE
+| RESULT_CACHE
 | RESUME
 | RETENTION
 | RETRY
@@ -18072,6 +18403,7 @@ bare_label_keywords:
 | VISIBILITY
 | VOLATILE
 | VOTERS
+| WEIGHT
 | WHEN
 | WORK
 | WRITE
@@ -406,6 +406,20 @@ func (r *commandResult) SendCopyData(ctx context.Context, copyData []byte, isHea
 	return nil
 }
 
+// SendCopyBinaryRow is part of the sql.CopyOutResult interface.
+func (r *commandResult) SendCopyBinaryRow(
+	ctx context.Context, row tree.Datums, cols colinfo.ResultColumns,
+) error {
+	if err := r.beforeAdd(); err != nil {
+		return err
+	}
+	if err := r.conn.bufferCopyBinaryRow(ctx, row, cols, r); err != nil {
+		return err
+	}
+	r.rowsAffected++
+	return nil
+}
+
 // SendCopyDone is part of the pgwirebase.Conn interface.
 func (r *commandResult) SendCopyDone(ctx context.Context) error {
 	r.assertNotReleased()
@@ -1184,6 +1184,27 @@ func (c *conn) bufferCopyData(copyData []byte, res *commandResult) error {
 	return nil
 }
 
+// bufferCopyBinaryRow serializes a row in the binary COPY format, which
+// encodes each value the same way as a DataRow message does, and adds it to
+// the buffer as a CopyData message.
+func (c *conn) bufferCopyBinaryRow(
+	ctx context.Context, row tree.Datums, cols colinfo.ResultColumns, r *commandResult,
+) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDataCommand)
+	c.msgBuilder.putInt16(int16(len(row)))
+	for i, col := range row {
+		c.msgBuilder.writeBinaryDatum(ctx, col, r.location, cols[i].Typ)
+	}
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		return err
+	}
+	if err := c.maybeFlush(r.pos, r.bufferingDisabled); err != nil {
+		return err
+	}
+	c.maybeReallocate()
+	return nil
+}
+
 func (c *conn) bufferCopyDone() error {
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDoneCommand)
 	return c.msgBuilder.finishMsg(&c.writerState.buf)
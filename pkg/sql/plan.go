@@ -18,9 +18,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -309,6 +311,13 @@ type planTop struct {
 	mem     *memo.Memo
 	catalog optPlanningCatalog
 
+	// resultCacheDeps is non-nil if the results of the plan can be stored in
+	// the result cache, in which case it contains the tables read by the plan,
+	// sorted by ID, and resultCacheReads contains the spans of their indexes
+	// that the plan reads. See resultCacheDeps.
+	resultCacheDeps  []cat.DataSource
+	resultCacheReads []resultcache.IndexSpans
+
 	// auditEventBuilders becomes non-nil if the current statement
 	// is eligible for auditing (see sql/audit_logging.go)
 	auditEventBuilders []auditlogging.AuditEventBuilder
@@ -430,6 +439,11 @@ type planComponents struct {
 	// return, negative if the stats weren't available to make a good estimate.
 	mainRowCount int64
 
+	// mainDistributionCosts are the estimated costs of running the main query
+	// locally and distributed. It is nil if cost-based distribution is disabled
+	// or the stats weren't available to make a good estimate.
+	mainDistributionCosts *planDistributionCosts
+
 	// cascades contains metadata for all cascades.
 	cascades []cascadeMetadata
 
@@ -629,6 +643,11 @@ const (
 	// planFlagSessionMigration is set if the plan is being created during
 	// a session migration.
 	planFlagSessionMigration
+
+	// planFlagCustomPlan is set if the plan was optimized for the placeholder
+	// values of this execution of a prepared statement, as opposed to a generic
+	// plan or a prepared memo that was already fully optimized.
+	planFlagCustomPlan
 )
 
 func (pf planFlags) IsSet(flag planFlags) bool {
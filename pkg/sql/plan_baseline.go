@@ -0,0 +1,119 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec/explain"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/planbaseline"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	createPlanBaselineOp = "CREATE PLAN BASELINE"
+	dropPlanBaselineOp   = "DROP PLAN BASELINE"
+)
+
+type createPlanBaselineNode struct {
+	n *tree.CreatePlanBaseline
+}
+
+// CreatePlanBaseline represents a CREATE PLAN BASELINE statement.
+func (p *planner) CreatePlanBaseline(
+	ctx context.Context, n *tree.CreatePlanBaseline,
+) (planNode, error) {
+	if err := p.checkPlanBaselinePrivilege(ctx); err != nil {
+		return nil, err
+	}
+	return &createPlanBaselineNode{n: n}, nil
+}
+
+func (c *createPlanBaselineNode) startExec(params runParams) error {
+	p := params.p
+	eval := p.ExprEvaluator(createPlanBaselineOp)
+	var b planbaseline.Baseline
+	var err error
+	if b.Fingerprint, err = eval.String(params.ctx, c.n.Fingerprint); err != nil {
+		return err
+	}
+	if b.Fingerprint == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "statement fingerprint must not be empty")
+	}
+	if c.n.PlanGist != nil {
+		if b.PlanGist, err = eval.String(params.ctx, c.n.PlanGist); err != nil {
+			return err
+		}
+		if _, err := explain.DecodePlanGistToIndexAccesses(b.PlanGist, p.optPlanningCtx.catalog); err != nil {
+			return pgerror.Wrapf(err, pgcode.InvalidParameterValue, "invalid plan gist %q", b.PlanGist)
+		}
+	} else {
+		if b.Hints, err = eval.String(params.ctx, c.n.Hints); err != nil {
+			return err
+		}
+		if _, err := planbaseline.ParseHints(b.Hints); err != nil {
+			return err
+		}
+	}
+	return p.execCfg.PlanBaselines.CreateBaseline(params.ctx, b)
+}
+
+func (c *createPlanBaselineNode) Next(_ runParams) (bool, error) { return false, nil }
+func (c *createPlanBaselineNode) Values() tree.Datums            { return nil }
+func (c *createPlanBaselineNode) Close(_ context.Context)        {}
+
+type dropPlanBaselineNode struct {
+	n *tree.DropPlanBaseline
+}
+
+// DropPlanBaseline represents a DROP PLAN BASELINE statement.
+func (p *planner) DropPlanBaseline(
+	ctx context.Context, n *tree.DropPlanBaseline,
+) (planNode, error) {
+	if err := p.checkPlanBaselinePrivilege(ctx); err != nil {
+		return nil, err
+	}
+	return &dropPlanBaselineNode{n: n}, nil
+}
+
+func (d *dropPlanBaselineNode) startExec(params runParams) error {
+	fingerprint, err := params.p.ExprEvaluator(dropPlanBaselineOp).String(
+		params.ctx, d.n.Fingerprint,
+	)
+	if err != nil {
+		return err
+	}
+	found, err := params.p.execCfg.PlanBaselines.DropBaseline(params.ctx, fingerprint)
+	if err != nil {
+		return errors.Wrap(err, "failed to drop plan baseline")
+	}
+	if !found && !d.n.IfExists {
+		return pgerror.Newf(pgcode.UndefinedObject,
+			"plan baseline for fingerprint %q does not exist", fingerprint)
+	}
+	return nil
+}
+
+func (d *dropPlanBaselineNode) Next(_ runParams) (bool, error) { return false, nil }
+func (d *dropPlanBaselineNode) Values() tree.Datums            { return nil }
+func (d *dropPlanBaselineNode) Close(_ context.Context)        {}
+
+// checkPlanBaselinePrivilege checks that the current user may manage plan
+// baselines. Since baselines affect the plans of all sessions, this requires
+// the same privilege as changing cluster settings.
+func (p *planner) checkPlanBaselinePrivilege(ctx context.Context) error {
+	return p.CheckPrivilege(ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.MODIFYCLUSTERSETTING)
+}
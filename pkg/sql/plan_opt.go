@@ -33,12 +33,14 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/planbaseline"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
@@ -144,7 +146,7 @@ func (p *planner) prepareUsingOptimizer(
 			if !pm.TypeHints.Identical(p.semaCtx.Placeholders.TypeHints) {
 				opc.log(ctx, "query cache hit but type hints don't match")
 			} else {
-				isStale, err := cachedData.Memo.IsStale(ctx, p.EvalContext(), opc.catalog)
+				isStale, err := opc.isStale(ctx, cachedData.Memo)
 				if err != nil {
 					return 0, err
 				}
@@ -349,6 +351,12 @@ type optPlanningCtx struct {
 	// allowMemoReuse is false.
 	useCache bool
 
+	// planBaseline is the plan baseline matching the statement's fingerprint,
+	// if any. When set, the indexes recorded in the baseline are pinned while
+	// building the memo, and the joins recorded in its plan gist are pinned
+	// while optimizing it.
+	planBaseline *planbaseline.Baseline
+
 	flags planFlags
 }
 
@@ -397,6 +405,197 @@ func (opc *optPlanningCtx) reset(ctx context.Context) {
 		opc.allowMemoReuse = false
 		opc.useCache = false
 	}
+
+	opc.planBaseline = nil
+	if p.execCfg.PlanBaselines.Active() {
+		if fingerprint, ok := opc.planBaselineFingerprint(); ok {
+			if b, ok := p.execCfg.PlanBaselines.Lookup(fingerprint); ok {
+				opc.planBaseline = &b
+			}
+		}
+	}
+}
+
+// planBaselineVersion returns the version of the plan baseline applied to the
+// statement, or the empty string if there is none. Memos are tagged with it
+// (see memo.Memo.SetPinnedVersion), so that cached memos are rebuilt when a
+// baseline is created, replaced or dropped.
+func (opc *optPlanningCtx) planBaselineVersion() string {
+	if opc.planBaseline == nil {
+		return ""
+	}
+	return opc.planBaseline.Version()
+}
+
+// isStale returns true if the given cached memo must be rebuilt, either
+// because it was invalidated by schema or other changes, or because it was
+// built with a different plan baseline.
+func (opc *optPlanningCtx) isStale(ctx context.Context, m *memo.Memo) (bool, error) {
+	if m.PinnedVersion() != opc.planBaselineVersion() {
+		return true, nil
+	}
+	return m.IsStale(ctx, opc.p.EvalContext(), opc.catalog)
+}
+
+// applyPlanBaseline pins the access paths of the plan baseline applied to the
+// statement, if any, when building the memo with the given builder. The memo
+// must be tagged with planBaselineVersion after it is built.
+func (opc *optPlanningCtx) applyPlanBaseline(ctx context.Context, bld *optbuilder.Builder) {
+	if opc.planBaseline == nil {
+		return
+	}
+	opc.log(ctx, "applying plan baseline")
+	bld.PinnedIndexFlags = opc.pinnedIndexFlags(ctx)
+}
+
+// pinPlanBaselineJoins makes the optimizer reproduce the joins recorded in the
+// plan gist of the plan baseline applied to the statement, if any: join
+// expressions of the memo that don't match the join order and the join
+// algorithms of the gist are given a prohibitive cost. It must be called after
+// the memo is built, before it is optimized. The joins are not pinned if the
+// statement reads a table more than once, since the gist does not identify
+// which reference of the table is read by each join.
+func (opc *optPlanningCtx) pinPlanBaselineJoins(ctx context.Context) {
+	b := opc.planBaseline
+	if b == nil || b.PlanGist == "" {
+		return
+	}
+	shapes, ok, err := explain.DecodePlanGistToJoins(b.PlanGist, opc.catalog)
+	if err != nil {
+		log.VEventf(ctx, 1, "unable to decode plan baseline gist: %v", err)
+		return
+	}
+	if !ok || len(shapes) == 0 {
+		return
+	}
+	tableIDs := make(map[cat.StableID]opt.TableID)
+	tables := opc.optimizer.Memo().Metadata().AllTables()
+	for i := range tables {
+		id := tables[i].Table.ID()
+		if _, ok := tableIDs[id]; ok {
+			opc.log(ctx, "not pinning plan baseline joins of a table read more than once")
+			return
+		}
+		tableIDs[id] = tables[i].MetaID
+	}
+	toTableSet := func(ids []cat.StableID) (res intsets.Fast, ok bool) {
+		for _, id := range ids {
+			tabID, ok := tableIDs[id]
+			if !ok {
+				return intsets.Fast{}, false
+			}
+			res.Add(int(tabID))
+		}
+		return res, true
+	}
+	joins := make([]xform.PinnedJoin, len(shapes))
+	for i, s := range shapes {
+		left, okLeft := toTableSet(s.Left)
+		right, okRight := toTableSet(s.Right)
+		if !okLeft || !okRight {
+			// The gist doesn't correspond to the statement's tables.
+			return
+		}
+		joins[i] = xform.PinnedJoin{Algorithm: s.Algorithm, Left: left, Right: right}
+	}
+	opc.log(ctx, "pinning plan baseline joins")
+	opc.optimizer.SetCoster(xform.NewPinnedJoinCoster(opc.optimizer.Coster(), joins))
+}
+
+// planBaselineFingerprint returns the statement fingerprint used to look up
+// plan baselines. For EXPLAIN statements, the fingerprint of the explained
+// statement is used so that EXPLAIN shows the plan that would be executed.
+func (opc *optPlanningCtx) planBaselineFingerprint() (string, bool) {
+	p := opc.p
+	ast := p.stmt.AST
+	if e, ok := ast.(*tree.Explain); ok {
+		ast = e.Statement
+	}
+	switch ast.(type) {
+	case *tree.ParenSelect, *tree.Select, *tree.SelectClause, *tree.UnionClause, *tree.ValuesClause,
+		*tree.Insert, *tree.Update, *tree.Delete:
+		// The fingerprint is recomputed from the AST rather than taken from
+		// p.stmt.StmtNoConstants, which still holds the outer statement for
+		// EXPLAIN and EXPLAIN ANALYZE.
+		mask := tree.FmtFlags(queryFormattingForFingerprintsMask.Get(&p.execCfg.Settings.SV))
+		return formatStatementHideConstants(ast, mask), true
+	default:
+		return "", false
+	}
+}
+
+// pinnedIndexFlags resolves the plan baseline into index flags to be applied
+// to each table referenced by the statement. Hints that can no longer be
+// resolved (for example, because an index was dropped) are skipped.
+func (opc *optPlanningCtx) pinnedIndexFlags(
+	ctx context.Context,
+) map[cat.StableID]*tree.IndexFlags {
+	b := opc.planBaseline
+	pinned := make(map[cat.StableID]*tree.IndexFlags)
+	if b.PlanGist != "" {
+		accesses, err := explain.DecodePlanGistToIndexAccesses(b.PlanGist, opc.catalog)
+		if err != nil {
+			log.VEventf(ctx, 1, "unable to decode plan baseline gist: %v", err)
+			return nil
+		}
+		conflicting := make(map[cat.StableID]struct{})
+		for _, a := range accesses {
+			if a.Table.IsVirtualTable() {
+				continue
+			}
+			id := a.Table.ID()
+			indexID := tree.IndexID(a.Index.ID())
+			if f, ok := pinned[id]; ok && f.IndexID != indexID {
+				// The table is accessed through different indexes; let the optimizer
+				// choose.
+				conflicting[id] = struct{}{}
+				continue
+			}
+			pinned[id] = &tree.IndexFlags{IndexID: indexID}
+		}
+		for id := range conflicting {
+			delete(pinned, id)
+		}
+		return pinned
+	}
+	hints, err := planbaseline.ParseHints(b.Hints)
+	if err != nil {
+		log.VEventf(ctx, 1, "unable to parse plan baseline hints: %v", err)
+		return nil
+	}
+	for _, h := range hints {
+		name := *h.Table
+		ds, _, err := opc.catalog.ResolveDataSource(ctx, cat.Flags{}, &name)
+		if err != nil {
+			log.VEventf(ctx, 1, "unable to resolve plan baseline table %s: %v", h.Table, err)
+			continue
+		}
+		tab, ok := ds.(cat.Table)
+		if !ok || !indexFlagsResolve(tab, h.Flags) {
+			log.VEventf(ctx, 1, "skipping plan baseline hint for %s", h.Table)
+			continue
+		}
+		pinned[tab.ID()] = h.Flags
+	}
+	return pinned
+}
+
+// indexFlagsResolve returns true if the index referenced by the given flags
+// exists in the table.
+func indexFlagsResolve(tab cat.Table, flags *tree.IndexFlags) bool {
+	if flags.Index == "" && flags.IndexID == 0 {
+		return true
+	}
+	for i, n := 0, tab.IndexCount(); i < n; i++ {
+		idx := tab.Index(i)
+		if flags.Index != "" && idx.Name() == tree.Name(flags.Index) {
+			return true
+		}
+		if flags.IndexID != 0 && idx.ID() == cat.StableID(flags.IndexID) {
+			return true
+		}
+	}
+	return false
 }
 
 func (opc *optPlanningCtx) log(ctx context.Context, msg redact.SafeString) {
@@ -447,9 +646,11 @@ func (opc *optPlanningCtx) buildReusableMemo(ctx context.Context) (_ *memo.Memo,
 	if opc.flags.IsSet(planFlagSessionMigration) {
 		bld.SkipAOST = true
 	}
+	opc.applyPlanBaseline(ctx, bld)
 	if err := bld.Build(); err != nil {
 		return nil, err
 	}
+	f.Memo().SetPinnedVersion(opc.planBaselineVersion())
 
 	if bld.DisableMemoReuse {
 		// The builder encountered a statement that prevents safe reuse of the memo.
@@ -484,6 +685,7 @@ func (opc *optPlanningCtx) buildReusableMemo(ctx context.Context) (_ *memo.Memo,
 		// can be reused without further changes to build the execution tree.
 		if !f.FoldingControl().PreventedStableFold() {
 			opc.log(ctx, "optimizing (no placeholders)")
+			opc.pinPlanBaselineJoins(ctx)
 			if _, err := opc.optimizer.Optimize(); err != nil {
 				return nil, err
 			}
@@ -521,12 +723,84 @@ func (opc *optPlanningCtx) reuseMemo(
 	if err := f.AssignPlaceholders(cachedMemo); err != nil {
 		return nil, err
 	}
+	opc.pinPlanBaselineJoins(ctx)
 	if _, err := opc.optimizer.Optimize(); err != nil {
 		return nil, err
 	}
 	return f.Memo(), nil
 }
 
+// autoPlanCacheModeMinCustomPlans is the number of custom plans that are built
+// for a prepared statement before a generic plan is considered when
+// plan_cache_mode is auto. This matches Postgres.
+const autoPlanCacheModeMinCustomPlans = 5
+
+// maybeUseGenericMemo returns the generic memo of the prepared statement if it
+// should be used for this execution according to the plan_cache_mode session
+// setting, building it if necessary. It returns nil if a custom memo should be
+// built instead.
+func (opc *optPlanningCtx) maybeUseGenericMemo(
+	ctx context.Context, prepared *PreparedStatement,
+) (*memo.Memo, error) {
+	mode := opc.p.SessionData().PlanCacheMode
+	if mode == sessiondatapb.PlanCacheModeForceCustom || prepared.Memo.IsOptimized() {
+		// If the prepared memo is already fully optimized, there is nothing to
+		// gain from a generic plan.
+		return nil, nil
+	}
+	if mode == sessiondatapb.PlanCacheModeAuto &&
+		prepared.customPlanCosts.measured < autoPlanCacheModeMinCustomPlans {
+		return nil, nil
+	}
+
+	genericMemo := prepared.GenericMemo
+	if genericMemo == nil {
+		var err error
+		genericMemo, err = opc.buildGenericMemo(ctx, prepared.Memo)
+		if err != nil {
+			return nil, err
+		}
+		if err := prepared.memAcc.Grow(ctx, genericMemo.MemoryEstimate()); err != nil {
+			// Use the generic memo for this execution, but don't keep it around.
+			opc.log(ctx, "not caching generic memo")
+		} else {
+			prepared.GenericMemo = genericMemo
+		}
+	}
+
+	if mode == sessiondatapb.PlanCacheModeAuto {
+		// As in Postgres, the cost of optimizing a custom plan is charged to the
+		// custom plans, since the generic plan is only optimized once. The cost is
+		// derived from the measured planning and execution latencies of the
+		// custom plans.
+		genericCost := genericMemo.RootExpr().(memo.RelExpr).Cost()
+		customCost := prepared.customPlanCosts.avg() + prepared.customPlanCosts.optimizationCost()
+		if genericCost >= customCost {
+			opc.log(ctx, "generic plan is more expensive than custom plans")
+			return nil, nil
+		}
+	}
+	return genericMemo, nil
+}
+
+// buildGenericMemo fully optimizes a copy of the given prepared memo without
+// assigning its placeholders. The returned memo is fully detached from the
+// planner and can be reused for any placeholder values.
+func (opc *optPlanningCtx) buildGenericMemo(
+	ctx context.Context, preparedMemo *memo.Memo,
+) (*memo.Memo, error) {
+	opc.log(ctx, "optimizing generic memo")
+	f := opc.optimizer.Factory()
+	if err := f.CopyMemoWithoutAssigningPlaceholders(preparedMemo); err != nil {
+		return nil, err
+	}
+	opc.pinPlanBaselineJoins(ctx)
+	if _, err := opc.optimizer.Optimize(); err != nil {
+		return nil, err
+	}
+	return opc.optimizer.DetachMemo(ctx), nil
+}
+
 // buildExecMemo creates a fully optimized memo, possibly reusing a previously
 // cached memo as a starting point.
 //
@@ -549,7 +823,7 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 
 		// If the prepared memo has been invalidated by schema or other changes,
 		// re-prepare it.
-		if isStale, err := prepared.Memo.IsStale(ctx, p.EvalContext(), opc.catalog); err != nil {
+		if isStale, err := opc.isStale(ctx, prepared.Memo); err != nil {
 			return nil, err
 		} else if isStale {
 			opc.log(ctx, "rebuilding cached memo")
@@ -557,16 +831,36 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 			if err != nil {
 				return nil, err
 			}
+			// The generic memo and the costs of custom plans were derived from the
+			// stale memo.
+			if prepared.GenericMemo != nil {
+				prepared.memAcc.Shrink(ctx, prepared.GenericMemo.MemoryEstimate())
+				prepared.GenericMemo = nil
+			}
+			prepared.customPlanCosts = customPlanCosts{}
+		}
+		if genericMemo, err := opc.maybeUseGenericMemo(ctx, prepared); err != nil {
+			return nil, err
+		} else if genericMemo != nil {
+			opc.log(ctx, "reusing generic memo")
+			return genericMemo, nil
 		}
 		opc.log(ctx, "reusing cached memo")
-		return opc.reuseMemo(ctx, prepared.Memo)
+		customMemo, err := opc.reuseMemo(ctx, prepared.Memo)
+		if err != nil {
+			return nil, err
+		}
+		if !prepared.Memo.IsOptimized() {
+			opc.flags.Set(planFlagCustomPlan)
+		}
+		return customMemo, nil
 	}
 
 	if opc.useCache {
 		// Consult the query cache.
 		cachedData, ok := p.execCfg.QueryCache.Find(&p.queryCacheSession, opc.p.stmt.SQL)
 		if ok {
-			if isStale, err := cachedData.Memo.IsStale(ctx, p.EvalContext(), opc.catalog); err != nil {
+			if isStale, err := opc.isStale(ctx, cachedData.Memo); err != nil {
 				return nil, err
 			} else if isStale {
 				opc.log(ctx, "query cache hit but needed update")
@@ -596,9 +890,11 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 	f := opc.optimizer.Factory()
 	f.FoldingControl().AllowStableFolds()
 	bld := optbuilder.New(ctx, &p.semaCtx, p.EvalContext(), opc.catalog, f, opc.p.stmt.AST)
+	opc.applyPlanBaseline(ctx, bld)
 	if err := bld.Build(); err != nil {
 		return nil, err
 	}
+	f.Memo().SetPinnedVersion(opc.planBaselineVersion())
 
 	// For index recommendations, after building we must interrupt the flow to
 	// find potential index candidates in the memo.
@@ -617,6 +913,7 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 	}
 
 	if _, isCanned := opc.p.stmt.AST.(*tree.CannedOptPlan); !isCanned {
+		opc.pinPlanBaselineJoins(ctx)
 		if _, err := opc.optimizer.Optimize(); err != nil {
 			return nil, err
 		}
@@ -714,6 +1011,12 @@ func (opc *optPlanningCtx) runExecBuilder(
 	}
 
 	planTop.planComponents = *result
+	if costBasedDistribution.Get(&opc.p.execCfg.Settings.SV) {
+		if est, ok := xform.EstimateDistribution(ctx, evalCtx, mem); ok {
+			costs := makePlanDistributionCosts(est, &opc.p.execCfg.Settings.SV)
+			planTop.mainDistributionCosts = &costs
+		}
+	}
 	planTop.stmt = stmt
 	planTop.flags |= opc.flags
 	if planTop.flags.IsSet(planFlagIsDDL) {
@@ -728,6 +1031,11 @@ func (opc *optPlanningCtx) runExecBuilder(
 	}
 	planTop.mem = mem
 	planTop.catalog = opc.catalog
+	if opc.p.SessionData().ResultCacheEnabled || mem.Metadata().HasResultCacheHint() {
+		planTop.resultCacheDeps, planTop.resultCacheReads = resultCacheDeps(
+			evalCtx, opc.p.ExecCfg().Codec, stmt.AST, planTop.flags, mem,
+		)
+	}
 	return nil
 }
 
@@ -792,6 +1100,19 @@ func (opc *optPlanningCtx) makeQueryIndexRecommendation(
 	indexCandidates := indexrec.FindIndexCandidateSet(f.Memo().RootExpr(), f.Metadata())
 	optTables, hypTables := indexrec.BuildOptAndHypTableMaps(opc.catalog, indexCandidates)
 
+	// Optimize with the saved memo and the existing indexes to find the cost of
+	// the plan without the recommendations.
+	opc.optimizer.Init(ctx, f.EvalContext(), opc.catalog)
+	f.CopyAndReplace(
+		savedMemo.RootExpr().(memo.RelExpr),
+		savedMemo.RootProps(),
+		f.CopyWithoutAssigningPlaceholders,
+	)
+	if _, err = opc.optimizer.Optimize(); err != nil {
+		return nil, err
+	}
+	existingCost := f.Memo().RootExpr().(memo.RelExpr).Cost()
+
 	// Optimize with the saved memo and hypothetical tables. Walk through the
 	// optimal plan to determine index recommendations.
 	opc.optimizer.Init(ctx, f.EvalContext(), opc.catalog)
@@ -810,6 +1131,12 @@ func (opc *optPlanningCtx) makeQueryIndexRecommendation(
 	if err != nil {
 		return nil, err
 	}
+	if hypCost := f.Memo().RootExpr().(memo.RelExpr).Cost(); existingCost > 0 && hypCost < existingCost {
+		costReduction := float64((existingCost - hypCost) / existingCost)
+		for i := range indexRecs {
+			indexRecs[i].CostReduction = costReduction
+		}
+	}
 
 	// Re-initialize the optimizer (which also re-initializes the factory) and
 	// update the saved memo's metadata with the original table information.
@@ -0,0 +1,365 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package planbaseline maintains the plan baselines stored in
+// system.statement_plan_hints. A plan baseline pins the access paths chosen by
+// the optimizer for all statements with a given fingerprint, either to the ones
+// of a captured plan gist or to an explicit set of index hints.
+//
+// A baseline captured from a plan gist pins the index used to read each table
+// as well as the join order and the hash, merge, lookup and inverted joins of
+// the plan, so the captured plan is reproduced as long as its indexes exist.
+// The joins of statements that read a table more than once or use apply joins
+// are not pinned. A baseline made of index hints only pins the indexes; join
+// hints in the statement itself (e.g. INNER LOOKUP JOIN) can be used to pin
+// the joins.
+package planbaseline
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/multitenant"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+var pollingInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.plan_baselines.poll_interval",
+	"rate at which the plan baselines stored in system.statement_plan_hints are "+
+		"reloaded, set to zero to disable",
+	10*time.Second,
+	settings.NonNegativeDuration,
+	settings.WithPublic,
+)
+
+// Enabled controls whether plan baselines are applied when planning
+// statements.
+var Enabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.plan_baselines.enabled",
+	"when true, the optimizer reproduces the access paths pinned by plan baselines",
+	true,
+	settings.WithPublic,
+)
+
+// Baseline is a plan baseline, i.e. a row of system.statement_plan_hints.
+// Exactly one of PlanGist and Hints is set.
+type Baseline struct {
+	// Fingerprint is the fingerprint of the statements the baseline applies to.
+	Fingerprint string
+	// PlanGist is a plan gist whose access paths are reproduced.
+	PlanGist string
+	// Hints is a comma-separated list of tables with index hints, using the
+	// syntax of a FROM clause, e.g. "t@t_a_idx, u@{NO_FULL_SCAN}"; see
+	// ParseHints.
+	Hints string
+	// CreatedAt is the time at which the baseline was created or last replaced.
+	CreatedAt time.Time
+}
+
+// Version identifies this version of the baseline of its fingerprint. It
+// changes whenever the baseline is replaced.
+func (b Baseline) Version() string {
+	return strconv.FormatInt(b.CreatedAt.UnixNano(), 10)
+}
+
+// IndexHint holds the index flags to use when reading a table.
+type IndexHint struct {
+	Table *tree.TableName
+	Flags *tree.IndexFlags
+}
+
+// ParseHints parses the hint set of a plan baseline. The hint set is a
+// comma-separated list of tables, each of which must carry index flags, as
+// they would be written in a FROM clause.
+func ParseHints(hints string) ([]IndexHint, error) {
+	errInvalid := func() error {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"invalid plan hints %q: expected a list of table@index hints", hints)
+	}
+	stmt, err := parser.ParseOne("SELECT * FROM " + hints)
+	if err != nil {
+		return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue, "invalid plan hints %q", hints)
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok || sel.With != nil || sel.OrderBy != nil || sel.Limit != nil || sel.Locking != nil {
+		return nil, errInvalid()
+	}
+	clause, ok := sel.Select.(*tree.SelectClause)
+	if !ok || clause.Where != nil || clause.GroupBy != nil || clause.Having != nil ||
+		clause.Window != nil || clause.From.AsOf.Expr != nil {
+		return nil, errInvalid()
+	}
+	res := make([]IndexHint, 0, len(clause.From.Tables))
+	for _, texpr := range clause.From.Tables {
+		ate, ok := texpr.(*tree.AliasedTableExpr)
+		if !ok || ate.IndexFlags == nil || ate.As.Alias != "" || ate.Ordinality {
+			return nil, errInvalid()
+		}
+		tn, ok := ate.Expr.(*tree.TableName)
+		if !ok {
+			return nil, errInvalid()
+		}
+		res = append(res, IndexHint{Table: tn, Flags: ate.IndexFlags})
+	}
+	return res, nil
+}
+
+// Registry maintains a view on the plan baselines stored in
+// system.statement_plan_hints. The view is refreshed periodically, and
+// immediately on the local node when baselines are created or dropped through
+// the Registry.
+type Registry struct {
+	mu struct {
+		// NOTE: This lock can't be held while the registry runs any statements
+		// internally; it'd deadlock.
+		syncutil.RWMutex
+		baselines map[string]Baseline
+
+		// epoch is observed before reading system.statement_plan_hints, and then
+		// checked again before loading the table's contents. If the value changed
+		// in between, then the table contents might be stale.
+		epoch int
+	}
+	st *cluster.Settings
+	db isql.DB
+}
+
+// NewRegistry constructs a new Registry.
+func NewRegistry(db isql.DB, st *cluster.Settings) *Registry {
+	r := &Registry{
+		db: db,
+		st: st,
+	}
+	r.mu.baselines = make(map[string]Baseline)
+	return r
+}
+
+// Start will start the polling loop for the Registry.
+func (r *Registry) Start(ctx context.Context, stopper *stop.Stopper) {
+	ctx, _ = stopper.WithCancelOnQuiesce(ctx)
+
+	// Reloading the baselines is not under user control, so exclude it from
+	// cost accounting and control.
+	ctx = multitenant.WithTenantCostControlExemption(ctx)
+
+	// NB: The only error that should occur here would be if the server were
+	// shutting down so let's swallow it.
+	_ = stopper.RunAsyncTask(ctx, "plan-baseline-poll", r.poll)
+}
+
+func (r *Registry) poll(ctx context.Context) {
+	var (
+		timer               timeutil.Timer
+		lastPoll            time.Time
+		deadline            time.Time
+		pollIntervalChanged = make(chan struct{}, 1)
+		maybeResetTimer     = func() {
+			if interval := pollingInterval.Get(&r.st.SV); interval == 0 {
+				// Setting the interval to zero stops the polling.
+				timer.Stop()
+			} else {
+				newDeadline := lastPoll.Add(interval)
+				if deadline.IsZero() || !deadline.Equal(newDeadline) {
+					deadline = newDeadline
+					timer.Reset(timeutil.Until(deadline))
+				}
+			}
+		}
+		poll = func() {
+			if err := r.pollBaselines(ctx); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.Warningf(ctx, "error polling for plan baselines: %s", err)
+			}
+			lastPoll = timeutil.Now()
+		}
+	)
+	pollingInterval.SetOnChange(&r.st.SV, func(ctx context.Context) {
+		select {
+		case pollIntervalChanged <- struct{}{}:
+		default:
+		}
+	})
+	for {
+		maybeResetTimer()
+		select {
+		case <-pollIntervalChanged:
+			continue // go back around and maybe reset the timer
+		case <-timer.C:
+			timer.Read = true
+		case <-ctx.Done():
+			return
+		}
+		poll()
+	}
+}
+
+// pollBaselines reads system.statement_plan_hints and replaces the baselines
+// known to the registry with its contents.
+func (r *Registry) pollBaselines(ctx context.Context) error {
+	if !r.st.Version.IsActive(ctx, clusterversion.V24_1_StatementPlanHintsTable) {
+		return nil
+	}
+	var baselines map[string]Baseline
+	// Loop until we run the query without straddling an epoch increment.
+	for {
+		r.mu.RLock()
+		epoch := r.mu.epoch
+		r.mu.RUnlock()
+
+		rows, err := r.db.Executor().QueryBufferedEx(ctx, "plan-baseline-poll", nil, /* txn */
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT fingerprint, plan_gist, hints, created_at FROM system.statement_plan_hints`,
+		)
+		if err != nil {
+			return err
+		}
+		baselines = make(map[string]Baseline, len(rows))
+		for _, row := range rows {
+			b := Baseline{Fingerprint: string(tree.MustBeDString(row[0]))}
+			if gist, ok := row[1].(*tree.DString); ok {
+				b.PlanGist = string(*gist)
+			}
+			if hints, ok := row[2].(*tree.DString); ok {
+				b.Hints = string(*hints)
+			}
+			b.CreatedAt = tree.MustBeDTimestampTZ(row[3]).Time
+			baselines[b.Fingerprint] = b
+		}
+
+		r.mu.Lock()
+		// If the epoch changed it means that a baseline was created or dropped
+		// on this node while the query was running, in which case the results
+		// might not reflect that change.
+		if r.mu.epoch != epoch {
+			r.mu.Unlock()
+			continue
+		}
+		break
+	}
+	defer r.mu.Unlock()
+	r.mu.baselines = baselines
+	return nil
+}
+
+// Active returns true if plan baselines are enabled and at least one baseline
+// exists. It allows callers to skip computing statement fingerprints when
+// there is nothing to look up. It is safe to call on a nil Registry.
+func (r *Registry) Active() bool {
+	if r == nil || !Enabled.Get(&r.st.SV) {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.mu.baselines) > 0
+}
+
+// Lookup returns the plan baseline for the given statement fingerprint, if
+// any.
+func (r *Registry) Lookup(fingerprint string) (Baseline, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	b, ok := r.mu.baselines[fingerprint]
+	return b, ok
+}
+
+// Baselines returns all the plan baselines known to the registry.
+func (r *Registry) Baselines() []Baseline {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	res := make([]Baseline, 0, len(r.mu.baselines))
+	for _, b := range r.mu.baselines {
+		res = append(res, b)
+	}
+	return res
+}
+
+func (r *Registry) checkVersion(ctx context.Context) error {
+	if !r.st.Version.IsActive(ctx, clusterversion.V24_1_StatementPlanHintsTable) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"plan baselines are only supported after the upgrade to 24.1 has been finalized")
+	}
+	return nil
+}
+
+// CreateBaseline stores the given plan baseline, replacing any existing
+// baseline for the same fingerprint. The baseline is written in its own
+// transaction and is applied by the local node right away; other nodes pick
+// it up the next time they poll.
+func (r *Registry) CreateBaseline(ctx context.Context, b Baseline) error {
+	if err := r.checkVersion(ctx); err != nil {
+		return err
+	}
+	if (b.PlanGist == "") == (b.Hints == "") {
+		return errors.AssertionFailedf("exactly one of plan gist and hints must be set")
+	}
+	var planGist, hints interface{}
+	if b.PlanGist != "" {
+		planGist = b.PlanGist
+	}
+	if b.Hints != "" {
+		hints = b.Hints
+	}
+	row, err := r.db.Executor().QueryRowEx(ctx, "plan-baseline-create", nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		`UPSERT INTO system.statement_plan_hints (fingerprint, plan_gist, hints, created_at)
+			VALUES ($1, $2, $3, now()) RETURNING created_at`,
+		b.Fingerprint, planGist, hints,
+	)
+	if err != nil {
+		return err
+	}
+	b.CreatedAt = tree.MustBeDTimestampTZ(row[0]).Time
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mu.epoch++
+	r.mu.baselines[b.Fingerprint] = b
+	return nil
+}
+
+// DropBaseline removes the plan baseline for the given fingerprint. It
+// returns false if there was no such baseline.
+func (r *Registry) DropBaseline(ctx context.Context, fingerprint string) (bool, error) {
+	if err := r.checkVersion(ctx); err != nil {
+		return false, err
+	}
+	n, err := r.db.Executor().ExecEx(ctx, "plan-baseline-drop", nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.statement_plan_hints WHERE fingerprint = $1`,
+		fingerprint,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mu.epoch++
+	delete(r.mu.baselines, fingerprint)
+	return n > 0, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planbaseline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHints(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		hints    string
+		expected string
+		err      string
+	}{
+		{hints: "t@t_a_idx", expected: "t@t_a_idx"},
+		{hints: "db.public.t@t_pkey", expected: "db.public.t@t_pkey"},
+		{
+			hints:    "t@t_a_idx, u@{NO_FULL_SCAN}",
+			expected: "t@t_a_idx, u@{NO_FULL_SCAN}",
+		},
+		{hints: "t@{FORCE_INDEX=t_b_idx,DESC}", expected: "t@{FORCE_INDEX=t_b_idx,DESC}"},
+		{hints: "", err: "invalid plan hints"},
+		{hints: "t", err: "expected a list of table@index hints"},
+		{hints: "t@t_a_idx AS x", err: "expected a list of table@index hints"},
+		{hints: "t@t_a_idx WHERE true", err: "expected a list of table@index hints"},
+		{hints: "t@t_a_idx JOIN u@u_pkey ON true", err: "expected a list of table@index hints"},
+		{hints: "t@t_a_idx; DROP TABLE t", err: "invalid plan hints"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.hints, func(t *testing.T) {
+			res, err := ParseHints(tc.hints)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			var strs []string
+			for _, h := range res {
+				strs = append(strs, tree.AsString(h.Table)+tree.AsString(h.Flags))
+			}
+			require.Equal(t, tc.expected, strings.Join(strs, ", "))
+		})
+	}
+}
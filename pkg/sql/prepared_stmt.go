@@ -60,6 +60,17 @@ type PreparedStatement struct {
 	// if it is used by the optimizer as a starting point.
 	Memo *memo.Memo
 
+	// GenericMemo is a fully optimized memo in which placeholders are left
+	// unassigned, so that it can be reused for any placeholder values. It is
+	// built from Memo the first time that plan_cache_mode calls for a generic
+	// query plan.
+	GenericMemo *memo.Memo
+
+	// customPlanCosts tracks the estimated costs and the measured latencies of
+	// the custom query plans built from Memo. It is used to choose between a
+	// custom and a generic plan when plan_cache_mode is auto.
+	customPlanCosts customPlanCosts
+
 	// refCount keeps track of the number of references to this PreparedStatement.
 	// New references are registered through incRef().
 	// Once refCount hits 0 (through calls to decRef()), the following memAcc is
@@ -87,9 +98,48 @@ func (p *PreparedStatement) MemoryEstimate() int64 {
 	if p.Memo != nil {
 		size += p.Memo.MemoryEstimate()
 	}
+	if p.GenericMemo != nil {
+		size += p.GenericMemo.MemoryEstimate()
+	}
 	return size
 }
 
+// customPlanCosts keeps track of the estimated costs and the measured planning
+// and execution latencies of the custom query plans built for a prepared
+// statement.
+type customPlanCosts struct {
+	measured int
+	total    memo.Cost
+	planning time.Duration
+	run      time.Duration
+}
+
+// add records a successful execution of a custom plan.
+func (c *customPlanCosts) add(cost memo.Cost, planning, run time.Duration) {
+	c.measured++
+	c.total += cost
+	c.planning += planning
+	c.run += run
+}
+
+func (c *customPlanCosts) avg() memo.Cost {
+	if c.measured == 0 {
+		return 0
+	}
+	return c.total / memo.Cost(c.measured)
+}
+
+// optimizationCost estimates the cost of optimizing a custom plan, in the units
+// of the optimizer's cost model. The estimated costs of the custom plans and
+// their measured execution latencies give the cost of a unit of time, which
+// converts the measured planning latency into a cost.
+func (c *customPlanCosts) optimizationCost() memo.Cost {
+	if c.run <= 0 {
+		return 0
+	}
+	return c.total * memo.Cost(c.planning) / memo.Cost(c.run) / memo.Cost(c.measured)
+}
+
 func (p *PreparedStatement) decRef(ctx context.Context) {
 	if p.refCount <= 0 {
 		log.Fatal(ctx, "corrupt PreparedStatement refcount")
@@ -0,0 +1,286 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/span"
+)
+
+// resultCacheDeps returns the tables read by the query in the given memo,
+// sorted by ID, and the spans of their indexes that the query reads, if the
+// results of the query can be stored in the result cache. It returns nil if
+// the results cannot be cached, which is the case if the query is not a
+// read-only SELECT, if it depends on the evaluation context (e.g. calls stable
+// or volatile functions), or if it reads from objects for which changes are
+// not visible to a rangefeed on the index spans (virtual tables, views,
+// user-defined types and functions). Tables with row-level security enabled
+// are not cached either, since the rows visible to a user depend on its role
+// memberships.
+func resultCacheDeps(
+	evalCtx *eval.Context, codec keys.SQLCodec, stmt tree.Statement, flags planFlags, mem *memo.Memo,
+) ([]cat.DataSource, []resultcache.IndexSpans) {
+	if _, ok := stmt.(*tree.Select); !ok {
+		return nil, nil
+	}
+	if flags.IsSet(planFlagContainsMutation) || flags.IsSet(planFlagIsDDL) ||
+		flags.IsSet(planFlagContainsLocking) {
+		return nil, nil
+	}
+	root, ok := mem.RootExpr().(memo.RelExpr)
+	if !ok {
+		return nil, nil
+	}
+	if vs := root.Relational().VolatilitySet; vs.HasStable() || vs.HasVolatile() {
+		return nil, nil
+	}
+	md := mem.Metadata()
+	if md.HasUserDefinedFunctions() || len(md.AllUserDefinedTypes()) > 0 ||
+		len(md.AllViews()) > 0 {
+		return nil, nil
+	}
+	tables := md.AllTables()
+	deps := make([]cat.DataSource, 0, len(tables))
+	seen := make(map[cat.StableID]struct{}, len(tables))
+	for i := range tables {
+		tab := tables[i].Table
+		if tab.IsVirtualTable() || tab.IsRowLevelSecurityEnabled() {
+			return nil, nil
+		}
+		if _, ok := seen[tab.ID()]; ok {
+			continue
+		}
+		seen[tab.ID()] = struct{}{}
+		deps = append(deps, tab)
+	}
+	if len(deps) == 0 {
+		return nil, nil
+	}
+	reads, ok := resultCacheReads(evalCtx, codec, root)
+	if !ok || len(reads) == 0 {
+		return nil, nil
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].ID() < deps[j].ID() })
+	return deps, reads
+}
+
+// resultCacheReads returns the spans of the indexes read by the given
+// optimized expression, sorted by index, or false if they cannot be
+// determined. Scans read the spans of their index constraint, while lookups
+// into an index (index, lookup, inverted and zigzag joins) read the entire
+// index.
+func resultCacheReads(
+	evalCtx *eval.Context, codec keys.SQLCodec, root memo.RelExpr,
+) (_ []resultcache.IndexSpans, ok bool) {
+	md := root.Memo().Metadata()
+	byIndex := make(map[string]*resultcache.IndexSpans)
+	ok = true
+	addSpans := func(tabID opt.TableID, idx cat.IndexOrdinal, c *constraint.Constraint) {
+		tab, isOptTable := md.Table(tabID).(*optTable)
+		if !isOptTable {
+			ok = false
+			return
+		}
+		var sb span.Builder
+		sb.Init(evalCtx, codec, tab.desc, tab.Index(idx).(*optIndex).idx)
+		spans, err := sb.SpansFromConstraint(c, span.NoopSplitter())
+		if err != nil {
+			ok = false
+			return
+		}
+		prefix := roachpb.Key(sb.KeyPrefix)
+		read := byIndex[string(prefix)]
+		if read == nil {
+			read = &resultcache.IndexSpans{
+				Index: roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()},
+			}
+			byIndex[string(prefix)] = read
+		}
+		for _, sp := range spans {
+			if len(sp.EndKey) == 0 {
+				sp.EndKey = sp.Key.Next()
+			}
+			read.Spans = append(read.Spans, sp)
+		}
+	}
+	var walk func(e opt.Expr)
+	walk = func(e opt.Expr) {
+		switch t := e.(type) {
+		case *memo.ScanExpr:
+			c := t.Constraint
+			if t.InvertedConstraint != nil {
+				c = nil
+			}
+			addSpans(t.Table, t.Index, c)
+		case *memo.PlaceholderScanExpr:
+			ok = false
+		case *memo.IndexJoinExpr:
+			addSpans(t.Table, cat.PrimaryIndex, nil /* c */)
+		case *memo.LookupJoinExpr:
+			addSpans(t.Table, t.Index, nil /* c */)
+		case *memo.InvertedJoinExpr:
+			addSpans(t.Table, t.Index, nil /* c */)
+		case *memo.ZigzagJoinExpr:
+			addSpans(t.LeftTable, t.LeftIndex, nil /* c */)
+			addSpans(t.RightTable, t.RightIndex, nil /* c */)
+		}
+		for i, n := 0, e.ChildCount(); i < n; i++ {
+			walk(e.Child(i))
+		}
+	}
+	walk(root)
+	if !ok {
+		return nil, false
+	}
+	reads := make([]resultcache.IndexSpans, 0, len(byIndex))
+	for _, read := range byIndex {
+		read.Spans, _ = roachpb.MergeSpans(&read.Spans)
+		reads = append(reads, *read)
+	}
+	sort.Slice(reads, func(i, j int) bool {
+		return bytes.Compare(reads[i].Index.Key, reads[j].Index.Key) < 0
+	})
+	return reads, true
+}
+
+// resultCacheKey returns the key under which the results of the current
+// statement are stored in the result cache. The statement is identified by
+// its fingerprint and its canonical form with the values of its placeholders,
+// so that statements which only differ in formatting share results.
+func (p *planner) resultCacheKey() resultcache.Key {
+	fmtCtx := tree.NewFmtCtx(
+		tree.FmtParsable,
+		tree.FmtPlaceholderFormat(func(fmtCtx *tree.FmtCtx, placeholder *tree.Placeholder) {
+			fmtCtx.FormatNode(p.semaCtx.Placeholders.Values[placeholder.Idx])
+		}),
+	)
+	fmtCtx.FormatNode(p.stmt.AST)
+	return resultcache.Key{
+		Fingerprint: p.stmt.StmtNoConstants,
+		Statement:   fmtCtx.CloseAndGetString(),
+		User:        p.User().Normalized(),
+		Database:    p.CurrentDatabase(),
+	}
+}
+
+// resultCacheReadSpec returns the timestamps at which the current statement
+// reads, or false if the read cannot be served by the result cache.
+func (p *planner) resultCacheReadSpec() (resultcache.ReadSpec, bool) {
+	if asOf := p.EvalContext().AsOfSystemTime; asOf != nil {
+		if asOf.BoundedStaleness {
+			if !asOf.MaxTimestampBound.IsEmpty() {
+				return resultcache.ReadSpec{}, false
+			}
+			return resultcache.ReadSpec{Timestamp: asOf.Timestamp, BoundedStaleness: true}, true
+		}
+		return resultcache.ReadSpec{Timestamp: asOf.Timestamp}, true
+	}
+	readTS := p.txn.ReadTimestamp()
+	return resultcache.ReadSpec{
+		Timestamp:        readTS,
+		UncertaintyLimit: readTS.Add(p.execCfg.Clock.MaxOffset().Nanoseconds(), 0 /* logical */),
+	}, true
+}
+
+// shouldUseResultCache returns whether the current statement may be served
+// from, or stored in, the result cache.
+func (ex *connExecutor) shouldUseResultCache(planner *planner) bool {
+	return ex.server.cfg.ResultCache != nil &&
+		planner.curPlan.resultCacheDeps != nil &&
+		planner.pausablePortal == nil &&
+		ex.implicitTxn() && ex.state.mu.stmtCount == 1 &&
+		!planner.instrumentation.collectBundle &&
+		!planner.instrumentation.ShouldCollectExecStats()
+}
+
+// execWithResultCache executes the current statement using the result cache.
+// If the cache contains the results of the statement, they are returned
+// without executing the plan. Otherwise, the plan is executed via the given
+// function and the results are added to the cache.
+func (ex *connExecutor) execWithResultCache(
+	ctx context.Context,
+	planner *planner,
+	res RestrictedCommandResult,
+	exec func(res RestrictedCommandResult) (topLevelQueryStats, error),
+) (topLevelQueryStats, error) {
+	read, ok := planner.resultCacheReadSpec()
+	if !ok {
+		return exec(res)
+	}
+	cache := ex.server.cfg.ResultCache
+	deps := planner.curPlan.resultCacheDeps
+	key := planner.resultCacheKey()
+	if rows, ok := cache.Get(ctx, key, deps, read); ok {
+		for _, row := range rows {
+			if err := res.AddRow(ctx, row); err != nil {
+				return topLevelQueryStats{}, err
+			}
+		}
+		return topLevelQueryStats{}, nil
+	}
+	w := &resultCacheWriter{
+		RestrictedCommandResult: res,
+		maxSize:                 resultcache.MaxEntrySize.Get(&ex.server.cfg.Settings.SV),
+	}
+	stats, err := exec(w)
+	if err == nil && res.Err() == nil && !w.overflow {
+		// Bounded staleness reads pick their timestamp during execution, so the
+		// transaction's read timestamp is only known at this point.
+		cache.Put(ctx, key, deps, planner.curPlan.resultCacheReads, w.rows, planner.txn.ReadTimestamp())
+	}
+	return stats, err
+}
+
+// resultCacheWriter is a RestrictedCommandResult that makes a copy of the rows
+// added to the wrapped result so that they can be stored in the result cache.
+type resultCacheWriter struct {
+	RestrictedCommandResult
+	rows     []tree.Datums
+	size     int64
+	maxSize  int64
+	overflow bool
+}
+
+var _ RestrictedCommandResult = &resultCacheWriter{}
+
+// AddRow is part of the RestrictedCommandResult interface.
+func (w *resultCacheWriter) AddRow(ctx context.Context, row tree.Datums) error {
+	if !w.overflow {
+		for _, d := range row {
+			w.size += int64(d.Size())
+		}
+		if w.size > w.maxSize {
+			w.overflow = true
+			w.rows = nil
+		} else {
+			w.rows = append(w.rows, append(tree.Datums(nil), row...))
+		}
+	}
+	return w.RestrictedCommandResult.AddRow(ctx, row)
+}
+
+// SupportsAddBatch is part of the RestrictedCommandResult interface. Batches
+// are not supported so that all rows are passed through AddRow.
+func (w *resultCacheWriter) SupportsAddBatch() bool {
+	return false
+}
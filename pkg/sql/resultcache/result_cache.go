@@ -0,0 +1,607 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package resultcache implements a node-level cache of the results of
+// read-only statements. Cached results are invalidated by rangefeeds on the
+// indexes the statements read from, and only by writes to the spans of those
+// indexes that the statements read.
+//
+// A cached result can only be returned to a read whose timestamp the
+// rangefeeds have fully resolved, since a write below that timestamp might
+// otherwise not have been observed yet. AS OF SYSTEM TIME reads (including
+// bounded staleness reads) old enough for their timestamp to be closed are
+// served immediately. The rangefeed frontiers trail the present time by the
+// closed timestamp target duration, so strong reads wait for the frontiers to
+// reach their timestamp, for up to sql.result_cache.strong_read_max_wait.
+package resultcache
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// MaxSize is the maximum total size of the results cached on a node.
+var MaxSize = settings.RegisterByteSizeSetting(
+	settings.ApplicationLevel,
+	"sql.result_cache.max_size",
+	"maximum total size of the results of read-only statements cached on a "+
+		"node; only statements executed with result_cache_enabled or with a "+
+		"RESULT_CACHE hint are cached",
+	64<<20, /* 64 MiB */
+	settings.NonNegativeInt,
+)
+
+// MaxEntrySize is the maximum size of a single cached result.
+var MaxEntrySize = settings.RegisterByteSizeSetting(
+	settings.ApplicationLevel,
+	"sql.result_cache.max_entry_size",
+	"maximum size of the result of a single statement that can be cached",
+	1<<20, /* 1 MiB */
+	settings.NonNegativeInt,
+)
+
+// StrongReadMaxWait is the maximum time a read that is not AS OF SYSTEM TIME
+// waits for the rangefeeds to resolve its timestamp.
+var StrongReadMaxWait = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.result_cache.strong_read_max_wait",
+	"maximum time a read that is not AS OF SYSTEM TIME waits for the "+
+		"rangefeeds on the indexes it reads to reach its timestamp before the "+
+		"statement is executed instead of being served from the result cache; "+
+		"reads that are further ahead of the rangefeeds are executed without "+
+		"waiting",
+	5*time.Second,
+	settings.NonNegativeDuration,
+)
+
+// maxRecentWrites is the number of writes that each index watcher remembers
+// in order to reject results that were read before one of them.
+const maxRecentWrites = 1024
+
+// Key identifies a cached result.
+type Key struct {
+	// Fingerprint is the fingerprint of the statement, in which constants and
+	// placeholders are replaced by underscores.
+	Fingerprint string
+	// Statement is the statement in its canonical form, with its placeholders
+	// replaced by their values. Statements that only differ in formatting,
+	// such as whitespace, letter case or comments, have the same canonical
+	// form.
+	Statement string
+	// User and Database are the session user and current database the
+	// statement was executed with.
+	User     string
+	Database string
+}
+
+// size returns the number of bytes used by the key.
+func (k Key) size() int64 {
+	return int64(len(k.Fingerprint) + len(k.Statement) + len(k.User) + len(k.Database))
+}
+
+// ReadSpec describes the timestamps at which a statement reads.
+type ReadSpec struct {
+	// Timestamp is the timestamp at which the statement reads. For bounded
+	// staleness reads, it is the minimum timestamp at which the statement may
+	// read.
+	Timestamp hlc.Timestamp
+	// UncertaintyLimit, if set, is the upper bound of the uncertainty interval
+	// of the read. Writes up to the uncertainty limit must have been observed
+	// before a cached result can be returned.
+	UncertaintyLimit hlc.Timestamp
+	// BoundedStaleness is true if the statement may read at any timestamp at
+	// or after Timestamp.
+	BoundedStaleness bool
+}
+
+// IndexSpans describes the parts of an index that a statement reads.
+type IndexSpans struct {
+	// Index is the span of the entire index.
+	Index roachpb.Span
+	// Spans are the spans of the index read by the statement. They must be
+	// sorted, non-overlapping and contained in Index, and each of them must
+	// have an EndKey.
+	Spans roachpb.Spans
+}
+
+// overlaps returns true if the given span, which may be a point span,
+// overlaps one of the spans read from the index.
+func (s *IndexSpans) overlaps(sp roachpb.Span) bool {
+	i := sort.Search(len(s.Spans), func(i int) bool {
+		return s.Spans[i].EndKey.Compare(sp.Key) > 0
+	})
+	return i < len(s.Spans) && s.Spans[i].Overlaps(sp)
+}
+
+// RangeFeedStarter starts a rangefeed over the given span beginning at (but
+// not including) startTS. onWrite must be called with the span and timestamp
+// of every write observed on the span, and onFrontier every time the frontier
+// of the rangefeed advances. The returned function stops the rangefeed; it
+// must not be called from within onWrite or onFrontier.
+type RangeFeedStarter func(
+	ctx context.Context,
+	span roachpb.Span,
+	startTS hlc.Timestamp,
+	onWrite func(ctx context.Context, span roachpb.Span, ts hlc.Timestamp),
+	onFrontier func(ctx context.Context, ts hlc.Timestamp),
+) (stop func(), _ error)
+
+// Cache is a cache of the results of read-only statements. A cached result
+// remains in the cache until it is evicted to make room for other results or
+// until a rangefeed observes a write, newer than the timestamp at which the
+// result was read, to one of the index spans it was read from.
+//
+// A Cache is safe for concurrent use.
+type Cache struct {
+	st             *cluster.Settings
+	stopper        *stop.Stopper
+	startRangeFeed RangeFeedStarter
+
+	mu struct {
+		syncutil.Mutex
+
+		// entries contains *entry values keyed by Key.
+		entries *cache.UnorderedCache
+		// size is the total size of all entries.
+		size int64
+		// watchers contains an indexWatcher for each index that at least one
+		// entry reads from, keyed by the start key of the index span.
+		watchers map[string]*indexWatcher
+		// toStop accumulates the rangefeeds of watchers that were removed
+		// while holding the mutex. They are stopped after the mutex is
+		// released.
+		toStop []func()
+	}
+}
+
+// entry is a cached result.
+type entry struct {
+	key  Key
+	rows []tree.Datums
+	// ts is the timestamp at which rows were read.
+	ts hlc.Timestamp
+	// deps are the data sources rows were read from.
+	deps []cat.DataSource
+	// reads are the index spans rows were read from.
+	reads []IndexSpans
+	size  int64
+}
+
+// observedWrite is a write observed by the rangefeed of an indexWatcher.
+type observedWrite struct {
+	span roachpb.Span
+	ts   hlc.Timestamp
+}
+
+// indexWatcher tracks the writes to a single index via a rangefeed.
+type indexWatcher struct {
+	// startTS is the timestamp the rangefeed was started at. Writes at or
+	// before startTS are not observed by the rangefeed.
+	startTS hlc.Timestamp
+	// frontier is the timestamp up to which all writes to the index have been
+	// observed.
+	frontier hlc.Timestamp
+	// frontierAdvanced is closed, and replaced, when the frontier advances.
+	frontierAdvanced chan struct{}
+	// recentWrites are the latest writes observed by the rangefeed. Results
+	// read before one of them are not added to the cache if they read its
+	// span, since the write might have been observed before the result was
+	// registered with the watcher, in which case it would not have
+	// invalidated the result.
+	recentWrites []observedWrite
+	// recentWritesHorizon is at or above the timestamp of every observed
+	// write that was dropped from recentWrites. Results read before it are
+	// not added to the cache.
+	recentWritesHorizon hlc.Timestamp
+	// entries maps each entry that reads from the index to the spans of the
+	// index it reads.
+	entries map[*entry]*IndexSpans
+	stop    func()
+}
+
+// New returns a new Cache that uses the given factory to create rangefeeds.
+// If factory is nil, results are never cached.
+func New(st *cluster.Settings, stopper *stop.Stopper, factory *rangefeed.Factory) *Cache {
+	var startRangeFeed RangeFeedStarter
+	if factory != nil {
+		startRangeFeed = makeRangeFeedStarter(factory)
+	}
+	return NewWithRangeFeedStarter(st, stopper, startRangeFeed)
+}
+
+// NewWithRangeFeedStarter is like New, but it allows the creation of
+// rangefeeds to be customized. It is exported for testing.
+func NewWithRangeFeedStarter(
+	st *cluster.Settings, stopper *stop.Stopper, startRangeFeed RangeFeedStarter,
+) *Cache {
+	c := &Cache{
+		st:             st,
+		stopper:        stopper,
+		startRangeFeed: startRangeFeed,
+	}
+	c.mu.watchers = make(map[string]*indexWatcher)
+	c.mu.entries = cache.NewUnorderedCache(cache.Config{
+		Policy: cache.CacheLRU,
+		ShouldEvict: func(_ int, _, _ interface{}) bool {
+			return c.mu.size > MaxSize.Get(&c.st.SV)
+		},
+		OnEvicted: func(_, value interface{}) {
+			c.removeLocked(value.(*entry))
+		},
+	})
+	return c
+}
+
+func makeRangeFeedStarter(factory *rangefeed.Factory) RangeFeedStarter {
+	return func(
+		ctx context.Context,
+		span roachpb.Span,
+		startTS hlc.Timestamp,
+		onWrite func(ctx context.Context, span roachpb.Span, ts hlc.Timestamp),
+		onFrontier func(ctx context.Context, ts hlc.Timestamp),
+	) (func(), error) {
+		rf, err := factory.RangeFeed(
+			ctx, fmt.Sprintf("result-cache-%s", span), []roachpb.Span{span}, startTS,
+			func(ctx context.Context, value *kvpb.RangeFeedValue) {
+				onWrite(ctx, roachpb.Span{Key: value.Key}, value.Value.Timestamp)
+			},
+			rangefeed.WithOnFrontierAdvance(onFrontier),
+			rangefeed.WithOnSSTable(func(
+				ctx context.Context, sst *kvpb.RangeFeedSSTable, _ roachpb.Span,
+			) {
+				onWrite(ctx, sst.Span, sst.WriteTS)
+			}),
+			rangefeed.WithOnDeleteRange(func(ctx context.Context, value *kvpb.RangeFeedDeleteRange) {
+				onWrite(ctx, value.Span, value.Timestamp)
+			}),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return rf.Close, nil
+	}
+}
+
+// Get returns the rows of the result cached for key, if there is one that can
+// be returned to a statement which depends on the given data sources, which
+// must be sorted by ID. Results that were read from different versions of the
+// data sources are never returned.
+//
+// For bounded staleness reads, any cached result that was read at or after the
+// minimum timestamp of the read is returned. Otherwise, the statement must
+// observe exactly the data at the read timestamp: a cached result is only
+// returned if it was read at or before the read timestamp and the rangefeeds
+// on all the indexes it was read from have observed all writes up to the read
+// timestamp (or its uncertainty limit) without observing one after the
+// result's timestamp to the spans it read. If the rangefeeds have not yet
+// reached that timestamp, Get waits for them for up to the
+// sql.result_cache.strong_read_max_wait setting, unless they are further
+// behind than that.
+//
+// The returned rows must not be modified.
+func (c *Cache) Get(
+	ctx context.Context, key Key, deps []cat.DataSource, read ReadSpec,
+) (rows []tree.Datums, ok bool) {
+	var timer timeutil.Timer
+	defer timer.Stop()
+	waiting := false
+	for {
+		rows, frontierAdvanced, lag, ok := c.lookup(key, deps, read)
+		if !ok {
+			return nil, false
+		}
+		if frontierAdvanced == nil {
+			return rows, true
+		}
+		if !waiting {
+			maxWait := StrongReadMaxWait.Get(&c.st.SV)
+			if lag > maxWait {
+				return nil, false
+			}
+			timer.Reset(maxWait)
+			waiting = true
+		}
+		select {
+		case <-frontierAdvanced:
+		case <-timer.C:
+			timer.Read = true
+			return nil, false
+		case <-ctx.Done():
+			return nil, false
+		}
+	}
+}
+
+// lookup returns the rows of the result cached for key; see Get. If the
+// result can be returned once the rangefeeds on the indexes it was read from
+// have advanced, lookup returns a channel that is closed when the frontier of
+// one of the lagging rangefeeds advances, along with the largest lag of the
+// rangefeeds behind the timestamp they need to reach.
+func (c *Cache) lookup(
+	key Key, deps []cat.DataSource, read ReadSpec,
+) (rows []tree.Datums, frontierAdvanced <-chan struct{}, lag time.Duration, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.mu.entries.Get(key)
+	if !ok {
+		return nil, nil, 0, false
+	}
+	e := v.(*entry)
+	if !depsEqual(e.deps, deps) {
+		return nil, nil, 0, false
+	}
+	if read.BoundedStaleness {
+		if e.ts.Less(read.Timestamp) {
+			return nil, nil, 0, false
+		}
+		return e.rows, nil, 0, true
+	}
+	if read.Timestamp.Less(e.ts) {
+		return nil, nil, 0, false
+	}
+	observedTS := read.Timestamp
+	observedTS.Forward(read.UncertaintyLimit)
+	for i := range e.reads {
+		w := c.mu.watchers[string(e.reads[i].Index.Key)]
+		if w == nil {
+			return nil, nil, 0, false
+		}
+		if w.frontier.Less(observedTS) {
+			frontierAdvanced = w.frontierAdvanced
+			if l := time.Duration(observedTS.WallTime - w.frontier.WallTime); l > lag {
+				lag = l
+			}
+		}
+	}
+	return e.rows, frontierAdvanced, lag, true
+}
+
+// Put adds the given rows, which were read at timestamp ts by the statement
+// identified by key from the given data sources (sorted by ID) and index
+// spans, to the cache. The rows are not added if they are larger than the
+// sql.result_cache.max_entry_size setting, if a rangefeed cannot be
+// established on one of the indexes, or if a write newer than ts to one of
+// the spans has already been observed. The cache takes ownership of the rows.
+func (c *Cache) Put(
+	ctx context.Context,
+	key Key,
+	deps []cat.DataSource,
+	reads []IndexSpans,
+	rows []tree.Datums,
+	ts hlc.Timestamp,
+) {
+	if c.startRangeFeed == nil || len(reads) == 0 {
+		return
+	}
+	e := &entry{key: key, rows: rows, ts: ts, deps: deps, reads: reads}
+	e.size = key.size()
+	for _, row := range rows {
+		for _, d := range row {
+			e.size += int64(d.Size())
+		}
+	}
+	for i := range reads {
+		for _, sp := range reads[i].Spans {
+			e.size += sp.MemUsage()
+		}
+	}
+	if e.size > MaxEntrySize.Get(&c.st.SV) || e.size > MaxSize.Get(&c.st.SV) {
+		return
+	}
+
+	// Start the rangefeeds outside of the mutex, since they might need to
+	// perform an RPC.
+	started := make(map[string]*indexWatcher)
+	for i := range reads {
+		watcherKey := string(reads[i].Index.Key)
+		c.mu.Lock()
+		w := c.mu.watchers[watcherKey]
+		c.mu.Unlock()
+		if w != nil || started[watcherKey] != nil {
+			continue
+		}
+		w, err := c.newIndexWatcher(ctx, reads[i].Index, ts)
+		if err != nil {
+			log.VEventf(ctx, 1, "unable to watch index span %s for result cache: %v",
+				reads[i].Index, err)
+			for _, w := range started {
+				w.stop()
+			}
+			return
+		}
+		started[watcherKey] = w
+	}
+
+	c.mu.Lock()
+	defer c.stopRemovedWatchers(ctx)
+	defer c.mu.Unlock()
+	for watcherKey, w := range started {
+		if _, ok := c.mu.watchers[watcherKey]; ok {
+			// Another goroutine started watching the index concurrently.
+			c.mu.toStop = append(c.mu.toStop, w.stop)
+			continue
+		}
+		c.mu.watchers[watcherKey] = w
+	}
+	for i := range reads {
+		w := c.mu.watchers[string(reads[i].Index.Key)]
+		if w == nil || !w.mayRegisterLocked(&reads[i], ts) {
+			// The rangefeed does not observe all writes after ts, or it has
+			// already observed one to the spans read, so the result could not
+			// be invalidated correctly.
+			for i := range reads {
+				c.maybeRemoveWatcherLocked(string(reads[i].Index.Key))
+			}
+			return
+		}
+	}
+	if _, ok := c.mu.entries.Get(key); ok {
+		c.mu.entries.Del(key)
+	}
+	for i := range reads {
+		c.mu.watchers[string(reads[i].Index.Key)].entries[e] = &e.reads[i]
+	}
+	c.mu.size += e.size
+	c.mu.entries.Add(key, e)
+}
+
+// mayRegisterLocked returns true if an entry that read the given spans of the
+// index at timestamp ts can be registered with the watcher, which is the case
+// if the rangefeed observes all writes after ts and none of the writes it
+// already observed after ts overlap the spans.
+func (w *indexWatcher) mayRegisterLocked(read *IndexSpans, ts hlc.Timestamp) bool {
+	if ts.Less(w.startTS) || ts.Less(w.recentWritesHorizon) {
+		return false
+	}
+	for _, write := range w.recentWrites {
+		if ts.Less(write.ts) && read.overlaps(write.span) {
+			return false
+		}
+	}
+	return true
+}
+
+// newIndexWatcher starts a rangefeed over the given index span at the given
+// timestamp.
+func (c *Cache) newIndexWatcher(
+	ctx context.Context, span roachpb.Span, startTS hlc.Timestamp,
+) (*indexWatcher, error) {
+	w := &indexWatcher{
+		startTS:          startTS,
+		frontier:         startTS,
+		frontierAdvanced: make(chan struct{}),
+		entries:          make(map[*entry]*IndexSpans),
+	}
+	stop, err := c.startRangeFeed(
+		ctx, span, startTS,
+		func(ctx context.Context, span roachpb.Span, ts hlc.Timestamp) {
+			c.onWrite(ctx, w, span, ts)
+		},
+		func(ctx context.Context, ts hlc.Timestamp) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if w.frontier.Forward(ts) {
+				close(w.frontierAdvanced)
+				w.frontierAdvanced = make(chan struct{})
+			}
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	w.stop = stop
+	return w, nil
+}
+
+// onWrite removes all entries that read the given span from the index watched
+// by w and that were read before the write at timestamp ts. w need not have
+// been added to c.mu.watchers yet.
+func (c *Cache) onWrite(
+	ctx context.Context, w *indexWatcher, span roachpb.Span, ts hlc.Timestamp,
+) {
+	c.mu.Lock()
+	defer c.stopRemovedWatchers(ctx)
+	defer c.mu.Unlock()
+	if len(w.recentWrites) == maxRecentWrites {
+		w.recentWritesHorizon.Forward(w.recentWrites[0].ts)
+		w.recentWrites = append(w.recentWrites[:0], w.recentWrites[1:]...)
+	}
+	w.recentWrites = append(w.recentWrites, observedWrite{span: span, ts: ts})
+	for e, read := range w.entries {
+		if e.ts.Less(ts) && read.overlaps(span) {
+			c.mu.entries.Del(e.key)
+		}
+	}
+}
+
+// removeLocked removes the entry from all the index watchers it is registered
+// with. It is called when the entry is removed from c.mu.entries.
+func (c *Cache) removeLocked(e *entry) {
+	c.mu.size -= e.size
+	for i := range e.reads {
+		watcherKey := string(e.reads[i].Index.Key)
+		if w := c.mu.watchers[watcherKey]; w != nil {
+			delete(w.entries, e)
+			c.maybeRemoveWatcherLocked(watcherKey)
+		}
+	}
+}
+
+// maybeRemoveWatcherLocked removes the watcher with the given key if no
+// entries depend on it anymore.
+func (c *Cache) maybeRemoveWatcherLocked(watcherKey string) {
+	if w := c.mu.watchers[watcherKey]; w != nil && len(w.entries) == 0 {
+		delete(c.mu.watchers, watcherKey)
+		c.mu.toStop = append(c.mu.toStop, w.stop)
+	}
+}
+
+// stopRemovedWatchers stops the rangefeeds of removed watchers. Since this
+// may be called from within a rangefeed callback, and stopping a rangefeed
+// waits for its callbacks to finish, the rangefeeds are stopped
+// asynchronously.
+func (c *Cache) stopRemovedWatchers(ctx context.Context) {
+	c.mu.Lock()
+	toStop := c.mu.toStop
+	c.mu.toStop = nil
+	c.mu.Unlock()
+	for _, stop := range toStop {
+		stop := stop
+		if err := c.stopper.RunAsyncTask(
+			ctx, "result-cache-stop-rangefeed", func(context.Context) { stop() },
+		); err != nil {
+			// The stopper is quiescing, which stops the rangefeed as well.
+			log.VEventf(ctx, 1, "unable to stop result cache rangefeed: %v", err)
+		}
+	}
+}
+
+// Clear removes all entries from the cache.
+func (c *Cache) Clear(ctx context.Context) {
+	c.mu.Lock()
+	c.mu.entries.Clear()
+	c.mu.Unlock()
+	c.stopRemovedWatchers(ctx)
+}
+
+// Len returns the number of cached results.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.mu.entries.Len()
+}
+
+func depsEqual(a, b []cat.DataSource) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equals(b[i]) {
+			return false
+		}
+	}
+	return true
+}
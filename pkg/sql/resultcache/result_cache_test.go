@@ -0,0 +1,371 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package resultcache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/testcat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRangeFeeds records the rangefeeds started by a Cache and allows the test
+// to deliver events to them.
+type fakeRangeFeeds struct {
+	syncutil.Mutex
+	feeds map[string]*fakeRangeFeed
+	err   error
+	// onStart, if set, is called with every rangefeed after it is started,
+	// before control is returned to the Cache.
+	onStart func(feed *fakeRangeFeed)
+}
+
+type fakeRangeFeed struct {
+	startTS    hlc.Timestamp
+	onWrite    func(ctx context.Context, span roachpb.Span, ts hlc.Timestamp)
+	onFrontier func(ctx context.Context, ts hlc.Timestamp)
+	stopped    bool
+}
+
+func (f *fakeRangeFeeds) start(
+	_ context.Context,
+	span roachpb.Span,
+	startTS hlc.Timestamp,
+	onWrite func(ctx context.Context, span roachpb.Span, ts hlc.Timestamp),
+	onFrontier func(ctx context.Context, ts hlc.Timestamp),
+) (func(), error) {
+	f.Lock()
+	defer f.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	feed := &fakeRangeFeed{startTS: startTS, onWrite: onWrite, onFrontier: onFrontier}
+	f.feeds[span.String()] = feed
+	if f.onStart != nil {
+		f.Unlock()
+		f.onStart(feed)
+		f.Lock()
+	}
+	return func() {
+		f.Lock()
+		defer f.Unlock()
+		feed.stopped = true
+	}, nil
+}
+
+func (f *fakeRangeFeeds) get(t *testing.T, id cat.StableID) *fakeRangeFeed {
+	f.Lock()
+	defer f.Unlock()
+	feed := f.feeds[indexSpan(id).String()]
+	require.NotNil(t, feed)
+	return feed
+}
+
+func (f *fakeRangeFeeds) isStopped(id cat.StableID) bool {
+	f.Lock()
+	defer f.Unlock()
+	feed := f.feeds[indexSpan(id).String()]
+	return feed != nil && feed.stopped
+}
+
+// indexSpan returns the span of the primary index of the table with the given
+// ID.
+func indexSpan(id cat.StableID) roachpb.Span {
+	prefix := keys.SystemSQLCodec.IndexPrefix(uint32(id), 1 /* indexID */)
+	return roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()}
+}
+
+// indexKey returns a key in the primary index of the table with the given ID.
+func indexKey(id cat.StableID, suffix string) roachpb.Key {
+	return append(keys.SystemSQLCodec.IndexPrefix(uint32(id), 1 /* indexID */), suffix...)
+}
+
+// fullReads returns IndexSpans that read the entire primary indexes of the
+// tables with the given IDs.
+func fullReads(ids ...cat.StableID) []IndexSpans {
+	reads := make([]IndexSpans, len(ids))
+	for i, id := range ids {
+		reads[i] = IndexSpans{Index: indexSpan(id), Spans: roachpb.Spans{indexSpan(id)}}
+	}
+	return reads
+}
+
+func TestResultCache(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+
+	ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+	rows := []tree.Datums{{tree.NewDInt(1)}, {tree.NewDInt(2)}}
+	t1 := &testcat.Table{TabID: 100, TabVersion: 1}
+	t2 := &testcat.Table{TabID: 101, TabVersion: 1}
+	deps := []cat.DataSource{t1, t2}
+	reads := fullReads(t1.TabID, t2.TabID)
+	key := Key{
+		Fingerprint: "SELECT * FROM t1, t2",
+		Statement:   "SELECT * FROM t1, t2",
+		User:        "root",
+		Database:    "defaultdb",
+	}
+	write := func(id cat.StableID) roachpb.Span {
+		return roachpb.Span{Key: indexKey(id, "a")}
+	}
+
+	setup := func() (*Cache, *fakeRangeFeeds) {
+		feeds := &fakeRangeFeeds{feeds: make(map[string]*fakeRangeFeed)}
+		return NewWithRangeFeedStarter(st, stopper, feeds.start), feeds
+	}
+	// Strong reads do not wait for the rangefeeds unless a test enables it.
+	StrongReadMaxWait.Override(ctx, &st.SV, 0)
+
+	t.Run("frontier", func(t *testing.T) {
+		c, feeds := setup()
+		c.Put(ctx, key, deps, reads, rows, ts(10))
+		require.Equal(t, 1, c.Len())
+		require.Equal(t, ts(10), feeds.get(t, t1.TabID).startTS)
+
+		// The result can be returned at its own timestamp, but not before it.
+		res, ok := c.Get(ctx, key, deps, ReadSpec{Timestamp: ts(10)})
+		require.True(t, ok)
+		require.Equal(t, rows, res)
+		_, ok = c.Get(ctx, key, deps, ReadSpec{Timestamp: ts(9)})
+		require.False(t, ok)
+
+		// The result can only be returned at later timestamps once the
+		// rangefeeds on all indexes have caught up.
+		_, ok = c.Get(ctx, key, deps, ReadSpec{Timestamp: ts(15)})
+		require.False(t, ok)
+		feeds.get(t, t1.TabID).onFrontier(ctx, ts(20))
+		_, ok = c.Get(ctx, key, deps, ReadSpec{Timestamp: ts(15)})
+		require.False(t, ok)
+		feeds.get(t, t2.TabID).onFrontier(ctx, ts(20))
+		_, ok = c.Get(ctx, key, deps, ReadSpec{Timestamp: ts(15)})
+		require.True(t, ok)
+
+		// Writes in the uncertainty interval of the read must have been
+		// observed as well.
+		_, ok = c.Get(ctx, key, deps, ReadSpec{Timestamp: ts(15), UncertaintyLimit: ts(25)})
+		require.False(t, ok)
+		_, ok = c.Get(ctx, key, deps, ReadSpec{Timestamp: ts(15), UncertaintyLimit: ts(20)})
+		require.True(t, ok)
+
+		// Other keys and other versions of the tables don't match.
+		_, ok = c.Get(ctx, Key{Fingerprint: key.Fingerprint, Statement: key.Statement, User: "other"}, deps, ReadSpec{Timestamp: ts(15)})
+		require.False(t, ok)
+		newT1 := &testcat.Table{TabID: 100, TabVersion: 2}
+		_, ok = c.Get(ctx, key, []cat.DataSource{newT1, t2}, ReadSpec{Timestamp: ts(15)})
+		require.False(t, ok)
+	})
+
+	t.Run("wait for frontier", func(t *testing.T) {
+		c, feeds := setup()
+		c.Put(ctx, key, deps, reads, rows, ts(10))
+		StrongReadMaxWait.Override(ctx, &st.SV, time.Minute)
+		defer StrongReadMaxWait.Override(ctx, &st.SV, 0)
+
+		// Reads that are further ahead of the rangefeeds than the maximum wait
+		// are not served.
+		_, ok := c.Get(ctx, key, deps, ReadSpec{Timestamp: ts(10 + 2*time.Minute.Nanoseconds())})
+		require.False(t, ok)
+
+		// Other reads wait for the rangefeeds to reach their timestamp.
+		done := make(chan bool)
+		go func() {
+			_, ok := c.Get(ctx, key, deps, ReadSpec{Timestamp: ts(15)})
+			done <- ok
+		}()
+		feeds.get(t, t1.TabID).onFrontier(ctx, ts(20))
+		select {
+		case <-done:
+			t.Fatal("read returned before the rangefeed on t2 caught up")
+		case <-time.After(10 * time.Millisecond):
+		}
+		feeds.get(t, t2.TabID).onFrontier(ctx, ts(20))
+		require.True(t, <-done)
+
+		// A read that times out is not served.
+		StrongReadMaxWait.Override(ctx, &st.SV, time.Millisecond)
+		_, ok = c.Get(ctx, key, deps, ReadSpec{Timestamp: ts(25)})
+		require.False(t, ok)
+	})
+
+	t.Run("invalidation", func(t *testing.T) {
+		c, feeds := setup()
+		c.Put(ctx, key, deps, reads, rows, ts(10))
+
+		// Writes at or before the timestamp of the result are already reflected
+		// in it.
+		feeds.get(t, t2.TabID).onWrite(ctx, write(t2.TabID), ts(10))
+		require.Equal(t, 1, c.Len())
+
+		// Later writes invalidate the result, which stops the rangefeeds.
+		feeds.get(t, t2.TabID).onWrite(ctx, write(t2.TabID), ts(11))
+		require.Equal(t, 0, c.Len())
+		testutils.SucceedsSoon(t, func() error {
+			if !feeds.isStopped(t1.TabID) || !feeds.isStopped(t2.TabID) {
+				return errors.New("rangefeeds not stopped")
+			}
+			return nil
+		})
+	})
+
+	t.Run("invalidation of spans", func(t *testing.T) {
+		c, feeds := setup()
+		// The result only reads the keys of t1 between "b" and "d".
+		spanReads := []IndexSpans{{
+			Index: indexSpan(t1.TabID),
+			Spans: roachpb.Spans{{Key: indexKey(t1.TabID, "b"), EndKey: indexKey(t1.TabID, "d")}},
+		}}
+		spanDeps := []cat.DataSource{t1}
+		c.Put(ctx, key, spanDeps, spanReads, rows, ts(10))
+		require.Equal(t, 1, c.Len())
+
+		// Writes outside of the spans do not invalidate the result, nor do they
+		// prevent results read before them from being cached.
+		feed := feeds.get(t, t1.TabID)
+		feed.onWrite(ctx, roachpb.Span{Key: indexKey(t1.TabID, "a")}, ts(11))
+		feed.onWrite(ctx, roachpb.Span{Key: indexKey(t1.TabID, "d")}, ts(11))
+		require.Equal(t, 1, c.Len())
+		otherKey := Key{Fingerprint: "SELECT * FROM t1", Statement: "SELECT * FROM t1"}
+		c.Put(ctx, otherKey, spanDeps, spanReads, rows, ts(10))
+		require.Equal(t, 2, c.Len())
+
+		// Range deletions that overlap the spans invalidate the result.
+		feed.onWrite(ctx, roachpb.Span{Key: indexKey(t1.TabID, "a"), EndKey: indexKey(t1.TabID, "c")}, ts(12))
+		require.Equal(t, 0, c.Len())
+	})
+
+	t.Run("bounded staleness", func(t *testing.T) {
+		c, _ := setup()
+		c.Put(ctx, key, deps, reads, rows, ts(10))
+		// Any result read at or after the minimum timestamp can be returned,
+		// regardless of the frontier of the rangefeeds.
+		_, ok := c.Get(ctx, key, deps, ReadSpec{Timestamp: ts(5), BoundedStaleness: true})
+		require.True(t, ok)
+		_, ok = c.Get(ctx, key, deps, ReadSpec{Timestamp: ts(10), BoundedStaleness: true})
+		require.True(t, ok)
+		_, ok = c.Get(ctx, key, deps, ReadSpec{Timestamp: ts(11), BoundedStaleness: true})
+		require.False(t, ok)
+	})
+
+	t.Run("rangefeed started later", func(t *testing.T) {
+		c, _ := setup()
+		c.Put(ctx, key, deps, reads, rows, ts(10))
+		// The rangefeed on t1 does not observe writes between 5 and 10, so a
+		// result read at 5 cannot be cached.
+		otherKey := Key{Fingerprint: "SELECT * FROM t1", Statement: "SELECT * FROM t1"}
+		c.Put(ctx, otherKey, []cat.DataSource{t1}, fullReads(t1.TabID), rows, ts(5))
+		require.Equal(t, 1, c.Len())
+		c.Put(ctx, otherKey, []cat.DataSource{t1}, fullReads(t1.TabID), rows, ts(15))
+		require.Equal(t, 2, c.Len())
+	})
+
+	t.Run("write before put", func(t *testing.T) {
+		c, feeds := setup()
+		c.Put(ctx, key, deps, reads, rows, ts(10))
+		// The result read at 13 keeps the rangefeeds running across the write
+		// below.
+		otherKey := Key{Fingerprint: "SELECT * FROM t2, t1", Statement: "SELECT * FROM t2, t1"}
+		c.Put(ctx, otherKey, deps, reads, rows, ts(13))
+		require.Equal(t, 2, c.Len())
+		feeds.get(t, t1.TabID).onWrite(ctx, write(t1.TabID), ts(12))
+		require.Equal(t, 1, c.Len())
+		// A write that is observed after a result is read, but before it is
+		// added to the cache, must prevent the result from being cached.
+		c.Put(ctx, key, deps, reads, rows, ts(11))
+		require.Equal(t, 1, c.Len())
+		// Results read after the write can be cached.
+		c.Put(ctx, key, deps, reads, rows, ts(12))
+		require.Equal(t, 2, c.Len())
+	})
+
+	t.Run("write before put on new rangefeed", func(t *testing.T) {
+		c, feeds := setup()
+		// The write is observed by the catch-up scan of the rangefeed that is
+		// started by Put, before the result is added to the cache.
+		feeds.onStart = func(feed *fakeRangeFeed) {
+			feed.onWrite(ctx, write(t1.TabID), ts(12))
+		}
+		c.Put(ctx, key, deps, reads, rows, ts(10))
+		require.Equal(t, 0, c.Len())
+	})
+
+	t.Run("forgotten writes", func(t *testing.T) {
+		c, feeds := setup()
+		spanReads := []IndexSpans{{
+			Index: indexSpan(t1.TabID),
+			Spans: roachpb.Spans{{Key: indexKey(t1.TabID, "b"), EndKey: indexKey(t1.TabID, "d")}},
+		}}
+		spanDeps := []cat.DataSource{t1}
+		c.Put(ctx, key, spanDeps, spanReads, rows, ts(10))
+		// Once the rangefeed has observed more writes than it remembers,
+		// results read before the forgotten writes cannot be cached, even if
+		// the writes did not touch the spans they read.
+		feed := feeds.get(t, t1.TabID)
+		for i := 0; i <= maxRecentWrites; i++ {
+			feed.onWrite(ctx, roachpb.Span{Key: indexKey(t1.TabID, "z")}, ts(int64(20+i)))
+		}
+		require.Equal(t, 1, c.Len())
+		otherKey := Key{Fingerprint: "SELECT * FROM t1", Statement: "SELECT * FROM t1"}
+		c.Put(ctx, otherKey, spanDeps, spanReads, rows, ts(15))
+		require.Equal(t, 1, c.Len())
+		c.Put(ctx, otherKey, spanDeps, spanReads, rows, ts(25))
+		require.Equal(t, 2, c.Len())
+	})
+
+	t.Run("rangefeed error", func(t *testing.T) {
+		c, feeds := setup()
+		feeds.err = errors.New("boom")
+		c.Put(ctx, key, deps, reads, rows, ts(10))
+		require.Equal(t, 0, c.Len())
+	})
+
+	t.Run("size limits", func(t *testing.T) {
+		c, _ := setup()
+		MaxEntrySize.Override(ctx, &st.SV, 1)
+		c.Put(ctx, key, deps, reads, rows, ts(10))
+		require.Equal(t, 0, c.Len())
+		MaxEntrySize.Override(ctx, &st.SV, 1<<20)
+
+		var size int64
+		for i := 0; i < 4; i++ {
+			c.Put(ctx, Key{Statement: string(rune('a' + i))}, deps, reads, rows, ts(10))
+			if i == 0 {
+				c.mu.Lock()
+				size = c.mu.size
+				c.mu.Unlock()
+			}
+		}
+		require.Equal(t, 4, c.Len())
+		MaxSize.Override(ctx, &st.SV, 2*size)
+		c.Put(ctx, Key{Statement: "e"}, deps, reads, rows, ts(10))
+		require.Equal(t, 2, c.Len())
+		MaxSize.Override(ctx, &st.SV, 64<<20)
+	})
+}
@@ -41,7 +41,14 @@ import (
 type indexBackfiller struct {
 	backfill.IndexBackfiller
 
-	adder kvserverbase.BulkAdder
+	// backfillers contains one IndexBackfiller per worker. Each worker builds
+	// the index entries for the spans it picks up and ingests them via its own
+	// BulkAdder, so that the workers produce sorted SSTs in parallel. The first
+	// element is always the embedded IndexBackfiller, and the memory monitors
+	// of the other workers are children of its monitor, so that the memory
+	// used by all workers is accounted for together. The workers also split
+	// the buffers of a single backfiller among themselves, see bufferShare.
+	backfillers []*backfill.IndexBackfiller
 
 	desc catalog.TableDescriptor
 
@@ -87,10 +94,40 @@ func newIndexBackfiller(
 		ib.spec.IndexesToBackfill, indexBackfillerMon); err != nil {
 		return nil, err
 	}
+	ib.backfillers = append(ib.backfillers, &ib.IndexBackfiller)
+
+	// There is no point in having more workers than spans to process.
+	numWorkers := int(spec.NumWorkers)
+	if numWorkers > len(spec.Spans) {
+		numWorkers = len(spec.Spans)
+	}
+	for i := 1; i < numWorkers; i++ {
+		workerMon := execinfra.NewMonitor(ctx, indexBackfillerMon,
+			"index-backfill-worker-mon")
+		bf := &backfill.IndexBackfiller{}
+		if err := bf.InitForDistributedUse(ctx, flowCtx, ib.desc,
+			ib.spec.IndexesToBackfill, workerMon); err != nil {
+			ib.Close(ctx)
+			return nil, err
+		}
+		ib.backfillers = append(ib.backfillers, bf)
+	}
 
 	return ib, nil
 }
 
+// Close releases the resources used by all workers of the indexBackfiller.
+func (ib *indexBackfiller) Close(ctx context.Context) {
+	// The embedded IndexBackfiller is closed last since the monitors of the
+	// other workers are children of its monitor.
+	for i := len(ib.backfillers) - 1; i >= 0; i-- {
+		ib.backfillers[i].Close(ctx)
+	}
+	if len(ib.backfillers) == 0 {
+		ib.IndexBackfiller.Close(ctx)
+	}
+}
+
 func (ib *indexBackfiller) OutputTypes() []*types.T {
 	// No output types.
 	return nil
@@ -111,15 +148,19 @@ type indexEntryBatch struct {
 }
 
 // constructIndexEntries is responsible for constructing the index entries of
-// all the spans assigned to the processor. It streams batches of constructed
-// index entries over the indexEntriesCh.
+// the spans assigned to the processor that it receives on spanIdxs (which are
+// indices into the spans of the spec). It streams batches of constructed index
+// entries over the indexEntriesCh.
 func (ib *indexBackfiller) constructIndexEntries(
-	ctx context.Context, indexEntriesCh chan indexEntryBatch,
+	ctx context.Context,
+	bf *backfill.IndexBackfiller,
+	spanIdxs <-chan int,
+	indexEntriesCh chan indexEntryBatch,
 ) error {
 	var memUsedBuildingBatch int64
 	var err error
 	var entries []rowenc.IndexEntry
-	for i := range ib.spec.Spans {
+	for i := range spanIdxs {
 		log.VEventf(ctx, 2, "index backfiller starting span %d of %d: %s",
 			i+1, len(ib.spec.Spans), ib.spec.Spans[i])
 		todo := ib.spec.Spans[i]
@@ -129,7 +170,7 @@ func (ib *indexBackfiller) constructIndexEntries(
 			if readAsOf.IsEmpty() { // old gateway
 				readAsOf = ib.spec.WriteAsOf
 			}
-			todo.Key, entries, memUsedBuildingBatch, err = ib.buildIndexEntryBatch(ctx, todo,
+			todo.Key, entries, memUsedBuildingBatch, err = ib.buildIndexEntryBatch(ctx, bf, todo,
 				readAsOf)
 			if err != nil {
 				return err
@@ -167,17 +208,21 @@ func (ib *indexBackfiller) constructIndexEntries(
 }
 
 // ingestIndexEntries adds the batches of built index entries to the buffering
-// adder and reports progress back to the coordinator node.
+// adder and reports progress back to the coordinator node. bf must be the
+// IndexBackfiller that built the index entries.
 func (ib *indexBackfiller) ingestIndexEntries(
 	ctx context.Context,
+	bf *backfill.IndexBackfiller,
 	indexEntryCh <-chan indexEntryBatch,
 	progCh chan execinfrapb.RemoteProducerMetadata_BulkProcessorProgress,
 ) error {
 	ctx, span := tracing.ChildSpan(ctx, "ingestIndexEntries")
 	defer span.Finish()
 
-	minBufferSize := backfillerBufferSize.Get(&ib.flowCtx.Cfg.Settings.SV)
-	maxBufferSize := func() int64 { return backfillerMaxBufferSize.Get(&ib.flowCtx.Cfg.Settings.SV) }
+	minBufferSize := ib.bufferShare(backfillerBufferSize.Get(&ib.flowCtx.Cfg.Settings.SV))
+	maxBufferSize := func() int64 {
+		return ib.bufferShare(backfillerMaxBufferSize.Get(&ib.flowCtx.Cfg.Settings.SV))
+	}
 	opts := kvserverbase.BulkAdderOptions{
 		Name:                     ib.desc.GetName() + " backfill",
 		MinBufferSize:            minBufferSize,
@@ -191,8 +236,7 @@ func (ib *indexBackfiller) ingestIndexEntries(
 	if err != nil {
 		return err
 	}
-	ib.adder = adder
-	defer ib.adder.Close(ctx)
+	defer adder.Close(ctx)
 
 	// Synchronizes read and write access on completedSpans which is updated on a
 	// BulkAdder flush, but is read when progress is being sent back to the
@@ -247,7 +291,7 @@ func (ib *indexBackfiller) ingestIndexEntries(
 
 		for indexBatch := range indexEntryCh {
 			for _, indexEntry := range indexBatch.indexEntries {
-				if err := ib.adder.Add(ctx, indexEntry.Key, indexEntry.Value.RawBytes); err != nil {
+				if err := adder.Add(ctx, indexEntry.Key, indexEntry.Value.RawBytes); err != nil {
 					return ib.wrapDupError(ctx, err)
 				}
 			}
@@ -263,11 +307,11 @@ func (ib *indexBackfiller) ingestIndexEntries(
 			// free the memory which was accounted when building the index entries of the
 			// current chunk.
 			indexBatch.indexEntries = nil
-			ib.ShrinkBoundAccount(ctx, indexBatch.memUsedBuildingBatch)
+			bf.ShrinkBoundAccount(ctx, indexBatch.memUsedBuildingBatch)
 
 			knobs := &ib.flowCtx.Cfg.TestingKnobs
 			if knobs.BulkAdderFlushesEveryBatch {
-				if err := ib.adder.Flush(ctx); err != nil {
+				if err := adder.Flush(ctx); err != nil {
 					return ib.wrapDupError(ctx, err)
 				}
 				pushProgress()
@@ -289,7 +333,7 @@ func (ib *indexBackfiller) ingestIndexEntries(
 		return err
 	}
 
-	if err := ib.adder.Flush(ctx); err != nil {
+	if err := adder.Flush(ctx); err != nil {
 		return ib.wrapDupError(ctx, err)
 	}
 
@@ -299,36 +343,61 @@ func (ib *indexBackfiller) ingestIndexEntries(
 	return nil
 }
 
+// indexEntriesChCapacity is the number of batches of index entries that can be
+// buffered between building and ingesting them.
+const indexEntriesChCapacity = 10
+
+// bufferShare returns the share of a worker of the given buffer size of a
+// single index backfiller, so that the total memory used for buffering by the
+// processor doesn't grow with the number of workers.
+func (ib *indexBackfiller) bufferShare(size int64) int64 {
+	share := size / int64(len(ib.backfillers))
+	if share < 1 {
+		share = 1
+	}
+	return share
+}
+
 func (ib *indexBackfiller) runBackfill(
 	ctx context.Context, progCh chan execinfrapb.RemoteProducerMetadata_BulkProcessorProgress,
 ) error {
-	// Used to send index entries to the KV layer.
-	indexEntriesCh := make(chan indexEntryBatch, 10)
+	// spanIdxs is the queue of spans to be backfilled, shared by all workers.
+	spanIdxs := make(chan int, len(ib.spec.Spans))
+	for i := range ib.spec.Spans {
+		spanIdxs <- i
+	}
+	close(spanIdxs)
 
 	// This group holds the go routines that are responsible for producing index
 	// entries and ingesting the KVs into storage.
 	group := ctxgroup.WithContext(ctx)
 
-	// Construct index entries for the spans.
-	group.GoCtx(func(ctx context.Context) error {
-		defer close(indexEntriesCh)
-		ctx, span := tracing.ChildSpan(ctx, "buildIndexEntries")
-		defer span.Finish()
-		err := ib.constructIndexEntries(ctx, indexEntriesCh)
-		if err != nil {
-			return errors.Wrap(err, "failed to construct index entries during backfill")
-		}
-		return nil
-	})
+	for _, bf := range ib.backfillers {
+		bf := bf
+		// Used to send index entries to the KV layer.
+		indexEntriesCh := make(chan indexEntryBatch, ib.bufferShare(indexEntriesChCapacity))
+
+		// Construct index entries for the spans.
+		group.GoCtx(func(ctx context.Context) error {
+			defer close(indexEntriesCh)
+			ctx, span := tracing.ChildSpan(ctx, "buildIndexEntries")
+			defer span.Finish()
+			err := ib.constructIndexEntries(ctx, bf, spanIdxs, indexEntriesCh)
+			if err != nil {
+				return errors.Wrap(err, "failed to construct index entries during backfill")
+			}
+			return nil
+		})
 
-	// Ingest the index entries that are emitted to the chan.
-	group.GoCtx(func(ctx context.Context) error {
-		err := ib.ingestIndexEntries(ctx, indexEntriesCh, progCh)
-		if err != nil {
-			return errors.Wrap(err, "failed to ingest index entries during backfill")
-		}
-		return nil
-	})
+		// Ingest the index entries that are emitted to the chan.
+		group.GoCtx(func(ctx context.Context) error {
+			err := ib.ingestIndexEntries(ctx, bf, indexEntriesCh, progCh)
+			if err != nil {
+				return errors.Wrap(err, "failed to ingest index entries during backfill")
+			}
+			return nil
+		})
+	}
 
 	if err := group.Wait(); err != nil {
 		return err
@@ -406,9 +475,10 @@ func (ib *indexBackfiller) getProgressReportInterval() time.Duration {
 	return indexBackfillProgressReportInterval
 }
 
-// buildIndexEntryBatch constructs the index entries for a single indexBatch.
+// buildIndexEntryBatch constructs the index entries for a single indexBatch
+// using the given IndexBackfiller.
 func (ib *indexBackfiller) buildIndexEntryBatch(
-	tctx context.Context, sp roachpb.Span, readAsOf hlc.Timestamp,
+	tctx context.Context, bf *backfill.IndexBackfiller, sp roachpb.Span, readAsOf hlc.Timestamp,
 ) (roachpb.Key, []rowenc.IndexEntry, int64, error) {
 	knobs := &ib.flowCtx.Cfg.TestingKnobs
 	var memUsedBuildingBatch int64
@@ -430,7 +500,7 @@ func (ib *indexBackfiller) buildIndexEntryBatch(
 
 		// TODO(knz): do KV tracing in DistSQL processors.
 		var err error
-		entries, key, memUsedBuildingBatch, err = ib.BuildIndexEntriesChunk(
+		entries, key, memUsedBuildingBatch, err = bf.BuildIndexEntriesChunk(
 			ctx, txn.KV(), ib.desc, sp, ib.spec.ChunkSize, false, /* traceKV */
 		)
 		return err
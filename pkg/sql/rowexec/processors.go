@@ -293,8 +293,13 @@ func NewProcessor(
 			return nil, err
 		}
 
-		if core.Exporter.Format.Format == roachpb.IOFileFormat_Parquet {
+		switch core.Exporter.Format.Format {
+		case roachpb.IOFileFormat_Parquet:
 			return NewParquetWriterProcessor(ctx, flowCtx, processorID, *core.Exporter, post, inputs[0])
+		case roachpb.IOFileFormat_Arrow:
+			return NewArrowWriterProcessor(ctx, flowCtx, processorID, *core.Exporter, post, inputs[0])
+		case roachpb.IOFileFormat_JSONL:
+			return NewJSONLWriterProcessor(ctx, flowCtx, processorID, *core.Exporter, post, inputs[0])
 		}
 		return NewCSVWriterProcessor(ctx, flowCtx, processorID, *core.Exporter, post, inputs[0])
 	}
@@ -422,6 +427,12 @@ var NewCSVWriterProcessor func(context.Context, *execinfra.FlowCtx, int32, execi
 // NewParquetWriterProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
 var NewParquetWriterProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.ExportSpec, *execinfrapb.PostProcessSpec, execinfra.RowSource) (execinfra.Processor, error)
 
+// NewArrowWriterProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
+var NewArrowWriterProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.ExportSpec, *execinfrapb.PostProcessSpec, execinfra.RowSource) (execinfra.Processor, error)
+
+// NewJSONLWriterProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
+var NewJSONLWriterProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.ExportSpec, *execinfrapb.PostProcessSpec, execinfra.RowSource) (execinfra.Processor, error)
+
 // NewChangeAggregatorProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
 var NewChangeAggregatorProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.ChangeAggregatorSpec, *execinfrapb.PostProcessSpec) (execinfra.Processor, error)
 
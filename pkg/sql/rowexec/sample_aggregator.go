@@ -155,6 +155,13 @@ func newSampleAggregator(
 		if spec.Sketches[i].GenerateHistogram {
 			sampleCols.Add(int(spec.Sketches[i].Columns[0]))
 		}
+		if spec.Sketches[i].GenerateDependencies || spec.Sketches[i].GenerateMostCommonValues {
+			// Extended statistics are built from the joint distribution of all
+			// the columns in the sketch.
+			for _, col := range spec.Sketches[i].Columns {
+				sampleCols.Add(int(col))
+			}
+		}
 	}
 
 	s.sr.Init(
@@ -521,6 +528,28 @@ func (s *sampleAggregator) writeResults(ctx context.Context) error {
 				columnIDs[i] = s.sampledCols[c]
 			}
 
+			var extendedStats *stats.ExtendedStatisticsData
+			if si.spec.GenerateDependencies || si.spec.GenerateMostCommonValues {
+				colIdxs := make([]int, len(si.spec.Columns))
+				colTypes := make([]*types.T, len(si.spec.Columns))
+				for i, c := range si.spec.Columns {
+					colIdxs[i] = int(c)
+					colTypes[i] = s.inTypes[c]
+				}
+				var err error
+				extendedStats, err = stats.BuildExtendedStatistics(
+					s.EvalCtx,
+					s.sr.Get(),
+					colIdxs,
+					colTypes,
+					si.spec.GenerateDependencies,
+					si.spec.GenerateMostCommonValues,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
 			// Delete old stats that have been superseded,
 			// if the new statistic is not partial
 			if si.spec.PartialPredicate == "" {
@@ -549,6 +578,7 @@ func (s *sampleAggregator) writeResults(ctx context.Context) error {
 				histogram,
 				si.spec.PartialPredicate,
 				si.spec.FullStatisticID,
+				extendedStats,
 			); err != nil {
 				return err
 			}
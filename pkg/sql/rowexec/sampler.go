@@ -146,6 +146,13 @@ func newSamplerProcessor(
 		if spec.Sketches[i].GenerateHistogram {
 			sampleCols.Add(int(spec.Sketches[i].Columns[0]))
 		}
+		if spec.Sketches[i].GenerateDependencies || spec.Sketches[i].GenerateMostCommonValues {
+			// Extended statistics are built from the joint distribution of all
+			// the columns in the sketch.
+			for _, col := range spec.Sketches[i].Columns {
+				sampleCols.Add(int(col))
+			}
+		}
 	}
 	for i := range spec.InvertedSketches {
 		var sr stats.SampleReservoir
@@ -2573,6 +2573,7 @@ var builtinOidsArray = []string{
 	2605: `merge_aggregated_stmt_metadata(arg1: jsonb) -> jsonb`,
 	2606: `crdb_internal.protect_mvcc_history(timestamp: decimal, expiration_window: interval, description: string) -> int`,
 	2607: `crdb_internal.extend_mvcc_history_protection(job_id: int) -> void`,
+	2608: `crdb_internal.workload_index_recommendations(since: timestamptz) -> tuple{string AS action, string AS statement, string AS reason, float AS estimated_benefit}`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
@@ -315,6 +315,18 @@ var generators = map[string]builtinDefinition{
 		),
 	),
 
+	"crdb_internal.workload_index_recommendations": makeBuiltin(genProps(),
+		makeGeneratorOverload(
+			tree.ParamTypes{{Name: "since", Typ: types.TimestampTZ}},
+			workloadIndexActionsGeneratorType,
+			makeWorkloadIndexActionsGenerator,
+			"Returns the CREATE, REPLACE and DROP index actions recommended for the "+
+				"workload of the current database executed after the given timestamp, "+
+				"ranked by their estimated net benefit (in seconds of statement execution time).",
+			volatility.Volatile,
+		),
+	),
+
 	"unnest": makeBuiltin(genProps(),
 		// See https://www.postgresql.org/docs/current/static/functions-array.html
 		makeGeneratorOverloadWithReturnType(
@@ -1233,6 +1245,63 @@ func makeWorkloadIndexRecsGeneratorFactory(hasTimestamp bool) eval.GeneratorOver
 	}
 }
 
+var workloadIndexActionsGeneratorType = types.MakeLabeledTuple(
+	[]*types.T{types.String, types.String, types.String, types.Float},
+	[]string{"action", "statement", "reason", "estimated_benefit"},
+)
+
+// workloadIndexActionsGenerator is a value generator that returns the index
+// actions recommended for the workload.
+type workloadIndexActionsGenerator struct {
+	evalCtx   *eval.Context
+	since     tree.DTimestampTZ
+	actions   []workloadindexrec.Action
+	nextIndex int
+}
+
+var _ eval.ValueGenerator = (*workloadIndexActionsGenerator)(nil)
+
+func makeWorkloadIndexActionsGenerator(
+	_ context.Context, evalCtx *eval.Context, args tree.Datums,
+) (eval.ValueGenerator, error) {
+	return &workloadIndexActionsGenerator{
+		evalCtx: evalCtx,
+		since:   tree.MustBeDTimestampTZ(args[0]),
+	}, nil
+}
+
+// ResolvedType implements the eval.ValueGenerator interface.
+func (g *workloadIndexActionsGenerator) ResolvedType() *types.T {
+	return workloadIndexActionsGeneratorType
+}
+
+// Start implements the eval.ValueGenerator interface.
+func (g *workloadIndexActionsGenerator) Start(ctx context.Context, _ *kv.Txn) (err error) {
+	g.actions, err = workloadindexrec.FindWorkloadActions(ctx, g.evalCtx, &g.since)
+	g.nextIndex = -1
+	return err
+}
+
+// Next implements the eval.ValueGenerator interface.
+func (g *workloadIndexActionsGenerator) Next(_ context.Context) (bool, error) {
+	g.nextIndex++
+	return g.nextIndex < len(g.actions), nil
+}
+
+// Values implements the eval.ValueGenerator interface.
+func (g *workloadIndexActionsGenerator) Values() (tree.Datums, error) {
+	action := &g.actions[g.nextIndex]
+	return tree.Datums{
+		tree.NewDString(action.Type.String()),
+		tree.NewDString(action.SQL),
+		tree.NewDString(action.Reason),
+		tree.NewDFloat(tree.DFloat(action.Benefit)),
+	}, nil
+}
+
+// Close implements the eval.ValueGenerator interface.
+func (g *workloadIndexActionsGenerator) Close(_ context.Context) {}
+
 func makeArrayGenerator(
 	_ context.Context, _ *eval.Context, args tree.Datums,
 ) (eval.ValueGenerator, error) {
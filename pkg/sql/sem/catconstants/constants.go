@@ -101,6 +101,7 @@ const (
 	MVCCStatistics                         SystemTableName = "mvcc_statistics"
 	StmtExecInsightsTableName              SystemTableName = "statement_execution_insights"
 	TxnExecInsightsTableName               SystemTableName = "transaction_execution_insights"
+	StatementPlanHintsTableName            SystemTableName = "statement_plan_hints"
 )
 
 // Oid for virtual database and table.
@@ -209,6 +210,8 @@ const (
 	CrdbInternalPCRStreamsTableID
 	CrdbInternalPCRStreamSpansTableID
 	CrdbInternalPCRStreamCheckpointsTableID
+	CrdbInternalHotKeysTableID
+	CrdbInternalEncryptionStatusTableID
 	InformationSchemaID
 	InformationSchemaAdministrableRoleAuthorizationsID
 	InformationSchemaApplicableRolesID
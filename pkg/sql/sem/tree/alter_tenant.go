@@ -245,6 +245,29 @@ func (n *AlterTenantService) Format(ctx *FmtCtx) {
 	}
 }
 
+// AlterTenantAdmission represents an ALTER VIRTUAL CLUSTER SET ADMISSION
+// statement. Exactly one of Weight and PriorityClass is set.
+type AlterTenantAdmission struct {
+	TenantSpec    *TenantSpec
+	Weight        Expr
+	PriorityClass Expr
+}
+
+var _ Statement = &AlterTenantAdmission{}
+
+// Format implements the NodeFormatter interface.
+func (n *AlterTenantAdmission) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER VIRTUAL CLUSTER ")
+	ctx.FormatNode(n.TenantSpec)
+	if n.Weight != nil {
+		ctx.WriteString(" SET ADMISSION WEIGHT = ")
+		ctx.FormatNode(n.Weight)
+	} else {
+		ctx.WriteString(" SET ADMISSION PRIORITY = ")
+		ctx.FormatNode(n.PriorityClass)
+	}
+}
+
 // AlterTenantReset represents an ALTER VIRTUAL CLUSTER RESET statement.
 type AlterTenantReset struct {
 	TenantSpec *TenantSpec
@@ -45,6 +45,7 @@ type BackupOptions struct {
 	EncryptionPassphrase            Expr
 	Detached                        *DBool
 	EncryptionKMSURI                StringOrPlaceholderOptList
+	EncryptionKMSURIPerDatabase     StringOrPlaceholderOptList
 	IncrementalStorage              StringOrPlaceholderOptList
 	ExecutionLocality               Expr
 	UpdatesClusterMonitoringMetrics Expr
@@ -130,6 +131,7 @@ func (node Backup) Coverage() DescriptorCoverage {
 type RestoreOptions struct {
 	EncryptionPassphrase             Expr
 	DecryptionKMSURI                 StringOrPlaceholderOptList
+	DecryptionKMSURIPerDatabase      StringOrPlaceholderOptList
 	IntoDB                           Expr
 	SkipMissingFKs                   bool
 	SkipMissingSequences             bool
@@ -297,6 +299,12 @@ func (o *BackupOptions) Format(ctx *FmtCtx) {
 		ctx.FormatNode(&o.EncryptionKMSURI)
 	}
 
+	if o.EncryptionKMSURIPerDatabase != nil {
+		maybeAddSep()
+		ctx.WriteString("kms_per_database = ")
+		ctx.FormatNode(&o.EncryptionKMSURIPerDatabase)
+	}
+
 	if o.IncrementalStorage != nil {
 		maybeAddSep()
 		ctx.WriteString("incremental_location = ")
@@ -353,6 +361,12 @@ func (o *BackupOptions) CombineWith(other *BackupOptions) error {
 		return errors.New("kms specified multiple times")
 	}
 
+	if o.EncryptionKMSURIPerDatabase == nil {
+		o.EncryptionKMSURIPerDatabase = other.EncryptionKMSURIPerDatabase
+	} else if other.EncryptionKMSURIPerDatabase != nil {
+		return errors.New("kms_per_database specified multiple times")
+	}
+
 	if o.IncrementalStorage == nil {
 		o.IncrementalStorage = other.IncrementalStorage
 	} else if other.IncrementalStorage != nil {
@@ -389,6 +403,7 @@ func (o BackupOptions) IsDefault() bool {
 	return o.CaptureRevisionHistory == options.CaptureRevisionHistory &&
 		(o.Detached == nil || o.Detached == DBoolFalse) &&
 		cmp.Equal(o.EncryptionKMSURI, options.EncryptionKMSURI) &&
+		cmp.Equal(o.EncryptionKMSURIPerDatabase, options.EncryptionKMSURIPerDatabase) &&
 		o.EncryptionPassphrase == options.EncryptionPassphrase &&
 		cmp.Equal(o.IncrementalStorage, options.IncrementalStorage) &&
 		o.ExecutionLocality == options.ExecutionLocality &&
@@ -421,6 +436,12 @@ func (o *RestoreOptions) Format(ctx *FmtCtx) {
 		ctx.FormatNode(&o.DecryptionKMSURI)
 	}
 
+	if o.DecryptionKMSURIPerDatabase != nil {
+		maybeAddSep()
+		ctx.WriteString("kms_per_database = ")
+		ctx.FormatNode(&o.DecryptionKMSURIPerDatabase)
+	}
+
 	if o.IntoDB != nil {
 		maybeAddSep()
 		ctx.WriteString("into_db = ")
@@ -538,6 +559,12 @@ func (o *RestoreOptions) CombineWith(other *RestoreOptions) error {
 		return errors.New("kms specified multiple times")
 	}
 
+	if o.DecryptionKMSURIPerDatabase == nil {
+		o.DecryptionKMSURIPerDatabase = other.DecryptionKMSURIPerDatabase
+	} else if other.DecryptionKMSURIPerDatabase != nil {
+		return errors.New("kms_per_database specified multiple times")
+	}
+
 	if o.IntoDB == nil {
 		o.IntoDB = other.IntoDB
 	} else if other.IntoDB != nil {
@@ -688,6 +715,7 @@ func (o RestoreOptions) IsDefault() bool {
 		o.SkipMissingViews == options.SkipMissingViews &&
 		o.SkipMissingUDFs == options.SkipMissingUDFs &&
 		cmp.Equal(o.DecryptionKMSURI, options.DecryptionKMSURI) &&
+		cmp.Equal(o.DecryptionKMSURIPerDatabase, options.DecryptionKMSURIPerDatabase) &&
 		o.EncryptionPassphrase == options.EncryptionPassphrase &&
 		o.IntoDB == options.IntoDB &&
 		o.Detached == options.Detached &&
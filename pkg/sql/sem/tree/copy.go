@@ -63,11 +63,38 @@ type CopyOptions struct {
 	Header      bool
 	Quote       *StrVal
 	Encoding    *StrVal
+	Freeze      bool
+
+	// ForceQuote, ForceNotNull and ForceNull are the columns the FORCE_QUOTE,
+	// FORCE_NOT_NULL and FORCE_NULL options apply to, or nil if the option was
+	// not specified.
+	ForceQuote   *CopyColumnList
+	ForceNotNull *CopyColumnList
+	ForceNull    *CopyColumnList
 
 	// Additional flags are needed to keep track of whether explicit default
 	// values were already set.
 	HasFormat bool
 	HasHeader bool
+	HasFreeze bool
+}
+
+// CopyColumnList is the list of columns a COPY option applies to.
+type CopyColumnList struct {
+	// All is set if the option applies to all columns (*).
+	All     bool
+	Columns NameList
+}
+
+// Format implements the NodeFormatter interface.
+func (l *CopyColumnList) Format(ctx *FmtCtx) {
+	if l.All {
+		ctx.WriteString("*")
+		return
+	}
+	ctx.WriteString("(")
+	ctx.FormatNode(&l.Columns)
+	ctx.WriteString(")")
 }
 
 var _ NodeFormatter = &CopyOptions{}
@@ -158,6 +185,30 @@ func (o *CopyOptions) Format(ctx *FmtCtx) {
 		ctx.WriteString("QUOTE ")
 		ctx.FormatNode(o.Quote)
 	}
+	if o.ForceQuote != nil {
+		maybeAddSep()
+		ctx.WriteString("FORCE_QUOTE ")
+		ctx.FormatNode(o.ForceQuote)
+	}
+	if o.ForceNotNull != nil {
+		maybeAddSep()
+		ctx.WriteString("FORCE_NOT_NULL ")
+		ctx.FormatNode(o.ForceNotNull)
+	}
+	if o.ForceNull != nil {
+		maybeAddSep()
+		ctx.WriteString("FORCE_NULL ")
+		ctx.FormatNode(o.ForceNull)
+	}
+	if o.HasFreeze {
+		maybeAddSep()
+		ctx.WriteString("FREEZE ")
+		if o.Freeze {
+			ctx.WriteString("true")
+		} else {
+			ctx.WriteString("false")
+		}
+	}
 	ctx.WriteString(")")
 }
 
@@ -219,6 +270,31 @@ func (o *CopyOptions) CombineWith(other *CopyOptions) error {
 		}
 		o.Quote = other.Quote
 	}
+	if other.ForceQuote != nil {
+		if o.ForceQuote != nil {
+			return pgerror.Newf(pgcode.Syntax, "force_quote option specified multiple times")
+		}
+		o.ForceQuote = other.ForceQuote
+	}
+	if other.ForceNotNull != nil {
+		if o.ForceNotNull != nil {
+			return pgerror.Newf(pgcode.Syntax, "force_not_null option specified multiple times")
+		}
+		o.ForceNotNull = other.ForceNotNull
+	}
+	if other.ForceNull != nil {
+		if o.ForceNull != nil {
+			return pgerror.Newf(pgcode.Syntax, "force_null option specified multiple times")
+		}
+		o.ForceNull = other.ForceNull
+	}
+	if other.HasFreeze {
+		if o.HasFreeze {
+			return pgerror.Newf(pgcode.Syntax, "freeze option specified multiple times")
+		}
+		o.Freeze = other.Freeze
+		o.HasFreeze = true
+	}
 	return nil
 }
 
@@ -2033,7 +2033,10 @@ func (node *RefreshMaterializedView) Format(ctx *FmtCtx) {
 
 // CreateStats represents a CREATE STATISTICS statement.
 type CreateStats struct {
-	Name        Name
+	Name Name
+	// Kinds lists the kinds of extended statistics to collect on the columns,
+	// e.g. dependencies or mcv. It is empty if none were requested.
+	Kinds       NameList
 	ColumnNames NameList
 	Table       TableExpr
 	Options     CreateStatsOptions
@@ -2044,6 +2047,12 @@ func (node *CreateStats) Format(ctx *FmtCtx) {
 	ctx.WriteString("CREATE STATISTICS ")
 	ctx.FormatNode(&node.Name)
 
+	if len(node.Kinds) > 0 {
+		ctx.WriteString(" (")
+		ctx.FormatNode(&node.Kinds)
+		ctx.WriteByte(')')
+	}
+
 	if len(node.ColumnNames) > 0 {
 		ctx.WriteString(" ON ")
 		ctx.FormatNode(&node.ColumnNames)
@@ -2182,6 +2191,132 @@ func (node *CreateExternalConnection) Format(ctx *FmtCtx) {
 	ctx.FormatNode(node.As)
 }
 
+// CreatePlanBaseline represents a CREATE PLAN BASELINE statement. Exactly one
+// of PlanGist and Hints is set.
+type CreatePlanBaseline struct {
+	Fingerprint Expr
+	PlanGist    Expr
+	Hints       Expr
+}
+
+var _ Statement = &CreatePlanBaseline{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreatePlanBaseline) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE PLAN BASELINE FOR ")
+	ctx.FormatNode(node.Fingerprint)
+	if node.PlanGist != nil {
+		ctx.WriteString(" USING PLAN ")
+		ctx.FormatNode(node.PlanGist)
+	} else {
+		ctx.WriteString(" USING HINTS ")
+		ctx.FormatNode(node.Hints)
+	}
+}
+
+// PolicyType indicates how a row-level security policy is combined with the
+// other policies on a table.
+type PolicyType int
+
+const (
+	// PolicyTypeDefault is used when the AS clause is omitted. It is
+	// equivalent to PolicyTypePermissive.
+	PolicyTypeDefault PolicyType = iota
+	// PolicyTypePermissive policies are combined with OR.
+	PolicyTypePermissive
+	// PolicyTypeRestrictive policies are combined with AND.
+	PolicyTypeRestrictive
+)
+
+// String implements the fmt.Stringer interface.
+func (p PolicyType) String() string {
+	switch p {
+	case PolicyTypePermissive:
+		return "PERMISSIVE"
+	case PolicyTypeRestrictive:
+		return "RESTRICTIVE"
+	}
+	return ""
+}
+
+// PolicyCommand is the command to which a row-level security policy applies.
+type PolicyCommand int
+
+const (
+	// PolicyCommandDefault is used when the FOR clause is omitted. It is
+	// equivalent to PolicyCommandAll.
+	PolicyCommandDefault PolicyCommand = iota
+	PolicyCommandAll
+	PolicyCommandSelect
+	PolicyCommandInsert
+	PolicyCommandUpdate
+	PolicyCommandDelete
+)
+
+// String implements the fmt.Stringer interface.
+func (p PolicyCommand) String() string {
+	switch p {
+	case PolicyCommandAll:
+		return "ALL"
+	case PolicyCommandSelect:
+		return "SELECT"
+	case PolicyCommandInsert:
+		return "INSERT"
+	case PolicyCommandUpdate:
+		return "UPDATE"
+	case PolicyCommandDelete:
+		return "DELETE"
+	}
+	return ""
+}
+
+// CreatePolicy represents a CREATE POLICY statement.
+type CreatePolicy struct {
+	PolicyName Name
+	TableName  *UnresolvedObjectName
+	Type       PolicyType
+	Cmd        PolicyCommand
+	Roles      RoleSpecList
+	// Using is the expression used to filter existing rows, or nil if there is
+	// no USING clause.
+	Using Expr
+	// WithCheck is the expression that new rows must satisfy, or nil if there
+	// is no WITH CHECK clause.
+	WithCheck Expr
+}
+
+var _ Statement = &CreatePolicy{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreatePolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE POLICY ")
+	ctx.FormatNode(&node.PolicyName)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	if node.Type != PolicyTypeDefault {
+		ctx.WriteString(" AS ")
+		ctx.WriteString(node.Type.String())
+	}
+	if node.Cmd != PolicyCommandDefault {
+		ctx.WriteString(" FOR ")
+		ctx.WriteString(node.Cmd.String())
+	}
+	if len(node.Roles) > 0 {
+		ctx.WriteString(" TO ")
+		ctx.FormatNode(&node.Roles)
+	}
+	if node.Using != nil {
+		ctx.WriteString(" USING (")
+		ctx.FormatNode(node.Using)
+		ctx.WriteString(")")
+	}
+	if node.WithCheck != nil {
+		ctx.WriteString(" WITH CHECK (")
+		ctx.FormatNode(node.WithCheck)
+		ctx.WriteString(")")
+	}
+}
+
 // CreateTenant represents a CREATE VIRTUAL CLUSTER statement.
 type CreateTenant struct {
 	IfNotExists bool
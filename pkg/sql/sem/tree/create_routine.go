@@ -628,6 +628,7 @@ const (
 	TTLExpirationExpr               SchemaExprContext = "TTL EXPIRATION EXPRESSION"
 	TTLDefaultExpr                  SchemaExprContext = "TTL DEFAULT"
 	TTLUpdateExpr                   SchemaExprContext = "TTL UPDATE"
+	PolicyExpr                      SchemaExprContext = "POLICY EXPRESSION"
 )
 
 func ComputedColumnExprContext(isVirtual bool) SchemaExprContext {
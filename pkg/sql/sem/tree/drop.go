@@ -235,6 +235,49 @@ func (node *DropExternalConnection) Format(ctx *FmtCtx) {
 	}
 }
 
+// DropPlanBaseline represents a DROP PLAN BASELINE statement.
+type DropPlanBaseline struct {
+	Fingerprint Expr
+	IfExists    bool
+}
+
+var _ Statement = &DropPlanBaseline{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropPlanBaseline) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP PLAN BASELINE ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.WriteString("FOR ")
+	ctx.FormatNode(node.Fingerprint)
+}
+
+// DropPolicy represents a DROP POLICY statement.
+type DropPolicy struct {
+	PolicyName   Name
+	TableName    *UnresolvedObjectName
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropPolicy{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropPolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP POLICY ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.PolicyName)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteString(" ")
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
 // DropTenant represents a DROP VIRTUAL CLUSTER command.
 type DropTenant struct {
 	TenantSpec *TenantSpec
@@ -122,19 +122,21 @@ const (
 	ExplainFlagShape
 	ExplainFlagViz
 	ExplainFlagRedact
+	ExplainFlagDistribution
 	numExplainFlags = iota
 )
 
 var explainFlagStrings = [...]string{
-	ExplainFlagVerbose: "VERBOSE",
-	ExplainFlagTypes:   "TYPES",
-	ExplainFlagEnv:     "ENV",
-	ExplainFlagCatalog: "CATALOG",
-	ExplainFlagJSON:    "JSON",
-	ExplainFlagMemo:    "MEMO",
-	ExplainFlagShape:   "SHAPE",
-	ExplainFlagViz:     "VIZ",
-	ExplainFlagRedact:  "REDACT",
+	ExplainFlagVerbose:      "VERBOSE",
+	ExplainFlagTypes:        "TYPES",
+	ExplainFlagEnv:          "ENV",
+	ExplainFlagCatalog:      "CATALOG",
+	ExplainFlagJSON:         "JSON",
+	ExplainFlagMemo:         "MEMO",
+	ExplainFlagShape:        "SHAPE",
+	ExplainFlagViz:          "VIZ",
+	ExplainFlagRedact:       "REDACT",
+	ExplainFlagDistribution: "DISTRIBUTION",
 }
 
 var explainFlagStringMap = func() map[string]ExplainFlag {
@@ -279,6 +281,15 @@ func MakeExplain(options []string, stmt Statement) (Statement, error) {
 		}
 	}
 
+	if opts.Flags[ExplainFlagDistribution] {
+		if opts.Mode != ExplainPlan {
+			return nil, pgerror.Newf(pgcode.Syntax, "the DISTRIBUTION flag can only be used with PLAN")
+		}
+		if analyze {
+			return nil, pgerror.Newf(pgcode.Syntax, "the DISTRIBUTION flag cannot be used with ANALYZE")
+		}
+	}
+
 	if opts.Flags[ExplainFlagRedact] {
 		// TODO(michae2): Support redaction of other EXPLAIN modes.
 		switch opts.Mode {
@@ -321,6 +321,7 @@ type FamilyID = catid.FamilyID
 //   - FORCE_ZIGZAG
 //   - FORCE_ZIGZAG=<index_name|index_id>*
 //   - FAMILY=[family_id]
+//   - RESULT_CACHE
 //
 // It is used optionally after a table name in SELECT statements.
 type IndexFlags struct {
@@ -357,6 +358,11 @@ type IndexFlags struct {
 	// Restrict select to the specified column family.
 	// Used by changefeed.
 	FamilyID *FamilyID
+
+	// ResultCache indicates that the results of the statement may be stored
+	// in and served from the result cache, even if the result_cache_enabled
+	// session variable is not set.
+	ResultCache bool
 }
 
 // ForceIndex returns true if a forced index was specified, either using a name
@@ -386,6 +392,9 @@ func (ih *IndexFlags) CombineWith(other *IndexFlags) error {
 	if ih.ForceInvertedIndex && other.ForceInvertedIndex {
 		return errors.New("FORCE_INVERTED_INDEX specified multiple times")
 	}
+	if ih.ResultCache && other.ResultCache {
+		return errors.New("RESULT_CACHE specified multiple times")
+	}
 	result := *ih
 	result.NoIndexJoin = ih.NoIndexJoin || other.NoIndexJoin
 	result.NoZigzagJoin = ih.NoZigzagJoin || other.NoZigzagJoin
@@ -394,6 +403,7 @@ func (ih *IndexFlags) CombineWith(other *IndexFlags) error {
 	result.IgnoreUniqueWithoutIndexKeys = ih.IgnoreUniqueWithoutIndexKeys ||
 		other.IgnoreUniqueWithoutIndexKeys
 	result.ForceInvertedIndex = ih.ForceInvertedIndex || other.ForceInvertedIndex
+	result.ResultCache = ih.ResultCache || other.ResultCache
 
 	if other.Direction != 0 {
 		if ih.Direction != 0 {
@@ -572,6 +582,11 @@ func (ih *IndexFlags) Format(ctx *FmtCtx) {
 		if ih.FamilyID != nil {
 			ctx.Printf("FAMILY=[%d]", *ih.FamilyID)
 		}
+
+		if ih.ResultCache {
+			sep()
+			ctx.WriteString("RESULT_CACHE")
+		}
 		ctx.WriteString("}")
 	}
 }
@@ -579,7 +594,7 @@ func (ih *IndexFlags) Format(ctx *FmtCtx) {
 func (ih *IndexFlags) indexOnlyHint() bool {
 	return !ih.NoIndexJoin && !ih.NoZigzagJoin && !ih.NoFullScan && !ih.IgnoreForeignKeys &&
 		!ih.IgnoreUniqueWithoutIndexKeys && ih.Direction == 0 && !ih.ForceInvertedIndex &&
-		!ih.zigzagForced() && ih.FamilyID == nil
+		!ih.zigzagForced() && ih.FamilyID == nil && !ih.ResultCache
 }
 
 func (ih *IndexFlags) zigzagForced() bool {
@@ -555,6 +555,15 @@ func (*AlterTenantReset) StatementTag() string { return "ALTER VIRTUAL CLUSTER R
 
 func (*AlterTenantReset) cclOnlyStatement() {}
 
+// StatementReturnType implements the Statement interface.
+func (*AlterTenantAdmission) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*AlterTenantAdmission) StatementType() StatementType { return TypeDCL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterTenantAdmission) StatementTag() string { return "ALTER VIRTUAL CLUSTER ADMISSION" }
+
 // StatementReturnType implements the Statement interface.
 func (*AlterTenantService) StatementReturnType() StatementReturnType { return Ack }
 
@@ -889,6 +898,24 @@ func (*CreateExternalConnection) StatementType() StatementType { return TypeDDL
 // StatementTag returns a short string identifying the type of statement.
 func (*CreateExternalConnection) StatementTag() string { return "CREATE EXTERNAL CONNECTION" }
 
+// StatementReturnType implements the Statement interface.
+func (*CreatePlanBaseline) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*CreatePlanBaseline) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreatePlanBaseline) StatementTag() string { return "CREATE PLAN BASELINE" }
+
+// StatementReturnType implements the Statement interface.
+func (*CreatePolicy) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreatePolicy) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreatePolicy) StatementTag() string { return "CREATE POLICY" }
+
 // StatementReturnType implements the Statement interface.
 func (*CreateTenant) StatementReturnType() StatementReturnType { return Ack }
 
@@ -920,6 +947,24 @@ func (*DropExternalConnection) StatementType() StatementType { return TypeDDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*DropExternalConnection) StatementTag() string { return "DROP EXTERNAL CONNECTION" }
 
+// StatementReturnType implements the Statement interface.
+func (*DropPlanBaseline) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*DropPlanBaseline) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropPlanBaseline) StatementTag() string { return "DROP PLAN BASELINE" }
+
+// StatementReturnType implements the Statement interface.
+func (*DropPolicy) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*DropPolicy) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropPolicy) StatementTag() string { return "DROP POLICY" }
+
 // StatementReturnType implements the Statement interface.
 func (*CreateIndex) StatementReturnType() StatementReturnType { return DDL }
 
@@ -2283,6 +2328,7 @@ func (n *AlterTableSetVisible) String() string                { return AsString(
 func (n *AlterTableSetNotNull) String() string                { return AsString(n) }
 func (n *AlterTableOwner) String() string                     { return AsString(n) }
 func (n *AlterTableSetSchema) String() string                 { return AsString(n) }
+func (n *AlterTenantAdmission) String() string                { return AsString(n) }
 func (n *AlterTenantCapability) String() string               { return AsString(n) }
 func (n *AlterTenantSetClusterSetting) String() string        { return AsString(n) }
 func (n *AlterTenantReset) String() string                    { return AsString(n) }
@@ -2347,6 +2393,10 @@ func (n *ExplainAnalyze) String() string                      { return AsString(
 func (n *Export) String() string                              { return AsString(n) }
 func (n *CreateExternalConnection) String() string            { return AsString(n) }
 func (n *DropExternalConnection) String() string              { return AsString(n) }
+func (n *CreatePlanBaseline) String() string                  { return AsString(n) }
+func (n *DropPlanBaseline) String() string                    { return AsString(n) }
+func (n *CreatePolicy) String() string                        { return AsString(n) }
+func (n *DropPolicy) String() string                          { return AsString(n) }
 func (n *FetchCursor) String() string                         { return AsString(n) }
 func (n *Grant) String() string                               { return AsString(n) }
 func (n *GrantRole) String() string                           { return AsString(n) }
@@ -1275,6 +1275,43 @@ func (n *AlterTenantService) walkStmt(v Visitor) Statement {
 	return ret
 }
 
+// copyNode makes a copy of this Statement without recursing in any child Statements.
+func (n *AlterTenantAdmission) copyNode() *AlterTenantAdmission {
+	stmtCopy := *n
+	return &stmtCopy
+}
+
+// walkStmt is part of the walkableStmt interface.
+func (n *AlterTenantAdmission) walkStmt(v Visitor) Statement {
+	ret := n
+	ts, changed := walkTenantSpec(v, n.TenantSpec)
+	if changed {
+		if ret == n {
+			ret = n.copyNode()
+		}
+		ret.TenantSpec = ts
+	}
+	if n.Weight != nil {
+		e, changed := WalkExpr(v, n.Weight)
+		if changed {
+			if ret == n {
+				ret = n.copyNode()
+			}
+			ret.Weight = e
+		}
+	}
+	if n.PriorityClass != nil {
+		e, changed := WalkExpr(v, n.PriorityClass)
+		if changed {
+			if ret == n {
+				ret = n.copyNode()
+			}
+			ret.PriorityClass = e
+		}
+	}
+	return ret
+}
+
 // copyNode makes a copy of this Statement without recursing in any child Statements.
 func (n *DropTenant) copyNode() *DropTenant {
 	stmtCopy := *n
@@ -2050,6 +2087,7 @@ func (stmt *BeginTransaction) walkStmt(v Visitor) Statement {
 	return ret
 }
 
+var _ walkableStmt = &AlterTenantAdmission{}
 var _ walkableStmt = &AlterTenantCapability{}
 var _ walkableStmt = &AlterTenantRename{}
 var _ walkableStmt = &AlterTenantReplication{}
@@ -115,6 +115,52 @@ func DistSQLExecModeFromString(val string) (_ DistSQLExecMode, ok bool) {
 	}
 }
 
+// PlanCacheMode controls whether the optimizer uses a custom or a generic query
+// plan to execute a prepared statement.
+// NB: The values of the enums must be stable across releases.
+type PlanCacheMode int64
+
+const (
+	// PlanCacheModeForceCustom means that a custom plan is built for every
+	// execution of a prepared statement, optimized for its placeholder values.
+	PlanCacheModeForceCustom PlanCacheMode = 0
+	// PlanCacheModeForceGeneric means that a generic plan, with placeholders
+	// left unassigned, is fully optimized once and reused for every execution
+	// of a prepared statement.
+	PlanCacheModeForceGeneric PlanCacheMode = 1
+	// PlanCacheModeAuto means that a generic plan is used if its estimated cost
+	// is lower than the average estimated cost of the custom plans built so
+	// far, including the cost of optimizing them.
+	PlanCacheModeAuto PlanCacheMode = 2
+)
+
+func (m PlanCacheMode) String() string {
+	switch m {
+	case PlanCacheModeForceCustom:
+		return "force_custom_plan"
+	case PlanCacheModeForceGeneric:
+		return "force_generic_plan"
+	case PlanCacheModeAuto:
+		return "auto"
+	default:
+		return fmt.Sprintf("invalid (%d)", m)
+	}
+}
+
+// PlanCacheModeFromString converts a string into a PlanCacheMode.
+func PlanCacheModeFromString(val string) (_ PlanCacheMode, ok bool) {
+	switch strings.ToUpper(val) {
+	case "FORCE_CUSTOM_PLAN":
+		return PlanCacheModeForceCustom, true
+	case "FORCE_GENERIC_PLAN":
+		return PlanCacheModeForceGeneric, true
+	case "AUTO":
+		return PlanCacheModeAuto, true
+	default:
+		return 0, false
+	}
+}
+
 // SerialNormalizationMode controls if and when the Executor uses DistSQL.
 // NB: The values of the enums must be stable across releases.
 type SerialNormalizationMode int64
@@ -113,7 +113,8 @@ func (p *planner) ShowTableStats(ctx context.Context, n *tree.ShowTableStats) (p
 							"avgSize",
 							"partialPredicate",
 							histogram,
-							"fullStatisticID"
+							"fullStatisticID",
+							` + stats.ExtendedStatsColumnExpr(ctx, p.ExecCfg().Settings) + `
 						FROM system.table_statistics
 						WHERE "tableID" = $1
 						ORDER BY "createdAt", "columnIDs", "statisticID"`
@@ -147,6 +148,7 @@ func (p *planner) ShowTableStats(ctx context.Context, n *tree.ShowTableStats) (p
 				partialPredicateIdx
 				histogramIdx
 				fullStatisticIDIdx
+				extendedStatsIdx
 				numCols
 			)
 
@@ -274,6 +276,10 @@ func (p *planner) ShowTableStats(ctx context.Context, n *tree.ShowTableStats) (p
 						v.Close(ctx)
 						return nil, err
 					}
+					if err := statsRow.DecodeAndSetExtendedStats(ctx, &p.semaCtx, r[extendedStatsIdx]); err != nil {
+						v.Close(ctx)
+						return nil, err
+					}
 					result = append(result, statsRow)
 				}
 				encoded, err := encjson.Marshal(result)
@@ -403,5 +409,15 @@ func tableStatisticProtoToRow(stat *stats.TableStatisticProto) (tree.Datums, err
 		row = append(row, tree.NewDBytes(tree.DBytes(histogram)))
 	}
 	row = append(row, FullStatisticID)
+
+	if stat.ExtendedStats == nil {
+		row = append(row, tree.DNull)
+	} else {
+		extendedStats, err := protoutil.Marshal(stat.ExtendedStats)
+		if err != nil {
+			return nil, err
+		}
+		row = append(row, tree.NewDBytes(tree.DBytes(extendedStats)))
+	}
 	return row, nil
 }
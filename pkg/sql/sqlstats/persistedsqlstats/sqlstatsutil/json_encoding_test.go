@@ -104,6 +104,7 @@ func TestSQLStatsJsonEncoding(t *testing.T) {
          "regions": [{{joinStrings .StringArray}}],
          "planGists": [{{joinStrings .StringArray}}],
          "indexes": [{{joinStrings .StringArray}}],
+         "idxRecCostReduction": {{.Float}},
          "latencyInfo": {
            "min": {{.Float}},
            "max": {{.Float}},
@@ -317,6 +317,7 @@ func (s *innerStmtStats) jsonFields() jsonFields {
 		{"regions", (*stringArray)(&s.Regions)},
 		{"planGists", (*stringArray)(&s.PlanGists)},
 		{"indexes", (*stringArray)(&s.Indexes)},
+		{"idxRecCostReduction", (*jsonFloat)(&s.IndexRecommendationsCostReduction)},
 		{"latencyInfo", (*latencyInfo)(&s.LatencyInfo)},
 		{"lastErrorCode", (*jsonString)(&s.LastErrorCode)},
 		{"failureCount", (*jsonInt)(&s.FailureCount)},
@@ -144,6 +144,7 @@ func (s *Container) RecordStatement(
 	}
 	stats.mu.data.PlanGists = util.CombineUnique(stats.mu.data.PlanGists, []string{value.PlanGist})
 	stats.mu.data.IndexRecommendations = value.IndexRecommendations
+	stats.mu.data.IndexRecommendationsCostReduction = value.IndexRecommendationsCostReduction
 	stats.mu.data.Indexes = util.CombineUnique(stats.mu.data.Indexes, value.Indexes)
 
 	// Percentile latencies are only being sampled if the latency was above the
@@ -218,13 +218,16 @@ type RecordedStmtStats struct {
 	PlanGist             string
 	StatementError       error
 	IndexRecommendations []string
-	Query                string
-	StartTime            time.Time
-	EndTime              time.Time
-	FullScan             bool
-	ExecStats            *execstats.QueryLevelStats
-	Indexes              []string
-	Database             string
+	// IndexRecommendationsCostReduction is the fraction of the estimated plan
+	// cost saved by applying all of IndexRecommendations.
+	IndexRecommendationsCostReduction float64
+	Query                             string
+	StartTime                         time.Time
+	EndTime                           time.Time
+	FullScan                          bool
+	ExecStats                         *execstats.QueryLevelStats
+	Indexes                           []string
+	Database                          string
 }
 
 // RecordedTxnStats stores the statistics of a transaction to be recorded.
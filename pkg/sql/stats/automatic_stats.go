@@ -72,6 +72,15 @@ var MultiColumnStatisticsClusterMode = settings.RegisterBoolSetting(
 	true,
 	settings.WithPublic)
 
+// ExtendedStatisticsClusterMode controls the cluster setting for enabling
+// automatic collection of extended statistics on multi-column statistics.
+var ExtendedStatisticsClusterMode = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.stats.extended_statistics_collection.enabled",
+	"extended statistics (functional dependencies and most common values) collection mode for multi-column statistics",
+	true,
+	settings.WithPublic)
+
 // AutomaticStatisticsMaxIdleTime controls the maximum fraction of time that
 // the sampler processors will be idle when scanning large tables for automatic
 // statistics (in high load scenarios). This value can be tuned to trade off
@@ -0,0 +1,263 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package stats
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// maxMostCommonValues is the maximum number of value combinations kept in the
+// most common values list of an extended statistic.
+const maxMostCommonValues = 100
+
+// minMostCommonValueCount is the minimum number of times a value combination
+// must appear in the sample to be considered one of the most common values. A
+// combination seen only once is no more common than any unsampled one.
+const minMostCommonValueCount = 2
+
+// ExtendedStatsColumnExpr returns the expression used to select the
+// extendedStats column of system.table_statistics. NULL is selected in its
+// place until the column is guaranteed to exist on all nodes.
+func ExtendedStatsColumnExpr(ctx context.Context, st *cluster.Settings) string {
+	if st.Version.IsActive(ctx, clusterversion.V24_1_TableStatisticsExtendedStats) {
+		return `"extendedStats"`
+	}
+	return `NULL::BYTES AS "extendedStats"`
+}
+
+// BuildExtendedStatistics builds the extended statistics on a set of columns
+// from a sample of rows. colIdxs are the ordinals of the statistic's columns
+// in the sampled rows, and colTypes are their types. Functional dependencies
+// are only computed if dependencies is true, and most common values are only
+// computed if mostCommonValues is true. It returns nil if there are no
+// samples.
+func BuildExtendedStatistics(
+	compareCtx tree.CompareContext,
+	samples []SampledRow,
+	colIdxs []int,
+	colTypes []*types.T,
+	dependencies, mostCommonValues bool,
+) (*ExtendedStatisticsData, error) {
+	if len(samples) == 0 || len(colIdxs) < 2 {
+		return nil, nil
+	}
+	if len(colIdxs) != len(colTypes) {
+		return nil, errors.AssertionFailedf(
+			"expected %d column types, found %d", len(colIdxs), len(colTypes),
+		)
+	}
+	rows := make([]tree.Datums, len(samples))
+	for i := range samples {
+		row := make(tree.Datums, len(colIdxs))
+		for j, c := range colIdxs {
+			row[j] = samples[i].Row[c].Datum
+			if row[j] == nil {
+				return nil, errors.AssertionFailedf("sampled column %d was not decoded", c)
+			}
+		}
+		rows[i] = row
+	}
+
+	ext := &ExtendedStatisticsData{ColumnTypes: colTypes}
+	if dependencies {
+		ext.Dependencies = buildFunctionalDependencies(compareCtx, rows)
+	}
+	if mostCommonValues {
+		var err error
+		if ext.MostCommonValues, err = buildMostCommonValues(compareCtx, rows); err != nil {
+			return nil, err
+		}
+	}
+	return ext, nil
+}
+
+// buildFunctionalDependencies computes the degree of the functional dependency
+// between every ordered pair of columns in rows. The degree of the dependency
+// a => b is the fraction of rows that belong to a group of rows with the same
+// value of a and a single value of b. Rows with a NULL in either column are
+// ignored.
+func buildFunctionalDependencies(
+	compareCtx tree.CompareContext, rows []tree.Datums,
+) []ExtendedStatisticsData_FunctionalDependency {
+	var deps []ExtendedStatisticsData_FunctionalDependency
+	numCols := len(rows[0])
+	pairs := make([][2]tree.Datum, 0, len(rows))
+	for from := 0; from < numCols; from++ {
+		for to := 0; to < numCols; to++ {
+			if from == to {
+				continue
+			}
+			pairs = pairs[:0]
+			for _, row := range rows {
+				if row[from] == tree.DNull || row[to] == tree.DNull {
+					continue
+				}
+				pairs = append(pairs, [2]tree.Datum{row[from], row[to]})
+			}
+			if len(pairs) == 0 {
+				continue
+			}
+			sort.Slice(pairs, func(i, j int) bool {
+				if c := pairs[i][0].Compare(compareCtx, pairs[j][0]); c != 0 {
+					return c < 0
+				}
+				return pairs[i][1].Compare(compareCtx, pairs[j][1]) < 0
+			})
+			// Since the pairs are sorted on both columns, a group of rows with the
+			// same value of the determining column has a single value of the
+			// dependent column iff its first and last rows agree.
+			var consistent int
+			for start := 0; start < len(pairs); {
+				end := start + 1
+				for end < len(pairs) && pairs[end][0].Compare(compareCtx, pairs[start][0]) == 0 {
+					end++
+				}
+				if pairs[start][1].Compare(compareCtx, pairs[end-1][1]) == 0 {
+					consistent += end - start
+				}
+				start = end
+			}
+			if consistent == 0 {
+				continue
+			}
+			deps = append(deps, ExtendedStatisticsData_FunctionalDependency{
+				From:   uint32(from),
+				To:     uint32(to),
+				Degree: float64(consistent) / float64(len(pairs)),
+			})
+		}
+	}
+	return deps
+}
+
+// buildMostCommonValues finds the most frequent combinations of values in
+// rows, ordered by descending frequency. Combinations that include a NULL are
+// ignored, but still count towards the total number of rows.
+func buildMostCommonValues(
+	compareCtx tree.CompareContext, rows []tree.Datums,
+) ([]ExtendedStatisticsData_MCVItem, error) {
+	nonNull := make([]tree.Datums, 0, len(rows))
+	for _, row := range rows {
+		hasNull := false
+		for _, d := range row {
+			if d == tree.DNull {
+				hasNull = true
+				break
+			}
+		}
+		if !hasNull {
+			nonNull = append(nonNull, row)
+		}
+	}
+	sort.Slice(nonNull, func(i, j int) bool {
+		return nonNull[i].Compare(compareCtx, nonNull[j]) < 0
+	})
+
+	type group struct {
+		row   tree.Datums
+		count int
+	}
+	var groups []group
+	for start := 0; start < len(nonNull); {
+		end := start + 1
+		for end < len(nonNull) && nonNull[end].Compare(compareCtx, nonNull[start]) == 0 {
+			end++
+		}
+		if end-start >= minMostCommonValueCount {
+			groups = append(groups, group{row: nonNull[start], count: end - start})
+		}
+		start = end
+	}
+	// The sort is stable so that ties are broken by value order, which keeps
+	// the result deterministic for a given sample.
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].count > groups[j].count
+	})
+	if len(groups) > maxMostCommonValues {
+		groups = groups[:maxMostCommonValues]
+	}
+
+	items := make([]ExtendedStatisticsData_MCVItem, len(groups))
+	for i, g := range groups {
+		items[i].Values = make([][]byte, len(g.row))
+		for j, d := range g.row {
+			enc, err := valueside.Encode(nil /* appendTo */, valueside.NoColumnID, d, nil /* scratch */)
+			if err != nil {
+				return nil, err
+			}
+			items[i].Values[j] = enc
+		}
+		items[i].Frequency = float64(g.count) / float64(len(rows))
+	}
+	return items, nil
+}
+
+// DecodeExtendedStats decodes the encoded ExtendedStats in tabStat and writes
+// the resulting dependencies and most common values into
+// tabStat.Dependencies and tabStat.MostCommonValues. Any user-defined types
+// in ExtendedStats must already be resolved.
+func DecodeExtendedStats(tabStat *TableStatistic) error {
+	ext := tabStat.ExtendedStats
+	if ext == nil {
+		return nil
+	}
+	numCols := len(tabStat.ColumnIDs)
+	if len(ext.ColumnTypes) != numCols {
+		return errors.Errorf(
+			"extended statistics have %d column types, expected %d", len(ext.ColumnTypes), numCols,
+		)
+	}
+
+	tabStat.Dependencies = make([]cat.StatisticDependency, len(ext.Dependencies))
+	for i, dep := range ext.Dependencies {
+		if int(dep.From) >= numCols || int(dep.To) >= numCols || dep.From == dep.To {
+			return errors.Errorf("invalid functional dependency %d => %d", dep.From, dep.To)
+		}
+		tabStat.Dependencies[i] = cat.StatisticDependency{
+			From:   int(dep.From),
+			To:     int(dep.To),
+			Degree: dep.Degree,
+		}
+	}
+
+	var a tree.DatumAlloc
+	tabStat.MostCommonValues = make([]cat.StatisticMCV, len(ext.MostCommonValues))
+	for i := range ext.MostCommonValues {
+		item := &ext.MostCommonValues[i]
+		if len(item.Values) != numCols {
+			return errors.Errorf(
+				"most common value has %d values, expected %d", len(item.Values), numCols,
+			)
+		}
+		values := make(tree.Datums, numCols)
+		for j, enc := range item.Values {
+			d, _, err := valueside.Decode(&a, ext.ColumnTypes[j], enc)
+			if err != nil {
+				return err
+			}
+			values[j] = d
+		}
+		tabStat.MostCommonValues[i] = cat.StatisticMCV{
+			Values:    values,
+			Frequency: item.Frequency,
+		}
+	}
+	return nil
+}
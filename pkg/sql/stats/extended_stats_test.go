@@ -0,0 +1,108 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package stats
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+func TestBuildExtendedStatistics(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	evalCtx := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+
+	// The first column is not part of the statistic, so that the mapping from
+	// the statistic's columns to the sampled row is exercised.
+	intOrNull := func(i int) tree.Datum {
+		if i < 0 {
+			return tree.DNull
+		}
+		return tree.NewDInt(tree.DInt(i))
+	}
+	var samples []SampledRow
+	for _, r := range [][2]int{
+		{1, 10}, {1, 10}, {1, 10},
+		{2, 20}, {2, 20},
+		{3, 20},
+		{4, 30}, {4, 31},
+		{-1, 10},
+		{5, -1},
+	} {
+		samples = append(samples, SampledRow{Row: rowenc.EncDatumRow{
+			rowenc.DatumToEncDatum(types.Int, tree.NewDInt(0)),
+			rowenc.DatumToEncDatum(types.Int, intOrNull(r[0])),
+			rowenc.DatumToEncDatum(types.Int, intOrNull(r[1])),
+		}})
+	}
+	colTypes := []*types.T{types.Int, types.Int}
+
+	ext, err := BuildExtendedStatistics(
+		&evalCtx, samples, []int{1, 2}, colTypes, true /* dependencies */, true, /* mostCommonValues */
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Rows with a NULL in either column are ignored. Of the remaining 8 rows,
+	// only those with a = 4 do not determine b. Every value of b but 20
+	// determines a.
+	expDeps := []ExtendedStatisticsData_FunctionalDependency{
+		{From: 0, To: 1, Degree: 6.0 / 8.0},
+		{From: 1, To: 0, Degree: 5.0 / 8.0},
+	}
+	if !reflect.DeepEqual(ext.Dependencies, expDeps) {
+		t.Errorf("expected dependencies %v, got %v", expDeps, ext.Dependencies)
+	}
+
+	tabStat := &TableStatistic{TableStatisticProto: TableStatisticProto{
+		ColumnIDs:     []descpb.ColumnID{2, 3},
+		ExtendedStats: ext,
+	}}
+	if err := DecodeExtendedStats(tabStat); err != nil {
+		t.Fatal(err)
+	}
+
+	// Combinations seen only once are not considered common. The frequencies
+	// are relative to all sampled rows, including those with NULLs.
+	expMCVs := []cat.StatisticMCV{
+		{Values: tree.Datums{tree.NewDInt(1), tree.NewDInt(10)}, Frequency: 0.3},
+		{Values: tree.Datums{tree.NewDInt(2), tree.NewDInt(20)}, Frequency: 0.2},
+	}
+	if len(tabStat.MostCommonValues) != len(expMCVs) {
+		t.Fatalf("expected %d most common values, got %d", len(expMCVs), len(tabStat.MostCommonValues))
+	}
+	for i, exp := range expMCVs {
+		actual := tabStat.MostCommonValues[i]
+		if actual.Values.Compare(&evalCtx, exp.Values) != 0 || actual.Frequency != exp.Frequency {
+			t.Errorf("expected most common value %v, got %v", exp, actual)
+		}
+	}
+
+	expDecodedDeps := []cat.StatisticDependency{
+		{From: 0, To: 1, Degree: 6.0 / 8.0},
+		{From: 1, To: 0, Degree: 5.0 / 8.0},
+	}
+	if !reflect.DeepEqual(tabStat.Dependencies, expDecodedDeps) {
+		t.Errorf("expected decoded dependencies %v, got %v", expDecodedDeps, tabStat.Dependencies)
+	}
+}
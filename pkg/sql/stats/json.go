@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -43,6 +44,12 @@ type JSONStatistic struct {
 	HistogramVersion    HistogramVersion  `json:"histo_version,omitempty"`
 	PartialPredicate    string            `json:"partial_predicate,omitempty"`
 	FullStatisticID     uint64            `json:"full_statistic_id,omitempty"`
+	// ExtendedStatsColumnTypes are the string representations of the column
+	// types for the extended statistics (or unset if there are none). Parsable
+	// with tree.GetTypeFromValidSQLSyntax.
+	ExtendedStatsColumnTypes []string         `json:"ext_col_types,omitempty"`
+	Dependencies             []JSONDependency `json:"dependencies,omitempty"`
+	MostCommonValues         []JSONMCVItem    `json:"most_common_values,omitempty"`
 }
 
 // JSONHistoBucket is a struct used for JSON marshaling and unmarshaling of
@@ -58,6 +65,29 @@ type JSONHistoBucket struct {
 	UpperBound string `json:"upper_bound"`
 }
 
+// JSONDependency is a struct used for JSON marshaling and unmarshaling of a
+// functional dependency between two columns of a multi-column statistic.
+//
+// See ExtendedStatisticsData_FunctionalDependency for a description of the
+// fields.
+type JSONDependency struct {
+	// From and To are the names of the columns.
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Degree float64 `json:"degree"`
+}
+
+// JSONMCVItem is a struct used for JSON marshaling and unmarshaling of one of
+// the most common value combinations of a multi-column statistic.
+//
+// See ExtendedStatisticsData_MCVItem for a description of the fields.
+type JSONMCVItem struct {
+	// Values are the string representations of the datums; parsable with
+	// sqlbase.ParseDatumStringAs.
+	Values    []string `json:"values"`
+	Frequency float64  `json:"frequency"`
+}
+
 // SetHistogram fills in the HistogramColumnType and HistogramBuckets fields.
 func (js *JSONStatistic) SetHistogram(h *HistogramData) error {
 	typ := h.ColumnType
@@ -163,6 +193,158 @@ func (js *JSONStatistic) GetHistogram(
 	return h, nil
 }
 
+// SetExtendedStats fills in the extended statistics fields. The Columns field
+// must already be set.
+func (js *JSONStatistic) SetExtendedStats(ext *ExtendedStatisticsData) error {
+	if len(ext.ColumnTypes) != len(js.Columns) {
+		return fmt.Errorf("extended statistics have %d column types, expected %d",
+			len(ext.ColumnTypes), len(js.Columns))
+	}
+	js.ExtendedStatsColumnTypes = make([]string, len(ext.ColumnTypes))
+	for i, typ := range ext.ColumnTypes {
+		js.ExtendedStatsColumnTypes[i] = typ.SQLString()
+	}
+	js.Dependencies = make([]JSONDependency, len(ext.Dependencies))
+	for i, dep := range ext.Dependencies {
+		if int(dep.From) >= len(js.Columns) || int(dep.To) >= len(js.Columns) {
+			return fmt.Errorf("invalid functional dependency %d => %d", dep.From, dep.To)
+		}
+		js.Dependencies[i] = JSONDependency{
+			From:   js.Columns[dep.From],
+			To:     js.Columns[dep.To],
+			Degree: dep.Degree,
+		}
+	}
+	js.MostCommonValues = make([]JSONMCVItem, len(ext.MostCommonValues))
+	var a tree.DatumAlloc
+	for i := range ext.MostCommonValues {
+		item := &ext.MostCommonValues[i]
+		if len(item.Values) != len(ext.ColumnTypes) {
+			return fmt.Errorf("most common value has %d values, expected %d",
+				len(item.Values), len(ext.ColumnTypes))
+		}
+		values := make([]string, len(item.Values))
+		for j, enc := range item.Values {
+			datum, _, err := valueside.Decode(&a, ext.ColumnTypes[j], enc)
+			if err != nil {
+				return err
+			}
+			values[j] = tree.AsStringWithFlags(datum, tree.FmtExport)
+		}
+		js.MostCommonValues[i] = JSONMCVItem{Values: values, Frequency: item.Frequency}
+	}
+	return nil
+}
+
+// DecodeAndSetExtendedStats decodes extended statistics marshaled as a Bytes
+// datum and fills in the JSONStatistic extended statistics fields. The Columns
+// field must already be set.
+func (js *JSONStatistic) DecodeAndSetExtendedStats(
+	ctx context.Context, semaCtx *tree.SemaContext, datum tree.Datum,
+) error {
+	if datum == tree.DNull {
+		return nil
+	}
+	if datum.ResolvedType().Family() != types.BytesFamily {
+		return fmt.Errorf("extended statistics datum type should be Bytes")
+	}
+	ext := &ExtendedStatisticsData{}
+	if err := protoutil.Unmarshal([]byte(*datum.(*tree.DBytes)), ext); err != nil {
+		return err
+	}
+	// If any serialized column type is user defined, then it needs to be
+	// hydrated before use.
+	for i, typ := range ext.ColumnTypes {
+		if !typ.UserDefined() {
+			continue
+		}
+		resolver := semaCtx.GetTypeResolver()
+		if resolver == nil {
+			return errors.AssertionFailedf("attempt to resolve user defined type with nil TypeResolver")
+		}
+		resolved, err := resolver.ResolveTypeByOID(ctx, typ.Oid())
+		if err != nil {
+			return err
+		}
+		ext.ColumnTypes[i] = resolved
+	}
+	return js.SetExtendedStats(ext)
+}
+
+// GetExtendedStats converts the json extended statistics into
+// ExtendedStatisticsData. It returns nil if the statistic has no extended
+// statistics.
+func (js *JSONStatistic) GetExtendedStats(
+	ctx context.Context, semaCtx *tree.SemaContext, evalCtx *eval.Context,
+) (*ExtendedStatisticsData, error) {
+	if len(js.ExtendedStatsColumnTypes) == 0 {
+		return nil, nil
+	}
+	if len(js.ExtendedStatsColumnTypes) != len(js.Columns) {
+		return nil, errors.Errorf("extended statistics have %d column types, expected %d",
+			len(js.ExtendedStatsColumnTypes), len(js.Columns))
+	}
+	ext := &ExtendedStatisticsData{
+		ColumnTypes: make([]*types.T, len(js.ExtendedStatsColumnTypes)),
+	}
+	for i, typStr := range js.ExtendedStatsColumnTypes {
+		colTypeRef, err := parser.GetTypeFromValidSQLSyntax(typStr)
+		if err != nil {
+			return nil, err
+		}
+		ext.ColumnTypes[i], err = tree.ResolveType(ctx, colTypeRef, semaCtx.GetTypeResolver())
+		if err != nil {
+			return nil, err
+		}
+	}
+	colOrdinal := func(name string) (uint32, error) {
+		for i := range js.Columns {
+			if js.Columns[i] == name {
+				return uint32(i), nil
+			}
+		}
+		return 0, errors.Errorf("functional dependency column %q is not in the statistic", name)
+	}
+	ext.Dependencies = make([]ExtendedStatisticsData_FunctionalDependency, len(js.Dependencies))
+	for i := range js.Dependencies {
+		dep := &js.Dependencies[i]
+		from, err := colOrdinal(dep.From)
+		if err != nil {
+			return nil, err
+		}
+		to, err := colOrdinal(dep.To)
+		if err != nil {
+			return nil, err
+		}
+		ext.Dependencies[i] = ExtendedStatisticsData_FunctionalDependency{
+			From: from, To: to, Degree: dep.Degree,
+		}
+	}
+	ext.MostCommonValues = make([]ExtendedStatisticsData_MCVItem, len(js.MostCommonValues))
+	for i := range js.MostCommonValues {
+		item := &js.MostCommonValues[i]
+		if len(item.Values) != len(ext.ColumnTypes) {
+			return nil, errors.Errorf("most common value has %d values, expected %d",
+				len(item.Values), len(ext.ColumnTypes))
+		}
+		ext.MostCommonValues[i].Values = make([][]byte, len(item.Values))
+		for j, s := range item.Values {
+			datum, err := rowenc.ParseDatumStringAs(ctx, ext.ColumnTypes[j], s, evalCtx, semaCtx)
+			if err != nil {
+				return nil, err
+			}
+			ext.MostCommonValues[i].Values[j], err = valueside.Encode(
+				nil /* appendTo */, valueside.NoColumnID, datum, nil, /* scratch */
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+		ext.MostCommonValues[i].Frequency = item.Frequency
+	}
+	return ext, nil
+}
+
 // IsPartial returns true if this statistic was collected with a where clause.
 func (js *JSONStatistic) IsPartial() bool {
 	return js.PartialPredicate != ""
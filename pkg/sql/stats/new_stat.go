@@ -13,6 +13,7 @@ package stats
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
@@ -42,6 +43,7 @@ func InsertNewStats(
 			statistic.HistogramData,
 			statistic.PartialPredicate,
 			statistic.FullStatisticID,
+			statistic.ExtendedStats,
 		)
 		if err != nil {
 			return err
@@ -65,6 +67,7 @@ func InsertNewStat(
 	h *HistogramData,
 	partialPredicate string,
 	fullStatisticID uint64,
+	ext *ExtendedStatisticsData,
 ) error {
 	// We must pass a nil interface{} if we want to insert a NULL.
 	var nameVal, histogramVal interface{}
@@ -93,6 +96,30 @@ func InsertNewStat(
 		predicateValue = partialPredicate
 	}
 
+	args := []interface{}{
+		tableID,
+		nameVal,
+		columnIDsVal,
+		rowCount,
+		distinctCount,
+		nullCount,
+		avgSize,
+		histogramVal,
+		predicateValue,
+		fullStatisticID,
+	}
+	extendedStatsCol, extendedStatsPlaceholder := "", ""
+	// The extendedStats column does not exist until the cluster has been
+	// upgraded, so extended statistics are dropped before then.
+	if ext != nil && settings.Version.IsActive(ctx, clusterversion.V24_1_TableStatisticsExtendedStats) {
+		extendedStatsVal, err := protoutil.Marshal(ext)
+		if err != nil {
+			return err
+		}
+		args = append(args, extendedStatsVal)
+		extendedStatsCol, extendedStatsPlaceholder = `, "extendedStats"`, ", $11"
+	}
+
 	_, err := txn.Exec(
 		ctx, "insert-statistic", txn.KV(),
 		`INSERT INTO system.table_statistics (
@@ -105,18 +132,9 @@ func InsertNewStat(
 					"avgSize",
 					histogram,
 					"partialPredicate",
-					"fullStatisticID"
-				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
-		tableID,
-		nameVal,
-		columnIDsVal,
-		rowCount,
-		distinctCount,
-		nullCount,
-		avgSize,
-		histogramVal,
-		predicateValue,
-		fullStatisticID,
+					"fullStatisticID"`+extendedStatsCol+`
+				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10`+extendedStatsPlaceholder+`)`,
+		args...,
 	)
 	return err
 }
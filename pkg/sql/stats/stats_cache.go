@@ -49,6 +49,14 @@ type TableStatistic struct {
 
 	// Histogram is the decoded histogram data.
 	Histogram []cat.HistogramBucket
+
+	// Dependencies are the decoded functional dependencies between the columns
+	// of a multi-column statistic.
+	Dependencies []cat.StatisticDependency
+
+	// MostCommonValues are the decoded most common value combinations of a
+	// multi-column statistic.
+	MostCommonValues []cat.StatisticMCV
 }
 
 // A TableStatisticsCache contains two underlying LRU caches:
@@ -517,12 +525,13 @@ const (
 	partialPredicateIndex
 	histogramIndex
 	fullStatisticsIdIndex
+	extendedStatsIndex
 	statsLen
 )
 
 // NewTableStatisticProto converts a row of datums from system.table_statistics
 // into a TableStatisticsProto. Note that any user-defined types in the
-// HistogramData will be unresolved.
+// HistogramData and ExtendedStats will be unresolved.
 func NewTableStatisticProto(datums tree.Datums) (*TableStatisticProto, error) {
 	if datums == nil || datums.Len() == 0 {
 		return nil, nil
@@ -554,6 +563,7 @@ func NewTableStatisticProto(datums tree.Datums) (*TableStatisticProto, error) {
 		{"partialPredicate", partialPredicateIndex, types.String, true},
 		{"histogram", hgIndex, types.Bytes, true},
 		{"fullStatisticID", fullStatisticsIdIndex, types.Int, true},
+		{"extendedStats", extendedStatsIndex, types.Bytes, true},
 	}
 
 	for _, v := range expectedTypes {
@@ -597,6 +607,15 @@ func NewTableStatisticProto(datums tree.Datums) (*TableStatisticProto, error) {
 			return nil, err
 		}
 	}
+	if datums[extendedStatsIndex] != tree.DNull {
+		res.ExtendedStats = &ExtendedStatisticsData{}
+		if err := protoutil.Unmarshal(
+			[]byte(*datums[extendedStatsIndex].(*tree.DBytes)),
+			res.ExtendedStats,
+		); err != nil {
+			return nil, err
+		}
+	}
 	return res, nil
 }
 
@@ -642,6 +661,28 @@ func (sc *TableStatisticsCache) parseStats(
 			return nil, err
 		}
 	}
+	if res.ExtendedStats != nil {
+		// As with histograms, hydrate any user defined column types before
+		// decoding the most common values.
+		for i, typ := range res.ExtendedStats.ColumnTypes {
+			if typ == nil || !typ.UserDefined() {
+				continue
+			}
+			if err := sc.db.DescsTxn(ctx, func(
+				ctx context.Context, txn descs.Txn,
+			) error {
+				resolver := descs.NewDistSQLTypeResolver(txn.Descriptors(), txn.KV())
+				var err error
+				res.ExtendedStats.ColumnTypes[i], err = resolver.ResolveTypeByOID(ctx, typ.Oid())
+				return err
+			}); err != nil {
+				return nil, err
+			}
+		}
+		if err := DecodeExtendedStats(res); err != nil {
+			return nil, err
+		}
+	}
 
 	return res, nil
 }
@@ -767,7 +808,8 @@ SELECT
 	"avgSize",
 	"partialPredicate",
 	histogram,
-	"fullStatisticID"
+	"fullStatisticID",
+	` + ExtendedStatsColumnExpr(ctx, st) + `
 FROM system.table_statistics
 WHERE "tableID" = $1
 ORDER BY "createdAt" DESC, "columnIDs" DESC, "statisticID" DESC
@@ -13,6 +13,7 @@ package sql
 import (
 	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
 )
 
@@ -29,7 +30,10 @@ type subquery struct {
 	// rowCount is the estimated number of rows that plan will output, negative
 	// if the stats weren't available to make a good estimate.
 	rowCount int64
-	result   tree.Datum
+	// deps contains the ordinals of the subqueries that must be evaluated
+	// before this one (see exec.Subquery.Deps).
+	deps   intsets.Fast
+	result tree.Datum
 }
 
 // EvalSubquery is called by `tree.Eval()` method implementations to
@@ -0,0 +1,130 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/multitenant/mtinfopb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+const alterTenantAdmissionOp = "ALTER VIRTUAL CLUSTER ADMISSION"
+
+// maxTenantAdmissionWeight bounds the weight that can be configured for a
+// tenant, so that a single tenant cannot starve all others.
+const maxTenantAdmissionWeight = 1000
+
+// tenantAdmissionPriorityClasses maps the names accepted by ALTER VIRTUAL
+// CLUSTER ... SET ADMISSION PRIORITY to priority classes.
+var tenantAdmissionPriorityClasses = map[string]mtinfopb.AdmissionConfig_PriorityClass{
+	"standard":    mtinfopb.AdmissionConfig_STANDARD,
+	"premium":     mtinfopb.AdmissionConfig_PREMIUM,
+	"best_effort": mtinfopb.AdmissionConfig_BEST_EFFORT,
+}
+
+type alterTenantAdmissionNode struct {
+	n          *tree.AlterTenantAdmission
+	tenantSpec tenantSpec
+	typedExpr  tree.TypedExpr
+}
+
+func (p *planner) AlterTenantAdmission(
+	ctx context.Context, n *tree.AlterTenantAdmission,
+) (planNode, error) {
+	if err := rejectIfCantCoordinateMultiTenancy(p.execCfg.Codec, "configure admission control for", p.execCfg.Settings); err != nil {
+		return nil, err
+	}
+
+	tSpec, err := p.planTenantSpec(ctx, n.TenantSpec, alterTenantAdmissionOp)
+	if err != nil {
+		return nil, err
+	}
+
+	expr, desiredType, what := n.Weight, types.Int, "WEIGHT"
+	if n.Weight == nil {
+		expr, desiredType, what = n.PriorityClass, types.String, "PRIORITY"
+	}
+	var dummyHelper tree.IndexedVarHelper
+	typedExpr, err := p.analyzeExpr(
+		ctx, expr, dummyHelper, desiredType, true /* requireType */, alterTenantAdmissionOp+" "+what,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &alterTenantAdmissionNode{
+		n:          n,
+		tenantSpec: tSpec,
+		typedExpr:  typedExpr,
+	}, nil
+}
+
+func (n *alterTenantAdmissionNode) startExec(params runParams) error {
+	p := params.p
+	ctx := params.ctx
+
+	// Privilege check.
+	if err := CanManageTenant(ctx, p); err != nil {
+		return err
+	}
+
+	// Refuse to work in read-only transactions.
+	if p.EvalContext().TxnReadOnly {
+		return readOnlyError(alterTenantAdmissionOp)
+	}
+
+	tenantInfo, err := n.tenantSpec.getTenantInfo(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	// The system tenant is not subject to inter-tenant fair sharing.
+	if err := rejectIfSystemTenant(tenantInfo.ID, alterTenantAdmissionOp); err != nil {
+		return err
+	}
+
+	datum, err := eval.Expr(ctx, p.EvalContext(), n.typedExpr)
+	if err != nil {
+		return err
+	}
+	if datum == tree.DNull {
+		return pgerror.Newf(pgcode.InvalidParameterValue, "%s value cannot be NULL", alterTenantAdmissionOp)
+	}
+
+	if n.n.Weight != nil {
+		weight := int64(tree.MustBeDInt(datum))
+		if weight < 1 || weight > maxTenantAdmissionWeight {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"admission weight must be between 1 and %d, got %d", maxTenantAdmissionWeight, weight)
+		}
+		tenantInfo.Admission.Weight = uint32(weight)
+	} else {
+		name := strings.ToLower(string(tree.MustBeDString(datum)))
+		class, ok := tenantAdmissionPriorityClasses[name]
+		if !ok {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"unknown admission priority %q; expected one of 'premium', 'standard' or 'best_effort'", name)
+		}
+		tenantInfo.Admission.PriorityClass = class
+	}
+
+	return UpdateTenantRecord(ctx, p.ExecCfg().Settings, p.InternalSQLTxn(), tenantInfo)
+}
+
+func (n *alterTenantAdmissionNode) Next(runParams) (bool, error) { return false, nil }
+func (n *alterTenantAdmissionNode) Values() tree.Datums          { return nil }
+func (n *alterTenantAdmissionNode) Close(context.Context)        {}
@@ -215,6 +215,7 @@ func (v *planVisitor) visitInternal(plan planNode, name string) {
 			n.sourcePlan = v.visit(n.sourcePlan)
 		}
 
+	case *alterTenantAdmissionNode:
 	case *alterTenantCapabilityNode:
 	case *alterTenantSetClusterSettingNode:
 	case *alterTenantServiceNode:
@@ -362,6 +363,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&alterTableOwnerNode{}):                     "alter table owner",
 	reflect.TypeOf(&alterTableSetLocalityNode{}):               "alter table set locality",
 	reflect.TypeOf(&alterTableSetSchemaNode{}):                 "alter table set schema",
+	reflect.TypeOf(&alterTenantAdmissionNode{}):                "alter tenant admission",
 	reflect.TypeOf(&alterTenantCapabilityNode{}):               "alter tenant capability",
 	reflect.TypeOf(&alterTenantSetClusterSettingNode{}):        "alter tenant set cluster setting",
 	reflect.TypeOf(&alterTenantServiceNode{}):                  "alter tenant service",
@@ -390,6 +392,8 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&createExternalConnectionNode{}):            "create external connection",
 	reflect.TypeOf(&createFunctionNode{}):                      "create function",
 	reflect.TypeOf(&createIndexNode{}):                         "create index",
+	reflect.TypeOf(&createPlanBaselineNode{}):                  "create plan baseline",
+	reflect.TypeOf(&createPolicyNode{}):                        "create policy",
 	reflect.TypeOf(&createSequenceNode{}):                      "create sequence",
 	reflect.TypeOf(&createSchemaNode{}):                        "create schema",
 	reflect.TypeOf(&createStatsNode{}):                         "create statistics",
@@ -407,6 +411,8 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&dropExternalConnectionNode{}):              "drop external connection",
 	reflect.TypeOf(&dropFunctionNode{}):                        "drop function",
 	reflect.TypeOf(&dropIndexNode{}):                           "drop index",
+	reflect.TypeOf(&dropPlanBaselineNode{}):                    "drop plan baseline",
+	reflect.TypeOf(&dropPolicyNode{}):                          "drop policy",
 	reflect.TypeOf(&dropSequenceNode{}):                        "drop sequence",
 	reflect.TypeOf(&dropSchemaNode{}):                          "drop schema",
 	reflect.TypeOf(&dropTableNode{}):                           "drop table",
@@ -1080,6 +1080,13 @@ type Engine interface {
 	// CompactRange ensures that the specified range of key value pairs is
 	// optimized for space efficiency.
 	CompactRange(start, end roachpb.Key) error
+	// CompactStaleEncryptedFiles rewrites, through manual compactions, the
+	// sstables that are not encrypted with the active data key, so that they
+	// are re-encrypted with it. It is used to complete the rotation of an
+	// encryption-at-rest key. It returns the number of such sstables that are
+	// left, which is non-zero if new ones were written concurrently or some
+	// could not be rewritten. It is a no-op if encryption is not enabled.
+	CompactStaleEncryptedFiles(ctx context.Context) (remaining int, _ error)
 	// ScanStorageInternalKeys returns key level statistics for each level of a pebble store (that overlap start and end).
 	ScanStorageInternalKeys(start, end roachpb.Key, megabytesPerSecond int64) ([]enginepb.StorageInternalKeysMetrics, error)
 	// GetTableMetrics returns information about sstables that overlap start and end.
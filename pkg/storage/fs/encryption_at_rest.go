@@ -101,6 +101,33 @@ type EncryptionStatsHandler interface {
 	GetActiveStoreKeyType() int32
 	// Returns the KeyID embedded in the serialized EncryptionSettings.
 	GetKeyIDFromSettings(settings []byte) (string, error)
+	// Returns the store and data keys in the key registry. The file stats of
+	// the returned keys are left empty.
+	GetKeyStats() ([]EncryptionKeyStats, error)
+}
+
+// EncryptionKeyStats describes an encryption-at-rest key of a store, along
+// with the files encrypted with it.
+type EncryptionKeyStats struct {
+	// KeyID is the ID of the key. Files that are not encrypted are reported
+	// under the "plain" key ID.
+	KeyID string
+	// ParentKeyID is the ID of the store key that encrypts a data key. It is
+	// empty for store keys.
+	ParentKeyID string
+	// StoreKey is true for store keys, and false for data keys.
+	StoreKey bool
+	// Active is true for the active store key and the active data key.
+	Active bool
+	// EncryptionType is the name of the encryption algorithm of the key.
+	EncryptionType string
+	// CreationTime is the time the key was first seen, in seconds since the
+	// epoch.
+	CreationTime int64
+	// Files is the number of files encrypted with the key.
+	Files uint64
+	// Bytes is the size of the sstables encrypted with the key.
+	Bytes uint64
 }
 
 // EnvStats is a set of RocksDB env stats, including encryption status.
@@ -118,4 +145,8 @@ type EnvStats struct {
 	EncryptionType int32
 	// EncryptionStatus is a serialized enginepbccl/stats.proto::EncryptionStatus protobuf.
 	EncryptionStatus []byte
+	// Keys describes the store and data keys, and the files encrypted with
+	// each of them. Files still encrypted with a data key that is no longer
+	// active are rewritten by Engine.CompactStaleEncryptedFiles.
+	Keys []EncryptionKeyStats
 }
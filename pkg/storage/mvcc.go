@@ -82,6 +82,14 @@ var minWALSyncInterval = settings.RegisterDurationSetting(
 	settings.NonNegativeDurationWithMaximum(1*time.Second),
 )
 
+var raftLogEngineMinWALSyncInterval = settings.RegisterDurationSetting(
+	settings.SystemOnly,
+	"storage.raft_log_engine.min_wal_sync_interval",
+	"minimum duration between syncs of the WAL of a separate Raft log engine",
+	0*time.Millisecond,
+	settings.NonNegativeDurationWithMaximum(1*time.Second),
+)
+
 // MaxConflictsPerLockConflictError sets maximum number of locks returned in
 // LockConflictError in operations that return multiple locks per error.
 var MaxConflictsPerLockConflictError = settings.RegisterIntSetting(
@@ -51,6 +51,15 @@ var MustExist ConfigOption = func(cfg *engineConfig) error {
 	return nil
 }
 
+// RaftLogEngine configures an engine that holds only the Raft log and
+// unreplicated Raft state of a store, separately from the state machine. Such
+// an engine syncs its WAL according to its own policy; see
+// storage.raft_log_engine.min_wal_sync_interval.
+var RaftLogEngine ConfigOption = func(cfg *engineConfig) error {
+	cfg.raftLogEngine = true
+	return nil
+}
+
 // DisableAutomaticCompactions configures an engine to be opened with disabled
 // automatic compactions. Used primarily for debugCompactCmd.
 var DisableAutomaticCompactions ConfigOption = func(cfg *engineConfig) error {
@@ -882,6 +882,9 @@ type engineConfig struct {
 	mustExist bool
 	// pebble specific options.
 	opts *pebble.Options
+	// raftLogEngine is set if the engine only holds a store's Raft log, and is
+	// separate from the engine holding the state machine.
+	raftLogEngine bool
 	// remoteStorageFactory is used to pass the ExternalStorage factory.
 	remoteStorageFactory *cloud.EarlyBootExternalStorageAccessor
 	// settings instance for cluster-wide knobs. Must not be nil.
@@ -1105,6 +1108,9 @@ func newPebble(ctx context.Context, cfg engineConfig) (p *Pebble, err error) {
 	logCtx = logtags.AddTag(logCtx, "pebble", nil)
 
 	cfg.opts.WALMinSyncInterval = func() time.Duration {
+		if cfg.raftLogEngine {
+			return raftLogEngineMinWALSyncInterval.Get(&cfg.settings.SV)
+		}
 		return minWALSyncInterval.Get(&cfg.settings.SV)
 	}
 	cfg.opts.Experimental.EnableValueBlocks = func() bool {
@@ -2086,29 +2092,40 @@ func (p *Pebble) GetEnvStats() (*fs.EnvStats, error) {
 		}
 	}
 
+	stats.Keys, err = p.cfg.env.Encryption.StatsHandler.GetKeyStats()
+	if err != nil {
+		return nil, err
+	}
+	keyIdx := make(map[string]int, len(stats.Keys))
+	for i := range stats.Keys {
+		keyIdx[stats.Keys[i].KeyID] = i
+	}
+
 	for filePath, entry := range fr.Files {
-		keyID, err := p.cfg.env.Encryption.StatsHandler.GetKeyIDFromSettings(entry.EncryptionSettings)
+		keyID, err := p.encryptionKeyID(entry)
 		if err != nil {
 			return nil, err
 		}
-		if len(keyID) == 0 {
-			keyID = "plain"
-		}
-		if keyID != activeKeyID {
-			continue
+		var size uint64
+		if num, ok, err := sstableFileNum(p.cfg.env.PathBase(filePath)); err != nil {
+			return nil, err
+		} else if ok {
+			size = sstSizes[num]
 		}
-		stats.ActiveKeyFiles++
 
-		filename := p.cfg.env.PathBase(filePath)
-		numStr := strings.TrimSuffix(filename, ".sst")
-		if len(numStr) == len(filename) {
-			continue // not a sstable
+		i, ok := keyIdx[keyID]
+		if !ok {
+			i = len(stats.Keys)
+			keyIdx[keyID] = i
+			stats.Keys = append(stats.Keys, fs.EncryptionKeyStats{KeyID: keyID})
 		}
-		u, err := strconv.ParseUint(numStr, 10, 64)
-		if err != nil {
-			return nil, errors.Wrapf(err, "parsing filename %q", errors.Safe(filename))
+		stats.Keys[i].Files++
+		stats.Keys[i].Bytes += size
+
+		if keyID == activeKeyID {
+			stats.ActiveKeyFiles++
+			stats.ActiveKeyBytes += size
 		}
-		stats.ActiveKeyBytes += sstSizes[pebble.FileNum(u)]
 	}
 
 	// Ensure that encryption percentage does not exceed 100%.
@@ -2124,6 +2141,119 @@ func (p *Pebble) GetEnvStats() (*fs.EnvStats, error) {
 	return stats, nil
 }
 
+// encryptionKeyID returns the ID of the data key the file with the given file
+// registry entry is encrypted with, or "plain" if it is not encrypted.
+func (p *Pebble) encryptionKeyID(entry *enginepb.FileEntry) (string, error) {
+	keyID, err := p.cfg.env.Encryption.StatsHandler.GetKeyIDFromSettings(entry.EncryptionSettings)
+	if err != nil {
+		return "", err
+	}
+	if len(keyID) == 0 {
+		keyID = "plain"
+	}
+	return keyID, nil
+}
+
+// sstableFileNum returns the file number of the sstable with the given file
+// name, or false if the file is not an sstable.
+func sstableFileNum(filename string) (pebble.FileNum, bool, error) {
+	numStr := strings.TrimSuffix(filename, ".sst")
+	if len(numStr) == len(filename) {
+		return 0, false, nil
+	}
+	u, err := strconv.ParseUint(numStr, 10, 64)
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "parsing filename %q", errors.Safe(filename))
+	}
+	return pebble.FileNum(u), true, nil
+}
+
+// staleEncryptedSSTables returns the sstables that are not encrypted with the
+// active data key, e.g. because they were written before the store key or the
+// data key was rotated.
+func (p *Pebble) staleEncryptedSSTables() ([]pebble.SSTableInfo, error) {
+	activeKeyID, err := p.cfg.env.Encryption.StatsHandler.GetActiveDataKeyID()
+	if err != nil {
+		return nil, err
+	}
+	stale := make(map[pebble.FileNum]struct{})
+	for filePath, entry := range p.cfg.env.Registry.GetRegistrySnapshot().Files {
+		keyID, err := p.encryptionKeyID(entry)
+		if err != nil {
+			return nil, err
+		}
+		if keyID == activeKeyID {
+			continue
+		}
+		if num, ok, err := sstableFileNum(p.cfg.env.PathBase(filePath)); err != nil {
+			return nil, err
+		} else if ok {
+			stale[num] = struct{}{}
+		}
+	}
+	sstInfos, err := p.db.SSTables()
+	if err != nil {
+		return nil, err
+	}
+	var res []pebble.SSTableInfo
+	for _, ssts := range sstInfos {
+		for _, sst := range ssts {
+			if _, ok := stale[sst.FileNum]; ok {
+				res = append(res, sst)
+			}
+		}
+	}
+	return res, nil
+}
+
+// CompactStaleEncryptedFiles implements the Engine interface.
+func (p *Pebble) CompactStaleEncryptedFiles(ctx context.Context) (int, error) {
+	if p.cfg.env.Encryption == nil {
+		return 0, nil
+	}
+	// Each compaction rewrites all the sstables overlapping the key span of
+	// a stale sstable, which usually includes other stale sstables. Re-list
+	// them after every compaction, and don't compact the span of an sstable
+	// twice in case a compaction could not rewrite it.
+	attempted := make(map[pebble.FileNum]struct{})
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		stale, err := p.staleEncryptedSSTables()
+		if err != nil {
+			return 0, err
+		}
+		var next *pebble.SSTableInfo
+		for i := range stale {
+			if _, ok := attempted[stale[i].FileNum]; !ok {
+				next = &stale[i]
+				break
+			}
+		}
+		if next == nil {
+			return len(stale), nil
+		}
+		attempted[next.FileNum] = struct{}{}
+
+		start, ok := DecodeEngineKey(next.Smallest.UserKey)
+		if !ok {
+			return 0, errors.AssertionFailedf("invalid smallest key %x of sstable %s",
+				next.Smallest.UserKey, next.FileNum)
+		}
+		end, ok := DecodeEngineKey(next.Largest.UserKey)
+		if !ok {
+			return 0, errors.AssertionFailedf("invalid largest key %x of sstable %s",
+				next.Largest.UserKey, next.FileNum)
+		}
+		log.Infof(ctx, "compacting [%s, %s] to re-encrypt sstable %s",
+			start.Key, end.Key, next.FileNum)
+		if err := p.CompactRange(start.Key, end.Key.Next()); err != nil {
+			return 0, err
+		}
+	}
+}
+
 // GetAuxiliaryDir implements the Engine interface.
 func (p *Pebble) GetAuxiliaryDir() string {
 	return p.auxDir
@@ -28,3 +28,4 @@ export * from "./tableDetailsApi";
 export * from "./types";
 export * from "./jobProfilerApi";
 export * from "./txnInsightDetailsApi";
+export * from "./planBaselinesApi";
@@ -0,0 +1,103 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+import {
+  executeInternalSql,
+  sqlApiErrorMessage,
+  SqlExecutionRequest,
+  txnResultIsEmpty,
+} from "./sqlApi";
+
+export type PlanBaseline = {
+  fingerprint: string;
+  planGist?: string;
+  hints?: string;
+  createdAt: string;
+};
+
+type PlanBaselineColumns = {
+  fingerprint: string;
+  plan_gist: string | null;
+  hints: string | null;
+  created_at: string;
+};
+
+/**
+ * getPlanBaseline returns the plan baseline pinning the plan of the statement
+ * with the given fingerprint, or null if there is none.
+ * @param fingerprint the statement fingerprint
+ */
+export function getPlanBaseline(
+  fingerprint: string,
+): Promise<PlanBaseline | null> {
+  const request: SqlExecutionRequest = {
+    statements: [
+      {
+        sql: `SELECT fingerprint, plan_gist, hints, created_at
+FROM system.statement_plan_hints
+WHERE fingerprint = $1`,
+        arguments: [fingerprint],
+      },
+    ],
+    execute: true,
+  };
+  return executeInternalSql<PlanBaselineColumns>(request).then(result => {
+    if (result.error) {
+      throw new Error(sqlApiErrorMessage(result.error.message));
+    }
+    const txnResult = result.execution?.txn_results[0];
+    if (txnResultIsEmpty(txnResult)) {
+      return null;
+    }
+    const row = txnResult.rows[0];
+    return {
+      fingerprint: row.fingerprint,
+      planGist: row.plan_gist ?? undefined,
+      hints: row.hints ?? undefined,
+      createdAt: row.created_at,
+    };
+  });
+}
+
+function executePlanBaselineStmt(sql: string, args: unknown[]): Promise<void> {
+  const request: SqlExecutionRequest = {
+    statements: [{ sql, arguments: args }],
+    execute: true,
+  };
+  return executeInternalSql(request).then(result => {
+    if (result.error) {
+      throw new Error(sqlApiErrorMessage(result.error.message));
+    }
+  });
+}
+
+/**
+ * createPlanBaselineFromGist pins the plan described by the plan gist for the
+ * statement with the given fingerprint.
+ * @param fingerprint the statement fingerprint
+ * @param planGist the plan gist to pin
+ */
+export function createPlanBaselineFromGist(
+  fingerprint: string,
+  planGist: string,
+): Promise<void> {
+  const sql = "CREATE PLAN BASELINE FOR $1 USING PLAN $2";
+  return executePlanBaselineStmt(sql, [fingerprint, planGist]);
+}
+
+/**
+ * dropPlanBaseline removes the plan baseline of the statement with the given
+ * fingerprint, if any.
+ * @param fingerprint the statement fingerprint
+ */
+export function dropPlanBaseline(fingerprint: string): Promise<void> {
+  const sql = "DROP PLAN BASELINE IF EXISTS FOR $1";
+  return executePlanBaselineStmt(sql, [fingerprint]);
+}
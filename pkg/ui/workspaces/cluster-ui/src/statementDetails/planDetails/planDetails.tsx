@@ -8,7 +8,12 @@
 // by the Apache License, Version 2.0, included in the file
 // licenses/APL.txt.
 
-import React, { useContext, useState } from "react";
+import React, {
+  useCallback,
+  useContext,
+  useEffect,
+  useState,
+} from "react";
 import { Helmet } from "react-helmet";
 import { ArrowLeft } from "@cockroachlabs/icons";
 import {
@@ -42,6 +47,12 @@ import {
 } from "../../util";
 import { formatIndexes } from "./plansTable";
 import { Timestamp } from "../../timestamp";
+import {
+  createPlanBaselineFromGist,
+  dropPlanBaseline,
+  getPlanBaseline,
+  PlanBaseline,
+} from "../../api/planBaselinesApi";
 
 const cx = classNames.bind(styles);
 
@@ -218,6 +229,11 @@ function ExplainPlan({
           </SummaryCard>
         </Col>
       </Row>
+      <PlanBaselineCard
+        fingerprint={plan.metadata.query}
+        planGist={plan.stats.plan_gists[0]}
+        hasAdminRole={hasAdminRole}
+      />
       {hasInsights && (
         <Insights
           idxRecommendations={plan.stats.index_recommendations}
@@ -234,6 +250,74 @@ function ExplainPlan({
   );
 }
 
+interface PlanBaselineCardProps {
+  fingerprint: string;
+  planGist: string;
+  hasAdminRole: boolean;
+}
+
+// PlanBaselineCard shows whether the plan of the statement is pinned by a plan
+// baseline, and allows admins to pin the current plan or remove the baseline.
+function PlanBaselineCard({
+  fingerprint,
+  planGist,
+  hasAdminRole,
+}: PlanBaselineCardProps): React.ReactElement {
+  const [baseline, setBaseline] = useState<PlanBaseline | null>(null);
+  const [error, setError] = useState<string | null>(null);
+
+  const refresh = useCallback((): void => {
+    getPlanBaseline(fingerprint)
+      .then(b => {
+        setBaseline(b);
+        setError(null);
+      })
+      .catch(e => setError(e.message));
+  }, [fingerprint]);
+  useEffect(refresh, [refresh]);
+
+  const onPin = (): void => {
+    createPlanBaselineFromGist(fingerprint, planGist)
+      .then(refresh)
+      .catch(e => setError(e.message));
+  };
+  const onUnpin = (): void => {
+    dropPlanBaseline(fingerprint)
+      .then(refresh)
+      .catch(e => setError(e.message));
+  };
+
+  let status: string;
+  if (!baseline) {
+    status = "Not pinned";
+  } else if (baseline.planGist === planGist) {
+    status = "This plan is pinned";
+  } else if (baseline.planGist) {
+    status = `Another plan is pinned (${baseline.planGist})`;
+  } else {
+    status = `Pinned by hints (${baseline.hints})`;
+  }
+
+  return (
+    <Row gutter={24} className={cx("margin-left-neg", "margin-bottom")}>
+      <Col className="gutter-row" span={24}>
+        <SummaryCard className={cx("summary-card")}>
+          <SummaryCardItem label="Plan Baseline" value={error ?? status} />
+          {hasAdminRole && (
+            <Button
+              onClick={baseline?.planGist === planGist ? onUnpin : onPin}
+              type="secondary"
+              size="small"
+            >
+              {baseline?.planGist === planGist ? "Unpin Plan" : "Pin Plan"}
+            </Button>
+          )}
+        </SummaryCard>
+      </Col>
+    </Row>
+  );
+}
+
 function formatIdxRecommendations(
   idxRecs: string[],
   database: string,
@@ -145,6 +145,22 @@ var upgrades = []upgradebase.Upgrade{
 		upgrade.RestoreActionNotRequired("cluster restore does not preserve the multiregion configuration of the system database"),
 	),
 
+	upgrade.NewTenantUpgrade(
+		"create system.statement_plan_hints table",
+		clusterversion.V24_1_StatementPlanHintsTable.Version(),
+		upgrade.NoPrecondition,
+		createStatementPlanHintsTable,
+		upgrade.RestoreActionNotRequired("the table is created empty and its rows are restored like any other system table"),
+	),
+
+	upgrade.NewTenantUpgrade(
+		"add extendedStats column to system.table_statistics",
+		clusterversion.V24_1_TableStatisticsExtendedStats.Version(),
+		upgrade.NoPrecondition,
+		tableStatisticsExtendedStatsMigration,
+		upgrade.RestoreActionNotRequired("restored statistics are rewritten through the current schema"),
+	),
+
 	// Note: when starting a new release version, the first upgrade (for
 	// Vxy_zStart) must be a newFirstUpgrade. Keep this comment at the bottom.
 }
@@ -0,0 +1,45 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package upgrades
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/upgrade"
+)
+
+// Target schema change in the system.table_statistics table, adding the
+// column that stores extended statistics.
+const addExtendedStatsColToTableStatistics = `
+ALTER TABLE system.table_statistics
+  ADD COLUMN IF NOT EXISTS "extendedStats" BYTES
+  FAMILY "fam_0_tableID_statisticID_name_columnIDs_createdAt_rowCount_distinctCount_nullCount_histogram"`
+
+// tableStatisticsExtendedStatsMigration adds the extendedStats column to the
+// system.table_statistics table.
+func tableStatisticsExtendedStatsMigration(
+	ctx context.Context, cs clusterversion.ClusterVersion, d upgrade.TenantDeps,
+) error {
+	op := operation{
+		name:           "add-table-statistics-extended-stats-column",
+		schemaList:     []string{"extendedStats"},
+		query:          addExtendedStatsColToTableStatistics,
+		schemaExistsFn: hasColumn,
+	}
+	if err := migrateTable(ctx, cs, d, op, keys.TableStatisticsTableID,
+		systemschema.TableStatisticsTable); err != nil {
+		return err
+	}
+	return bumpSystemDatabaseSchemaVersion(ctx, cs, d)
+}
@@ -53,6 +53,7 @@ type elasticCPUInternalWorkQueue interface {
 	requester
 	Admit(ctx context.Context, info WorkInfo) (enabled bool, err error)
 	SetTenantWeights(tenantWeights map[uint64]uint32)
+	SetTenantPriorityClasses(tenantClasses map[uint64]TenantPriorityClass)
 	adjustTenantUsed(tenantID roachpb.TenantID, additionalUsed int64)
 }
 
@@ -124,6 +125,14 @@ func (e *ElasticCPUWorkQueue) SetTenantWeights(tenantWeights map[uint64]uint32)
 	e.workQueue.SetTenantWeights(tenantWeights)
 }
 
+// SetTenantPriorityClasses passes through to
+// WorkQueue.SetTenantPriorityClasses.
+func (e *ElasticCPUWorkQueue) SetTenantPriorityClasses(
+	tenantClasses map[uint64]TenantPriorityClass,
+) {
+	e.workQueue.SetTenantPriorityClasses(tenantClasses)
+}
+
 func (e *ElasticCPUWorkQueue) enabled() bool {
 	if e.testingEnabled {
 		return true
@@ -171,6 +171,12 @@ func (t *testElasticCPUInternalWorkQueue) SetTenantWeights(tenantWeights map[uin
 	panic("unimplemented")
 }
 
+func (t *testElasticCPUInternalWorkQueue) SetTenantPriorityClasses(
+	tenantClasses map[uint64]TenantPriorityClass,
+) {
+	panic("unimplemented")
+}
+
 func (t *testElasticCPUInternalWorkQueue) adjustTenantUsed(
 	tenantID roachpb.TenantID, additionalUsed int64,
 ) {
@@ -432,7 +432,7 @@ func makeElasticGrantCoordinator(
 	registry.AddMetricStruct(elasticCPUGranterMetrics)
 
 	elasticWorkQueueMetrics := makeWorkQueueMetrics("elastic-cpu", registry,
-		admissionpb.BulkNormalPri, admissionpb.NormalPri)
+		admissionpb.BulkNormalPri, admissionpb.NormalPri).withTenantClassMetrics()
 
 	elasticCPUGranter := newElasticCPUGranter(ambientCtx, st, elasticCPUGranterMetrics)
 	schedulerLatencyListener := newSchedulerLatencyListener(ambientCtx, st, schedulerLatencyListenerMetrics, elasticCPUGranter)
@@ -463,7 +463,7 @@ func makeStoresGrantCoordinators(
 	storeWorkQueueMetrics :=
 		makeWorkQueueMetrics(fmt.Sprintf("%s-stores", KVWork), registry,
 			admissionpb.TTLLowPri, admissionpb.BulkNormalPri,
-			admissionpb.NormalPri, admissionpb.LockingNormalPri)
+			admissionpb.NormalPri, admissionpb.LockingNormalPri).withTenantClassMetrics()
 	makeStoreRequester := makeStoreWorkQueue
 	if opts.makeStoreRequesterFunc != nil {
 		makeStoreRequester = opts.makeStoreRequesterFunc
@@ -531,7 +531,8 @@ func makeRegularGrantCoordinator(
 	}
 
 	kvSlotAdjuster.granter = kvg
-	wqMetrics := makeWorkQueueMetrics(KVWork.String(), registry, admissionpb.NormalPri, admissionpb.LockingNormalPri)
+	wqMetrics := makeWorkQueueMetrics(KVWork.String(), registry, admissionpb.NormalPri, admissionpb.LockingNormalPri).
+		withTenantClassMetrics()
 	req := makeRequester(ambientCtx, KVWork, kvg, st, wqMetrics, makeWorkQueueOptions(KVWork))
 	coord.queues[KVWork] = req
 	kvg.requester = req
@@ -306,6 +306,10 @@ type WorkQueue struct {
 			// The maps are lazily allocated.
 			active, inactive map[uint64]uint32
 		}
+		// tenantClasses is the tenant ID => priority class map set by
+		// SetTenantPriorityClasses. Tenants that are not in the map are in
+		// TenantPriorityClassStandard.
+		tenantClasses map[uint64]TenantPriorityClass
 		// The highest epoch that is closed.
 		closedEpochThreshold int64
 		// Following values are copied from the cluster settings.
@@ -604,9 +608,10 @@ func (q *WorkQueue) Admit(ctx context.Context, info WorkInfo) (enabled bool, err
 	q.mu.Lock()
 	tenant, ok := q.mu.tenants[tenantID]
 	if !ok {
-		tenant = newTenantInfo(tenantID, q.getTenantWeightLocked(tenantID))
+		tenant = newTenantInfo(tenantID, q.getTenantWeightLocked(tenantID), q.getTenantPriorityClassLocked(tenantID))
 		q.mu.tenants[tenantID] = tenant
 	}
+	tenantClass := tenant.class
 	if info.ReplicatedWorkInfo.Enabled {
 		if info.BypassAdmission {
 			// TODO(irfansharif): "Admin" work (like splits, scatters, lease
@@ -632,6 +637,7 @@ func (q *WorkQueue) Admit(ctx context.Context, info WorkInfo) (enabled bool, err
 		q.granter.tookWithoutPermission(info.RequestedCount)
 		q.metrics.incAdmitted(info.Priority)
 		q.metrics.recordBypassedAdmission(info.Priority)
+		q.metrics.recordTenantClassAdmission(tenantClass, 0 /* waitDur */)
 		return true, nil
 	}
 	// Work is subject to admission control.
@@ -678,6 +684,7 @@ func (q *WorkQueue) Admit(ctx context.Context, info WorkInfo) (enabled bool, err
 				)
 			}
 			q.metrics.recordFastPathAdmission(info.Priority)
+			q.metrics.recordTenantClassAdmission(tenantClass, 0 /* waitDur */)
 			return true, nil
 		}
 		// Did not get token/slot.
@@ -701,7 +708,7 @@ func (q *WorkQueue) Admit(ctx context.Context, info WorkInfo) (enabled bool, err
 		// tenantInfo struct is declared.
 		tenant, ok = q.mu.tenants[tenantID]
 		if !ok {
-			tenant = newTenantInfo(tenantID, q.getTenantWeightLocked(tenantID))
+			tenant = newTenantInfo(tenantID, q.getTenantWeightLocked(tenantID), q.getTenantPriorityClassLocked(tenantID))
 			q.mu.tenants[tenantID] = tenant
 		}
 		// Don't want to overflow tenant.used if it has decreased because of being
@@ -824,6 +831,7 @@ func (q *WorkQueue) Admit(ctx context.Context, info WorkInfo) (enabled bool, err
 		q.metrics.incAdmitted(info.Priority)
 		waitDur := q.timeNow().Sub(startTime)
 		q.metrics.recordFinishWait(info.Priority, waitDur)
+		q.metrics.recordTenantClassAdmission(tenantClass, waitDur)
 		if work.heapIndex != -1 {
 			panic(errors.AssertionFailedf("grantee should be removed from heap"))
 		}
@@ -888,6 +896,7 @@ func (q *WorkQueue) granted(grantChainID grantChainID) int64 {
 		return 0
 	}
 	tenant := q.mu.tenantHeap[0]
+	tenantClass := tenant.class
 	var item *waitingWork
 	if len(tenant.waitingWorkHeap) > 0 {
 		item = heap.Pop(&tenant.waitingWorkHeap).(*waitingWork)
@@ -937,6 +946,7 @@ func (q *WorkQueue) granted(grantChainID grantChainID) int64 {
 		q.metrics.incAdmitted(item.priority)
 		waitDur := q.timeNow().Sub(item.enqueueingTime)
 		q.metrics.recordFinishWait(item.priority, waitDur)
+		q.metrics.recordTenantClassAdmission(tenantClass, waitDur)
 		if item.heapIndex != -1 {
 			panic(errors.AssertionFailedf("grantee should be removed from heap"))
 		}
@@ -1011,6 +1021,9 @@ func (q *WorkQueue) SafeFormat(s redact.SafePrinter, _ rune) {
 		tenant := q.mu.tenants[id]
 		s.Printf("\n tenant-id: %d used: %d, w: %d, fifo: %d", tenant.id, tenant.used,
 			tenant.weight, tenant.fifoPriorityThreshold)
+		if tenant.class != TenantPriorityClassStandard {
+			s.Printf(", class: %s", tenant.class)
+		}
 		if len(tenant.waitingWorkHeap) > 0 {
 			s.Printf(" waiting work heap:")
 			for i := range tenant.waitingWorkHeap {
@@ -1038,6 +1051,102 @@ func (q *WorkQueue) SafeFormat(s redact.SafePrinter, _ rune) {
 	}
 }
 
+// TenantPriorityClass is the priority class of a tenant. The class scales the
+// weight of the tenant, so that tenants in a higher class get a larger share of
+// the resources than tenants in a lower class with the same weight. Classes
+// are not strictly ordered, so that a lower class is never starved by a busy
+// higher class. The zero value is the default class.
+type TenantPriorityClass uint8
+
+const (
+	// TenantPriorityClassStandard is the class of tenants that were not
+	// assigned one.
+	TenantPriorityClassStandard TenantPriorityClass = iota
+	// TenantPriorityClassPremium gets tenantClassWeightFactor times the share
+	// of the standard class.
+	TenantPriorityClassPremium
+	// TenantPriorityClassBestEffort gets 1/tenantClassWeightFactor of the
+	// share of the standard class.
+	TenantPriorityClassBestEffort
+	numTenantPriorityClasses
+)
+
+// tenantClassWeightFactor is the ratio between the shares of adjacent
+// priority classes, for tenants with the same weight.
+const tenantClassWeightFactor = 4
+
+// SafeValue implements the redact.SafeValue interface.
+func (c TenantPriorityClass) SafeValue() {}
+
+func (c TenantPriorityClass) String() string {
+	switch c {
+	case TenantPriorityClassStandard:
+		return "standard"
+	case TenantPriorityClassPremium:
+		return "premium"
+	case TenantPriorityClassBestEffort:
+		return "best-effort"
+	default:
+		return fmt.Sprintf("class-%d", uint8(c))
+	}
+}
+
+// rank returns a number that is higher for classes that get a larger share.
+func (c TenantPriorityClass) rank() int {
+	switch c {
+	case TenantPriorityClassPremium:
+		return 2
+	case TenantPriorityClassBestEffort:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// weight returns the factor by which the class scales the tenant weights.
+func (c TenantPriorityClass) weight() uint64 {
+	w := uint64(1)
+	for i := 0; i < c.rank(); i++ {
+		w *= tenantClassWeightFactor
+	}
+	return w
+}
+
+func (q *WorkQueue) getTenantPriorityClassLocked(tenantID uint64) TenantPriorityClass {
+	return q.mu.tenantClasses[tenantID]
+}
+
+// SetTenantPriorityClasses sets the priority class of tenants, using the
+// provided tenant ID => class map. Tenants that are not in the map are in
+// TenantPriorityClassStandard. The map must not be modified after the call.
+func (q *WorkQueue) SetTenantPriorityClasses(tenantClasses map[uint64]TenantPriorityClass) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	prev := q.mu.tenantClasses
+	q.mu.tenantClasses = tenantClasses
+	// Only tenants in either map can have changed class. Classes are assigned
+	// explicitly by operators, so unlike the tenant weights, there are few of
+	// them and they can be updated under a single acquisition of q.mu.
+	update := func(tenantID uint64) {
+		tenant := q.mu.tenants[tenantID]
+		if tenant == nil {
+			return
+		}
+		if class := q.getTenantPriorityClassLocked(tenantID); tenant.class != class {
+			tenant.class = class
+			if isInTenantHeap(tenant) {
+				q.mu.tenantHeap.fix(tenant)
+			}
+		}
+	}
+	for tenantID := range prev {
+		update(tenantID)
+	}
+	for tenantID := range tenantClasses {
+		update(tenantID)
+	}
+}
+
 // Weight for tenants that are not assigned a weight. This typically applies
 // to tenants which weren't on this node in the prior call to
 // SetTenantWeights. Additionally, it is also the minimum tenant weight.
@@ -1297,6 +1406,8 @@ type tenantInfo struct {
 	id uint64
 	// The weight assigned to the tenant. Must be > 0.
 	weight uint32
+	// The priority class of the tenant, which scales the weight.
+	class TenantPriorityClass
 	// used is computed over an interval and periodically reset. Ordering
 	// between tenants, for fair sharing, utilizes this value.
 	//
@@ -1343,8 +1454,10 @@ type tenantInfo struct {
 }
 
 // tenantHeap is a heap of tenants with waiting work, ordered in increasing
-// order of tenantInfo.used/tenantInfo.weight (weights are an optional
-// feature, and default to 1). That is, we prefer tenants that are using less.
+// order of tenantInfo.used/(tenantInfo.weight*tenantInfo.class.weight())
+// (weights and classes are optional features, and the weights default to 1).
+// That is, we prefer tenants that are using less. Ties are broken in favor of
+// the higher class.
 type tenantHeap []*tenantInfo
 
 var _ heap.Interface = (*tenantHeap)(nil)
@@ -1355,11 +1468,12 @@ var tenantInfoPool = sync.Pool{
 	},
 }
 
-func newTenantInfo(id uint64, weight uint32) *tenantInfo {
+func newTenantInfo(id uint64, weight uint32, class TenantPriorityClass) *tenantInfo {
 	ti := tenantInfoPool.Get().(*tenantInfo)
 	*ti = tenantInfo{
 		id:                    id,
 		weight:                weight,
+		class:                 class,
 		waitingWorkHeap:       ti.waitingWorkHeap,
 		openEpochsHeap:        ti.openEpochsHeap,
 		priorityStates:        makePriorityStates(ti.priorityStates.ps),
@@ -1403,8 +1517,14 @@ func (th *tenantHeap) Len() int {
 }
 
 func (th *tenantHeap) Less(i, j int) bool {
+	wi := uint64((*th)[i].weight) * (*th)[i].class.weight()
+	wj := uint64((*th)[j].weight) * (*th)[j].class.weight()
 	// used_i/weight_i < used_j/weight_j
-	return (*th)[i].used*uint64((*th)[j].weight) < (*th)[j].used*uint64((*th)[i].weight)
+	ui, uj := (*th)[i].used*wj, (*th)[j].used*wi
+	if ui != uj {
+		return ui < uj
+	}
+	return (*th)[i].class.rank() > (*th)[j].class.rank()
 }
 
 func (th *tenantHeap) Swap(i, j int) {
@@ -1738,6 +1858,39 @@ type WorkQueueMetrics struct {
 	total      workQueueMetricsSingle
 	byPriority sync.Map
 	registry   *metric.Registry
+	// byTenantClass is only populated for queues whose tenants are assigned
+	// priority classes, see withTenantClassMetrics.
+	byTenantClass [numTenantPriorityClasses]*tenantClassMetrics
+}
+
+// tenantClassMetrics are the metrics of the work of all the tenants in a
+// TenantPriorityClass.
+type tenantClassMetrics struct {
+	Admitted      *metric.Counter
+	WaitDurations metric.IHistogram
+}
+
+// MetricStruct implements the metric.Struct interface.
+func (*tenantClassMetrics) MetricStruct() {}
+
+// withTenantClassMetrics registers metrics broken down by the priority class
+// of the tenants.
+func (m *WorkQueueMetrics) withTenantClassMetrics() *WorkQueueMetrics {
+	for c := TenantPriorityClass(0); c < numTenantPriorityClasses; c++ {
+		name := fmt.Sprintf("%s.%s-class", m.name, c)
+		cm := &tenantClassMetrics{
+			Admitted: metric.NewCounter(addName(name, admittedMeta)),
+			WaitDurations: metric.NewHistogram(metric.HistogramOptions{
+				Mode:         metric.HistogramModePreferHdrLatency,
+				Metadata:     addName(name, waitDurationsMeta),
+				Duration:     base.DefaultHistogramWindowInterval(),
+				BucketConfig: metric.IOLatencyBuckets,
+			}),
+		}
+		m.registry.AddMetricStruct(cm)
+		m.byTenantClass[c] = cm
+	}
+	return m
 }
 
 // getOrCreate will return the metric if it exists or create it and then return
@@ -1820,6 +1973,16 @@ func (m *WorkQueueMetrics) recordFastPathAdmission(priority admissionpb.WorkPrio
 	priorityStats.WaitDurations.RecordValue(0)
 }
 
+func (m *WorkQueueMetrics) recordTenantClassAdmission(
+	class TenantPriorityClass, waitDur time.Duration,
+) {
+	if class >= numTenantPriorityClasses || m.byTenantClass[class] == nil {
+		return
+	}
+	m.byTenantClass[class].Admitted.Inc(1)
+	m.byTenantClass[class].WaitDurations.RecordValue(waitDur.Nanoseconds())
+}
+
 // MetricStruct implements the metric.Struct interface.
 func (*WorkQueueMetrics) MetricStruct() {}
 
@@ -2157,6 +2320,14 @@ func (q *StoreWorkQueue) SetTenantWeights(tenantWeights map[uint64]uint32) {
 	}
 }
 
+// SetTenantPriorityClasses passes through to
+// WorkQueue.SetTenantPriorityClasses.
+func (q *StoreWorkQueue) SetTenantPriorityClasses(tenantClasses map[uint64]TenantPriorityClass) {
+	for i := range q.q {
+		q.q[i].SetTenantPriorityClasses(tenantClasses)
+	}
+}
+
 // getRequesters implements storeRequester.
 func (q *StoreWorkQueue) getRequesters() [admissionpb.NumWorkClasses]requester {
 	var result [admissionpb.NumWorkClasses]requester
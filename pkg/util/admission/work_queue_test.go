@@ -11,6 +11,7 @@
 package admission
 
 import (
+	"container/heap"
 	"context"
 	"fmt"
 	"strconv"
@@ -318,6 +319,33 @@ func TestWorkQueueBasic(t *testing.T) {
 				q.SetTenantWeights(weightMap)
 				return q.String()
 
+			case "set-tenant-classes":
+				var classes string
+				d.ScanArgs(t, "classes", &classes)
+				fields := strings.FieldsFunc(classes, func(r rune) bool {
+					return r == ':' || r == ',' || unicode.IsSpace(r)
+				})
+				if len(fields)%2 != 0 {
+					return "tenant and class are not paired"
+				}
+				classMap := make(map[uint64]TenantPriorityClass)
+				for i := 0; i < len(fields); i += 2 {
+					tenantID, err := strconv.Atoi(fields[i])
+					require.NoError(t, err)
+					class := numTenantPriorityClasses
+					for c := TenantPriorityClass(0); c < numTenantPriorityClasses; c++ {
+						if c.String() == fields[i+1] {
+							class = c
+						}
+					}
+					if class == numTenantPriorityClasses {
+						return fmt.Sprintf("unknown class %s", fields[i+1])
+					}
+					classMap[uint64(tenantID)] = class
+				}
+				q.SetTenantPriorityClasses(classMap)
+				return q.String()
+
 			case "print":
 				return q.String()
 
@@ -690,3 +718,36 @@ func TestStoreWorkQueueBasic(t *testing.T) {
 // - Test race between grant and cancellation
 // - Add microbenchmark with high concurrency and procs for full admission
 //   system
+
+// TestTenantHeapClassesNoStarvation tests that tenants in a lower priority
+// class continue to be admitted while tenants in a higher class always have
+// waiting work, and get a share of the admissions proportional to the weight
+// of their class.
+func TestTenantHeapClassesNoStarvation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	var th tenantHeap
+	tenants := []*tenantInfo{
+		newTenantInfo(1, defaultTenantWeight, TenantPriorityClassPremium),
+		newTenantInfo(2, defaultTenantWeight, TenantPriorityClassStandard),
+		newTenantInfo(3, defaultTenantWeight, TenantPriorityClassBestEffort),
+	}
+	for _, tenant := range tenants {
+		heap.Push(&th, tenant)
+	}
+	// All tenants always have waiting work, so the top of the heap is admitted
+	// every time.
+	admitted := map[uint64]int{}
+	const numAdmissions = 2100
+	for i := 0; i < numAdmissions; i++ {
+		top := th[0]
+		admitted[top.id]++
+		top.used++
+		th.fix(top)
+	}
+	// The shares are 16:4:1.
+	require.Equal(t, 1600, admitted[1])
+	require.Equal(t, 400, admitted[2])
+	require.Equal(t, 100, admitted[3])
+}
@@ -0,0 +1,330 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parquet
+
+import (
+	"github.com/apache/arrow/go/v11/parquet"
+	"github.com/apache/arrow/go/v11/parquet/file"
+	"github.com/apache/arrow/go/v11/parquet/schema"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// readBatchSize is the number of values read from a column chunk at a time.
+const readBatchSize = 1024
+
+// Reader reads rows of datums out of a parquet file.
+//
+// Unlike ReadFile, the Reader does not depend on the CRDB-specific metadata
+// written by the Writer in test builds. Instead, the caller supplies the type
+// each column should be decoded as. Values written by the Writer for that type
+// are decoded exactly. Values with any other physical representation (for
+// example, files written by other tools) are decoded into a datum that
+// corresponds to their physical type (BOOL, INT, FLOAT, STRING or BYTES), and
+// it is up to the caller to convert them to the desired type.
+//
+// Only top-level scalar columns and arrays of scalars are supported; nested
+// groups, such as the ones the Writer produces for tuples, are not.
+type Reader struct {
+	r *file.Reader
+}
+
+// NewReader returns a Reader for the parquet file in r. The caller is
+// responsible for calling Close.
+func NewReader(r parquet.ReaderAtSeeker) (*Reader, error) {
+	fr, err := file.NewParquetReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{r: fr}, nil
+}
+
+// Close releases the resources held by the Reader.
+func (r *Reader) Close() error {
+	return r.r.Close()
+}
+
+// NumRows returns the total number of rows in the file.
+func (r *Reader) NumRows() int64 {
+	return r.r.NumRows()
+}
+
+// NumRowGroups returns the number of row groups in the file.
+func (r *Reader) NumRowGroups() int {
+	return r.r.NumRowGroups()
+}
+
+// ColumnNames returns the names of the columns in the file, in order. An error
+// is returned if the file contains columns that cannot be read by the Reader.
+func (r *Reader) ColumnNames() ([]string, error) {
+	sch := r.r.MetaData().Schema
+	names := make([]string, sch.NumColumns())
+	for i := range names {
+		col := sch.Column(i)
+		root := sch.ColumnRoot(i)
+		if i > 0 && sch.ColumnRoot(i-1) == root {
+			return nil, errors.Newf("nested column %q is not supported", root.Name())
+		}
+		if _, err := columnShape(col.MaxDefinitionLevel(), col.MaxRepetitionLevel()); err != nil {
+			return nil, errors.Wrapf(err, "column %q", root.Name())
+		}
+		names[i] = root.Name()
+	}
+	return names, nil
+}
+
+// ReadRowGroup decodes the given columns of row group rg. typs must have the
+// same length as cols and specifies the type each column is decoded as; a nil
+// type skips the column. The result is indexed by the position in cols, and
+// then by row.
+func (r *Reader) ReadRowGroup(rg int, cols []int, typs []*types.T) ([][]tree.Datum, error) {
+	if len(cols) != len(typs) {
+		return nil, errors.AssertionFailedf("expected %d types, found %d", len(cols), len(typs))
+	}
+	rgr := r.r.RowGroup(rg)
+	numRows := rgr.NumRows()
+	res := make([][]tree.Datum, len(cols))
+	for i, colIdx := range cols {
+		if typs[i] == nil {
+			continue
+		}
+		col, err := rgr.Column(colIdx)
+		if err != nil {
+			return nil, err
+		}
+		desc := col.Descriptor()
+		dec, decTyp, isArray, err := columnDecoder(desc, typs[i])
+		if err != nil {
+			return nil, err
+		}
+		vals := make([]tree.Datum, 0, numRows)
+		switch col.Type() {
+		case parquet.Types.Boolean:
+			vals, err = readColumnChunk(col, make([]bool, readBatchSize), dec, decTyp, desc.MaxDefinitionLevel(), isArray, vals)
+		case parquet.Types.Int32:
+			vals, err = readColumnChunk(col, make([]int32, readBatchSize), dec, decTyp, desc.MaxDefinitionLevel(), isArray, vals)
+		case parquet.Types.Int64:
+			vals, err = readColumnChunk(col, make([]int64, readBatchSize), dec, decTyp, desc.MaxDefinitionLevel(), isArray, vals)
+		case parquet.Types.Float:
+			vals, err = readColumnChunk(col, make([]float32, readBatchSize), dec, decTyp, desc.MaxDefinitionLevel(), isArray, vals)
+		case parquet.Types.Double:
+			vals, err = readColumnChunk(col, make([]float64, readBatchSize), dec, decTyp, desc.MaxDefinitionLevel(), isArray, vals)
+		case parquet.Types.ByteArray:
+			vals, err = readColumnChunk(col, make([]parquet.ByteArray, readBatchSize), dec, decTyp, desc.MaxDefinitionLevel(), isArray, vals)
+		case parquet.Types.FixedLenByteArray:
+			vals, err = readColumnChunk(col, make([]parquet.FixedLenByteArray, readBatchSize), dec, decTyp, desc.MaxDefinitionLevel(), isArray, vals)
+		default:
+			err = errors.Newf("unsupported physical type %s", col.Type())
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %q", desc.Name())
+		}
+		if int64(len(vals)) != numRows {
+			return nil, errors.AssertionFailedf(
+				"expected to read %d rows in row group, found %d", numRows, len(vals))
+		}
+		res[i] = vals
+	}
+	return res, nil
+}
+
+// DecodedType returns the type of the datums that ReadRowGroup produces when
+// column col is decoded as typ.
+func (r *Reader) DecodedType(col int, typ *types.T) (*types.T, error) {
+	_, decTyp, isArray, err := columnDecoder(r.r.MetaData().Schema.Column(col), typ)
+	if err != nil {
+		return nil, err
+	}
+	if isArray {
+		return types.MakeArray(decTyp), nil
+	}
+	return decTyp, nil
+}
+
+// columnDecoder returns the decoder used to read the column described by desc
+// as typ, the type of the datums it produces and whether the column is an
+// array. For arrays, the decoder and the returned type are those of the array
+// elements.
+func columnDecoder(
+	desc *schema.Column, typ *types.T,
+) (_ decoder, decTyp *types.T, isArray bool, _ error) {
+	isArray, err := columnShape(desc.MaxDefinitionLevel(), desc.MaxRepetitionLevel())
+	if err != nil {
+		return nil, nil, false, errors.Wrapf(err, "column %q", desc.Name())
+	}
+	if isArray && typ.Family() != types.ArrayFamily {
+		return nil, nil, false, errors.Newf("cannot decode repeated column %q as %s", desc.Name(), typ)
+	}
+	scalarTyp := typ
+	if isArray {
+		scalarTyp = typ.ArrayContents()
+	}
+	dec, decTyp, err := decoderForColumn(desc.PhysicalType(), scalarTyp)
+	if err != nil {
+		return nil, nil, false, errors.Wrapf(err, "column %q", desc.Name())
+	}
+	return dec, decTyp, isArray, nil
+}
+
+// columnShape returns whether a column with the given max definition and
+// repetition levels is an array. Scalars may be required (definition level 0)
+// or optional (definition level 1). Arrays must be laid out as an optional
+// list of optional elements, which is what the Writer and most other tools
+// produce.
+func columnShape(maxDef, maxRep int16) (isArray bool, _ error) {
+	switch {
+	case maxRep == 0 && maxDef <= 1:
+		return false, nil
+	case maxRep == 1 && maxDef == 3:
+		return true, nil
+	default:
+		return false, errors.Newf(
+			"unsupported column layout (definition level %d, repetition level %d)", maxDef, maxRep)
+	}
+}
+
+// decoderForColumn returns the decoder used to read values of the given
+// physical type as typ, as well as the type of the datums it produces. If the
+// Writer would not have written typ using the given physical type, a decoder
+// for the physical type is returned instead.
+func decoderForColumn(physical parquet.Type, typ *types.T) (decoder, *types.T, error) {
+	var dec decoder
+	switch typ.Family() {
+	case types.EnumFamily, types.CollatedStringFamily:
+		// The decoders for these types don't produce fully typed datums, so we
+		// return strings which the caller can parse using the actual type.
+		dec = stringDecoder{}
+	case types.ArrayFamily, types.TupleFamily:
+		return nil, nil, errors.Newf("cannot decode nested type %s", typ)
+	default:
+		var err error
+		if dec, err = decoderFromFamilyAndType(typ.Oid(), typ.Family()); err != nil {
+			dec = nil
+		}
+	}
+	if dec != nil && decoderAcceptsPhysicalType(dec, physical) {
+		if _, ok := dec.(stringDecoder); ok {
+			return dec, types.String, nil
+		}
+		return dec, typ, nil
+	}
+	switch physical {
+	case parquet.Types.Boolean:
+		return boolDecoder{}, types.Bool, nil
+	case parquet.Types.Int32:
+		return int32Decoder{}, types.Int, nil
+	case parquet.Types.Int64:
+		return int64Decoder{}, types.Int, nil
+	case parquet.Types.Float:
+		return float32Decoder{}, types.Float, nil
+	case parquet.Types.Double:
+		return float64Decoder{}, types.Float, nil
+	case parquet.Types.ByteArray:
+		return stringDecoder{}, types.String, nil
+	case parquet.Types.FixedLenByteArray:
+		return fixedLenBytesDecoder{}, types.Bytes, nil
+	default:
+		return nil, nil, errors.Newf("unsupported physical type %s", physical)
+	}
+}
+
+func decoderAcceptsPhysicalType(dec decoder, physical parquet.Type) bool {
+	var ok bool
+	switch physical {
+	case parquet.Types.Boolean:
+		_, ok = dec.(typedDecoder[bool])
+	case parquet.Types.Int32:
+		_, ok = dec.(typedDecoder[int32])
+	case parquet.Types.Int64:
+		_, ok = dec.(typedDecoder[int64])
+	case parquet.Types.Float:
+		_, ok = dec.(typedDecoder[float32])
+	case parquet.Types.Double:
+		_, ok = dec.(typedDecoder[float64])
+	case parquet.Types.ByteArray:
+		_, ok = dec.(typedDecoder[parquet.ByteArray])
+	case parquet.Types.FixedLenByteArray:
+		_, ok = dec.(typedDecoder[parquet.FixedLenByteArray])
+	}
+	return ok
+}
+
+type fixedLenBytesDecoder struct{}
+
+func (fixedLenBytesDecoder) decode(v parquet.FixedLenByteArray) (tree.Datum, error) {
+	return tree.NewDBytes(tree.DBytes(v)), nil
+}
+
+// readColumnChunk appends the values in the column chunk r to res. Elements of
+// arrays are typed as elemTyp.
+func readColumnChunk[T parquetDatatypes](
+	r file.ColumnChunkReader,
+	values []T,
+	dec decoder,
+	elemTyp *types.T,
+	maxDef int16,
+	isArray bool,
+	res []tree.Datum,
+) ([]tree.Datum, error) {
+	br, ok := r.(batchReader[T])
+	if !ok {
+		return nil, errors.AssertionFailedf("expected batchReader for type %T, but found %T instead", values, r)
+	}
+	defLevels := make([]int16, len(values))
+	repLevels := make([]int16, len(values))
+	for {
+		total, _, err := br.ReadBatch(int64(len(values)), values, defLevels, repLevels)
+		if err != nil {
+			return nil, err
+		}
+		if total == 0 {
+			return res, nil
+		}
+		// Only non-null values are stored in values, so we keep a separate
+		// index into it.
+		valIdx := 0
+		for i := 0; i < int(total); i++ {
+			d := tree.DNull
+			if defLevels[i] == maxDef {
+				d, err = decode(dec, values[valIdx])
+				if err != nil {
+					return nil, err
+				}
+				valIdx++
+			}
+			if !isArray {
+				res = append(res, d)
+				continue
+			}
+			// Repetition level 0 indicates the start of a new array. Definition
+			// level 0 represents a NULL array and definition level 1 an empty
+			// array.
+			if repLevels[i] == 0 {
+				if defLevels[i] == 0 {
+					res = append(res, tree.DNull)
+					continue
+				}
+				res = append(res, tree.NewDArray(elemTyp))
+				if defLevels[i] == 1 {
+					continue
+				}
+			}
+			arr, ok := res[len(res)-1].(*tree.DArray)
+			if !ok {
+				return nil, errors.AssertionFailedf("array element without array")
+			}
+			if err := arr.Append(d); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
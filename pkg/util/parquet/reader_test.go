@@ -0,0 +1,95 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parquet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	names := []string{"i", "s", "d", "a"}
+	typs := []*types.T{types.Int, types.String, types.Decimal, types.IntArray}
+
+	arr := tree.NewDArray(types.Int)
+	require.NoError(t, arr.Append(tree.NewDInt(1)))
+	require.NoError(t, arr.Append(tree.DNull))
+	rows := [][]tree.Datum{
+		{tree.NewDInt(1), tree.NewDString("a"), tree.DNull, arr},
+		{tree.NewDInt(2), tree.DNull, &tree.DDecimal{}, tree.NewDArray(types.Int)},
+		{tree.DNull, tree.NewDString("c"), tree.DNull, tree.DNull},
+	}
+
+	sch, err := NewSchema(names, typs)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	w, err := NewWriter(sch, &buf, WithMaxRowGroupLength(2))
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NoError(t, w.AddRow(row))
+	}
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, r.Close()) }()
+
+	readNames, err := r.ColumnNames()
+	require.NoError(t, err)
+	require.Equal(t, names, readNames)
+	require.Equal(t, int64(len(rows)), r.NumRows())
+	require.Equal(t, 2, r.NumRowGroups())
+
+	t.Run("roundtrip", func(t *testing.T) {
+		var rowIdx int
+		for rg := 0; rg < r.NumRowGroups(); rg++ {
+			cols, err := r.ReadRowGroup(rg, []int{0, 1, 2, 3}, typs)
+			require.NoError(t, err)
+			for i := range cols[0] {
+				for colIdx := range cols {
+					ValidateDatum(t, rows[rowIdx][colIdx], cols[colIdx][i])
+				}
+				rowIdx++
+			}
+		}
+		require.Equal(t, len(rows), rowIdx)
+	})
+
+	t.Run("physical fallback", func(t *testing.T) {
+		// Decoding as a type which the writer would not have produced from the
+		// physical type falls back to decoding the physical type.
+		cols, err := r.ReadRowGroup(0, []int{0, 1}, []*types.T{types.Int4, nil})
+		require.NoError(t, err)
+		require.Equal(t, tree.NewDInt(1), cols[0][0])
+		require.Nil(t, cols[1])
+
+		cols, err = r.ReadRowGroup(0, []int{2}, []*types.T{types.Bool})
+		require.NoError(t, err)
+		require.Equal(t, tree.DNull, cols[0][0])
+		require.Equal(t, tree.NewDString("0"), cols[0][1])
+
+		decTyp, err := r.DecodedType(2, types.Bool)
+		require.NoError(t, err)
+		require.Equal(t, types.String, decTyp)
+		decTyp, err = r.DecodedType(3, types.MakeArray(types.Int4))
+		require.NoError(t, err)
+		require.Equal(t, types.IntArray, decTyp)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := r.ReadRowGroup(0, []int{3}, []*types.T{types.Int})
+		require.Regexp(t, `cannot decode repeated column`, err)
+	})
+}